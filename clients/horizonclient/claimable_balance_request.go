@@ -28,6 +28,9 @@ func (cbr ClaimableBalanceRequest) BuildURL() (endpoint string, err error) {
 				"sponsor":  cbr.Sponsor,
 				"asset":    cbr.Asset,
 			},
+			cursor(cbr.Cursor),
+			limit(cbr.Limit),
+			cbr.Order,
 		)
 
 		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
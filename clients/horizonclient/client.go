@@ -1,12 +1,9 @@
 package horizonclient
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -15,16 +12,15 @@ import (
 
 	"github.com/stellar/go/txnbuild"
 
-	"github.com/manucorporat/sse"
-
 	hProtocol "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/protocols/horizon/effects"
 	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
 )
 
 // sendRequest builds the URL for the given horizon request and sends the url to a horizon server
-func (c *Client) sendRequest(hr HorizonRequest, resp interface{}) (err error) {
+func (c *Client) sendRequest(ctx context.Context, hr HorizonRequest, resp interface{}) (err error) {
 	endpoint, err := hr.BuildURL()
 	if err != nil {
 		return
@@ -33,15 +29,15 @@ func (c *Client) sendRequest(hr HorizonRequest, resp interface{}) (err error) {
 	c.HorizonURL = c.fixHorizonURL()
 	_, ok := hr.(submitRequest)
 	if ok {
-		return c.sendRequestURL(c.HorizonURL+endpoint, "post", resp)
+		return c.sendRequestURL(ctx, c.HorizonURL+endpoint, "post", resp)
 	}
 
-	return c.sendRequestURL(c.HorizonURL+endpoint, "get", resp)
+	return c.sendRequestURL(ctx, c.HorizonURL+endpoint, "get", resp)
 }
 
 // checkMemoRequired implements a memo required check as defined in
 // https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0029.md
-func (c *Client) checkMemoRequired(transaction *txnbuild.Transaction) error {
+func (c *Client) checkMemoRequired(ctx context.Context, transaction *txnbuild.Transaction) error {
 	destinations := map[string]bool{}
 
 	for i, op := range transaction.Operations() {
@@ -64,8 +60,11 @@ func (c *Client) checkMemoRequired(transaction *txnbuild.Transaction) error {
 			continue
 		}
 
-		// TODO: once we support M-strkeys (SEP23), also check whether the destination
-		//       is a muxed account with a memo ID.
+		// muxed accounts (SEP-23) identify the user of the destination
+		// account, so they don't require a memo
+		if version, err := strkey.Version(destination); err == nil && version == strkey.VersionByteMuxedAccount {
+			continue
+		}
 
 		if destinations[destination] {
 			continue
@@ -77,7 +76,7 @@ func (c *Client) checkMemoRequired(transaction *txnbuild.Transaction) error {
 			DataKey:   "config.memo_required",
 		}
 
-		data, err := c.AccountData(request)
+		data, err := c.AccountDataContext(ctx, request)
 		if err != nil {
 			horizonError := GetError(err)
 
@@ -101,160 +100,58 @@ func (c *Client) checkMemoRequired(transaction *txnbuild.Transaction) error {
 
 // sendRequestURL sends a url to a horizon server.
 // It can be used for requests that do not implement the HorizonRequest interface.
-func (c *Client) sendRequestURL(requestURL string, method string, a interface{}) (err error) {
-	var req *http.Request
-
-	if method == "post" || method == "POST" {
-		req, err = http.NewRequest("POST", requestURL, nil)
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
-	} else {
-		req, err = http.NewRequest("GET", requestURL, nil)
-	}
-
-	if err != nil {
-		return errors.Wrap(err, "error creating HTTP request")
-	}
-	c.setClientAppHeaders(req)
+// Requests which fail because of rate limiting or temporary unavailability of
+// Horizon are retried according to c.RetryPolicy.
+func (c *Client) sendRequestURL(ctx context.Context, requestURL string, method string, a interface{}) error {
 	c.setDefaultClient()
 	if c.horizonTimeout == 0 {
 		c.horizonTimeout = HorizonTimeout
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), c.horizonTimeout)
-	resp, err := c.HTTP.Do(req.WithContext(ctx))
-	if err != nil {
-		cancel()
-		return
-	}
-
-	err = decodeResponse(resp, &a, c)
-	cancel()
-	return
-}
-
-// stream handles connections to endpoints that support streaming on a horizon server
-func (c *Client) stream(
-	ctx context.Context,
-	streamURL string,
-	handler func(data []byte) error,
-) error {
-	su, err := url.Parse(streamURL)
-	if err != nil {
-		return errors.Wrap(err, "error parsing stream url")
-	}
 
-	query := su.Query()
-	if query.Get("cursor") == "" {
-		query.Set("cursor", "now")
-	}
+	for attempt := 0; ; attempt++ {
+		var req *http.Request
+		var err error
+		if method == "post" || method == "POST" {
+			req, err = http.NewRequest("POST", requestURL, nil)
+			if err == nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
+			}
+		} else {
+			req, err = http.NewRequest("GET", requestURL, nil)
+		}
 
-	for {
-		// updates the url with new cursor
-		su.RawQuery = query.Encode()
-		req, err := http.NewRequest("GET", su.String(), nil)
 		if err != nil {
 			return errors.Wrap(err, "error creating HTTP request")
 		}
-		req.Header.Set("Accept", "text/event-stream")
-		c.setDefaultClient()
 		c.setClientAppHeaders(req)
-
-		// We can use c.HTTP here because we set Timeout per request not on the client. See sendRequest()
-		resp, err := c.HTTP.Do(req)
+		requestCtx, cancel := context.WithTimeout(ctx, c.horizonTimeout)
+		resp, err := c.HTTP.Do(req.WithContext(requestCtx))
 		if err != nil {
-			return errors.Wrap(err, "error sending HTTP request")
-		}
-
-		// Expected statusCode are 200-299
-		if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-			return fmt.Errorf("got bad HTTP status code %d", resp.StatusCode)
-		}
-		defer resp.Body.Close()
-
-		reader := bufio.NewReader(resp.Body)
-
-		// Read events one by one. Break this loop when there is no more data to be
-		// read from resp.Body (io.EOF).
-	Events:
-		for {
-			// Read until empty line = event delimiter. The perfect solution would be to read
-			// as many bytes as possible and forward them to sse.Decode. However this
-			// requires much more complicated code.
-			// We could also write our own `sse` package that works fine with streams directly
-			// (github.com/manucorporat/sse is just using io/ioutils.ReadAll).
-			var buffer bytes.Buffer
-			nonEmptylinesRead := 0
-			for {
-				// Check if ctx is not cancelled
-				select {
-				case <-ctx.Done():
-					return nil
-				default:
-					// Continue
-				}
-
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					if err == io.EOF || err == io.ErrUnexpectedEOF {
-						// We catch EOF errors to handle two possible situations:
-						// - The last line before closing the stream was not empty. This should never
-						//   happen in Horizon as it always sends an empty line after each event.
-						// - The stream was closed by the server/proxy because the connection was idle.
-						//
-						// In the former case, that (again) should never happen in Horizon, we need to
-						// check if there are any events we need to decode. We do this in the `if`
-						// statement below just in case if Horizon behaviour changes in a future.
-						//
-						// From spec:
-						// > Once the end of the file is reached, the user agent must dispatch the
-						// > event one final time, as defined below.
-						if nonEmptylinesRead == 0 {
-							break Events
-						}
-					} else {
-						return errors.Wrap(err, "error reading line")
-					}
-				}
-				buffer.WriteString(line)
-
-				if strings.TrimRight(line, "\n\r") == "" {
-					break
-				}
-
-				nonEmptylinesRead++
+			cancel()
+			// a failed POST request could have been processed by Horizon so
+			// only GET requests are retried
+			delay, retry := c.RetryPolicy.retryDelay(attempt, nil, c.clock.Now())
+			if !retry || req.Method != "GET" || ctx.Err() != nil {
+				return err
 			}
-
-			events, err := sse.Decode(strings.NewReader(buffer.String()))
-			if err != nil {
-				return errors.Wrap(err, "error decoding event")
+			if err = sleep(ctx, delay); err != nil {
+				return err
 			}
+			continue
+		}
 
-			// Right now len(events) should always be 1. This loop will be helpful after writing
-			// new SSE decoder that can handle io.Reader without using ioutils.ReadAll().
-			for _, event := range events {
-				if event.Event != "message" {
-					continue
-				}
-
-				// Update cursor with event ID
-				if event.Id != "" {
-					query.Set("cursor", event.Id)
-				}
-
-				switch data := event.Data.(type) {
-				case string:
-					err = handler([]byte(data))
-					err = errors.Wrap(err, "handler error")
-				case []byte:
-					err = handler(data)
-					err = errors.Wrap(err, "handler error")
-				default:
-					err = errors.New("invalid event.Data type")
-				}
-				if err != nil {
-					return err
-				}
+		if delay, retry := c.RetryPolicy.retryDelay(attempt, resp, c.clock.Now()); retry {
+			resp.Body.Close()
+			cancel()
+			if err = sleep(ctx, delay); err != nil {
+				return err
 			}
+			continue
 		}
+
+		err = decodeResponse(resp, &a, c)
+		cancel()
+		return err
 	}
 }
 
@@ -293,13 +190,25 @@ func (c *Client) HorizonTimeout() time.Duration {
 // have a trustline to an asset.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/accounts.html
 func (c *Client) Accounts(request AccountsRequest) (accounts hProtocol.AccountsPage, err error) {
-	err = c.sendRequest(request, &accounts)
+	return c.AccountsContext(context.Background(), request)
+}
+
+// AccountsContext is the same as Accounts but it takes a context which can be used
+// to cancel the request.
+func (c *Client) AccountsContext(ctx context.Context, request AccountsRequest) (accounts hProtocol.AccountsPage, err error) {
+	err = c.sendRequest(ctx, request, &accounts)
 	return
 }
 
 // AccountDetail returns information for a single account.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/accounts-single.html
 func (c *Client) AccountDetail(request AccountRequest) (account hProtocol.Account, err error) {
+	return c.AccountDetailContext(context.Background(), request)
+}
+
+// AccountDetailContext is the same as AccountDetail but it takes a context which can be used
+// to cancel the request.
+func (c *Client) AccountDetailContext(ctx context.Context, request AccountRequest) (account hProtocol.Account, err error) {
 	if request.AccountID == "" {
 		err = errors.New("no account ID provided")
 	}
@@ -308,13 +217,19 @@ func (c *Client) AccountDetail(request AccountRequest) (account hProtocol.Accoun
 		return
 	}
 
-	err = c.sendRequest(request, &account)
+	err = c.sendRequest(ctx, request, &account)
 	return
 }
 
 // AccountData returns a single data associated with a given account
 // See https://www.stellar.org/developers/horizon/reference/endpoints/data-for-account.html
 func (c *Client) AccountData(request AccountRequest) (accountData hProtocol.AccountData, err error) {
+	return c.AccountDataContext(context.Background(), request)
+}
+
+// AccountDataContext is the same as AccountData but it takes a context which can be used
+// to cancel the request.
+func (c *Client) AccountDataContext(ctx context.Context, request AccountRequest) (accountData hProtocol.AccountData, err error) {
 	if request.AccountID == "" || request.DataKey == "" {
 		err = errors.New("too few parameters")
 	}
@@ -323,34 +238,58 @@ func (c *Client) AccountData(request AccountRequest) (accountData hProtocol.Acco
 		return
 	}
 
-	err = c.sendRequest(request, &accountData)
+	err = c.sendRequest(ctx, request, &accountData)
 	return
 }
 
 // Effects returns effects(https://www.stellar.org/developers/horizon/reference/resources/effect.html)
 // It can be used to return effects for an account, a ledger, an operation, a transaction and all effects on the network.
 func (c *Client) Effects(request EffectRequest) (effects effects.EffectsPage, err error) {
-	err = c.sendRequest(request, &effects)
+	return c.EffectsContext(context.Background(), request)
+}
+
+// EffectsContext is the same as Effects but it takes a context which can be used
+// to cancel the request.
+func (c *Client) EffectsContext(ctx context.Context, request EffectRequest) (effects effects.EffectsPage, err error) {
+	err = c.sendRequest(ctx, request, &effects)
 	return
 }
 
 // Assets returns asset information.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/assets-all.html
 func (c *Client) Assets(request AssetRequest) (assets hProtocol.AssetsPage, err error) {
-	err = c.sendRequest(request, &assets)
+	return c.AssetsContext(context.Background(), request)
+}
+
+// AssetsContext is the same as Assets but it takes a context which can be used
+// to cancel the request.
+func (c *Client) AssetsContext(ctx context.Context, request AssetRequest) (assets hProtocol.AssetsPage, err error) {
+	err = c.sendRequest(ctx, request, &assets)
 	return
 }
 
 // Ledgers returns information about all ledgers.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/ledgers-all.html
 func (c *Client) Ledgers(request LedgerRequest) (ledgers hProtocol.LedgersPage, err error) {
-	err = c.sendRequest(request, &ledgers)
+	return c.LedgersContext(context.Background(), request)
+}
+
+// LedgersContext is the same as Ledgers but it takes a context which can be used
+// to cancel the request.
+func (c *Client) LedgersContext(ctx context.Context, request LedgerRequest) (ledgers hProtocol.LedgersPage, err error) {
+	err = c.sendRequest(ctx, request, &ledgers)
 	return
 }
 
 // LedgerDetail returns information about a particular ledger for a given sequence number
 // See https://www.stellar.org/developers/horizon/reference/endpoints/ledgers-single.html
 func (c *Client) LedgerDetail(sequence uint32) (ledger hProtocol.Ledger, err error) {
+	return c.LedgerDetailContext(context.Background(), sequence)
+}
+
+// LedgerDetailContext is the same as LedgerDetail but it takes a context which can be used
+// to cancel the request.
+func (c *Client) LedgerDetailContext(ctx context.Context, sequence uint32) (ledger hProtocol.Ledger, err error) {
 	if sequence == 0 {
 		err = errors.New("invalid sequence number provided")
 	}
@@ -360,28 +299,46 @@ func (c *Client) LedgerDetail(sequence uint32) (ledger hProtocol.Ledger, err err
 	}
 
 	request := LedgerRequest{forSequence: sequence}
-	err = c.sendRequest(request, &ledger)
+	err = c.sendRequest(ctx, request, &ledger)
 	return
 }
 
 // FeeStats returns information about fees in the last 5 ledgers.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/fee-stats.html
 func (c *Client) FeeStats() (feestats hProtocol.FeeStats, err error) {
+	return c.FeeStatsContext(context.Background())
+}
+
+// FeeStatsContext is the same as FeeStats but it takes a context which can be used
+// to cancel the request.
+func (c *Client) FeeStatsContext(ctx context.Context) (feestats hProtocol.FeeStats, err error) {
 	request := feeStatsRequest{endpoint: "fee_stats"}
-	err = c.sendRequest(request, &feestats)
+	err = c.sendRequest(ctx, request, &feestats)
 	return
 }
 
 // Offers returns information about offers made on the SDEX.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/offers-for-account.html
 func (c *Client) Offers(request OfferRequest) (offers hProtocol.OffersPage, err error) {
-	err = c.sendRequest(request, &offers)
+	return c.OffersContext(context.Background(), request)
+}
+
+// OffersContext is the same as Offers but it takes a context which can be used
+// to cancel the request.
+func (c *Client) OffersContext(ctx context.Context, request OfferRequest) (offers hProtocol.OffersPage, err error) {
+	err = c.sendRequest(ctx, request, &offers)
 	return
 }
 
 // OfferDetails returns information for a single offer.
 // See https://www.stellar.org/developers/horizon/reference/endpoints/offer-details.html
 func (c *Client) OfferDetails(offerID string) (offer hProtocol.Offer, err error) {
+	return c.OfferDetailsContext(context.Background(), offerID)
+}
+
+// OfferDetailsContext is the same as OfferDetails but it takes a context which can be used
+// to cancel the request.
+func (c *Client) OfferDetailsContext(ctx context.Context, offerID string) (offer hProtocol.Offer, err error) {
 	if len(offerID) == 0 {
 		err = errors.New("no offer ID provided")
 		return
@@ -392,20 +349,32 @@ func (c *Client) OfferDetails(offerID string) (offer hProtocol.Offer, err error)
 		return
 	}
 
-	err = c.sendRequest(OfferRequest{OfferID: offerID}, &offer)
+	err = c.sendRequest(ctx, OfferRequest{OfferID: offerID}, &offer)
 	return
 }
 
 // Operations returns stellar operations (https://www.stellar.org/developers/horizon/reference/resources/operation.html)
 // It can be used to return operations for an account, a ledger, a transaction and all operations on the network.
 func (c *Client) Operations(request OperationRequest) (ops operations.OperationsPage, err error) {
-	err = c.sendRequest(request.SetOperationsEndpoint(), &ops)
+	return c.OperationsContext(context.Background(), request)
+}
+
+// OperationsContext is the same as Operations but it takes a context which can be used
+// to cancel the request.
+func (c *Client) OperationsContext(ctx context.Context, request OperationRequest) (ops operations.OperationsPage, err error) {
+	err = c.sendRequest(ctx, request.SetOperationsEndpoint(), &ops)
 	return
 }
 
 // OperationDetail returns a single stellar operation for a given operation id
 // See https://www.stellar.org/developers/horizon/reference/resources/operation.html
 func (c *Client) OperationDetail(id string) (ops operations.Operation, err error) {
+	return c.OperationDetailContext(context.Background(), id)
+}
+
+// OperationDetailContext is the same as OperationDetail but it takes a context which can be used
+// to cancel the request.
+func (c *Client) OperationDetailContext(ctx context.Context, id string) (ops operations.Operation, err error) {
 	if id == "" {
 		return ops, errors.New("invalid operation id provided")
 	}
@@ -414,7 +383,7 @@ func (c *Client) OperationDetail(id string) (ops operations.Operation, err error
 
 	var record interface{}
 
-	err = c.sendRequest(request, &record)
+	err = c.sendRequest(ctx, request, &record)
 	if err != nil {
 		return ops, errors.Wrap(err, "sending request to horizon")
 	}
@@ -436,11 +405,27 @@ func (c *Client) OperationDetail(id string) (ops operations.Operation, err error
 }
 
 // SubmitTransactionXDR submits a transaction represented as a base64 XDR string to the network. err can be either error object or horizon.Error object.
+//
+// If Horizon responds with a 504 Timeout error and RetryPolicy.SubmissionPollTimeout is set, the
+// transaction is not resubmitted. Instead, the client polls TransactionDetail until the transaction
+// is found in a ledger or the poll timeout elapses (in which case the original error is returned).
+// ErrTransactionFailed is returned together with the transaction if it was found but failed.
+//
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-create.html
-func (c *Client) SubmitTransactionXDR(transactionXdr string) (tx hProtocol.Transaction,
-	err error) {
+func (c *Client) SubmitTransactionXDR(transactionXdr string) (tx hProtocol.Transaction, err error) {
+	return c.SubmitTransactionXDRContext(context.Background(), transactionXdr)
+}
+
+// SubmitTransactionXDRContext is the same as SubmitTransactionXDR but it takes a context which can be used
+// to cancel the request.
+func (c *Client) SubmitTransactionXDRContext(ctx context.Context, transactionXdr string) (tx hProtocol.Transaction, err error) {
 	request := submitRequest{endpoint: "transactions", transactionXdr: transactionXdr}
-	err = c.sendRequest(request, &tx)
+	err = c.sendRequest(ctx, request, &tx)
+	if hErr := GetError(err); hErr != nil &&
+		hErr.Response.StatusCode == http.StatusGatewayTimeout &&
+		c.RetryPolicy.SubmissionPollTimeout > 0 {
+		return c.pollSubmittedTransaction(ctx, transactionXdr, err)
+	}
 	return
 }
 
@@ -454,7 +439,13 @@ func (c *Client) SubmitTransactionXDR(transactionXdr string) (tx hProtocol.Trans
 //
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-create.html
 func (c *Client) SubmitFeeBumpTransaction(transaction *txnbuild.FeeBumpTransaction) (tx hProtocol.Transaction, err error) {
-	return c.SubmitFeeBumpTransactionWithOptions(transaction, SubmitTxOpts{})
+	return c.SubmitFeeBumpTransactionContext(context.Background(), transaction)
+}
+
+// SubmitFeeBumpTransactionContext is the same as SubmitFeeBumpTransaction but it takes a context which can be used
+// to cancel the request.
+func (c *Client) SubmitFeeBumpTransactionContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction) (tx hProtocol.Transaction, err error) {
+	return c.SubmitFeeBumpTransactionWithOptionsContext(ctx, transaction, SubmitTxOpts{})
 }
 
 // SubmitFeeBumpTransactionWithOptions submits a fee bump transaction to the network, allowing
@@ -462,10 +453,16 @@ func (c *Client) SubmitFeeBumpTransaction(transaction *txnbuild.FeeBumpTransacti
 //
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-create.html
 func (c *Client) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (tx hProtocol.Transaction, err error) {
+	return c.SubmitFeeBumpTransactionWithOptionsContext(context.Background(), transaction, opts)
+}
+
+// SubmitFeeBumpTransactionWithOptionsContext is the same as SubmitFeeBumpTransactionWithOptions but it takes a context which can be used
+// to cancel the request.
+func (c *Client) SubmitFeeBumpTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (tx hProtocol.Transaction, err error) {
 	// only check if memo is required if skip is false and the inner transaction
 	// doesn't have a memo.
 	if inner := transaction.InnerTransaction(); !opts.SkipMemoRequiredCheck && inner.Memo() == nil {
-		err = c.checkMemoRequired(inner)
+		err = c.checkMemoRequired(ctx, inner)
 		if err != nil {
 			return
 		}
@@ -477,7 +474,7 @@ func (c *Client) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBu
 		return
 	}
 
-	return c.SubmitTransactionXDR(txeBase64)
+	return c.SubmitTransactionXDRContext(ctx, txeBase64)
 }
 
 // SubmitTransaction submits a transaction to the network. err can be either an
@@ -490,7 +487,13 @@ func (c *Client) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBu
 //
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-create.html
 func (c *Client) SubmitTransaction(transaction *txnbuild.Transaction) (tx hProtocol.Transaction, err error) {
-	return c.SubmitTransactionWithOptions(transaction, SubmitTxOpts{})
+	return c.SubmitTransactionContext(context.Background(), transaction)
+}
+
+// SubmitTransactionContext is the same as SubmitTransaction but it takes a context which can be used
+// to cancel the request.
+func (c *Client) SubmitTransactionContext(ctx context.Context, transaction *txnbuild.Transaction) (tx hProtocol.Transaction, err error) {
+	return c.SubmitTransactionWithOptionsContext(ctx, transaction, SubmitTxOpts{})
 }
 
 // SubmitTransactionWithOptions submits a transaction to the network, allowing
@@ -498,10 +501,16 @@ func (c *Client) SubmitTransaction(transaction *txnbuild.Transaction) (tx hProto
 //
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-create.html
 func (c *Client) SubmitTransactionWithOptions(transaction *txnbuild.Transaction, opts SubmitTxOpts) (tx hProtocol.Transaction, err error) {
+	return c.SubmitTransactionWithOptionsContext(context.Background(), transaction, opts)
+}
+
+// SubmitTransactionWithOptionsContext is the same as SubmitTransactionWithOptions but it takes a context which can be used
+// to cancel the request.
+func (c *Client) SubmitTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.Transaction, opts SubmitTxOpts) (tx hProtocol.Transaction, err error) {
 	// only check if memo is required if skip is false and the transaction
 	// doesn't have a memo.
 	if !opts.SkipMemoRequiredCheck && transaction.Memo() == nil {
-		err = c.checkMemoRequired(transaction)
+		err = c.checkMemoRequired(ctx, transaction)
 		if err != nil {
 			return
 		}
@@ -513,75 +522,129 @@ func (c *Client) SubmitTransactionWithOptions(transaction *txnbuild.Transaction,
 		return
 	}
 
-	return c.SubmitTransactionXDR(txeBase64)
+	return c.SubmitTransactionXDRContext(ctx, txeBase64)
 }
 
 // Transactions returns stellar transactions (https://www.stellar.org/developers/horizon/reference/resources/transaction.html)
 // It can be used to return transactions for an account, a ledger,and all transactions on the network.
 func (c *Client) Transactions(request TransactionRequest) (txs hProtocol.TransactionsPage, err error) {
-	err = c.sendRequest(request, &txs)
+	return c.TransactionsContext(context.Background(), request)
+}
+
+// TransactionsContext is the same as Transactions but it takes a context which can be used
+// to cancel the request.
+func (c *Client) TransactionsContext(ctx context.Context, request TransactionRequest) (txs hProtocol.TransactionsPage, err error) {
+	err = c.sendRequest(ctx, request, &txs)
 	return
 }
 
 // TransactionDetail returns information about a particular transaction for a given transaction hash
 // See https://www.stellar.org/developers/horizon/reference/endpoints/transactions-single.html
 func (c *Client) TransactionDetail(txHash string) (tx hProtocol.Transaction, err error) {
+	return c.TransactionDetailContext(context.Background(), txHash)
+}
+
+// TransactionDetailContext is the same as TransactionDetail but it takes a context which can be used
+// to cancel the request.
+func (c *Client) TransactionDetailContext(ctx context.Context, txHash string) (tx hProtocol.Transaction, err error) {
 	if txHash == "" {
 		return tx, errors.New("no transaction hash provided")
 	}
 
 	request := TransactionRequest{forTransactionHash: txHash}
-	err = c.sendRequest(request, &tx)
+	err = c.sendRequest(ctx, request, &tx)
 	return
 }
 
 // OrderBook returns the orderbook for an asset pair (https://www.stellar.org/developers/horizon/reference/resources/orderbook.html)
 func (c *Client) OrderBook(request OrderBookRequest) (obs hProtocol.OrderBookSummary, err error) {
-	err = c.sendRequest(request, &obs)
+	return c.OrderBookContext(context.Background(), request)
+}
+
+// OrderBookContext is the same as OrderBook but it takes a context which can be used
+// to cancel the request.
+func (c *Client) OrderBookContext(ctx context.Context, request OrderBookRequest) (obs hProtocol.OrderBookSummary, err error) {
+	err = c.sendRequest(ctx, request, &obs)
 	return
 }
 
 // Paths returns the available paths to make a strict receive path payment. See https://www.stellar.org/developers/horizon/reference/endpoints/path-finding-strict-receive.html
 // This function is an alias for `client.StrictReceivePaths` and will be deprecated, use `client.StrictReceivePaths` instead.
 func (c *Client) Paths(request PathsRequest) (paths hProtocol.PathsPage, err error) {
-	paths, err = c.StrictReceivePaths(request)
+	return c.PathsContext(context.Background(), request)
+}
+
+// PathsContext is the same as Paths but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PathsContext(ctx context.Context, request PathsRequest) (paths hProtocol.PathsPage, err error) {
+	paths, err = c.StrictReceivePathsContext(ctx, request)
 	return
 }
 
 // StrictReceivePaths returns the available paths to make a strict receive path payment. See https://www.stellar.org/developers/horizon/reference/endpoints/path-finding-strict-receive.html
 func (c *Client) StrictReceivePaths(request PathsRequest) (paths hProtocol.PathsPage, err error) {
-	err = c.sendRequest(request, &paths)
+	return c.StrictReceivePathsContext(context.Background(), request)
+}
+
+// StrictReceivePathsContext is the same as StrictReceivePaths but it takes a context which can be used
+// to cancel the request.
+func (c *Client) StrictReceivePathsContext(ctx context.Context, request PathsRequest) (paths hProtocol.PathsPage, err error) {
+	err = c.sendRequest(ctx, request, &paths)
 	return
 }
 
 // StrictSendPaths returns the available paths to make a strict send path payment. See https://www.stellar.org/developers/horizon/reference/endpoints/path-finding-strict-send.html
 func (c *Client) StrictSendPaths(request StrictSendPathsRequest) (paths hProtocol.PathsPage, err error) {
-	err = c.sendRequest(request, &paths)
+	return c.StrictSendPathsContext(context.Background(), request)
+}
+
+// StrictSendPathsContext is the same as StrictSendPaths but it takes a context which can be used
+// to cancel the request.
+func (c *Client) StrictSendPathsContext(ctx context.Context, request StrictSendPathsRequest) (paths hProtocol.PathsPage, err error) {
+	err = c.sendRequest(ctx, request, &paths)
 	return
 }
 
 // Payments returns stellar account_merge, create_account, path payment and payment operations.
 // It can be used to return payments for an account, a ledger, a transaction and all payments on the network.
 func (c *Client) Payments(request OperationRequest) (ops operations.OperationsPage, err error) {
-	err = c.sendRequest(request.SetPaymentsEndpoint(), &ops)
+	return c.PaymentsContext(context.Background(), request)
+}
+
+// PaymentsContext is the same as Payments but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PaymentsContext(ctx context.Context, request OperationRequest) (ops operations.OperationsPage, err error) {
+	err = c.sendRequest(ctx, request.SetPaymentsEndpoint(), &ops)
 	return
 }
 
 // Trades returns stellar trades (https://www.stellar.org/developers/horizon/reference/resources/trade.html)
 // It can be used to return trades for an account, an offer and all trades on the network.
 func (c *Client) Trades(request TradeRequest) (tds hProtocol.TradesPage, err error) {
-	err = c.sendRequest(request, &tds)
+	return c.TradesContext(context.Background(), request)
+}
+
+// TradesContext is the same as Trades but it takes a context which can be used
+// to cancel the request.
+func (c *Client) TradesContext(ctx context.Context, request TradeRequest) (tds hProtocol.TradesPage, err error) {
+	err = c.sendRequest(ctx, request, &tds)
 	return
 }
 
 // Fund creates a new account funded from friendbot. It only works on test networks. See
 // https://www.stellar.org/developers/guides/get-started/create-account.html for more information.
 func (c *Client) Fund(addr string) (tx hProtocol.Transaction, err error) {
+	return c.FundContext(context.Background(), addr)
+}
+
+// FundContext is the same as Fund but it takes a context which can be used
+// to cancel the request.
+func (c *Client) FundContext(ctx context.Context, addr string) (tx hProtocol.Transaction, err error) {
 	if !c.isTestNet {
 		return tx, errors.New("can't fund account from friendbot on production network")
 	}
 	friendbotURL := fmt.Sprintf("%sfriendbot?addr=%s", c.fixHorizonURL(), addr)
-	err = c.sendRequestURL(friendbotURL, "get", &tx)
+	err = c.sendRequestURL(ctx, friendbotURL, "get", &tx)
 	return
 }
 
@@ -595,7 +658,13 @@ func (c *Client) StreamTrades(ctx context.Context, request TradeRequest, handler
 
 // TradeAggregations returns stellar trade aggregations (https://www.stellar.org/developers/horizon/reference/resources/trade_aggregation.html)
 func (c *Client) TradeAggregations(request TradeAggregationRequest) (tds hProtocol.TradeAggregationsPage, err error) {
-	err = c.sendRequest(request, &tds)
+	return c.TradeAggregationsContext(context.Background(), request)
+}
+
+// TradeAggregationsContext is the same as TradeAggregations but it takes a context which can be used
+// to cancel the request.
+func (c *Client) TradeAggregationsContext(ctx context.Context, request TradeAggregationRequest) (tds hProtocol.TradeAggregationsPage, err error) {
+	err = c.sendRequest(ctx, request, &tds)
 	return
 }
 
@@ -672,7 +741,13 @@ func (c *Client) FetchTimebounds(seconds int64) (txnbuild.Timebounds, error) {
 
 // Root loads the root endpoint of horizon
 func (c *Client) Root() (root hProtocol.Root, err error) {
-	err = c.sendRequestURL(c.fixHorizonURL(), "get", &root)
+	return c.RootContext(context.Background())
+}
+
+// RootContext is the same as Root but it takes a context which can be used
+// to cancel the request.
+func (c *Client) RootContext(ctx context.Context) (root hProtocol.Root, err error) {
+	err = c.sendRequestURL(ctx, c.fixHorizonURL(), "get", &root)
 	return
 }
 
@@ -683,111 +758,219 @@ func (c *Client) Version() string {
 
 // NextAccountsPage returns the next page of accounts.
 func (c *Client) NextAccountsPage(page hProtocol.AccountsPage) (accounts hProtocol.AccountsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &accounts)
+	return c.NextAccountsPageContext(context.Background(), page)
+}
+
+// NextAccountsPageContext is the same as NextAccountsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextAccountsPageContext(ctx context.Context, page hProtocol.AccountsPage) (accounts hProtocol.AccountsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &accounts)
 	return
 }
 
 // NextAssetsPage returns the next page of assets.
 func (c *Client) NextAssetsPage(page hProtocol.AssetsPage) (assets hProtocol.AssetsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &assets)
+	return c.NextAssetsPageContext(context.Background(), page)
+}
+
+// NextAssetsPageContext is the same as NextAssetsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (assets hProtocol.AssetsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &assets)
 	return
 }
 
 // PrevAssetsPage returns the previous page of assets.
 func (c *Client) PrevAssetsPage(page hProtocol.AssetsPage) (assets hProtocol.AssetsPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &assets)
+	return c.PrevAssetsPageContext(context.Background(), page)
+}
+
+// PrevAssetsPageContext is the same as PrevAssetsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (assets hProtocol.AssetsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &assets)
 	return
 }
 
 // NextLedgersPage returns the next page of ledgers.
 func (c *Client) NextLedgersPage(page hProtocol.LedgersPage) (ledgers hProtocol.LedgersPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &ledgers)
+	return c.NextLedgersPageContext(context.Background(), page)
+}
+
+// NextLedgersPageContext is the same as NextLedgersPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (ledgers hProtocol.LedgersPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &ledgers)
 	return
 }
 
 // PrevLedgersPage returns the previous page of ledgers.
 func (c *Client) PrevLedgersPage(page hProtocol.LedgersPage) (ledgers hProtocol.LedgersPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &ledgers)
+	return c.PrevLedgersPageContext(context.Background(), page)
+}
+
+// PrevLedgersPageContext is the same as PrevLedgersPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (ledgers hProtocol.LedgersPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &ledgers)
 	return
 }
 
 // NextEffectsPage returns the next page of effects.
 func (c *Client) NextEffectsPage(page effects.EffectsPage) (efp effects.EffectsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &efp)
+	return c.NextEffectsPageContext(context.Background(), page)
+}
+
+// NextEffectsPageContext is the same as NextEffectsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextEffectsPageContext(ctx context.Context, page effects.EffectsPage) (efp effects.EffectsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &efp)
 	return
 }
 
 // PrevEffectsPage returns the previous page of effects.
 func (c *Client) PrevEffectsPage(page effects.EffectsPage) (efp effects.EffectsPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &efp)
+	return c.PrevEffectsPageContext(context.Background(), page)
+}
+
+// PrevEffectsPageContext is the same as PrevEffectsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevEffectsPageContext(ctx context.Context, page effects.EffectsPage) (efp effects.EffectsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &efp)
 	return
 }
 
 // NextTransactionsPage returns the next page of transactions.
 func (c *Client) NextTransactionsPage(page hProtocol.TransactionsPage) (transactions hProtocol.TransactionsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &transactions)
+	return c.NextTransactionsPageContext(context.Background(), page)
+}
+
+// NextTransactionsPageContext is the same as NextTransactionsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (transactions hProtocol.TransactionsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &transactions)
 	return
 }
 
 // PrevTransactionsPage returns the previous page of transactions.
 func (c *Client) PrevTransactionsPage(page hProtocol.TransactionsPage) (transactions hProtocol.TransactionsPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &transactions)
+	return c.PrevTransactionsPageContext(context.Background(), page)
+}
+
+// PrevTransactionsPageContext is the same as PrevTransactionsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (transactions hProtocol.TransactionsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &transactions)
 	return
 }
 
 // NextOperationsPage returns the next page of operations.
 func (c *Client) NextOperationsPage(page operations.OperationsPage) (operations operations.OperationsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &operations)
+	return c.NextOperationsPageContext(context.Background(), page)
+}
+
+// NextOperationsPageContext is the same as NextOperationsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextOperationsPageContext(ctx context.Context, page operations.OperationsPage) (operations operations.OperationsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &operations)
 	return
 }
 
 // PrevOperationsPage returns the previous page of operations.
 func (c *Client) PrevOperationsPage(page operations.OperationsPage) (operations operations.OperationsPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &operations)
+	return c.PrevOperationsPageContext(context.Background(), page)
+}
+
+// PrevOperationsPageContext is the same as PrevOperationsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevOperationsPageContext(ctx context.Context, page operations.OperationsPage) (operations operations.OperationsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &operations)
 	return
 }
 
 // NextPaymentsPage returns the next page of payments.
 func (c *Client) NextPaymentsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
-	return c.NextOperationsPage(page)
+	return c.NextPaymentsPageContext(context.Background(), page)
+}
+
+// NextPaymentsPageContext is the same as NextPaymentsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	return c.NextOperationsPageContext(ctx, page)
 }
 
 // PrevPaymentsPage returns the previous page of payments.
 func (c *Client) PrevPaymentsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
-	return c.PrevOperationsPage(page)
+	return c.PrevPaymentsPageContext(context.Background(), page)
+}
+
+// PrevPaymentsPageContext is the same as PrevPaymentsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	return c.PrevOperationsPageContext(ctx, page)
 }
 
 // NextOffersPage returns the next page of offers.
 func (c *Client) NextOffersPage(page hProtocol.OffersPage) (offers hProtocol.OffersPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &offers)
+	return c.NextOffersPageContext(context.Background(), page)
+}
+
+// NextOffersPageContext is the same as NextOffersPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (offers hProtocol.OffersPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &offers)
 	return
 }
 
 // PrevOffersPage returns the previous page of offers.
 func (c *Client) PrevOffersPage(page hProtocol.OffersPage) (offers hProtocol.OffersPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &offers)
+	return c.PrevOffersPageContext(context.Background(), page)
+}
+
+// PrevOffersPageContext is the same as PrevOffersPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (offers hProtocol.OffersPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &offers)
 	return
 }
 
 // NextTradesPage returns the next page of trades.
 func (c *Client) NextTradesPage(page hProtocol.TradesPage) (trades hProtocol.TradesPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &trades)
+	return c.NextTradesPageContext(context.Background(), page)
+}
+
+// NextTradesPageContext is the same as NextTradesPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (trades hProtocol.TradesPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &trades)
 	return
 }
 
 // PrevTradesPage returns the previous page of trades.
 func (c *Client) PrevTradesPage(page hProtocol.TradesPage) (trades hProtocol.TradesPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &trades)
+	return c.PrevTradesPageContext(context.Background(), page)
+}
+
+// PrevTradesPageContext is the same as PrevTradesPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (trades hProtocol.TradesPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &trades)
 	return
 }
 
 // HomeDomainForAccount returns the home domain for a single account.
 func (c *Client) HomeDomainForAccount(aid string) (string, error) {
+	return c.HomeDomainForAccountContext(context.Background(), aid)
+}
+
+// HomeDomainForAccountContext is the same as HomeDomainForAccount but it takes a context which can be used
+// to cancel the request.
+func (c *Client) HomeDomainForAccountContext(ctx context.Context, aid string) (string, error) {
 	if aid == "" {
 		return "", errors.New("no account ID provided")
 	}
 
-	accountDetail, err := c.AccountDetail(AccountRequest{AccountID: aid})
+	accountDetail, err := c.AccountDetailContext(ctx, AccountRequest{AccountID: aid})
 	if err != nil {
 		return "", errors.Wrap(err, "get account detail failed")
 	}
@@ -798,28 +981,52 @@ func (c *Client) HomeDomainForAccount(aid string) (string, error) {
 // NextTradeAggregationsPage returns the next page of trade aggregations from the current
 // trade aggregations response.
 func (c *Client) NextTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (ta hProtocol.TradeAggregationsPage, err error) {
-	err = c.sendRequestURL(page.Links.Next.Href, "get", &ta)
+	return c.NextTradeAggregationsPageContext(context.Background(), page)
+}
+
+// NextTradeAggregationsPageContext is the same as NextTradeAggregationsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) NextTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (ta hProtocol.TradeAggregationsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Next.Href, "get", &ta)
 	return
 }
 
 // PrevTradeAggregationsPage returns the previous page of trade aggregations from the current
 // trade aggregations response.
 func (c *Client) PrevTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (ta hProtocol.TradeAggregationsPage, err error) {
-	err = c.sendRequestURL(page.Links.Prev.Href, "get", &ta)
+	return c.PrevTradeAggregationsPageContext(context.Background(), page)
+}
+
+// PrevTradeAggregationsPageContext is the same as PrevTradeAggregationsPage but it takes a context which can be used
+// to cancel the request.
+func (c *Client) PrevTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (ta hProtocol.TradeAggregationsPage, err error) {
+	err = c.sendRequestURL(ctx, page.Links.Prev.Href, "get", &ta)
 	return
 }
 
 // ClaimableBalances returns details about available claimable balances,
 // possibly filtered to a specific sponsor or other parameters.
 func (c *Client) ClaimableBalances(cbr ClaimableBalanceRequest) (cb hProtocol.ClaimableBalances, err error) {
-	err = c.sendRequest(cbr, &cb)
+	return c.ClaimableBalancesContext(context.Background(), cbr)
+}
+
+// ClaimableBalancesContext is the same as ClaimableBalances but it takes a context which can be used
+// to cancel the request.
+func (c *Client) ClaimableBalancesContext(ctx context.Context, cbr ClaimableBalanceRequest) (cb hProtocol.ClaimableBalances, err error) {
+	err = c.sendRequest(ctx, cbr, &cb)
 	return
 }
 
 // ClaimableBalance returns details about a *specific*, unique claimable balance.
 func (c *Client) ClaimableBalance(id string) (cb hProtocol.ClaimableBalance, err error) {
+	return c.ClaimableBalanceContext(context.Background(), id)
+}
+
+// ClaimableBalanceContext is the same as ClaimableBalance but it takes a context which can be used
+// to cancel the request.
+func (c *Client) ClaimableBalanceContext(ctx context.Context, id string) (cb hProtocol.ClaimableBalance, err error) {
 	cbr := ClaimableBalanceRequest{ID: id}
-	err = c.sendRequest(cbr, &cb)
+	err = c.sendRequest(ctx, cbr, &cb)
 	return
 }
 
@@ -0,0 +1,214 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+// FeePolicy configures how base fees (the fees offered per operation) are
+// picked from the fee stats of recent ledgers and how transactions which were
+// not accepted because of their fee are resubmitted with fee bump
+// transactions.
+type FeePolicy struct {
+	// Percentile of the maximum fees offered by the transactions in recent
+	// ledgers which is used as the base fee. It must be one of 10, 20, 30,
+	// 40, 50, 60, 70, 80, 90, 95 or 99. Zero uses the most common fee (the
+	// mode).
+	Percentile int
+	// MinBaseFee is the lowest base fee which is used. The base fee is never
+	// lower than txnbuild.MinBaseFee and the base fee of the last ledger.
+	MinBaseFee int64
+	// MaxBaseFee is the highest base fee which is used by estimates and fee
+	// bumps. Zero means there is no limit, but fee bumps are only possible
+	// with a limit.
+	MaxBaseFee int64
+	// FeeAccount signs and pays for the fee bump transactions. Fee bumps are
+	// disabled when it's nil.
+	FeeAccount *keypair.Full
+	// NetworkPassphrase is the passphrase used to sign fee bump transactions.
+	// It's loaded from Horizon when it's empty.
+	NetworkPassphrase string
+}
+
+// baseFee picks the base fee from the given fee stats
+func (p FeePolicy) baseFee(stats hProtocol.FeeStats) (int64, error) {
+	var fee int64
+	offered := stats.MaxFee
+	switch p.Percentile {
+	case 0:
+		fee = offered.Mode
+	case 10:
+		fee = offered.P10
+	case 20:
+		fee = offered.P20
+	case 30:
+		fee = offered.P30
+	case 40:
+		fee = offered.P40
+	case 50:
+		fee = offered.P50
+	case 60:
+		fee = offered.P60
+	case 70:
+		fee = offered.P70
+	case 80:
+		fee = offered.P80
+	case 90:
+		fee = offered.P90
+	case 95:
+		fee = offered.P95
+	case 99:
+		fee = offered.P99
+	default:
+		return 0, errors.Errorf("unsupported fee percentile %d", p.Percentile)
+	}
+
+	for _, min := range []int64{stats.LastLedgerBaseFee, p.MinBaseFee, txnbuild.MinBaseFee} {
+		if fee < min {
+			fee = min
+		}
+	}
+	if p.MaxBaseFee > 0 && fee > p.MaxBaseFee {
+		fee = p.MaxBaseFee
+	}
+	return fee, nil
+}
+
+// EstimateBaseFee returns the base fee to use in new transactions according
+// to the given policy and the fees offered by the transactions in recent
+// ledgers
+func (c *Client) EstimateBaseFee(policy FeePolicy) (int64, error) {
+	return c.EstimateBaseFeeContext(context.Background(), policy)
+}
+
+// EstimateBaseFeeContext is the same as EstimateBaseFee but it takes a context which can be used
+// to cancel the request.
+func (c *Client) EstimateBaseFeeContext(ctx context.Context, policy FeePolicy) (int64, error) {
+	stats, err := c.FeeStatsContext(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not load fee stats")
+	}
+	return policy.baseFee(stats)
+}
+
+// SubmitTransactionWithFeePolicy submits a transaction to the network and
+// resubmits it wrapped in fee bump transactions paid by policy.FeeAccount if
+// it was rejected with tx_insufficient_fee or the submission timed out.
+// Every fee bump offers at least twice the previous base fee or the fee
+// estimated using the policy, whichever is higher, up to policy.MaxBaseFee.
+// The last error is returned once a transaction offering the maximum fee is
+// not accepted.
+//
+// The transaction should be built using a base fee returned by
+// EstimateBaseFee. err can be either an error object or a horizon.Error
+// object.
+func (c *Client) SubmitTransactionWithFeePolicy(
+	transaction *txnbuild.Transaction,
+	policy FeePolicy,
+) (tx hProtocol.Transaction, err error) {
+	return c.SubmitTransactionWithFeePolicyContext(context.Background(), transaction, policy)
+}
+
+// SubmitTransactionWithFeePolicyContext is the same as SubmitTransactionWithFeePolicy but it takes a context which can be used
+// to cancel the requests.
+func (c *Client) SubmitTransactionWithFeePolicyContext(
+	ctx context.Context,
+	transaction *txnbuild.Transaction,
+	policy FeePolicy,
+) (tx hProtocol.Transaction, err error) {
+	if policy.FeeAccount != nil && policy.MaxBaseFee <= 0 {
+		return tx, errors.New("MaxBaseFee is required for fee bumps")
+	}
+
+	tx, err = c.SubmitTransactionContext(ctx, transaction)
+	if policy.FeeAccount == nil || !isFeeRelatedError(err) {
+		return
+	}
+
+	passphrase := policy.NetworkPassphrase
+	if passphrase == "" {
+		root, rootErr := c.RootContext(ctx)
+		if rootErr != nil {
+			return tx, errors.Wrap(rootErr, "could not load network passphrase")
+		}
+		passphrase = root.NetworkPassphrase
+	}
+	innerHash, hashErr := transaction.HashHex(passphrase)
+	if hashErr != nil {
+		return tx, errors.Wrap(hashErr, "could not hash transaction")
+	}
+
+	baseFee := transaction.BaseFee()
+	timedOut := isTimeoutError(err)
+	for {
+		next := 2 * baseFee
+		if estimate, estimateErr := c.EstimateBaseFeeContext(ctx, policy); estimateErr == nil && estimate > next {
+			next = estimate
+		}
+		if next > policy.MaxBaseFee {
+			next = policy.MaxBaseFee
+		}
+		if next <= baseFee {
+			// the ceiling was reached
+			return
+		}
+		baseFee = next
+
+		feeBump, bumpErr := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+			Inner:      transaction,
+			FeeAccount: policy.FeeAccount.Address(),
+			BaseFee:    baseFee,
+		})
+		if bumpErr != nil {
+			return tx, errors.Wrap(bumpErr, "could not build fee bump transaction")
+		}
+		if feeBump, bumpErr = feeBump.Sign(passphrase, policy.FeeAccount); bumpErr != nil {
+			return tx, errors.Wrap(bumpErr, "could not sign fee bump transaction")
+		}
+
+		// the memo requirements were checked by the first submission
+		tx, err = c.SubmitFeeBumpTransactionWithOptionsContext(
+			ctx, feeBump, SubmitTxOpts{SkipMemoRequiredCheck: true},
+		)
+		if err != nil && timedOut && !isFeeRelatedError(err) {
+			// a previous submission which timed out could have been included
+			// in a ledger in the meantime
+			if found, findErr := c.TransactionDetailContext(ctx, innerHash); findErr == nil {
+				if !found.Successful {
+					return found, ErrTransactionFailed
+				}
+				return found, nil
+			}
+		}
+		if !isFeeRelatedError(err) {
+			return
+		}
+		timedOut = timedOut || isTimeoutError(err)
+	}
+}
+
+// isTimeoutError returns true if the submission of a transaction timed out
+func isTimeoutError(err error) bool {
+	hErr := GetError(err)
+	return hErr != nil && hErr.Response != nil && hErr.Response.StatusCode == http.StatusGatewayTimeout
+}
+
+// isFeeRelatedError returns true if a transaction may be accepted if it's
+// resubmitted with a higher fee. Transactions which timed out are usually
+// waiting in the queue of Stellar Core because their fee is too low.
+func isFeeRelatedError(err error) bool {
+	if isTimeoutError(err) {
+		return true
+	}
+	hErr := GetError(err)
+	if hErr == nil {
+		return false
+	}
+	codes, codesErr := hErr.ResultCodes()
+	return codesErr == nil && codes.TransactionCode == "tx_insufficient_fee"
+}
@@ -0,0 +1,160 @@
+package horizonclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var insufficientFeeResponse = `{
+  "type": "https://stellar.org/horizon-errors/transaction_failed",
+  "title": "Transaction Failed",
+  "status": 400,
+  "extras": {
+    "result_codes": {
+      "transaction": "tx_insufficient_fee"
+    }
+  }
+}`
+
+// submission is a transaction received by submissionsResponder
+type submission struct {
+	feeBump bool
+	maxFee  int64
+}
+
+// submissionsResponder records the submitted transactions and responds with
+// the given responders in sequence
+func submissionsResponder(submissions *[]submission, responders ...httpmock.Responder) httpmock.Responder {
+	calls := 0
+	next := sequenceResponder(&calls, responders...)
+	return func(req *http.Request) (*http.Response, error) {
+		var envelope xdr.TransactionEnvelope
+		if err := xdr.SafeUnmarshalBase64(req.URL.Query().Get("tx"), &envelope); err != nil {
+			return nil, err
+		}
+		received := submission{feeBump: envelope.IsFeeBump(), maxFee: int64(envelope.Fee())}
+		if envelope.IsFeeBump() {
+			received.maxFee = envelope.FeeBumpFee()
+		}
+		*submissions = append(*submissions, received)
+		return next(req)
+	}
+}
+
+func TestFeePolicyBaseFee(t *testing.T) {
+	stats := hProtocol.FeeStats{
+		LastLedgerBaseFee: 100,
+		MaxFee: hProtocol.FeeDistribution{
+			Mode: 250, P10: 90, P50: 500, P95: 5000, P99: 8000,
+		},
+	}
+	for _, testCase := range []struct {
+		policy   FeePolicy
+		expected int64
+	}{
+		{FeePolicy{}, 250},
+		{FeePolicy{Percentile: 50}, 500},
+		{FeePolicy{Percentile: 95, MaxBaseFee: 1000}, 1000},
+		{FeePolicy{Percentile: 99, MinBaseFee: 10000}, 10000},
+		// the fee is never lower than the base fee of the last ledger
+		{FeePolicy{Percentile: 10}, 100},
+	} {
+		fee, err := testCase.policy.baseFee(stats)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, fee, "%+v", testCase.policy)
+	}
+
+	_, err := FeePolicy{Percentile: 15}.baseFee(stats)
+	assert.EqualError(t, err, "unsupported fee percentile 15")
+}
+
+func TestEstimateBaseFee(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	hmock.On("GET", "https://localhost/fee_stats").ReturnString(http.StatusOK, feesResponse)
+
+	fee, err := client.EstimateBaseFee(FeePolicy{Percentile: 70})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), fee)
+}
+
+func TestSubmitTransactionWithFeePolicy(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+	hmock.On("GET", "https://localhost/fee_stats").Return(stringResponder(http.StatusOK, feesResponse))
+
+	source := keypair.MustRandom()
+	feeAccount := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 0}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	policy := FeePolicy{
+		Percentile:        50,
+		MaxBaseFee:        1500,
+		FeeAccount:        feeAccount,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+	}
+
+	// the fee is escalated to the estimate and then doubled
+	var submissions []submission
+	hmock.On("POST", "https://localhost/transactions").Return(submissionsResponder(
+		&submissions,
+		stringResponder(http.StatusBadRequest, insufficientFeeResponse),
+		stringResponder(http.StatusGatewayTimeout, timeoutResponse),
+		stringResponder(http.StatusOK, txSuccess),
+	))
+	_, err = client.SubmitTransactionWithFeePolicy(tx, policy)
+	require.NoError(t, err)
+	assert.Equal(t, []submission{{false, 100}, {true, 1000}, {true, 2000}}, submissions)
+
+	// the last error is returned once the ceiling is reached
+	submissions = nil
+	hmock.On("POST", "https://localhost/transactions").Return(submissionsResponder(
+		&submissions,
+		stringResponder(http.StatusBadRequest, insufficientFeeResponse),
+	))
+	_, err = client.SubmitTransactionWithFeePolicy(tx, policy)
+	hErr := GetError(err)
+	require.NotNil(t, hErr)
+	codes, err := hErr.ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, "tx_insufficient_fee", codes.TransactionCode)
+	assert.Equal(t, []submission{{false, 100}, {true, 1000}, {true, 2000}, {true, 3000}}, submissions)
+
+	// other errors are returned immediately
+	submissions = nil
+	hmock.On("POST", "https://localhost/transactions").Return(submissionsResponder(
+		&submissions,
+		stringResponder(http.StatusBadRequest, transactionFailure),
+	))
+	_, err = client.SubmitTransactionWithFeePolicy(tx, policy)
+	require.NotNil(t, GetError(err))
+	assert.Len(t, submissions, 1)
+
+	// fee bumps are disabled without a fee account
+	submissions = nil
+	hmock.On("POST", "https://localhost/transactions").Return(submissionsResponder(
+		&submissions,
+		stringResponder(http.StatusBadRequest, insufficientFeeResponse),
+	))
+	_, err = client.SubmitTransactionWithFeePolicy(tx, FeePolicy{})
+	require.NotNil(t, GetError(err))
+	assert.Len(t, submissions, 1)
+}
@@ -0,0 +1,613 @@
+package horizontest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 200
+)
+
+// record is an entry of a collection served by the server
+type record struct {
+	id       int64
+	resource hal.Pageable
+}
+
+// collection returns the records of a collection in ascending order. It's
+// called with the server lock held.
+type collection func() []record
+
+func (s *Server) router() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.getRoot)
+	r.Get("/health", s.getHealth)
+	r.Get("/fee_stats", s.getFeeStats)
+	r.Get("/friendbot", s.getFriendbot)
+	r.Get("/accounts/{account_id}", s.getAccount)
+	r.Get("/accounts/{account_id}/data/{key}", s.getAccountData)
+	r.Get("/accounts/{account_id}/transactions", s.getTransactions)
+	r.Get("/accounts/{account_id}/operations", s.getOperations(false))
+	r.Get("/accounts/{account_id}/payments", s.getOperations(true))
+	r.Get("/transactions", s.getTransactions)
+	r.Post("/transactions", s.postTransaction)
+	r.Get("/transactions/{tx_id}", s.getTransaction)
+	r.Get("/transactions/{tx_id}/operations", s.getOperations(false))
+	r.Get("/transactions/{tx_id}/payments", s.getOperations(true))
+	r.Get("/operations", s.getOperations(false))
+	r.Get("/operations/{op_id}", s.getOperation)
+	r.Get("/payments", s.getOperations(true))
+	r.Get("/paths", s.getStrictReceivePaths)
+	r.Get("/paths/strict-receive", s.getStrictReceivePaths)
+	r.Get("/paths/strict-send", s.getStrictSendPaths)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		s.problems.Render(r.Context(), w, problem.NotFound)
+	})
+	return r
+}
+
+func (s *Server) getRoot(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var root hProtocol.Root
+	root.Links.Self = hal.NewLink(s.URL())
+	root.Links.Account = hal.NewLink(s.URL() + "accounts/{account_id}")
+	root.Links.AccountTransactions = hal.NewLink(s.URL() + "accounts/{account_id}/transactions{?cursor,limit,order}")
+	root.Links.FeeStats = hal.NewLink(s.URL() + "fee_stats")
+	friendbot := hal.NewLink(s.URL() + "friendbot{?addr}")
+	root.Links.Friendbot = &friendbot
+	root.Links.Operation = hal.NewLink(s.URL() + "operations/{id}")
+	root.Links.Operations = hal.NewLink(s.URL() + "operations{?cursor,limit,order,include_failed}")
+	root.Links.Payments = hal.NewLink(s.URL() + "payments{?cursor,limit,order,include_failed}")
+	strictReceivePaths := hal.NewLink(s.URL() + "paths/strict-receive{?source_assets,source_account,destination_account,destination_asset_type,destination_asset_issuer,destination_asset_code,destination_amount}")
+	root.Links.StrictReceivePaths = &strictReceivePaths
+	strictSendPaths := hal.NewLink(s.URL() + "paths/strict-send{?destination_account,destination_assets,source_asset_type,source_asset_issuer,source_asset_code,source_amount}")
+	root.Links.StrictSendPaths = &strictSendPaths
+	root.Links.Transaction = hal.NewLink(s.URL() + "transactions/{hash}")
+	root.Links.Transactions = hal.NewLink(s.URL() + "transactions{?cursor,limit,order}")
+	root.HorizonVersion = "horizontest"
+	root.StellarCoreVersion = "horizontest"
+	root.IngestSequence = s.ledger
+	root.HorizonSequence = int32(s.ledger)
+	root.HorizonLatestClosedAt = s.closedAt
+	root.HistoryElderSequence = 1
+	root.CoreSequence = int32(s.ledger)
+	root.NetworkPassphrase = s.networkPassphrase
+	root.CurrentProtocolVersion = 15
+	root.CoreSupportedProtocolVersion = 15
+	hal.Render(w, root)
+}
+
+func (s *Server) getHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprint(w, `{"database_connected": true, "core_up": true, "core_synced": true}`)
+}
+
+func (s *Server) getFeeStats(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fees := hProtocol.FeeDistribution{
+		Max: baseFee, Min: baseFee, Mode: baseFee,
+		P10: baseFee, P20: baseFee, P30: baseFee, P40: baseFee, P50: baseFee,
+		P60: baseFee, P70: baseFee, P80: baseFee, P90: baseFee, P95: baseFee, P99: baseFee,
+	}
+	hal.Render(w, hProtocol.FeeStats{
+		LastLedger:        s.ledger,
+		LastLedgerBaseFee: baseFee,
+		FeeCharged:        fees,
+		MaxFee:            fees,
+	})
+}
+
+func (s *Server) getFriendbot(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("addr")
+	if _, err := keypair.ParseAddress(address); err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("addr", err))
+		return
+	}
+	tx, err := s.Fund(address, FriendbotAmount)
+	if err != nil {
+		s.problems.Render(r.Context(), w, err)
+		return
+	}
+	hal.Render(w, tx)
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	acc, ok := s.accounts[chi.URLParam(r, "account_id")]
+	if !ok {
+		s.problems.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, s.renderAccount(acc))
+}
+
+func (s *Server) renderAccount(acc *account) hProtocol.Account {
+	result := hProtocol.Account{
+		ID:                 acc.id,
+		AccountID:          acc.id,
+		Sequence:           strconv.FormatInt(acc.sequence, 10),
+		SubentryCount:      acc.subentries(),
+		HomeDomain:         acc.homeDomain,
+		LastModifiedLedger: acc.lastModified,
+		Thresholds: hProtocol.AccountThresholds{
+			LowThreshold:  acc.thresholds[lowThreshold],
+			MedThreshold:  acc.thresholds[mediumThreshold],
+			HighThreshold: acc.thresholds[highThreshold],
+		},
+		Data: map[string]string{},
+		PT:   acc.id,
+	}
+	for _, line := range acc.trustlines {
+		balance := hProtocol.Balance{
+			Balance:            amount.StringFromInt64(line.balance),
+			Limit:              amount.StringFromInt64(line.limit),
+			BuyingLiabilities:  "0.0000000",
+			SellingLiabilities: "0.0000000",
+		}
+		line.asset.MustExtract(&balance.Type, &balance.Code, &balance.Issuer)
+		result.Balances = append(result.Balances, balance)
+	}
+	result.Balances = append(result.Balances, hProtocol.Balance{
+		Balance:            amount.StringFromInt64(acc.balance),
+		BuyingLiabilities:  "0.0000000",
+		SellingLiabilities: "0.0000000",
+		Asset:              base.Asset{Type: "native"},
+	})
+	for _, signer := range acc.signers {
+		result.Signers = append(result.Signers, hProtocol.Signer{
+			Weight: signer.weight,
+			Key:    signer.key,
+			Type:   "ed25519_public_key",
+		})
+	}
+	result.Signers = append(result.Signers, hProtocol.Signer{
+		Weight: acc.masterWeight,
+		Key:    acc.id,
+		Type:   "ed25519_public_key",
+	})
+	for key, value := range acc.data {
+		result.Data[key] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	self := s.URL() + "accounts/" + acc.id
+	result.Links.Self = hal.NewLink(self)
+	result.Links.Transactions = hal.NewLink(self + "/transactions{?cursor,limit,order}")
+	result.Links.Operations = hal.NewLink(self + "/operations{?cursor,limit,order}")
+	result.Links.Payments = hal.NewLink(self + "/payments{?cursor,limit,order}")
+	result.Links.Effects = hal.NewLink(self + "/effects{?cursor,limit,order}")
+	result.Links.Offers = hal.NewLink(self + "/offers{?cursor,limit,order}")
+	result.Links.Trades = hal.NewLink(self + "/trades{?cursor,limit,order}")
+	result.Links.Data = hal.NewLink(self + "/data/{key}")
+	return result
+}
+
+func (s *Server) getAccountData(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	acc, ok := s.accounts[chi.URLParam(r, "account_id")]
+	if !ok {
+		s.problems.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	value, ok := acc.data[chi.URLParam(r, "key")]
+	if !ok {
+		s.problems.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, hProtocol.AccountData{Value: base64.StdEncoding.EncodeToString(value)})
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	hash := chi.URLParam(r, "tx_id")
+	for _, tx := range s.transactions {
+		if tx.transaction.Hash == hash ||
+			(tx.transaction.InnerTransaction != nil && tx.transaction.InnerTransaction.Hash == hash) {
+			hal.Render(w, tx.transaction)
+			return
+		}
+	}
+	s.problems.Render(r.Context(), w, problem.NotFound)
+}
+
+func (s *Server) getTransactions(w http.ResponseWriter, r *http.Request) {
+	accountID := chi.URLParam(r, "account_id")
+	includeFailed := r.URL.Query().Get("include_failed") == "true"
+	s.serveCollection(w, r, func() []record {
+		var records []record
+		for _, tx := range s.transactions {
+			if accountID != "" && !tx.participants[accountID] {
+				continue
+			}
+			if !includeFailed && !tx.transaction.Successful {
+				continue
+			}
+			records = append(records, record{id: tx.id, resource: tx.transaction})
+		}
+		return records
+	})
+}
+
+func (s *Server) postTransaction(w http.ResponseWriter, r *http.Request) {
+	tx, err := s.Submit(r.FormValue("tx"))
+	if err != nil {
+		s.problems.Render(r.Context(), w, err)
+		return
+	}
+	hal.Render(w, tx)
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := chi.URLParam(r, "op_id")
+	for _, op := range s.operations {
+		if op.operation.GetID() == id {
+			hal.Render(w, op.operation)
+			return
+		}
+	}
+	s.problems.Render(r.Context(), w, problem.NotFound)
+}
+
+// getOperations serves the operations or the payments of the network, of an
+// account or of a transaction
+func (s *Server) getOperations(payments bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID := chi.URLParam(r, "account_id")
+		txHash := chi.URLParam(r, "tx_id")
+		includeFailed := r.URL.Query().Get("include_failed") == "true"
+		s.serveCollection(w, r, func() []record {
+			var records []record
+			for _, op := range s.operations {
+				if payments && !op.payment {
+					continue
+				}
+				if accountID != "" && !op.participants[accountID] {
+					continue
+				}
+				if txHash != "" && op.operation.GetTransactionHash() != txHash {
+					continue
+				}
+				if !includeFailed && txHash == "" && !op.operation.IsTransactionSuccessful() {
+					continue
+				}
+				records = append(records, record{id: op.id, resource: op.operation})
+			}
+			return records
+		})
+	}
+}
+
+// serveCollection renders a page of records, or streams them if the client
+// accepts server sent events
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, records collection) {
+	query := r.URL.Query()
+	cursor := query.Get("cursor")
+	if r.Header.Get("Accept") == "text/event-stream" {
+		s.streamCollection(w, r, cursor, records)
+		return
+	}
+
+	order := query.Get("order")
+	if order == "" {
+		order = "asc"
+	}
+	if order != "asc" && order != "desc" {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"order", errors.New("order must be asc or desc"),
+		))
+		return
+	}
+	limit := uint64(defaultLimit)
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 || limit > maxLimit {
+			s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+				"limit", errors.Errorf("limit must be between 1 and %d", maxLimit),
+			))
+			return
+		}
+	}
+	after, err := parseCursor(cursor, order)
+	if err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("cursor", err))
+		return
+	}
+	fullURL, err := url.Parse(s.httpServer.URL + r.URL.RequestURI())
+	if err != nil {
+		s.problems.Render(r.Context(), w, err)
+		return
+	}
+
+	s.lock.Lock()
+	all := records()
+	s.lock.Unlock()
+
+	if order == "desc" {
+		sort.SliceStable(all, func(i, j int) bool { return all[i].id > all[j].id })
+	}
+	page := hal.Page{Order: order, Limit: limit, Cursor: cursor}
+	page.FullURL = fullURL
+	page.Init()
+	for _, rec := range all {
+		if uint64(len(page.Embedded.Records)) == limit {
+			break
+		}
+		if (order == "asc" && rec.id > after) || (order == "desc" && rec.id < after) {
+			page.Add(rec.resource)
+		}
+	}
+	page.PopulateLinks()
+	hal.Render(w, page)
+}
+
+// parseCursor returns the id after which the records are returned
+func parseCursor(cursor, order string) (int64, error) {
+	switch {
+	case cursor == "" && order == "desc", cursor == "now":
+		return math.MaxInt64, nil
+	case cursor == "":
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || id < 0 {
+		return 0, errors.New("cursor must be a positive number")
+	}
+	return id, nil
+}
+
+// streamCollection sends the records after cursor as server sent events
+// and then the records created later until the client disconnects or the
+// server is closed
+func (s *Server) streamCollection(w http.ResponseWriter, r *http.Request, cursor string, records collection) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.problems.Render(r.Context(), w, errors.New("streaming is not supported"))
+		return
+	}
+	after := int64(0)
+	if cursor != "now" {
+		var err error
+		if after, err = parseCursor(cursor, "asc"); err != nil {
+			s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("cursor", err))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 1000\nevent: open\ndata: \"hello\"\n\n")
+	flusher.Flush()
+
+	for {
+		s.lock.Lock()
+		all := records()
+		changed := s.changed
+		s.lock.Unlock()
+
+		if cursor == "now" {
+			// the stream starts after the latest existing record
+			if len(all) > 0 {
+				after = all[len(all)-1].id
+			}
+			cursor = ""
+		}
+		for _, rec := range all {
+			if rec.id <= after {
+				continue
+			}
+			data, err := json.Marshal(rec.resource)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s\ndata: %s\n\n", rec.resource.PagingToken(), data)
+			after = rec.id
+		}
+		flusher.Flush()
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *Server) getStrictReceivePaths(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	destination, err := xdr.BuildAsset(
+		query.Get("destination_asset_type"),
+		query.Get("destination_asset_issuer"),
+		query.Get("destination_asset_code"),
+	)
+	if err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("destination_asset_type", err))
+		return
+	}
+	destinationAmount, err := amount.ParseInt64(query.Get("destination_amount"))
+	if err != nil || destinationAmount <= 0 {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"destination_amount", errors.New("destination_amount must be positive"),
+		))
+		return
+	}
+	sources, err := xdr.BuildAssets(query.Get("source_assets"))
+	if err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("source_assets", err))
+		return
+	}
+	sourceAccount := query.Get("source_account")
+	if (sourceAccount == "") == (len(sources) == 0) {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"source_assets", errors.New("either source_account or source_assets must be set"),
+		))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var balances map[string]int64
+	if sourceAccount != "" {
+		if sources, balances, err = s.holdings(sourceAccount); err != nil {
+			s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("source_account", err))
+			return
+		}
+	}
+
+	var paths hProtocol.PathsPage
+	paths.Embedded.Records = []hProtocol.Path{}
+	for _, source := range sources {
+		for _, path := range s.candidatePaths(source, destination) {
+			sourceAmount, ok := s.rates.costPath(destinationAmount, path)
+			if !ok {
+				continue
+			}
+			if balances != nil && balances[source.String()] < sourceAmount {
+				continue
+			}
+			paths.Embedded.Records = append(
+				paths.Embedded.Records,
+				renderPath(path, sourceAmount, destinationAmount),
+			)
+			break
+		}
+	}
+	hal.Render(w, paths)
+}
+
+func (s *Server) getStrictSendPaths(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	source, err := xdr.BuildAsset(
+		query.Get("source_asset_type"),
+		query.Get("source_asset_issuer"),
+		query.Get("source_asset_code"),
+	)
+	if err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("source_asset_type", err))
+		return
+	}
+	sourceAmount, err := amount.ParseInt64(query.Get("source_amount"))
+	if err != nil || sourceAmount <= 0 {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"source_amount", errors.New("source_amount must be positive"),
+		))
+		return
+	}
+	destinations, err := xdr.BuildAssets(query.Get("destination_assets"))
+	if err != nil {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("destination_assets", err))
+		return
+	}
+	destinationAccount := query.Get("destination_account")
+	if (destinationAccount == "") == (len(destinations) == 0) {
+		s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"destination_assets", errors.New("either destination_account or destination_assets must be set"),
+		))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if destinationAccount != "" {
+		if destinations, _, err = s.holdings(destinationAccount); err != nil {
+			s.problems.Render(r.Context(), w, problem.MakeInvalidFieldProblem("destination_account", err))
+			return
+		}
+	}
+
+	var paths hProtocol.PathsPage
+	paths.Embedded.Records = []hProtocol.Path{}
+	for _, destination := range destinations {
+		for _, path := range s.candidatePaths(source, destination) {
+			destinationAmount, ok := s.rates.convertPath(sourceAmount, path)
+			if !ok || destinationAmount <= 0 {
+				continue
+			}
+			paths.Embedded.Records = append(
+				paths.Embedded.Records,
+				renderPath(path, sourceAmount, destinationAmount),
+			)
+			break
+		}
+	}
+	hal.Render(w, paths)
+}
+
+// holdings returns the assets held by an account and their balances
+func (s *Server) holdings(accountID string) ([]xdr.Asset, map[string]int64, error) {
+	acc, ok := s.accounts[accountID]
+	if !ok {
+		return nil, nil, errors.New("account not found")
+	}
+	native := xdr.MustNewNativeAsset()
+	assets := []xdr.Asset{native}
+	balances := map[string]int64{native.String(): acc.availableBalance()}
+	for _, line := range acc.trustlines {
+		assets = append(assets, line.asset)
+		balances[line.asset.String()] = line.balance
+	}
+	return assets, balances, nil
+}
+
+// candidatePaths returns the paths from source to destination in order of
+// preference: a direct conversion and a conversion through lumens. The
+// returned paths include source and destination.
+func (s *Server) candidatePaths(source, destination xdr.Asset) [][]xdr.Asset {
+	paths := [][]xdr.Asset{{source, destination}}
+	native := xdr.MustNewNativeAsset()
+	if !source.Equals(native) && !destination.Equals(native) {
+		paths = append(paths, []xdr.Asset{source, native, destination})
+	}
+	return paths
+}
+
+func renderPath(path []xdr.Asset, sourceAmount, destinationAmount int64) hProtocol.Path {
+	result := hProtocol.Path{
+		SourceAmount:      amount.StringFromInt64(sourceAmount),
+		DestinationAmount: amount.StringFromInt64(destinationAmount),
+		Path:              []hProtocol.Asset{},
+	}
+	path[0].MustExtract(&result.SourceAssetType, &result.SourceAssetCode, &result.SourceAssetIssuer)
+	path[len(path)-1].MustExtract(
+		&result.DestinationAssetType, &result.DestinationAssetCode, &result.DestinationAssetIssuer,
+	)
+	for _, asset := range renderAssets(path[1 : len(path)-1]) {
+		result.Path = append(result.Path, hProtocol.Asset(asset))
+	}
+	return result
+}
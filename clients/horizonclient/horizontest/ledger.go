@@ -0,0 +1,522 @@
+package horizontest
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	// baseFee is the minimum fee per operation in stroops
+	baseFee = 100
+	// baseReserve is the base reserve in stroops
+	baseReserve = 5000000
+)
+
+// threshold levels of operations
+const (
+	lowThreshold = iota
+	mediumThreshold
+	highThreshold
+)
+
+type trustline struct {
+	asset   xdr.Asset
+	balance int64
+	limit   int64
+}
+
+type signer struct {
+	key    string
+	weight int32
+}
+
+// account is the state of an account in the fake ledger. Only ed25519
+// signers are supported.
+type account struct {
+	id           string
+	sequence     int64
+	balance      int64
+	trustlines   []trustline
+	signers      []signer
+	masterWeight int32
+	thresholds   [3]uint8
+	homeDomain   string
+	data         map[string][]byte
+	lastModified uint32
+}
+
+func newAccount(id string, balance int64, ledger uint32) *account {
+	return &account{
+		id:           id,
+		sequence:     int64(ledger) << 32,
+		balance:      balance,
+		masterWeight: 1,
+		data:         map[string][]byte{},
+		lastModified: ledger,
+	}
+}
+
+func (a *account) clone() *account {
+	c := *a
+	c.trustlines = append([]trustline{}, a.trustlines...)
+	c.signers = append([]signer{}, a.signers...)
+	c.data = make(map[string][]byte, len(a.data))
+	for name, value := range a.data {
+		c.data[name] = value
+	}
+	return &c
+}
+
+func (a *account) subentries() int32 {
+	return int32(len(a.trustlines) + len(a.signers) + len(a.data))
+}
+
+func (a *account) minBalance() int64 {
+	return int64(2+a.subentries()) * baseReserve
+}
+
+// availableBalance returns the amount of lumens the account can spend
+func (a *account) availableBalance() int64 {
+	return a.balance - a.minBalance()
+}
+
+func (a *account) trustline(asset xdr.Asset) *trustline {
+	for i := range a.trustlines {
+		if a.trustlines[i].asset.Equals(asset) {
+			return &a.trustlines[i]
+		}
+	}
+	return nil
+}
+
+// signatureWeight returns the total weight of the signers of the account
+// which signed hash
+func (a *account) signatureWeight(hash [32]byte, signatures []xdr.DecoratedSignature) int32 {
+	signers := append([]signer{{key: a.id, weight: a.masterWeight}}, a.signers...)
+	var weight int32
+	for _, s := range signers {
+		if s.weight == 0 {
+			continue
+		}
+		kp, err := keypair.ParseAddress(s.key)
+		if err != nil {
+			continue
+		}
+		hint := kp.Hint()
+		for _, signature := range signatures {
+			if [4]byte(signature.Hint) == hint && kp.Verify(hash[:], signature.Signature) == nil {
+				weight += s.weight
+				break
+			}
+		}
+	}
+	return weight
+}
+
+// authorized returns true if the signatures meet the given threshold level
+// of the account
+func (a *account) authorized(level int, hash [32]byte, signatures []xdr.DecoratedSignature) bool {
+	needed := int32(a.thresholds[level])
+	if needed == 0 {
+		needed = 1
+	}
+	return a.signatureWeight(hash, signatures) >= needed
+}
+
+// ledgerState contains all the accounts in the fake ledger
+type ledgerState map[string]*account
+
+func (s ledgerState) clone() ledgerState {
+	c := make(ledgerState, len(s))
+	for id, acc := range s {
+		c[id] = acc.clone()
+	}
+	return c
+}
+
+// exchangeRates contains the prices at which assets can be converted by path
+// payments. The fake ledger has no order books, instead every conversion with
+// a known rate has unlimited liquidity.
+type exchangeRates map[string]xdr.Price
+
+func rateKey(source, destination xdr.Asset) string {
+	return source.String() + ">" + destination.String()
+}
+
+// convert returns how much of destination can be bought with amount of
+// source
+func (r exchangeRates) convert(amount int64, source, destination xdr.Asset) (int64, bool) {
+	if source.Equals(destination) {
+		return amount, true
+	}
+	rate, ok := r[rateKey(source, destination)]
+	if !ok {
+		return 0, false
+	}
+	return mulFraction(amount, int64(rate.N), int64(rate.D), false)
+}
+
+// cost returns how much of source is needed to buy amount of destination
+func (r exchangeRates) cost(amount int64, source, destination xdr.Asset) (int64, bool) {
+	if source.Equals(destination) {
+		return amount, true
+	}
+	rate, ok := r[rateKey(source, destination)]
+	if !ok {
+		return 0, false
+	}
+	return mulFraction(amount, int64(rate.D), int64(rate.N), true)
+}
+
+// convertPath returns how much of the last asset of the path can be bought
+// with amount of the first asset
+func (r exchangeRates) convertPath(amount int64, path []xdr.Asset) (int64, bool) {
+	for i := 1; i < len(path); i++ {
+		var ok bool
+		if amount, ok = r.convert(amount, path[i-1], path[i]); !ok {
+			return 0, false
+		}
+	}
+	return amount, true
+}
+
+// costPath returns how much of the first asset of the path is needed to buy
+// amount of the last asset
+func (r exchangeRates) costPath(amount int64, path []xdr.Asset) (int64, bool) {
+	for i := len(path) - 1; i > 0; i-- {
+		var ok bool
+		if amount, ok = r.cost(amount, path[i-1], path[i]); !ok {
+			return 0, false
+		}
+	}
+	return amount, true
+}
+
+func mulFraction(x, n, d int64, roundUp bool) (int64, bool) {
+	if d == 0 {
+		return 0, false
+	}
+	result := new(big.Int).Mul(big.NewInt(x), big.NewInt(n))
+	remainder := new(big.Int)
+	result.QuoRem(result, big.NewInt(d), remainder)
+	if roundUp && remainder.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	if !result.IsInt64() {
+		return 0, false
+	}
+	return result.Int64(), true
+}
+
+// opThreshold returns the threshold level needed to authorize op
+func opThreshold(op xdr.Operation) int {
+	switch op.Body.Type {
+	case xdr.OperationTypeBumpSequence, xdr.OperationTypeAllowTrust:
+		return lowThreshold
+	case xdr.OperationTypeSetOptions:
+		setOptions := op.Body.MustSetOptionsOp()
+		if setOptions.MasterWeight != nil || setOptions.LowThreshold != nil ||
+			setOptions.MedThreshold != nil || setOptions.HighThreshold != nil ||
+			setOptions.Signer != nil {
+			return highThreshold
+		}
+	}
+	return mediumThreshold
+}
+
+// ledgerTx applies operations of a transaction to a copy of the ledger state
+type ledgerTx struct {
+	state  ledgerState
+	rates  exchangeRates
+	ledger uint32
+}
+
+func (tx *ledgerTx) debit(acc *account, asset xdr.Asset, amount int64) string {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		if acc.availableBalance() < amount {
+			return "op_underfunded"
+		}
+		acc.balance -= amount
+		return ""
+	}
+	if isIssuer(acc, asset) {
+		return ""
+	}
+	line := acc.trustline(asset)
+	if line == nil {
+		return "op_src_no_trust"
+	}
+	if line.balance < amount {
+		return "op_underfunded"
+	}
+	line.balance -= amount
+	return ""
+}
+
+func (tx *ledgerTx) credit(acc *account, asset xdr.Asset, amount int64) string {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		acc.balance += amount
+		return ""
+	}
+	if isIssuer(acc, asset) {
+		return ""
+	}
+	line := acc.trustline(asset)
+	if line == nil {
+		return "op_no_trust"
+	}
+	if line.limit-line.balance < amount {
+		return "op_line_full"
+	}
+	line.balance += amount
+	return ""
+}
+
+func isIssuer(acc *account, asset xdr.Asset) bool {
+	var typ, code, issuer string
+	asset.MustExtract(&typ, &code, &issuer)
+	return issuer == acc.id
+}
+
+// transfer moves amount of asset from source to destination
+func (tx *ledgerTx) transfer(source, destination *account, asset xdr.Asset, amount int64) string {
+	if amount <= 0 {
+		return "op_malformed"
+	}
+	if code := tx.debit(source, asset, amount); code != "" {
+		return code
+	}
+	return tx.credit(destination, asset, amount)
+}
+
+// apply applies op with the given source account and returns the result code
+func (tx *ledgerTx) apply(source *account, op xdr.Operation) string {
+	var code string
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		code = tx.createAccount(source, op.Body.MustCreateAccountOp())
+	case xdr.OperationTypePayment:
+		payment := op.Body.MustPaymentOp()
+		destination, ok := tx.state[accountAddress(payment.Destination)]
+		if !ok {
+			return "op_no_destination"
+		}
+		code = tx.transfer(source, destination, payment.Asset, int64(payment.Amount))
+	case xdr.OperationTypePathPaymentStrictReceive:
+		payment := op.Body.MustPathPaymentStrictReceiveOp()
+		path := append(append([]xdr.Asset{payment.SendAsset}, payment.Path...), payment.DestAsset)
+		sendAmount, ok := tx.rates.costPath(int64(payment.DestAmount), path)
+		if !ok {
+			return "op_too_few_offers"
+		}
+		if sendAmount > int64(payment.SendMax) {
+			return "op_over_source_max"
+		}
+		code = tx.pathPayment(source, payment.Destination, payment.SendAsset, sendAmount, payment.DestAsset, int64(payment.DestAmount))
+	case xdr.OperationTypePathPaymentStrictSend:
+		payment := op.Body.MustPathPaymentStrictSendOp()
+		path := append(append([]xdr.Asset{payment.SendAsset}, payment.Path...), payment.DestAsset)
+		destAmount, ok := tx.rates.convertPath(int64(payment.SendAmount), path)
+		if !ok {
+			return "op_too_few_offers"
+		}
+		if destAmount < int64(payment.DestMin) {
+			return "op_under_dest_min"
+		}
+		code = tx.pathPayment(source, payment.Destination, payment.SendAsset, int64(payment.SendAmount), payment.DestAsset, destAmount)
+	case xdr.OperationTypeChangeTrust:
+		code = tx.changeTrust(source, op.Body.MustChangeTrustOp())
+	case xdr.OperationTypeManageData:
+		code = tx.manageData(source, op.Body.MustManageDataOp())
+	case xdr.OperationTypeBumpSequence:
+		bumpTo := int64(op.Body.MustBumpSequenceOp().BumpTo)
+		if bumpTo < 0 {
+			return "op_bad_seq"
+		}
+		if bumpTo > source.sequence {
+			source.sequence = bumpTo
+		}
+	case xdr.OperationTypeSetOptions:
+		code = tx.setOptions(source, op.Body.MustSetOptionsOp())
+	case xdr.OperationTypeAccountMerge:
+		destinationID := accountAddress(op.Body.MustDestination())
+		destination, ok := tx.state[destinationID]
+		if !ok {
+			return "op_no_account"
+		}
+		if destinationID == source.id {
+			return "op_malformed"
+		}
+		if source.subentries() > 0 {
+			return "op_has_sub_entries"
+		}
+		destination.balance += source.balance
+		destination.lastModified = tx.ledger
+		delete(tx.state, source.id)
+		return "op_success"
+	default:
+		return "op_not_supported"
+	}
+
+	if code != "" {
+		return code
+	}
+	source.lastModified = tx.ledger
+	return "op_success"
+}
+
+func (tx *ledgerTx) createAccount(source *account, op xdr.CreateAccountOp) string {
+	destination := op.Destination.Address()
+	if _, ok := tx.state[destination]; ok {
+		return "op_already_exists"
+	}
+	created := newAccount(destination, 0, tx.ledger)
+	if int64(op.StartingBalance) < created.minBalance() {
+		return "op_low_reserve"
+	}
+	if source.availableBalance() < int64(op.StartingBalance) {
+		return "op_underfunded"
+	}
+	source.balance -= int64(op.StartingBalance)
+	created.balance = int64(op.StartingBalance)
+	tx.state[destination] = created
+	return ""
+}
+
+func (tx *ledgerTx) pathPayment(
+	source *account,
+	destinationAccount xdr.MuxedAccount,
+	sendAsset xdr.Asset,
+	sendAmount int64,
+	destAsset xdr.Asset,
+	destAmount int64,
+) string {
+	destination, ok := tx.state[accountAddress(destinationAccount)]
+	if !ok {
+		return "op_no_destination"
+	}
+	if sendAmount <= 0 || destAmount <= 0 {
+		return "op_malformed"
+	}
+	if code := tx.debit(source, sendAsset, sendAmount); code != "" {
+		return code
+	}
+	code := tx.credit(destination, destAsset, destAmount)
+	destination.lastModified = tx.ledger
+	return code
+}
+
+func (tx *ledgerTx) changeTrust(source *account, op xdr.ChangeTrustOp) string {
+	if op.Line.Type == xdr.AssetTypeAssetTypeNative || isIssuer(source, op.Line) || op.Limit < 0 {
+		return "op_malformed"
+	}
+	var typ, code, issuer string
+	op.Line.MustExtract(&typ, &code, &issuer)
+	if _, ok := tx.state[issuer]; !ok {
+		return "op_no_issuer"
+	}
+
+	line := source.trustline(op.Line)
+	switch {
+	case line == nil && op.Limit == 0:
+		return "op_invalid_limit"
+	case line == nil:
+		source.trustlines = append(source.trustlines, trustline{asset: op.Line, limit: int64(op.Limit)})
+		if source.balance < source.minBalance() {
+			return "op_low_reserve"
+		}
+	case op.Limit == 0:
+		if line.balance > 0 {
+			return "op_invalid_limit"
+		}
+		for i := range source.trustlines {
+			if source.trustlines[i].asset.Equals(op.Line) {
+				source.trustlines = append(source.trustlines[:i], source.trustlines[i+1:]...)
+				break
+			}
+		}
+	default:
+		if int64(op.Limit) < line.balance {
+			return "op_invalid_limit"
+		}
+		line.limit = int64(op.Limit)
+	}
+	return ""
+}
+
+func (tx *ledgerTx) manageData(source *account, op xdr.ManageDataOp) string {
+	name := string(op.DataName)
+	if op.DataValue == nil {
+		if _, ok := source.data[name]; !ok {
+			return "op_data_name_not_found"
+		}
+		delete(source.data, name)
+		return ""
+	}
+
+	_, exists := source.data[name]
+	source.data[name] = append([]byte{}, (*op.DataValue)...)
+	if !exists && source.balance < source.minBalance() {
+		return "op_low_reserve"
+	}
+	return ""
+}
+
+func (tx *ledgerTx) setOptions(source *account, op xdr.SetOptionsOp) string {
+	if op.MasterWeight != nil {
+		source.masterWeight = int32(*op.MasterWeight)
+	}
+	for level, value := range []*xdr.Uint32{op.LowThreshold, op.MedThreshold, op.HighThreshold} {
+		if value != nil {
+			if *value > 255 {
+				return "op_malformed"
+			}
+			source.thresholds[level] = uint8(*value)
+		}
+	}
+	if op.HomeDomain != nil {
+		source.homeDomain = string(*op.HomeDomain)
+	}
+	if op.Signer == nil {
+		return ""
+	}
+
+	if op.Signer.Key.Type != xdr.SignerKeyTypeSignerKeyTypeEd25519 {
+		return "op_not_supported"
+	}
+	key := op.Signer.Key.Address()
+	if key == source.id {
+		return "op_bad_signer"
+	}
+	for i, s := range source.signers {
+		if s.key != key {
+			continue
+		}
+		if op.Signer.Weight == 0 {
+			source.signers = append(source.signers[:i], source.signers[i+1:]...)
+		} else {
+			source.signers[i].weight = int32(op.Signer.Weight)
+		}
+		return ""
+	}
+	if op.Signer.Weight == 0 {
+		return ""
+	}
+	source.signers = append(source.signers, signer{key: key, weight: int32(op.Signer.Weight)})
+	sort.Slice(source.signers, func(i, j int) bool {
+		return source.signers[i].key < source.signers[j].key
+	})
+	if source.balance < source.minBalance() {
+		return "op_low_reserve"
+	}
+	return ""
+}
+
+// accountAddress returns the address of the account underlying m
+func accountAddress(m xdr.MuxedAccount) string {
+	id := m.ToAccountId()
+	return id.Address()
+}
@@ -0,0 +1,693 @@
+// Package horizontest provides a fake Horizon server for end-to-end tests of
+// applications using horizonclient and txnbuild.
+//
+// The server runs in-process and keeps the state of the ledger in memory.
+// Transactions submitted to it are validated and applied immediately, each
+// one in its own ledger. The following operations are supported: create
+// account, payment, path payments, change trust, manage data, bump sequence,
+// set options and account merge. Other operations fail with
+// op_not_supported. There are no order books, path payments convert assets
+// using the rates set with SetExchangeRate instead.
+//
+// The server serves the root, accounts, transactions, operations, payments,
+// paths, fee stats and friendbot endpoints. Transactions, operations and
+// payments can be streamed.
+package horizontest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/price"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+// rootBalance is the balance of the root account of the fake network in
+// stroops (100 billion lumens)
+const rootBalance = 100000000000 * 10000000
+
+// FriendbotAmount is the amount of lumens given to accounts funded with
+// friendbot
+const FriendbotAmount = "10000"
+
+type transactionRecord struct {
+	id           int64
+	transaction  hProtocol.Transaction
+	participants map[string]bool
+}
+
+type operationRecord struct {
+	id           int64
+	operation    operations.Operation
+	payment      bool
+	participants map[string]bool
+}
+
+// Server is a fake Horizon server
+type Server struct {
+	networkPassphrase string
+	root              *keypair.Full
+	httpServer        *httptest.Server
+	problems          *problem.Problem
+	// fundLock serializes the transactions sent by the root account
+	fundLock sync.Mutex
+
+	lock         sync.Mutex
+	ledger       uint32
+	closedAt     time.Time
+	accounts     ledgerState
+	rates        exchangeRates
+	transactions []transactionRecord
+	operations   []operationRecord
+	// changed is closed and replaced when a new ledger is closed
+	changed chan struct{}
+	closed  chan struct{}
+}
+
+// NewServer starts a fake Horizon server for the network with the given
+// passphrase. The root account of the network holds all the lumens. The
+// server must be closed with Close.
+func NewServer(networkPassphrase string) *Server {
+	root := keypair.Master(networkPassphrase).(*keypair.Full)
+	s := &Server{
+		networkPassphrase: networkPassphrase,
+		root:              root,
+		problems:          problem.New("https://stellar.org/horizon-errors/", log.DefaultLogger, problem.LogNoErrors),
+		ledger:            1,
+		closedAt:          time.Now().UTC().Truncate(time.Second),
+		accounts:          ledgerState{},
+		rates:             exchangeRates{},
+		changed:           make(chan struct{}),
+		closed:            make(chan struct{}),
+	}
+	s.accounts[root.Address()] = newAccount(root.Address(), rootBalance, 0)
+	s.httpServer = httptest.NewServer(s.router())
+	return s
+}
+
+// URL returns the URL of the server
+func (s *Server) URL() string {
+	return s.httpServer.URL + "/"
+}
+
+// Client returns a horizonclient.Client connected to the server. The client
+// can fund accounts using friendbot.
+func (s *Server) Client() *horizonclient.Client {
+	client := *horizonclient.DefaultTestNetClient
+	client.HorizonURL = s.URL()
+	client.HTTP = s.httpServer.Client()
+	return &client
+}
+
+// NetworkPassphrase returns the passphrase of the network of the server
+func (s *Server) NetworkPassphrase() string {
+	return s.networkPassphrase
+}
+
+// Root returns the keypair of the root account of the network
+func (s *Server) Root() *keypair.Full {
+	return s.root
+}
+
+// Close stops the server, it blocks until all the requests are finished
+func (s *Server) Close() {
+	close(s.closed)
+	s.httpServer.Close()
+}
+
+// Fund creates the account with the given address and starting balance (in
+// lumens) using a transaction sent by the root account
+func (s *Server) Fund(address string, startingBalance string) (hProtocol.Transaction, error) {
+	s.fundLock.Lock()
+	defer s.fundLock.Unlock()
+
+	s.lock.Lock()
+	sequence := s.accounts[s.root.Address()].sequence
+	s.lock.Unlock()
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: s.root.Address(), Sequence: sequence},
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{&txnbuild.CreateAccount{
+			Destination: address,
+			Amount:      startingBalance,
+		}},
+		BaseFee:    baseFee,
+		Timebounds: txnbuild.NewInfiniteTimeout(),
+	})
+	if err != nil {
+		return hProtocol.Transaction{}, errors.Wrap(err, "could not build transaction")
+	}
+	if tx, err = tx.Sign(s.networkPassphrase, s.root); err != nil {
+		return hProtocol.Transaction{}, errors.Wrap(err, "could not sign transaction")
+	}
+	envelope, err := tx.Base64()
+	if err != nil {
+		return hProtocol.Transaction{}, errors.Wrap(err, "could not encode transaction")
+	}
+	return s.Submit(envelope)
+}
+
+// SetExchangeRate allows path payments to convert source to destination at
+// the given price (the amount of destination received for one unit of
+// source) and destination to source at the inverse price. Conversions have
+// unlimited liquidity.
+func (s *Server) SetExchangeRate(source, destination txnbuild.Asset, rate string) error {
+	sourceXDR, err := source.ToXDR()
+	if err != nil {
+		return errors.Wrap(err, "invalid source asset")
+	}
+	destinationXDR, err := destination.ToXDR()
+	if err != nil {
+		return errors.Wrap(err, "invalid destination asset")
+	}
+	p, err := price.Parse(rate)
+	if err != nil {
+		return errors.Wrap(err, "invalid rate")
+	}
+	if p.N <= 0 || p.D <= 0 {
+		return errors.New("rate must be positive")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rates[rateKey(sourceXDR, destinationXDR)] = p
+	s.rates[rateKey(destinationXDR, sourceXDR)] = xdr.Price{N: p.D, D: p.N}
+	return nil
+}
+
+// Submit validates and applies the transaction. The transaction is returned
+// as it would be by Horizon. Transactions which were rejected or failed are
+// reported with a problem.P error with the same extras as Horizon would
+// return.
+func (s *Server) Submit(envelopeXDR string) (hProtocol.Transaction, error) {
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXDR, &envelope); err != nil {
+		return hProtocol.Transaction{}, malformedTransaction(envelopeXDR)
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, s.networkPassphrase)
+	if err != nil {
+		return hProtocol.Transaction{}, malformedTransaction(envelopeXDR)
+	}
+	innerHash := hash
+	if envelope.IsFeeBump() {
+		innerHash, err = network.HashTransaction(envelope.FeeBump.Tx.InnerTx.V1.Tx, s.networkPassphrase)
+		if err != nil {
+			return hProtocol.Transaction{}, malformedTransaction(envelopeXDR)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ops := envelope.Operations()
+	sourceID := accountAddress(envelope.SourceAccount())
+	feeSourceID := sourceID
+	maxFee := int64(envelope.Fee())
+	feeCharged := int64(baseFee * len(ops))
+	if envelope.IsFeeBump() {
+		feeSourceID = accountAddress(envelope.FeeBumpAccount())
+		maxFee = envelope.FeeBumpFee()
+		feeCharged = int64(baseFee * (len(ops) + 1))
+	}
+	rejected := func(code string, inner bool) error {
+		return transactionFailed(envelopeXDR, innerHash, code, nil, inner, 0)
+	}
+
+	if len(ops) == 0 {
+		return hProtocol.Transaction{}, rejected("tx_missing_operation", envelope.IsFeeBump())
+	}
+	feeSource, ok := s.accounts[feeSourceID]
+	if !ok {
+		return hProtocol.Transaction{}, rejected("tx_no_source_account", false)
+	}
+	source, ok := s.accounts[sourceID]
+	if !ok {
+		return hProtocol.Transaction{}, rejected("tx_no_source_account", envelope.IsFeeBump())
+	}
+	if maxFee < feeCharged {
+		return hProtocol.Transaction{}, rejected("tx_insufficient_fee", false)
+	}
+	if envelope.IsFeeBump() && !feeSource.authorized(lowThreshold, hash, envelope.FeeBumpSignatures()) {
+		return hProtocol.Transaction{}, rejected("tx_bad_auth", false)
+	}
+	if feeSource.availableBalance() < feeCharged {
+		return hProtocol.Transaction{}, rejected("tx_insufficient_balance", false)
+	}
+	if timeBounds := envelope.TimeBounds(); timeBounds != nil {
+		now := time.Now().Unix()
+		if timeBounds.MinTime > 0 && now < int64(timeBounds.MinTime) {
+			return hProtocol.Transaction{}, rejected("tx_too_early", envelope.IsFeeBump())
+		}
+		if timeBounds.MaxTime > 0 && now > int64(timeBounds.MaxTime) {
+			return hProtocol.Transaction{}, rejected("tx_too_late", envelope.IsFeeBump())
+		}
+	}
+	if envelope.SeqNum() != source.sequence+1 {
+		return hProtocol.Transaction{}, rejected("tx_bad_seq", envelope.IsFeeBump())
+	}
+	if !source.authorized(lowThreshold, innerHash, envelope.Signatures()) {
+		return hProtocol.Transaction{}, rejected("tx_bad_auth", envelope.IsFeeBump())
+	}
+
+	// the transaction is valid, it's included in a new ledger even if its
+	// operations fail
+	s.ledger++
+	s.closedAt = time.Now().UTC().Truncate(time.Second)
+	feeSource.balance -= feeCharged
+	feeSource.lastModified = s.ledger
+	source.sequence = envelope.SeqNum()
+	source.lastModified = s.ledger
+
+	applied := s.accounts.clone()
+	ltx := &ledgerTx{state: applied, rates: s.rates, ledger: s.ledger}
+	opCodes := make([]string, len(ops))
+	successful := true
+	for i, op := range ops {
+		opSourceID := sourceID
+		if op.SourceAccount != nil {
+			opSourceID = accountAddress(*op.SourceAccount)
+		}
+		// signatures are checked using the state before the transaction
+		signers, ok := s.accounts[opSourceID]
+		if !ok {
+			signers = applied[opSourceID]
+		}
+		opSource, ok := applied[opSourceID]
+		switch {
+		case !ok:
+			opCodes[i] = "op_no_source_account"
+		case !signers.authorized(opThreshold(op), innerHash, envelope.Signatures()):
+			opCodes[i] = "op_bad_auth"
+		default:
+			opCodes[i] = ltx.apply(opSource, op)
+		}
+		successful = successful && opCodes[i] == "op_success"
+	}
+	if successful {
+		s.accounts = applied
+	}
+
+	tx := s.recordTransaction(envelopeXDR, envelope, hash, innerHash, successful, feeCharged, maxFee)
+	close(s.changed)
+	s.changed = make(chan struct{})
+
+	if !successful {
+		return tx, transactionFailed(
+			envelopeXDR, innerHash, "tx_failed", opCodes, envelope.IsFeeBump(), feeCharged,
+		)
+	}
+	return tx, nil
+}
+
+// recordTransaction adds the transaction and its operations to the history
+func (s *Server) recordTransaction(
+	envelopeXDR string,
+	envelope xdr.TransactionEnvelope,
+	hash, innerHash [32]byte,
+	successful bool,
+	feeCharged, maxFee int64,
+) hProtocol.Transaction {
+	id := toid(s.ledger, 1, 0)
+	hexHash := hex.EncodeToString(hash[:])
+	sourceID := accountAddress(envelope.SourceAccount())
+	resultCode := "tx_success"
+	if !successful {
+		resultCode = "tx_failed"
+	}
+	resultXDR, _ := xdr.MarshalBase64(transactionResult(
+		resultCode, envelope.IsFeeBump(), innerHash, feeCharged,
+	))
+
+	tx := hProtocol.Transaction{
+		ID:              hexHash,
+		PT:              strconv.FormatInt(id, 10),
+		Successful:      successful,
+		Hash:            hexHash,
+		Ledger:          int32(s.ledger),
+		LedgerCloseTime: s.closedAt,
+		Account:         sourceID,
+		AccountSequence: strconv.FormatInt(envelope.SeqNum(), 10),
+		FeeAccount:      sourceID,
+		FeeCharged:      feeCharged,
+		MaxFee:          maxFee,
+		OperationCount:  int32(len(envelope.Operations())),
+		EnvelopeXdr:     envelopeXDR,
+		ResultXdr:       resultXDR,
+		Signatures:      signatures(envelope.Signatures()),
+	}
+	tx.MemoType, tx.Memo, tx.MemoBytes = memo(envelope.Memo())
+	if timeBounds := envelope.TimeBounds(); timeBounds != nil {
+		tx.ValidAfter = time.Unix(int64(timeBounds.MinTime), 0).UTC().Format(time.RFC3339)
+		if timeBounds.MaxTime > 0 {
+			tx.ValidBefore = time.Unix(int64(timeBounds.MaxTime), 0).UTC().Format(time.RFC3339)
+		}
+	}
+	if envelope.IsFeeBump() {
+		tx.FeeAccount = accountAddress(envelope.FeeBumpAccount())
+		tx.Signatures = signatures(envelope.FeeBumpSignatures())
+		tx.FeeBumpTransaction = &hProtocol.FeeBumpTransaction{
+			Hash:       hexHash,
+			Signatures: tx.Signatures,
+		}
+		tx.InnerTransaction = &hProtocol.InnerTransaction{
+			Hash:       hex.EncodeToString(innerHash[:]),
+			Signatures: signatures(envelope.Signatures()),
+			MaxFee:     int64(envelope.Fee()),
+		}
+	}
+	tx.Links.Self = hal.NewLink(s.URL() + "transactions/" + hexHash)
+	tx.Links.Transaction = tx.Links.Self
+	tx.Links.Account = hal.NewLink(s.URL() + "accounts/" + sourceID)
+	tx.Links.Ledger = hal.NewLink(s.URL() + "ledgers/" + strconv.FormatUint(uint64(s.ledger), 10))
+	tx.Links.Operations = hal.NewLink(s.URL() + "transactions/" + hexHash + "/operations")
+
+	participants := map[string]bool{sourceID: true, tx.FeeAccount: true}
+	for i, op := range envelope.Operations() {
+		record := s.operationRecord(toid(s.ledger, 1, int32(i+1)), tx, op)
+		for account := range record.participants {
+			participants[account] = true
+		}
+		s.operations = append(s.operations, record)
+	}
+	s.transactions = append(s.transactions, transactionRecord{
+		id:           id,
+		transaction:  tx,
+		participants: participants,
+	})
+	return tx
+}
+
+// operationRecord renders op as it would be rendered by Horizon
+func (s *Server) operationRecord(id int64, tx hProtocol.Transaction, op xdr.Operation) operationRecord {
+	sourceID := tx.Account
+	if op.SourceAccount != nil {
+		sourceID = accountAddress(*op.SourceAccount)
+	}
+	base := operations.Base{
+		ID:                    strconv.FormatInt(id, 10),
+		PT:                    strconv.FormatInt(id, 10),
+		TransactionSuccessful: tx.Successful,
+		SourceAccount:         sourceID,
+		Type:                  operations.TypeNames[op.Body.Type],
+		TypeI:                 int32(op.Body.Type),
+		LedgerCloseTime:       tx.LedgerCloseTime,
+		TransactionHash:       tx.Hash,
+	}
+	base.Links.Self = hal.NewLink(s.URL() + "operations/" + base.ID)
+	base.Links.Transaction = hal.NewLink(s.URL() + "transactions/" + tx.Hash)
+
+	record := operationRecord{id: id, participants: map[string]bool{sourceID: true}}
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		body := op.Body.MustCreateAccountOp()
+		record.operation = operations.CreateAccount{
+			Base:            base,
+			StartingBalance: amount.String(body.StartingBalance),
+			Funder:          sourceID,
+			Account:         body.Destination.Address(),
+		}
+		record.payment = true
+		record.participants[body.Destination.Address()] = true
+	case xdr.OperationTypePayment:
+		body := op.Body.MustPaymentOp()
+		destination := accountAddress(body.Destination)
+		payment := operations.Payment{
+			Base:   base,
+			From:   sourceID,
+			To:     destination,
+			Amount: amount.String(body.Amount),
+		}
+		body.Asset.MustExtract(&payment.Asset.Type, &payment.Asset.Code, &payment.Asset.Issuer)
+		record.operation = payment
+		record.payment = true
+		record.participants[destination] = true
+	case xdr.OperationTypePathPaymentStrictReceive:
+		body := op.Body.MustPathPaymentStrictReceiveOp()
+		destination := accountAddress(body.Destination)
+		payment := operations.PathPayment{
+			Payment: operations.Payment{
+				Base:   base,
+				From:   sourceID,
+				To:     destination,
+				Amount: amount.String(body.DestAmount),
+			},
+			Path:      renderAssets(body.Path),
+			SourceMax: amount.String(body.SendMax),
+		}
+		if sourceAmount, ok := s.rates.costPath(int64(body.DestAmount), fullPath(body.SendAsset, body.Path, body.DestAsset)); ok {
+			payment.SourceAmount = amount.StringFromInt64(sourceAmount)
+		}
+		body.DestAsset.MustExtract(&payment.Asset.Type, &payment.Asset.Code, &payment.Asset.Issuer)
+		body.SendAsset.MustExtract(&payment.SourceAssetType, &payment.SourceAssetCode, &payment.SourceAssetIssuer)
+		record.operation = payment
+		record.payment = true
+		record.participants[destination] = true
+	case xdr.OperationTypePathPaymentStrictSend:
+		body := op.Body.MustPathPaymentStrictSendOp()
+		destination := accountAddress(body.Destination)
+		payment := operations.PathPaymentStrictSend{
+			Payment: operations.Payment{
+				Base: base,
+				From: sourceID,
+				To:   destination,
+			},
+			Path:           renderAssets(body.Path),
+			SourceAmount:   amount.String(body.SendAmount),
+			DestinationMin: amount.String(body.DestMin),
+		}
+		if destAmount, ok := s.rates.convertPath(int64(body.SendAmount), fullPath(body.SendAsset, body.Path, body.DestAsset)); ok {
+			payment.Amount = amount.StringFromInt64(destAmount)
+		}
+		body.DestAsset.MustExtract(&payment.Asset.Type, &payment.Asset.Code, &payment.Asset.Issuer)
+		body.SendAsset.MustExtract(&payment.SourceAssetType, &payment.SourceAssetCode, &payment.SourceAssetIssuer)
+		record.operation = payment
+		record.payment = true
+		record.participants[destination] = true
+	case xdr.OperationTypeChangeTrust:
+		body := op.Body.MustChangeTrustOp()
+		changeTrust := operations.ChangeTrust{
+			Base:    base,
+			Limit:   amount.String(body.Limit),
+			Trustor: sourceID,
+		}
+		body.Line.MustExtract(&changeTrust.Asset.Type, &changeTrust.Asset.Code, &changeTrust.Asset.Issuer)
+		changeTrust.Trustee = changeTrust.Asset.Issuer
+		record.operation = changeTrust
+	case xdr.OperationTypeManageData:
+		body := op.Body.MustManageDataOp()
+		manageData := operations.ManageData{Base: base, Name: string(body.DataName)}
+		if body.DataValue != nil {
+			manageData.Value = base64.StdEncoding.EncodeToString(*body.DataValue)
+		}
+		record.operation = manageData
+	case xdr.OperationTypeBumpSequence:
+		record.operation = operations.BumpSequence{
+			Base:   base,
+			BumpTo: strconv.FormatInt(int64(op.Body.MustBumpSequenceOp().BumpTo), 10),
+		}
+	case xdr.OperationTypeSetOptions:
+		body := op.Body.MustSetOptionsOp()
+		setOptions := operations.SetOptions{Base: base}
+		if body.HomeDomain != nil {
+			setOptions.HomeDomain = string(*body.HomeDomain)
+		}
+		if body.MasterWeight != nil {
+			weight := int(*body.MasterWeight)
+			setOptions.MasterKeyWeight = &weight
+		}
+		if body.LowThreshold != nil {
+			threshold := int(*body.LowThreshold)
+			setOptions.LowThreshold = &threshold
+		}
+		if body.MedThreshold != nil {
+			threshold := int(*body.MedThreshold)
+			setOptions.MedThreshold = &threshold
+		}
+		if body.HighThreshold != nil {
+			threshold := int(*body.HighThreshold)
+			setOptions.HighThreshold = &threshold
+		}
+		if body.Signer != nil {
+			weight := int(body.Signer.Weight)
+			setOptions.SignerKey = body.Signer.Key.Address()
+			setOptions.SignerWeight = &weight
+		}
+		record.operation = setOptions
+	case xdr.OperationTypeAccountMerge:
+		destination := accountAddress(op.Body.MustDestination())
+		record.operation = operations.AccountMerge{Base: base, Account: sourceID, Into: destination}
+		record.payment = true
+		record.participants[destination] = true
+	default:
+		record.operation = base
+	}
+	return record
+}
+
+// toid returns the id of an operation in the history as defined in
+// https://github.com/stellar/stellar-protocol/blob/master/core/cap-0015.md
+func toid(ledger uint32, transaction, operation int32) int64 {
+	return int64(ledger)<<32 | int64(transaction)<<12 | int64(operation)
+}
+
+func fullPath(source xdr.Asset, path []xdr.Asset, destination xdr.Asset) []xdr.Asset {
+	return append(append([]xdr.Asset{source}, path...), destination)
+}
+
+func renderAssets(assets []xdr.Asset) []base.Asset {
+	result := make([]base.Asset, len(assets))
+	for i, asset := range assets {
+		asset.MustExtract(&result[i].Type, &result[i].Code, &result[i].Issuer)
+	}
+	return result
+}
+
+func signatures(decorated []xdr.DecoratedSignature) []string {
+	result := make([]string, len(decorated))
+	for i, signature := range decorated {
+		result[i] = base64.StdEncoding.EncodeToString(signature.Signature)
+	}
+	return result
+}
+
+// memo returns the memo type, the memo and the memo bytes as rendered by
+// Horizon
+func memo(m xdr.Memo) (string, string, string) {
+	switch m.Type {
+	case xdr.MemoTypeMemoText:
+		text := m.MustText()
+		return "text", text, base64.StdEncoding.EncodeToString([]byte(text))
+	case xdr.MemoTypeMemoId:
+		return "id", strconv.FormatUint(uint64(m.MustId()), 10), ""
+	case xdr.MemoTypeMemoHash:
+		hash := m.MustHash()
+		return "hash", base64.StdEncoding.EncodeToString(hash[:]), ""
+	case xdr.MemoTypeMemoReturn:
+		hash := m.MustRetHash()
+		return "return", base64.StdEncoding.EncodeToString(hash[:]), ""
+	default:
+		return "none", "", ""
+	}
+}
+
+var transactionResultCodes = map[string]xdr.TransactionResultCode{
+	"tx_success":              xdr.TransactionResultCodeTxSuccess,
+	"tx_failed":               xdr.TransactionResultCodeTxFailed,
+	"tx_too_early":            xdr.TransactionResultCodeTxTooEarly,
+	"tx_too_late":             xdr.TransactionResultCodeTxTooLate,
+	"tx_missing_operation":    xdr.TransactionResultCodeTxMissingOperation,
+	"tx_bad_seq":              xdr.TransactionResultCodeTxBadSeq,
+	"tx_bad_auth":             xdr.TransactionResultCodeTxBadAuth,
+	"tx_insufficient_balance": xdr.TransactionResultCodeTxInsufficientBalance,
+	"tx_no_source_account":    xdr.TransactionResultCodeTxNoAccount,
+	"tx_insufficient_fee":     xdr.TransactionResultCodeTxInsufficientFee,
+}
+
+// transactionResult builds the result of a transaction. The results of
+// operations are not included, use the result codes instead.
+func transactionResult(code string, feeBump bool, innerHash [32]byte, feeCharged int64) xdr.TransactionResult {
+	xdrCode := transactionResultCodes[code]
+	var results *[]xdr.OperationResult
+	if xdrCode == xdr.TransactionResultCodeTxSuccess || xdrCode == xdr.TransactionResultCodeTxFailed {
+		results = &[]xdr.OperationResult{}
+	}
+	if !feeBump {
+		return xdr.TransactionResult{
+			FeeCharged: xdr.Int64(feeCharged),
+			Result:     xdr.TransactionResultResult{Code: xdrCode, Results: results},
+		}
+	}
+
+	innerFeeCharged := feeCharged - baseFee
+	if innerFeeCharged < 0 {
+		innerFeeCharged = 0
+	}
+	outerCode := xdr.TransactionResultCodeTxFeeBumpInnerFailed
+	if xdrCode == xdr.TransactionResultCodeTxSuccess {
+		outerCode = xdr.TransactionResultCodeTxFeeBumpInnerSuccess
+	}
+	return xdr.TransactionResult{
+		FeeCharged: xdr.Int64(feeCharged),
+		Result: xdr.TransactionResultResult{
+			Code: outerCode,
+			InnerResultPair: &xdr.InnerTransactionResultPair{
+				TransactionHash: xdr.Hash(innerHash),
+				Result: xdr.InnerTransactionResult{
+					FeeCharged: xdr.Int64(innerFeeCharged),
+					Result:     xdr.InnerTransactionResultResult{Code: xdrCode, Results: results},
+				},
+			},
+		},
+	}
+}
+
+func malformedTransaction(envelopeXDR string) problem.P {
+	return problem.P{
+		Type:   "transaction_malformed",
+		Title:  "Transaction Malformed",
+		Status: http.StatusBadRequest,
+		Detail: "Horizon could not decode the transaction envelope in this " +
+			"request. A transaction should be an XDR TransactionEnvelope struct " +
+			"encoded using base64.",
+		Extras: map[string]interface{}{
+			"envelope_xdr": envelopeXDR,
+		},
+	}
+}
+
+// transactionFailed returns the problem reported by Horizon when a
+// transaction was rejected or failed. inner is true if code is the result of
+// the inner transaction of a fee bump transaction.
+func transactionFailed(
+	envelopeXDR string,
+	innerHash [32]byte,
+	code string,
+	opCodes []string,
+	inner bool,
+	feeCharged int64,
+) problem.P {
+	resultXDR, _ := xdr.MarshalBase64(transactionResult(
+		code, inner, innerHash, feeCharged,
+	))
+	resultCodes := map[string]interface{}{"transaction": code}
+	if inner {
+		resultCodes["transaction"] = "tx_fee_bump_inner_failed"
+		resultCodes["inner_transaction"] = code
+	}
+	if len(opCodes) > 0 {
+		resultCodes["operations"] = opCodes
+	}
+
+	return problem.P{
+		Type:   "transaction_failed",
+		Title:  "Transaction Failed",
+		Status: http.StatusBadRequest,
+		Detail: "The transaction failed when submitted to the stellar network. " +
+			"The `extras.result_codes` field on this response contains further " +
+			"details.  Descriptions of each code can be found at: " +
+			"https://www.stellar.org/developers/guides/concepts/list-of-operations.html",
+		Extras: map[string]interface{}{
+			"envelope_xdr": envelopeXDR,
+			"result_xdr":   resultXDR,
+			"result_codes": resultCodes,
+		},
+	}
+}
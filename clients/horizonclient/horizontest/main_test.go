@@ -0,0 +1,320 @@
+package horizontest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func submit(
+	t *testing.T,
+	client *horizonclient.Client,
+	source *keypair.Full,
+	ops ...txnbuild.Operation,
+) (hProtocol.Transaction, error) {
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: source.Address()})
+	require.NoError(t, err)
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &account,
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewTimeout(300),
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	return client.SubmitTransaction(tx)
+}
+
+func nativeBalance(t *testing.T, client *horizonclient.Client, address string) string {
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: address})
+	require.NoError(t, err)
+	balance, err := account.GetNativeBalance()
+	require.NoError(t, err)
+	return balance
+}
+
+func TestFundAndPay(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	alice := keypair.MustRandom()
+	bob := keypair.MustRandom()
+	_, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+	assert.True(t, horizonclient.IsNotFoundError(err))
+
+	_, err = client.Fund(alice.Address())
+	require.NoError(t, err)
+	_, err = server.Fund(bob.Address(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, "10000.0000000", nativeBalance(t, client, alice.Address()))
+
+	tx, err := submit(t, client, alice, &txnbuild.Payment{
+		Destination: bob.Address(),
+		Amount:      "25",
+		Asset:       txnbuild.NativeAsset{},
+	})
+	require.NoError(t, err)
+	assert.True(t, tx.Successful)
+	assert.Equal(t, int64(100), tx.FeeCharged)
+	assert.Equal(t, "9974.9999900", nativeBalance(t, client, alice.Address()))
+	assert.Equal(t, "125.0000000", nativeBalance(t, client, bob.Address()))
+
+	fetched, err := client.TransactionDetail(tx.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, tx.EnvelopeXdr, fetched.EnvelopeXdr)
+
+	payments, err := client.Payments(horizonclient.OperationRequest{ForAccount: bob.Address()})
+	require.NoError(t, err)
+	require.Len(t, payments.Embedded.Records, 2)
+	assert.IsType(t, operations.CreateAccount{}, payments.Embedded.Records[0])
+	payment := payments.Embedded.Records[1].(operations.Payment)
+	assert.Equal(t, alice.Address(), payment.From)
+	assert.Equal(t, "25.0000000", payment.Amount)
+}
+
+func TestFailedTransactions(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	alice := keypair.MustRandom()
+	_, err := server.Fund(alice.Address(), "10")
+	require.NoError(t, err)
+
+	// bob doesn't exist
+	bob := keypair.MustRandom()
+	_, err = submit(t, client, alice, &txnbuild.Payment{
+		Destination: bob.Address(),
+		Amount:      "1",
+		Asset:       txnbuild.NativeAsset{},
+	})
+	hError := horizonclient.GetError(err)
+	require.NotNil(t, hError)
+	codes, err := hError.ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", codes.TransactionCode)
+	assert.Equal(t, []string{"op_no_destination"}, codes.OperationCodes)
+	// the fee was charged and the sequence number was consumed
+	assert.Equal(t, "9.9999900", nativeBalance(t, client, alice.Address()))
+
+	// the reserve can't be spent
+	_, err = submit(t, client, alice, &txnbuild.CreateAccount{
+		Destination: bob.Address(),
+		Amount:      "9",
+	})
+	codes, err = horizonclient.GetError(err).ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_underfunded"}, codes.OperationCodes)
+
+	// transactions signed by the wrong key are rejected
+	_, err = submit(t, client, alice, &txnbuild.BumpSequence{BumpTo: 0, SourceAccount: bob.Address()})
+	codes, err = horizonclient.GetError(err).ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_no_source_account"}, codes.OperationCodes)
+
+	transactions, err := client.Transactions(horizonclient.TransactionRequest{ForAccount: alice.Address()})
+	require.NoError(t, err)
+	assert.Len(t, transactions.Embedded.Records, 1)
+	transactions, err = client.Transactions(horizonclient.TransactionRequest{
+		ForAccount:    alice.Address(),
+		IncludeFailed: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, transactions.Embedded.Records, 4)
+	assert.False(t, transactions.Embedded.Records[1].Successful)
+
+	_, err = client.SubmitTransactionXDR("AAAA")
+	hError = horizonclient.GetError(err)
+	require.NotNil(t, hError)
+	assert.Equal(t, "Transaction Malformed", hError.Problem.Title)
+}
+
+func TestPaging(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	var hashes []string
+	for i := 0; i < 5; i++ {
+		tx, err := server.Fund(keypair.MustRandom().Address(), "1")
+		require.NoError(t, err)
+		hashes = append(hashes, tx.Hash)
+	}
+
+	page, err := client.Transactions(horizonclient.TransactionRequest{Limit: 2})
+	require.NoError(t, err)
+	var fetched []string
+	for len(page.Embedded.Records) > 0 {
+		for _, tx := range page.Embedded.Records {
+			fetched = append(fetched, tx.Hash)
+		}
+		page, err = client.NextTransactionsPage(page)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, hashes, fetched)
+
+	page, err = client.Transactions(horizonclient.TransactionRequest{Order: horizonclient.OrderDesc, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Embedded.Records, 1)
+	assert.Equal(t, hashes[4], page.Embedded.Records[0].Hash)
+}
+
+func TestPathPayments(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	issuer := keypair.MustRandom()
+	alice := keypair.MustRandom()
+	for _, kp := range []*keypair.Full{issuer, alice} {
+		_, err := server.Fund(kp.Address(), "1000")
+		require.NoError(t, err)
+	}
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: issuer.Address()}
+	require.NoError(t, server.SetExchangeRate(txnbuild.NativeAsset{}, usd, "0.5"))
+
+	_, err := submit(t, client, alice, &txnbuild.ChangeTrust{Line: usd, Limit: "1000"})
+	require.NoError(t, err)
+
+	paths, err := client.StrictSendPaths(horizonclient.StrictSendPathsRequest{
+		SourceAssetType:   horizonclient.AssetTypeNative,
+		SourceAmount:      "10",
+		DestinationAssets: "USD:" + issuer.Address(),
+	})
+	require.NoError(t, err)
+	require.Len(t, paths.Embedded.Records, 1)
+	assert.Equal(t, "5.0000000", paths.Embedded.Records[0].DestinationAmount)
+
+	paths, err = client.StrictReceivePaths(horizonclient.PathsRequest{
+		DestinationAssetType:   horizonclient.AssetType4,
+		DestinationAssetCode:   "USD",
+		DestinationAssetIssuer: issuer.Address(),
+		DestinationAmount:      "5",
+		SourceAccount:          alice.Address(),
+	})
+	require.NoError(t, err)
+	require.Len(t, paths.Embedded.Records, 1)
+	assert.Equal(t, "10.0000000", paths.Embedded.Records[0].SourceAmount)
+
+	_, err = submit(t, client, issuer, &txnbuild.PathPaymentStrictReceive{
+		SendAsset:   txnbuild.NativeAsset{},
+		SendMax:     "10",
+		Destination: alice.Address(),
+		DestAsset:   usd,
+		DestAmount:  "5",
+	})
+	require.NoError(t, err)
+
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+	require.NoError(t, err)
+	assert.Equal(t, "5.0000000", account.GetCreditBalance("USD", issuer.Address()))
+	assert.Equal(t, "989.9999900", nativeBalance(t, client, issuer.Address()))
+}
+
+func TestStreaming(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	alice := keypair.MustRandom()
+	_, err := server.Fund(alice.Address(), "100")
+	require.NoError(t, err)
+
+	// the stream starts after the payment which created the account
+	payments, err := client.Payments(horizonclient.OperationRequest{ForAccount: alice.Address()})
+	require.NoError(t, err)
+	require.Len(t, payments.Embedded.Records, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var amounts []string
+	done := make(chan error)
+	// clients update their HorizonURL on every request so they can't be
+	// used concurrently
+	streamClient := server.Client()
+	go func() {
+		done <- streamClient.StreamPayments(ctx, horizonclient.OperationRequest{
+			ForAccount: alice.Address(),
+			Cursor:     payments.Embedded.Records[0].PagingToken(),
+		}, func(op operations.Operation) {
+			amounts = append(amounts, op.(operations.Payment).Amount)
+			if len(amounts) == 2 {
+				cancel()
+			}
+		})
+	}()
+
+	for _, value := range []string{"1", "2"} {
+		_, err = submit(t, client, alice, &txnbuild.Payment{
+			Destination: server.Root().Address(),
+			Amount:      value,
+			Asset:       txnbuild.NativeAsset{},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"1.0000000", "2.0000000"}, amounts)
+}
+
+func TestFeeBump(t *testing.T) {
+	server := NewServer(network.TestNetworkPassphrase)
+	defer server.Close()
+	client := server.Client()
+
+	alice := keypair.MustRandom()
+	sponsor := keypair.MustRandom()
+	_, err := server.Fund(alice.Address(), "10")
+	require.NoError(t, err)
+	_, err = server.Fund(sponsor.Address(), "10")
+	require.NoError(t, err)
+
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+	require.NoError(t, err)
+	inner, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &account,
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 0}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewTimeout(300),
+	})
+	require.NoError(t, err)
+	inner, err = inner.Sign(network.TestNetworkPassphrase, alice)
+	require.NoError(t, err)
+	feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: sponsor.Address(),
+		BaseFee:    txnbuild.MinBaseFee,
+	})
+	require.NoError(t, err)
+
+	// the fee bump transaction isn't signed by the fee account
+	_, err = client.SubmitFeeBumpTransaction(feeBump)
+	codes, err := horizonclient.GetError(err).ResultCodes()
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_auth", codes.TransactionCode)
+
+	feeBump, err = feeBump.Sign(network.TestNetworkPassphrase, sponsor)
+	require.NoError(t, err)
+	tx, err := client.SubmitFeeBumpTransaction(feeBump)
+	require.NoError(t, err)
+	assert.Equal(t, sponsor.Address(), tx.FeeAccount)
+	assert.Equal(t, int64(200), tx.FeeCharged)
+	require.NotNil(t, tx.InnerTransaction)
+	innerHash, err := inner.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, innerHash, tx.InnerTransaction.Hash)
+	assert.Equal(t, "10.0000000", nativeBalance(t, client, alice.Address()))
+	assert.Equal(t, "9.9999800", nativeBalance(t, client, sponsor.Address()))
+}
@@ -0,0 +1,419 @@
+package horizonclient
+
+import (
+	"context"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/effects"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+)
+
+// pagedRecord is a record of a paged Horizon collection
+type pagedRecord interface {
+	PagingToken() string
+}
+
+// iterator implements lazy paging of Horizon collections. It's embedded in
+// iterators of all the supported collections.
+type iterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// fetch loads the page of records following cursor
+	fetch func(ctx context.Context, cursor string) ([]pagedRecord, error)
+	// stream streams records following cursor, it's nil if the iterator
+	// stops once all the pages are loaded
+	stream func(ctx context.Context, cursor string, handler func(pagedRecord)) error
+
+	cursor  string
+	page    []pagedRecord
+	current pagedRecord
+	err     error
+	done    bool
+
+	streaming bool
+	streamed  chan pagedRecord
+	streamErr error
+}
+
+func newIterator(
+	ctx context.Context,
+	cursor string,
+	fetch func(ctx context.Context, cursor string) ([]pagedRecord, error),
+) iterator {
+	ctx, cancel := context.WithCancel(ctx)
+	return iterator{
+		ctx:    ctx,
+		cancel: cancel,
+		fetch:  fetch,
+		cursor: cursor,
+	}
+}
+
+// follow configures the iterator to stream records once it reaches the
+// last page. Following is only possible when records are in ascending order.
+func (it *iterator) follow(
+	order Order,
+	stream func(ctx context.Context, cursor string, handler func(pagedRecord)) error,
+) {
+	if order == OrderDesc {
+		it.stop(errors.New("following is not supported for records in descending order"))
+		return
+	}
+	it.stream = stream
+}
+
+// Next advances the iterator to the next record. It returns false when there
+// are no more records or an error occurred (see Err). Pages are loaded
+// lazily, when all records from the previous page were returned.
+//
+// If the iterator follows the collection, Next blocks until a new record is
+// streamed after all existing records were returned.
+func (it *iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		return it.stop(err)
+	}
+
+	if len(it.page) == 0 && !it.streaming {
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			return it.stop(err)
+		}
+		if len(page) == 0 {
+			if it.stream == nil {
+				return it.stop(nil)
+			}
+			it.startStreaming()
+		}
+		it.page = page
+	}
+
+	if it.streaming {
+		select {
+		case record, ok := <-it.streamed:
+			if !ok {
+				return it.stop(it.streamErr)
+			}
+			it.setCurrent(record)
+			return true
+		case <-it.ctx.Done():
+			return it.stop(it.ctx.Err())
+		}
+	}
+
+	it.setCurrent(it.page[0])
+	it.page = it.page[1:]
+	return true
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the paging token of the current record (or the cursor of the
+// original request if Next was not called yet). It can be used as the cursor
+// of a new request to resume iterating after the current record.
+func (it *iterator) Cursor() string {
+	return it.cursor
+}
+
+// Close stops the iterator. It must be called when the iterator follows the
+// collection and is not used anymore before Next returns false.
+func (it *iterator) Close() {
+	it.done = true
+	it.cancel()
+}
+
+func (it *iterator) setCurrent(record pagedRecord) {
+	it.current = record
+	it.cursor = record.PagingToken()
+}
+
+func (it *iterator) stop(err error) bool {
+	it.err = err
+	it.done = true
+	it.cancel()
+	return false
+}
+
+func (it *iterator) startStreaming() {
+	it.streaming = true
+	it.streamed = make(chan pagedRecord)
+	go func() {
+		err := it.stream(it.ctx, it.cursor, func(record pagedRecord) {
+			select {
+			case it.streamed <- record:
+			case <-it.ctx.Done():
+			}
+		})
+		// streamErr is read only after the channel is closed
+		it.streamErr = err
+		close(it.streamed)
+	}()
+}
+
+// AccountIterator iterates over accounts returned by an AccountsRequest
+type AccountIterator struct {
+	iterator
+}
+
+// Account returns the current account
+func (it *AccountIterator) Account() hProtocol.Account {
+	return it.current.(hProtocol.Account)
+}
+
+// IterateAccounts returns an iterator over all accounts matching the request
+// starting after request.Cursor. request.Limit is the number of accounts
+// loaded at once.
+func (c *Client) IterateAccounts(ctx context.Context, request AccountsRequest) *AccountIterator {
+	return &AccountIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := c.AccountsContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+}
+
+// ClaimableBalanceIterator iterates over claimable balances returned by a
+// ClaimableBalanceRequest
+type ClaimableBalanceIterator struct {
+	iterator
+}
+
+// ClaimableBalance returns the current claimable balance
+func (it *ClaimableBalanceIterator) ClaimableBalance() hProtocol.ClaimableBalance {
+	return it.current.(hProtocol.ClaimableBalance)
+}
+
+// IterateClaimableBalances returns an iterator over all claimable balances
+// matching the request starting after request.Cursor. request.Limit is the
+// number of claimable balances loaded at once. request.ID must be empty.
+func (c *Client) IterateClaimableBalances(ctx context.Context, request ClaimableBalanceRequest) *ClaimableBalanceIterator {
+	it := &ClaimableBalanceIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := c.ClaimableBalancesContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+	if request.ID != "" {
+		it.stop(errors.New("claimable balance ID can't be set when iterating"))
+	}
+	return it
+}
+
+// EffectIterator iterates over effects returned by an EffectRequest
+type EffectIterator struct {
+	iterator
+}
+
+// Effect returns the current effect
+func (it *EffectIterator) Effect() effects.Effect {
+	return it.current.(effects.Effect)
+}
+
+// IterateEffects returns an iterator over all effects matching the request
+// starting after request.Cursor. request.Limit is the number of effects loaded
+// at once.
+func (c *Client) IterateEffects(ctx context.Context, request EffectRequest) *EffectIterator {
+	return &EffectIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := c.EffectsContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+}
+
+// FollowEffects is like IterateEffects but once all existing effects are
+// returned the iterator streams new effects until ctx is done or the
+// iterator is closed. request.Order can't be OrderDesc.
+func (c *Client) FollowEffects(ctx context.Context, request EffectRequest) *EffectIterator {
+	it := c.IterateEffects(ctx, request)
+	it.follow(request.Order, func(ctx context.Context, cursor string, handler func(pagedRecord)) error {
+		request.Cursor = cursor
+		return c.StreamEffects(ctx, request, func(effect effects.Effect) {
+			handler(effect)
+		})
+	})
+	return it
+}
+
+// OperationIterator iterates over operations (or payments) returned by an
+// OperationRequest
+type OperationIterator struct {
+	iterator
+}
+
+// Operation returns the current operation
+func (it *OperationIterator) Operation() operations.Operation {
+	return it.current.(operations.Operation)
+}
+
+func (c *Client) iterateOperations(
+	ctx context.Context,
+	request OperationRequest,
+	load func(ctx context.Context, request OperationRequest) (operations.OperationsPage, error),
+) *OperationIterator {
+	return &OperationIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := load(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+}
+
+func (it *OperationIterator) followOperations(
+	request OperationRequest,
+	stream func(ctx context.Context, request OperationRequest, handler OperationHandler) error,
+) {
+	it.follow(request.Order, func(ctx context.Context, cursor string, handler func(pagedRecord)) error {
+		request.Cursor = cursor
+		return stream(ctx, request, func(op operations.Operation) {
+			handler(op)
+		})
+	})
+}
+
+// IterateOperations returns an iterator over all operations matching the
+// request starting after request.Cursor. request.Limit is the number of
+// operations loaded at once.
+func (c *Client) IterateOperations(ctx context.Context, request OperationRequest) *OperationIterator {
+	return c.iterateOperations(ctx, request, c.OperationsContext)
+}
+
+// FollowOperations is like IterateOperations but once all existing operations
+// are returned the iterator streams new operations until ctx is done or the
+// iterator is closed. request.Order can't be OrderDesc.
+func (c *Client) FollowOperations(ctx context.Context, request OperationRequest) *OperationIterator {
+	it := c.IterateOperations(ctx, request)
+	it.followOperations(request, c.StreamOperations)
+	return it
+}
+
+// IteratePayments returns an iterator over all payments matching the request
+// starting after request.Cursor. request.Limit is the number of payments
+// loaded at once.
+func (c *Client) IteratePayments(ctx context.Context, request OperationRequest) *OperationIterator {
+	return c.iterateOperations(ctx, request, c.PaymentsContext)
+}
+
+// FollowPayments is like IteratePayments but once all existing payments are
+// returned the iterator streams new payments until ctx is done or the
+// iterator is closed. request.Order can't be OrderDesc.
+func (c *Client) FollowPayments(ctx context.Context, request OperationRequest) *OperationIterator {
+	it := c.IteratePayments(ctx, request)
+	it.followOperations(request, c.StreamPayments)
+	return it
+}
+
+// TradeIterator iterates over trades returned by a TradeRequest
+type TradeIterator struct {
+	iterator
+}
+
+// Trade returns the current trade
+func (it *TradeIterator) Trade() hProtocol.Trade {
+	return it.current.(hProtocol.Trade)
+}
+
+// IterateTrades returns an iterator over all trades matching the request
+// starting after request.Cursor. request.Limit is the number of trades loaded
+// at once.
+func (c *Client) IterateTrades(ctx context.Context, request TradeRequest) *TradeIterator {
+	return &TradeIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := c.TradesContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+}
+
+// FollowTrades is like IterateTrades but once all existing trades are
+// returned the iterator streams new trades until ctx is done or the iterator
+// is closed. request.Order can't be OrderDesc.
+func (c *Client) FollowTrades(ctx context.Context, request TradeRequest) *TradeIterator {
+	it := c.IterateTrades(ctx, request)
+	it.follow(request.Order, func(ctx context.Context, cursor string, handler func(pagedRecord)) error {
+		request.Cursor = cursor
+		return c.StreamTrades(ctx, request, func(trade hProtocol.Trade) {
+			handler(trade)
+		})
+	})
+	return it
+}
+
+// TransactionIterator iterates over transactions returned by a
+// TransactionRequest
+type TransactionIterator struct {
+	iterator
+}
+
+// Transaction returns the current transaction
+func (it *TransactionIterator) Transaction() hProtocol.Transaction {
+	return it.current.(hProtocol.Transaction)
+}
+
+// IterateTransactions returns an iterator over all transactions matching the
+// request starting after request.Cursor. request.Limit is the number of
+// transactions loaded at once.
+func (c *Client) IterateTransactions(ctx context.Context, request TransactionRequest) *TransactionIterator {
+	return &TransactionIterator{newIterator(ctx, request.Cursor, func(ctx context.Context, cursor string) ([]pagedRecord, error) {
+		request.Cursor = cursor
+		page, err := c.TransactionsContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]pagedRecord, len(page.Embedded.Records))
+		for i, record := range page.Embedded.Records {
+			records[i] = record
+		}
+		return records, nil
+	})}
+}
+
+// FollowTransactions is like IterateTransactions but once all existing
+// transactions are returned the iterator streams new transactions until ctx
+// is done or the iterator is closed. request.Order can't be OrderDesc.
+func (c *Client) FollowTransactions(ctx context.Context, request TransactionRequest) *TransactionIterator {
+	it := c.IterateTransactions(ctx, request)
+	it.follow(request.Order, func(ctx context.Context, cursor string, handler func(pagedRecord)) error {
+		request.Cursor = cursor
+		return c.StreamTransactions(ctx, request, func(tx hProtocol.Transaction) {
+			handler(tx)
+		})
+	})
+	return it
+}
@@ -0,0 +1,183 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transactionsPage(tokens ...string) string {
+	records := make([]string, len(tokens))
+	for i, token := range tokens {
+		records[i] = fmt.Sprintf(`{"paging_token": "%s", "hash": "tx%s"}`, token, token)
+	}
+	return fmt.Sprintf(`{"_embedded": {"records": [%s]}}`, strings.Join(records, ","))
+}
+
+// requestLog records cursors of requests sent by the client, requests can be
+// sent concurrently by a streaming iterator
+type requestLog struct {
+	lock    sync.Mutex
+	cursors []string
+}
+
+func (l *requestLog) add(cursor string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.cursors = append(l.cursors, cursor)
+}
+
+func (l *requestLog) get() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]string{}, l.cursors...)
+}
+
+// transactionsResponder serves pages of transactions with paging tokens from
+// 1 to 3 (2 transactions per page) and streams the transaction with paging
+// token 4
+func transactionsResponder(requests *requestLog) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		cursor := req.URL.Query().Get("cursor")
+		if req.Header.Get("Accept") == "text/event-stream" {
+			requests.add("stream:" + cursor)
+			if cursor != "3" {
+				return nil, fmt.Errorf("unexpected stream cursor %s", cursor)
+			}
+			return stringResponder(
+				http.StatusOK,
+				"id: 4\ndata: {\"paging_token\": \"4\", \"hash\": \"tx4\"}\n\n",
+			)(req)
+		}
+
+		requests.add(cursor)
+		switch cursor {
+		case "":
+			return stringResponder(http.StatusOK, transactionsPage("1", "2"))(req)
+		case "2":
+			return stringResponder(http.StatusOK, transactionsPage("3"))(req)
+		case "3", "4":
+			return stringResponder(http.StatusOK, transactionsPage())(req)
+		default:
+			return stringResponder(http.StatusInternalServerError, `{"title": "Internal Server Error"}`)(req)
+		}
+	}
+}
+
+func TestIterateTransactions(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	requests := &requestLog{}
+	hmock.On("GET", "https://localhost/transactions").Return(transactionsResponder(requests))
+
+	it := client.IterateTransactions(context.Background(), TransactionRequest{Limit: 2})
+	var hashes []string
+	for it.Next() {
+		hashes = append(hashes, it.Transaction().Hash)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, hashes)
+	assert.Equal(t, "3", it.Cursor())
+	assert.Equal(t, []string{"", "2", "3"}, requests.get())
+	assert.False(t, it.Next())
+
+	// resume from a cursor
+	requests.cursors = nil
+	it = client.IterateTransactions(context.Background(), TransactionRequest{Cursor: "2"})
+	assert.Equal(t, "2", it.Cursor())
+	require.True(t, it.Next())
+	assert.Equal(t, "tx3", it.Transaction().Hash)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"2", "3"}, requests.get())
+
+	// stop early
+	requests.cursors = nil
+	it = client.IterateTransactions(context.Background(), TransactionRequest{})
+	require.True(t, it.Next())
+	it.Close()
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Equal(t, "1", it.Cursor())
+	assert.Equal(t, []string{""}, requests.get())
+
+	// errors stop the iterator
+	it = client.IterateTransactions(context.Background(), TransactionRequest{Cursor: "5"})
+	assert.False(t, it.Next())
+	hErr := GetError(it.Err())
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusInternalServerError, hErr.Response.StatusCode)
+	assert.False(t, it.Next())
+
+	// cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = client.IterateTransactions(ctx, TransactionRequest{})
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+}
+
+func TestFollowTransactions(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	requests := &requestLog{}
+	hmock.On("GET", "https://localhost/transactions").Return(transactionsResponder(requests))
+
+	it := client.FollowTransactions(context.Background(), TransactionRequest{Limit: 2})
+	var hashes []string
+	for len(hashes) < 4 && it.Next() {
+		hashes = append(hashes, it.Transaction().Hash)
+	}
+	it.Close()
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, hashes)
+	assert.Equal(t, "4", it.Cursor())
+	assert.Equal(t, []string{"", "2", "3", "stream:3"}, requests.get()[:4])
+	assert.False(t, it.Next())
+
+	it = client.FollowTransactions(context.Background(), TransactionRequest{Order: OrderDesc})
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "following is not supported for records in descending order")
+}
+
+func TestIterateClaimableBalances(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/claimable_balances?claimant=GAAA&cursor=abc&limit=1").ReturnString(
+		http.StatusOK,
+		`{"_embedded": {"records": [{"id": "b1", "paging_token": "b1"}]}}`,
+	)
+	hmock.On("GET", "https://localhost/claimable_balances?claimant=GAAA&cursor=b1&limit=1").ReturnString(
+		http.StatusOK,
+		`{"_embedded": {"records": []}}`,
+	)
+
+	it := client.IterateClaimableBalances(
+		context.Background(),
+		ClaimableBalanceRequest{Claimant: "GAAA", Cursor: "abc", Limit: 1},
+	)
+	require.True(t, it.Next())
+	assert.Equal(t, "b1", it.ClaimableBalance().BalanceID)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+
+	it = client.IterateClaimableBalances(context.Background(), ClaimableBalanceRequest{ID: "b1"})
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "claimable balance ID can't be set when iterating")
+}
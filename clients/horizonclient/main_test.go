@@ -1,6 +1,7 @@
 package horizonclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -78,6 +79,12 @@ func TestCheckMemoRequired(t *testing.T) {
 		Destination: "GBVZZ5XPHECNGA5SENAJP4C6ZJ7FGZ55ZZUCTFTHREZM73LKUGCQDRHR",
 	}
 
+	paymentMuxed := txnbuild.Payment{
+		Destination: "MAYHAAKPAQLMGIJYMIWPDWCGUCQ5LAWY4Q7Q3IKSP57O7GUPD3NEOAAAAAAAAAAAAETGC",
+		Amount:      "10",
+		Asset:       txnbuild.NativeAsset{},
+	}
+
 	testCases := []struct {
 		desc         string
 		destination  string
@@ -126,6 +133,14 @@ func TestCheckMemoRequired(t *testing.T) {
 			},
 			mockNotFound: true,
 		},
+		{
+			desc: "muxed destination",
+			operations: []txnbuild.Operation{
+				&paymentMuxed,
+				&paymentNoMemo,
+			},
+			mockNotFound: true,
+		},
 		{
 			desc: "two operations with same destination",
 			operations: []txnbuild.Operation{
@@ -162,7 +177,7 @@ func TestCheckMemoRequired(t *testing.T) {
 				).ReturnString(404, notFoundResponse)
 			}
 
-			err = client.checkMemoRequired(tx)
+			err = client.checkMemoRequired(context.Background(), tx)
 
 			if len(tc.expected) > 0 {
 				tt.Error(err)
@@ -21,150 +21,300 @@ func (m *MockClient) Accounts(request AccountsRequest) (hProtocol.AccountsPage,
 	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
 }
 
+// AccountsContext is a mocking method
+func (m *MockClient) AccountsContext(ctx context.Context, request AccountsRequest) (hProtocol.AccountsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
+}
+
 // AccountDetail is a mocking method
 func (m *MockClient) AccountDetail(request AccountRequest) (hProtocol.Account, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.Account), a.Error(1)
 }
 
+// AccountDetailContext is a mocking method
+func (m *MockClient) AccountDetailContext(ctx context.Context, request AccountRequest) (hProtocol.Account, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.Account), a.Error(1)
+}
+
 // AccountData is a mocking method
 func (m *MockClient) AccountData(request AccountRequest) (hProtocol.AccountData, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.AccountData), a.Error(1)
 }
 
+// AccountDataContext is a mocking method
+func (m *MockClient) AccountDataContext(ctx context.Context, request AccountRequest) (hProtocol.AccountData, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.AccountData), a.Error(1)
+}
+
 // Effects is a mocking method
 func (m *MockClient) Effects(request EffectRequest) (effects.EffectsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(effects.EffectsPage), a.Error(1)
 }
 
+// EffectsContext is a mocking method
+func (m *MockClient) EffectsContext(ctx context.Context, request EffectRequest) (effects.EffectsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(effects.EffectsPage), a.Error(1)
+}
+
 // Assets is a mocking method
 func (m *MockClient) Assets(request AssetRequest) (hProtocol.AssetsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
 }
 
+// AssetsContext is a mocking method
+func (m *MockClient) AssetsContext(ctx context.Context, request AssetRequest) (hProtocol.AssetsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
+}
+
 // Ledgers is a mocking method
 func (m *MockClient) Ledgers(request LedgerRequest) (hProtocol.LedgersPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
 }
 
+// LedgersContext is a mocking method
+func (m *MockClient) LedgersContext(ctx context.Context, request LedgerRequest) (hProtocol.LedgersPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
+}
+
 // LedgerDetail is a mocking method
 func (m *MockClient) LedgerDetail(sequence uint32) (hProtocol.Ledger, error) {
 	a := m.Called(sequence)
 	return a.Get(0).(hProtocol.Ledger), a.Error(1)
 }
 
+// LedgerDetailContext is a mocking method
+func (m *MockClient) LedgerDetailContext(ctx context.Context, sequence uint32) (hProtocol.Ledger, error) {
+	a := m.Called(ctx, sequence)
+	return a.Get(0).(hProtocol.Ledger), a.Error(1)
+}
+
 // FeeStats is a mocking method
 func (m *MockClient) FeeStats() (hProtocol.FeeStats, error) {
 	a := m.Called()
 	return a.Get(0).(hProtocol.FeeStats), a.Error(1)
 }
 
+// FeeStatsContext is a mocking method
+func (m *MockClient) FeeStatsContext(ctx context.Context) (hProtocol.FeeStats, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(hProtocol.FeeStats), a.Error(1)
+}
+
 // Offers is a mocking method
 func (m *MockClient) Offers(request OfferRequest) (hProtocol.OffersPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
 }
 
+// OffersContext is a mocking method
+func (m *MockClient) OffersContext(ctx context.Context, request OfferRequest) (hProtocol.OffersPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
+}
+
 // OfferDetail is a mocking method
 func (m *MockClient) OfferDetails(offerID string) (hProtocol.Offer, error) {
 	a := m.Called(offerID)
 	return a.Get(0).(hProtocol.Offer), a.Error(1)
 }
 
+// OfferDetailsContext is a mocking method
+func (m *MockClient) OfferDetailsContext(ctx context.Context, offerID string) (hProtocol.Offer, error) {
+	a := m.Called(ctx, offerID)
+	return a.Get(0).(hProtocol.Offer), a.Error(1)
+}
+
 // Operations is a mocking method
 func (m *MockClient) Operations(request OperationRequest) (operations.OperationsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(operations.OperationsPage), a.Error(1)
 }
 
+// OperationsContext is a mocking method
+func (m *MockClient) OperationsContext(ctx context.Context, request OperationRequest) (operations.OperationsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(operations.OperationsPage), a.Error(1)
+}
+
 // OperationDetail is a mocking method
 func (m *MockClient) OperationDetail(id string) (operations.Operation, error) {
 	a := m.Called(id)
 	return a.Get(0).(operations.Operation), a.Error(1)
 }
 
+// OperationDetailContext is a mocking method
+func (m *MockClient) OperationDetailContext(ctx context.Context, id string) (operations.Operation, error) {
+	a := m.Called(ctx, id)
+	return a.Get(0).(operations.Operation), a.Error(1)
+}
+
 // SubmitTransactionXDR is a mocking method
 func (m *MockClient) SubmitTransactionXDR(transactionXdr string) (hProtocol.Transaction, error) {
 	a := m.Called(transactionXdr)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// SubmitTransactionXDRContext is a mocking method
+func (m *MockClient) SubmitTransactionXDRContext(ctx context.Context, transactionXdr string) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, transactionXdr)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // SubmitFeeBumpTransaction is a mocking method
 func (m *MockClient) SubmitFeeBumpTransaction(transaction *txnbuild.FeeBumpTransaction) (hProtocol.Transaction, error) {
 	a := m.Called(transaction)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// SubmitFeeBumpTransactionContext is a mocking method
+func (m *MockClient) SubmitFeeBumpTransactionContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, transaction)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // SubmitTransaction is a mocking method
 func (m *MockClient) SubmitTransaction(transaction *txnbuild.Transaction) (hProtocol.Transaction, error) {
 	a := m.Called(transaction)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// SubmitTransactionContext is a mocking method
+func (m *MockClient) SubmitTransactionContext(ctx context.Context, transaction *txnbuild.Transaction) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, transaction)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // SubmitFeeBumpTransactionWithOptions is a mocking method
 func (m *MockClient) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (hProtocol.Transaction, error) {
 	a := m.Called(transaction, opts)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// SubmitFeeBumpTransactionWithOptionsContext is a mocking method
+func (m *MockClient) SubmitFeeBumpTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, transaction, opts)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // SubmitTransactionWithOptions is a mocking method
 func (m *MockClient) SubmitTransactionWithOptions(transaction *txnbuild.Transaction, opts SubmitTxOpts) (hProtocol.Transaction, error) {
 	a := m.Called(transaction, opts)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// SubmitTransactionWithOptionsContext is a mocking method
+func (m *MockClient) SubmitTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.Transaction, opts SubmitTxOpts) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, transaction, opts)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // Transactions is a mocking method
 func (m *MockClient) Transactions(request TransactionRequest) (hProtocol.TransactionsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
 }
 
+// TransactionsContext is a mocking method
+func (m *MockClient) TransactionsContext(ctx context.Context, request TransactionRequest) (hProtocol.TransactionsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
+}
+
 // TransactionDetail is a mocking method
 func (m *MockClient) TransactionDetail(txHash string) (hProtocol.Transaction, error) {
 	a := m.Called(txHash)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// TransactionDetailContext is a mocking method
+func (m *MockClient) TransactionDetailContext(ctx context.Context, txHash string) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, txHash)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // OrderBook is a mocking method
 func (m *MockClient) OrderBook(request OrderBookRequest) (hProtocol.OrderBookSummary, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.OrderBookSummary), a.Error(1)
 }
 
+// OrderBookContext is a mocking method
+func (m *MockClient) OrderBookContext(ctx context.Context, request OrderBookRequest) (hProtocol.OrderBookSummary, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.OrderBookSummary), a.Error(1)
+}
+
 // Paths is a mocking method
 func (m *MockClient) Paths(request PathsRequest) (hProtocol.PathsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.PathsPage), a.Error(1)
 }
 
+// PathsContext is a mocking method
+func (m *MockClient) PathsContext(ctx context.Context, request PathsRequest) (hProtocol.PathsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.PathsPage), a.Error(1)
+}
+
 // Payments is a mocking method
 func (m *MockClient) Payments(request OperationRequest) (operations.OperationsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(operations.OperationsPage), a.Error(1)
 }
 
+// PaymentsContext is a mocking method
+func (m *MockClient) PaymentsContext(ctx context.Context, request OperationRequest) (operations.OperationsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(operations.OperationsPage), a.Error(1)
+}
+
 // TradeAggregations is a mocking method
 func (m *MockClient) TradeAggregations(request TradeAggregationRequest) (hProtocol.TradeAggregationsPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
 }
 
+// TradeAggregationsContext is a mocking method
+func (m *MockClient) TradeAggregationsContext(ctx context.Context, request TradeAggregationRequest) (hProtocol.TradeAggregationsPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
+}
+
 // Trades is a mocking method
 func (m *MockClient) Trades(request TradeRequest) (hProtocol.TradesPage, error) {
 	a := m.Called(request)
 	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
 }
 
+// TradesContext is a mocking method
+func (m *MockClient) TradesContext(ctx context.Context, request TradeRequest) (hProtocol.TradesPage, error) {
+	a := m.Called(ctx, request)
+	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
+}
+
 // Fund is a mocking method
 func (m *MockClient) Fund(addr string) (hProtocol.Transaction, error) {
 	a := m.Called(addr)
 	return a.Get(0).(hProtocol.Transaction), a.Error(1)
 }
 
+// FundContext is a mocking method
+func (m *MockClient) FundContext(ctx context.Context, addr string) (hProtocol.Transaction, error) {
+	a := m.Called(ctx, addr)
+	return a.Get(0).(hProtocol.Transaction), a.Error(1)
+}
+
 // StreamTransactions is a mocking method
 func (m *MockClient) StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error {
 	return m.Called(ctx, request, handler).Error(0)
@@ -211,123 +361,247 @@ func (m *MockClient) Root() (hProtocol.Root, error) {
 	return a.Get(0).(hProtocol.Root), a.Error(1)
 }
 
+// RootContext is a mocking method
+func (m *MockClient) RootContext(ctx context.Context) (hProtocol.Root, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(hProtocol.Root), a.Error(1)
+}
+
 // NextAccountsPage is a mocking method
 func (m *MockClient) NextAccountsPage(page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
 }
 
+// NextAccountsPageContext is a mocking method
+func (m *MockClient) NextAccountsPageContext(ctx context.Context, page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
+}
+
 // NextAssetsPage is a mocking method
 func (m *MockClient) NextAssetsPage(page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
 }
 
+// NextAssetsPageContext is a mocking method
+func (m *MockClient) NextAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
+}
+
 // PrevAssetsPage is a mocking method
 func (m *MockClient) PrevAssetsPage(page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
 }
 
+// PrevAssetsPageContext is a mocking method
+func (m *MockClient) PrevAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.AssetsPage), a.Error(1)
+}
+
 // NextLedgersPage is a mocking method
 func (m *MockClient) NextLedgersPage(page hProtocol.LedgersPage) (hProtocol.LedgersPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
 }
 
+// NextLedgersPageContext is a mocking method
+func (m *MockClient) NextLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (hProtocol.LedgersPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
+}
+
 // PrevLedgersPage is a mocking method
 func (m *MockClient) PrevLedgersPage(page hProtocol.LedgersPage) (hProtocol.LedgersPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
 }
 
+// PrevLedgersPageContext is a mocking method
+func (m *MockClient) PrevLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (hProtocol.LedgersPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.LedgersPage), a.Error(1)
+}
+
 // NextEffectsPage is a mocking method
 func (m *MockClient) NextEffectsPage(page effects.EffectsPage) (effects.EffectsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(effects.EffectsPage), a.Error(1)
 }
 
+// NextEffectsPageContext is a mocking method
+func (m *MockClient) NextEffectsPageContext(ctx context.Context, page effects.EffectsPage) (effects.EffectsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(effects.EffectsPage), a.Error(1)
+}
+
 // PrevEffectsPage is a mocking method
 func (m *MockClient) PrevEffectsPage(page effects.EffectsPage) (effects.EffectsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(effects.EffectsPage), a.Error(1)
 }
 
+// PrevEffectsPageContext is a mocking method
+func (m *MockClient) PrevEffectsPageContext(ctx context.Context, page effects.EffectsPage) (effects.EffectsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(effects.EffectsPage), a.Error(1)
+}
+
 // NextTransactionsPage is a mocking method
 func (m *MockClient) NextTransactionsPage(page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
 }
 
+// NextTransactionsPageContext is a mocking method
+func (m *MockClient) NextTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
+}
+
 // PrevTransactionsPage is a mocking method
 func (m *MockClient) PrevTransactionsPage(page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
 }
 
+// PrevTransactionsPageContext is a mocking method
+func (m *MockClient) PrevTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TransactionsPage), a.Error(1)
+}
+
 // NextOperationsPage is a mocking method
 func (m *MockClient) NextOperationsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(operations.OperationsPage), a.Error(1)
 }
 
+// NextOperationsPageContext is a mocking method
+func (m *MockClient) NextOperationsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(operations.OperationsPage), a.Error(1)
+}
+
 // PrevOperationsPage is a mocking method
 func (m *MockClient) PrevOperationsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(operations.OperationsPage), a.Error(1)
 }
 
+// PrevOperationsPageContext is a mocking method
+func (m *MockClient) PrevOperationsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(operations.OperationsPage), a.Error(1)
+}
+
 // NextPaymentsPage is a mocking method
 func (m *MockClient) NextPaymentsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
 	return m.NextOperationsPage(page)
 }
 
+// NextPaymentsPageContext is a mocking method
+func (m *MockClient) NextPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	return m.NextOperationsPage(page)
+}
+
 // PrevPaymentsPage is a mocking method
 func (m *MockClient) PrevPaymentsPage(page operations.OperationsPage) (operations.OperationsPage, error) {
 	return m.PrevOperationsPage(page)
 }
 
+// PrevPaymentsPageContext is a mocking method
+func (m *MockClient) PrevPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (operations.OperationsPage, error) {
+	return m.PrevOperationsPage(page)
+}
+
 // NextOffersPage is a mocking method
 func (m *MockClient) NextOffersPage(page hProtocol.OffersPage) (hProtocol.OffersPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
 }
 
+// NextOffersPageContext is a mocking method
+func (m *MockClient) NextOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (hProtocol.OffersPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
+}
+
 // PrevOffersPage is a mocking method
 func (m *MockClient) PrevOffersPage(page hProtocol.OffersPage) (hProtocol.OffersPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
 }
 
+// PrevOffersPageContext is a mocking method
+func (m *MockClient) PrevOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (hProtocol.OffersPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
+}
+
 // NextTradesPage is a mocking method
 func (m *MockClient) NextTradesPage(page hProtocol.TradesPage) (hProtocol.TradesPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
 }
 
+// NextTradesPageContext is a mocking method
+func (m *MockClient) NextTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (hProtocol.TradesPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
+}
+
 // PrevTradesPage is a mocking method
 func (m *MockClient) PrevTradesPage(page hProtocol.TradesPage) (hProtocol.TradesPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
 }
 
+// PrevTradesPageContext is a mocking method
+func (m *MockClient) PrevTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (hProtocol.TradesPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TradesPage), a.Error(1)
+}
+
 // HomeDomainForAccount is a mocking method
 func (m *MockClient) HomeDomainForAccount(aid string) (string, error) {
 	a := m.Called(aid)
 	return a.Get(0).(string), a.Error(1)
 }
 
+// HomeDomainForAccountContext is a mocking method
+func (m *MockClient) HomeDomainForAccountContext(ctx context.Context, aid string) (string, error) {
+	a := m.Called(ctx, aid)
+	return a.Get(0).(string), a.Error(1)
+}
+
 // NextTradeAggregationsPage is a mocking method
 func (m *MockClient) NextTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
 }
 
+// NextTradeAggregationsPageContext is a mocking method
+func (m *MockClient) NextTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
+}
+
 // PrevTradeAggregationsPage is a mocking method
 func (m *MockClient) PrevTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
 	a := m.Called(page)
 	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
 }
 
+// PrevTradeAggregationsPageContext is a mocking method
+func (m *MockClient) PrevTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).(hProtocol.TradeAggregationsPage), a.Error(1)
+}
+
 // ensure that the MockClient implements ClientInterface
 var _ ClientInterface = &MockClient{}
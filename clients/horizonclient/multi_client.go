@@ -0,0 +1,1060 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/effects"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+const (
+	// DefaultMaxLedgerLag is the default maximum number of ledgers a Horizon
+	// server can lag behind the most up to date server to receive requests
+	DefaultMaxLedgerLag = 5
+	// DefaultHealthCheckInterval is the default interval between checks of
+	// health of Horizon servers
+	DefaultHealthCheckInterval = 10 * time.Second
+	// DefaultHealthCheckTimeout is the default timeout of health checks
+	DefaultHealthCheckTimeout = 5 * time.Second
+)
+
+// MultiClientConfig configures a MultiClient
+type MultiClientConfig struct {
+	// Clients contains clients of the Horizon servers in the order of
+	// preference: requests are sent to the first server which is healthy and
+	// not lagging behind the other servers.
+	Clients []*Client
+	// MaxLedgerLag is the maximum number of ledgers a server can lag behind
+	// the most up to date server to receive requests. Defaults to
+	// DefaultMaxLedgerLag.
+	MaxLedgerLag uint32
+	// HealthCheckInterval is how often the health of the servers is checked.
+	// Checks are run lazily, when a request is sent after the interval
+	// passed. Defaults to DefaultHealthCheckInterval.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout is the timeout of checking the health of all servers.
+	// Defaults to DefaultHealthCheckTimeout.
+	HealthCheckTimeout time.Duration
+}
+
+// ServerStatus describes the state of a Horizon server used by a MultiClient
+type ServerStatus struct {
+	HorizonURL string
+	// Healthy is false if the /health endpoint of the server reported
+	// problems, the server could not be reached or a request sent to it after
+	// the last health check failed
+	Healthy bool
+	// LatestLedger is the latest ledger ingested by the server
+	// (history_latest_ledger in the root endpoint)
+	LatestLedger uint32
+	// LastChecked is the time of the last health check of the server
+	LastChecked time.Time
+}
+
+// MultiClient implements ClientInterface on top of multiple Horizon servers
+// (ex. instances of Horizon behind different load balancers). Requests are
+// sent to the first healthy server which is not lagging behind the other
+// servers by more than MaxLedgerLag ledgers. If the request fails because the
+// server is unavailable (network errors, 429 and 5xx responses) the server is
+// marked as unhealthy until the next health check and the request is retried
+// using the next server.
+//
+// Streams which fail are resumed using the next server, starting after the
+// last record received from the failed server, so that handlers don't
+// receive duplicate records. A stream only fails over when the stream of the
+// server returns an error so MaxConsecutiveFailures should be set in the
+// StreamPolicy of clients which reconnect streams.
+type MultiClient struct {
+	config  MultiClientConfig
+	lock    sync.Mutex
+	servers []ServerStatus
+}
+
+// NewMultiClient creates a MultiClient using the given config
+func NewMultiClient(config MultiClientConfig) (*MultiClient, error) {
+	if len(config.Clients) == 0 {
+		return nil, errors.New("at least one client is required")
+	}
+	if config.MaxLedgerLag == 0 {
+		config.MaxLedgerLag = DefaultMaxLedgerLag
+	}
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if config.HealthCheckTimeout == 0 {
+		config.HealthCheckTimeout = DefaultHealthCheckTimeout
+	}
+
+	servers := make([]ServerStatus, len(config.Clients))
+	for i, client := range config.Clients {
+		client.HorizonURL = client.fixHorizonURL()
+		servers[i] = ServerStatus{HorizonURL: client.HorizonURL, Healthy: true}
+	}
+	return &MultiClient{config: config, servers: servers}, nil
+}
+
+// Status returns the current state of all the servers
+func (m *MultiClient) Status() []ServerStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]ServerStatus{}, m.servers...)
+}
+
+// CheckHealth checks the health and the latest ledger of all the servers
+func (m *MultiClient) CheckHealth(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.HealthCheckTimeout)
+	defer cancel()
+
+	statuses := make([]ServerStatus, len(m.config.Clients))
+	var wg sync.WaitGroup
+	for i, client := range m.config.Clients {
+		wg.Add(1)
+		go func(i int, client *Client) {
+			defer wg.Done()
+			statuses[i] = checkServer(ctx, client)
+		}(i, client)
+	}
+	wg.Wait()
+	if ctx.Err() != nil && ctx.Err() != context.DeadlineExceeded {
+		// the check was cancelled by the caller, the results are meaningless
+		return
+	}
+
+	m.lock.Lock()
+	m.servers = statuses
+	m.lock.Unlock()
+}
+
+// checkServer checks the /health endpoint and the latest ledger of the server.
+// Servers which don't have the /health endpoint are considered healthy if the
+// root endpoint responds.
+func checkServer(ctx context.Context, client *Client) ServerStatus {
+	status := ServerStatus{
+		HorizonURL:  client.HorizonURL,
+		LastChecked: client.clock.Now(),
+	}
+
+	req, err := http.NewRequest("GET", client.HorizonURL+"health", nil)
+	if err != nil {
+		return status
+	}
+	client.setDefaultClient()
+	client.setClientAppHeaders(req)
+	resp, err := client.HTTP.Do(req.WithContext(ctx))
+	if err != nil {
+		return status
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return status
+	}
+
+	// health checks are not retried
+	noRetries := *client
+	noRetries.RetryPolicy = RetryPolicy{}
+	root, err := noRetries.RootContext(ctx)
+	if err != nil {
+		return status
+	}
+	status.Healthy = true
+	if root.HorizonSequence > 0 {
+		status.LatestLedger = uint32(root.HorizonSequence)
+	}
+	return status
+}
+
+// candidates returns the indexes of clients in the order in which they should
+// be used to send a request: healthy servers which are not lagging behind
+// first followed by the other servers which are used as a last resort
+func (m *MultiClient) candidates(ctx context.Context) []int {
+	m.lock.Lock()
+	stale := false
+	for _, server := range m.servers {
+		if server.LastChecked.IsZero() ||
+			m.config.Clients[0].clock.Now().Sub(server.LastChecked) >= m.config.HealthCheckInterval {
+			stale = true
+			break
+		}
+	}
+	m.lock.Unlock()
+	if stale {
+		m.CheckHealth(ctx)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var latestLedger uint32
+	for _, server := range m.servers {
+		if server.Healthy && server.LatestLedger > latestLedger {
+			latestLedger = server.LatestLedger
+		}
+	}
+
+	preferred := make([]int, 0, len(m.servers))
+	var others []int
+	for i, server := range m.servers {
+		if server.Healthy && server.LatestLedger+m.config.MaxLedgerLag >= latestLedger {
+			preferred = append(preferred, i)
+		} else {
+			others = append(others, i)
+		}
+	}
+	return append(preferred, others...)
+}
+
+func (m *MultiClient) markUnhealthy(i int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.servers[i].Healthy = false
+}
+
+// isUnavailable returns true if err means the server could not handle the
+// request (as opposed to errors caused by the request itself)
+func isUnavailable(err error) bool {
+	if hErr := GetError(err); hErr != nil {
+		return hErr.Response.StatusCode == http.StatusTooManyRequests ||
+			hErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	_, ok := errors.Cause(err).(*url.Error)
+	return ok
+}
+
+// do calls f with the clients of the candidate servers until f succeeds or
+// fails because of an error which isn't caused by the server being
+// unavailable
+func (m *MultiClient) do(ctx context.Context, f func(c *Client) error) error {
+	return m.doWith(ctx, isUnavailable, f)
+}
+
+// submit is like do but it doesn't resubmit transactions to other servers
+// when the submission timed out, the transaction could still be included in
+// the ledger
+func (m *MultiClient) submit(ctx context.Context, f func(c *Client) error) error {
+	return m.doWith(ctx, func(err error) bool {
+		if hErr := GetError(err); hErr != nil && hErr.Response.StatusCode == http.StatusGatewayTimeout {
+			return false
+		}
+		return isUnavailable(err)
+	}, f)
+}
+
+func (m *MultiClient) doWith(ctx context.Context, failover func(error) bool, f func(c *Client) error) error {
+	var err error
+	for _, i := range m.candidates(ctx) {
+		err = f(m.config.Clients[i])
+		if err == nil || ctx.Err() != nil || !failover(err) {
+			return err
+		}
+		m.markUnhealthy(i)
+	}
+	return err
+}
+
+// stream calls f with the clients of the candidate servers until f returns
+// without an error (which means ctx is done). f must increment received for
+// every record and resume the stream after the last record it received. The
+// error is returned when all the servers failed without receiving any records.
+func (m *MultiClient) stream(ctx context.Context, received *int, f func(c *Client) error) error {
+	for {
+		start := *received
+		var err error
+		for _, i := range m.candidates(ctx) {
+			if err = f(m.config.Clients[i]); err == nil || ctx.Err() != nil {
+				return nil
+			}
+			m.markUnhealthy(i)
+		}
+		if *received == start {
+			return err
+		}
+	}
+}
+
+// rebaseLink changes the Horizon server in a link returned by one of the
+// servers to the server of the given client. Links to other hosts are not
+// changed.
+func (m *MultiClient) rebaseLink(c *Client, link string) string {
+	for _, client := range m.config.Clients {
+		if strings.HasPrefix(link, client.HorizonURL) {
+			return c.HorizonURL + strings.TrimPrefix(link, client.HorizonURL)
+		}
+	}
+	return link
+}
+
+// Accounts is the same as Client.Accounts.
+func (m *MultiClient) Accounts(request AccountsRequest) (result hProtocol.AccountsPage, err error) {
+	return m.AccountsContext(context.Background(), request)
+}
+
+// AccountsContext is the same as Client.AccountsContext.
+func (m *MultiClient) AccountsContext(ctx context.Context, request AccountsRequest) (result hProtocol.AccountsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.AccountsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// AccountDetail is the same as Client.AccountDetail.
+func (m *MultiClient) AccountDetail(request AccountRequest) (result hProtocol.Account, err error) {
+	return m.AccountDetailContext(context.Background(), request)
+}
+
+// AccountDetailContext is the same as Client.AccountDetailContext.
+func (m *MultiClient) AccountDetailContext(ctx context.Context, request AccountRequest) (result hProtocol.Account, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.AccountDetailContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// AccountData is the same as Client.AccountData.
+func (m *MultiClient) AccountData(request AccountRequest) (result hProtocol.AccountData, err error) {
+	return m.AccountDataContext(context.Background(), request)
+}
+
+// AccountDataContext is the same as Client.AccountDataContext.
+func (m *MultiClient) AccountDataContext(ctx context.Context, request AccountRequest) (result hProtocol.AccountData, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.AccountDataContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Effects is the same as Client.Effects.
+func (m *MultiClient) Effects(request EffectRequest) (result effects.EffectsPage, err error) {
+	return m.EffectsContext(context.Background(), request)
+}
+
+// EffectsContext is the same as Client.EffectsContext.
+func (m *MultiClient) EffectsContext(ctx context.Context, request EffectRequest) (result effects.EffectsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.EffectsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Assets is the same as Client.Assets.
+func (m *MultiClient) Assets(request AssetRequest) (result hProtocol.AssetsPage, err error) {
+	return m.AssetsContext(context.Background(), request)
+}
+
+// AssetsContext is the same as Client.AssetsContext.
+func (m *MultiClient) AssetsContext(ctx context.Context, request AssetRequest) (result hProtocol.AssetsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.AssetsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Ledgers is the same as Client.Ledgers.
+func (m *MultiClient) Ledgers(request LedgerRequest) (result hProtocol.LedgersPage, err error) {
+	return m.LedgersContext(context.Background(), request)
+}
+
+// LedgersContext is the same as Client.LedgersContext.
+func (m *MultiClient) LedgersContext(ctx context.Context, request LedgerRequest) (result hProtocol.LedgersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.LedgersContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// LedgerDetail is the same as Client.LedgerDetail.
+func (m *MultiClient) LedgerDetail(sequence uint32) (result hProtocol.Ledger, err error) {
+	return m.LedgerDetailContext(context.Background(), sequence)
+}
+
+// LedgerDetailContext is the same as Client.LedgerDetailContext.
+func (m *MultiClient) LedgerDetailContext(ctx context.Context, sequence uint32) (result hProtocol.Ledger, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.LedgerDetailContext(ctx, sequence)
+		return err
+	})
+	return
+}
+
+// FeeStats is the same as Client.FeeStats.
+func (m *MultiClient) FeeStats() (result hProtocol.FeeStats, err error) {
+	return m.FeeStatsContext(context.Background())
+}
+
+// FeeStatsContext is the same as Client.FeeStatsContext.
+func (m *MultiClient) FeeStatsContext(ctx context.Context) (result hProtocol.FeeStats, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.FeeStatsContext(ctx)
+		return err
+	})
+	return
+}
+
+// Offers is the same as Client.Offers.
+func (m *MultiClient) Offers(request OfferRequest) (result hProtocol.OffersPage, err error) {
+	return m.OffersContext(context.Background(), request)
+}
+
+// OffersContext is the same as Client.OffersContext.
+func (m *MultiClient) OffersContext(ctx context.Context, request OfferRequest) (result hProtocol.OffersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.OffersContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// OfferDetails is the same as Client.OfferDetails.
+func (m *MultiClient) OfferDetails(offerID string) (offer hProtocol.Offer, err error) {
+	return m.OfferDetailsContext(context.Background(), offerID)
+}
+
+// OfferDetailsContext is the same as Client.OfferDetailsContext.
+func (m *MultiClient) OfferDetailsContext(ctx context.Context, offerID string) (offer hProtocol.Offer, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		offer, err = c.OfferDetailsContext(ctx, offerID)
+		return err
+	})
+	return
+}
+
+// Operations is the same as Client.Operations.
+func (m *MultiClient) Operations(request OperationRequest) (result operations.OperationsPage, err error) {
+	return m.OperationsContext(context.Background(), request)
+}
+
+// OperationsContext is the same as Client.OperationsContext.
+func (m *MultiClient) OperationsContext(ctx context.Context, request OperationRequest) (result operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.OperationsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// OperationDetail is the same as Client.OperationDetail.
+func (m *MultiClient) OperationDetail(id string) (result operations.Operation, err error) {
+	return m.OperationDetailContext(context.Background(), id)
+}
+
+// OperationDetailContext is the same as Client.OperationDetailContext.
+func (m *MultiClient) OperationDetailContext(ctx context.Context, id string) (result operations.Operation, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.OperationDetailContext(ctx, id)
+		return err
+	})
+	return
+}
+
+// SubmitTransactionXDR is the same as Client.SubmitTransactionXDR.
+func (m *MultiClient) SubmitTransactionXDR(transactionXdr string) (result hProtocol.Transaction, err error) {
+	return m.SubmitTransactionXDRContext(context.Background(), transactionXdr)
+}
+
+// SubmitTransactionXDRContext is the same as Client.SubmitTransactionXDRContext.
+func (m *MultiClient) SubmitTransactionXDRContext(ctx context.Context, transactionXdr string) (result hProtocol.Transaction, err error) {
+	err = m.submit(ctx, func(c *Client) error {
+		result, err = c.SubmitTransactionXDRContext(ctx, transactionXdr)
+		return err
+	})
+	return
+}
+
+// SubmitFeeBumpTransactionWithOptions is the same as Client.SubmitFeeBumpTransactionWithOptions.
+func (m *MultiClient) SubmitFeeBumpTransactionWithOptions(transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (result hProtocol.Transaction, err error) {
+	return m.SubmitFeeBumpTransactionWithOptionsContext(context.Background(), transaction, opts)
+}
+
+// SubmitFeeBumpTransactionWithOptionsContext is the same as Client.SubmitFeeBumpTransactionWithOptionsContext.
+func (m *MultiClient) SubmitFeeBumpTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction, opts SubmitTxOpts) (result hProtocol.Transaction, err error) {
+	err = m.submit(ctx, func(c *Client) error {
+		result, err = c.SubmitFeeBumpTransactionWithOptionsContext(ctx, transaction, opts)
+		return err
+	})
+	return
+}
+
+// SubmitTransactionWithOptions is the same as Client.SubmitTransactionWithOptions.
+func (m *MultiClient) SubmitTransactionWithOptions(transaction *txnbuild.Transaction, opts SubmitTxOpts) (result hProtocol.Transaction, err error) {
+	return m.SubmitTransactionWithOptionsContext(context.Background(), transaction, opts)
+}
+
+// SubmitTransactionWithOptionsContext is the same as Client.SubmitTransactionWithOptionsContext.
+func (m *MultiClient) SubmitTransactionWithOptionsContext(ctx context.Context, transaction *txnbuild.Transaction, opts SubmitTxOpts) (result hProtocol.Transaction, err error) {
+	err = m.submit(ctx, func(c *Client) error {
+		result, err = c.SubmitTransactionWithOptionsContext(ctx, transaction, opts)
+		return err
+	})
+	return
+}
+
+// SubmitFeeBumpTransaction is the same as Client.SubmitFeeBumpTransaction.
+func (m *MultiClient) SubmitFeeBumpTransaction(transaction *txnbuild.FeeBumpTransaction) (result hProtocol.Transaction, err error) {
+	return m.SubmitFeeBumpTransactionContext(context.Background(), transaction)
+}
+
+// SubmitFeeBumpTransactionContext is the same as Client.SubmitFeeBumpTransactionContext.
+func (m *MultiClient) SubmitFeeBumpTransactionContext(ctx context.Context, transaction *txnbuild.FeeBumpTransaction) (result hProtocol.Transaction, err error) {
+	err = m.submit(ctx, func(c *Client) error {
+		result, err = c.SubmitFeeBumpTransactionContext(ctx, transaction)
+		return err
+	})
+	return
+}
+
+// SubmitTransaction is the same as Client.SubmitTransaction.
+func (m *MultiClient) SubmitTransaction(transaction *txnbuild.Transaction) (result hProtocol.Transaction, err error) {
+	return m.SubmitTransactionContext(context.Background(), transaction)
+}
+
+// SubmitTransactionContext is the same as Client.SubmitTransactionContext.
+func (m *MultiClient) SubmitTransactionContext(ctx context.Context, transaction *txnbuild.Transaction) (result hProtocol.Transaction, err error) {
+	err = m.submit(ctx, func(c *Client) error {
+		result, err = c.SubmitTransactionContext(ctx, transaction)
+		return err
+	})
+	return
+}
+
+// Transactions is the same as Client.Transactions.
+func (m *MultiClient) Transactions(request TransactionRequest) (result hProtocol.TransactionsPage, err error) {
+	return m.TransactionsContext(context.Background(), request)
+}
+
+// TransactionsContext is the same as Client.TransactionsContext.
+func (m *MultiClient) TransactionsContext(ctx context.Context, request TransactionRequest) (result hProtocol.TransactionsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.TransactionsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// TransactionDetail is the same as Client.TransactionDetail.
+func (m *MultiClient) TransactionDetail(txHash string) (result hProtocol.Transaction, err error) {
+	return m.TransactionDetailContext(context.Background(), txHash)
+}
+
+// TransactionDetailContext is the same as Client.TransactionDetailContext.
+func (m *MultiClient) TransactionDetailContext(ctx context.Context, txHash string) (result hProtocol.Transaction, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.TransactionDetailContext(ctx, txHash)
+		return err
+	})
+	return
+}
+
+// OrderBook is the same as Client.OrderBook.
+func (m *MultiClient) OrderBook(request OrderBookRequest) (result hProtocol.OrderBookSummary, err error) {
+	return m.OrderBookContext(context.Background(), request)
+}
+
+// OrderBookContext is the same as Client.OrderBookContext.
+func (m *MultiClient) OrderBookContext(ctx context.Context, request OrderBookRequest) (result hProtocol.OrderBookSummary, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.OrderBookContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Paths is the same as Client.Paths.
+func (m *MultiClient) Paths(request PathsRequest) (result hProtocol.PathsPage, err error) {
+	return m.PathsContext(context.Background(), request)
+}
+
+// PathsContext is the same as Client.PathsContext.
+func (m *MultiClient) PathsContext(ctx context.Context, request PathsRequest) (result hProtocol.PathsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.PathsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Payments is the same as Client.Payments.
+func (m *MultiClient) Payments(request OperationRequest) (result operations.OperationsPage, err error) {
+	return m.PaymentsContext(context.Background(), request)
+}
+
+// PaymentsContext is the same as Client.PaymentsContext.
+func (m *MultiClient) PaymentsContext(ctx context.Context, request OperationRequest) (result operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.PaymentsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// TradeAggregations is the same as Client.TradeAggregations.
+func (m *MultiClient) TradeAggregations(request TradeAggregationRequest) (result hProtocol.TradeAggregationsPage, err error) {
+	return m.TradeAggregationsContext(context.Background(), request)
+}
+
+// TradeAggregationsContext is the same as Client.TradeAggregationsContext.
+func (m *MultiClient) TradeAggregationsContext(ctx context.Context, request TradeAggregationRequest) (result hProtocol.TradeAggregationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.TradeAggregationsContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Trades is the same as Client.Trades.
+func (m *MultiClient) Trades(request TradeRequest) (result hProtocol.TradesPage, err error) {
+	return m.TradesContext(context.Background(), request)
+}
+
+// TradesContext is the same as Client.TradesContext.
+func (m *MultiClient) TradesContext(ctx context.Context, request TradeRequest) (result hProtocol.TradesPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.TradesContext(ctx, request)
+		return err
+	})
+	return
+}
+
+// Fund is the same as Client.Fund.
+func (m *MultiClient) Fund(addr string) (result hProtocol.Transaction, err error) {
+	return m.FundContext(context.Background(), addr)
+}
+
+// FundContext is the same as Client.FundContext.
+func (m *MultiClient) FundContext(ctx context.Context, addr string) (result hProtocol.Transaction, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.FundContext(ctx, addr)
+		return err
+	})
+	return
+}
+
+// StreamTransactions is the same as Client.StreamTransactions. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamTransactions(ctx, request, func(tx hProtocol.Transaction) {
+			request.Cursor = tx.PagingToken()
+			received++
+			handler(tx)
+		})
+	})
+}
+
+// StreamTrades is the same as Client.StreamTrades. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamTrades(ctx context.Context, request TradeRequest, handler TradeHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamTrades(ctx, request, func(trade hProtocol.Trade) {
+			request.Cursor = trade.PagingToken()
+			received++
+			handler(trade)
+		})
+	})
+}
+
+// StreamEffects is the same as Client.StreamEffects. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamEffects(ctx context.Context, request EffectRequest, handler EffectHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamEffects(ctx, request, func(effect effects.Effect) {
+			request.Cursor = effect.PagingToken()
+			received++
+			handler(effect)
+		})
+	})
+}
+
+// StreamOperations is the same as Client.StreamOperations. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamOperations(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamOperations(ctx, request, func(op operations.Operation) {
+			request.Cursor = op.PagingToken()
+			received++
+			handler(op)
+		})
+	})
+}
+
+// StreamPayments is the same as Client.StreamPayments. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamPayments(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamPayments(ctx, request, func(op operations.Operation) {
+			request.Cursor = op.PagingToken()
+			received++
+			handler(op)
+		})
+	})
+}
+
+// StreamOffers is the same as Client.StreamOffers. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamOffers(ctx context.Context, request OfferRequest, handler OfferHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamOffers(ctx, request, func(offer hProtocol.Offer) {
+			request.Cursor = offer.PagingToken()
+			received++
+			handler(offer)
+		})
+	})
+}
+
+// StreamLedgers is the same as Client.StreamLedgers. The stream is resumed using the next
+// server if it fails.
+func (m *MultiClient) StreamLedgers(ctx context.Context, request LedgerRequest, handler LedgerHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamLedgers(ctx, request, func(ledger hProtocol.Ledger) {
+			request.Cursor = ledger.PagingToken()
+			received++
+			handler(ledger)
+		})
+	})
+}
+
+// StreamOrderBooks is the same as Client.StreamOrderBooks. The stream is reconnected to the next
+// server if it fails.
+func (m *MultiClient) StreamOrderBooks(ctx context.Context, request OrderBookRequest, handler OrderBookHandler) error {
+	received := 0
+	return m.stream(ctx, &received, func(c *Client) error {
+		return c.StreamOrderBooks(ctx, request, func(summary hProtocol.OrderBookSummary) {
+			received++
+			handler(summary)
+		})
+	})
+}
+
+// Root is the same as Client.Root.
+func (m *MultiClient) Root() (result hProtocol.Root, err error) {
+	return m.RootContext(context.Background())
+}
+
+// RootContext is the same as Client.RootContext.
+func (m *MultiClient) RootContext(ctx context.Context) (result hProtocol.Root, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.RootContext(ctx)
+		return err
+	})
+	return
+}
+
+// NextAccountsPage is the same as Client.NextAccountsPage.
+func (m *MultiClient) NextAccountsPage(page hProtocol.AccountsPage) (next hProtocol.AccountsPage, err error) {
+	return m.NextAccountsPageContext(context.Background(), page)
+}
+
+// NextAccountsPageContext is the same as Client.NextAccountsPageContext.
+func (m *MultiClient) NextAccountsPageContext(ctx context.Context, page hProtocol.AccountsPage) (next hProtocol.AccountsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextAccountsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextAssetsPage is the same as Client.NextAssetsPage.
+func (m *MultiClient) NextAssetsPage(page hProtocol.AssetsPage) (next hProtocol.AssetsPage, err error) {
+	return m.NextAssetsPageContext(context.Background(), page)
+}
+
+// NextAssetsPageContext is the same as Client.NextAssetsPageContext.
+func (m *MultiClient) NextAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (next hProtocol.AssetsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextAssetsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevAssetsPage is the same as Client.PrevAssetsPage.
+func (m *MultiClient) PrevAssetsPage(page hProtocol.AssetsPage) (prev hProtocol.AssetsPage, err error) {
+	return m.PrevAssetsPageContext(context.Background(), page)
+}
+
+// PrevAssetsPageContext is the same as Client.PrevAssetsPageContext.
+func (m *MultiClient) PrevAssetsPageContext(ctx context.Context, page hProtocol.AssetsPage) (prev hProtocol.AssetsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevAssetsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextLedgersPage is the same as Client.NextLedgersPage.
+func (m *MultiClient) NextLedgersPage(page hProtocol.LedgersPage) (next hProtocol.LedgersPage, err error) {
+	return m.NextLedgersPageContext(context.Background(), page)
+}
+
+// NextLedgersPageContext is the same as Client.NextLedgersPageContext.
+func (m *MultiClient) NextLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (next hProtocol.LedgersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextLedgersPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevLedgersPage is the same as Client.PrevLedgersPage.
+func (m *MultiClient) PrevLedgersPage(page hProtocol.LedgersPage) (prev hProtocol.LedgersPage, err error) {
+	return m.PrevLedgersPageContext(context.Background(), page)
+}
+
+// PrevLedgersPageContext is the same as Client.PrevLedgersPageContext.
+func (m *MultiClient) PrevLedgersPageContext(ctx context.Context, page hProtocol.LedgersPage) (prev hProtocol.LedgersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevLedgersPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextEffectsPage is the same as Client.NextEffectsPage.
+func (m *MultiClient) NextEffectsPage(page effects.EffectsPage) (next effects.EffectsPage, err error) {
+	return m.NextEffectsPageContext(context.Background(), page)
+}
+
+// NextEffectsPageContext is the same as Client.NextEffectsPageContext.
+func (m *MultiClient) NextEffectsPageContext(ctx context.Context, page effects.EffectsPage) (next effects.EffectsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextEffectsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevEffectsPage is the same as Client.PrevEffectsPage.
+func (m *MultiClient) PrevEffectsPage(page effects.EffectsPage) (prev effects.EffectsPage, err error) {
+	return m.PrevEffectsPageContext(context.Background(), page)
+}
+
+// PrevEffectsPageContext is the same as Client.PrevEffectsPageContext.
+func (m *MultiClient) PrevEffectsPageContext(ctx context.Context, page effects.EffectsPage) (prev effects.EffectsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevEffectsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextTransactionsPage is the same as Client.NextTransactionsPage.
+func (m *MultiClient) NextTransactionsPage(page hProtocol.TransactionsPage) (next hProtocol.TransactionsPage, err error) {
+	return m.NextTransactionsPageContext(context.Background(), page)
+}
+
+// NextTransactionsPageContext is the same as Client.NextTransactionsPageContext.
+func (m *MultiClient) NextTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (next hProtocol.TransactionsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextTransactionsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevTransactionsPage is the same as Client.PrevTransactionsPage.
+func (m *MultiClient) PrevTransactionsPage(page hProtocol.TransactionsPage) (prev hProtocol.TransactionsPage, err error) {
+	return m.PrevTransactionsPageContext(context.Background(), page)
+}
+
+// PrevTransactionsPageContext is the same as Client.PrevTransactionsPageContext.
+func (m *MultiClient) PrevTransactionsPageContext(ctx context.Context, page hProtocol.TransactionsPage) (prev hProtocol.TransactionsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevTransactionsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextOperationsPage is the same as Client.NextOperationsPage.
+func (m *MultiClient) NextOperationsPage(page operations.OperationsPage) (next operations.OperationsPage, err error) {
+	return m.NextOperationsPageContext(context.Background(), page)
+}
+
+// NextOperationsPageContext is the same as Client.NextOperationsPageContext.
+func (m *MultiClient) NextOperationsPageContext(ctx context.Context, page operations.OperationsPage) (next operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextOperationsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevOperationsPage is the same as Client.PrevOperationsPage.
+func (m *MultiClient) PrevOperationsPage(page operations.OperationsPage) (prev operations.OperationsPage, err error) {
+	return m.PrevOperationsPageContext(context.Background(), page)
+}
+
+// PrevOperationsPageContext is the same as Client.PrevOperationsPageContext.
+func (m *MultiClient) PrevOperationsPageContext(ctx context.Context, page operations.OperationsPage) (prev operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevOperationsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextPaymentsPage is the same as Client.NextPaymentsPage.
+func (m *MultiClient) NextPaymentsPage(page operations.OperationsPage) (next operations.OperationsPage, err error) {
+	return m.NextPaymentsPageContext(context.Background(), page)
+}
+
+// NextPaymentsPageContext is the same as Client.NextPaymentsPageContext.
+func (m *MultiClient) NextPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (next operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextPaymentsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevPaymentsPage is the same as Client.PrevPaymentsPage.
+func (m *MultiClient) PrevPaymentsPage(page operations.OperationsPage) (prev operations.OperationsPage, err error) {
+	return m.PrevPaymentsPageContext(context.Background(), page)
+}
+
+// PrevPaymentsPageContext is the same as Client.PrevPaymentsPageContext.
+func (m *MultiClient) PrevPaymentsPageContext(ctx context.Context, page operations.OperationsPage) (prev operations.OperationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevPaymentsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextOffersPage is the same as Client.NextOffersPage.
+func (m *MultiClient) NextOffersPage(page hProtocol.OffersPage) (next hProtocol.OffersPage, err error) {
+	return m.NextOffersPageContext(context.Background(), page)
+}
+
+// NextOffersPageContext is the same as Client.NextOffersPageContext.
+func (m *MultiClient) NextOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (next hProtocol.OffersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextOffersPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevOffersPage is the same as Client.PrevOffersPage.
+func (m *MultiClient) PrevOffersPage(page hProtocol.OffersPage) (prev hProtocol.OffersPage, err error) {
+	return m.PrevOffersPageContext(context.Background(), page)
+}
+
+// PrevOffersPageContext is the same as Client.PrevOffersPageContext.
+func (m *MultiClient) PrevOffersPageContext(ctx context.Context, page hProtocol.OffersPage) (prev hProtocol.OffersPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevOffersPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// NextTradesPage is the same as Client.NextTradesPage.
+func (m *MultiClient) NextTradesPage(page hProtocol.TradesPage) (next hProtocol.TradesPage, err error) {
+	return m.NextTradesPageContext(context.Background(), page)
+}
+
+// NextTradesPageContext is the same as Client.NextTradesPageContext.
+func (m *MultiClient) NextTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (next hProtocol.TradesPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextTradesPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevTradesPage is the same as Client.PrevTradesPage.
+func (m *MultiClient) PrevTradesPage(page hProtocol.TradesPage) (prev hProtocol.TradesPage, err error) {
+	return m.PrevTradesPageContext(context.Background(), page)
+}
+
+// PrevTradesPageContext is the same as Client.PrevTradesPageContext.
+func (m *MultiClient) PrevTradesPageContext(ctx context.Context, page hProtocol.TradesPage) (prev hProtocol.TradesPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevTradesPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// HomeDomainForAccount is the same as Client.HomeDomainForAccount.
+func (m *MultiClient) HomeDomainForAccount(aid string) (result string, err error) {
+	return m.HomeDomainForAccountContext(context.Background(), aid)
+}
+
+// HomeDomainForAccountContext is the same as Client.HomeDomainForAccountContext.
+func (m *MultiClient) HomeDomainForAccountContext(ctx context.Context, aid string) (result string, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		result, err = c.HomeDomainForAccountContext(ctx, aid)
+		return err
+	})
+	return
+}
+
+// NextTradeAggregationsPage is the same as Client.NextTradeAggregationsPage.
+func (m *MultiClient) NextTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (next hProtocol.TradeAggregationsPage, err error) {
+	return m.NextTradeAggregationsPageContext(context.Background(), page)
+}
+
+// NextTradeAggregationsPageContext is the same as Client.NextTradeAggregationsPageContext.
+func (m *MultiClient) NextTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (next hProtocol.TradeAggregationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Next.Href = m.rebaseLink(c, page.Links.Next.Href)
+		next, err = c.NextTradeAggregationsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// PrevTradeAggregationsPage is the same as Client.PrevTradeAggregationsPage.
+func (m *MultiClient) PrevTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (prev hProtocol.TradeAggregationsPage, err error) {
+	return m.PrevTradeAggregationsPageContext(context.Background(), page)
+}
+
+// PrevTradeAggregationsPageContext is the same as Client.PrevTradeAggregationsPageContext.
+func (m *MultiClient) PrevTradeAggregationsPageContext(ctx context.Context, page hProtocol.TradeAggregationsPage) (prev hProtocol.TradeAggregationsPage, err error) {
+	err = m.do(ctx, func(c *Client) error {
+		page.Links.Prev.Href = m.rebaseLink(c, page.Links.Prev.Href)
+		prev, err = c.PrevTradeAggregationsPageContext(ctx, page)
+		return err
+	})
+	return
+}
+
+// ensure that the MultiClient implements ClientInterface
+var _ ClientInterface = &MultiClient{}
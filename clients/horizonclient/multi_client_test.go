@@ -0,0 +1,201 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rootWithLedger(ledger uint32) string {
+	return fmt.Sprintf(`{"history_latest_ledger": %d, "network_passphrase": "Test SDF Network ; September 2015"}`, ledger)
+}
+
+func mockServer(horizonURL string, ledger uint32) (*Client, *httptest.Client) {
+	hmock := httptest.NewClient()
+	hmock.On("GET", horizonURL+"health").Return(stringResponder(http.StatusOK, "{}"))
+	hmock.On("GET", horizonURL).Return(stringResponder(http.StatusOK, rootWithLedger(ledger)))
+	return &Client{HorizonURL: horizonURL, HTTP: hmock}, hmock
+}
+
+var unavailableResponse = `{
+  "title": "Service Unavailable",
+  "status": 503
+}`
+
+func TestNewMultiClient(t *testing.T) {
+	_, err := NewMultiClient(MultiClientConfig{})
+	assert.EqualError(t, err, "at least one client is required")
+
+	client, err := NewMultiClient(MultiClientConfig{Clients: []*Client{{HorizonURL: "https://a"}}})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultMaxLedgerLag), client.config.MaxLedgerLag)
+	assert.Equal(t, DefaultHealthCheckInterval, client.config.HealthCheckInterval)
+	assert.Equal(t, DefaultHealthCheckTimeout, client.config.HealthCheckTimeout)
+	assert.Equal(t, []ServerStatus{{HorizonURL: "https://a/", Healthy: true}}, client.Status())
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	a, amock := mockServer("https://a/", 100)
+	b, bmock := mockServer("https://b/", 100)
+	client, err := NewMultiClient(MultiClientConfig{Clients: []*Client{a, b}})
+	require.NoError(t, err)
+
+	acalls, bcalls := 0, 0
+	amock.On("GET", "https://a/ledgers/1").Return(sequenceResponder(
+		&acalls,
+		stringResponder(http.StatusServiceUnavailable, unavailableResponse),
+	))
+	bmock.On("GET", "https://b/ledgers/1").Return(sequenceResponder(
+		&bcalls,
+		stringResponder(http.StatusOK, ledgerResponse),
+	))
+
+	ledger, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(69859), ledger.Sequence)
+	assert.Equal(t, 1, acalls)
+	assert.Equal(t, 1, bcalls)
+
+	status := client.Status()
+	assert.False(t, status[0].Healthy)
+	assert.True(t, status[1].Healthy)
+	assert.Equal(t, uint32(100), status[1].LatestLedger)
+
+	// the unhealthy server is not used until the next health check
+	ledger, err = client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, acalls)
+	assert.Equal(t, 2, bcalls)
+
+	// network errors
+	client.CheckHealth(context.Background())
+	acalls = 0
+	amock.On("GET", "https://a/ledgers/1").Return(sequenceResponder(
+		&acalls,
+		func(*http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("connection refused")
+		},
+	))
+	_, err = client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, acalls)
+	assert.Equal(t, 3, bcalls)
+
+	// errors caused by the request are returned without failover
+	client.CheckHealth(context.Background())
+	amock.On("GET", "https://a/ledgers/1").Return(stringResponder(http.StatusNotFound, notFoundResponse))
+	_, err = client.LedgerDetail(1)
+	hErr := GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusNotFound, hErr.Response.StatusCode)
+	assert.Equal(t, 3, bcalls)
+
+	// the last error is returned when all servers are unavailable
+	amock.On("GET", "https://a/ledgers/1").Return(stringResponder(http.StatusServiceUnavailable, unavailableResponse))
+	bmock.On("GET", "https://b/ledgers/1").Return(stringResponder(http.StatusBadGateway, `{"title": "Bad Gateway", "status": 502}`))
+	_, err = client.LedgerDetail(1)
+	hErr = GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusBadGateway, hErr.Response.StatusCode)
+}
+
+func TestMultiClientHealthChecks(t *testing.T) {
+	a, amock := mockServer("https://a/", 90)
+	b, _ := mockServer("https://b/", 100)
+	c, cmock := mockServer("https://c/", 100)
+	client, err := NewMultiClient(MultiClientConfig{Clients: []*Client{a, b, c}})
+	require.NoError(t, err)
+	cmock.On("GET", "https://c/health").Return(stringResponder(http.StatusServiceUnavailable, "{}"))
+
+	client.CheckHealth(context.Background())
+	status := client.Status()
+	assert.True(t, status[0].Healthy)
+	assert.Equal(t, uint32(90), status[0].LatestLedger)
+	assert.True(t, status[1].Healthy)
+	assert.False(t, status[2].Healthy)
+
+	// a is lagging behind so it's used only when b fails, c is unhealthy so
+	// it's the last resort
+	assert.Equal(t, []int{1, 0, 2}, client.candidates(context.Background()))
+
+	// servers without the health endpoint are healthy if the root responds
+	amock.On("GET", "https://a/health").Return(stringResponder(http.StatusNotFound, notFoundResponse))
+	amock.On("GET", "https://a/").Return(stringResponder(http.StatusOK, rootWithLedger(98)))
+	client.CheckHealth(context.Background())
+	assert.Equal(t, []int{0, 1, 2}, client.candidates(context.Background()))
+
+	amock.On("GET", "https://a/").Return(stringResponder(http.StatusInternalServerError, "{}"))
+	client.CheckHealth(context.Background())
+	assert.False(t, client.Status()[0].Healthy)
+}
+
+func TestMultiClientNextPage(t *testing.T) {
+	a, amock := mockServer("https://a/", 100)
+	b, bmock := mockServer("https://b/", 100)
+	client, err := NewMultiClient(MultiClientConfig{Clients: []*Client{a, b}})
+	require.NoError(t, err)
+
+	amock.On("GET", "https://a/transactions").Return(stringResponder(http.StatusServiceUnavailable, unavailableResponse))
+	bmock.On("GET", "https://b/transactions").Return(stringResponder(http.StatusOK, transactionsPage("1")))
+
+	var page hProtocol.TransactionsPage
+	page.Links.Next.Href = "https://a/transactions?cursor=1"
+	next, err := client.NextTransactionsPage(page)
+	require.NoError(t, err)
+	require.Len(t, next.Embedded.Records, 1)
+	assert.Equal(t, "tx1", next.Embedded.Records[0].Hash)
+
+	assert.Equal(t, "https://b/transactions?cursor=1", client.rebaseLink(b, "https://a/transactions?cursor=1"))
+	assert.Equal(t, "https://c/transactions", client.rebaseLink(b, "https://c/transactions"))
+}
+
+func TestMultiClientStreamFailover(t *testing.T) {
+	a, amock := mockServer("https://a/", 100)
+	b, bmock := mockServer("https://b/", 100)
+	client, err := NewMultiClient(MultiClientConfig{Clients: []*Client{a, b}})
+	require.NoError(t, err)
+
+	// a streams the first transaction and then fails
+	acalls := 0
+	amock.On("GET", "https://a/transactions?cursor=now").Return(sequenceResponder(
+		&acalls,
+		stringResponder(http.StatusOK, "id: 1\ndata: {\"paging_token\": \"1\", \"hash\": \"tx1\"}\n\n"),
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+	))
+	amock.On("GET", "https://a/transactions?cursor=1").Return(
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+	)
+	// b must resume the stream after the last received transaction
+	bmock.On("GET", "https://b/transactions?cursor=1").Return(
+		stringResponder(http.StatusOK, "id: 2\ndata: {\"paging_token\": \"2\", \"hash\": \"tx2\"}\n\n"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var hashes []string
+	err = client.StreamTransactions(ctx, TransactionRequest{}, func(tx hProtocol.Transaction) {
+		hashes = append(hashes, tx.Hash)
+		if len(hashes) == 2 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx2"}, hashes)
+	assert.False(t, client.Status()[0].Healthy)
+
+	// the error is returned if no server streams any records
+	client.CheckHealth(context.Background())
+	bmock.On("GET", "https://b/transactions?cursor=now").Return(
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+	)
+	err = client.StreamTransactions(context.Background(), TransactionRequest{}, func(tx hProtocol.Transaction) {
+		t.Fatal("unexpected transaction")
+	})
+	assert.EqualError(t, err, "got bad HTTP status code 503")
+}
@@ -0,0 +1,34 @@
+package horizonclient
+
+import (
+	"context"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+)
+
+// SignerSets loads the signers and thresholds of the source accounts of the
+// transaction, which can be passed to txnbuild's Transaction.SignatureStatus
+// and Transaction.RemoveExtraneousSignatures.
+func (c *Client) SignerSets(transaction *txnbuild.Transaction) (map[string]txnbuild.SignerSet, error) {
+	return c.SignerSetsContext(context.Background(), transaction)
+}
+
+// SignerSetsContext is the same as SignerSets but it takes a context which can be used to cancel the
+// requests.
+func (c *Client) SignerSetsContext(ctx context.Context, transaction *txnbuild.Transaction) (map[string]txnbuild.SignerSet, error) {
+	sets := map[string]txnbuild.SignerSet{}
+	for _, accountID := range transaction.SourceAccounts() {
+		account, err := c.AccountDetailContext(ctx, AccountRequest{AccountID: accountID})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not load account %s", accountID)
+		}
+		sets[accountID] = txnbuild.SignerSet{
+			Signers:       account.SignerSummary(),
+			LowThreshold:  txnbuild.Threshold(account.Thresholds.LowThreshold),
+			MedThreshold:  txnbuild.Threshold(account.Thresholds.MedThreshold),
+			HighThreshold: txnbuild.Threshold(account.Thresholds.HighThreshold),
+		}
+	}
+	return sets, nil
+}
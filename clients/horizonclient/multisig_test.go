@@ -0,0 +1,73 @@
+package horizonclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerSets(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+
+	source, cosigner, other := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	hmock.On("GET", "https://localhost/accounts/"+source.Address()).ReturnJSON(http.StatusOK, hProtocol.Account{
+		AccountID:  source.Address(),
+		Sequence:   "1",
+		Thresholds: hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+		Signers: []hProtocol.Signer{
+			{Key: source.Address(), Weight: 1},
+			{Key: cosigner.Address(), Weight: 2},
+		},
+	})
+	hmock.On("GET", "https://localhost/accounts/"+other.Address()).ReturnString(http.StatusNotFound, notFoundResponse)
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+		Operations: []txnbuild.Operation{
+			&txnbuild.BumpSequence{BumpTo: 10},
+		},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, cosigner)
+	require.NoError(t, err)
+
+	sets, err := client.SignerSets(tx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]txnbuild.SignerSet{
+		source.Address(): {
+			Signers:       txnbuild.SignerSummary{source.Address(): 1, cosigner.Address(): 2},
+			LowThreshold:  1,
+			MedThreshold:  2,
+			HighThreshold: 3,
+		},
+	}, sets)
+
+	statuses, err := tx.SignatureStatus(network.TestNetworkPassphrase, sets)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Met())
+
+	tx, err = txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+		Operations: []txnbuild.Operation{
+			&txnbuild.BumpSequence{BumpTo: 10, SourceAccount: other.Address()},
+		},
+	})
+	require.NoError(t, err)
+	_, err = client.SignerSets(tx)
+	assert.Error(t, err)
+}
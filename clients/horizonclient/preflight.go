@@ -0,0 +1,300 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/stellar/go/amount"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+// maxOffersPageSize is the maximum page size of the offers endpoint.
+const maxOffersPageSize = 200
+
+// PreflightTransaction predicts the result codes Stellar Core would return if
+// the transaction was submitted now, using txnbuild's Transaction.Preflight.
+// Unless params.State is set, the accounts, trust lines, offers and data
+// entries used by the transaction are loaded from Horizon. The base fee and
+// base reserve of the latest ledger are used unless params sets them.
+// Signatures are only checked if params.NetworkPassphrase is set.
+func (c *Client) PreflightTransaction(
+	transaction *txnbuild.Transaction,
+	params txnbuild.PreflightParams,
+) (txnbuild.PreflightResult, error) {
+	return c.PreflightTransactionContext(context.Background(), transaction, params)
+}
+
+// PreflightTransactionContext is the same as PreflightTransaction but it takes a context which can be used
+// to cancel the requests.
+func (c *Client) PreflightTransactionContext(
+	ctx context.Context,
+	transaction *txnbuild.Transaction,
+	params txnbuild.PreflightParams,
+) (txnbuild.PreflightResult, error) {
+	if params.BaseFee == 0 || params.BaseReserve == 0 {
+		ledgers, err := c.LedgersContext(ctx, LedgerRequest{Order: OrderDesc, Limit: 1})
+		if err != nil {
+			return txnbuild.PreflightResult{}, errors.Wrap(err, "could not load latest ledger")
+		}
+		if len(ledgers.Embedded.Records) == 0 {
+			return txnbuild.PreflightResult{}, errors.New("could not load latest ledger")
+		}
+		latest := ledgers.Embedded.Records[0]
+		if params.BaseFee == 0 {
+			params.BaseFee = int64(latest.BaseFee)
+		}
+		if params.BaseReserve == 0 {
+			params.BaseReserve = int64(latest.BaseReserve)
+		}
+	}
+	if params.State == nil {
+		params.State = &ledgerState{
+			ctx:      ctx,
+			client:   c,
+			accounts: map[string]*hProtocol.Account{},
+		}
+	}
+	return transaction.Preflight(params)
+}
+
+// ledgerState is a txnbuild.LedgerState which loads ledger entries from
+// Horizon. Trust lines and data entries are part of the account resources, so
+// accounts are only loaded once.
+type ledgerState struct {
+	ctx    context.Context
+	client *Client
+	// accounts holds the loaded accounts. Accounts which don't exist are nil.
+	accounts map[string]*hProtocol.Account
+}
+
+func (s *ledgerState) account(accountID string) (*hProtocol.Account, error) {
+	if account, ok := s.accounts[accountID]; ok {
+		return account, nil
+	}
+	account, err := s.client.AccountDetailContext(s.ctx, AccountRequest{AccountID: accountID})
+	switch {
+	case IsNotFoundError(err):
+		s.accounts[accountID] = nil
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	s.accounts[accountID] = &account
+	return &account, nil
+}
+
+// Account implements txnbuild.LedgerState.
+func (s *ledgerState) Account(accountID string) (*xdr.AccountEntry, error) {
+	account, err := s.account(accountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+
+	entry := xdr.AccountEntry{
+		Thresholds: xdr.Thresholds{
+			0,
+			account.Thresholds.LowThreshold,
+			account.Thresholds.MedThreshold,
+			account.Thresholds.HighThreshold,
+		},
+		NumSubEntries: xdr.Uint32(account.SubentryCount),
+	}
+	if err = entry.AccountId.SetAddress(account.AccountID); err != nil {
+		return nil, errors.Wrap(err, "invalid account id")
+	}
+	sequence, err := strconv.ParseInt(account.Sequence, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sequence number")
+	}
+	entry.SeqNum = xdr.SequenceNumber(sequence)
+
+	var liabilities xdr.Liabilities
+	for _, balance := range account.Balances {
+		if balance.Type != "native" {
+			continue
+		}
+		if entry.Balance, err = parseAmount(balance.Balance); err != nil {
+			return nil, err
+		}
+		if liabilities.Buying, err = parseAmount(balance.BuyingLiabilities); err != nil {
+			return nil, err
+		}
+		if liabilities.Selling, err = parseAmount(balance.SellingLiabilities); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, signer := range account.Signers {
+		if signer.Key == account.AccountID {
+			entry.Thresholds[0] = byte(signer.Weight)
+			continue
+		}
+		var key xdr.SignerKey
+		if err = key.SetAddress(signer.Key); err != nil {
+			return nil, errors.Wrapf(err, "invalid signer %s", signer.Key)
+		}
+		entry.Signers = append(entry.Signers, xdr.Signer{Key: key, Weight: xdr.Uint32(signer.Weight)})
+	}
+
+	var flags xdr.AccountFlags
+	if account.Flags.AuthRequired {
+		flags |= xdr.AccountFlagsAuthRequiredFlag
+	}
+	if account.Flags.AuthRevocable {
+		flags |= xdr.AccountFlagsAuthRevocableFlag
+	}
+	if account.Flags.AuthImmutable {
+		flags |= xdr.AccountFlagsAuthImmutableFlag
+	}
+	if account.Flags.AuthClawbackEnabled {
+		flags |= xdr.AccountFlagsAuthClawbackEnabledFlag
+	}
+	entry.Flags = xdr.Uint32(flags)
+
+	entry.Ext = xdr.AccountEntryExt{
+		V: 1,
+		V1: &xdr.AccountEntryExtensionV1{
+			Liabilities: liabilities,
+			Ext: xdr.AccountEntryExtensionV1Ext{
+				V: 2,
+				V2: &xdr.AccountEntryExtensionV2{
+					NumSponsored:        xdr.Uint32(account.NumSponsored),
+					NumSponsoring:       xdr.Uint32(account.NumSponsoring),
+					SignerSponsoringIDs: make([]xdr.SponsorshipDescriptor, len(entry.Signers)),
+				},
+			},
+		},
+	}
+	return &entry, nil
+}
+
+// TrustLine implements txnbuild.LedgerState.
+func (s *ledgerState) TrustLine(accountID string, asset xdr.Asset) (*xdr.TrustLineEntry, error) {
+	account, err := s.account(accountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	var assetType, code, issuer string
+	if err = asset.Extract(&assetType, &code, &issuer); err != nil {
+		return nil, errors.Wrap(err, "invalid asset")
+	}
+
+	for _, balance := range account.Balances {
+		if balance.Type != assetType || balance.Code != code || balance.Issuer != issuer {
+			continue
+		}
+		entry := xdr.TrustLineEntry{
+			Asset: asset,
+			Ext: xdr.TrustLineEntryExt{
+				V:  1,
+				V1: &xdr.TrustLineEntryV1{},
+			},
+		}
+		if err = entry.AccountId.SetAddress(accountID); err != nil {
+			return nil, errors.Wrap(err, "invalid account id")
+		}
+		if entry.Balance, err = parseAmount(balance.Balance); err != nil {
+			return nil, err
+		}
+		if entry.Limit, err = parseAmount(balance.Limit); err != nil {
+			return nil, err
+		}
+		liabilities := &entry.Ext.V1.Liabilities
+		if liabilities.Buying, err = parseAmount(balance.BuyingLiabilities); err != nil {
+			return nil, err
+		}
+		if liabilities.Selling, err = parseAmount(balance.SellingLiabilities); err != nil {
+			return nil, err
+		}
+
+		var flags xdr.TrustLineFlags
+		if balance.IsAuthorized != nil && *balance.IsAuthorized {
+			flags |= xdr.TrustLineFlagsAuthorizedFlag
+		}
+		if balance.IsAuthorizedToMaintainLiabilities != nil && *balance.IsAuthorizedToMaintainLiabilities {
+			flags |= xdr.TrustLineFlagsAuthorizedToMaintainLiabilitiesFlag
+		}
+		if balance.IsClawbackEnabled != nil && *balance.IsClawbackEnabled {
+			flags |= xdr.TrustLineFlagsTrustlineClawbackEnabledFlag
+		}
+		entry.Flags = xdr.Uint32(flags)
+		return &entry, nil
+	}
+	return nil, nil
+}
+
+// Offers implements txnbuild.LedgerState.
+func (s *ledgerState) Offers(sellerID string) ([]xdr.OfferEntry, error) {
+	var entries []xdr.OfferEntry
+	request := OfferRequest{ForAccount: sellerID, Limit: maxOffersPageSize}
+	for {
+		page, err := s.client.OffersContext(s.ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, offer := range page.Embedded.Records {
+			entry, err := offerEntry(offer)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid offer %d", offer.ID)
+			}
+			entries = append(entries, entry)
+			request.Cursor = offer.PT
+		}
+		if len(page.Embedded.Records) < maxOffersPageSize {
+			return entries, nil
+		}
+	}
+}
+
+func offerEntry(offer hProtocol.Offer) (xdr.OfferEntry, error) {
+	entry := xdr.OfferEntry{
+		OfferId: xdr.Int64(offer.ID),
+		Price:   xdr.Price{N: xdr.Int32(offer.PriceR.N), D: xdr.Int32(offer.PriceR.D)},
+	}
+	err := entry.SellerId.SetAddress(offer.Seller)
+	if err != nil {
+		return entry, errors.Wrap(err, "invalid seller")
+	}
+	if entry.Selling, err = xdr.BuildAsset(offer.Selling.Type, offer.Selling.Issuer, offer.Selling.Code); err != nil {
+		return entry, errors.Wrap(err, "invalid selling asset")
+	}
+	if entry.Buying, err = xdr.BuildAsset(offer.Buying.Type, offer.Buying.Issuer, offer.Buying.Code); err != nil {
+		return entry, errors.Wrap(err, "invalid buying asset")
+	}
+	entry.Amount, err = parseAmount(offer.Amount)
+	return entry, err
+}
+
+// Data implements txnbuild.LedgerState.
+func (s *ledgerState) Data(accountID, name string) (*xdr.DataEntry, error) {
+	account, err := s.account(accountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	encoded, ok := account.Data[name]
+	if !ok {
+		return nil, nil
+	}
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value of data entry %s", name)
+	}
+	entry := xdr.DataEntry{DataName: xdr.String64(name), DataValue: value}
+	if err = entry.AccountId.SetAddress(accountID); err != nil {
+		return nil, errors.Wrap(err, "invalid account id")
+	}
+	return &entry, nil
+}
+
+// parseAmount parses an amount returned by Horizon into stroops.
+func parseAmount(value string) (xdr.Int64, error) {
+	parsed, err := amount.ParseInt64(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid amount %q", value)
+	}
+	return xdr.Int64(parsed), nil
+}
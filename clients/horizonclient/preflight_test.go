@@ -0,0 +1,115 @@
+package horizonclient
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightTransaction(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{HorizonURL: "https://localhost/", HTTP: hmock}
+
+	source := keypair.MustRandom().Address()
+	destination := keypair.MustRandom().Address()
+	missing := keypair.MustRandom().Address()
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: destination}
+	authorized := true
+
+	hmock.On("GET", "https://localhost/ledgers?limit=1&order=desc").ReturnJSON(http.StatusOK, map[string]interface{}{
+		"_embedded": map[string]interface{}{
+			"records": []hProtocol.Ledger{{BaseFee: 100, BaseReserve: 5000000}},
+		},
+	})
+	hmock.On("GET", "https://localhost/accounts/"+source).ReturnJSON(http.StatusOK, hProtocol.Account{
+		AccountID:     source,
+		Sequence:      "41",
+		SubentryCount: 3,
+		Thresholds:    hProtocol.AccountThresholds{MedThreshold: 1},
+		Balances: []hProtocol.Balance{
+			{
+				Balance:            "20.0000000",
+				BuyingLiabilities:  "0.0000000",
+				SellingLiabilities: "10.0000000",
+				Asset:              base.Asset{Type: "native"},
+			},
+			{
+				Balance:            "100.0000000",
+				Limit:              "1000.0000000",
+				BuyingLiabilities:  "20.0000000",
+				SellingLiabilities: "0.0000000",
+				IsAuthorized:       &authorized,
+				Asset:              base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: destination},
+			},
+		},
+		Signers: []hProtocol.Signer{{Key: source, Weight: 1}},
+		Data:    map[string]string{"name": base64.StdEncoding.EncodeToString([]byte("value"))},
+	})
+	hmock.On("GET", "https://localhost/accounts/"+destination).ReturnJSON(http.StatusOK, hProtocol.Account{
+		AccountID: destination,
+		Sequence:  "1",
+		Balances: []hProtocol.Balance{{
+			Balance:            "10.0000000",
+			BuyingLiabilities:  "0.0000000",
+			SellingLiabilities: "0.0000000",
+			Asset:              base.Asset{Type: "native"},
+		}},
+	})
+	hmock.On("GET", "https://localhost/accounts/"+missing).ReturnString(http.StatusNotFound, notFoundResponse)
+	hmock.On("GET", "https://localhost/accounts/"+source+"/offers?limit=200").ReturnJSON(http.StatusOK, map[string]interface{}{
+		"_embedded": map[string]interface{}{
+			"records": []hProtocol.Offer{{
+				ID:      12,
+				Seller:  source,
+				Selling: hProtocol.Asset{Type: "native"},
+				Buying:  hProtocol.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: destination},
+				Amount:  "10.0000000",
+				PriceR:  hProtocol.Price{N: 2, D: 1},
+			}},
+		},
+	})
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: 41},
+		IncrementSequenceNum: true,
+		BaseFee:              txnbuild.MinBaseFee,
+		Timebounds:           txnbuild.NewInfiniteTimeout(),
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: destination, Amount: "50", Asset: usd},
+			&txnbuild.Payment{Destination: missing, Amount: "1", Asset: txnbuild.NativeAsset{}},
+			&txnbuild.ManageData{Name: "name"},
+			&txnbuild.ManageSellOffer{Selling: usd, Buying: txnbuild.NativeAsset{}, Amount: "10", Price: "0.5"},
+			&txnbuild.ManageSellOffer{Selling: usd, Buying: txnbuild.NativeAsset{}, Amount: "10", Price: "1"},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := client.PreflightTransaction(tx, txnbuild.PreflightParams{})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{
+		"op_success",
+		"op_no_destination",
+		"op_success",
+		"op_cross_self",
+		"op_success",
+	}, result.OperationCodes)
+	assert.Equal(t, int64(500), result.Fee)
+	assert.Equal(t, []txnbuild.AccountReserve{
+		{AccountID: source, Balance: 199999500, MinimumBalance: 25000000, SellingLiabilities: 100000000},
+		{AccountID: destination, Balance: 100000000, MinimumBalance: 10000000},
+	}, result.Reserves)
+
+	// Horizon errors are returned
+	hmock.On("GET", "https://localhost/accounts/"+source).ReturnString(http.StatusInternalServerError, "")
+	_, err = client.PreflightTransaction(tx, txnbuild.PreflightParams{BaseFee: 100, BaseReserve: 5000000})
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/network"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// RetryPolicy configures how the client retries requests which failed
+// because Horizon was rate limiting the client (429 Too Many Requests) or was
+// temporarily unavailable (503 Service Unavailable). GET requests which failed
+// because of network errors are retried too.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried
+	MaxRetries int
+	// MinBackoff is the delay before the first retry if Horizon doesn't
+	// specify one in the Retry-After or X-RateLimit-Reset headers. The delay
+	// doubles with every following retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries. Requests are not
+	// retried if Horizon asks the client to wait for longer than MaxBackoff.
+	// Zero means there is no limit.
+	MaxBackoff time.Duration
+	// SubmissionPollTimeout is how long the client polls for the result of a
+	// transaction after its submission timed out (504 Gateway Timeout).
+	// Zero disables polling.
+	SubmissionPollTimeout time.Duration
+	// SubmissionPollInterval is the delay between consecutive polls for the
+	// result of a transaction which submission timed out. Zero means
+	// MinBackoff, or one second if MinBackoff is zero as well.
+	SubmissionPollInterval time.Duration
+}
+
+// DefaultRetryPolicy is a retry policy suitable for most applications
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:             3,
+	MinBackoff:             time.Second,
+	MaxBackoff:             30 * time.Second,
+	SubmissionPollTimeout:  time.Minute,
+	SubmissionPollInterval: 2 * time.Second,
+}
+
+// retryDelay returns how long to wait before retrying a request and whether
+// the request should be retried at all. resp is nil if the request failed
+// because of a network error.
+func (p RetryPolicy) retryDelay(attempt int, resp *http.Response, now time.Time) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	if resp != nil {
+		if resp.StatusCode != http.StatusTooManyRequests &&
+			resp.StatusCode != http.StatusServiceUnavailable {
+			return 0, false
+		}
+		if delay, ok := serverRetryDelay(resp, now); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return 0, false
+			}
+			return delay, true
+		}
+	}
+
+	delay := p.MinBackoff << uint(attempt)
+	if p.MaxBackoff > 0 && (delay > p.MaxBackoff || delay < p.MinBackoff) {
+		delay = p.MaxBackoff
+	}
+	return delay, true
+}
+
+// pollInterval returns the delay between consecutive polls for the result of
+// a transaction, which is never zero to avoid polling Horizon in a tight loop.
+func (p RetryPolicy) pollInterval() time.Duration {
+	if p.SubmissionPollInterval > 0 {
+		return p.SubmissionPollInterval
+	}
+	if p.MinBackoff > 0 {
+		return p.MinBackoff
+	}
+	return time.Second
+}
+
+// serverRetryDelay returns the delay requested by Horizon in the Retry-After
+// header or, if the request was rate limited, the time left until the rate
+// limit resets as specified in the X-RateLimit-Reset header.
+func serverRetryDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			if delay := date.Sub(now); delay > 0 {
+				return delay, true
+			}
+			return 0, true
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if value := resp.Header.Get("X-RateLimit-Reset"); value != "" {
+			if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given duration or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pollSubmittedTransaction polls Horizon for the result of a transaction which
+// submission timed out with submitErr. Polling is safe because Horizon keeps
+// processing the transaction after the timeout and a transaction can be
+// included in a ledger at most once.
+func (c *Client) pollSubmittedTransaction(
+	ctx context.Context,
+	transactionXdr string,
+	submitErr error,
+) (hProtocol.Transaction, error) {
+	hash, err := c.transactionHash(ctx, transactionXdr)
+	if err != nil {
+		return hProtocol.Transaction{}, errors.Wrap(err, "could not determine transaction hash")
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, c.RetryPolicy.SubmissionPollTimeout)
+	defer cancel()
+	interval := c.RetryPolicy.pollInterval()
+	for {
+		if err = sleep(pollCtx, interval); err != nil {
+			break
+		}
+
+		tx, err := c.TransactionDetailContext(pollCtx, hash)
+		if err == nil {
+			if !tx.Successful {
+				return tx, ErrTransactionFailed
+			}
+			return tx, nil
+		}
+		// the transaction is not in a ledger yet (404) or the request failed,
+		// in both cases the transaction can still be found by the next poll
+	}
+
+	if ctx.Err() != nil {
+		return hProtocol.Transaction{}, ctx.Err()
+	}
+	return hProtocol.Transaction{}, submitErr
+}
+
+// transactionHash returns the hex encoded hash of the transaction in the
+// given envelope using the network passphrase of the Horizon server
+func (c *Client) transactionHash(ctx context.Context, transactionXdr string) (string, error) {
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(transactionXdr, &envelope); err != nil {
+		return "", errors.Wrap(err, "could not decode transaction envelope")
+	}
+
+	root, err := c.RootContext(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not load network passphrase")
+	}
+
+	hash, err := network.HashTransactionInEnvelope(envelope, root.NetworkPassphrase)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,296 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceResponder returns a responder which replies with the given
+// responders in order and repeats the last one afterwards
+func sequenceResponder(calls *int, responders ...httpmock.Responder) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		responder := responders[len(responders)-1]
+		if *calls < len(responders) {
+			responder = responders[*calls]
+		}
+		*calls++
+		return responder(req)
+	}
+}
+
+// stringResponder is like httpmock.NewStringResponder but it creates a new
+// response for every request so the response body can be read many times
+func stringResponder(status int, body string) httpmock.Responder {
+	return responderWithHeader(status, body, http.Header{})
+}
+
+func responderWithHeader(status int, body string, header http.Header) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(status, body)
+		resp.Header = header
+		return resp, nil
+	}
+}
+
+var rateLimitedResponse = `{
+  "type": "https://stellar.org/horizon-errors/rate_limit_exceeded",
+  "title": "Rate Limit Exceeded",
+  "status": 429
+}`
+
+var timeoutResponse = `{
+  "type": "https://stellar.org/horizon-errors/timeout",
+  "title": "Timeout",
+  "status": 504
+}`
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{
+		MaxRetries: 5,
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		attempt  int
+		status   int
+		header   http.Header
+		expected time.Duration
+		retry    bool
+	}{
+		{"first retry", 0, http.StatusServiceUnavailable, http.Header{}, time.Second, true},
+		{"exponential backoff", 2, http.StatusServiceUnavailable, http.Header{}, 4 * time.Second, true},
+		{"max backoff", 3, http.StatusTooManyRequests, http.Header{}, 5 * time.Second, true},
+		{"max retries", 5, http.StatusTooManyRequests, http.Header{}, 0, false},
+		{"not retriable", 0, http.StatusBadRequest, http.Header{}, 0, false},
+		{"gateway timeout", 0, http.StatusGatewayTimeout, http.Header{}, 0, false},
+		{
+			"retry after seconds", 0, http.StatusServiceUnavailable,
+			http.Header{"Retry-After": []string{"3"}}, 3 * time.Second, true,
+		},
+		{
+			"retry after date", 0, http.StatusTooManyRequests,
+			http.Header{"Retry-After": []string{now.Add(2 * time.Second).Format(http.TimeFormat)}},
+			2 * time.Second, true,
+		},
+		{
+			"retry after date in the past", 0, http.StatusTooManyRequests,
+			http.Header{"Retry-After": []string{now.Add(-time.Minute).Format(http.TimeFormat)}},
+			0, true,
+		},
+		{
+			"rate limit reset", 0, http.StatusTooManyRequests,
+			http.Header{"X-Ratelimit-Reset": []string{"4"}}, 4 * time.Second, true,
+		},
+		{
+			"rate limit reset is ignored when not rate limited", 0, http.StatusServiceUnavailable,
+			http.Header{"X-Ratelimit-Reset": []string{"4"}}, time.Second, true,
+		},
+		{
+			"retry after exceeds max backoff", 0, http.StatusServiceUnavailable,
+			http.Header{"Retry-After": []string{"60"}}, 0, false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: testCase.status, Header: testCase.header}
+			delay, retry := policy.retryDelay(testCase.attempt, resp, now)
+			assert.Equal(t, testCase.retry, retry)
+			assert.Equal(t, testCase.expected, delay)
+		})
+	}
+
+	// network errors
+	delay, retry := policy.retryDelay(1, nil, now)
+	assert.True(t, retry)
+	assert.Equal(t, 2*time.Second, delay)
+
+	// retries are disabled by default
+	_, retry = RetryPolicy{}.retryDelay(0, &http.Response{StatusCode: http.StatusTooManyRequests}, now)
+	assert.False(t, retry)
+}
+
+func TestPollInterval(t *testing.T) {
+	assert.Equal(t, 3*time.Second, RetryPolicy{
+		MinBackoff:             time.Second,
+		SubmissionPollInterval: 3 * time.Second,
+	}.pollInterval())
+	// the poll interval defaults to MinBackoff, or one second
+	assert.Equal(t, 500*time.Millisecond, RetryPolicy{MinBackoff: 500 * time.Millisecond}.pollInterval())
+	assert.Equal(t, time.Second, RetryPolicy{SubmissionPollTimeout: time.Minute}.pollInterval())
+}
+
+func TestRetryRateLimitedRequests(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:  "https://localhost/",
+		HTTP:        hmock,
+		RetryPolicy: RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond},
+	}
+
+	calls := 0
+	rateLimited := responderWithHeader(
+		http.StatusTooManyRequests,
+		rateLimitedResponse,
+		http.Header{"Retry-After": []string{"0"}, "X-Ratelimit-Remaining": []string{"0"}},
+	)
+	hmock.On("GET", "https://localhost/ledgers/1").Return(sequenceResponder(
+		&calls,
+		rateLimited,
+		stringResponder(http.StatusServiceUnavailable, "<html>unavailable</html>"),
+		stringResponder(http.StatusOK, ledgerResponse),
+	))
+
+	ledger, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(69859), ledger.Sequence)
+	assert.Equal(t, 3, calls)
+
+	// the error is returned once all retries are used
+	calls = 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(sequenceResponder(&calls, rateLimited))
+	_, err = client.LedgerDetail(1)
+	hErr := GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusTooManyRequests, hErr.Response.StatusCode)
+	assert.Equal(t, "Rate Limit Exceeded", hErr.Problem.Title)
+	assert.Equal(t, 3, calls)
+
+	// network errors are retried for GET requests
+	calls = 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(sequenceResponder(
+		&calls,
+		func(*http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("connection reset")
+		},
+		stringResponder(http.StatusOK, ledgerResponse),
+	))
+	_, err = client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetryRespectsContext(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL:  "https://localhost/",
+		HTTP:        hmock,
+		RetryPolicy: RetryPolicy{MaxRetries: 2, MinBackoff: time.Hour},
+	}
+
+	calls := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(sequenceResponder(
+		&calls,
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+	))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.LedgerDetailContext(ctx, 1)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSubmitTransactionTimeoutPolling(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		RetryPolicy: RetryPolicy{
+			SubmissionPollTimeout:  time.Second,
+			SubmissionPollInterval: time.Millisecond,
+		},
+	}
+
+	txXdr := `AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0AAuV/AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAyTBGxOgfSApppsTnb/YRr6gOR8WT0LZNrhLh4y3FCgoAAAAXSHboAAAAAAAAAAABhlbgnAAAAEAivKe977CQCxMOKTuj+cWTFqc2OOJU8qGr9afrgu2zDmQaX5Q0cNshc3PiBwe0qw/+D/qJk5QqM5dYeSUGeDQP`
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(txXdr, &envelope))
+	hashBytes, err := network.HashTransactionInEnvelope(envelope, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	hash := hex.EncodeToString(hashBytes[:])
+
+	submissions := 0
+	hmock.On("POST", "https://localhost/transactions").Return(sequenceResponder(
+		&submissions,
+		stringResponder(http.StatusGatewayTimeout, timeoutResponse),
+	))
+	hmock.On("GET", "https://localhost/").Return(stringResponder(http.StatusOK, rootResponse))
+	polls := 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(sequenceResponder(
+		&polls,
+		stringResponder(http.StatusNotFound, notFoundResponse),
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+		stringResponder(
+			http.StatusOK,
+			fmt.Sprintf(`{"hash": "%s", "ledger": 100, "successful": true}`, hash),
+		),
+	))
+
+	tx, err := client.SubmitTransactionXDR(txXdr)
+	require.NoError(t, err)
+	assert.Equal(t, hash, tx.Hash)
+	assert.Equal(t, int32(100), tx.Ledger)
+	assert.Equal(t, 1, submissions)
+	assert.Equal(t, 3, polls)
+
+	// failed transactions are returned with ErrTransactionFailed
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(stringResponder(
+		http.StatusOK,
+		fmt.Sprintf(`{"hash": "%s", "ledger": 100, "successful": false}`, hash),
+	))
+	tx, err = client.SubmitTransactionXDR(txXdr)
+	assert.Equal(t, ErrTransactionFailed, err)
+	assert.Equal(t, hash, tx.Hash)
+
+	// the timeout error is returned if the transaction is not found in time
+	client.RetryPolicy.SubmissionPollTimeout = 20 * time.Millisecond
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(
+		stringResponder(http.StatusNotFound, notFoundResponse),
+	)
+	_, err = client.SubmitTransactionXDR(txXdr)
+	hErr := GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusGatewayTimeout, hErr.Response.StatusCode)
+
+	// without a poll interval Horizon is polled every MinBackoff instead of
+	// in a tight loop
+	client.RetryPolicy = RetryPolicy{
+		MinBackoff:            10 * time.Millisecond,
+		SubmissionPollTimeout: 55 * time.Millisecond,
+	}
+	polls = 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(sequenceResponder(
+		&polls,
+		stringResponder(http.StatusNotFound, notFoundResponse),
+	))
+	_, err = client.SubmitTransactionXDR(txXdr)
+	hErr = GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusGatewayTimeout, hErr.Response.StatusCode)
+	assert.True(t, polls > 0 && polls <= 5, "unexpected number of polls: %d", polls)
+
+	// polling is disabled by default
+	client.RetryPolicy = RetryPolicy{}
+	polls = 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(sequenceResponder(
+		&polls,
+		stringResponder(http.StatusNotFound, notFoundResponse),
+	))
+	_, err = client.SubmitTransactionXDR(txXdr)
+	hErr = GetError(err)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusGatewayTimeout, hErr.Response.StatusCode)
+	assert.Equal(t, 0, polls)
+}
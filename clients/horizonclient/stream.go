@@ -0,0 +1,417 @@
+package horizonclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manucorporat/sse"
+	"github.com/stellar/go/support/errors"
+)
+
+// ErrStreamIdle is the error reported when no data was received from a
+// stream within StreamPolicy.IdleTimeout
+var ErrStreamIdle = errors.New("no data received from the stream within the idle timeout")
+
+// StreamErrorHandler is called with every error which caused a stream to
+// reconnect
+type StreamErrorHandler func(error)
+
+// StreamPolicy configures how the client recovers from stream failures. By
+// default streams only reconnect when Horizon closes the connection, all other
+// errors are returned by the Stream methods.
+type StreamPolicy struct {
+	// Reconnect enables reconnecting streams which failed because of network
+	// errors, 429 and 5xx responses or because they were idle for too long.
+	// When Reconnect is enabled, streams which start at the "now" cursor are
+	// started after the latest existing record instead so that records
+	// created while the stream is reconnecting are not missed.
+	Reconnect bool
+	// MaxConsecutiveFailures is the maximum number of reconnects in a row
+	// which fail before the stream gives up and returns the error. The count
+	// is reset when a connection is established. Zero means there is no limit.
+	MaxConsecutiveFailures int
+	// MinBackoff is the delay before the first reconnect. The delay doubles
+	// with every following failure.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between reconnects. Zero means there is
+	// no limit.
+	MaxBackoff time.Duration
+	// IdleTimeout is how long a stream can go without receiving any data
+	// before it's considered dead and reconnected. Zero disables the timeout.
+	IdleTimeout time.Duration
+	// ErrorHandler is called with every error which caused the stream to
+	// reconnect
+	ErrorHandler StreamErrorHandler
+}
+
+// DefaultStreamPolicy is a stream policy suitable for most applications
+var DefaultStreamPolicy = StreamPolicy{
+	Reconnect:   true,
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	IdleTimeout: 5 * time.Minute,
+}
+
+// backoff returns the delay before the reconnect following the given number
+// of consecutive failures
+func (p StreamPolicy) backoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	delay := p.MinBackoff << uint(failures-1)
+	if p.MaxBackoff > 0 && (delay > p.MaxBackoff || delay < p.MinBackoff) {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// stream handles connections to endpoints that support streaming on a horizon server.
+// Events are delivered to the handler at most once: the stream is resumed
+// from the paging token of the last delivered event and events which don't
+// come after that token are skipped.
+func (c *Client) stream(
+	ctx context.Context,
+	streamURL string,
+	handler func(data []byte) error,
+) error {
+	su, err := url.Parse(streamURL)
+	if err != nil {
+		return errors.Wrap(err, "error parsing stream url")
+	}
+
+	query := su.Query()
+	if query.Get("cursor") == "" {
+		query.Set("cursor", "now")
+	}
+	lastDelivered := ""
+	if cursor := query.Get("cursor"); cursor != "now" {
+		lastDelivered = cursor
+	}
+
+	onEvent := func(event sse.Event) error {
+		if event.Id != "" {
+			if lastDelivered != "" && !pagingTokenAfter(event.Id, lastDelivered) {
+				return nil
+			}
+		}
+
+		var err error
+		switch data := event.Data.(type) {
+		case string:
+			err = handler([]byte(data))
+			err = errors.Wrap(err, "handler error")
+		case []byte:
+			err = handler(data)
+			err = errors.Wrap(err, "handler error")
+		default:
+			err = errors.New("invalid event.Data type")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Update cursor with event ID once the event is delivered
+		if event.Id != "" {
+			lastDelivered = event.Id
+			query.Set("cursor", event.Id)
+		}
+		return nil
+	}
+
+	policy := c.StreamPolicy
+	resolveNow := policy.Reconnect
+	failures := 0
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var connected, retriable bool
+		if resolveNow && query.Get("cursor") == "now" {
+			err = c.resolveNowCursor(ctx, *su, query)
+			retriable = true
+			if err == nil {
+				resolveNow = false
+			}
+		}
+		if err == nil {
+			// updates the url with new cursor
+			su.RawQuery = query.Encode()
+			connected, retriable, err = c.streamConnection(ctx, su.String(), onEvent)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			// the stream was closed by the server, reconnect immediately
+			failures = 0
+			continue
+		}
+		if !policy.Reconnect || !retriable {
+			return err
+		}
+
+		if connected {
+			failures = 0
+		}
+		failures++
+		if policy.MaxConsecutiveFailures > 0 && failures > policy.MaxConsecutiveFailures {
+			return err
+		}
+		if policy.ErrorHandler != nil {
+			policy.ErrorHandler(err)
+		}
+		if sleep(ctx, policy.backoff(failures)) != nil {
+			return nil
+		}
+		err = nil
+	}
+}
+
+// resolveNowCursor replaces the "now" cursor in query with the paging token of
+// the latest record in the streamed collection. Streams of single resources
+// (ex. order books) keep the "now" cursor.
+func (c *Client) resolveNowCursor(ctx context.Context, su url.URL, query url.Values) error {
+	latest := url.Values{}
+	for key, values := range query {
+		latest[key] = values
+	}
+	latest.Del("cursor")
+	latest.Set("order", string(OrderDesc))
+	latest.Set("limit", "1")
+	su.RawQuery = latest.Encode()
+
+	var page struct {
+		Embedded *struct {
+			Records []struct {
+				PagingToken string `json:"paging_token"`
+			} `json:"records"`
+		} `json:"_embedded"`
+	}
+	if err := c.sendRequestURL(ctx, su.String(), "get", &page); err != nil {
+		return errors.Wrap(err, "error fetching the latest record")
+	}
+
+	switch {
+	case page.Embedded == nil:
+		// not a collection
+	case len(page.Embedded.Records) == 0:
+		query.Set("cursor", "")
+	default:
+		query.Set("cursor", page.Embedded.Records[0].PagingToken)
+	}
+	return nil
+}
+
+// streamConnection reads events from a single connection to a stream until
+// the connection is closed. connected is true if Horizon accepted the
+// connection and retriable is true if the returned error is temporary.
+func (c *Client) streamConnection(
+	ctx context.Context,
+	streamURL string,
+	onEvent func(event sse.Event) error,
+) (connected bool, retriable bool, err error) {
+	req, err := http.NewRequest("GET", streamURL, nil)
+	if err != nil {
+		return false, false, errors.Wrap(err, "error creating HTTP request")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	c.setDefaultClient()
+	c.setClientAppHeaders(req)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idle := newIdleTimer(c.StreamPolicy.IdleTimeout, cancel)
+	defer idle.stop()
+
+	// We can use c.HTTP here because we set Timeout per request not on the client. See sendRequest()
+	resp, err := c.HTTP.Do(req.WithContext(ctx))
+	if err != nil {
+		if idle.expired() {
+			return false, true, ErrStreamIdle
+		}
+		return false, true, errors.Wrap(err, "error sending HTTP request")
+	}
+	defer resp.Body.Close()
+
+	// Expected statusCode are 200-299
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		retriable = resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError
+		return false, retriable, fmt.Errorf("got bad HTTP status code %d", resp.StatusCode)
+	}
+	idle.watch(resp.Body)
+
+	reader := bufio.NewReader(resp.Body)
+
+	// Read events one by one. Return when there is no more data to be
+	// read from resp.Body (io.EOF).
+	for {
+		// Read until empty line = event delimiter. The perfect solution would be to read
+		// as many bytes as possible and forward them to sse.Decode. However this
+		// requires much more complicated code.
+		// We could also write our own `sse` package that works fine with streams directly
+		// (github.com/manucorporat/sse is just using io/ioutils.ReadAll).
+		var buffer bytes.Buffer
+		nonEmptylinesRead := 0
+		for {
+			// Check if ctx is not cancelled
+			if ctx.Err() != nil && !idle.expired() {
+				return true, false, nil
+			}
+
+			line, err := reader.ReadString('\n')
+			idle.reset()
+			if err != nil {
+				if idle.expired() {
+					return true, true, ErrStreamIdle
+				}
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					// We catch EOF errors to handle two possible situations:
+					// - The last line before closing the stream was not empty. This should never
+					//   happen in Horizon as it always sends an empty line after each event.
+					// - The stream was closed by the server/proxy because the connection was idle.
+					//
+					// In the former case, that (again) should never happen in Horizon, we need to
+					// check if there are any events we need to decode. We do this in the `if`
+					// statement below just in case if Horizon behaviour changes in a future.
+					//
+					// From spec:
+					// > Once the end of the file is reached, the user agent must dispatch the
+					// > event one final time, as defined below.
+					if nonEmptylinesRead == 0 {
+						return true, false, nil
+					}
+				} else {
+					return true, true, errors.Wrap(err, "error reading line")
+				}
+			}
+			buffer.WriteString(line)
+
+			if strings.TrimRight(line, "\n\r") == "" {
+				break
+			}
+
+			nonEmptylinesRead++
+		}
+
+		events, err := sse.Decode(strings.NewReader(buffer.String()))
+		if err != nil {
+			return true, false, errors.Wrap(err, "error decoding event")
+		}
+
+		// Right now len(events) should always be 1. This loop will be helpful after writing
+		// new SSE decoder that can handle io.Reader without using ioutils.ReadAll().
+		for _, event := range events {
+			if event.Event != "message" {
+				continue
+			}
+
+			// the time spent by the handler doesn't count as idle time
+			idle.pause()
+			err = onEvent(event)
+			idle.reset()
+			if err != nil {
+				return true, false, err
+			}
+		}
+	}
+}
+
+// idleTimer cancels a stream connection when no data is read from it within
+// the timeout. A zero timeout disables the timer.
+type idleTimer struct {
+	timeout time.Duration
+	lock    sync.Mutex
+	timer   *time.Timer
+	body    io.Closer
+	fired   bool
+}
+
+func newIdleTimer(timeout time.Duration, cancel context.CancelFunc) *idleTimer {
+	t := &idleTimer{timeout: timeout}
+	if timeout <= 0 {
+		return t
+	}
+	t.timer = time.AfterFunc(timeout, func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		t.fired = true
+		cancel()
+		// closing the body unblocks reads for transports which don't
+		// abort them when the request context is cancelled
+		if t.body != nil {
+			t.body.Close()
+		}
+	})
+	return t
+}
+
+// watch sets the response body which is closed when the timer expires
+func (t *idleTimer) watch(body io.Closer) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.body = body
+}
+
+func (t *idleTimer) reset() {
+	if t.timer == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.fired {
+		t.timer.Reset(t.timeout)
+	}
+}
+
+func (t *idleTimer) pause() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+func (t *idleTimer) stop() {
+	t.pause()
+}
+
+func (t *idleTimer) expired() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.fired
+}
+
+// pagingTokenAfter returns true if token comes after last. Paging tokens are
+// numbers or numbers separated with dashes (ex. "12884905985-1"). Tokens in
+// other formats can't be compared so they are assumed to come after last.
+func pagingTokenAfter(token, last string) bool {
+	tokenParts := strings.Split(token, "-")
+	lastParts := strings.Split(last, "-")
+	for i, tokenPart := range tokenParts {
+		if i >= len(lastParts) {
+			return true
+		}
+		a, err := strconv.ParseInt(tokenPart, 10, 64)
+		if err != nil {
+			return true
+		}
+		b, err := strconv.ParseInt(lastParts[i], 10, 64)
+		if err != nil {
+			return true
+		}
+		if a != b {
+			return a > b
+		}
+	}
+	return false
+}
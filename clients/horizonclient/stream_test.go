@@ -0,0 +1,177 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func txEvents(tokens ...string) string {
+	var events strings.Builder
+	for _, token := range tokens {
+		fmt.Fprintf(&events, "id: %s\ndata: {\"paging_token\": \"%s\", \"hash\": \"tx%s\"}\n\n", token, token, token)
+	}
+	return events.String()
+}
+
+// hangingBody returns the given content and then blocks until it's closed
+type hangingBody struct {
+	io.Reader
+	io.Closer
+}
+
+func hangingResponder(content string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		pr, _ := io.Pipe()
+		resp := httpmock.NewStringResponse(http.StatusOK, "")
+		resp.Body = hangingBody{io.MultiReader(strings.NewReader(content), pr), pr}
+		return resp, nil
+	}
+}
+
+func TestStreamReconnects(t *testing.T) {
+	hmock := httptest.NewClient()
+	var reportedErrors []error
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		StreamPolicy: StreamPolicy{
+			Reconnect:   true,
+			MinBackoff:  time.Millisecond,
+			IdleTimeout: 50 * time.Millisecond,
+			ErrorHandler: func(err error) {
+				reportedErrors = append(reportedErrors, err)
+			},
+		},
+	}
+
+	requests := &requestLog{}
+	streams := 0
+	hmock.On("GET", "https://localhost/transactions").Return(func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if req.Header.Get("Accept") != "text/event-stream" {
+			requests.add("latest:" + query.Get("order") + ":" + query.Get("limit"))
+			return stringResponder(http.StatusOK, transactionsPage("5"))(req)
+		}
+
+		requests.add("stream:" + query.Get("cursor"))
+		streams++
+		switch streams {
+		case 1:
+			return stringResponder(http.StatusServiceUnavailable, "unavailable")(req)
+		case 2:
+			// the server closes the connection
+			return stringResponder(http.StatusOK, txEvents("6", "7"))(req)
+		case 3:
+			// the event which was already delivered is skipped and the
+			// connection hangs
+			return hangingResponder(txEvents("7", "8"))(req)
+		default:
+			return stringResponder(http.StatusOK, txEvents("9"))(req)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var hashes []string
+	err := client.StreamTransactions(ctx, TransactionRequest{}, func(tx hProtocol.Transaction) {
+		hashes = append(hashes, tx.Hash)
+		if tx.Hash == "tx9" {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx6", "tx7", "tx8", "tx9"}, hashes)
+	assert.Equal(
+		t,
+		[]string{"latest:desc:1", "stream:5", "stream:5", "stream:7", "stream:8"},
+		requests.get(),
+	)
+	require.Len(t, reportedErrors, 2)
+	assert.EqualError(t, reportedErrors[0], "got bad HTTP status code 503")
+	assert.Equal(t, ErrStreamIdle, reportedErrors[1])
+}
+
+func TestStreamGivesUp(t *testing.T) {
+	hmock := httptest.NewClient()
+	reported := 0
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		StreamPolicy: StreamPolicy{
+			Reconnect:              true,
+			MaxConsecutiveFailures: 2,
+			MinBackoff:             time.Millisecond,
+			ErrorHandler: func(error) {
+				reported++
+			},
+		},
+	}
+
+	calls := 0
+	hmock.On("GET", "https://localhost/ledgers?cursor=10").Return(sequenceResponder(
+		&calls,
+		stringResponder(http.StatusServiceUnavailable, "unavailable"),
+	))
+	err := client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "10"}, func(hProtocol.Ledger) {
+		t.Fatal("unexpected ledger")
+	})
+	assert.EqualError(t, err, "got bad HTTP status code 503")
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, reported)
+
+	// errors caused by the request are not retried
+	calls = 0
+	reported = 0
+	hmock.On("GET", "https://localhost/ledgers?cursor=10").Return(sequenceResponder(
+		&calls,
+		stringResponder(http.StatusBadRequest, "bad request"),
+	))
+	err = client.StreamLedgers(context.Background(), LedgerRequest{Cursor: "10"}, func(hProtocol.Ledger) {
+		t.Fatal("unexpected ledger")
+	})
+	assert.EqualError(t, err, "got bad HTTP status code 400")
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 0, reported)
+}
+
+func TestStreamPolicyBackoff(t *testing.T) {
+	policy := StreamPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Duration(0), policy.backoff(0))
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+	assert.Equal(t, 5*time.Second, policy.backoff(100))
+}
+
+func TestPagingTokenAfter(t *testing.T) {
+	for _, testCase := range []struct {
+		token    string
+		last     string
+		expected bool
+	}{
+		{"10", "9", true},
+		{"9", "10", false},
+		{"10", "10", false},
+		{"12884905985-2", "12884905985-1", true},
+		{"12884905985-1", "12884905985-1", false},
+		{"12884905984-3", "12884905985-1", false},
+		{"12884905985-1", "12884905985", true},
+		{"abc", "10", true},
+	} {
+		assert.Equal(
+			t, testCase.expected, pagingTokenAfter(testCase.token, testCase.last),
+			"%s after %s", testCase.token, testCase.last,
+		)
+	}
+}
@@ -0,0 +1,168 @@
+package orderbook
+
+import (
+	"sort"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Cycle is a sequence of trades which starts and ends with the same asset
+// (ex. A -> B -> C -> A)
+type Cycle struct {
+	// Asset is the asset which is sold in the first trade and bought in the
+	// last trade of the cycle
+	Asset xdr.Asset
+	// SourceAmount is the amount of Asset sold in the first trade
+	SourceAmount xdr.Int64
+	// DestinationAmount is the amount of Asset bought in the last trade
+	DestinationAmount xdr.Int64
+	// InteriorNodes contains the assets traded between the first and the
+	// last trade, in order
+	InteriorNodes []xdr.Asset
+}
+
+// Profit returns the amount of Asset gained by trading along the cycle
+func (c Cycle) Profit() xdr.Int64 {
+	return c.DestinationAmount - c.SourceAmount
+}
+
+// cycleSearchState configures a DFS on the orderbook graph which finds
+// cycles starting and ending with `asset`. Like buyingGraphSearchState only
+// edges in `graph.edgesForBuyingAsset` are traversed. The DFS starts at
+// the neighbours of `asset` so that `asset` is never marked as visited and
+// the search can return to it. Edges are never followed from `asset` which
+// means every cycle is closed the first time the search returns to `asset`.
+type cycleSearchState struct {
+	graph       *OrderBookGraph
+	asset       xdr.Asset
+	assetString string
+	amount      xdr.Int64
+	minProfit   xdr.Int64
+	cycles      []Cycle
+}
+
+func (state *cycleSearchState) isTerminalNode(
+	currentAsset string,
+	currentAssetAmount xdr.Int64,
+) bool {
+	return currentAsset == state.assetString &&
+		currentAssetAmount-state.amount >= state.minProfit
+}
+
+func (state *cycleSearchState) appendToPaths(
+	updatedVisitedList []xdr.Asset,
+	currentAsset string,
+	currentAssetAmount xdr.Int64,
+) {
+	// skip the first and last elements which are both `asset`
+	interiorNodes := make([]xdr.Asset, len(updatedVisitedList)-2)
+	copy(interiorNodes, updatedVisitedList[1:len(updatedVisitedList)-1])
+
+	state.cycles = append(state.cycles, Cycle{
+		Asset:             state.asset,
+		SourceAmount:      state.amount,
+		DestinationAmount: currentAssetAmount,
+		InteriorNodes:     interiorNodes,
+	})
+}
+
+func (state *cycleSearchState) edges(currentAsset string) edgeSet {
+	if currentAsset == state.assetString {
+		return nil
+	}
+	return state.graph.edgesForBuyingAsset[currentAsset]
+}
+
+func (state *cycleSearchState) consumeOffers(
+	currentAssetAmount xdr.Int64,
+	offers []xdr.OfferEntry,
+) (xdr.Asset, xdr.Int64, error) {
+	var nextAsset xdr.Asset
+	nextAmount, err := consumeOffersForBuyingAsset(offers, currentAssetAmount)
+	if err == nil {
+		nextAsset = offers[0].Selling
+	}
+
+	return nextAsset, nextAmount, err
+}
+
+// FindArbitrageCycles returns a list of cycles which start by selling `amount`
+// of `asset` and end by buying at least `amount` + `minProfit` of `asset`
+// along with the ledger the order book is accurate up to. Cycles consist of
+// at most `maxCycleLength` trades and no asset is traded twice within a cycle.
+// The returned cycles are sorted by profit (from highest to lowest), cycles
+// with the same profit are sorted by length (from shortest to longest).
+//
+// Note that the profits of the returned cycles are not additive: different
+// cycles may consume the same offers.
+func (graph *OrderBookGraph) FindArbitrageCycles(
+	maxCycleLength int,
+	asset xdr.Asset,
+	amount xdr.Int64,
+	minProfit xdr.Int64,
+) ([]Cycle, uint32, error) {
+	if amount <= 0 {
+		return nil, 0, errors.New("amount must be positive")
+	}
+	if minProfit <= 0 {
+		return nil, 0, errors.New("minimum profit must be positive")
+	}
+
+	searchState := &cycleSearchState{
+		graph:       graph,
+		asset:       asset,
+		assetString: asset.String(),
+		amount:      amount,
+		minProfit:   minProfit,
+		cycles:      []Cycle{},
+	}
+	graph.lock.RLock()
+	err := findCycles(searchState, maxCycleLength)
+	lastLedger := graph.lastLedger
+	graph.lock.RUnlock()
+	if err != nil {
+		return nil, lastLedger, errors.Wrap(err, "could not determine cycles")
+	}
+
+	sort.SliceStable(searchState.cycles, func(i, j int) bool {
+		a, b := searchState.cycles[i], searchState.cycles[j]
+		if a.Profit() != b.Profit() {
+			return a.Profit() > b.Profit()
+		}
+		return len(a.InteriorNodes) < len(b.InteriorNodes)
+	})
+	return searchState.cycles, lastLedger, nil
+}
+
+// findCycles runs the DFS from every neighbour of the starting asset. The
+// caller must hold the read lock.
+func findCycles(state *cycleSearchState, maxCycleLength int) error {
+	for nextAssetString, offers := range state.graph.edgesForBuyingAsset[state.assetString] {
+		if len(offers) == 0 {
+			continue
+		}
+
+		nextAsset, nextAssetAmount, err := state.consumeOffers(state.amount, offers)
+		if err != nil {
+			return err
+		}
+		if nextAssetAmount <= 0 {
+			continue
+		}
+
+		err = dfs(
+			state,
+			maxCycleLength,
+			map[string]bool{},
+			[]xdr.Asset{state.asset},
+			nextAssetString,
+			nextAsset,
+			nextAssetAmount,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
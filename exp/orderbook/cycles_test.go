@@ -0,0 +1,154 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+func cyclesTestGraph(t *testing.T) *OrderBookGraph {
+	graph := NewOrderBookGraph()
+	for _, offer := range []xdr.OfferEntry{
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(1),
+			Buying:   nativeAsset,
+			Selling:  usdAsset,
+			Price:    xdr.Price{N: 1, D: 2},
+			Amount:   xdr.Int64(1000),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(2),
+			Buying:   usdAsset,
+			Selling:  eurAsset,
+			Price:    xdr.Price{N: 1, D: 1},
+			Amount:   xdr.Int64(1000),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(3),
+			Buying:   eurAsset,
+			Selling:  nativeAsset,
+			Price:    xdr.Price{N: 1, D: 1},
+			Amount:   xdr.Int64(1000),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(4),
+			Buying:   nativeAsset,
+			Selling:  eurAsset,
+			Price:    xdr.Price{N: 4, D: 5},
+			Amount:   xdr.Int64(1000),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(5),
+			Buying:   usdAsset,
+			Selling:  nativeAsset,
+			Price:    xdr.Price{N: 3, D: 1},
+			Amount:   xdr.Int64(1000),
+		},
+	} {
+		graph.AddOffer(offer)
+	}
+	if err := graph.Apply(5); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return graph
+}
+
+func assertCyclesEqual(t *testing.T, expected, actual []Cycle) {
+	if len(expected) != len(actual) {
+		t.Fatalf("expected cycles %v but got %v", expected, actual)
+	}
+	for i := range expected {
+		if !expected[i].Asset.Equals(actual[i].Asset) ||
+			expected[i].SourceAmount != actual[i].SourceAmount ||
+			expected[i].DestinationAmount != actual[i].DestinationAmount ||
+			len(expected[i].InteriorNodes) != len(actual[i].InteriorNodes) {
+			t.Fatalf("expected cycles %v but got %v", expected, actual)
+		}
+		for j := range expected[i].InteriorNodes {
+			if !expected[i].InteriorNodes[j].Equals(actual[i].InteriorNodes[j]) {
+				t.Fatalf("expected cycles %v but got %v", expected, actual)
+			}
+		}
+	}
+}
+
+func TestFindArbitrageCycles(t *testing.T) {
+	graph := cyclesTestGraph(t)
+
+	cycles, lastLedger, err := graph.FindArbitrageCycles(4, nativeAsset, 100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if lastLedger != 5 {
+		t.Fatalf("expected last ledger to be %v but got %v", 5, lastLedger)
+	}
+	usdCycle := Cycle{
+		Asset:             nativeAsset,
+		SourceAmount:      100,
+		DestinationAmount: 200,
+		InteriorNodes:     []xdr.Asset{usdAsset, eurAsset},
+	}
+	eurCycle := Cycle{
+		Asset:             nativeAsset,
+		SourceAmount:      100,
+		DestinationAmount: 125,
+		InteriorNodes:     []xdr.Asset{eurAsset},
+	}
+	assertCyclesEqual(t, []Cycle{usdCycle, eurCycle}, cycles)
+	if cycles[0].Profit() != 100 || cycles[1].Profit() != 25 {
+		t.Fatalf("unexpected profits %v %v", cycles[0].Profit(), cycles[1].Profit())
+	}
+
+	// the native -> usd -> eur -> native cycle is too long
+	cycles, _, err = graph.FindArbitrageCycles(2, nativeAsset, 100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertCyclesEqual(t, []Cycle{eurCycle}, cycles)
+
+	// the native -> eur -> native cycle is not profitable enough
+	cycles, _, err = graph.FindArbitrageCycles(4, nativeAsset, 100, 26)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertCyclesEqual(t, []Cycle{usdCycle}, cycles)
+
+	// the same cycles can be found starting from a different asset
+	cycles, _, err = graph.FindArbitrageCycles(4, eurAsset, 100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertCyclesEqual(t, []Cycle{
+		{
+			Asset:             eurAsset,
+			SourceAmount:      100,
+			DestinationAmount: 200,
+			InteriorNodes:     []xdr.Asset{nativeAsset, usdAsset},
+		},
+		{
+			Asset:             eurAsset,
+			SourceAmount:      100,
+			DestinationAmount: 125,
+			InteriorNodes:     []xdr.Asset{nativeAsset},
+		},
+	}, cycles)
+
+	// there isn't enough liquidity to sell the amount
+	cycles, _, err = graph.FindArbitrageCycles(4, nativeAsset, 2000, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertCyclesEqual(t, []Cycle{}, cycles)
+
+	if _, _, err = graph.FindArbitrageCycles(4, nativeAsset, 0, 1); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, _, err = graph.FindArbitrageCycles(4, nativeAsset, 100, 0); err == nil {
+		t.Fatalf("expected error")
+	}
+}
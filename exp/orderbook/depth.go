@@ -0,0 +1,221 @@
+package orderbook
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// DepthLevel is an aggregation of offers on one side of an order book which
+// share the same price
+type DepthLevel struct {
+	// Price is the price of the base (selling) asset in terms of the
+	// counter (buying) asset
+	Price xdr.Price
+	// Amount is the sum of amounts of all offers at the price level
+	Amount xdr.Int64
+	// CumulativeAmount is the sum of amounts of all offers at the price level
+	// and at better price levels
+	CumulativeAmount xdr.Int64
+}
+
+// Depth is an aggregated view of the order book of a trading pair
+type Depth struct {
+	// Asks contains price levels of offers selling the base asset for the
+	// counter asset from the cheapest to the most expensive. Amounts are in
+	// units of the base asset.
+	Asks []DepthLevel
+	// Bids contains price levels of offers selling the counter asset for the
+	// base asset from the highest to the lowest price (in terms of the
+	// counter asset). Amounts are in units of the counter asset.
+	Bids []DepthLevel
+}
+
+// MidPrice returns the average of the best ask price and the best bid price.
+// The second return value is false if one of the sides of the order book is
+// empty.
+func (d Depth) MidPrice() (float64, bool) {
+	if len(d.Asks) == 0 || len(d.Bids) == 0 {
+		return 0, false
+	}
+	return (priceFloat(d.Asks[0].Price) + priceFloat(d.Bids[0].Price)) / 2, true
+}
+
+// AmountWithin returns the sum of amounts of asks and bids with prices
+// within the given percentage of the mid price. Asks amount is in units of
+// the base asset and bids amount is in units of the counter asset. Both
+// amounts are 0 if one of the sides of the order book is empty.
+func (d Depth) AmountWithin(percentage float64) (xdr.Int64, xdr.Int64) {
+	mid, ok := d.MidPrice()
+	if !ok {
+		return 0, 0
+	}
+
+	var asks, bids xdr.Int64
+	maxAskPrice := mid * (1 + percentage/100)
+	for _, level := range d.Asks {
+		if priceFloat(level.Price) > maxAskPrice {
+			break
+		}
+		asks = level.CumulativeAmount
+	}
+	minBidPrice := mid * (1 - percentage/100)
+	for _, level := range d.Bids {
+		if priceFloat(level.Price) < minBidPrice {
+			break
+		}
+		bids = level.CumulativeAmount
+	}
+	return asks, bids
+}
+
+// Quote describes the result of trading a given amount of the base (selling)
+// asset directly against one side of an order book
+type Quote struct {
+	// Amount is the amount of the base asset bought or sold
+	Amount xdr.Int64
+	// Total is the amount of the counter asset paid or received
+	Total xdr.Int64
+	// BestPrice is the price of the first offer consumed in terms of the
+	// counter asset
+	BestPrice xdr.Price
+	// WorstPrice is the price of the last offer consumed in terms of the
+	// counter asset
+	WorstPrice xdr.Price
+	// AveragePrice is the volume weighted average price (Total / Amount)
+	AveragePrice float64
+	// PriceImpact is the relative difference between WorstPrice and BestPrice,
+	// it's how much the best price changes after the trade
+	PriceImpact float64
+	// Slippage is the relative difference between AveragePrice and BestPrice,
+	// it's how much worse the trade is than if the whole amount could be
+	// traded at the best price
+	Slippage float64
+}
+
+// FindDepth returns aggregated asks and bids of the order book of the given
+// trading pair and the ledger the order book is accurate up to. Both asks and
+// bids span at most `maxPriceLevels` price levels.
+func (graph *OrderBookGraph) FindDepth(selling, buying xdr.Asset, maxPriceLevels int) (Depth, uint32) {
+	asks, bids, lastLedger := graph.FindAsksAndBids(selling, buying, maxPriceLevels)
+	return Depth{
+		Asks: aggregateOffers(asks, false),
+		Bids: aggregateOffers(bids, true),
+	}, lastLedger
+}
+
+// aggregateOffers groups offers sorted by price into price levels. If invert is
+// true prices are inverted (to express bids prices in terms of the counter
+// asset).
+func aggregateOffers(offers []xdr.OfferEntry, invert bool) []DepthLevel {
+	levels := []DepthLevel{}
+	var cumulative xdr.Int64
+	for _, offer := range offers {
+		price := offer.Price
+		if invert {
+			price = xdr.Price{N: price.D, D: price.N}
+		}
+		cumulative += offer.Amount
+		if len(levels) > 0 && levels[len(levels)-1].Price.Equal(price) {
+			levels[len(levels)-1].Amount += offer.Amount
+			levels[len(levels)-1].CumulativeAmount = cumulative
+			continue
+		}
+		levels = append(levels, DepthLevel{
+			Price:            price,
+			Amount:           offer.Amount,
+			CumulativeAmount: cumulative,
+		})
+	}
+	return levels
+}
+
+// QuoteBuy returns a quote for buying `amount` of `selling` with `buying`
+// using offers selling `selling` (asks) and the ledger the order book is
+// accurate up to. ErrNotEnoughLiquidity is returned if the asks can't fill
+// the amount.
+func (graph *OrderBookGraph) QuoteBuy(selling, buying xdr.Asset, amount xdr.Int64) (Quote, uint32, error) {
+	if amount <= 0 {
+		return Quote{}, 0, errors.New("amount must be positive")
+	}
+
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+
+	asks := graph.edgesForSellingAsset[selling.String()][buying.String()]
+	if len(asks) == 0 {
+		return Quote{}, graph.lastLedger, ErrNotEnoughLiquidity
+	}
+
+	var last xdr.OfferEntry
+	total, err := consumeOffersForSellingAssetWithCallback(
+		asks, nil, amount,
+		func(offer xdr.OfferEntry, sellingUnits xdr.Int64) {
+			last = offer
+		},
+	)
+	if err != nil {
+		return Quote{}, graph.lastLedger, errors.Wrap(err, "could not consume offers")
+	}
+	if total <= 0 {
+		return Quote{}, graph.lastLedger, ErrNotEnoughLiquidity
+	}
+
+	return newQuote(amount, total, asks[0].Price, last.Price), graph.lastLedger, nil
+}
+
+// QuoteSell returns a quote for selling `amount` of `selling` for `buying`
+// using offers selling `buying` (bids) and the ledger the order book is
+// accurate up to. ErrNotEnoughLiquidity is returned if the bids can't fill
+// the amount.
+func (graph *OrderBookGraph) QuoteSell(selling, buying xdr.Asset, amount xdr.Int64) (Quote, uint32, error) {
+	if amount <= 0 {
+		return Quote{}, 0, errors.New("amount must be positive")
+	}
+
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+
+	bids := graph.edgesForSellingAsset[buying.String()][selling.String()]
+	if len(bids) == 0 {
+		return Quote{}, graph.lastLedger, ErrNotEnoughLiquidity
+	}
+
+	var last xdr.OfferEntry
+	total, err := consumeOffersForBuyingAssetWithCallback(
+		bids, amount,
+		func(offer xdr.OfferEntry, sellingUnits xdr.Int64) {
+			last = offer
+		},
+	)
+	if err != nil {
+		return Quote{}, graph.lastLedger, errors.Wrap(err, "could not consume offers")
+	}
+	if total <= 0 {
+		return Quote{}, graph.lastLedger, ErrNotEnoughLiquidity
+	}
+
+	best := xdr.Price{N: bids[0].Price.D, D: bids[0].Price.N}
+	worst := xdr.Price{N: last.Price.D, D: last.Price.N}
+	return newQuote(amount, total, best, worst), graph.lastLedger, nil
+}
+
+func newQuote(amount, total xdr.Int64, best, worst xdr.Price) Quote {
+	average, _ := new(big.Rat).SetFrac64(int64(total), int64(amount)).Float64()
+	bestFloat := priceFloat(best)
+	return Quote{
+		Amount:       amount,
+		Total:        total,
+		BestPrice:    best,
+		WorstPrice:   worst,
+		AveragePrice: average,
+		PriceImpact:  math.Abs(priceFloat(worst)-bestFloat) / bestFloat,
+		Slippage:     math.Abs(average-bestFloat) / bestFloat,
+	}
+}
+
+func priceFloat(price xdr.Price) float64 {
+	return float64(price.N) / float64(price.D)
+}
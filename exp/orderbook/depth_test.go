@@ -0,0 +1,169 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+func depthTestGraph(t *testing.T) *OrderBookGraph {
+	graph := NewOrderBookGraph()
+	for _, offer := range []xdr.OfferEntry{
+		dollarOffer,
+		quarterOffer,
+		fiftyCentsOffer,
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(20),
+			Buying:   nativeAsset,
+			Selling:  usdAsset,
+			Price:    xdr.Price{N: 2, D: 1},
+			Amount:   xdr.Int64(100),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(21),
+			Buying:   nativeAsset,
+			Selling:  usdAsset,
+			Price:    xdr.Price{N: 4, D: 1},
+			Amount:   xdr.Int64(60),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(22),
+			Buying:   nativeAsset,
+			Selling:  usdAsset,
+			Price:    xdr.Price{N: 4, D: 1},
+			Amount:   xdr.Int64(40),
+		},
+	} {
+		graph.AddOffer(offer)
+	}
+	if err := graph.Apply(10); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return graph
+}
+
+func assertFloatEquals(t *testing.T, expected, actual float64) {
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestFindDepth(t *testing.T) {
+	graph := depthTestGraph(t)
+
+	depth, lastLedger := graph.FindDepth(nativeAsset, usdAsset, 10)
+	if lastLedger != 10 {
+		t.Fatalf("expected last ledger to be %v but got %v", 10, lastLedger)
+	}
+	expectedAsks := []DepthLevel{
+		{Price: xdr.Price{N: 1, D: 4}, Amount: 500, CumulativeAmount: 500},
+		{Price: xdr.Price{N: 1, D: 2}, Amount: 500, CumulativeAmount: 1000},
+		{Price: xdr.Price{N: 1, D: 1}, Amount: 500, CumulativeAmount: 1500},
+	}
+	expectedBids := []DepthLevel{
+		{Price: xdr.Price{N: 1, D: 2}, Amount: 100, CumulativeAmount: 100},
+		{Price: xdr.Price{N: 1, D: 4}, Amount: 100, CumulativeAmount: 200},
+	}
+	assertDepthLevelsEqual(t, expectedAsks, depth.Asks)
+	assertDepthLevelsEqual(t, expectedBids, depth.Bids)
+
+	mid, ok := depth.MidPrice()
+	if !ok {
+		t.Fatalf("expected mid price")
+	}
+	assertFloatEquals(t, 0.375, mid)
+
+	asks, bids := depth.AmountWithin(50)
+	if asks != 1000 || bids != 200 {
+		t.Fatalf("unexpected amounts %v %v", asks, bids)
+	}
+	asks, bids = depth.AmountWithin(10)
+	if asks != 500 || bids != 100 {
+		t.Fatalf("unexpected amounts %v %v", asks, bids)
+	}
+
+	depth, _ = graph.FindDepth(nativeAsset, usdAsset, 1)
+	assertDepthLevelsEqual(t, expectedAsks[:1], depth.Asks)
+	assertDepthLevelsEqual(t, expectedBids[:1], depth.Bids)
+
+	depth, _ = graph.FindDepth(nativeAsset, eurAsset, 10)
+	if len(depth.Asks) != 0 || len(depth.Bids) != 0 {
+		t.Fatalf("expected empty depth but got %v", depth)
+	}
+	if _, ok = depth.MidPrice(); ok {
+		t.Fatalf("expected no mid price")
+	}
+	asks, bids = depth.AmountWithin(10)
+	if asks != 0 || bids != 0 {
+		t.Fatalf("unexpected amounts %v %v", asks, bids)
+	}
+}
+
+func assertDepthLevelsEqual(t *testing.T, expected, actual []DepthLevel) {
+	if len(expected) != len(actual) {
+		t.Fatalf("expected levels %v but got %v", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected levels %v but got %v", expected, actual)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	graph := depthTestGraph(t)
+
+	quote, lastLedger, err := graph.QuoteBuy(nativeAsset, usdAsset, 750)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if lastLedger != 10 {
+		t.Fatalf("expected last ledger to be %v but got %v", 10, lastLedger)
+	}
+	if quote.Amount != 750 || quote.Total != 250 {
+		t.Fatalf("unexpected quote %v", quote)
+	}
+	if quote.BestPrice != (xdr.Price{N: 1, D: 4}) || quote.WorstPrice != (xdr.Price{N: 1, D: 2}) {
+		t.Fatalf("unexpected quote %v", quote)
+	}
+	assertFloatEquals(t, 1.0/3, quote.AveragePrice)
+	assertFloatEquals(t, 1, quote.PriceImpact)
+	assertFloatEquals(t, 1.0/3, quote.Slippage)
+
+	quote, _, err = graph.QuoteSell(nativeAsset, usdAsset, 250)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if quote.Amount != 250 || quote.Total != 112 {
+		t.Fatalf("unexpected quote %v", quote)
+	}
+	if quote.BestPrice != (xdr.Price{N: 1, D: 2}) || quote.WorstPrice != (xdr.Price{N: 1, D: 4}) {
+		t.Fatalf("unexpected quote %v", quote)
+	}
+	assertFloatEquals(t, 0.448, quote.AveragePrice)
+	assertFloatEquals(t, 0.5, quote.PriceImpact)
+	assertFloatEquals(t, 0.104, quote.Slippage)
+
+	// trading at the best price level only
+	quote, _, err = graph.QuoteBuy(nativeAsset, usdAsset, 100)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertFloatEquals(t, 0.25, quote.AveragePrice)
+	assertFloatEquals(t, 0, quote.PriceImpact)
+	assertFloatEquals(t, 0, quote.Slippage)
+
+	if _, _, err = graph.QuoteBuy(nativeAsset, usdAsset, 1501); err != ErrNotEnoughLiquidity {
+		t.Fatalf("expected error %v but got %v", ErrNotEnoughLiquidity, err)
+	}
+	if _, _, err = graph.QuoteSell(nativeAsset, eurAsset, 1); err != ErrNotEnoughLiquidity {
+		t.Fatalf("expected error %v but got %v", ErrNotEnoughLiquidity, err)
+	}
+	if _, _, err = graph.QuoteSell(nativeAsset, usdAsset, 0); err == nil || err.Error() != "amount must be positive" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
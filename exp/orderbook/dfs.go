@@ -256,6 +256,18 @@ func consumeOffersForSellingAsset(
 	offers []xdr.OfferEntry,
 	ignoreOffersFrom *xdr.AccountId,
 	currentAssetAmount xdr.Int64,
+) (xdr.Int64, error) {
+	return consumeOffersForSellingAssetWithCallback(offers, ignoreOffersFrom, currentAssetAmount, nil)
+}
+
+// consumeOffersForSellingAssetWithCallback is like consumeOffersForSellingAsset
+// but it also calls onConsume (if not nil) with the amount of the selling
+// asset taken from every consumed offer.
+func consumeOffersForSellingAssetWithCallback(
+	offers []xdr.OfferEntry,
+	ignoreOffersFrom *xdr.AccountId,
+	currentAssetAmount xdr.Int64,
+	onConsume func(offer xdr.OfferEntry, sellingUnits xdr.Int64),
 ) (xdr.Int64, error) {
 	totalConsumed := xdr.Int64(0)
 
@@ -288,6 +300,9 @@ func consumeOffersForSellingAsset(
 
 		totalConsumed += xdr.Int64(buyingUnitsFromOffer)
 		currentAssetAmount -= xdr.Int64(sellingUnitsFromOffer)
+		if onConsume != nil {
+			onConsume(offers[i], xdr.Int64(sellingUnitsFromOffer))
+		}
 
 		if currentAssetAmount == 0 {
 			return totalConsumed, nil
@@ -303,6 +318,17 @@ func consumeOffersForSellingAsset(
 func consumeOffersForBuyingAsset(
 	offers []xdr.OfferEntry,
 	currentAssetAmount xdr.Int64,
+) (xdr.Int64, error) {
+	return consumeOffersForBuyingAssetWithCallback(offers, currentAssetAmount, nil)
+}
+
+// consumeOffersForBuyingAssetWithCallback is like consumeOffersForBuyingAsset
+// but it also calls onConsume (if not nil) with the amount of the selling
+// asset taken from every consumed offer.
+func consumeOffersForBuyingAssetWithCallback(
+	offers []xdr.OfferEntry,
+	currentAssetAmount xdr.Int64,
+	onConsume func(offer xdr.OfferEntry, sellingUnits xdr.Int64),
 ) (xdr.Int64, error) {
 	totalConsumed := xdr.Int64(0)
 
@@ -332,6 +358,9 @@ func consumeOffersForBuyingAsset(
 			}
 			if amountSoldXDR <= offers[i].Amount {
 				totalConsumed += amountSoldXDR
+				if onConsume != nil {
+					onConsume(offers[i], amountSoldXDR)
+				}
 				return totalConsumed, nil
 			}
 		} else if err != price.ErrOverflow {
@@ -354,6 +383,9 @@ func consumeOffersForBuyingAsset(
 
 		totalConsumed += xdr.Int64(sellingUnitsFromOffer)
 		currentAssetAmount -= xdr.Int64(buyingUnitsFromOffer)
+		if onConsume != nil {
+			onConsume(offers[i], xdr.Int64(sellingUnitsFromOffer))
+		}
 
 		if currentAssetAmount == 0 {
 			return totalConsumed, nil
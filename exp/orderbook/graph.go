@@ -1,6 +1,7 @@
 package orderbook
 
 import (
+	"io"
 	"sort"
 	"sync"
 
@@ -42,6 +43,8 @@ type OBGraph interface {
 	RemoveOffer(xdr.Int64) OBGraph
 	Pending() ([]xdr.OfferEntry, []xdr.Int64)
 	Clear()
+	WriteSnapshot(w io.Writer) error
+	ReadSnapshot(r io.Reader) (uint32, error)
 }
 
 // OrderBookGraph is an in memory graph representation of all the offers in the stellar ledger
@@ -146,6 +149,11 @@ func (graph *OrderBookGraph) Clear() {
 	graph.lock.Lock()
 	defer graph.lock.Unlock()
 
+	graph.clear()
+}
+
+// clear removes all offers from the graph, the caller must hold the write lock
+func (graph *OrderBookGraph) clear() {
 	graph.edgesForSellingAsset = map[string]edgeSet{}
 	graph.edgesForBuyingAsset = map[string]edgeSet{}
 	graph.tradingPairForOffer = map[xdr.Int64]tradingPair{}
@@ -0,0 +1,124 @@
+package orderbook
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// snapshotMagic identifies order book graph snapshots
+var snapshotMagic = [4]byte{'O', 'B', 'G', 'S'}
+
+const snapshotVersion = uint32(1)
+
+// snapshotHeader is written at the beginning of every snapshot
+type snapshotHeader struct {
+	Magic      [4]byte
+	Version    uint32
+	LastLedger uint32
+	Offers     uint64
+}
+
+// WriteSnapshot writes a compact binary snapshot of the offers in the graph
+// and the ledger the graph is accurate up to. Snapshot is a gzip stream
+// containing a header followed by XDR encoded offers. Queued operations which
+// were not applied are not included in the snapshot.
+func (graph *OrderBookGraph) WriteSnapshot(w io.Writer) error {
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+
+	gzipWriter := gzip.NewWriter(w)
+	bufferedWriter := bufio.NewWriter(gzipWriter)
+
+	header := snapshotHeader{
+		Magic:      snapshotMagic,
+		Version:    snapshotVersion,
+		LastLedger: graph.lastLedger,
+		Offers:     uint64(len(graph.tradingPairForOffer)),
+	}
+	if err := binary.Write(bufferedWriter, binary.BigEndian, header); err != nil {
+		return errors.Wrap(err, "could not write snapshot header")
+	}
+
+	for _, edges := range graph.edgesForSellingAsset {
+		for _, offers := range edges {
+			for i := range offers {
+				if _, err := xdr.Marshal(bufferedWriter, &offers[i]); err != nil {
+					return errors.Wrap(err, "could not write offer")
+				}
+			}
+		}
+	}
+
+	if err := bufferedWriter.Flush(); err != nil {
+		return errors.Wrap(err, "could not flush snapshot")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "could not close snapshot")
+	}
+	return nil
+}
+
+// ReadSnapshot replaces all offers in the graph with the offers from a
+// snapshot created by WriteSnapshot and returns the ledger the snapshot is
+// accurate up to. Queued operations which were not applied are discarded. If
+// an error is returned the graph is left empty.
+func (graph *OrderBookGraph) ReadSnapshot(r io.Reader) (uint32, error) {
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+
+	graph.clear()
+	ledger, err := graph.readSnapshot(r)
+	if err != nil {
+		graph.clear()
+		return 0, err
+	}
+	graph.lastLedger = ledger
+	return ledger, nil
+}
+
+// readSnapshot adds offers from the snapshot to the graph and returns the
+// ledger the snapshot is accurate up to, the caller must hold the write lock
+func (graph *OrderBookGraph) readSnapshot(r io.Reader) (uint32, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open snapshot")
+	}
+	defer gzipReader.Close()
+	bufferedReader := bufio.NewReader(gzipReader)
+
+	var header snapshotHeader
+	if err = binary.Read(bufferedReader, binary.BigEndian, &header); err != nil {
+		return 0, errors.Wrap(err, "could not read snapshot header")
+	}
+	if header.Magic != snapshotMagic {
+		return 0, errors.New("invalid snapshot")
+	}
+	if header.Version != snapshotVersion {
+		return 0, errors.Errorf("unsupported snapshot version %d", header.Version)
+	}
+
+	for i := uint64(0); i < header.Offers; i++ {
+		var offer xdr.OfferEntry
+		if _, err = xdr.Unmarshal(bufferedReader, &offer); err != nil {
+			return 0, errors.Wrapf(err, "could not read offer %d", i)
+		}
+		if err = graph.add(offer); err != nil {
+			return 0, errors.Wrapf(err, "could not add offer %d", i)
+		}
+	}
+	if _, err = bufferedReader.ReadByte(); err == nil {
+		return 0, errors.New("unexpected data after the last offer in snapshot")
+	} else if err != io.EOF {
+		// gzip checksum is verified when the end of the stream is reached
+		return 0, errors.Wrap(err, "could not read snapshot")
+	}
+	if len(graph.tradingPairForOffer) != int(header.Offers) {
+		return 0, errors.New("snapshot contains duplicate offers")
+	}
+	return header.LastLedger, nil
+}
@@ -0,0 +1,148 @@
+package orderbook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+func TestSnapshot(t *testing.T) {
+	graph := NewOrderBookGraph()
+	for _, offer := range []xdr.OfferEntry{
+		dollarOffer,
+		threeEurOffer,
+		eurOffer,
+		twoEurOffer,
+		quarterOffer,
+		fiftyCentsOffer,
+	} {
+		graph.AddOffer(offer)
+	}
+	if err := graph.Apply(123); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// pending operations are not included in the snapshot
+	graph.RemoveOffer(dollarOffer.OfferId)
+
+	var snapshot bytes.Buffer
+	if err := graph.WriteSnapshot(&snapshot); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	graph.Discard()
+
+	restored := NewOrderBookGraph()
+	restored.AddOffer(xdr.OfferEntry{
+		SellerId: issuer,
+		OfferId:  xdr.Int64(100),
+		Buying:   eurAsset,
+		Selling:  chfAsset,
+		Price:    xdr.Price{N: 1, D: 1},
+		Amount:   xdr.Int64(100),
+	})
+	if err := restored.Apply(1); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ledger, err := restored.ReadSnapshot(bytes.NewReader(snapshot.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ledger != 123 {
+		t.Fatalf("expected ledger to be %v but got %v", 123, ledger)
+	}
+	assertGraphEquals(t, graph, restored)
+	if restored.lastLedger != 123 {
+		t.Fatalf("expected last ledger to be %v but got %v", 123, restored.lastLedger)
+	}
+
+	// updates can be applied to the restored graph
+	restored.RemoveOffer(dollarOffer.OfferId)
+	if err = restored.Apply(124); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(restored.Offers()) != 5 {
+		t.Fatalf("expected 5 offers but got %v", restored.Offers())
+	}
+
+	// an empty graph can be restored too
+	snapshot.Reset()
+	if err = NewOrderBookGraph().WriteSnapshot(&snapshot); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ledger, err = restored.ReadSnapshot(&snapshot); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ledger != 0 || !restored.IsEmpty() {
+		t.Fatalf("expected empty graph")
+	}
+}
+
+func TestInvalidSnapshot(t *testing.T) {
+	graph := NewOrderBookGraph()
+	graph.AddOffer(eurOffer)
+	graph.AddOffer(twoEurOffer)
+	if err := graph.Apply(2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var snapshot bytes.Buffer
+	if err := graph.WriteSnapshot(&snapshot); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	gzipped := func(data []byte) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		writer.Write(data)
+		writer.Close()
+		return buf.Bytes()
+	}
+
+	truncated := snapshot.Bytes()[:snapshot.Len()-10]
+	corrupted := append([]byte{}, snapshot.Bytes()...)
+	corrupted[len(corrupted)-5]++
+
+	for _, testCase := range []struct {
+		name     string
+		snapshot []byte
+		expected string
+	}{
+		{"not gzipped", []byte("snapshot"), "could not open snapshot: unexpected EOF"},
+		{"invalid magic", gzipped([]byte("OBGX\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00")), "invalid snapshot"},
+		{
+			"unsupported version",
+			gzipped([]byte("OBGS\x00\x00\x00\x02\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00")),
+			"unsupported snapshot version 2",
+		},
+		{
+			"missing offers",
+			gzipped([]byte("OBGS\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x01")),
+			"could not read offer 0: ",
+		},
+		{
+			"trailing data",
+			gzipped([]byte("OBGS\x00\x00\x00\x01\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x00x")),
+			"unexpected data after the last offer in snapshot",
+		},
+		{"truncated", truncated, "could not read snapshot: unexpected EOF"},
+		{"corrupted", corrupted, "could not read snapshot: gzip: invalid checksum"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			restored := NewOrderBookGraph()
+			restored.AddOffer(dollarOffer)
+			if err := restored.Apply(1); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			_, err := restored.ReadSnapshot(bytes.NewReader(testCase.snapshot))
+			if err == nil || !strings.HasPrefix(err.Error(), testCase.expected) {
+				t.Fatalf("expected error %v but got %v", testCase.expected, err)
+			}
+			if !restored.IsEmpty() || restored.lastLedger != 0 {
+				t.Fatalf("expected graph to be empty")
+			}
+		})
+	}
+}
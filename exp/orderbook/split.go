@@ -0,0 +1,394 @@
+package orderbook
+
+import (
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ErrNotEnoughLiquidity is returned by FindSplitPaths and FindFixedSplitPaths
+// when the order book does not contain enough offers to fill the requested
+// amount.
+var ErrNotEnoughLiquidity = errors.New("not enough liquidity in the order book")
+
+// SplitPaths is an allocation of a payment across multiple payment paths
+type SplitPaths struct {
+	// Legs contains the payment paths the payment is split into. The amounts
+	// of every leg take into account offers consumed by the previous legs so
+	// the legs must be submitted in order (ex. as operations of a single
+	// transaction) to clear with the given amounts.
+	Legs []Path
+	// SourceAmount is the sum of the source amounts of all legs
+	SourceAmount xdr.Int64
+	// DestinationAmount is the sum of the destination amounts of all legs
+	DestinationAmount xdr.Int64
+}
+
+// residualOffers maps an offer id to the amount of the offer consumed by
+// already allocated payment paths
+type residualOffers map[xdr.Int64]xdr.Int64
+
+// offers returns the given offers with amounts reduced by the consumed amounts.
+// Offers which were fully consumed are skipped.
+func (r residualOffers) offers(offers []xdr.OfferEntry) []xdr.OfferEntry {
+	if len(r) == 0 {
+		return offers
+	}
+	remaining := make([]xdr.OfferEntry, 0, len(offers))
+	for _, offer := range offers {
+		offer.Amount -= r[offer.OfferId]
+		if offer.Amount > 0 {
+			remaining = append(remaining, offer)
+		}
+	}
+	return remaining
+}
+
+// edges returns the given edge set with amounts of offers reduced by the
+// consumed amounts
+func (r residualOffers) edges(edges edgeSet) edgeSet {
+	if len(r) == 0 {
+		return edges
+	}
+	remaining := make(edgeSet, len(edges))
+	for asset, offers := range edges {
+		if offersForEdge := r.offers(offers); len(offersForEdge) > 0 {
+			remaining[asset] = offersForEdge
+		}
+	}
+	return remaining
+}
+
+// residualSellingSearchState is a sellingGraphSearchState which only
+// traverses offers which were not consumed by already allocated payment paths
+type residualSellingSearchState struct {
+	*sellingGraphSearchState
+	residual residualOffers
+}
+
+func (state *residualSellingSearchState) edges(currentAssetString string) edgeSet {
+	return state.residual.edges(state.sellingGraphSearchState.edges(currentAssetString))
+}
+
+// residualBuyingSearchState is a buyingGraphSearchState which only traverses
+// offers which were not consumed by already allocated payment paths
+type residualBuyingSearchState struct {
+	*buyingGraphSearchState
+	residual residualOffers
+}
+
+func (state *residualBuyingSearchState) edges(currentAssetString string) edgeSet {
+	return state.residual.edges(state.buyingGraphSearchState.edges(currentAssetString))
+}
+
+// pathSplitter allocates a payment across multiple payment paths. The payment
+// amount is divided into chunks and every chunk is allocated to the best
+// payment path available in the order book left after allocating the previous
+// chunks.
+type pathSplitter struct {
+	graph            *OrderBookGraph
+	maxPathLength    int
+	maxLegs          int
+	ignoreOffersFrom *xdr.AccountId
+	// strictSend is true when the source amount is fixed (and the destination
+	// amount is maximized) and false when the destination amount is fixed (and
+	// the source amount is minimized)
+	strictSend       bool
+	sourceAsset      xdr.Asset
+	destinationAsset xdr.Asset
+
+	residual residualOffers
+	legs     []Path
+	legIndex map[string]int
+}
+
+// FindSplitPaths splits a payment of `destinationAmount` of `destinationAsset`
+// paid with `sourceAsset` into at most `maxLegs` payment paths minimizing the
+// total amount of `sourceAsset` spent. The destination amount is divided into
+// `splits` chunks and each chunk is allocated to the cheapest payment path,
+// taking into account offers consumed by the chunks allocated before it.
+// `sourceAccountID` is optional. if `sourceAccountID` is provided then no offers
+// created by `sourceAccountID` will be considered when evaluating payment paths.
+// ErrNotEnoughLiquidity is returned if the payment cannot be filled.
+func (graph *OrderBookGraph) FindSplitPaths(
+	maxPathLength int,
+	sourceAsset xdr.Asset,
+	destinationAsset xdr.Asset,
+	destinationAmount xdr.Int64,
+	sourceAccountID *xdr.AccountId,
+	maxLegs int,
+	splits int,
+) (SplitPaths, uint32, error) {
+	splitter := &pathSplitter{
+		graph:            graph,
+		maxPathLength:    maxPathLength,
+		maxLegs:          maxLegs,
+		ignoreOffersFrom: sourceAccountID,
+		sourceAsset:      sourceAsset,
+		destinationAsset: destinationAsset,
+	}
+
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+	result, err := splitter.split(destinationAmount, splits)
+	return result, graph.lastLedger, err
+}
+
+// FindFixedSplitPaths splits a payment spending `amountToSpend` of
+// `sourceAsset` into at most `maxLegs` payment paths ending with
+// `destinationAsset`, maximizing the total amount of `destinationAsset`
+// received. The source amount is divided into `splits` chunks and each chunk
+// is allocated to the payment path delivering the most, taking into account
+// offers consumed by the chunks allocated before it.
+// ErrNotEnoughLiquidity is returned if the payment cannot be filled.
+func (graph *OrderBookGraph) FindFixedSplitPaths(
+	maxPathLength int,
+	sourceAsset xdr.Asset,
+	amountToSpend xdr.Int64,
+	destinationAsset xdr.Asset,
+	maxLegs int,
+	splits int,
+) (SplitPaths, uint32, error) {
+	splitter := &pathSplitter{
+		graph:            graph,
+		maxPathLength:    maxPathLength,
+		maxLegs:          maxLegs,
+		strictSend:       true,
+		sourceAsset:      sourceAsset,
+		destinationAsset: destinationAsset,
+	}
+
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+	result, err := splitter.split(amountToSpend, splits)
+	return result, graph.lastLedger, err
+}
+
+func (splitter *pathSplitter) split(amount xdr.Int64, splits int) (SplitPaths, error) {
+	if amount <= 0 {
+		return SplitPaths{}, errors.New("amount must be positive")
+	}
+	if splits <= 0 {
+		return SplitPaths{}, errors.New("splits must be positive")
+	}
+	if splitter.maxLegs <= 0 {
+		return SplitPaths{}, errors.New("maxLegs must be positive")
+	}
+	if xdr.Int64(splits) > amount {
+		splits = int(amount)
+	}
+
+	splitter.residual = residualOffers{}
+	splitter.legIndex = map[string]int{}
+	chunk := amount / xdr.Int64(splits)
+	for i := 0; i < splits; i++ {
+		chunkAmount := chunk
+		if i == splits-1 {
+			chunkAmount = amount - chunk*xdr.Int64(splits-1)
+		}
+		if err := splitter.allocate(chunkAmount); err != nil {
+			return SplitPaths{}, err
+		}
+	}
+
+	// Legs are allocated chunk by chunk so offers shared by multiple legs are
+	// consumed in a different order than when the legs are submitted. Amounts
+	// are recalculated by submitting legs one after another.
+	splitter.residual = residualOffers{}
+	result := SplitPaths{Legs: splitter.legs}
+	for i := range result.Legs {
+		leg := &result.Legs[i]
+		if splitter.strictSend {
+			destinationAmount, err := splitter.consume(*leg, leg.SourceAmount)
+			if err != nil {
+				return SplitPaths{}, err
+			}
+			leg.DestinationAmount = destinationAmount
+		} else {
+			sourceAmount, err := splitter.consume(*leg, leg.DestinationAmount)
+			if err != nil {
+				return SplitPaths{}, err
+			}
+			leg.SourceAmount = sourceAmount
+		}
+		result.SourceAmount += leg.SourceAmount
+		result.DestinationAmount += leg.DestinationAmount
+	}
+	return result, nil
+}
+
+// allocate finds the best payment path for the given amount in the residual
+// order book and adds the amount to the leg using that path.
+func (splitter *pathSplitter) allocate(amount xdr.Int64) error {
+	candidates, err := splitter.candidates(amount)
+	if err != nil {
+		return err
+	}
+
+	var (
+		best    *Path
+		bestKey string
+	)
+	for i := range candidates {
+		candidate := &candidates[i]
+		key := pathKey(*candidate)
+		if _, ok := splitter.legIndex[key]; !ok && len(splitter.legs) >= splitter.maxLegs {
+			continue
+		}
+		if best == nil || splitter.better(*candidate, *best) ||
+			(!splitter.better(*best, *candidate) && key < bestKey) {
+			best = candidate
+			bestKey = key
+		}
+	}
+	if best == nil {
+		return ErrNotEnoughLiquidity
+	}
+
+	if _, err = splitter.consume(*best, amount); err != nil {
+		return err
+	}
+
+	index, ok := splitter.legIndex[bestKey]
+	if !ok {
+		leg := *best
+		leg.SourceAmount = 0
+		leg.DestinationAmount = 0
+		splitter.legs = append(splitter.legs, leg)
+		index = len(splitter.legs) - 1
+		splitter.legIndex[bestKey] = index
+	}
+	if splitter.strictSend {
+		splitter.legs[index].SourceAmount += amount
+	} else {
+		splitter.legs[index].DestinationAmount += amount
+	}
+	return nil
+}
+
+// candidates returns all payment paths able to fill the given amount in the
+// residual order book
+func (splitter *pathSplitter) candidates(amount xdr.Int64) ([]Path, error) {
+	if splitter.strictSend {
+		state := &buyingGraphSearchState{
+			graph:             splitter.graph,
+			sourceAsset:       splitter.sourceAsset,
+			sourceAssetAmount: amount,
+			targetAssets:      map[string]bool{splitter.destinationAsset.String(): true},
+			paths:             []Path{},
+		}
+		err := dfs(
+			&residualBuyingSearchState{state, splitter.residual},
+			splitter.maxPathLength,
+			map[string]bool{},
+			[]xdr.Asset{},
+			splitter.sourceAsset.String(),
+			splitter.sourceAsset,
+			amount,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine paths")
+		}
+		return state.paths, nil
+	}
+
+	state := &sellingGraphSearchState{
+		graph:                  splitter.graph,
+		destinationAsset:       splitter.destinationAsset,
+		destinationAssetAmount: amount,
+		ignoreOffersFrom:       splitter.ignoreOffersFrom,
+		targetAssets:           map[string]xdr.Int64{splitter.sourceAsset.String(): 0},
+		paths:                  []Path{},
+	}
+	err := dfs(
+		&residualSellingSearchState{state, splitter.residual},
+		splitter.maxPathLength,
+		map[string]bool{},
+		[]xdr.Asset{},
+		splitter.destinationAsset.String(),
+		splitter.destinationAsset,
+		amount,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine paths")
+	}
+	return state.paths, nil
+}
+
+// better returns true if path a is a better allocation than path b
+func (splitter *pathSplitter) better(a, b Path) bool {
+	if splitter.strictSend {
+		if a.DestinationAmount != b.DestinationAmount {
+			return a.DestinationAmount > b.DestinationAmount
+		}
+	} else if a.SourceAmount != b.SourceAmount {
+		return a.SourceAmount < b.SourceAmount
+	}
+	return len(a.InteriorNodes) < len(b.InteriorNodes)
+}
+
+// consume sends the given amount (of the source asset when strictSend is true
+// and of the destination asset otherwise) through the given path, consuming
+// offers in the residual order book. It returns the amount received (when
+// strictSend is true) or the amount which needs to be spent (otherwise). The
+// residual order book is updated only if the whole amount can be filled.
+func (splitter *pathSplitter) consume(path Path, amount xdr.Int64) (xdr.Int64, error) {
+	assets := make([]xdr.Asset, 0, len(path.InteriorNodes)+2)
+	assets = append(assets, path.SourceAsset)
+	assets = append(assets, path.InteriorNodes...)
+	assets = append(assets, path.DestinationAsset)
+	if !splitter.strictSend {
+		// When the destination amount is fixed offers are consumed starting
+		// from the destination asset.
+		for i, j := 0, len(assets)-1; i < j; i, j = i+1, j-1 {
+			assets[i], assets[j] = assets[j], assets[i]
+		}
+	}
+
+	consumed := residualOffers{}
+	onConsume := func(offer xdr.OfferEntry, sellingUnits xdr.Int64) {
+		consumed[offer.OfferId] += sellingUnits
+	}
+	for i := 0; i+1 < len(assets); i++ {
+		current, next := assets[i].String(), assets[i+1].String()
+		var err error
+		if splitter.strictSend {
+			offers := splitter.residual.offers(splitter.graph.edgesForBuyingAsset[current][next])
+			if len(offers) == 0 {
+				return -1, ErrNotEnoughLiquidity
+			}
+			amount, err = consumeOffersForBuyingAssetWithCallback(offers, amount, onConsume)
+		} else {
+			offers := splitter.residual.offers(splitter.graph.edgesForSellingAsset[current][next])
+			if len(offers) == 0 {
+				return -1, ErrNotEnoughLiquidity
+			}
+			amount, err = consumeOffersForSellingAssetWithCallback(
+				offers, splitter.ignoreOffersFrom, amount, onConsume,
+			)
+		}
+		if err != nil {
+			return -1, errors.Wrap(err, "could not consume offers")
+		}
+		if amount <= 0 {
+			return -1, ErrNotEnoughLiquidity
+		}
+	}
+
+	for offerID, amount := range consumed {
+		splitter.residual[offerID] += amount
+	}
+	return amount, nil
+}
+
+// pathKey returns a string identifying the assets of a payment path
+func pathKey(path Path) string {
+	assets := make([]string, 0, len(path.InteriorNodes)+2)
+	assets = append(assets, path.SourceAssetString())
+	for _, asset := range path.InteriorNodes {
+		assets = append(assets, asset.String())
+	}
+	assets = append(assets, path.DestinationAssetString())
+	return strings.Join(assets, ">")
+}
@@ -0,0 +1,203 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+// splitTestGraph returns a graph where EUR can be bought with USD directly
+// (500 EUR for 1 USD each and 500 EUR for 3 USD each) or through XLM
+// (500 EUR for 2 USD each).
+func splitTestGraph(t *testing.T) *OrderBookGraph {
+	graph := NewOrderBookGraph()
+	for _, offer := range []xdr.OfferEntry{
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(1),
+			Buying:   usdAsset,
+			Selling:  eurAsset,
+			Price:    xdr.Price{N: 1, D: 1},
+			Amount:   xdr.Int64(500),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(2),
+			Buying:   usdAsset,
+			Selling:  eurAsset,
+			Price:    xdr.Price{N: 3, D: 1},
+			Amount:   xdr.Int64(500),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(3),
+			Buying:   usdAsset,
+			Selling:  nativeAsset,
+			Price:    xdr.Price{N: 2, D: 1},
+			Amount:   xdr.Int64(1000),
+		},
+		{
+			SellerId: issuer,
+			OfferId:  xdr.Int64(4),
+			Buying:   nativeAsset,
+			Selling:  eurAsset,
+			Price:    xdr.Price{N: 1, D: 1},
+			Amount:   xdr.Int64(500),
+		},
+	} {
+		graph.AddOffer(offer)
+	}
+	if err := graph.Apply(1); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return graph
+}
+
+func assertSplitPathsEquals(t *testing.T, expected, actual SplitPaths) {
+	assertPathEquals(t, expected.Legs, actual.Legs)
+	for i := range expected.Legs {
+		if pathKey(expected.Legs[i]) != pathKey(actual.Legs[i]) {
+			t.Fatalf("expected paths to be same got %v %v", expected.Legs, actual.Legs)
+		}
+	}
+	if expected.SourceAmount != actual.SourceAmount {
+		t.Fatalf("expected source amount %v but got %v", expected.SourceAmount, actual.SourceAmount)
+	}
+	if expected.DestinationAmount != actual.DestinationAmount {
+		t.Fatalf("expected destination amount %v but got %v", expected.DestinationAmount, actual.DestinationAmount)
+	}
+}
+
+func TestFindSplitPaths(t *testing.T) {
+	graph := splitTestGraph(t)
+
+	result, lastLedger, err := graph.FindSplitPaths(3, usdAsset, eurAsset, 1000, nil, 3, 10)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if lastLedger != 1 {
+		t.Fatalf("expected last ledger to be %v but got %v", 1, lastLedger)
+	}
+	assertSplitPathsEquals(t, SplitPaths{
+		Legs: []Path{
+			{
+				SourceAmount:      500,
+				SourceAsset:       usdAsset,
+				InteriorNodes:     []xdr.Asset{},
+				DestinationAsset:  eurAsset,
+				DestinationAmount: 500,
+			},
+			{
+				SourceAmount:      1000,
+				SourceAsset:       usdAsset,
+				InteriorNodes:     []xdr.Asset{nativeAsset},
+				DestinationAsset:  eurAsset,
+				DestinationAmount: 500,
+			},
+		},
+		SourceAmount:      1500,
+		DestinationAmount: 1000,
+	}, result)
+
+	// A single path is as expensive as the best path found by FindPaths.
+	paths, _, err := graph.FindPaths(3, eurAsset, 1000, nil, []xdr.Asset{usdAsset}, []xdr.Int64{0}, false, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	result, _, err = graph.FindSplitPaths(3, usdAsset, eurAsset, 1000, nil, 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertSplitPathsEquals(t, SplitPaths{
+		Legs:              paths,
+		SourceAmount:      2000,
+		DestinationAmount: 1000,
+	}, result)
+
+	_, _, err = graph.FindSplitPaths(3, usdAsset, eurAsset, 1501, nil, 3, 10)
+	if err != ErrNotEnoughLiquidity {
+		t.Fatalf("expected error %v but got %v", ErrNotEnoughLiquidity, err)
+	}
+
+	// Offers of the source account are ignored.
+	_, _, err = graph.FindSplitPaths(3, usdAsset, eurAsset, 1000, &issuer, 3, 10)
+	if err != ErrNotEnoughLiquidity {
+		t.Fatalf("expected error %v but got %v", ErrNotEnoughLiquidity, err)
+	}
+}
+
+func TestFindFixedSplitPaths(t *testing.T) {
+	graph := splitTestGraph(t)
+
+	result, _, err := graph.FindFixedSplitPaths(3, usdAsset, 1500, eurAsset, 3, 10)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertSplitPathsEquals(t, SplitPaths{
+		Legs: []Path{
+			{
+				SourceAmount:      600,
+				SourceAsset:       usdAsset,
+				InteriorNodes:     []xdr.Asset{},
+				DestinationAsset:  eurAsset,
+				DestinationAmount: 533,
+			},
+			{
+				SourceAmount:      900,
+				SourceAsset:       usdAsset,
+				InteriorNodes:     []xdr.Asset{nativeAsset},
+				DestinationAsset:  eurAsset,
+				DestinationAmount: 450,
+			},
+		},
+		SourceAmount:      1500,
+		DestinationAmount: 983,
+	}, result)
+
+	paths, _, err := graph.FindFixedPaths(3, usdAsset, 1500, []xdr.Asset{eurAsset}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if paths[0].DestinationAmount >= result.DestinationAmount {
+		t.Fatalf(
+			"expected split paths to deliver more than %v but got %v",
+			paths[0].DestinationAmount,
+			result.DestinationAmount,
+		)
+	}
+
+	_, _, err = graph.FindFixedSplitPaths(3, usdAsset, 3000, eurAsset, 3, 10)
+	if err != ErrNotEnoughLiquidity {
+		t.Fatalf("expected error %v but got %v", ErrNotEnoughLiquidity, err)
+	}
+}
+
+func TestFindSplitPathsInvalidParameters(t *testing.T) {
+	graph := splitTestGraph(t)
+
+	for _, testCase := range []struct {
+		amount   xdr.Int64
+		maxLegs  int
+		splits   int
+		expected string
+	}{
+		{0, 1, 1, "amount must be positive"},
+		{10, 0, 1, "maxLegs must be positive"},
+		{10, 1, 0, "splits must be positive"},
+	} {
+		_, _, err := graph.FindSplitPaths(3, usdAsset, eurAsset, testCase.amount, nil, testCase.maxLegs, testCase.splits)
+		if err == nil || err.Error() != testCase.expected {
+			t.Fatalf("expected error %v but got %v", testCase.expected, err)
+		}
+	}
+
+	// Amounts smaller than the number of splits are allocated in one unit
+	// chunks.
+	result, _, err := graph.FindSplitPaths(3, usdAsset, eurAsset, 3, nil, 3, 10)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result.SourceAmount != 3 || len(result.Legs) != 1 {
+		t.Fatalf("unexpected result %v", result)
+	}
+}
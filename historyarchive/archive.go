@@ -43,10 +43,22 @@ type ConnectOptions struct {
 	NetworkPassphrase string
 	S3Region          string
 	S3Endpoint        string
-	UnsignedRequests  bool
+	// GCSEndpoint is the (optional) endpoint of the Google Cloud Storage JSON
+	// API, ex. the endpoint of a local emulator. STORAGE_EMULATOR_HOST
+	// environment variable is used when empty.
+	GCSEndpoint string
+	// AzureEndpoint is the (optional) endpoint of Azure Blob Storage, ex. the
+	// endpoint of a local Azurite emulator. The endpoint of the account is
+	// used when empty.
+	AzureEndpoint    string
+	UnsignedRequests bool
 	// CheckpointFrequency is the number of ledgers between checkpoints
 	// if unset, DefaultCheckpointFrequency will be used
 	CheckpointFrequency uint32
+	// BucketCache is the (optional) cache of buckets. When set, buckets are
+	// read from the cache and downloaded from the archive only when missing.
+	// It can be shared by many archives.
+	BucketCache *BucketCache
 }
 
 type Ledger struct {
@@ -64,6 +76,14 @@ type ArchiveBackend interface {
 	CanListFiles() bool
 }
 
+// ContextArchiveBackend is an ArchiveBackend which can download files using
+// a context other than the one passed in ConnectOptions, so that a single
+// download can be canceled or bounded by a deadline.
+type ContextArchiveBackend interface {
+	ArchiveBackend
+	GetFileContext(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
 type ArchiveInterface interface {
 	GetPathHAS(path string) (HistoryArchiveState, error)
 	PutPathHAS(path string, has HistoryArchiveState, opts *CommandOptions) error
@@ -72,7 +92,9 @@ type ArchiveInterface interface {
 	GetLedgerHeader(chk uint32) (xdr.LedgerHeaderHistoryEntry, error)
 	GetRootHAS() (HistoryArchiveState, error)
 	GetLedgers(start, end uint32) (map[uint32]*Ledger, error)
+	GetLedgersContext(ctx context.Context, start, end uint32) (map[uint32]*Ledger, error)
 	GetCheckpointHAS(chk uint32) (HistoryArchiveState, error)
+	GetCheckpointHASContext(ctx context.Context, chk uint32) (HistoryArchiveState, error)
 	PutCheckpointHAS(chk uint32, has HistoryArchiveState, opts *CommandOptions) error
 	PutRootHAS(has HistoryArchiveState, opts *CommandOptions) error
 	ListBucket(dp DirPrefix) (chan string, chan error)
@@ -80,7 +102,9 @@ type ArchiveInterface interface {
 	ListAllBucketHashes() (chan Hash, chan error)
 	ListCategoryCheckpoints(cat string, pth string) (chan uint32, chan error)
 	GetXdrStreamForHash(hash Hash) (*XdrStream, error)
+	GetXdrStreamForHashContext(ctx context.Context, hash Hash) (*XdrStream, error)
 	GetXdrStream(pth string) (*XdrStream, error)
+	GetXdrStreamContext(ctx context.Context, pth string) (*XdrStream, error)
 	GetCheckpointManager() CheckpointManager
 }
 
@@ -88,6 +112,9 @@ var _ ArchiveInterface = &Archive{}
 
 type Archive struct {
 	networkPassphrase string
+	// ctx is the context passed in ConnectOptions. It's used by methods
+	// which don't accept a context.
+	ctx context.Context
 
 	mutex             sync.Mutex
 	checkpointFiles   map[string](map[uint32]bool)
@@ -109,7 +136,8 @@ type Archive struct {
 
 	checkpointManager CheckpointManager
 
-	backend ArchiveBackend
+	backend     ArchiveBackend
+	bucketCache *BucketCache
 }
 
 func (arch *Archive) GetCheckpointManager() CheckpointManager {
@@ -117,8 +145,38 @@ func (arch *Archive) GetCheckpointManager() CheckpointManager {
 }
 
 func (a *Archive) GetPathHAS(path string) (HistoryArchiveState, error) {
+	return a.getPathHAS(a.ctx, path)
+}
+
+// getFile downloads a file using the given context if the backend supports
+// it, otherwise it only checks if the context is done before downloading.
+func (a *Archive) getFile(ctx context.Context, pth string) (io.ReadCloser, error) {
+	if ctx == nil {
+		return a.backend.GetFile(pth)
+	}
+	if backend, ok := a.backend.(ContextArchiveBackend); ok {
+		return backend.GetFileContext(ctx, pth)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.backend.GetFile(pth)
+}
+
+// getBucketFile downloads a gzipped bucket, using the bucket cache if set.
+func (a *Archive) getBucketFile(ctx context.Context, hash Hash) (io.ReadCloser, error) {
+	pth := BucketPath(hash)
+	if a.bucketCache == nil {
+		return a.getFile(ctx, pth)
+	}
+	return a.bucketCache.GetFile(hash, func() (io.ReadCloser, error) {
+		return a.getFile(ctx, pth)
+	})
+}
+
+func (a *Archive) getPathHAS(ctx context.Context, path string) (HistoryArchiveState, error) {
 	var has HistoryArchiveState
-	rdr, err := a.backend.GetFile(path)
+	rdr, err := a.getFile(ctx, path)
 	if err != nil {
 		return has, err
 	}
@@ -195,7 +253,7 @@ func (a *Archive) GetLedgerHeader(ledger uint32) (xdr.LedgerHeaderHistoryEntry,
 		}
 	}
 
-	return xdr.LedgerHeaderHistoryEntry{}, errors.New("ledger header not found in checkpoint")
+	return xdr.LedgerHeaderHistoryEntry{}, permanentError{errors.New("ledger header not found in checkpoint")}
 }
 
 func (a *Archive) GetRootHAS() (HistoryArchiveState, error) {
@@ -203,6 +261,12 @@ func (a *Archive) GetRootHAS() (HistoryArchiveState, error) {
 }
 
 func (a *Archive) GetLedgers(start, end uint32) (map[uint32]*Ledger, error) {
+	return a.GetLedgersContext(a.ctx, start, end)
+}
+
+// GetLedgersContext is like GetLedgers but downloads checkpoint files using
+// the given context.
+func (a *Archive) GetLedgersContext(ctx context.Context, start, end uint32) (map[uint32]*Ledger, error) {
 	if start > end {
 		return nil, errors.Errorf("range is invalid, start: %d end: %d", start, end)
 	}
@@ -214,10 +278,10 @@ func (a *Archive) GetLedgers(start, end uint32) (map[uint32]*Ledger, error) {
 			if exists, err := a.CategoryCheckpointExists(category, cur); err != nil {
 				return nil, errors.Wrap(err, "could not check if category checkpoint exists")
 			} else if !exists {
-				return nil, errors.Errorf("checkpoint %d is not published", cur)
+				return nil, permanentError{errors.Errorf("checkpoint %d is not published", cur)}
 			}
 
-			if err := a.fetchCategory(cache, category, cur); err != nil {
+			if err := a.fetchCategory(ctx, cache, category, cur); err != nil {
 				return nil, errors.Wrap(err, "could not fetch category checkpoint")
 			}
 		}
@@ -226,9 +290,9 @@ func (a *Archive) GetLedgers(start, end uint32) (map[uint32]*Ledger, error) {
 	return cache, nil
 }
 
-func (a *Archive) fetchCategory(cache map[uint32]*Ledger, category string, checkpointSequence uint32) error {
+func (a *Archive) fetchCategory(ctx context.Context, cache map[uint32]*Ledger, category string, checkpointSequence uint32) error {
 	checkpointPath := CategoryCheckpointPath(category, checkpointSequence)
-	xdrStream, err := a.GetXdrStream(checkpointPath)
+	xdrStream, err := a.GetXdrStreamContext(ctx, checkpointPath)
 	if err != nil {
 		return errors.Wrapf(err, "error opening %s stream", category)
 	}
@@ -281,7 +345,13 @@ func (a *Archive) fetchCategory(cache map[uint32]*Ledger, category string, check
 }
 
 func (a *Archive) GetCheckpointHAS(chk uint32) (HistoryArchiveState, error) {
-	return a.GetPathHAS(CategoryCheckpointPath("history", chk))
+	return a.GetCheckpointHASContext(a.ctx, chk)
+}
+
+// GetCheckpointHASContext is like GetCheckpointHAS but downloads the HAS
+// using the given context.
+func (a *Archive) GetCheckpointHASContext(ctx context.Context, chk uint32) (HistoryArchiveState, error) {
+	return a.getPathHAS(ctx, CategoryCheckpointPath("history", chk))
 }
 
 func (a *Archive) PutCheckpointHAS(chk uint32, has HistoryArchiveState, opts *CommandOptions) error {
@@ -355,14 +425,42 @@ func (a *Archive) GetBucketPathForHash(hash Hash) string {
 }
 
 func (a *Archive) GetXdrStreamForHash(hash Hash) (*XdrStream, error) {
-	return a.GetXdrStream(a.GetBucketPathForHash(hash))
+	return a.GetXdrStreamForHashContext(a.ctx, hash)
+}
+
+// GetXdrStreamForHashContext is like GetXdrStreamForHash but downloads the
+// bucket using the given context. Canceling the context interrupts reading
+// from the returned stream for backends implementing ContextArchiveBackend.
+func (a *Archive) GetXdrStreamForHashContext(ctx context.Context, hash Hash) (*XdrStream, error) {
+	rdr, err := a.getBucketFile(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := NewXdrGzStream(rdr)
+	if err != nil {
+		return nil, err
+	}
+	if a.bucketCache != nil {
+		// The stream is read from the bucket cache, a cached file which
+		// turns out to be corrupt must not be served again.
+		stream.onHashMismatch = func() {
+			a.bucketCache.Remove(hash)
+		}
+	}
+	return stream, nil
 }
 
 func (a *Archive) GetXdrStream(pth string) (*XdrStream, error) {
+	return a.GetXdrStreamContext(a.ctx, pth)
+}
+
+// GetXdrStreamContext is like GetXdrStream but downloads the file using the
+// given context.
+func (a *Archive) GetXdrStreamContext(ctx context.Context, pth string) (*XdrStream, error) {
 	if !strings.HasSuffix(pth, ".xdr.gz") {
 		return nil, errors.New("File has non-.xdr.gz suffix: " + pth)
 	}
-	rdr, err := a.backend.GetFile(pth)
+	rdr, err := a.getFile(ctx, pth)
 	if err != nil {
 		return nil, err
 	}
@@ -382,6 +480,7 @@ func Connect(u string, opts ConnectOptions) (*Archive, error) {
 		expectTxResultSetHashes: make(map[uint32]Hash),
 		actualTxResultSetHashes: make(map[uint32]Hash),
 		checkpointManager:       NewCheckpointManager(opts.CheckpointFrequency),
+		bucketCache:             opts.BucketCache,
 	}
 	for _, cat := range Categories() {
 		arch.checkpointFiles[cat] = make(map[uint32]bool)
@@ -399,6 +498,7 @@ func Connect(u string, opts ConnectOptions) (*Archive, error) {
 	if opts.Context == nil {
 		opts.Context = context.Background()
 	}
+	arch.ctx = opts.Context
 
 	pth := parsed.Path
 	if parsed.Scheme == "s3" {
@@ -407,6 +507,12 @@ func Connect(u string, opts ConnectOptions) (*Archive, error) {
 			pth = pth[1:]
 		}
 		arch.backend, err = makeS3Backend(parsed.Host, pth, opts)
+	} else if parsed.Scheme == "gs" {
+		// Inside GCS, all paths start _without_ the leading /
+		arch.backend, err = makeGCSBackend(parsed.Host, strings.TrimPrefix(pth, "/"), opts)
+	} else if parsed.Scheme == "azure" {
+		// Inside Azure, all paths start _without_ the leading /
+		arch.backend, err = makeAzureBackend(parsed.Host, strings.TrimPrefix(pth, "/"), opts)
 	} else if parsed.Scheme == "file" {
 		pth = path.Join(parsed.Host, pth)
 		arch.backend = makeFsBackend(pth, opts)
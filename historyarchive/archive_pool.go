@@ -5,6 +5,7 @@
 package historyarchive
 
 import (
+	"context"
 	"math/rand"
 
 	"github.com/stellar/go/support/errors"
@@ -12,7 +13,9 @@ import (
 )
 
 // A ArchivePool is just a collection of `ArchiveInterface`s so that we can
-// distribute requests fairly throughout the pool.
+// distribute requests fairly throughout the pool. Errors of the picked archive
+// are returned as is, use RetryingArchivePool to retry failed requests using
+// other archives.
 type ArchivePool []ArchiveInterface
 
 // NewArchivePool tries connecting to each of the provided history archive URLs,
@@ -37,6 +40,7 @@ func NewArchivePool(archiveURLs []string, config ConnectOptions) (ArchivePool, e
 				NetworkPassphrase:   config.NetworkPassphrase,
 				CheckpointFrequency: config.CheckpointFrequency,
 				Context:             config.Context,
+				BucketCache:         config.BucketCache,
 			},
 		)
 
@@ -92,10 +96,18 @@ func (pa ArchivePool) GetLedgers(start, end uint32) (map[uint32]*Ledger, error)
 	return pa.GetAnyArchive().GetLedgers(start, end)
 }
 
+func (pa ArchivePool) GetLedgersContext(ctx context.Context, start, end uint32) (map[uint32]*Ledger, error) {
+	return pa.GetAnyArchive().GetLedgersContext(ctx, start, end)
+}
+
 func (pa ArchivePool) GetCheckpointHAS(chk uint32) (HistoryArchiveState, error) {
 	return pa.GetAnyArchive().GetCheckpointHAS(chk)
 }
 
+func (pa ArchivePool) GetCheckpointHASContext(ctx context.Context, chk uint32) (HistoryArchiveState, error) {
+	return pa.GetAnyArchive().GetCheckpointHASContext(ctx, chk)
+}
+
 func (pa ArchivePool) PutCheckpointHAS(chk uint32, has HistoryArchiveState, opts *CommandOptions) error {
 	return pa.GetAnyArchive().PutCheckpointHAS(chk, has, opts)
 }
@@ -124,10 +136,18 @@ func (pa ArchivePool) GetXdrStreamForHash(hash Hash) (*XdrStream, error) {
 	return pa.GetAnyArchive().GetXdrStreamForHash(hash)
 }
 
+func (pa ArchivePool) GetXdrStreamForHashContext(ctx context.Context, hash Hash) (*XdrStream, error) {
+	return pa.GetAnyArchive().GetXdrStreamForHashContext(ctx, hash)
+}
+
 func (pa ArchivePool) GetXdrStream(pth string) (*XdrStream, error) {
 	return pa.GetAnyArchive().GetXdrStream(pth)
 }
 
+func (pa ArchivePool) GetXdrStreamContext(ctx context.Context, pth string) (*XdrStream, error) {
+	return pa.GetAnyArchive().GetXdrStreamContext(ctx, pth)
+}
+
 func (pa ArchivePool) GetCheckpointManager() CheckpointManager {
 	return pa.GetAnyArchive().GetCheckpointManager()
 }
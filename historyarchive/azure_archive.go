@@ -0,0 +1,441 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	azureStorageAPIVersion = "2019-12-12"
+	// Environment variables used by Azure tools (az CLI, azcopy).
+	azureStorageAccountEnv          = "AZURE_STORAGE_ACCOUNT"
+	azureStorageKeyEnv              = "AZURE_STORAGE_KEY"
+	azureStorageSASTokenEnv         = "AZURE_STORAGE_SAS_TOKEN"
+	azureStorageConnectionStringEnv = "AZURE_STORAGE_CONNECTION_STRING"
+	// Well-known credentials of the Azurite storage emulator.
+	azuriteAccount  = "devstoreaccount1"
+	azuriteKey      = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	azuriteEndpoint = "http://127.0.0.1:10000/devstoreaccount1"
+	// azureBlockSize is the size of blocks used to upload files larger than
+	// a single block, so that files (ex. buckets) are never held in memory.
+	azureBlockSize = 4 << 20
+)
+
+// AzureArchiveBackend is an ArchiveBackend storing files in an Azure Blob
+// Storage container. Requests are authorized using a Shared Key or a SAS token
+// found in the environment (AZURE_STORAGE_CONNECTION_STRING or
+// AZURE_STORAGE_ACCOUNT with AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN).
+// Requests are anonymous when no credentials are found.
+type AzureArchiveBackend struct {
+	ctx       context.Context
+	client    http.Client
+	endpoint  url.URL
+	account   string
+	key       []byte
+	sasToken  url.Values
+	container string
+	prefix    string
+	// blockSize overrides azureBlockSize in tests.
+	blockSize int
+}
+
+type azureCredentials struct {
+	account  string
+	key      string
+	sasToken string
+	endpoint string
+}
+
+// parseAzureConnectionString parses Azure Storage connection strings, ex:
+// DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key;EndpointSuffix=core.windows.net
+func parseAzureConnectionString(connectionString string) (azureCredentials, error) {
+	if strings.TrimSpace(connectionString) == "UseDevelopmentStorage=true" {
+		return azureCredentials{
+			account:  azuriteAccount,
+			key:      azuriteKey,
+			endpoint: azuriteEndpoint,
+		}, nil
+	}
+
+	values := map[string]string{}
+	for _, part := range strings.Split(connectionString, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return azureCredentials{}, errors.Errorf("invalid connection string part: %s", part)
+		}
+		values[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	creds := azureCredentials{
+		account:  values["AccountName"],
+		key:      values["AccountKey"],
+		sasToken: values["SharedAccessSignature"],
+		endpoint: values["BlobEndpoint"],
+	}
+	if creds.endpoint == "" && creds.account != "" {
+		protocol := values["DefaultEndpointsProtocol"]
+		if protocol == "" {
+			protocol = "https"
+		}
+		suffix := values["EndpointSuffix"]
+		if suffix == "" {
+			suffix = "core.windows.net"
+		}
+		creds.endpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, creds.account, suffix)
+	}
+	return creds, nil
+}
+
+func azureCredentialsFromEnv() (azureCredentials, error) {
+	if connectionString := os.Getenv(azureStorageConnectionStringEnv); connectionString != "" {
+		return parseAzureConnectionString(connectionString)
+	}
+	return azureCredentials{
+		account:  os.Getenv(azureStorageAccountEnv),
+		key:      os.Getenv(azureStorageKeyEnv),
+		sasToken: os.Getenv(azureStorageSASTokenEnv),
+	}, nil
+}
+
+func (b *AzureArchiveBackend) blobURL(pth string) url.URL {
+	derived := b.endpoint
+	derived.Path = path.Join("/", derived.Path, b.container, b.prefix, pth)
+	return derived
+}
+
+// do sends a request adding the SAS token or the Shared Key authorization.
+func (b *AzureArchiveBackend) do(ctx context.Context, method string, u url.URL, body []byte, header http.Header) (*http.Response, error) {
+	query := u.Query()
+	for k, v := range b.sasToken {
+		query[k] = v
+	}
+	u.RawQuery = query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageAPIVersion)
+	if len(b.key) > 0 && len(b.sasToken) == 0 {
+		req.Header.Set("Authorization", fmt.Sprintf(
+			"SharedKey %s:%s", b.account, azureSharedKeySignature(req, b.account, b.key),
+		))
+	}
+	return b.client.Do(req)
+}
+
+// azureSharedKeySignature returns the Shared Key signature of the request, see:
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func azureSharedKeySignature(req *http.Request, account string, key []byte) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for k := range req.Header {
+		if lower := strings.ToLower(k); strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower)
+		}
+	}
+	sort.Strings(msHeaders)
+	var canonicalizedHeaders strings.Builder
+	for _, k := range msHeaders {
+		canonicalizedHeaders.WriteString(k + ":" + strings.TrimSpace(req.Header.Get(k)) + "\n")
+	}
+
+	canonicalizedResource := "/" + account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for k := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		values := query[k]
+		sort.Strings(values)
+		canonicalizedResource += "\n" + strings.ToLower(k) + ":" + strings.Join(values, ",")
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonicalizedHeaders.String() + canonicalizedResource,
+	}, "\n")
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (b *AzureArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
+	return b.GetFileContext(b.ctx, pth)
+}
+
+// GetFileContext downloads a file using the given context instead of the
+// context passed in ConnectOptions.
+func (b *AzureArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
+	resp, err := b.do(ctx, http.MethodGet, b.blobURL(pth), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkResp(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (b *AzureArchiveBackend) Head(pth string) (*http.Response, error) {
+	resp, err := b.do(b.ctx, http.MethodHead, b.blobURL(pth), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+func (b *AzureArchiveBackend) Exists(pth string) (bool, error) {
+	resp, err := b.Head(pth)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		return true, nil
+	} else if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	} else {
+		return false, errors.Errorf("Unkown status code=%d", resp.StatusCode)
+	}
+}
+
+func (b *AzureArchiveBackend) Size(pth string) (int64, error) {
+	resp, err := b.Head(pth)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		return resp.ContentLength, nil
+	} else if resp.StatusCode == http.StatusNotFound {
+		return 0, nil
+	} else {
+		return 0, errors.Errorf("Unkown status code=%d", resp.StatusCode)
+	}
+}
+
+// PutFile uploads a file. Files larger than a single block are streamed: they
+// are uploaded block by block (Put Block) and committed using Put Block List.
+func (b *AzureArchiveBackend) PutFile(pth string, in io.ReadCloser) error {
+	defer in.Close()
+
+	blockSize := b.blockSize
+	if blockSize == 0 {
+		blockSize = azureBlockSize
+	}
+	buf := make([]byte, blockSize)
+	n, err := io.ReadFull(in, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		header := http.Header{}
+		header.Set("x-ms-blob-type", "BlockBlob")
+		return b.put(b.blobURL(pth), buf[:n], header)
+	} else if err != nil {
+		return err
+	}
+
+	var blockIDs []string
+	for {
+		// Block IDs must have the same length for a given blob.
+		id := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(blockIDs))))
+		u := b.blobURL(pth)
+		u.RawQuery = url.Values{"comp": {"block"}, "blockid": {id}}.Encode()
+		if err = b.put(u, buf[:n], nil); err != nil {
+			return errors.Wrapf(err, "error uploading block %d", len(blockIDs))
+		}
+		blockIDs = append(blockIDs, id)
+
+		n, err = io.ReadFull(in, buf)
+		if err == io.EOF {
+			break
+		} else if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+	}
+
+	var blockList bytes.Buffer
+	blockList.WriteString(xml.Header)
+	if err = xml.NewEncoder(&blockList).Encode(azureBlockList{Latest: blockIDs}); err != nil {
+		return errors.Wrap(err, "error encoding block list")
+	}
+	u := b.blobURL(pth)
+	u.RawQuery = url.Values{"comp": {"blocklist"}}.Encode()
+	return errors.Wrap(b.put(u, blockList.Bytes(), nil), "error committing block list")
+}
+
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+func (b *AzureArchiveBackend) put(u url.URL, body []byte, header http.Header) error {
+	resp, err := b.do(b.ctx, http.MethodPut, u, body, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResp(resp)
+}
+
+type azureListBlobsResult struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (b *AzureArchiveBackend) listBlobs(prefix, marker string) (azureListBlobsResult, error) {
+	var result azureListBlobsResult
+
+	u := b.endpoint
+	u.Path = path.Join("/", u.Path, b.container)
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("prefix", prefix)
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+	u.RawQuery = query.Encode()
+
+	resp, err := b.do(b.ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if err = checkResp(resp); err != nil {
+		return result, err
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, errors.Wrap(err, "error decoding list blobs response")
+	}
+	return result, nil
+}
+
+func (b *AzureArchiveBackend) ListFiles(pth string) (chan string, chan error) {
+	prefix := path.Join(b.prefix, pth)
+	ch := make(chan string)
+	errs := make(chan error)
+
+	go func() {
+		marker := ""
+		for {
+			result, err := b.listBlobs(prefix, marker)
+			if err != nil {
+				errs <- err
+				break
+			}
+			for _, blob := range result.Blobs {
+				ch <- blob.Name
+			}
+			if result.NextMarker == "" {
+				break
+			}
+			marker = result.NextMarker
+		}
+		close(ch)
+		close(errs)
+	}()
+	return ch, errs
+}
+
+func (b *AzureArchiveBackend) CanListFiles() bool {
+	return true
+}
+
+func makeAzureBackend(container string, prefix string, opts ConnectOptions) (ArchiveBackend, error) {
+	creds, err := azureCredentialsFromEnv()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading Azure Storage credentials")
+	}
+
+	endpoint := opts.AzureEndpoint
+	if endpoint == "" {
+		endpoint = creds.endpoint
+	}
+	if endpoint == "" {
+		if creds.account == "" {
+			return nil, errors.Errorf(
+				"Azure Storage account not set, use %s or %s environment variables",
+				azureStorageAccountEnv, azureStorageConnectionStringEnv,
+			)
+		}
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", creds.account)
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing Azure Storage endpoint")
+	}
+
+	backend := AzureArchiveBackend{
+		ctx:       opts.Context,
+		endpoint:  *parsed,
+		account:   creds.account,
+		container: container,
+		prefix:    prefix,
+	}
+	if !opts.UnsignedRequests {
+		if creds.sasToken != "" {
+			backend.sasToken, err = url.ParseQuery(strings.TrimPrefix(creds.sasToken, "?"))
+			if err != nil {
+				return nil, errors.Wrap(err, "error parsing Azure Storage SAS token")
+			}
+		} else if creds.key != "" {
+			backend.key, err = base64.StdEncoding.DecodeString(creds.key)
+			if err != nil {
+				return nil, errors.Wrap(err, "error decoding Azure Storage account key")
+			}
+		}
+	}
+	return &backend, nil
+}
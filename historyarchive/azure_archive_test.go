@@ -0,0 +1,258 @@
+package historyarchive
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAzureServer implements a subset of Azure Blob Storage API using
+// path-style URLs (like Azurite): /<account>/<container>/<blob>.
+type fakeAzureServer struct {
+	t         *testing.T
+	account   string
+	key       []byte
+	container string
+	pageSize  int
+
+	lock  sync.Mutex
+	files map[string][]byte
+	// blocks holds uncommitted blocks by blob name and block id.
+	blocks map[string]map[string][]byte
+}
+
+func (s *fakeAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	expected := "SharedKey " + s.account + ":" + azureSharedKeySignature(r, s.account, s.key)
+	if r.Header.Get("Authorization") != expected {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	assert.Equal(s.t, azureStorageAPIVersion, r.Header.Get("x-ms-version"))
+	assert.NotEmpty(s.t, r.Header.Get("x-ms-date"))
+
+	containerPath := "/" + s.account + "/" + s.container
+	if r.URL.Path == containerPath {
+		s.list(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, containerPath+"/") {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, containerPath+"/")
+
+	switch r.Method {
+	case http.MethodPut:
+		switch r.URL.Query().Get("comp") {
+		case "block":
+			s.putBlock(w, r, name)
+			return
+		case "blocklist":
+			s.putBlockList(w, r, name)
+			return
+		}
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			http.Error(w, "invalid blob type", http.StatusBadRequest)
+			return
+		}
+		contents, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.files[name] = contents
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		contents, ok := s.files[name]
+		if !ok {
+			http.Error(w, "BlobNotFound", http.StatusNotFound)
+			return
+		}
+		w.Write(contents)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (s *fakeAzureServer) putBlock(w http.ResponseWriter, r *http.Request, name string) {
+	contents, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if s.blocks[name] == nil {
+		s.blocks[name] = map[string][]byte{}
+	}
+	s.blocks[name][r.URL.Query().Get("blockid")] = contents
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *fakeAzureServer) putBlockList(w http.ResponseWriter, r *http.Request, name string) {
+	var blockList azureBlockList
+	if err := xml.NewDecoder(r.Body).Decode(&blockList); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var contents []byte
+	for _, id := range blockList.Latest {
+		block, ok := s.blocks[name][id]
+		if !ok {
+			http.Error(w, "InvalidBlockList", http.StatusBadRequest)
+			return
+		}
+		contents = append(contents, block...)
+	}
+	delete(s.blocks, name)
+	s.files[name] = contents
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *fakeAzureServer) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("restype") != "container" || query.Get("comp") != "list" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+
+	var names []string
+	for name := range s.files {
+		if strings.HasPrefix(name, query.Get("prefix")) && name > query.Get("marker") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var result azureListBlobsResult
+	if len(names) > s.pageSize {
+		names = names[:s.pageSize]
+		result.NextMarker = names[len(names)-1]
+	}
+	for _, name := range names {
+		result.Blobs = append(result.Blobs, struct {
+			Name string `xml:"Name"`
+		}{name})
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"EnumerationResults"`
+		azureListBlobsResult
+	}{azureListBlobsResult: result})
+}
+
+func setEnv(t *testing.T, values map[string]string) func() {
+	old := map[string]*string{}
+	for k, v := range values {
+		if current, ok := os.LookupEnv(k); ok {
+			old[k] = &current
+		} else {
+			old[k] = nil
+		}
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k, v := range old {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+}
+
+func TestAzureArchiveBackend(t *testing.T) {
+	key, err := base64.StdEncoding.DecodeString(azuriteKey)
+	require.NoError(t, err)
+	fake := &fakeAzureServer{
+		t:         t,
+		account:   azuriteAccount,
+		key:       key,
+		container: "archives",
+		pageSize:  3,
+		files:     map[string][]byte{},
+		blocks:    map[string]map[string][]byte{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	defer setEnv(t, map[string]string{
+		azureStorageConnectionStringEnv: "DefaultEndpointsProtocol=http;" +
+			"AccountName=" + azuriteAccount + ";" +
+			"AccountKey=" + azuriteKey + ";" +
+			"BlobEndpoint=" + server.URL + "/" + azuriteAccount + ";",
+	})()
+
+	archive, err := Connect("azure://archives/testnet/core", ConnectOptions{})
+	require.NoError(t, err)
+	require.IsType(t, &AzureArchiveBackend{}, archive.backend)
+	testBackendOperations(t, archive.backend, "testnet/core")
+
+	fake.lock.Lock()
+	assert.Equal(t, []byte("history"), fake.files["testnet/core/history/00/00/00/history-0000003f.json"])
+	fake.lock.Unlock()
+
+	// Files larger than a block are uploaded in blocks.
+	backend := archive.backend.(*AzureArchiveBackend)
+	backend.blockSize = 4
+	for _, contents := range []string{"0123456789", "01234567", "0123"} {
+		require.NoError(t, backend.PutFile("bucket.xdr.gz", ioutil.NopCloser(strings.NewReader(contents))))
+		fake.lock.Lock()
+		assert.Equal(t, []byte(contents), fake.files["testnet/core/bucket.xdr.gz"])
+		assert.Empty(t, fake.blocks)
+		fake.lock.Unlock()
+	}
+
+	// Requests signed with a wrong key are rejected.
+	defer setEnv(t, map[string]string{
+		azureStorageConnectionStringEnv: "",
+		azureStorageAccountEnv:          azuriteAccount,
+		azureStorageKeyEnv:              base64.StdEncoding.EncodeToString([]byte("wrong key")),
+	})()
+	archive, err = Connect("azure://archives/testnet/core", ConnectOptions{
+		AzureEndpoint: server.URL + "/" + azuriteAccount,
+	})
+	require.NoError(t, err)
+	_, err = archive.backend.Exists(rootHASPath)
+	assert.Error(t, err)
+}
+
+func TestParseAzureConnectionString(t *testing.T) {
+	creds, err := parseAzureConnectionString(
+		"DefaultEndpointsProtocol=https;AccountName=name;AccountKey=a2V5;EndpointSuffix=core.windows.net",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, azureCredentials{
+		account:  "name",
+		key:      "a2V5",
+		endpoint: "https://name.blob.core.windows.net",
+	}, creds)
+
+	creds, err = parseAzureConnectionString(
+		"BlobEndpoint=https://name.blob.core.windows.net/;SharedAccessSignature=sv=2019-12-12&sig=abc%3D",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, azureCredentials{
+		sasToken: "sv=2019-12-12&sig=abc%3D",
+		endpoint: "https://name.blob.core.windows.net/",
+	}, creds)
+
+	creds, err = parseAzureConnectionString("UseDevelopmentStorage=true")
+	require.NoError(t, err)
+	assert.Equal(t, azuriteEndpoint, creds.endpoint)
+
+	_, err = parseAzureConnectionString("AccountName")
+	assert.Error(t, err)
+}
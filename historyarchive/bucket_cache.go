@@ -0,0 +1,343 @@
+package historyarchive
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	bucketCacheTempPattern = "bucket-*.tmp"
+	// bucketCacheStaleTempAge is the age of temporary files after which they
+	// are considered leftovers of interrupted downloads. Younger files can
+	// belong to other processes sharing the cache directory.
+	bucketCacheStaleTempAge = time.Hour
+)
+
+var bucketCacheFileRegexp = regexp.MustCompile(`^bucket-([0-9a-f]{64})\.xdr\.gz$`)
+
+type bucketCacheEntry struct {
+	size     int64
+	lastUsed time.Time
+	// verified is true if the hash of the cached file was checked by this
+	// process. Files found on disk are verified when first opened.
+	verified bool
+}
+
+// BucketCache is a content-addressed, persistent cache of buckets stored in a
+// local directory (using the same layout as history archives). Buckets are
+// verified (the hash of the uncompressed contents must match the bucket hash)
+// before being added to the cache so cached files can be shared by many
+// archives and processes, ex. consecutive state rebuilds in Horizon and
+// stellar-archivist mirroring the same archive.
+//
+// Many processes can share a directory: buckets added by other processes are
+// found on disk when requested. Files found on disk (including files left by
+// previous runs) are verified when first opened, corrupt files are removed
+// and downloaded again.
+//
+// When the total size of the cached files exceeds the configured maximum size,
+// the least recently used buckets are removed. Access times are persisted in
+// file modification times so they survive restarts.
+//
+// BucketCache is thread-safe.
+type BucketCache struct {
+	dir     string
+	maxSize int64
+
+	lock    sync.Mutex
+	entries map[Hash]*bucketCacheEntry
+	size    int64
+}
+
+// NewBucketCache returns a BucketCache storing buckets in the given directory
+// (created if it does not exist). Buckets already present in the directory are
+// added to the cache. maxSize is the maximum total size (in bytes) of the
+// cached files, 0 means no limit.
+func NewBucketCache(dir string, maxSize int64) (*BucketCache, error) {
+	if maxSize < 0 {
+		return nil, errors.New("maxSize must be non-negative")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "error creating bucket cache directory")
+	}
+
+	c := &BucketCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: map[Hash]*bucketCacheEntry{},
+	}
+
+	err := filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(info.Name(), ".tmp") {
+			if time.Since(info.ModTime()) > bucketCacheStaleTempAge {
+				// Leftover of an interrupted download.
+				return os.Remove(pth)
+			}
+			return nil
+		}
+		matches := bucketCacheFileRegexp.FindStringSubmatch(info.Name())
+		if matches == nil {
+			return nil
+		}
+		hash, err := DecodeHash(matches[1])
+		if err != nil {
+			return nil
+		}
+		c.entries[hash] = &bucketCacheEntry{size: info.Size(), lastUsed: info.ModTime()}
+		c.size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error scanning bucket cache directory")
+	}
+
+	c.lock.Lock()
+	c.evict(Hash{})
+	c.lock.Unlock()
+	return c, nil
+}
+
+func (c *BucketCache) path(hash Hash) string {
+	return filepath.Join(c.dir, filepath.FromSlash(BucketPath(hash)))
+}
+
+// Size returns the total size (in bytes) of the cached buckets.
+func (c *BucketCache) Size() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.size
+}
+
+// Contains returns true if the bucket with the given hash is cached.
+func (c *BucketCache) Contains(hash Hash) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lookup(hash) != nil
+}
+
+// lookup returns the cache entry of the given bucket, checking the directory
+// for buckets added by other processes. Must be called with the lock held.
+func (c *BucketCache) lookup(hash Hash) *bucketCacheEntry {
+	if entry, ok := c.entries[hash]; ok {
+		return entry
+	}
+	info, err := os.Stat(c.path(hash))
+	if err != nil || info.IsDir() {
+		return nil
+	}
+	entry := &bucketCacheEntry{size: info.Size(), lastUsed: info.ModTime()}
+	c.entries[hash] = entry
+	c.size += entry.size
+	return entry
+}
+
+// GetFile returns a reader of the gzipped bucket with the given hash. If the
+// bucket is not cached, it's downloaded using fetch, verified and added to the
+// cache first.
+func (c *BucketCache) GetFile(hash Hash, fetch func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if file, ok := c.open(hash); ok {
+		return file, nil
+	}
+
+	rdr, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	err = c.Add(hash, rdr)
+	closeErr := rdr.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, errors.Wrap(closeErr, "error closing bucket reader")
+	}
+
+	file, ok := c.open(hash)
+	if !ok {
+		return nil, errors.Errorf("bucket %s evicted from cache", hash)
+	}
+	return file, nil
+}
+
+// open returns the cached file of the given bucket and marks it as recently
+// used. The second returned value is false when the bucket is not cached or
+// the cached file is corrupt, in which case it's removed from the cache.
+func (c *BucketCache) open(hash Hash) (io.ReadCloser, bool) {
+	c.lock.Lock()
+	entry := c.lookup(hash)
+	if entry == nil {
+		c.lock.Unlock()
+		return nil, false
+	}
+	verified := entry.verified
+	c.lock.Unlock()
+
+	pth := c.path(hash)
+	file, err := os.Open(pth)
+	if err != nil {
+		// The file was removed from outside, forget it.
+		c.lock.Lock()
+		c.remove(hash, entry)
+		c.lock.Unlock()
+		return nil, false
+	}
+
+	if !verified {
+		// Verified without holding the lock, buckets can be large.
+		err = verifyGzippedBucket(file, hash)
+		if err == nil {
+			_, err = file.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			file.Close()
+			log.Printf("Removing invalid bucket %s from cache: %v", hash, err)
+			c.lock.Lock()
+			c.remove(hash, entry)
+			c.lock.Unlock()
+			return nil, false
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry.verified = true
+	entry.lastUsed = time.Now()
+	// Persist the access time, errors are not critical.
+	os.Chtimes(pth, entry.lastUsed, entry.lastUsed)
+	return file, true
+}
+
+// Remove removes the bucket with the given hash from the cache, ex. when
+// reading the cached file fails hash verification.
+func (c *BucketCache) Remove(hash Hash) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if entry := c.lookup(hash); entry != nil {
+		c.remove(hash, entry)
+	}
+}
+
+// remove deletes the file of the given cache entry and forgets the entry,
+// unless the bucket was added again in the meantime. Must be called with the
+// lock held.
+func (c *BucketCache) remove(hash Hash, entry *bucketCacheEntry) {
+	if c.entries[hash] != entry {
+		return
+	}
+	if err := os.Remove(c.path(hash)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Error removing bucket %s from cache: %v", hash, err)
+	}
+	c.size -= entry.size
+	delete(c.entries, hash)
+}
+
+// Add reads a gzipped bucket from the given reader and adds it to the cache
+// if the hash of the uncompressed contents matches the given hash.
+func (c *BucketCache) Add(hash Hash, rdr io.Reader) error {
+	tmp, err := ioutil.TempFile(c.dir, bucketCacheTempPattern)
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary file")
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	size, err := io.Copy(tmp, rdr)
+	if err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "error downloading bucket %s", hash)
+	}
+	if err = verifyGzippedBucket(tmp, hash); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "error closing temporary file")
+	}
+
+	pth := c.path(hash)
+	if err = os.MkdirAll(filepath.Dir(pth), 0755); err != nil {
+		return errors.Wrap(err, "error creating bucket directory")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err = os.Rename(tmpPath, pth); err != nil {
+		return errors.Wrap(err, "error moving bucket to cache")
+	}
+	if old, ok := c.entries[hash]; ok {
+		// Downloaded concurrently by another go routine.
+		c.size -= old.size
+	}
+	c.entries[hash] = &bucketCacheEntry{size: size, lastUsed: time.Now(), verified: true}
+	c.size += size
+	c.evict(hash)
+	return nil
+}
+
+// verifyGzippedBucket checks if the hash of the uncompressed contents of the
+// given file matches the bucket hash.
+func verifyGzippedBucket(file *os.File, hash Hash) error {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "error seeking temporary file")
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrapf(err, "error opening gzip stream of bucket %s", hash)
+	}
+	defer gzipReader.Close()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, gzipReader); err != nil {
+		return errors.Wrapf(err, "error reading bucket %s", hash)
+	}
+	return checkBucketHash(hasher, hash)
+}
+
+// evict removes the least recently used buckets (except keep) until the total
+// size does not exceed the maximum size. Must be called with the lock held.
+func (c *BucketCache) evict(keep Hash) {
+	if c.maxSize == 0 || c.size <= c.maxSize {
+		return
+	}
+
+	hashes := make([]Hash, 0, len(c.entries))
+	for hash := range c.entries {
+		if hash != keep {
+			hashes = append(hashes, hash)
+		}
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return c.entries[hashes[i]].lastUsed.Before(c.entries[hashes[j]].lastUsed)
+	})
+
+	for _, hash := range hashes {
+		if c.size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(c.path(hash)); err != nil && !os.IsNotExist(err) {
+			log.Printf("Error removing bucket %s from cache: %v", hash, err)
+			continue
+		}
+		c.size -= c.entries[hash].size
+		delete(c.entries, hash)
+	}
+}
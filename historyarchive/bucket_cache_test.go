@@ -0,0 +1,247 @@
+package historyarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomGzippedBucket(t *testing.T) (Hash, []byte) {
+	contents := make([]byte, 1024)
+	_, err := rand.Read(contents)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err = writer.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return sha256.Sum256(contents), buf.Bytes()
+}
+
+func fetchBytes(fetches *int, contents []byte) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		*fetches++
+		return ioutil.NopCloser(bytes.NewReader(contents)), nil
+	}
+}
+
+func TestBucketCacheGetFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+
+	hash, contents := randomGzippedBucket(t)
+	fetches := 0
+	for i := 0; i < 2; i++ {
+		rdr, err := cache.GetFile(hash, fetchBytes(&fetches, contents))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadAll(rdr)
+		require.NoError(t, err)
+		require.NoError(t, rdr.Close())
+		assert.Equal(t, contents, actual)
+	}
+	assert.Equal(t, 1, fetches)
+	assert.True(t, cache.Contains(hash))
+	assert.Equal(t, int64(len(contents)), cache.Size())
+
+	// Buckets are found by other instances sharing the directory.
+	otherCache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+	assert.True(t, otherCache.Contains(hash))
+	rdr, err := otherCache.GetFile(hash, fetchBytes(&fetches, contents))
+	require.NoError(t, err)
+	require.NoError(t, rdr.Close())
+	assert.Equal(t, 1, fetches)
+
+	// Temporary files are removed.
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestBucketCacheInvalidHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+
+	hash, _ := randomGzippedBucket(t)
+	_, contents := randomGzippedBucket(t)
+	fetches := 0
+	_, err = cache.GetFile(hash, fetchBytes(&fetches, contents))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Bucket hash mismatch")
+	assert.False(t, cache.Contains(hash))
+	assert.Equal(t, int64(0), cache.Size())
+
+	err = cache.Add(hash, bytes.NewReader([]byte("not gzipped")))
+	assert.Error(t, err)
+	assert.False(t, cache.Contains(hash))
+}
+
+func TestBucketCacheEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		hashes   []Hash
+		contents [][]byte
+	)
+	for i := 0; i < 3; i++ {
+		hash, bucket := randomGzippedBucket(t)
+		hashes = append(hashes, hash)
+		contents = append(contents, bucket)
+	}
+	bucketSize := int64(len(contents[0]))
+
+	cache, err := NewBucketCache(dir, 2*bucketSize+bucketSize/2)
+	require.NoError(t, err)
+
+	fetches := 0
+	for i := range hashes {
+		rdr, err := cache.GetFile(hashes[i], fetchBytes(&fetches, contents[i]))
+		require.NoError(t, err)
+		require.NoError(t, rdr.Close())
+		// Make sure access times are different.
+		time.Sleep(10 * time.Millisecond)
+		if i == 1 {
+			// Bucket 0 becomes more recently used than bucket 1.
+			rdr, err = cache.GetFile(hashes[0], fetchBytes(&fetches, contents[0]))
+			require.NoError(t, err)
+			require.NoError(t, rdr.Close())
+		}
+	}
+
+	assert.Equal(t, 3, fetches)
+	assert.True(t, cache.Contains(hashes[0]))
+	assert.False(t, cache.Contains(hashes[1]))
+	assert.True(t, cache.Contains(hashes[2]))
+	assert.True(t, cache.Size() <= 2*bucketSize+bucketSize/2)
+
+	// A smaller cache evicts the least recently used bucket when opened.
+	cache, err = NewBucketCache(dir, bucketSize)
+	require.NoError(t, err)
+	assert.False(t, cache.Contains(hashes[0]))
+	assert.True(t, cache.Contains(hashes[2]))
+	assert.Equal(t, bucketSize, cache.Size())
+}
+
+func TestArchiveBucketCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+
+	src := MustConnect("mock://test", ConnectOptions{CheckpointFrequency: 64, BucketCache: cache})
+	hash, contents := randomGzippedBucket(t)
+	require.NoError(t, src.backend.PutFile(BucketPath(hash), ioutil.NopCloser(bytes.NewReader(contents))))
+
+	stream, err := src.GetXdrStreamForHash(hash)
+	require.NoError(t, err)
+	stream.SetExpectedHash(hash)
+	require.NoError(t, stream.Close())
+	assert.True(t, cache.Contains(hash))
+
+	// The bucket is read from the cache when removed from the archive.
+	delete(src.backend.(*MockArchiveBackend).files, BucketPath(hash))
+	stream, err = src.GetXdrStreamForHash(hash)
+	require.NoError(t, err)
+	stream.SetExpectedHash(hash)
+	require.NoError(t, stream.Close())
+
+	// Mirror copies the bucket from the cache too.
+	dst := GetTestMockArchive()
+	opts := &CommandOptions{}
+	require.NoError(t, copyBucket(src, dst, hash, opts))
+	exists, err := dst.BucketExists(hash)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestBucketCacheCorruptFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A truncated file left in the directory, ex. by a crash.
+	hash, contents := randomGzippedBucket(t)
+	pth := filepath.Join(dir, filepath.FromSlash(BucketPath(hash)))
+	require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+	require.NoError(t, ioutil.WriteFile(pth, contents[:len(contents)/2], 0644))
+
+	cache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+	assert.True(t, cache.Contains(hash))
+
+	// The corrupt file is not served, the bucket is downloaded again.
+	fetches := 0
+	rdr, err := cache.GetFile(hash, fetchBytes(&fetches, contents))
+	require.NoError(t, err)
+	actual, err := ioutil.ReadAll(rdr)
+	require.NoError(t, err)
+	require.NoError(t, rdr.Close())
+	assert.Equal(t, contents, actual)
+	assert.Equal(t, 1, fetches)
+	assert.Equal(t, int64(len(contents)), cache.Size())
+
+	cache.Remove(hash)
+	assert.False(t, cache.Contains(hash))
+	assert.Equal(t, int64(0), cache.Size())
+	_, err = os.Stat(pth)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRetryingArchivePoolBucketCacheHashMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bucket-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := NewBucketCache(dir, 0)
+	require.NoError(t, err)
+
+	hash, contents := randomGzippedBucket(t)
+	var archives []ArchiveInterface
+	for i := 0; i < 2; i++ {
+		archive := MustConnect("mock://test", ConnectOptions{CheckpointFrequency: 64, BucketCache: cache})
+		require.NoError(t, archive.backend.PutFile(BucketPath(hash), ioutil.NopCloser(bytes.NewReader(contents))))
+		archives = append(archives, archive)
+	}
+	test := newArchivePoolTest(t, ArchivePoolOptions{}, archives...)
+
+	// A mismatch of a stream read from the cache removes the cached file but
+	// doesn't count against the archive.
+	stream, err := test.pool.GetXdrStreamForHash(hash)
+	require.NoError(t, err)
+	stream.SetExpectedHash(Hash{1})
+	assert.Error(t, stream.Close())
+	assert.False(t, cache.Contains(hash))
+	for _, stats := range test.pool.Stats() {
+		assert.Equal(t, uint64(0), stats.HashMismatches)
+		assert.Equal(t, uint64(0), stats.Quarantines)
+	}
+
+	stream, err = test.pool.GetXdrStreamForHash(hash)
+	require.NoError(t, err)
+	stream.SetExpectedHash(hash)
+	require.NoError(t, stream.Close())
+	assert.True(t, cache.Contains(hash))
+}
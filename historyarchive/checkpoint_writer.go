@@ -0,0 +1,245 @@
+// Copyright 2021 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"log"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// CheckpointWriterServer is the value of the server field of HAS files
+// written by CheckpointWriter.
+const CheckpointWriterServer = "stellar/go historyarchive.CheckpointWriter"
+
+// CheckpointWriterConfig contains the parameters used to create a
+// CheckpointWriter.
+type CheckpointWriterConfig struct {
+	// NetworkPassphrase is written to HAS files.
+	NetworkPassphrase string
+	// Overwrite enables overwriting checkpoint files which already exist in
+	// the archive. The root HAS is only overwritten by HAS files of newer
+	// checkpoints.
+	Overwrite bool
+	// SetBuckets (optional) sets the bucket list (CurrentBuckets) of the HAS
+	// of the given checkpoint. Referenced buckets must be published to the
+	// archive separately. When nil, HAS files reference empty buckets only,
+	// which is enough to replay ledgers from the archive (ex. using
+	// ledgerbackend.HistoryArchiveBackend) but not to build ledger state.
+	SetBuckets func(has *HistoryArchiveState) error
+}
+
+// CheckpointWriter publishes history archive checkpoint files (ledger,
+// transactions, results and scp categories) and HAS files built from
+// xdr.LedgerCloseMeta objects, so that archives of standalone networks can be
+// created without running stellar-core's publish machinery.
+//
+// Ledgers must be written in order, without gaps. Files of a checkpoint are
+// published when its last ledger is written, ledgers after the last complete
+// checkpoint are not published. If the first written ledger is not the first
+// ledger of a checkpoint, files of the first checkpoint contain only ledgers
+// starting from it.
+type CheckpointWriter struct {
+	archive *Archive
+	config  CheckpointWriterConfig
+	opts    *CommandOptions
+
+	headers      []xdr.LedgerHeaderHistoryEntry
+	transactions []xdr.TransactionHistoryEntry
+	results      []xdr.TransactionHistoryResultEntry
+	scp          []xdr.ScpHistoryEntry
+
+	// next is the sequence of the next ledger expected by Write.
+	next     uint32
+	prevHash xdr.Hash
+}
+
+// NewCheckpointWriter returns a new CheckpointWriter publishing files to the
+// given archive.
+func NewCheckpointWriter(archive *Archive, config CheckpointWriterConfig) *CheckpointWriter {
+	return &CheckpointWriter{
+		archive: archive,
+		config:  config,
+		opts:    &CommandOptions{Force: config.Overwrite},
+	}
+}
+
+// Write adds a ledger to the current checkpoint and publishes the checkpoint
+// files if it's the last ledger of the checkpoint.
+func (w *CheckpointWriter) Write(ledger xdr.LedgerCloseMeta) error {
+	v0, ok := ledger.GetV0()
+	if !ok {
+		return errors.Errorf("unsupported LedgerCloseMeta version %d", ledger.V)
+	}
+	header := v0.LedgerHeader
+	seq := uint32(header.Header.LedgerSeq)
+
+	if w.next != 0 {
+		if seq != w.next {
+			return errors.Errorf("unexpected ledger sequence (expected=%d actual=%d)", w.next, seq)
+		}
+		if header.Header.PreviousLedgerHash != w.prevHash {
+			return errors.Errorf("previous ledger hash of ledger %d does not match hash of ledger %d", seq, seq-1)
+		}
+	}
+	hash, err := HashXdr(&header.Header)
+	if err != nil {
+		return errors.Wrapf(err, "error hashing header of ledger %d", seq)
+	}
+	if xdr.Hash(hash) != header.Hash {
+		return errors.Errorf("hash of ledger %d does not match its header", seq)
+	}
+
+	w.headers = append(w.headers, header)
+	if len(v0.TxSet.Txs) > 0 {
+		w.transactions = append(w.transactions, xdr.TransactionHistoryEntry{
+			LedgerSeq: xdr.Uint32(seq),
+			TxSet:     v0.TxSet,
+		})
+		results := make([]xdr.TransactionResultPair, 0, len(v0.TxProcessing))
+		for _, processing := range v0.TxProcessing {
+			results = append(results, processing.Result)
+		}
+		w.results = append(w.results, xdr.TransactionHistoryResultEntry{
+			LedgerSeq:   xdr.Uint32(seq),
+			TxResultSet: xdr.TransactionResultSet{Results: results},
+		})
+	}
+	w.scp = append(w.scp, v0.ScpInfo...)
+
+	w.next = seq + 1
+	w.prevHash = header.Hash
+
+	if w.archive.checkpointManager.IsCheckpoint(seq) {
+		return w.publish(seq)
+	}
+	return nil
+}
+
+// publish writes files of the checkpoint ending at the given ledger.
+func (w *CheckpointWriter) publish(checkpoint uint32) error {
+	err := w.putXdrFile(CategoryCheckpointPath("ledger", checkpoint), len(w.headers), func(i int) interface{} {
+		return w.headers[i]
+	})
+	if err != nil {
+		return err
+	}
+	err = w.putXdrFile(CategoryCheckpointPath("transactions", checkpoint), len(w.transactions), func(i int) interface{} {
+		return w.transactions[i]
+	})
+	if err != nil {
+		return err
+	}
+	err = w.putXdrFile(CategoryCheckpointPath("results", checkpoint), len(w.results), func(i int) interface{} {
+		return w.results[i]
+	})
+	if err != nil {
+		return err
+	}
+	if len(w.scp) > 0 {
+		err = w.putXdrFile(CategoryCheckpointPath("scp", checkpoint), len(w.scp), func(i int) interface{} {
+			return w.scp[i]
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	has, err := w.checkpointHAS(checkpoint)
+	if err != nil {
+		return err
+	}
+	// HAS files are published last so that readers never see a checkpoint
+	// with missing files.
+	if err = w.archive.PutCheckpointHAS(checkpoint, has, w.opts); err != nil {
+		return errors.Wrapf(err, "error writing HAS of checkpoint %d", checkpoint)
+	}
+	if err = w.putRootHAS(has); err != nil {
+		return err
+	}
+
+	w.headers = nil
+	w.transactions = nil
+	w.results = nil
+	w.scp = nil
+	return nil
+}
+
+// putRootHAS writes the root HAS unless the archive already contains a newer
+// checkpoint, which happens when older ledgers are written again (ex. to
+// repair checkpoints) after newer ones.
+func (w *CheckpointWriter) putRootHAS(has HistoryArchiveState) error {
+	exists, err := w.archive.backend.Exists(rootHASPath)
+	if err != nil {
+		return errors.Wrap(err, "error checking if root HAS exists")
+	}
+	if exists {
+		root, err := w.archive.GetRootHAS()
+		if err != nil {
+			return errors.Wrap(err, "error reading root HAS")
+		}
+		if root.CurrentLedger >= has.CurrentLedger {
+			log.Printf("skipping root HAS update, archive is at checkpoint %d", root.CurrentLedger)
+			return nil
+		}
+	}
+	if err = w.archive.PutRootHAS(has, w.opts); err != nil {
+		return errors.Wrap(err, "error writing root HAS")
+	}
+	return nil
+}
+
+func (w *CheckpointWriter) checkpointHAS(checkpoint uint32) (HistoryArchiveState, error) {
+	has := HistoryArchiveState{
+		Version:           1,
+		Server:            CheckpointWriterServer,
+		CurrentLedger:     checkpoint,
+		NetworkPassphrase: w.config.NetworkPassphrase,
+	}
+	emptyBucket := Hash{}.String()
+	for i := range has.CurrentBuckets {
+		has.CurrentBuckets[i].Curr = emptyBucket
+		has.CurrentBuckets[i].Snap = emptyBucket
+	}
+	if w.config.SetBuckets != nil {
+		if err := w.config.SetBuckets(&has); err != nil {
+			return has, errors.Wrapf(err, "error setting buckets of checkpoint %d", checkpoint)
+		}
+	}
+	return has, nil
+}
+
+// putXdrFile writes count gzipped, framed XDR objects returned by get to the
+// given path.
+func (w *CheckpointWriter) putXdrFile(pth string, count int, get func(i int) interface{}) error {
+	exists, err := w.archive.backend.Exists(pth)
+	if err != nil {
+		return errors.Wrapf(err, "error checking if %s exists", pth)
+	}
+	if exists && !w.opts.Force {
+		log.Printf("skipping existing " + pth)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	for i := 0; i < count; i++ {
+		if err = xdr.MarshalFramed(gzipWriter, get(i)); err != nil {
+			return errors.Wrapf(err, "error encoding %s", pth)
+		}
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return errors.Wrapf(err, "error compressing %s", pth)
+	}
+
+	if err = w.archive.backend.PutFile(pth, ioutil.NopCloser(&buf)); err != nil {
+		return errors.Wrapf(err, "error writing %s", pth)
+	}
+	return nil
+}
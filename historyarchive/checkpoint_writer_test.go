@@ -0,0 +1,172 @@
+package historyarchive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+// testLedgerChain returns ledgers with valid hashes, previous ledger hashes,
+// transaction set hashes and result set hashes. Every 10th ledger contains a
+// transaction.
+func testLedgerChain(t *testing.T, from, to uint32) []xdr.LedgerCloseMeta {
+	var (
+		ledgers  []xdr.LedgerCloseMeta
+		prevHash xdr.Hash
+	)
+	for seq := from; seq <= to; seq++ {
+		meta := xdr.LedgerCloseMetaV0{
+			TxSet: xdr.TransactionSet{PreviousLedgerHash: prevHash},
+		}
+		txSetHash := HashEmptyTxSet(Hash(prevHash))
+		resultSetHash := EmptyXdrArrayHash()
+		if seq%10 == 0 {
+			source := xdr.MustAddress("GAHK7EEG2WWHVKDNT4CEQFZGKF2LGDSW2IVM4S5DP42RBW3K6BTODB4A")
+			meta.TxSet.Txs = []xdr.TransactionEnvelope{{
+				Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+				V1: &xdr.TransactionV1Envelope{
+					Tx: xdr.Transaction{
+						SourceAccount: source.ToMuxedAccount(),
+						Fee:           xdr.Uint32(seq),
+						SeqNum:        xdr.SequenceNumber(seq),
+						Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+					},
+				},
+			}}
+			meta.TxProcessing = []xdr.TransactionResultMeta{{
+				Result: xdr.TransactionResultPair{
+					TransactionHash: xdr.Hash{byte(seq)},
+					Result: xdr.TransactionResult{
+						FeeCharged: xdr.Int64(seq),
+						Result: xdr.TransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxSuccess,
+							Results: &[]xdr.OperationResult{},
+						},
+					},
+				},
+			}}
+
+			var err error
+			txSetHash, err = HashTxSet(&meta.TxSet)
+			require.NoError(t, err)
+			resultSetHash, err = HashXdr(&xdr.TransactionResultSet{
+				Results: []xdr.TransactionResultPair{meta.TxProcessing[0].Result},
+			})
+			require.NoError(t, err)
+		}
+
+		header := xdr.LedgerHeader{
+			LedgerSeq:          xdr.Uint32(seq),
+			PreviousLedgerHash: prevHash,
+			ScpValue:           xdr.StellarValue{TxSetHash: xdr.Hash(txSetHash)},
+			TxSetResultHash:    xdr.Hash(resultSetHash),
+		}
+		hash, err := HashXdr(&header)
+		require.NoError(t, err)
+		meta.LedgerHeader = xdr.LedgerHeaderHistoryEntry{Hash: xdr.Hash(hash), Header: header}
+
+		ledgers = append(ledgers, xdr.LedgerCloseMeta{V0: &meta})
+		prevHash = xdr.Hash(hash)
+	}
+	return ledgers
+}
+
+func TestCheckpointWriter(t *testing.T) {
+	archive := GetTestMockArchive()
+	writer := NewCheckpointWriter(archive, CheckpointWriterConfig{
+		NetworkPassphrase: "test network",
+		SetBuckets: func(has *HistoryArchiveState) error {
+			has.CurrentBuckets[0].Curr = Hash{byte(has.CurrentLedger)}.String()
+			return nil
+		},
+	})
+
+	ledgers := testLedgerChain(t, 1, 130)
+	for _, ledger := range ledgers {
+		require.NoError(t, writer.Write(ledger))
+	}
+
+	root, err := archive.GetRootHAS()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(127), root.CurrentLedger)
+	assert.Equal(t, "test network", root.NetworkPassphrase)
+	assert.Equal(t, CheckpointWriterServer, root.Server)
+	assert.Equal(t, Hash{127}.String(), root.CurrentBuckets[0].Curr)
+	assert.Equal(t, Hash{}.String(), root.CurrentBuckets[0].Snap)
+
+	has, err := archive.GetCheckpointHAS(63)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(63), has.CurrentLedger)
+	assert.Equal(t, Hash{63}.String(), has.CurrentBuckets[0].Curr)
+
+	published, err := archive.GetLedgers(1, 127)
+	require.NoError(t, err)
+	require.Len(t, published, 127)
+	for _, ledger := range ledgers[:127] {
+		v0 := ledger.MustV0()
+		seq := uint32(v0.LedgerHeader.Header.LedgerSeq)
+		require.Contains(t, published, seq)
+		assert.Equal(t, v0.LedgerHeader, published[seq].Header)
+		if len(v0.TxSet.Txs) > 0 {
+			assert.Equal(t, v0.TxSet, published[seq].Transaction.TxSet)
+			expected, err := xdr.MarshalBase64(v0.TxProcessing[0].Result)
+			require.NoError(t, err)
+			actual, err := xdr.MarshalBase64(published[seq].TransactionResult.TxResultSet.Results[0])
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		} else {
+			assert.Equal(t, xdr.Uint32(0), published[seq].Transaction.LedgerSeq)
+		}
+	}
+
+	// The incomplete checkpoint is not published.
+	exists, err := archive.CategoryCheckpointExists("ledger", 191)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCheckpointWriterInvalidLedgers(t *testing.T) {
+	ledgers := testLedgerChain(t, 64, 70)
+
+	writer := NewCheckpointWriter(GetTestMockArchive(), CheckpointWriterConfig{})
+	require.NoError(t, writer.Write(ledgers[0]))
+	assert.EqualError(t, writer.Write(ledgers[2]), "unexpected ledger sequence (expected=65 actual=66)")
+
+	writer = NewCheckpointWriter(GetTestMockArchive(), CheckpointWriterConfig{})
+	require.NoError(t, writer.Write(ledgers[0]))
+	ledgers[1].V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{1}
+	assert.EqualError(t, writer.Write(ledgers[1]), "previous ledger hash of ledger 65 does not match hash of ledger 64")
+
+	writer = NewCheckpointWriter(GetTestMockArchive(), CheckpointWriterConfig{})
+	ledgers[3].V0.LedgerHeader.Hash = xdr.Hash{1}
+	assert.EqualError(t, writer.Write(ledgers[3]), "hash of ledger 67 does not match its header")
+}
+
+func TestCheckpointWriterKeepsNewerRootHAS(t *testing.T) {
+	archive := GetTestMockArchive()
+	ledgers := testLedgerChain(t, 1, 127)
+
+	writer := NewCheckpointWriter(archive, CheckpointWriterConfig{})
+	for _, ledger := range ledgers[63:] {
+		require.NoError(t, writer.Write(ledger))
+	}
+	root, err := archive.GetRootHAS()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(127), root.CurrentLedger)
+
+	// Writing an older checkpoint afterwards publishes its files but doesn't
+	// move the root HAS backwards.
+	writer = NewCheckpointWriter(archive, CheckpointWriterConfig{})
+	for _, ledger := range ledgers[:63] {
+		require.NoError(t, writer.Write(ledger))
+	}
+	has, err := archive.GetCheckpointHAS(63)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(63), has.CurrentLedger)
+	root, err = archive.GetRootHAS()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(127), root.CurrentLedger)
+}
@@ -5,6 +5,7 @@
 package historyarchive
 
 import (
+	"context"
 	"io"
 	"os"
 	"path"
@@ -19,6 +20,14 @@ func (b *FsArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
 	return os.Open(path.Join(b.prefix, pth))
 }
 
+// GetFileContext opens a file if the given context is not done.
+func (b *FsArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.GetFile(pth)
+}
+
 func (b *FsArchiveBackend) Exists(pth string) (bool, error) {
 	pth = path.Join(b.prefix, pth)
 	_, err := os.Stat(pth)
@@ -0,0 +1,149 @@
+// Copyright 2016 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// gcsEmulatorHostEnv is the environment variable used by Google Cloud Storage
+// client libraries to connect to a local emulator (ex. fake-gcs-server).
+const gcsEmulatorHostEnv = "STORAGE_EMULATOR_HOST"
+
+// GCSArchiveBackend is an ArchiveBackend storing files in a Google Cloud
+// Storage bucket. Credentials are found using Application Default Credentials
+// (ex. GOOGLE_APPLICATION_CREDENTIALS environment variable).
+type GCSArchiveBackend struct {
+	ctx    context.Context
+	svc    *storage.Service
+	bucket string
+	prefix string
+}
+
+func isGCSNotFound(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusNotFound
+}
+
+func (b *GCSArchiveBackend) key(pth string) string {
+	return path.Join(b.prefix, pth)
+}
+
+func (b *GCSArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
+	return b.GetFileContext(b.ctx, pth)
+}
+
+// GetFileContext downloads a file using the given context instead of the
+// context passed in ConnectOptions.
+func (b *GCSArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
+	resp, err := b.svc.Objects.Get(b.bucket, b.key(pth)).Context(ctx).Download()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (b *GCSArchiveBackend) Exists(pth string) (bool, error) {
+	_, err := b.svc.Objects.Get(b.bucket, b.key(pth)).Fields("name").Context(b.ctx).Do()
+	if isGCSNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *GCSArchiveBackend) Size(pth string) (int64, error) {
+	obj, err := b.svc.Objects.Get(b.bucket, b.key(pth)).Fields("size").Context(b.ctx).Do()
+	if isGCSNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return int64(obj.Size), nil
+}
+
+func (b *GCSArchiveBackend) PutFile(pth string, in io.ReadCloser) error {
+	defer in.Close()
+	_, err := b.svc.Objects.Insert(b.bucket, &storage.Object{Name: b.key(pth)}).
+		Media(in).
+		Fields("name").
+		Context(b.ctx).
+		Do()
+	return err
+}
+
+func (b *GCSArchiveBackend) ListFiles(pth string) (chan string, chan error) {
+	prefix := b.key(pth)
+	ch := make(chan string)
+	errs := make(chan error)
+
+	go func() {
+		err := b.svc.Objects.List(b.bucket).
+			Prefix(prefix).
+			Fields("nextPageToken", "items/name").
+			Pages(b.ctx, func(objects *storage.Objects) error {
+				for _, obj := range objects.Items {
+					ch <- obj.Name
+				}
+				return nil
+			})
+		if err != nil {
+			errs <- err
+		}
+		close(ch)
+		close(errs)
+	}()
+	return ch, errs
+}
+
+func (b *GCSArchiveBackend) CanListFiles() bool {
+	return true
+}
+
+func makeGCSBackend(bucket string, prefix string, opts ConnectOptions) (ArchiveBackend, error) {
+	endpoint := opts.GCSEndpoint
+	if endpoint == "" {
+		if host := os.Getenv(gcsEmulatorHostEnv); host != "" {
+			if !strings.Contains(host, "://") {
+				host = "http://" + host
+			}
+			endpoint = strings.TrimSuffix(host, "/") + "/storage/v1/"
+		}
+	}
+
+	var clientOpts []option.ClientOption
+	if endpoint != "" {
+		clientOpts = append(clientOpts, option.WithEndpoint(endpoint))
+	}
+	if opts.UnsignedRequests || (endpoint != "" && opts.GCSEndpoint == "") {
+		// Public buckets and emulators do not require credentials.
+		clientOpts = append(clientOpts, option.WithoutAuthentication())
+	}
+
+	svc, err := storage.NewService(opts.Context, clientOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating Google Cloud Storage client")
+	}
+
+	backend := GCSArchiveBackend{
+		ctx:    opts.Context,
+		svc:    svc,
+		bucket: bucket,
+		prefix: prefix,
+	}
+	return &backend, nil
+}
@@ -0,0 +1,250 @@
+package historyarchive
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackendOperations checks operations of a backend storing files under
+// the given prefix.
+func testBackendOperations(t *testing.T, backend ArchiveBackend, prefix string) {
+	files := map[string]string{
+		"history/00/00/00/history-0000003f.json": "history",
+		"ledger/00/00/00/ledger-0000003f.xdr.gz": "ledger",
+		"bucket/aa/bb/cc/bucket-aabbcc.xdr.gz":   "bucket",
+		".well-known/stellar-history.json":       "root",
+	}
+	for pth, contents := range files {
+		require.NoError(t, backend.PutFile(pth, ioutil.NopCloser(strings.NewReader(contents))))
+	}
+
+	for pth, contents := range files {
+		exists, err := backend.Exists(pth)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		size, err := backend.Size(pth)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(contents)), size)
+
+		rdr, err := backend.GetFile(pth)
+		require.NoError(t, err)
+		actual, err := ioutil.ReadAll(rdr)
+		require.NoError(t, err)
+		require.NoError(t, rdr.Close())
+		assert.Equal(t, contents, string(actual))
+	}
+
+	exists, err := backend.Exists("history/00/00/00/history-0000007f.json")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	size, err := backend.Size("history/00/00/00/history-0000007f.json")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+	_, err = backend.GetFile("history/00/00/00/history-0000007f.json")
+	assert.Error(t, err)
+
+	assert.True(t, backend.CanListFiles())
+	ch, errs := backend.ListFiles("")
+	var listed []string
+	for ch != nil || errs != nil {
+		select {
+		case pth, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			listed = append(listed, pth)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			require.NoError(t, err)
+		}
+	}
+	var expected []string
+	for pth := range files {
+		expected = append(expected, prefix+"/"+pth)
+	}
+	sort.Strings(expected)
+	sort.Strings(listed)
+	assert.Equal(t, expected, listed)
+}
+
+// fakeGCSServer implements a subset of Google Cloud Storage JSON API.
+type fakeGCSServer struct {
+	bucket   string
+	pageSize int
+
+	lock  sync.Mutex
+	files map[string][]byte
+}
+
+func (s *fakeGCSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pth := r.URL.EscapedPath()
+	objectsPath := "/storage/v1/b/" + s.bucket + "/o"
+	switch {
+	// Clients use the objects path for uploads when the endpoint is not the
+	// default one.
+	case r.Method == http.MethodPost && (pth == "/upload"+objectsPath || pth == objectsPath):
+		s.upload(w, r)
+	case r.Method == http.MethodGet && pth == objectsPath:
+		s.list(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(pth, objectsPath+"/"):
+		name, err := url.PathUnescape(strings.TrimPrefix(pth, objectsPath+"/"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		contents, ok := s.files[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404,"message":"Not Found"}}`))
+			return
+		}
+		if r.URL.Query().Get("alt") == "media" {
+			w.Write(contents)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"name": name,
+			"size": strconv.Itoa(len(contents)),
+		})
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (s *fakeGCSServer) upload(w http.ResponseWriter, r *http.Request) {
+	var (
+		name     string
+		contents []byte
+		err      error
+	)
+	switch r.URL.Query().Get("uploadType") {
+	case "media":
+		name = r.URL.Query().Get("name")
+		contents, err = ioutil.ReadAll(r.Body)
+	case "multipart":
+		var params map[string]string
+		_, params, err = mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			break
+		}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		var part *multipart.Part
+		if part, err = reader.NextPart(); err != nil {
+			break
+		}
+		var object struct {
+			Name string `json:"name"`
+		}
+		if err = json.NewDecoder(part).Decode(&object); err != nil {
+			break
+		}
+		name = object.Name
+		if part, err = reader.NextPart(); err != nil {
+			break
+		}
+		contents, err = ioutil.ReadAll(part)
+	default:
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.files[name] = contents
+	json.NewEncoder(w).Encode(map[string]string{"name": name})
+}
+
+func (s *fakeGCSServer) list(w http.ResponseWriter, r *http.Request) {
+	var names []string
+	for name := range s.files {
+		if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start := 0
+	if token := r.URL.Query().Get("pageToken"); token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := start + s.pageSize
+	response := map[string]interface{}{}
+	if end < len(names) {
+		response["nextPageToken"] = strconv.Itoa(end)
+	} else {
+		end = len(names)
+	}
+	var items []map[string]string
+	for _, name := range names[start:end] {
+		items = append(items, map[string]string{"name": name})
+	}
+	response["items"] = items
+	json.NewEncoder(w).Encode(response)
+}
+
+func TestGCSArchiveBackend(t *testing.T) {
+	fake := &fakeGCSServer{bucket: "archives", pageSize: 3, files: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	archive, err := Connect("gs://archives/testnet/core", ConnectOptions{
+		GCSEndpoint:      server.URL + "/storage/v1/",
+		UnsignedRequests: true,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &GCSArchiveBackend{}, archive.backend)
+	testBackendOperations(t, archive.backend, "testnet/core")
+
+	fake.lock.Lock()
+	assert.Equal(t, []byte("history"), fake.files["testnet/core/history/00/00/00/history-0000003f.json"])
+	fake.lock.Unlock()
+}
+
+func TestGCSArchiveBackendEmulatorHost(t *testing.T) {
+	fake := &fakeGCSServer{bucket: "archives", pageSize: 100, files: map[string][]byte{
+		"prefix/" + rootHASPath: bytes.Repeat([]byte{'a'}, 10),
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	emulatorHost, hadEmulatorHost := os.LookupEnv(gcsEmulatorHostEnv)
+	defer func() {
+		if hadEmulatorHost {
+			os.Setenv(gcsEmulatorHostEnv, emulatorHost)
+		} else {
+			os.Unsetenv(gcsEmulatorHostEnv)
+		}
+	}()
+	os.Setenv(gcsEmulatorHostEnv, strings.TrimPrefix(server.URL, "http://"))
+
+	// Credentials are not required by the emulator.
+	archive, err := Connect("gs://archives/prefix", ConnectOptions{})
+	require.NoError(t, err)
+	size, err := archive.backend.Size(rootHASPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+}
@@ -21,23 +21,44 @@ type HttpArchiveBackend struct {
 	base   url.URL
 }
 
+// httpResponseError is returned when an archive responds with an
+// unsuccessful HTTP status code.
+type httpResponseError struct {
+	statusCode int
+	status     string
+	url        string
+}
+
+func (e httpResponseError) Error() string {
+	return fmt.Sprintf("Bad HTTP response '%s' for GET '%s'", e.status, e.url)
+}
+
 func checkResp(r *http.Response) error {
 	if r.StatusCode >= 200 && r.StatusCode < 400 {
 		return nil
 	} else {
-		return fmt.Errorf("Bad HTTP response '%s' for GET '%s'",
-			r.Status, r.Request.URL.String())
+		return httpResponseError{
+			statusCode: r.StatusCode,
+			status:     r.Status,
+			url:        r.Request.URL.String(),
+		}
 	}
 }
 
 func (b *HttpArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
+	return b.GetFileContext(b.ctx, pth)
+}
+
+// GetFileContext downloads a file using the given context instead of the
+// context passed in ConnectOptions.
+func (b *HttpArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
 	var derived url.URL = b.base
 	derived.Path = path.Join(derived.Path, pth)
 	req, err := http.NewRequest("GET", derived.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(b.ctx)
+	req = req.WithContext(ctx)
 	resp, err := b.client.Do(req)
 	if err != nil {
 		if resp != nil && resp.Body != nil {
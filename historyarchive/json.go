@@ -5,6 +5,7 @@
 package historyarchive
 
 import (
+	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
@@ -16,6 +17,9 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+// DumpXdrAsJson writes the records of the given XDR files from a history
+// archive to stdout in the canonical JSON format of the xdr package (see
+// xdr.MarshalJSON), one indented JSON document per record.
 func DumpXdrAsJson(args []string) error {
 	var tmp interface{}
 	var rdr io.ReadCloser
@@ -58,7 +62,7 @@ func DumpXdrAsJson(args []string) error {
 				return fmt.Errorf("Error: unrecognized XDR file type %s", base)
 			}
 
-			if err = xr.ReadOne(&tmp); err != nil {
+			if err = xr.ReadOne(tmp); err != nil {
 				if err == io.EOF {
 					break
 				} else {
@@ -67,11 +71,17 @@ func DumpXdrAsJson(args []string) error {
 				}
 			}
 			n++
-			buf, err := json.MarshalIndent(tmp, "", "    ")
+			buf, err := xdr.MarshalJSON(tmp)
 			if err != nil {
+				return fmt.Errorf("Error converting XDR record %d of %s to JSON: %v",
+					n-1, arg, err)
+			}
+			var indented bytes.Buffer
+			if err = json.Indent(&indented, buf, "", "    "); err != nil {
 				return err
 			}
-			os.Stdout.Write(buf)
+			indented.WriteByte('\n')
+			os.Stdout.Write(indented.Bytes())
 		}
 		xr.Close()
 	}
@@ -70,8 +70,7 @@ func Mirror(src *Archive, dst *Archive, opts *CommandOptions) error {
 					}
 					bucketFetchMutex.Unlock()
 					if !alreadyFetching {
-						pth := BucketPath(bucket)
-						err = copyPath(src, dst, pth, opts)
+						err = copyBucket(src, dst, bucket, opts)
 						atomic.AddUint32(&errs, noteError(err))
 					}
 				}
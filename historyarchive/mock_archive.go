@@ -6,6 +6,7 @@ package historyarchive
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -42,11 +43,19 @@ func (b *MockArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
 	var buf []byte
 	buf, ok := b.files[pth]
 	if !ok {
-		return nil, errors.New("no such file: " + pth)
+		return nil, permanentError{errors.New("no such file: " + pth)}
 	}
 	return ioutil.NopCloser(bytes.NewReader(buf)), nil
 }
 
+// GetFileContext returns a file if the given context is not done.
+func (b *MockArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.GetFile(pth)
+}
+
 func (b *MockArchiveBackend) PutFile(pth string, in io.ReadCloser) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
@@ -1,6 +1,8 @@
 package historyarchive
 
 import (
+	"context"
+
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/mock"
 )
@@ -45,6 +47,11 @@ func (m *MockArchive) GetLedgers(start, end uint32) (map[uint32]*Ledger, error)
 	return a.Get(0).(map[uint32]*Ledger), a.Error(1)
 }
 
+func (m *MockArchive) GetLedgersContext(ctx context.Context, start, end uint32) (map[uint32]*Ledger, error) {
+	a := m.Called(ctx, start, end)
+	return a.Get(0).(map[uint32]*Ledger), a.Error(1)
+}
+
 func (m *MockArchive) GetRootHAS() (HistoryArchiveState, error) {
 	a := m.Called()
 	return a.Get(0).(HistoryArchiveState), a.Error(1)
@@ -55,6 +62,11 @@ func (m *MockArchive) GetCheckpointHAS(chk uint32) (HistoryArchiveState, error)
 	return a.Get(0).(HistoryArchiveState), a.Error(1)
 }
 
+func (m *MockArchive) GetCheckpointHASContext(ctx context.Context, chk uint32) (HistoryArchiveState, error) {
+	a := m.Called(ctx, chk)
+	return a.Get(0).(HistoryArchiveState), a.Error(1)
+}
+
 func (m *MockArchive) PutCheckpointHAS(chk uint32, has HistoryArchiveState, opts *CommandOptions) error {
 	a := m.Called(chk, has, opts)
 	return a.Error(0)
@@ -98,3 +110,13 @@ func (m *MockArchive) GetXdrStream(pth string) (*XdrStream, error) {
 	a := m.Called(pth)
 	return a.Get(0).(*XdrStream), a.Error(1)
 }
+
+func (m *MockArchive) GetXdrStreamForHashContext(ctx context.Context, hash Hash) (*XdrStream, error) {
+	a := m.Called(ctx, hash)
+	return a.Get(0).(*XdrStream), a.Error(1)
+}
+
+func (m *MockArchive) GetXdrStreamContext(ctx context.Context, pth string) (*XdrStream, error) {
+	a := m.Called(ctx, pth)
+	return a.Get(0).(*XdrStream), a.Error(1)
+}
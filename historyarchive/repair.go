@@ -59,7 +59,7 @@ func Repair(src *Archive, dst *Archive, opts *CommandOptions) error {
 	for bkt := range missingBuckets {
 		pth := BucketPath(bkt)
 		log.Printf("Repairing %s", pth)
-		errs += noteError(copyPath(src, dst, pth, opts))
+		errs += noteError(copyBucket(src, dst, bkt, opts))
 	}
 
 	if errs != 0 {
@@ -0,0 +1,578 @@
+// Copyright 2021 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"google.golang.org/api/googleapi"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	DefaultArchivePoolMaxRetries          = 3
+	DefaultArchivePoolInitialBackoff      = 500 * time.Millisecond
+	DefaultArchivePoolMaxBackoff          = 10 * time.Second
+	DefaultArchivePoolQuarantineThreshold = 3
+	DefaultArchivePoolQuarantineDuration  = 5 * time.Minute
+)
+
+// ArchivePoolOptions configures retries, failover and quarantining of
+// archives in a RetryingArchivePool. Zero values are replaced with defaults.
+type ArchivePoolOptions struct {
+	// MaxRetries is the maximum number of times a failed request is retried.
+	// Other archives are used for retries when possible.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It's doubled after
+	// every retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// QuarantineThreshold is the number of consecutive failures after which
+	// an archive is quarantined. Archives serving files with mismatched hashes
+	// are quarantined immediately.
+	QuarantineThreshold int
+	// QuarantineDuration is the time during which quarantined archives are
+	// not used unless all other archives failed.
+	QuarantineDuration time.Duration
+	// RequestsPerSecond limits the rate of requests sent to every archive,
+	// 0 means no limit.
+	RequestsPerSecond float64
+}
+
+func (o *ArchivePoolOptions) setDefaults() {
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultArchivePoolMaxRetries
+	}
+	if o.InitialBackoff == 0 {
+		o.InitialBackoff = DefaultArchivePoolInitialBackoff
+	}
+	if o.MaxBackoff == 0 {
+		o.MaxBackoff = DefaultArchivePoolMaxBackoff
+	}
+	if o.QuarantineThreshold == 0 {
+		o.QuarantineThreshold = DefaultArchivePoolQuarantineThreshold
+	}
+	if o.QuarantineDuration == 0 {
+		o.QuarantineDuration = DefaultArchivePoolQuarantineDuration
+	}
+}
+
+// ArchiveStats contains health metrics of an archive in a
+// RetryingArchivePool.
+type ArchiveStats struct {
+	URL string
+	// Requests is the number of requests sent to the archive.
+	Requests uint64
+	// Failures is the number of failed requests.
+	Failures uint64
+	// HashMismatches is the number of files with mismatched hashes served by
+	// the archive.
+	HashMismatches uint64
+	// Quarantines is the number of times the archive was quarantined.
+	Quarantines         uint64
+	ConsecutiveFailures int
+	// QuarantinedUntil is the time until which the archive is quarantined,
+	// zero or in the past when the archive is healthy.
+	QuarantinedUntil time.Time
+	LastError        string
+}
+
+// poolArchive is an archive in a RetryingArchivePool with its health state.
+type poolArchive struct {
+	archive ArchiveInterface
+
+	lock        sync.Mutex
+	stats       ArchiveStats
+	nextRequest time.Time
+}
+
+func (a *poolArchive) quarantined(now time.Time) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return now.Before(a.stats.QuarantinedUntil)
+}
+
+// RetryingArchivePool is an ArchivePool which distributes requests fairly
+// between its archives like ArchivePool but also handles failures: failed
+// requests are retried with a backoff using other archives when possible and
+// archives which fail repeatedly or serve files with mismatched hashes are
+// quarantined for a while.
+//
+// RetryingArchivePool is thread-safe.
+type RetryingArchivePool struct {
+	ctx      context.Context
+	options  ArchivePoolOptions
+	archives []*poolArchive
+	// now and sleep can be replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryingArchivePool tries connecting to each of the provided history
+// archive URLs, returning a RetryingArchivePool of valid archives. Zero values
+// of options are replaced with defaults.
+//
+// If none of the archives work, this returns the error message of the last
+// failed archive.
+func NewRetryingArchivePool(
+	archiveURLs []string,
+	config ConnectOptions,
+	options ArchivePoolOptions,
+) (*RetryingArchivePool, error) {
+	if len(archiveURLs) <= 0 {
+		return nil, errors.New("No history archives provided")
+	}
+
+	var lastErr error = nil
+
+	// Try connecting to all of the listed archives, but only store valid ones.
+	var (
+		validURLs     []string
+		validArchives []ArchiveInterface
+	)
+	for _, url := range archiveURLs {
+		archive, err := Connect(
+			url,
+			ConnectOptions{
+				NetworkPassphrase:   config.NetworkPassphrase,
+				CheckpointFrequency: config.CheckpointFrequency,
+				Context:             config.Context,
+				BucketCache:         config.BucketCache,
+			},
+		)
+
+		if err != nil {
+			lastErr = errors.Wrapf(err, "Error connecting to history archive (%s)", url)
+			continue
+		}
+
+		validURLs = append(validURLs, url)
+		validArchives = append(validArchives, archive)
+	}
+
+	if len(validArchives) == 0 {
+		return nil, lastErr
+	}
+
+	return NewRetryingArchivePoolFromArchives(config.Context, validURLs, validArchives, options)
+}
+
+// NewRetryingArchivePoolFromArchives returns a pool of the given archives. urls are
+// used to identify archives in stats and errors. ctx is used by methods which
+// don't accept a context (context.Background if nil).
+func NewRetryingArchivePoolFromArchives(
+	ctx context.Context,
+	urls []string,
+	archives []ArchiveInterface,
+	options ArchivePoolOptions,
+) (*RetryingArchivePool, error) {
+	if len(archives) == 0 {
+		return nil, errors.New("No history archives provided")
+	}
+	if len(urls) != len(archives) {
+		return nil, errors.New("Number of URLs does not match number of archives")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	options.setDefaults()
+
+	pool := &RetryingArchivePool{
+		ctx:     ctx,
+		options: options,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+	for i, archive := range archives {
+		pool.archives = append(pool.archives, &poolArchive{
+			archive: archive,
+			stats:   ArchiveStats{URL: urls[i]},
+		})
+	}
+	return pool, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Stats returns health metrics of archives in the pool.
+func (pa *RetryingArchivePool) Stats() []ArchiveStats {
+	stats := make([]ArchiveStats, 0, len(pa.archives))
+	for _, a := range pa.archives {
+		a.lock.Lock()
+		stats = append(stats, a.stats)
+		a.lock.Unlock()
+	}
+	return stats
+}
+
+// pick returns a random archive which is not quarantined and not in the
+// tried set. Quarantined archives are used when all other archives were
+// tried. When all archives were tried, the tried set is cleared.
+func (pa *RetryingArchivePool) pick(tried map[*poolArchive]bool) *poolArchive {
+	if len(tried) >= len(pa.archives) {
+		for a := range tried {
+			delete(tried, a)
+		}
+	}
+
+	now := pa.now()
+	var healthy, quarantined []*poolArchive
+	for _, a := range pa.archives {
+		if tried[a] {
+			continue
+		}
+		if a.quarantined(now) {
+			quarantined = append(quarantined, a)
+		} else {
+			healthy = append(healthy, a)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy[rand.Intn(len(healthy))]
+	}
+	return quarantined[rand.Intn(len(quarantined))]
+}
+
+// waitForRateLimit blocks until a request can be sent to the archive.
+func (pa *RetryingArchivePool) waitForRateLimit(ctx context.Context, a *poolArchive) error {
+	if pa.options.RequestsPerSecond <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / pa.options.RequestsPerSecond)
+
+	a.lock.Lock()
+	now := pa.now()
+	at := a.nextRequest
+	if at.Before(now) {
+		at = now
+	}
+	a.nextRequest = at.Add(interval)
+	a.lock.Unlock()
+
+	return pa.sleep(ctx, at.Sub(now))
+}
+
+func (pa *RetryingArchivePool) recordSuccess(a *poolArchive) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.stats.Requests++
+	a.stats.ConsecutiveFailures = 0
+}
+
+func (pa *RetryingArchivePool) recordFailure(a *poolArchive, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.stats.Requests++
+	a.stats.Failures++
+	a.stats.ConsecutiveFailures++
+	a.stats.LastError = err.Error()
+	if a.stats.ConsecutiveFailures >= pa.options.QuarantineThreshold {
+		pa.quarantine(a, fmt.Sprintf("%d consecutive failures", a.stats.ConsecutiveFailures))
+	}
+}
+
+func (pa *RetryingArchivePool) recordHashMismatch(a *poolArchive, hash Hash) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.stats.HashMismatches++
+	a.stats.LastError = fmt.Sprintf("hash mismatch of bucket %s", hash)
+	pa.quarantine(a, a.stats.LastError)
+}
+
+// quarantine must be called with the archive lock held.
+func (pa *RetryingArchivePool) quarantine(a *poolArchive, reason string) {
+	a.stats.QuarantinedUntil = pa.now().Add(pa.options.QuarantineDuration)
+	a.stats.Quarantines++
+	a.stats.ConsecutiveFailures = 0
+	log.Printf(
+		"Quarantining history archive %s until %s: %s",
+		a.stats.URL, a.stats.QuarantinedUntil.Format(time.RFC3339), reason,
+	)
+}
+
+func isContextError(err error) bool {
+	cause := errors.Cause(err)
+	return cause == context.Canceled || cause == context.DeadlineExceeded
+}
+
+// permanentError is an error which doesn't go away when a request is retried,
+// ex. because a file is not published (yet).
+type permanentError struct {
+	error
+}
+
+// isPermanentError returns true if err is not caused by a temporary problem
+// of an archive: files which don't exist and other 4xx responses (except
+// 408 Request Timeout and 429 Too Many Requests). Errors which can't be
+// classified (ex. network errors) are considered temporary.
+func isPermanentError(err error) bool {
+	cause := errors.Cause(err)
+	if os.IsNotExist(cause) {
+		return true
+	}
+	switch e := cause.(type) {
+	case permanentError:
+		return true
+	case httpResponseError:
+		return isPermanentStatusCode(e.statusCode)
+	case *googleapi.Error:
+		return isPermanentStatusCode(e.Code)
+	case awserr.RequestFailure:
+		return isPermanentStatusCode(e.StatusCode())
+	}
+	return false
+}
+
+func isPermanentStatusCode(code int) bool {
+	return code >= 400 && code < 500 &&
+		code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+}
+
+// hasUntriedHealthy returns true if an archive which is not quarantined was
+// not tried yet.
+func (pa *RetryingArchivePool) hasUntriedHealthy(tried map[*poolArchive]bool) bool {
+	now := pa.now()
+	for _, a := range pa.archives {
+		if !tried[a] && !a.quarantined(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// do runs op on archives from the pool until it succeeds, retrying failures
+// with a backoff on other archives when possible. Permanent errors (ex. files
+// which are not published yet) don't count as failures of the archive and are
+// not retried with a backoff. They are returned once all healthy archives
+// returned them: an archive lagging behind the others may not have published
+// a file yet.
+func (pa *RetryingArchivePool) do(ctx context.Context, op func(a *poolArchive) error) error {
+	tried := map[*poolArchive]bool{}
+	backoff := pa.options.InitialBackoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		a := pa.pick(tried)
+		tried[a] = true
+
+		if err = pa.waitForRateLimit(ctx, a); err != nil {
+			return err
+		}
+
+		err = op(a)
+		if err == nil {
+			pa.recordSuccess(a)
+			return nil
+		}
+		if ctx.Err() != nil || isContextError(err) {
+			return err
+		}
+		if isPermanentError(err) {
+			// The archive is healthy, it responded to the request.
+			pa.recordSuccess(a)
+			if pa.hasUntriedHealthy(tried) {
+				attempt--
+				continue
+			}
+			return errors.Wrapf(err, "error using history archive (%s)", a.stats.URL)
+		}
+		pa.recordFailure(a, err)
+		err = errors.Wrapf(err, "error using history archive (%s)", a.stats.URL)
+
+		if attempt >= pa.options.MaxRetries {
+			return err
+		}
+		if sleepErr := pa.sleep(ctx, backoff); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > pa.options.MaxBackoff {
+			backoff = pa.options.MaxBackoff
+		}
+	}
+}
+
+// Ensure the pool conforms to the ArchiveInterface
+var _ ArchiveInterface = &RetryingArchivePool{}
+
+// Below are the ArchiveInterface method implementations.
+
+// GetAnyArchive returns a random archive which is not quarantined (or a
+// random archive when all of them are quarantined).
+func (pa *RetryingArchivePool) GetAnyArchive() ArchiveInterface {
+	return pa.pick(map[*poolArchive]bool{}).archive
+}
+
+func (pa *RetryingArchivePool) GetPathHAS(path string) (has HistoryArchiveState, err error) {
+	err = pa.do(pa.ctx, func(a *poolArchive) error {
+		has, err = a.archive.GetPathHAS(path)
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) PutPathHAS(path string, has HistoryArchiveState, opts *CommandOptions) error {
+	return pa.GetAnyArchive().PutPathHAS(path, has, opts)
+}
+
+// errFileNotFound is returned by existence checks of exists to ask other
+// archives before reporting that a file does not exist.
+var errFileNotFound = permanentError{errors.New("file not found")}
+
+// exists runs an existence check on archives from the pool. Like for other
+// permanent errors, false is returned once all healthy archives reported that
+// the file does not exist.
+func (pa *RetryingArchivePool) exists(check func(a ArchiveInterface) (bool, error)) (bool, error) {
+	err := pa.do(pa.ctx, func(a *poolArchive) error {
+		exists, err := check(a.archive)
+		if err == nil && !exists {
+			return errFileNotFound
+		}
+		return err
+	})
+	if err != nil {
+		if errors.Cause(err) == errFileNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (pa *RetryingArchivePool) BucketExists(bucket Hash) (bool, error) {
+	return pa.exists(func(a ArchiveInterface) (bool, error) {
+		return a.BucketExists(bucket)
+	})
+}
+
+func (pa *RetryingArchivePool) CategoryCheckpointExists(cat string, chk uint32) (bool, error) {
+	return pa.exists(func(a ArchiveInterface) (bool, error) {
+		return a.CategoryCheckpointExists(cat, chk)
+	})
+}
+
+func (pa *RetryingArchivePool) GetLedgerHeader(chk uint32) (header xdr.LedgerHeaderHistoryEntry, err error) {
+	err = pa.do(pa.ctx, func(a *poolArchive) error {
+		header, err = a.archive.GetLedgerHeader(chk)
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) GetRootHAS() (has HistoryArchiveState, err error) {
+	err = pa.do(pa.ctx, func(a *poolArchive) error {
+		has, err = a.archive.GetRootHAS()
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) GetLedgers(start, end uint32) (map[uint32]*Ledger, error) {
+	return pa.GetLedgersContext(pa.ctx, start, end)
+}
+
+func (pa *RetryingArchivePool) GetLedgersContext(ctx context.Context, start, end uint32) (ledgers map[uint32]*Ledger, err error) {
+	err = pa.do(ctx, func(a *poolArchive) error {
+		ledgers, err = a.archive.GetLedgersContext(ctx, start, end)
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) GetCheckpointHAS(chk uint32) (HistoryArchiveState, error) {
+	return pa.GetCheckpointHASContext(pa.ctx, chk)
+}
+
+func (pa *RetryingArchivePool) GetCheckpointHASContext(ctx context.Context, chk uint32) (has HistoryArchiveState, err error) {
+	err = pa.do(ctx, func(a *poolArchive) error {
+		has, err = a.archive.GetCheckpointHASContext(ctx, chk)
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) PutCheckpointHAS(chk uint32, has HistoryArchiveState, opts *CommandOptions) error {
+	return pa.GetAnyArchive().PutCheckpointHAS(chk, has, opts)
+}
+
+func (pa *RetryingArchivePool) PutRootHAS(has HistoryArchiveState, opts *CommandOptions) error {
+	return pa.GetAnyArchive().PutRootHAS(has, opts)
+}
+
+func (pa *RetryingArchivePool) ListBucket(dp DirPrefix) (chan string, chan error) {
+	return pa.GetAnyArchive().ListBucket(dp)
+}
+
+func (pa *RetryingArchivePool) ListAllBuckets() (chan string, chan error) {
+	return pa.GetAnyArchive().ListAllBuckets()
+}
+
+func (pa *RetryingArchivePool) ListAllBucketHashes() (chan Hash, chan error) {
+	return pa.GetAnyArchive().ListAllBucketHashes()
+}
+
+func (pa *RetryingArchivePool) ListCategoryCheckpoints(cat string, pth string) (chan uint32, chan error) {
+	return pa.GetAnyArchive().ListCategoryCheckpoints(cat, pth)
+}
+
+func (pa *RetryingArchivePool) GetXdrStreamForHash(hash Hash) (*XdrStream, error) {
+	return pa.GetXdrStreamForHashContext(pa.ctx, hash)
+}
+
+// GetXdrStreamForHashContext returns a stream of the bucket with the given
+// hash. If the hash of the stream does not match the expected hash (checked
+// when the stream is closed), the archive which served it is quarantined so
+// that the next attempt uses another archive. Streams read from a bucket
+// cache already handle mismatches by removing the cached file, the archive
+// is not at fault then.
+func (pa *RetryingArchivePool) GetXdrStreamForHashContext(ctx context.Context, hash Hash) (stream *XdrStream, err error) {
+	err = pa.do(ctx, func(a *poolArchive) error {
+		stream, err = a.archive.GetXdrStreamForHashContext(ctx, hash)
+		if err == nil && stream != nil && stream.onHashMismatch == nil {
+			stream.onHashMismatch = func() {
+				pa.recordHashMismatch(a, hash)
+			}
+		}
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) GetXdrStream(pth string) (*XdrStream, error) {
+	return pa.GetXdrStreamContext(pa.ctx, pth)
+}
+
+func (pa *RetryingArchivePool) GetXdrStreamContext(ctx context.Context, pth string) (stream *XdrStream, err error) {
+	err = pa.do(ctx, func(a *poolArchive) error {
+		stream, err = a.archive.GetXdrStreamContext(ctx, pth)
+		return err
+	})
+	return
+}
+
+func (pa *RetryingArchivePool) GetCheckpointManager() CheckpointManager {
+	return pa.GetAnyArchive().GetCheckpointManager()
+}
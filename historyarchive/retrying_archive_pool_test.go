@@ -0,0 +1,289 @@
+package historyarchive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+
+	supportErrors "github.com/stellar/go/support/errors"
+)
+
+type archivePoolTest struct {
+	pool   *RetryingArchivePool
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newArchivePoolTest(t *testing.T, options ArchivePoolOptions, archives ...ArchiveInterface) *archivePoolTest {
+	urls := []string{"http://a", "http://b", "http://c"}[:len(archives)]
+	pool, err := NewRetryingArchivePoolFromArchives(context.Background(), urls, archives, options)
+	require.NoError(t, err)
+
+	test := &archivePoolTest{
+		pool: pool,
+		now:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	pool.now = func() time.Time { return test.now }
+	pool.sleep = func(ctx context.Context, d time.Duration) error {
+		if d > 0 {
+			test.sleeps = append(test.sleeps, d)
+		}
+		return ctx.Err()
+	}
+	return test
+}
+
+func TestRetryingArchivePoolFailover(t *testing.T) {
+	failing := &MockArchive{}
+	healthy := &MockArchive{}
+	failing.On("GetCheckpointHASContext", mock.Anything, uint32(63)).
+		Return(HistoryArchiveState{}, errors.New("connection reset"))
+	healthy.On("GetCheckpointHASContext", mock.Anything, uint32(63)).
+		Return(HistoryArchiveState{CurrentLedger: 63}, nil)
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{}, failing, healthy)
+	for i := 0; i < 20; i++ {
+		has, err := test.pool.GetCheckpointHAS(63)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(63), has.CurrentLedger)
+	}
+
+	stats := test.pool.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "http://a", stats[0].URL)
+	assert.Equal(t, stats[0].Requests, stats[0].Failures)
+	assert.Equal(t, "connection reset", stats[0].LastError)
+	assert.Equal(t, uint64(20), stats[1].Requests)
+	assert.Equal(t, uint64(0), stats[1].Failures)
+
+	// Failing archive is quarantined after 3 consecutive failures and not
+	// used anymore.
+	assert.Equal(t, uint64(3), stats[0].Failures)
+	assert.Equal(t, uint64(1), stats[0].Quarantines)
+	assert.Equal(t, test.now.Add(DefaultArchivePoolQuarantineDuration), stats[0].QuarantinedUntil)
+
+	// It's used again when quarantine ends.
+	test.now = test.now.Add(DefaultArchivePoolQuarantineDuration)
+	for i := 0; i < 20; i++ {
+		_, err := test.pool.GetCheckpointHAS(63)
+		require.NoError(t, err)
+	}
+	assert.True(t, test.pool.Stats()[0].Failures > 3)
+}
+
+func TestRetryingArchivePoolRetriesWithBackoff(t *testing.T) {
+	archive := &MockArchive{}
+	archive.On("GetRootHAS").
+		Return(HistoryArchiveState{}, errors.New("503 Service Unavailable")).Times(3)
+	archive.On("GetRootHAS").
+		Return(HistoryArchiveState{CurrentLedger: 127}, nil).Once()
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{
+		MaxRetries:          5,
+		InitialBackoff:      time.Second,
+		MaxBackoff:          3 * time.Second,
+		QuarantineThreshold: 10,
+	}, archive)
+	has, err := test.pool.GetRootHAS()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(127), has.CurrentLedger)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, test.sleeps)
+	archive.AssertExpectations(t)
+
+	stats := test.pool.Stats()[0]
+	assert.Equal(t, uint64(4), stats.Requests)
+	assert.Equal(t, uint64(3), stats.Failures)
+	assert.Equal(t, 0, stats.ConsecutiveFailures)
+}
+
+func TestRetryingArchivePoolGivesUp(t *testing.T) {
+	a := &MockArchive{}
+	b := &MockArchive{}
+	a.On("BucketExists", Hash{1}).Return(false, errors.New("timeout"))
+	b.On("BucketExists", Hash{1}).Return(false, errors.New("timeout"))
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{MaxRetries: 2}, a, b)
+	_, err := test.pool.BucketExists(Hash{1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout")
+	assert.Len(t, test.sleeps, 2)
+
+	stats := test.pool.Stats()
+	assert.Equal(t, uint64(3), stats[0].Requests+stats[1].Requests)
+	// Both archives were tried.
+	assert.True(t, stats[0].Requests > 0)
+	assert.True(t, stats[1].Requests > 0)
+}
+
+func TestRetryingArchivePoolContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	archive := &MockArchive{}
+	archive.On("GetLedgersContext", ctx, uint32(1), uint32(63)).
+		Return(map[uint32]*Ledger(nil), context.Canceled).Once()
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{}, archive)
+	_, err := test.pool.GetLedgersContext(ctx, 1, 63)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, test.sleeps)
+	assert.Equal(t, uint64(0), test.pool.Stats()[0].Failures)
+	archive.AssertExpectations(t)
+}
+
+func TestRetryingArchivePoolPermanentErrors(t *testing.T) {
+	for _, err := range []error{
+		httpResponseError{statusCode: http.StatusNotFound, status: "404 Not Found", url: "http://a/.well-known/stellar-history.json"},
+		&googleapi.Error{Code: http.StatusNotFound},
+		awserr.NewRequestFailure(awserr.New("NotFound", "not found", nil), http.StatusNotFound, "request"),
+		&os.PathError{Op: "open", Path: "history.json", Err: os.ErrNotExist},
+		permanentError{errors.New("checkpoint 127 is not published")},
+	} {
+		archive := &MockArchive{}
+		archive.On("GetRootHAS").Return(HistoryArchiveState{}, err).Once()
+
+		test := newArchivePoolTest(t, ArchivePoolOptions{QuarantineThreshold: 1}, archive)
+		_, poolErr := test.pool.GetRootHAS()
+		require.Error(t, poolErr)
+		assert.Equal(t, err, supportErrors.Cause(poolErr))
+		assert.Empty(t, test.sleeps)
+		archive.AssertExpectations(t)
+
+		stats := test.pool.Stats()[0]
+		assert.Equal(t, uint64(1), stats.Requests)
+		assert.Equal(t, uint64(0), stats.Failures)
+		assert.Equal(t, uint64(0), stats.Quarantines)
+	}
+
+	// 5xx, 408 and 429 responses are retried.
+	for _, code := range []int{http.StatusServiceUnavailable, http.StatusRequestTimeout, http.StatusTooManyRequests} {
+		archive := &MockArchive{}
+		archive.On("GetRootHAS").
+			Return(HistoryArchiveState{}, httpResponseError{statusCode: code}).Once()
+		archive.On("GetRootHAS").
+			Return(HistoryArchiveState{CurrentLedger: 127}, nil).Once()
+
+		test := newArchivePoolTest(t, ArchivePoolOptions{}, archive)
+		_, err := test.pool.GetRootHAS()
+		require.NoError(t, err)
+		assert.Len(t, test.sleeps, 1)
+		assert.Equal(t, uint64(1), test.pool.Stats()[0].Failures)
+	}
+}
+
+func TestRetryingArchivePoolLaggingArchive(t *testing.T) {
+	lagging := &MockArchive{}
+	upToDate := &MockArchive{}
+	lagging.On("CategoryCheckpointExists", "ledger", uint32(127)).Return(false, nil)
+	upToDate.On("CategoryCheckpointExists", "ledger", uint32(127)).Return(true, nil)
+	lagging.On("GetCheckpointHASContext", mock.Anything, uint32(127)).
+		Return(HistoryArchiveState{}, permanentError{errors.New("checkpoint 127 is not published")})
+	upToDate.On("GetCheckpointHASContext", mock.Anything, uint32(127)).
+		Return(HistoryArchiveState{CurrentLedger: 127}, nil)
+
+	// Negative answers of the lagging archive are checked with the other
+	// archive.
+	test := newArchivePoolTest(t, ArchivePoolOptions{}, lagging, upToDate)
+	for i := 0; i < 10; i++ {
+		exists, err := test.pool.CategoryCheckpointExists("ledger", 127)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		has, err := test.pool.GetCheckpointHAS(127)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(127), has.CurrentLedger)
+	}
+	assert.Empty(t, test.sleeps)
+	for _, stats := range test.pool.Stats() {
+		assert.Equal(t, uint64(0), stats.Failures)
+	}
+
+	// False is returned when no archive has the file, every archive is
+	// asked once.
+	a := &MockArchive{}
+	b := &MockArchive{}
+	a.On("BucketExists", Hash{1}).Return(false, nil).Once()
+	b.On("BucketExists", Hash{1}).Return(false, nil).Once()
+	test = newArchivePoolTest(t, ArchivePoolOptions{}, a, b)
+	exists, err := test.pool.BucketExists(Hash{1})
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Empty(t, test.sleeps)
+	a.AssertExpectations(t)
+	b.AssertExpectations(t)
+}
+
+func TestRetryingArchivePoolHashMismatch(t *testing.T) {
+	a := &MockArchive{}
+	b := &MockArchive{}
+	for _, archive := range []*MockArchive{a, b} {
+		archive.On("GetXdrStreamForHashContext", mock.Anything, Hash{1}).
+			Return(NewXdrStream(ioutil.NopCloser(bytes.NewReader([]byte("bucket")))), nil).Once()
+	}
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{}, a, b)
+	stream, err := test.pool.GetXdrStreamForHash(Hash{1})
+	require.NoError(t, err)
+	stream.SetExpectedHash(Hash{1})
+	assert.EqualError(t, stream.Close(), "Stream hash does not match expected hash!")
+
+	stats := test.pool.Stats()
+	var mismatched, other int
+	if stats[0].HashMismatches == 1 {
+		mismatched, other = 0, 1
+	} else {
+		mismatched, other = 1, 0
+	}
+	assert.Equal(t, uint64(1), stats[mismatched].HashMismatches)
+	assert.Equal(t, uint64(1), stats[mismatched].Quarantines)
+	assert.True(t, stats[mismatched].QuarantinedUntil.After(test.now))
+	assert.Equal(t, uint64(0), stats[other].HashMismatches)
+
+	// The next stream is fetched from the other archive.
+	_, err = test.pool.GetXdrStreamForHash(Hash{1})
+	require.NoError(t, err)
+	a.AssertExpectations(t)
+	b.AssertExpectations(t)
+}
+
+func TestRetryingArchivePoolRateLimit(t *testing.T) {
+	archive := &MockArchive{}
+	archive.On("CategoryCheckpointExists", "history", uint32(63)).Return(true, nil)
+
+	test := newArchivePoolTest(t, ArchivePoolOptions{RequestsPerSecond: 10}, archive)
+	for i := 0; i < 3; i++ {
+		exists, err := test.pool.CategoryCheckpointExists("history", 63)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, test.sleeps)
+}
+
+func TestNewRetryingArchivePool(t *testing.T) {
+	_, err := NewRetryingArchivePool(nil, ConnectOptions{}, ArchivePoolOptions{})
+	assert.EqualError(t, err, "No history archives provided")
+
+	_, err = NewRetryingArchivePool([]string{"invalid://archive"}, ConnectOptions{}, ArchivePoolOptions{})
+	assert.Error(t, err)
+
+	pool, err := NewRetryingArchivePool(
+		[]string{"invalid://archive", "mock://archive"},
+		ConnectOptions{},
+		ArchivePoolOptions{},
+	)
+	require.NoError(t, err)
+	stats := pool.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "mock://archive", stats[0].URL)
+}
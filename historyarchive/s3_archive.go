@@ -26,6 +26,12 @@ type S3ArchiveBackend struct {
 }
 
 func (b *S3ArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
+	return b.GetFileContext(b.ctx, pth)
+}
+
+// GetFileContext downloads a file using the given context instead of the
+// context passed in ConnectOptions.
+func (b *S3ArchiveBackend) GetFileContext(ctx context.Context, pth string) (io.ReadCloser, error) {
 	params := &s3.GetObjectInput{
 		Bucket: aws.String(b.bucket),
 		Key:    aws.String(path.Join(b.prefix, pth)),
@@ -35,7 +41,7 @@ func (b *S3ArchiveBackend) GetFile(pth string) (io.ReadCloser, error) {
 	if b.unsignedRequests {
 		req.Handlers.Sign.Clear() // makes this request unsigned
 	}
-	req.SetContext(b.ctx)
+	req.SetContext(ctx)
 	err := req.Send()
 	if err != nil {
 		return nil, err
@@ -34,6 +34,20 @@ func bufReadCloser(in io.ReadCloser) io.ReadCloser {
 }
 
 func copyPath(src *Archive, dst *Archive, pth string, opts *CommandOptions) error {
+	return copyArchiveFile(dst, pth, opts, func() (io.ReadCloser, error) {
+		return src.backend.GetFile(pth)
+	})
+}
+
+// copyBucket is like copyPath but reads the bucket through the bucket cache of
+// the source archive (if set).
+func copyBucket(src *Archive, dst *Archive, bucket Hash, opts *CommandOptions) error {
+	return copyArchiveFile(dst, BucketPath(bucket), opts, func() (io.ReadCloser, error) {
+		return src.getBucketFile(src.ctx, bucket)
+	})
+}
+
+func copyArchiveFile(dst *Archive, pth string, opts *CommandOptions, get func() (io.ReadCloser, error)) error {
 	if opts.DryRun {
 		log.Printf("dryrun skipping " + pth)
 		return nil
@@ -46,7 +60,7 @@ func copyPath(src *Archive, dst *Archive, pth string, opts *CommandOptions) erro
 		log.Printf("skipping existing " + pth)
 		return nil
 	}
-	rdr, err := src.backend.GetFile(pth)
+	rdr, err := get()
 	if err != nil {
 		return err
 	}
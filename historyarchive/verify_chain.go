@@ -0,0 +1,143 @@
+// Copyright 2021 Stellar Development Foundation and contributors. Licensed
+// under the Apache License, Version 2.0. See the COPYING file at the root
+// of this distribution or at http://www.apache.org/licenses/LICENSE-2.0
+
+package historyarchive
+
+import (
+	"context"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// TrustedLedger is a ledger hash obtained from a trusted source (ex. a
+// stellar-core node run by the caller or a hardcoded value) used to anchor
+// the verification of a ledger chain. It must be the last ledger of the
+// verified range.
+type TrustedLedger struct {
+	Sequence uint32
+	Hash     Hash
+}
+
+// VerifyLedgerChain downloads ledgers in the given range (inclusive) from the
+// archive and verifies them using VerifyLedgers.
+//
+// Without a trusted ledger VerifyLedgerChain only proves that the ledgers are
+// internally consistent, which is not enough to trust data coming from
+// third-party archives: anyone can build a valid chain of fake ledgers.
+func VerifyLedgerChain(ctx context.Context, archive ArchiveInterface, r Range, trusted *TrustedLedger) (map[uint32]*Ledger, error) {
+	ledgers, err := archive.GetLedgersContext(ctx, r.Low, r.High)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting ledgers")
+	}
+	if err = VerifyLedgers(ledgers, r, trusted); err != nil {
+		return nil, err
+	}
+	return ledgers, nil
+}
+
+// VerifyLedgers verifies the ledgers in the given range (inclusive). It
+// checks that:
+//
+//   - all ledgers in the range are present,
+//   - hash of every ledger header matches its contents,
+//   - previous ledger hash of every ledger (except the first one) matches the
+//     hash of the previous ledger,
+//   - hashes of the transaction set and the transaction result set of every
+//     ledger match the hashes in the ledger header,
+//   - if trusted is not nil, hash of the trusted ledger (which must be the
+//     last ledger of the range) matches the trusted hash.
+//
+// Because every ledger header contains the hash of the previous one, a
+// trusted ledger authenticates itself and all ledgers before it, but none of
+// the ledgers after it: anyone can build a valid chain of fake ledgers on top
+// of a trusted ledger. This is why the trusted ledger must be r.High.
+func VerifyLedgers(ledgers map[uint32]*Ledger, r Range, trusted *TrustedLedger) error {
+	if r.Low == 0 || r.Low > r.High {
+		return errors.Errorf("range is invalid, low: %d high: %d", r.Low, r.High)
+	}
+	if trusted != nil && trusted.Sequence != r.High {
+		return errors.Errorf("trusted ledger %d is not the last ledger of range [%d, %d]", trusted.Sequence, r.Low, r.High)
+	}
+
+	for seq := r.Low; seq <= r.High; seq++ {
+		ledger, ok := ledgers[seq]
+		if !ok {
+			return errors.Errorf("ledger %d is missing", seq)
+		}
+		if err := verifyLedger(seq, ledger); err != nil {
+			return err
+		}
+
+		if seq > r.Low {
+			prev := ledgers[seq-1].Header.Hash
+			if ledger.Header.Header.PreviousLedgerHash != prev {
+				return errors.Errorf(
+					"previous ledger hash of ledger %d (%s) does not match hash of ledger %d (%s)",
+					seq, Hash(ledger.Header.Header.PreviousLedgerHash), seq-1, Hash(prev),
+				)
+			}
+		}
+
+		if trusted != nil && trusted.Sequence == seq && Hash(ledger.Header.Hash) != trusted.Hash {
+			return errors.Errorf(
+				"hash of ledger %d (%s) does not match trusted hash (%s)",
+				seq, Hash(ledger.Header.Hash), trusted.Hash,
+			)
+		}
+	}
+	return nil
+}
+
+// verifyLedger checks that the ledger header hash, the transaction set hash
+// and the transaction result set hash of a single ledger are valid.
+func verifyLedger(seq uint32, ledger *Ledger) error {
+	header := ledger.Header.Header
+	if uint32(header.LedgerSeq) != seq {
+		return errors.Errorf("ledger %d has unexpected sequence %d", seq, header.LedgerSeq)
+	}
+
+	hash, err := HashXdr(&header)
+	if err != nil {
+		return errors.Wrapf(err, "error hashing header of ledger %d", seq)
+	}
+	if hash != Hash(ledger.Header.Hash) {
+		return errors.Errorf("hash of ledger %d (%s) does not match its header (%s)", seq, Hash(ledger.Header.Hash), hash)
+	}
+
+	// Ledgers without transactions are not present in transactions and
+	// results checkpoint files.
+	txSetHash := HashEmptyTxSet(Hash(header.PreviousLedgerHash))
+	if uint32(ledger.Transaction.LedgerSeq) == seq {
+		// HashTxSet sorts transactions in place, don't modify the caller's
+		// ledger.
+		txSet := ledger.Transaction.TxSet
+		txSet.Txs = append([]xdr.TransactionEnvelope(nil), txSet.Txs...)
+		txSetHash, err = HashTxSet(&txSet)
+		if err != nil {
+			return errors.Wrapf(err, "error hashing transaction set of ledger %d", seq)
+		}
+	}
+	if txSetHash != Hash(header.ScpValue.TxSetHash) {
+		return errors.Errorf(
+			"transaction set hash of ledger %d (%s) does not match header (%s)",
+			seq, txSetHash, Hash(header.ScpValue.TxSetHash),
+		)
+	}
+
+	resultSetHash := EmptyXdrArrayHash()
+	if uint32(ledger.TransactionResult.LedgerSeq) == seq {
+		resultSetHash, err = HashXdr(&ledger.TransactionResult.TxResultSet)
+		if err != nil {
+			return errors.Wrapf(err, "error hashing transaction result set of ledger %d", seq)
+		}
+	}
+	if resultSetHash != Hash(header.TxSetResultHash) {
+		return errors.Errorf(
+			"transaction result set hash of ledger %d (%s) does not match header (%s)",
+			seq, resultSetHash, Hash(header.TxSetResultHash),
+		)
+	}
+	return nil
+}
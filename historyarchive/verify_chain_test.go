@@ -0,0 +1,145 @@
+package historyarchive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+func publishTestLedgerChain(t *testing.T, from, to uint32) (*Archive, []xdr.LedgerCloseMeta) {
+	archive := GetTestMockArchive()
+	writer := NewCheckpointWriter(archive, CheckpointWriterConfig{})
+	ledgers := testLedgerChain(t, from, to)
+	for _, ledger := range ledgers {
+		require.NoError(t, writer.Write(ledger))
+	}
+	return archive, ledgers
+}
+
+func TestVerifyLedgerChain(t *testing.T) {
+	archive, ledgers := publishTestLedgerChain(t, 1, 127)
+	trusted := &TrustedLedger{
+		Sequence: 100,
+		Hash:     Hash(ledgers[99].MustV0().LedgerHeader.Hash),
+	}
+
+	verified, err := VerifyLedgerChain(context.Background(), archive, Range{Low: 5, High: 100}, trusted)
+	require.NoError(t, err)
+	for seq := uint32(5); seq <= 100; seq++ {
+		assert.Equal(t, ledgers[seq-1].MustV0().LedgerHeader, verified[seq].Header)
+	}
+
+	_, err = VerifyLedgerChain(context.Background(), archive, Range{Low: 1, High: 127}, nil)
+	require.NoError(t, err)
+
+	trusted.Hash = Hash{1}
+	_, err = VerifyLedgerChain(context.Background(), archive, Range{Low: 5, High: 100}, trusted)
+	assert.EqualError(t, err, "hash of ledger 100 ("+Hash(ledgers[99].MustV0().LedgerHeader.Hash).String()+
+		") does not match trusted hash ("+Hash{1}.String()+")")
+
+	_, err = VerifyLedgerChain(context.Background(), archive, Range{Low: 101, High: 120}, trusted)
+	assert.EqualError(t, err, "trusted ledger 100 is not the last ledger of range [101, 120]")
+	_, err = VerifyLedgerChain(context.Background(), archive, Range{Low: 5, High: 120}, trusted)
+	assert.EqualError(t, err, "trusted ledger 100 is not the last ledger of range [5, 120]")
+
+	_, err = VerifyLedgerChain(context.Background(), archive, Range{Low: 1, High: 128}, nil)
+	assert.EqualError(t, err, "error getting ledgers: checkpoint 191 is not published")
+}
+
+func TestVerifyLedgers(t *testing.T) {
+	archive, _ := publishTestLedgerChain(t, 1, 63)
+	r := Range{Low: 1, High: 63}
+
+	for _, testCase := range []struct {
+		name     string
+		modify   func(ledgers map[uint32]*Ledger)
+		expected string
+	}{
+		{
+			"missing ledger",
+			func(ledgers map[uint32]*Ledger) {
+				delete(ledgers, 30)
+			},
+			"ledger 30 is missing",
+		},
+		{
+			"modified header",
+			func(ledgers map[uint32]*Ledger) {
+				ledgers[30].Header.Header.TotalCoins = 1
+			},
+			"hash of ledger 30 (",
+		},
+		{
+			"broken chain",
+			func(ledgers map[uint32]*Ledger) {
+				// Ledger 31 is rebuilt so that its own hash is valid but it
+				// doesn't point to ledger 30.
+				ledgers[31].Header.Header.PreviousLedgerHash = xdr.Hash{1}
+				ledgers[31].Header.Header.ScpValue.TxSetHash = xdr.Hash(HashEmptyTxSet(Hash{1}))
+				hash, err := HashXdr(&ledgers[31].Header.Header)
+				require.NoError(t, err)
+				ledgers[31].Header.Hash = xdr.Hash(hash)
+			},
+			"previous ledger hash of ledger 31 (",
+		},
+		{
+			"modified transaction set",
+			func(ledgers map[uint32]*Ledger) {
+				ledgers[30].Transaction.TxSet.Txs[0].V1.Tx.Fee++
+			},
+			"transaction set hash of ledger 30 (",
+		},
+		{
+			"removed transaction set",
+			func(ledgers map[uint32]*Ledger) {
+				ledgers[30].Transaction = xdr.TransactionHistoryEntry{}
+			},
+			"transaction set hash of ledger 30 (",
+		},
+		{
+			"modified results",
+			func(ledgers map[uint32]*Ledger) {
+				ledgers[40].TransactionResult.TxResultSet.Results[0].Result.FeeCharged++
+			},
+			"transaction result set hash of ledger 40 (",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ledgers, err := archive.GetLedgers(r.Low, r.High)
+			require.NoError(t, err)
+			require.NoError(t, VerifyLedgers(ledgers, r, nil))
+
+			testCase.modify(ledgers)
+			err = VerifyLedgers(ledgers, r, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expected)
+		})
+	}
+}
+
+func TestVerifyLedgersForgedSuccessorOfTrustedLedger(t *testing.T) {
+	archive, _ := publishTestLedgerChain(t, 1, 63)
+	r := Range{Low: 1, High: 41}
+	ledgers, err := archive.GetLedgers(r.Low, r.High)
+	require.NoError(t, err)
+	trusted := TrustedLedger{Sequence: 40, Hash: Hash(ledgers[40].Header.Hash)}
+	genuine := TrustedLedger{Sequence: 41, Hash: Hash(ledgers[41].Header.Hash)}
+
+	// Ledger 41 is forged: it is internally consistent and points to the
+	// trusted ledger 40.
+	ledgers[41].Header.Header.TotalCoins++
+	hash, err := HashXdr(&ledgers[41].Header.Header)
+	require.NoError(t, err)
+	ledgers[41].Header.Hash = xdr.Hash(hash)
+	require.NoError(t, VerifyLedgers(ledgers, r, nil))
+
+	err = VerifyLedgers(ledgers, r, &trusted)
+	assert.EqualError(t, err, "trusted ledger 40 is not the last ledger of range [1, 41]")
+	err = VerifyLedgers(ledgers, r, &genuine)
+	assert.EqualError(t, err, "hash of ledger 41 ("+hash.String()+") does not match trusted hash ("+genuine.Hash.String()+")")
+	require.NoError(t, VerifyLedgers(ledgers, Range{Low: 1, High: 40}, &trusted))
+}
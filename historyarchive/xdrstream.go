@@ -27,6 +27,9 @@ type XdrStream struct {
 
 	validateHash bool
 	expectedHash [sha256.Size]byte
+	// onHashMismatch is called when the hash of the stream does not match the
+	// expected hash.
+	onHashMismatch func()
 }
 
 type countReader struct {
@@ -115,6 +118,9 @@ func (x *XdrStream) Close() error {
 		if !bytes.Equal(x.expectedHash[:], actualHash[:]) {
 			// close the internal readers to avoid memory leaks
 			x.closeReaders()
+			if x.onHashMismatch != nil {
+				x.onHashMismatch()
+			}
 			return errors.New("Stream hash does not match expected hash!")
 		}
 	}
@@ -70,6 +70,21 @@ const (
 	sleepDuration = time.Second
 )
 
+// CheckpointChangeReaderOption values can be passed into
+// NewCheckpointChangeReader to customize a CheckpointChangeReader instance.
+type CheckpointChangeReaderOption func(r *CheckpointChangeReader)
+
+// DiskTempSet configures CheckpointChangeReader to track seen ledger keys
+// using temporary files created in the given directory (os.TempDir() when
+// empty) instead of memory. At most memoryKeys keys (1,000,000 when not
+// positive) are kept in memory. It makes memory usage predictable at the
+// expense of disk I/O and is useful on memory-constrained hosts.
+func DiskTempSet(dir string, memoryKeys int) CheckpointChangeReaderOption {
+	return func(r *CheckpointChangeReader) {
+		r.tempStore = newDiskTempSet(dir, memoryKeys)
+	}
+}
+
 // NewCheckpointChangeReader constructs a new CheckpointChangeReader instance.
 //
 // The ledger sequence must be a checkpoint ledger. By default (see
@@ -80,6 +95,7 @@ func NewCheckpointChangeReader(
 	ctx context.Context,
 	archive historyarchive.ArchiveInterface,
 	sequence uint32,
+	options ...CheckpointChangeReaderOption,
 ) (*CheckpointChangeReader, error) {
 	manager := archive.GetCheckpointManager()
 
@@ -94,29 +110,33 @@ func NewCheckpointChangeReader(
 			manager.GetCheckpointFrequency())
 	}
 
-	has, err := archive.GetCheckpointHAS(sequence)
+	has, err := archive.GetCheckpointHASContext(ctx, sequence)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to get checkpoint HAS at ledger sequence %d", sequence)
 	}
 
-	tempStore := &memoryTempSet{}
-	err = tempStore.Open()
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to get open temp store")
-	}
-
-	return &CheckpointChangeReader{
+	reader := &CheckpointChangeReader{
 		ctx:        ctx,
 		has:        &has,
 		archive:    archive,
-		tempStore:  tempStore,
+		tempStore:  &memoryTempSet{},
 		sequence:   sequence,
 		readChan:   make(chan readResult, msrBufferSize),
 		streamOnce: sync.Once{},
 		closeOnce:  sync.Once{},
 		done:       make(chan bool),
 		sleep:      time.Sleep,
-	}, nil
+	}
+	for _, option := range options {
+		option(reader)
+	}
+
+	err = reader.tempStore.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get open temp store")
+	}
+
+	return reader, nil
 }
 
 func (r *CheckpointChangeReader) bucketExists(hash historyarchive.Hash) (bool, error) {
@@ -131,6 +151,9 @@ func (r *CheckpointChangeReader) bucketExists(hash historyarchive.Hash) (bool, e
 		if attempts >= maxStreamRetries {
 			break
 		}
+		if r.ctx.Err() != nil {
+			return false, r.ctx.Err()
+		}
 		r.sleep(duration)
 		duration *= 2
 	}
@@ -264,7 +287,7 @@ func (r *CheckpointChangeReader) newXDRStream(hash historyarchive.Hash) (
 	*historyarchive.XdrStream,
 	error,
 ) {
-	rdr, e := r.archive.GetXdrStreamForHash(hash)
+	rdr, e := r.archive.GetXdrStreamForHashContext(r.ctx, hash)
 	if e == nil && !r.disableBucketListHashValidation {
 		// Calling SetExpectedHash will enable validation of the stream hash. If hashes
 		// don't match, rdr.Close() will return an error.
@@ -38,7 +38,7 @@ func (s *SingleLedgerStateReaderTestSuite) SetupTest() {
 	ledgerSeq := uint32(24123007)
 
 	s.mockArchive.
-		On("GetCheckpointHAS", ledgerSeq).
+		On("GetCheckpointHASContext", mock.Anything, ledgerSeq).
 		Return(s.has, nil)
 
 	// BucketExists should be called 21 times (11 levels, last without `snap`)
@@ -79,13 +79,13 @@ func (s *SingleLedgerStateReaderTestSuite) TestSimple() {
 
 	// Return curr1 stream for the first bucket...
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	// ...and empty streams for the rest of the buckets.
 	for hash := range nextBucket {
 		s.mockArchive.
-			On("GetXdrStreamForHash", hash).
+			On("GetXdrStreamForHashContext", mock.Anything, hash).
 			Return(createXdrStream(), nil).Once()
 	}
 
@@ -115,17 +115,17 @@ func (s *SingleLedgerStateReaderTestSuite) TestRemoved() {
 
 	// Return curr1 and snap1 stream for the first two bucket...
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(snap1, nil).Once()
 
 	// ...and empty streams for the rest of the buckets.
 	for hash := range nextBucket {
 		s.mockArchive.
-			On("GetXdrStreamForHash", hash).
+			On("GetXdrStreamForHashContext", mock.Anything, hash).
 			Return(createXdrStream(), nil).Once()
 	}
 
@@ -133,6 +133,57 @@ func (s *SingleLedgerStateReaderTestSuite) TestRemoved() {
 	s.Require().Equal(err, io.EOF)
 }
 
+// TestDiskTempSet test reading buckets using diskTempSet spilling every key
+// to disk.
+func (s *SingleLedgerStateReaderTestSuite) TestDiskTempSet() {
+	var err error
+	s.reader, err = NewCheckpointChangeReader(
+		context.Background(),
+		s.mockArchive,
+		s.reader.sequence,
+		DiskTempSet("", 1),
+	)
+	s.Require().NoError(err)
+	s.reader.disableBucketListHashValidation = true
+	s.Require().IsType(&diskTempSet{}, s.reader.tempStore)
+
+	curr1 := createXdrStream(
+		entryAccount(xdr.BucketEntryTypeDeadentry, "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML", 1),
+		entryAccount(xdr.BucketEntryTypeLiveentry, "GALPCCZN4YXA3YMJHKL6CVIECKPLJJCTVMSNYWBTKJW4K5HQLYLDMZTB", 2),
+	)
+
+	snap1 := createXdrStream(
+		entryAccount(xdr.BucketEntryTypeLiveentry, "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML", 1),
+		entryAccount(xdr.BucketEntryTypeLiveentry, "GALPCCZN4YXA3YMJHKL6CVIECKPLJJCTVMSNYWBTKJW4K5HQLYLDMZTB", 1),
+	)
+
+	nextBucket := s.getNextBucketChannel()
+
+	s.mockArchive.
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
+		Return(curr1, nil).Once()
+
+	s.mockArchive.
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
+		Return(snap1, nil).Once()
+
+	for hash := range nextBucket {
+		s.mockArchive.
+			On("GetXdrStreamForHashContext", mock.Anything, hash).
+			Return(createXdrStream(), nil).Once()
+	}
+
+	change, err := s.reader.Read()
+	s.Require().NoError(err)
+	account := change.Post.Data.MustAccount()
+	s.Assert().Equal("GALPCCZN4YXA3YMJHKL6CVIECKPLJJCTVMSNYWBTKJW4K5HQLYLDMZTB", account.AccountId.Address())
+	s.Assert().Equal(xdr.Int64(2), account.Balance)
+
+	_, err = s.reader.Read()
+	s.Require().Equal(err, io.EOF)
+	s.Require().NoError(s.reader.Close())
+}
+
 // TestConcurrentRead test concurrent reads for race conditions
 func (s *SingleLedgerStateReaderTestSuite) TestConcurrentRead() {
 	curr1 := createXdrStream(
@@ -150,17 +201,17 @@ func (s *SingleLedgerStateReaderTestSuite) TestConcurrentRead() {
 
 	// Return curr1 and snap1 stream for the first two bucket...
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(snap1, nil).Once()
 
 	// ...and empty streams for the rest of the buckets.
 	for hash := range nextBucket {
 		s.mockArchive.
-			On("GetXdrStreamForHash", hash).
+			On("GetXdrStreamForHashContext", mock.Anything, hash).
 			Return(createXdrStream(), nil).Once()
 	}
 
@@ -195,13 +246,13 @@ func (s *SingleLedgerStateReaderTestSuite) TestEnsureLatestLiveEntry() {
 
 	// Return curr1 stream, rest won't be read due to an error
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	// ...and empty streams for the rest of the buckets.
 	for hash := range nextBucket {
 		s.mockArchive.
-			On("GetXdrStreamForHash", hash).
+			On("GetXdrStreamForHashContext", mock.Anything, hash).
 			Return(createXdrStream(), nil).Once()
 	}
 
@@ -225,7 +276,7 @@ func (s *SingleLedgerStateReaderTestSuite) TestMalformedProtocol11Bucket() {
 
 	// Return curr1 stream, rest won't be read due to an error
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	// BucketExists will be called only once in this test due to an error
@@ -251,7 +302,7 @@ func (s *SingleLedgerStateReaderTestSuite) TestMalformedProtocol11BucketNoMeta()
 
 	// Return curr1 stream, rest won't be read due to an error
 	s.mockArchive.
-		On("GetXdrStreamForHash", <-nextBucket).
+		On("GetXdrStreamForHashContext", mock.Anything, <-nextBucket).
 		Return(curr1, nil).Once()
 
 	// BucketExists will be called only once in this test due to an error
@@ -280,7 +331,7 @@ func (s *BucketExistsTestSuite) SetupTest() {
 
 	ledgerSeq := uint32(24123007)
 	s.mockArchive.
-		On("GetCheckpointHAS", ledgerSeq).
+		On("GetCheckpointHASContext", mock.Anything, ledgerSeq).
 		Return(historyarchive.HistoryArchiveState{}, nil)
 
 	s.mockArchive.
@@ -370,7 +421,7 @@ func (s *ReadBucketEntryTestSuite) SetupTest() {
 
 	ledgerSeq := uint32(24123007)
 	s.mockArchive.
-		On("GetCheckpointHAS", ledgerSeq).
+		On("GetCheckpointHASContext", mock.Anything, ledgerSeq).
 		Return(historyarchive.HistoryArchiveState{}, nil)
 
 	s.mockArchive.
@@ -402,7 +453,7 @@ func (s *ReadBucketEntryTestSuite) TestNewXDRStream() {
 	s.Require().False(ok)
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(expectedStream, nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -419,7 +470,7 @@ func (s *ReadBucketEntryTestSuite) TestReadAllEntries() {
 	firstEntry := metaEntry(1)
 	secondEntry := metaEntry(2)
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry, secondEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -442,7 +493,7 @@ func (s *ReadBucketEntryTestSuite) TestFirstReadFailsWithContextError() {
 	firstEntry := metaEntry(1)
 	secondEntry := metaEntry(2)
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry, secondEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -458,7 +509,7 @@ func (s *ReadBucketEntryTestSuite) TestSecondReadFailsWithContextError() {
 	firstEntry := metaEntry(1)
 	secondEntry := metaEntry(2)
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry, secondEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -478,7 +529,7 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryAllRetriesFail() {
 
 	for i := 0; i < 4; i++ {
 		s.mockArchive.
-			On("GetXdrStreamForHash", emptyHash).
+			On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 			Return(createInvalidXdrStream(nil), nil).Once()
 	}
 
@@ -493,7 +544,7 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetryIgnoresProtocolCloseError()
 	emptyHash := historyarchive.EmptyXdrArrayHash()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(
 			createInvalidXdrStream(errors.New("stream error: stream ID 75; PROTOCOL_ERROR")),
 			nil,
@@ -501,7 +552,7 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetryIgnoresProtocolCloseError()
 
 	expectedEntry := metaEntry(1)
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(expectedEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -523,12 +574,12 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetryFailsToCreateNewStream() {
 	emptyHash := historyarchive.EmptyXdrArrayHash()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createInvalidXdrStream(nil), nil).Once()
 
 	var nilStream *historyarchive.XdrStream
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(nilStream, errors.New("cannot create new stream")).Times(3)
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -542,18 +593,18 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetrySucceedsAfterFailsToCreateN
 	emptyHash := historyarchive.EmptyXdrArrayHash()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createInvalidXdrStream(nil), nil).Once()
 
 	var nilStream *historyarchive.XdrStream
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(nilStream, errors.New("cannot create new stream")).Once()
 
 	firstEntry := metaEntry(1)
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -571,16 +622,16 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetrySucceeds() {
 	emptyHash := historyarchive.EmptyXdrArrayHash()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createInvalidXdrStream(nil), nil).Once()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createInvalidXdrStream(nil), nil).Once()
 
 	expectedEntry := metaEntry(1)
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(expectedEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -609,11 +660,11 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetrySucceedsWithDiscard() {
 	writeInvalidFrame(b)
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(xdrStreamFromBuffer(b), nil).Once()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry, secondEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
@@ -645,7 +696,7 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetryFailsWithDiscardError() {
 	writeInvalidFrame(b)
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(xdrStreamFromBuffer(b), nil).Times(4)
 
 	b = &bytes.Buffer{}
@@ -673,18 +724,18 @@ func (s *ReadBucketEntryTestSuite) TestReadEntryRetrySucceedsAfterDiscardError()
 	writeInvalidFrame(b)
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(xdrStreamFromBuffer(b), nil).Once()
 
 	b = &bytes.Buffer{}
 	b.WriteString("a")
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(xdrStreamFromBuffer(b), nil).Once()
 
 	s.mockArchive.
-		On("GetXdrStreamForHash", emptyHash).
+		On("GetXdrStreamForHashContext", mock.Anything, emptyHash).
 		Return(createXdrStream(firstEntry, secondEntry), nil).Once()
 
 	stream, err := s.reader.newXDRStream(emptyHash)
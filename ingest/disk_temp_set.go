@@ -0,0 +1,425 @@
+package ingest
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// defaultDiskTempSetMemoryKeys is the default number of keys kept in
+	// memory by diskTempSet before spilling them to disk.
+	defaultDiskTempSetMemoryKeys = 1000000
+	// spillIndexInterval is the number of keys between keys stored in the
+	// sparse index of a spill file. It defines the size of the block read from
+	// disk when checking if a key exists.
+	spillIndexInterval = 128
+	// maxSpillFiles is the number of spill files after which all the files are
+	// merged into a single one.
+	maxSpillFiles = 8
+	// bloomFilterBitsPerKey is the number of bits per key in bloom filters.
+	// With 7 hash functions it gives ~1% false positive rate.
+	bloomFilterBitsPerKey = 10
+	bloomFilterHashes     = 7
+)
+
+// diskTempSet is an implementation of TempSet interface which keeps a bounded
+// number of keys in memory. When the limit is reached, keys are sorted and
+// spilled to a temporary file. Every spill file has a bloom filter and a
+// sparse index kept in memory so checking if a key exists requires at most
+// one small disk read per spill file (and usually none for keys which were not
+// added). Spill files are merged when there are too many of them.
+//
+// Memory usage is bounded by the number of keys kept in memory plus ~2 bytes
+// per spilled key (bloom filters and sparse indexes).
+type diskTempSet struct {
+	dir        string
+	memoryKeys int
+
+	tempDir string
+	memory  map[string]struct{}
+	files   []*spillFile
+	// preloaded contains results of Exist for keys passed to Preload which
+	// are not in memory.
+	preloaded map[string]bool
+}
+
+// newDiskTempSet returns a diskTempSet creating spill files in the given
+// directory (os.TempDir() when empty) and keeping at most memoryKeys keys in
+// memory (defaultDiskTempSetMemoryKeys when not positive).
+func newDiskTempSet(dir string, memoryKeys int) *diskTempSet {
+	if memoryKeys <= 0 {
+		memoryKeys = defaultDiskTempSetMemoryKeys
+	}
+	return &diskTempSet{dir: dir, memoryKeys: memoryKeys}
+}
+
+// Open creates a temporary directory for spill files.
+func (s *diskTempSet) Open() error {
+	tempDir, err := ioutil.TempDir(s.dir, "temp-set")
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary directory")
+	}
+	s.tempDir = tempDir
+	s.memory = make(map[string]struct{})
+	s.preloaded = make(map[string]bool)
+	return nil
+}
+
+// Add adds a key to TempSet.
+func (s *diskTempSet) Add(key string) error {
+	s.memory[key] = struct{}{}
+	delete(s.preloaded, key)
+	if len(s.memory) < s.memoryKeys {
+		return nil
+	}
+	return s.spill()
+}
+
+// Preload checks which of the given keys exist in spill files, in a sorted
+// order to read blocks of spill files sequentially. Results are used by Exist
+// until the next call to Preload.
+func (s *diskTempSet) Preload(keys []string) error {
+	s.preloaded = make(map[string]bool, len(keys))
+	if len(s.files) == 0 {
+		return nil
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := s.memory[key]; !ok {
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		exists, err := s.existOnDisk(key)
+		if err != nil {
+			return err
+		}
+		s.preloaded[key] = exists
+	}
+	return nil
+}
+
+// Exist check if the key exists in a TempSet.
+func (s *diskTempSet) Exist(key string) (bool, error) {
+	if _, ok := s.memory[key]; ok {
+		return true, nil
+	}
+	if exists, ok := s.preloaded[key]; ok {
+		return exists, nil
+	}
+	return s.existOnDisk(key)
+}
+
+func (s *diskTempSet) existOnDisk(key string) (bool, error) {
+	// Newest files first, they are the smallest.
+	for i := len(s.files) - 1; i >= 0; i-- {
+		exists, err := s.files[i].exist(key)
+		if err != nil {
+			return false, errors.Wrap(err, "error reading spill file")
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// spill writes all the keys kept in memory to a new spill file.
+func (s *diskTempSet) spill() error {
+	keys := make([]string, 0, len(s.memory))
+	for key := range s.memory {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	i := 0
+	file, err := s.writeSpillFile(len(keys), func() (string, bool, error) {
+		if i == len(keys) {
+			return "", false, nil
+		}
+		i++
+		return keys[i-1], true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.files = append(s.files, file)
+	s.memory = make(map[string]struct{})
+	if len(s.files) > maxSpillFiles {
+		return s.merge()
+	}
+	return nil
+}
+
+// merge merges all spill files into a single one.
+func (s *diskTempSet) merge() error {
+	var (
+		readers spillReaderHeap
+		keys    int
+	)
+	for _, file := range s.files {
+		reader := &spillReader{
+			r: bufio.NewReader(io.NewSectionReader(file.file, 0, file.size)),
+		}
+		ok, err := reader.next()
+		if err != nil {
+			return errors.Wrap(err, "error reading spill file")
+		}
+		if ok {
+			readers = append(readers, reader)
+		}
+		keys += file.keys
+	}
+	heap.Init(&readers)
+
+	var last *string
+	file, err := s.writeSpillFile(keys, func() (string, bool, error) {
+		for readers.Len() > 0 {
+			reader := readers[0]
+			key := reader.key
+			ok, err := reader.next()
+			if err != nil {
+				return "", false, errors.Wrap(err, "error reading spill file")
+			}
+			if ok {
+				heap.Fix(&readers, 0)
+			} else {
+				heap.Pop(&readers)
+			}
+			// The same key can be added multiple times in different files.
+			if last == nil || key != *last {
+				last = &key
+				return key, true, nil
+			}
+		}
+		return "", false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.closeFiles(); err != nil {
+		return err
+	}
+	s.files = []*spillFile{file}
+	return nil
+}
+
+// writeSpillFile writes sorted keys returned by next to a new spill file.
+// expectedKeys is used to size the bloom filter.
+func (s *diskTempSet) writeSpillFile(expectedKeys int, next func() (string, bool, error)) (*spillFile, error) {
+	f, err := ioutil.TempFile(s.tempDir, "spill")
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating spill file")
+	}
+
+	file := &spillFile{file: f, bloom: newBloomFilter(expectedKeys)}
+	w := bufio.NewWriter(f)
+	lengthBuf := make([]byte, binary.MaxVarintLen64)
+	for {
+		var (
+			key string
+			ok  bool
+		)
+		key, ok, err = next()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		if file.keys%spillIndexInterval == 0 {
+			file.indexKeys = append(file.indexKeys, key)
+			file.indexOffsets = append(file.indexOffsets, file.size)
+		}
+		n := binary.PutUvarint(lengthBuf, uint64(len(key)))
+		if _, err = w.Write(lengthBuf[:n]); err != nil {
+			f.Close()
+			return nil, errors.Wrap(err, "error writing spill file")
+		}
+		if _, err = w.WriteString(key); err != nil {
+			f.Close()
+			return nil, errors.Wrap(err, "error writing spill file")
+		}
+		file.size += int64(n + len(key))
+		file.keys++
+		file.bloom.add(key)
+	}
+
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "error writing spill file")
+	}
+	return file, nil
+}
+
+func (s *diskTempSet) closeFiles() error {
+	for _, file := range s.files {
+		if err := file.file.Close(); err != nil {
+			return errors.Wrap(err, "error closing spill file")
+		}
+		if err := os.Remove(file.file.Name()); err != nil {
+			return errors.Wrap(err, "error removing spill file")
+		}
+	}
+	s.files = nil
+	return nil
+}
+
+// Close removes all spill files and references to internal data structures.
+func (s *diskTempSet) Close() error {
+	s.memory = nil
+	s.preloaded = nil
+	err := s.closeFiles()
+	if removeErr := os.RemoveAll(s.tempDir); removeErr != nil && err == nil {
+		err = errors.Wrap(removeErr, "error removing temporary directory")
+	}
+	return err
+}
+
+// spillFile is a file containing sorted, length-prefixed keys.
+type spillFile struct {
+	file  *os.File
+	size  int64
+	keys  int
+	bloom *bloomFilter
+	// indexKeys contains every spillIndexInterval-th key and indexOffsets
+	// their offsets in the file.
+	indexKeys    []string
+	indexOffsets []int64
+}
+
+func (f *spillFile) exist(key string) (bool, error) {
+	if !f.bloom.mayContain(key) {
+		return false, nil
+	}
+
+	// Find the last block starting with a key <= the given key.
+	i := sort.Search(len(f.indexKeys), func(i int) bool {
+		return f.indexKeys[i] > key
+	}) - 1
+	if i < 0 {
+		return false, nil
+	}
+
+	end := f.size
+	if i+1 < len(f.indexOffsets) {
+		end = f.indexOffsets[i+1]
+	}
+	block := make([]byte, end-f.indexOffsets[i])
+	if _, err := f.file.ReadAt(block, f.indexOffsets[i]); err != nil {
+		return false, err
+	}
+
+	for len(block) > 0 {
+		length, n := binary.Uvarint(block)
+		if n <= 0 || uint64(len(block)-n) < length {
+			return false, errors.New("corrupted spill file")
+		}
+		current := string(block[n : n+int(length)])
+		if current == key {
+			return true, nil
+		}
+		if current > key {
+			break
+		}
+		block = block[n+int(length):]
+	}
+	return false, nil
+}
+
+// spillReader reads keys from a spill file sequentially.
+type spillReader struct {
+	r   *bufio.Reader
+	key string
+}
+
+func (r *spillReader) next() (bool, error) {
+	length, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	buf := make([]byte, length)
+	if _, err = io.ReadFull(r.r, buf); err != nil {
+		return false, err
+	}
+	r.key = string(buf)
+	return true, nil
+}
+
+// spillReaderHeap is a min-heap of spill readers ordered by the current key.
+type spillReaderHeap []*spillReader
+
+func (h spillReaderHeap) Len() int            { return len(h) }
+func (h spillReaderHeap) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h spillReaderHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *spillReaderHeap) Push(x interface{}) { *h = append(*h, x.(*spillReader)) }
+func (h *spillReaderHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+type bloomFilter struct {
+	bits []uint64
+	m    uint64
+}
+
+func newBloomFilter(keys int) *bloomFilter {
+	m := uint64(keys) * bloomFilterBitsPerKey
+	if m < 64 {
+		m = 64
+	}
+	return &bloomFilter{bits: make([]uint64, (m+63)/64), m: m}
+}
+
+// bloomHashes returns two 32-bit halves of the FNV-1a hash of the key used to
+// generate bloomFilterHashes hashes (Kirsch-Mitzenmacher).
+func bloomHashes(key string) (uint64, uint64) {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= prime64
+	}
+	return h & 0xffffffff, h >> 32
+}
+
+func (b *bloomFilter) add(key string) {
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (b *bloomFilter) mayContain(key string) bool {
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
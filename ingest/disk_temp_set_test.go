@@ -0,0 +1,76 @@
+package ingest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskTempSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disk-temp-set")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newDiskTempSet(dir, 10)
+	require.NoError(t, s.Open())
+
+	// Spill and merge files multiple times. Every key is added twice to
+	// test deduplication when merging.
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, s.Add(fmt.Sprintf("key-%d", i)))
+		require.NoError(t, s.Add(fmt.Sprintf("key-%d", i/2)))
+	}
+	assert.True(t, len(s.files) > 0)
+	assert.True(t, len(s.files) <= maxSpillFiles)
+	assert.True(t, len(s.memory) < 10)
+
+	for i := 0; i < 1000; i++ {
+		v, err := s.Exist(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		require.True(t, v, "key-%d", i)
+
+		v, err = s.Exist(fmt.Sprintf("other-%d", i))
+		require.NoError(t, err)
+		require.False(t, v, "other-%d", i)
+	}
+
+	// Preload
+	require.NoError(t, s.Preload([]string{"key-1", "key-999", "other-1", "other-2"}))
+	v, err := s.Exist("key-999")
+	assert.NoError(t, err)
+	assert.True(t, v)
+	v, err = s.Exist("other-1")
+	assert.NoError(t, err)
+	assert.False(t, v)
+
+	// Adding a preloaded key updates the result.
+	require.NoError(t, s.Add("other-1"))
+	v, err = s.Exist("other-1")
+	assert.NoError(t, err)
+	assert.True(t, v)
+
+	tempDir := s.tempDir
+	require.NoError(t, s.Close())
+	_, err = os.Stat(tempDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBloomFilter(t *testing.T) {
+	b := newBloomFilter(1000)
+	for i := 0; i < 1000; i++ {
+		b.add(fmt.Sprintf("key-%d", i))
+	}
+
+	falsePositives := 0
+	for i := 0; i < 1000; i++ {
+		assert.True(t, b.mayContain(fmt.Sprintf("key-%d", i)))
+		if b.mayContain(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	assert.True(t, falsePositives < 50, "too many false positives: %d", falsePositives)
+}
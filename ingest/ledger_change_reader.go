@@ -1,6 +1,7 @@
 package ingest
 
 import (
+	"context"
 	"io"
 
 	"github.com/stellar/go/ingest/ledgerbackend"
@@ -57,6 +58,20 @@ func NewLedgerChangeReader(backend ledgerbackend.LedgerBackend, networkPassphras
 	}, nil
 }
 
+// NewLedgerChangeReaderContext is like NewLedgerChangeReader but stops waiting
+// for the ledger when the context is done.
+func NewLedgerChangeReaderContext(ctx context.Context, backend ledgerbackend.LedgerBackend, networkPassphrase string, sequence uint32) (*LedgerChangeReader, error) {
+	transactionReader, err := NewLedgerTransactionReaderContext(ctx, backend, networkPassphrase, sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerChangeReader{
+		LedgerTransactionReader: transactionReader,
+		state:                   feeChangesState,
+	}, nil
+}
+
 // Read returns the next change in the stream.
 // If there are no changes remaining io.EOF is returned as an error.
 func (r *LedgerChangeReader) Read() (Change, error) {
@@ -1,6 +1,7 @@
 package ingest
 
 import (
+	"context"
 	"encoding/hex"
 	"io"
 
@@ -26,12 +27,27 @@ func NewLedgerTransactionReader(backend ledgerbackend.LedgerBackend, networkPass
 		return nil, errors.Wrap(err, "error getting ledger from the backend")
 	}
 
+	return newLedgerTransactionReader(exists, ledgerCloseMeta, networkPassphrase)
+}
+
+// NewLedgerTransactionReaderContext is like NewLedgerTransactionReader but
+// stops waiting for the ledger when the context is done.
+func NewLedgerTransactionReaderContext(ctx context.Context, backend ledgerbackend.LedgerBackend, networkPassphrase string, sequence uint32) (*LedgerTransactionReader, error) {
+	exists, ledgerCloseMeta, err := backend.GetLedgerContext(ctx, sequence)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting ledger from the backend")
+	}
+
+	return newLedgerTransactionReader(exists, ledgerCloseMeta, networkPassphrase)
+}
+
+func newLedgerTransactionReader(exists bool, ledgerCloseMeta xdr.LedgerCloseMeta, networkPassphrase string) (*LedgerTransactionReader, error) {
 	if !exists {
 		return nil, ErrNotFound
 	}
 
 	reader := &LedgerTransactionReader{ledgerCloseMeta: ledgerCloseMeta}
-	if err = reader.storeTransactions(ledgerCloseMeta, networkPassphrase); err != nil {
+	if err := reader.storeTransactions(ledgerCloseMeta, networkPassphrase); err != nil {
 		return nil, errors.Wrap(err, "error extracting transactions from ledger close meta")
 	}
 	return reader, nil
@@ -161,13 +161,14 @@ func NewCaptive(config CaptiveCoreConfig) (*CaptiveStellarCore, error) {
 	var cancel context.CancelFunc
 	config.Context, cancel = context.WithCancel(parentCtx)
 
-	archivePool, err := historyarchive.NewArchivePool(
+	archivePool, err := historyarchive.NewRetryingArchivePool(
 		config.HistoryArchiveURLs,
 		historyarchive.ConnectOptions{
 			NetworkPassphrase:   config.NetworkPassphrase,
 			CheckpointFrequency: config.CheckpointFrequency,
 			Context:             config.Context,
 		},
+		historyarchive.ArchivePoolOptions{},
 	)
 
 	if err != nil {
@@ -176,7 +177,7 @@ func NewCaptive(config CaptiveCoreConfig) (*CaptiveStellarCore, error) {
 	}
 
 	c := &CaptiveStellarCore{
-		archive:           &archivePool,
+		archive:           archivePool,
 		ledgerHashStore:   config.LedgerHashStore,
 		cancel:            cancel,
 		checkpointManager: historyarchive.NewCheckpointManager(config.CheckpointFrequency),
@@ -197,11 +198,14 @@ func (c *CaptiveStellarCore) getLatestCheckpointSequence() (uint32, error) {
 	return has.CurrentLedger, nil
 }
 
-func (c *CaptiveStellarCore) openOfflineReplaySubprocess(from, to uint32) error {
+func (c *CaptiveStellarCore) openOfflineReplaySubprocess(ctx context.Context, from, to uint32) error {
 	latestCheckpointSequence, err := c.getLatestCheckpointSequence()
 	if err != nil {
 		return errors.Wrap(err, "error getting latest checkpoint sequence")
 	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
 
 	if from > latestCheckpointSequence {
 		return errors.Errorf(
@@ -224,7 +228,12 @@ func (c *CaptiveStellarCore) openOfflineReplaySubprocess(from, to uint32) error
 		c.stellarCoreRunner = runner
 	}
 
+	stop := closeRunnerOnDone(ctx, runner)
 	err = c.stellarCoreRunner.catchup(from, to)
+	stop()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
 	if err != nil {
 		return errors.Wrap(err, "error running stellar-core")
 	}
@@ -239,11 +248,14 @@ func (c *CaptiveStellarCore) openOfflineReplaySubprocess(from, to uint32) error
 	return nil
 }
 
-func (c *CaptiveStellarCore) openOnlineReplaySubprocess(from uint32) error {
+func (c *CaptiveStellarCore) openOnlineReplaySubprocess(ctx context.Context, from uint32) error {
 	latestCheckpointSequence, err := c.getLatestCheckpointSequence()
 	if err != nil {
 		return errors.Wrap(err, "error getting latest checkpoint sequence")
 	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
 
 	// We don't allow starting the online mode starting with more than two
 	// checkpoints from now. Such requests are likely buggy.
@@ -271,8 +283,16 @@ func (c *CaptiveStellarCore) openOnlineReplaySubprocess(from uint32) error {
 	if err != nil {
 		return errors.Wrap(err, "error calculating ledger and hash for stelar-core run")
 	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
 
+	stop := closeRunnerOnDone(ctx, runner)
 	err = c.stellarCoreRunner.runFrom(runFrom, ledgerHash)
+	stop()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
 	if err != nil {
 		return errors.Wrap(err, "error running stellar-core")
 	}
@@ -355,7 +375,26 @@ func (c *CaptiveStellarCore) runFromParams(from uint32) (runFrom uint32, ledgerH
 	return
 }
 
-func (c *CaptiveStellarCore) startPreparingRange(ledgerRange Range) (bool, error) {
+// closeRunnerOnDone closes the runner when the context is done, until the
+// returned function is called. It's used to interrupt starting Stellar-Core.
+func closeRunnerOnDone(ctx context.Context, runner stellarCoreRunnerInterface) func() {
+	stop := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			runner.close()
+		case <-stop:
+		}
+	}()
+	return func() {
+		close(stop)
+		<-exited
+	}
+}
+
+func (c *CaptiveStellarCore) startPreparingRange(ctx context.Context, ledgerRange Range) (bool, error) {
 	c.stellarCoreLock.Lock()
 	defer c.stellarCoreLock.Unlock()
 
@@ -371,9 +410,15 @@ func (c *CaptiveStellarCore) startPreparingRange(ledgerRange Range) (bool, error
 
 	var err error
 	if ledgerRange.bounded {
-		err = c.openOfflineReplaySubprocess(ledgerRange.from, ledgerRange.to)
+		err = c.openOfflineReplaySubprocess(ctx, ledgerRange.from, ledgerRange.to)
 	} else {
-		err = c.openOnlineReplaySubprocess(ledgerRange.from)
+		err = c.openOnlineReplaySubprocess(ctx, ledgerRange.from)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if c.stellarCoreRunner != nil {
+			c.stellarCoreRunner.close()
+		}
+		return false, ctxErr
 	}
 	if err != nil {
 		return false, errors.Wrap(err, "opening subprocess")
@@ -392,7 +437,25 @@ func (c *CaptiveStellarCore) startPreparingRange(ledgerRange Range) (bool, error
 // Please note that using a BoundedRange, currently, requires a full-trust on
 // history archive. This issue is being fixed in Stellar-Core.
 func (c *CaptiveStellarCore) PrepareRange(ledgerRange Range) error {
-	if alreadyPrepared, err := c.startPreparingRange(ledgerRange); err != nil {
+	return c.PrepareRangeContext(context.Background(), ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but it can be cancelled using the
+// context. Starting Stellar-Core (including commands run before catchup) and
+// waiting for the first ledger in the range are interrupted when the context
+// is done, requests to history archives are not but the context is checked
+// after them. When preparing is cancelled, Stellar-Core is stopped and the
+// context error is returned. The backend is not closed so PrepareRange can be
+// called again, it starts preparing the range from scratch.
+func (c *CaptiveStellarCore) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if alreadyPrepared, err := c.startPreparingRange(ctx, ledgerRange); err != nil {
+		if err == ctx.Err() {
+			return err
+		}
 		return errors.Wrap(err, "error starting prepare range")
 	} else if alreadyPrepared {
 		return nil
@@ -400,9 +463,20 @@ func (c *CaptiveStellarCore) PrepareRange(ledgerRange Range) error {
 
 	old := c.blocking
 	c.blocking = true
-	_, _, err := c.GetLedger(ledgerRange.from)
+	_, _, err := c.GetLedgerContext(ctx, ledgerRange.from)
 	c.blocking = old
 
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		// Stop the partially fast-forwarded Stellar-Core so that the range
+		// is not reported as prepared.
+		c.stellarCoreLock.RLock()
+		runner := c.stellarCoreRunner
+		c.stellarCoreLock.RUnlock()
+		if runner != nil {
+			runner.close()
+		}
+		return ctxErr
+	}
 	if err != nil {
 		return errors.Wrapf(err, "Error fast-forwarding to %d", ledgerRange.from)
 	}
@@ -470,6 +544,13 @@ func (c *CaptiveStellarCore) isPrepared(ledgerRange Range) bool {
 //     the first argument equal false.
 // This is done to provide maximum performance when streaming old ledgers.
 func (c *CaptiveStellarCore) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return c.GetLedgerContext(context.Background(), sequence)
+}
+
+// GetLedgerContext is like GetLedger but stops waiting for the ledger and
+// returns ctx.Err() when the context is done. The stream is not interrupted
+// so GetLedger can be called again with the same sequence.
+func (c *CaptiveStellarCore) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
 	c.stellarCoreLock.RLock()
 	defer c.stellarCoreLock.RUnlock()
 
@@ -506,7 +587,15 @@ func (c *CaptiveStellarCore) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMe
 			return false, xdr.LedgerCloseMeta{}, nil
 		}
 
-		result, ok := <-c.stellarCoreRunner.getMetaPipe()
+		var (
+			result metaResult
+			ok     bool
+		)
+		select {
+		case result, ok = <-c.stellarCoreRunner.getMetaPipe():
+		case <-ctx.Done():
+			return false, xdr.LedgerCloseMeta{}, ctx.Err()
+		}
 		if errOut = c.checkMetaPipeResult(result, ok); errOut != nil {
 			break
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -603,6 +604,170 @@ func TestCaptiveGetLedger(t *testing.T) {
 	mockRunner.AssertExpectations(t)
 }
 
+func TestCaptiveGetLedgerContextCancelled(t *testing.T) {
+	metaChan := make(chan metaResult, 300)
+	for i := 64; i <= 65; i++ {
+		meta := buildLedgerCloseMeta(testLedgerHeader{sequence: uint32(i)})
+		metaChan <- metaResult{
+			LedgerCloseMeta: &meta,
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRunner := &stellarCoreRunnerMock{}
+	mockRunner.On("catchup", uint32(65), uint32(67)).Return(nil)
+	mockRunner.On("getMetaPipe").Return((<-chan metaResult)(metaChan))
+	mockRunner.On("context").Return(ctx)
+
+	mockArchive := &historyarchive.MockArchive{}
+	mockArchive.
+		On("GetRootHAS").
+		Return(historyarchive.HistoryArchiveState{
+			CurrentLedger: uint32(200),
+		}, nil)
+
+	captiveBackend := CaptiveStellarCore{
+		archive: mockArchive,
+		stellarCoreRunnerFactory: func(_ stellarCoreRunnerMode) (stellarCoreRunnerInterface, error) {
+			return mockRunner, nil
+		},
+		checkpointManager: historyarchive.NewCheckpointManager(64),
+	}
+
+	cancelledCtx, cancelRequest := context.WithCancel(context.Background())
+	cancelRequest()
+	assert.Equal(t, context.Canceled, captiveBackend.PrepareRangeContext(cancelledCtx, BoundedRange(65, 67)))
+
+	assert.NoError(t, captiveBackend.PrepareRange(BoundedRange(65, 67)))
+
+	// Ledger 66 is not in the meta pipe yet.
+	_, _, err := captiveBackend.GetLedgerContext(cancelledCtx, 66)
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, captiveBackend.isClosed())
+
+	meta66 := buildLedgerCloseMeta(testLedgerHeader{sequence: 66})
+	metaChan <- metaResult{LedgerCloseMeta: &meta66}
+
+	exists, meta, err := captiveBackend.GetLedgerContext(context.Background(), 66)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(66), meta.LedgerSequence())
+
+	meta67 := buildLedgerCloseMeta(testLedgerHeader{sequence: 67})
+	metaChan <- metaResult{LedgerCloseMeta: &meta67}
+
+	// closes after last ledger is consumed
+	mockRunner.On("close").Return(nil).Run(func(args mock.Arguments) {
+		cancel()
+	}).Once()
+	_, _, err = captiveBackend.GetLedger(67)
+	assert.NoError(t, err)
+	assert.True(t, captiveBackend.isClosed())
+
+	mockArchive.AssertExpectations(t)
+	mockRunner.AssertExpectations(t)
+}
+
+func TestCaptivePrepareRangeContextCancelledFastForward(t *testing.T) {
+	metaChan := make(chan metaResult, 300)
+	meta64 := buildLedgerCloseMeta(testLedgerHeader{sequence: 64})
+	metaChan <- metaResult{LedgerCloseMeta: &meta64}
+
+	runnerCtx, cancelRunner := context.WithCancel(context.Background())
+	mockRunner := &stellarCoreRunnerMock{}
+	mockRunner.On("catchup", uint32(65), uint32(67)).Return(nil).Once()
+	mockRunner.On("getMetaPipe").Return((<-chan metaResult)(metaChan))
+	mockRunner.On("context").Return(runnerCtx)
+	mockRunner.On("close").Return(nil).Run(func(args mock.Arguments) {
+		cancelRunner()
+	})
+
+	nextMetaChan := make(chan metaResult, 300)
+	for i := 64; i <= 65; i++ {
+		meta := buildLedgerCloseMeta(testLedgerHeader{sequence: uint32(i)})
+		nextMetaChan <- metaResult{LedgerCloseMeta: &meta}
+	}
+	nextRunner := &stellarCoreRunnerMock{}
+	nextRunner.On("catchup", uint32(65), uint32(67)).Return(nil).Once()
+	nextRunner.On("getMetaPipe").Return((<-chan metaResult)(nextMetaChan))
+	nextRunner.On("context").Return(context.Background())
+
+	mockArchive := &historyarchive.MockArchive{}
+	mockArchive.
+		On("GetRootHAS").
+		Return(historyarchive.HistoryArchiveState{
+			CurrentLedger: uint32(200),
+		}, nil)
+
+	runners := []stellarCoreRunnerInterface{mockRunner, nextRunner}
+	captiveBackend := CaptiveStellarCore{
+		archive: mockArchive,
+		stellarCoreRunnerFactory: func(_ stellarCoreRunnerMode) (stellarCoreRunnerInterface, error) {
+			runner := runners[0]
+			runners = runners[1:]
+			return runner, nil
+		},
+		checkpointManager: historyarchive.NewCheckpointManager(64),
+	}
+
+	// Ledger 65 is never streamed by the first runner.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, captiveBackend.PrepareRangeContext(ctx, BoundedRange(65, 67)))
+	assert.True(t, captiveBackend.isClosed())
+	prepared, err := captiveBackend.IsPrepared(BoundedRange(65, 67))
+	assert.NoError(t, err)
+	assert.False(t, prepared)
+
+	// PrepareRange starts a new Stellar-Core instead of reporting the range
+	// as prepared.
+	assert.NoError(t, captiveBackend.PrepareRange(BoundedRange(65, 67)))
+	assert.Empty(t, runners)
+
+	mockArchive.AssertExpectations(t)
+	mockRunner.AssertExpectations(t)
+	nextRunner.AssertExpectations(t)
+}
+
+func TestCaptivePrepareRangeContextCancelledCatchup(t *testing.T) {
+	closed := make(chan struct{})
+	runnerCtx, cancelRunner := context.WithCancel(context.Background())
+	mockRunner := &stellarCoreRunnerMock{}
+	// catchup blocks (ex. running new-db) until the runner is closed
+	mockRunner.On("catchup", uint32(65), uint32(67)).Return(errors.New("signal: killed")).Run(func(args mock.Arguments) {
+		<-closed
+	}).Once()
+	mockRunner.On("close").Return(nil).Run(func(args mock.Arguments) {
+		if runnerCtx.Err() == nil {
+			cancelRunner()
+			close(closed)
+		}
+	})
+
+	mockArchive := &historyarchive.MockArchive{}
+	mockArchive.
+		On("GetRootHAS").
+		Return(historyarchive.HistoryArchiveState{
+			CurrentLedger: uint32(200),
+		}, nil)
+
+	captiveBackend := CaptiveStellarCore{
+		archive: mockArchive,
+		stellarCoreRunnerFactory: func(_ stellarCoreRunnerMode) (stellarCoreRunnerInterface, error) {
+			return mockRunner, nil
+		},
+		checkpointManager: historyarchive.NewCheckpointManager(64),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, captiveBackend.PrepareRangeContext(ctx, BoundedRange(65, 67)))
+	assert.True(t, captiveBackend.isClosed())
+
+	mockArchive.AssertExpectations(t)
+	mockRunner.AssertExpectations(t)
+}
+
 func TestCaptiveGetLedger_NextLedgerIsDifferentToLedgerFromBuffer(t *testing.T) {
 	metaChan := make(chan metaResult, 100)
 
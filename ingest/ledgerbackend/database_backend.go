@@ -1,6 +1,7 @@
 package ledgerbackend
 
 import (
+	"context"
 	"database/sql"
 	"sort"
 
@@ -43,7 +44,28 @@ func NewDatabaseBackendFromSession(session *db.Session, networkPassphrase string
 }
 
 func (dbb *DatabaseBackend) PrepareRange(ledgerRange Range) error {
-	fromExists, _, err := dbb.GetLedger(ledgerRange.from)
+	return dbb.prepareRange(dbb.session, ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but runs queries using the given
+// context.
+func (dbb *DatabaseBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	return dbb.prepareRange(dbb.sessionWithContext(ctx), ledgerRange)
+}
+
+// sessionWithContext returns a session running queries using the given
+// context.
+func (dbb *DatabaseBackend) sessionWithContext(ctx context.Context) session {
+	if dbSession, ok := dbb.session.(*db.Session); ok {
+		clone := dbSession.Clone()
+		clone.Ctx = ctx
+		return clone
+	}
+	return dbb.session
+}
+
+func (dbb *DatabaseBackend) prepareRange(s session, ledgerRange Range) error {
+	fromExists, _, err := dbb.getLedger(s, ledgerRange.from)
 	if err != nil {
 		return errors.Wrap(err, "error getting ledger")
 	}
@@ -53,7 +75,7 @@ func (dbb *DatabaseBackend) PrepareRange(ledgerRange Range) error {
 	}
 
 	if ledgerRange.bounded {
-		toExists, _, err := dbb.GetLedger(ledgerRange.to)
+		toExists, _, err := dbb.getLedger(s, ledgerRange.to)
 		if err != nil {
 			return errors.Wrap(err, "error getting ledger")
 		}
@@ -120,6 +142,15 @@ func sortByHash(transactions []xdr.TransactionEnvelope, passphrase string) error
 // GetLedger returns the LedgerCloseMeta for the given ledger sequence number.
 // The first returned value is false when the ledger does not exist in the database.
 func (dbb *DatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return dbb.getLedger(dbb.session, sequence)
+}
+
+// GetLedgerContext is like GetLedger but runs queries using the given context.
+func (dbb *DatabaseBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return dbb.getLedger(dbb.sessionWithContext(ctx), sequence)
+}
+
+func (dbb *DatabaseBackend) getLedger(s session, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
 	lcm := xdr.LedgerCloseMeta{
 		V0: &xdr.LedgerCloseMetaV0{},
 	}
@@ -127,7 +158,7 @@ func (dbb *DatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMet
 	// Query - ledgerheader
 	var lRow ledgerHeaderHistory
 
-	err := dbb.session.GetRaw(&lRow, ledgerHeaderQuery, sequence)
+	err := s.GetRaw(&lRow, ledgerHeaderQuery, sequence)
 	// Return errors...
 	if err != nil {
 		switch err {
@@ -148,7 +179,7 @@ func (dbb *DatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMet
 
 	// Query - txhistory
 	var txhRows []txHistory
-	err = dbb.session.SelectRaw(&txhRows, txHistoryQuery+orderBy, sequence)
+	err = s.SelectRaw(&txhRows, txHistoryQuery+orderBy, sequence)
 	// Return errors...
 	if err != nil {
 		return false, lcm, errors.Wrap(err, "Error getting txHistory")
@@ -174,7 +205,7 @@ func (dbb *DatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMet
 
 	// Query - txfeehistory
 	var txfhRows []txFeeHistory
-	err = dbb.session.SelectRaw(&txfhRows, txFeeHistoryQuery+orderBy, sequence)
+	err = s.SelectRaw(&txfhRows, txFeeHistoryQuery+orderBy, sequence)
 	// Return errors...
 	if err != nil {
 		return false, lcm, errors.Wrap(err, "Error getting txFeeHistory")
@@ -191,7 +222,7 @@ func (dbb *DatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMet
 
 	// Query - upgradehistory
 	var upgradeHistoryRows []upgradeHistory
-	err = dbb.session.SelectRaw(&upgradeHistoryRows, upgradeHistoryQuery, sequence)
+	err = s.SelectRaw(&upgradeHistoryRows, upgradeHistoryQuery, sequence)
 	// Return errors...
 	if err != nil {
 		return false, lcm, errors.Wrap(err, "Error getting upgradeHistoryRows")
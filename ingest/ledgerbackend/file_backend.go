@@ -0,0 +1,439 @@
+package ledgerbackend
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// fileShardRegexp matches the names of files containing ledgers, ex.
+// ledgers-0000000002-0000000063.xdr.gz. The numbers are the first and the last
+// ledger sequence (inclusive) stored in a file.
+var fileShardRegexp = regexp.MustCompile(`^ledgers-(\d+)-(\d+)\.xdr(\.gz)?$`)
+
+// Ensure FileBackend implements LedgerBackend
+var _ LedgerBackend = (*FileBackend)(nil)
+
+// fileShard describes a single file containing a consecutive sequence of
+// length-prefixed (framed) xdr.LedgerCloseMeta objects.
+type fileShard struct {
+	from, to uint32
+	// name is the file path for directories and the entry name for tarballs.
+	name    string
+	gzipped bool
+}
+
+func fileShardName(from, to uint32, gzipped bool) string {
+	name := fmt.Sprintf("ledgers-%010d-%010d.xdr", from, to)
+	if gzipped {
+		name += ".gz"
+	}
+	return name
+}
+
+func parseFileShard(name string) (fileShard, bool) {
+	matches := fileShardRegexp.FindStringSubmatch(path.Base(filepath.ToSlash(name)))
+	if matches == nil {
+		return fileShard{}, false
+	}
+	from, err := strconv.ParseUint(matches[1], 10, 32)
+	if err != nil {
+		return fileShard{}, false
+	}
+	to, err := strconv.ParseUint(matches[2], 10, 32)
+	if err != nil || to < from {
+		return fileShard{}, false
+	}
+	return fileShard{
+		from:    uint32(from),
+		to:      uint32(to),
+		name:    name,
+		gzipped: matches[3] != "",
+	}, true
+}
+
+// fileShardReader streams ledgers from a single shard.
+type fileShardReader struct {
+	shard   fileShard
+	r       *bufio.Reader
+	closers []io.Closer
+	// next is the sequence of the next ledger in the stream.
+	next uint32
+}
+
+func (r *fileShardReader) skip() error {
+	frameLength, err := xdr.ReadFrameLength(r.r)
+	if err != nil {
+		return errors.Wrapf(err, "error reading frame length of ledger %d", r.next)
+	}
+	if _, err = io.CopyN(ioutil.Discard, r.r, int64(frameLength)); err != nil {
+		return errors.Wrapf(err, "error skipping ledger %d", r.next)
+	}
+	r.next++
+	return nil
+}
+
+func (r *fileShardReader) read() (xdr.LedgerCloseMeta, error) {
+	var ledger xdr.LedgerCloseMeta
+	if _, err := xdr.UnmarshalFramed(r.r, &ledger); err != nil {
+		return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "error unmarshalling ledger %d", r.next)
+	}
+	if seq := ledger.LedgerSequence(); seq != r.next {
+		return xdr.LedgerCloseMeta{}, errors.Errorf(
+			"unexpected ledger sequence in %s (expected=%d actual=%d)",
+			r.shard.name,
+			r.next,
+			seq,
+		)
+	}
+	r.next++
+	return ledger, nil
+}
+
+func (r *fileShardReader) close() error {
+	var err error
+	// Close in reverse order: decompressors first, the file last.
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if closeErr := r.closers[i].Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// FileBackend is a LedgerBackend which replays xdr.LedgerCloseMeta objects
+// stored in local files. It allows reproducing ingestion deterministically
+// without stellar-core (ex. in tests or offline analysis).
+//
+// Ledgers are stored as length-prefixed XDR frames (the same format used by
+// the Stellar-Core meta pipe) in files named
+// `ledgers-<first ledger>-<last ledger>.xdr`, optionally gzip-compressed
+// (`.xdr.gz` extension). Files can be stored in a directory or a tarball
+// (`.tar`, `.tar.gz` or `.tgz`). Use FileLedgerWriter to create them.
+//
+// FileBackend supports random access but it is the most efficient when ledgers
+// are requested in an increasing order. Requesting a ledger behind the last
+// requested ledger requires reopening a file (and, for tarballs, scanning the
+// archive again).
+//
+// FileBackend is not thread-safe and should not be accessed by multiple go
+// routines.
+type FileBackend struct {
+	path    string
+	tarball bool
+	// shards are sorted by the ledger range and do not overlap.
+	shards []fileShard
+
+	preparedRange *Range
+	current       *fileShardReader
+	// cachedMeta keeps that ledger data of the last fetched ledger. Updated in GetLedger().
+	cachedMeta *xdr.LedgerCloseMeta
+}
+
+// NewFileBackend returns a new FileBackend reading ledgers from the given
+// path. The path can be a directory, a tarball or a single ledgers file.
+func NewFileBackend(filePath string) (*FileBackend, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading ledgers path")
+	}
+
+	backend := &FileBackend{path: filePath}
+	switch {
+	case info.IsDir():
+		backend.shards, err = listDirectoryShards(filePath)
+	case isTarball(filePath):
+		backend.tarball = true
+		backend.shards, err = listTarballShards(filePath)
+	default:
+		shard, ok := parseFileShard(filePath)
+		if !ok {
+			return nil, errors.Errorf("%s is not a valid ledgers file name", filePath)
+		}
+		backend.shards = []fileShard{shard}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(backend.shards) == 0 {
+		return nil, errors.Errorf("no ledger files found in %s", filePath)
+	}
+
+	sort.Slice(backend.shards, func(i, j int) bool {
+		return backend.shards[i].from < backend.shards[j].from
+	})
+	for i := 1; i < len(backend.shards); i++ {
+		if backend.shards[i].from <= backend.shards[i-1].to {
+			return nil, errors.Errorf(
+				"ledger files %s and %s overlap",
+				backend.shards[i-1].name,
+				backend.shards[i].name,
+			)
+		}
+	}
+
+	return backend, nil
+}
+
+func isTarball(filePath string) bool {
+	return strings.HasSuffix(filePath, ".tar") ||
+		strings.HasSuffix(filePath, ".tar.gz") ||
+		strings.HasSuffix(filePath, ".tgz")
+}
+
+func listDirectoryShards(dir string) ([]fileShard, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing ledgers directory")
+	}
+
+	var shards []fileShard
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if shard, ok := parseFileShard(filepath.Join(dir, file.Name())); ok {
+			shards = append(shards, shard)
+		}
+	}
+	return shards, nil
+}
+
+func listTarballShards(tarball string) ([]fileShard, error) {
+	tr, closers, err := openTarball(tarball)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAll(closers)
+
+	var shards []fileShard
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "error reading tarball")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if shard, ok := parseFileShard(header.Name); ok {
+			shards = append(shards, shard)
+		}
+	}
+	return shards, nil
+}
+
+func openTarball(tarball string) (*tar.Reader, []io.Closer, error) {
+	file, err := os.Open(tarball)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error opening tarball")
+	}
+	closers := []io.Closer{file}
+
+	var r io.Reader = file
+	if !strings.HasSuffix(tarball, ".tar") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			closeAll(closers)
+			return nil, nil, errors.Wrap(err, "error opening gzip stream")
+		}
+		closers = append(closers, gzipReader)
+		r = gzipReader
+	}
+	return tar.NewReader(r), closers, nil
+}
+
+func closeAll(closers []io.Closer) {
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i].Close()
+	}
+}
+
+func (b *FileBackend) openShard(shard fileShard) (*fileShardReader, error) {
+	var (
+		r       io.Reader
+		closers []io.Closer
+	)
+
+	if b.tarball {
+		tr, tarClosers, err := openTarball(b.path)
+		if err != nil {
+			return nil, err
+		}
+		closers = tarClosers
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				closeAll(closers)
+				return nil, errors.Errorf("%s not found in tarball", shard.name)
+			} else if err != nil {
+				closeAll(closers)
+				return nil, errors.Wrap(err, "error reading tarball")
+			}
+			if header.Name == shard.name {
+				break
+			}
+		}
+		r = tr
+	} else {
+		file, err := os.Open(shard.name)
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening ledgers file")
+		}
+		closers = []io.Closer{file}
+		r = file
+	}
+
+	if shard.gzipped {
+		gzipReader, err := gzip.NewReader(r)
+		if err != nil {
+			closeAll(closers)
+			return nil, errors.Wrapf(err, "error opening gzip stream of %s", shard.name)
+		}
+		closers = append(closers, gzipReader)
+		r = gzipReader
+	}
+
+	return &fileShardReader{
+		shard:   shard,
+		r:       bufio.NewReaderSize(r, metaPipeBufferSize),
+		closers: closers,
+		next:    shard.from,
+	}, nil
+}
+
+// findShard returns the index of the shard containing the given ledger or -1
+// if the ledger is not available.
+func (b *FileBackend) findShard(sequence uint32) int {
+	i := sort.Search(len(b.shards), func(i int) bool {
+		return b.shards[i].to >= sequence
+	})
+	if i < len(b.shards) && b.shards[i].from <= sequence {
+		return i
+	}
+	return -1
+}
+
+// GetLatestLedgerSequence returns the sequence of the latest ledger available
+// in the files.
+func (b *FileBackend) GetLatestLedgerSequence() (uint32, error) {
+	return b.shards[len(b.shards)-1].to, nil
+}
+
+// PrepareRange checks if the `from` (and `to` if the range is bounded) ledgers
+// are available and positions the stream at the `from` ledger.
+func (b *FileBackend) PrepareRange(ledgerRange Range) error {
+	return b.PrepareRangeContext(context.Background(), ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but stops fast-forwarding the
+// stream when the context is done.
+func (b *FileBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	if b.findShard(ledgerRange.from) == -1 {
+		return errors.Errorf("`from` ledger %d does not exist", ledgerRange.from)
+	}
+
+	if ledgerRange.bounded && b.findShard(ledgerRange.to) == -1 {
+		return errors.Errorf("`to` ledger %d does not exist", ledgerRange.to)
+	}
+
+	if _, _, err := b.GetLedgerContext(ctx, ledgerRange.from); err != nil {
+		return errors.Wrapf(err, "error fast-forwarding to %d", ledgerRange.from)
+	}
+
+	b.preparedRange = &ledgerRange
+	return nil
+}
+
+// IsPrepared returns true if a given ledgerRange is prepared.
+func (b *FileBackend) IsPrepared(ledgerRange Range) (bool, error) {
+	return b.preparedRange != nil && b.preparedRange.Contains(ledgerRange), nil
+}
+
+// GetLedger returns the LedgerCloseMeta for the given ledger sequence number.
+// The first returned value is false when the ledger does not exist in the files.
+func (b *FileBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return b.GetLedgerContext(context.Background(), sequence)
+}
+
+// GetLedgerContext is like GetLedger but stops skipping ledgers in a file when
+// the context is done.
+func (b *FileBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	if b.cachedMeta != nil && sequence == b.cachedMeta.LedgerSequence() {
+		// GetLedger can be called multiple times using the same sequence, ex. to create
+		// change and transaction readers. If we have this ledger buffered, let's return it.
+		return true, *b.cachedMeta, nil
+	}
+
+	i := b.findShard(sequence)
+	if i == -1 {
+		return false, xdr.LedgerCloseMeta{}, nil
+	}
+
+	if b.current == nil || b.current.shard != b.shards[i] || b.current.next > sequence {
+		if err := b.closeCurrent(); err != nil {
+			return false, xdr.LedgerCloseMeta{}, err
+		}
+		reader, err := b.openShard(b.shards[i])
+		if err != nil {
+			return false, xdr.LedgerCloseMeta{}, err
+		}
+		b.current = reader
+	}
+
+	for b.current.next < sequence {
+		if err := ctx.Err(); err != nil {
+			// The stream position is still valid so there is no need to
+			// close the current file.
+			return false, xdr.LedgerCloseMeta{}, err
+		}
+		if err := b.current.skip(); err != nil {
+			b.closeCurrent()
+			return false, xdr.LedgerCloseMeta{}, err
+		}
+	}
+
+	ledger, err := b.current.read()
+	if err != nil {
+		b.closeCurrent()
+		return false, xdr.LedgerCloseMeta{}, err
+	}
+
+	b.cachedMeta = &ledger
+	return true, ledger, nil
+}
+
+func (b *FileBackend) closeCurrent() error {
+	if b.current == nil {
+		return nil
+	}
+	err := b.current.close()
+	b.current = nil
+	if err != nil {
+		return errors.Wrap(err, "error closing ledgers file")
+	}
+	return nil
+}
+
+// Close closes the currently open file.
+func (b *FileBackend) Close() error {
+	b.preparedRange = nil
+	b.cachedMeta = nil
+	return b.closeCurrent()
+}
@@ -0,0 +1,210 @@
+package ledgerbackend
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+func testFileLedger(sequence uint32) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Hash: xdr.Hash{byte(sequence)},
+				Header: xdr.LedgerHeader{
+					LedgerSeq:          xdr.Uint32(sequence),
+					PreviousLedgerHash: xdr.Hash{byte(sequence - 1)},
+				},
+			},
+		},
+	}
+}
+
+func writeTestLedgers(t *testing.T, dir string, config FileWriterConfig, from, to uint32) {
+	writer, err := NewFileLedgerWriter(dir, config)
+	require.NoError(t, err)
+	for seq := from; seq <= to; seq++ {
+		require.NoError(t, writer.Write(testFileLedger(seq)))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func assertFileBackendLedgers(t *testing.T, backend *FileBackend, from, to uint32) {
+	latest, err := backend.GetLatestLedgerSequence()
+	assert.NoError(t, err)
+	assert.Equal(t, to, latest)
+
+	assert.NoError(t, backend.PrepareRange(BoundedRange(from, to)))
+	prepared, err := backend.IsPrepared(BoundedRange(from+1, to))
+	assert.NoError(t, err)
+	assert.True(t, prepared)
+
+	for seq := from; seq <= to; seq++ {
+		exists, ledger, err := backend.GetLedger(seq)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, testFileLedger(seq), ledger)
+	}
+
+	// Random access
+	for _, seq := range []uint32{from + 3, from + 1, to, from} {
+		exists, ledger, err := backend.GetLedger(seq)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, seq, ledger.LedgerSequence())
+	}
+
+	exists, _, err := backend.GetLedger(to + 1)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, backend.Close())
+}
+
+func TestFileBackendDirectory(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		dir, err := ioutil.TempDir("", "file-backend")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		writeTestLedgers(t, dir, FileWriterConfig{LedgersPerFile: 4, Compress: compress}, 2, 11)
+
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 3)
+		assert.Equal(t, fileShardName(2, 5, compress), files[0].Name())
+		assert.Equal(t, fileShardName(10, 11, compress), files[2].Name())
+
+		backend, err := NewFileBackend(dir)
+		require.NoError(t, err)
+		assertFileBackendLedgers(t, backend, 2, 11)
+
+		err = backend.PrepareRange(BoundedRange(1, 11))
+		assert.EqualError(t, err, "`from` ledger 1 does not exist")
+	}
+}
+
+func TestFileBackendSingleFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestLedgers(t, dir, FileWriterConfig{}, 10, 20)
+
+	backend, err := NewFileBackend(filepath.Join(dir, fileShardName(10, 20, false)))
+	require.NoError(t, err)
+	assertFileBackendLedgers(t, backend, 10, 20)
+}
+
+func TestFileBackendTarball(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ledgersDir := filepath.Join(dir, "ledgers")
+	writeTestLedgers(t, ledgersDir, FileWriterConfig{LedgersPerFile: 3, Compress: true}, 2, 10)
+
+	tarball := filepath.Join(dir, "ledgers.tar.gz")
+	file, err := os.Create(tarball)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	files, err := ioutil.ReadDir(ledgersDir)
+	require.NoError(t, err)
+	for _, info := range files {
+		contents, err := ioutil.ReadFile(filepath.Join(ledgersDir, info.Name()))
+		require.NoError(t, err)
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     "ledgers/" + info.Name(),
+			Mode:     0644,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err = tarWriter.Write(contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+
+	backend, err := NewFileBackend(tarball)
+	require.NoError(t, err)
+	assertFileBackendLedgers(t, backend, 2, 10)
+}
+
+func TestFileBackendContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestLedgers(t, dir, FileWriterConfig{}, 2, 10)
+
+	backend, err := NewFileBackend(dir)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = backend.PrepareRangeContext(ctx, BoundedRange(5, 10))
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+
+	exists, ledger, err := backend.GetLedgerContext(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(5), ledger.LedgerSequence())
+	assert.NoError(t, backend.Close())
+}
+
+func TestFileLedgerWriterOutOfOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writer, err := NewFileLedgerWriter(dir, FileWriterConfig{})
+	require.NoError(t, err)
+	assert.NoError(t, writer.Write(testFileLedger(2)))
+	assert.EqualError(
+		t,
+		writer.Write(testFileLedger(4)),
+		"unexpected ledger sequence (expected=3 actual=4)",
+	)
+	assert.NoError(t, writer.Close())
+}
+
+func TestCaptureLedgers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	source := &MockDatabaseBackend{}
+	source.On("PrepareRange", BoundedRange(5, 8)).Return(nil).Once()
+	for seq := uint32(5); seq <= 8; seq++ {
+		source.On("GetLedger", seq).Return(true, testFileLedger(seq), nil).Once()
+	}
+
+	writer, err := NewFileLedgerWriter(dir, FileWriterConfig{Compress: true})
+	require.NoError(t, err)
+	assert.NoError(t, CaptureLedgers(source, BoundedRange(5, 8), writer))
+	assert.NoError(t, writer.Close())
+	source.AssertExpectations(t)
+
+	assert.EqualError(
+		t,
+		CaptureLedgers(source, UnboundedRange(5), writer),
+		"only bounded ranges can be captured",
+	)
+
+	backend, err := NewFileBackend(dir)
+	require.NoError(t, err)
+	assertFileBackendLedgers(t, backend, 5, 8)
+}
@@ -0,0 +1,164 @@
+package ledgerbackend
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// FileWriterConfig contains the parameters used to create a FileLedgerWriter.
+type FileWriterConfig struct {
+	// LedgersPerFile is the maximum number of ledgers stored in a single file.
+	// If unset, all ledgers are written to a single file.
+	LedgersPerFile uint32
+	// Compress enables gzip compression of the files.
+	Compress bool
+}
+
+// FileLedgerWriter writes xdr.LedgerCloseMeta objects to files which can be
+// later replayed using FileBackend.
+//
+// Ledgers must be written in order, without gaps. A file is written to a
+// temporary location and moved to its final name (containing the range of
+// ledgers it holds) once it is full or the writer is closed, so a reader never
+// sees partially written files.
+type FileLedgerWriter struct {
+	dir    string
+	config FileWriterConfig
+
+	file       *os.File
+	gzipWriter *gzip.Writer
+	w          *bufio.Writer
+
+	// from is the first ledger in the currently open file.
+	from uint32
+	// next is the sequence of the next ledger expected by Write.
+	next uint32
+}
+
+// NewFileLedgerWriter returns a new FileLedgerWriter storing files in the
+// given directory. The directory is created if it does not exist.
+func NewFileLedgerWriter(dir string, config FileWriterConfig) (*FileLedgerWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "error creating ledgers directory")
+	}
+	return &FileLedgerWriter{dir: dir, config: config}, nil
+}
+
+// Write appends a ledger to the current file, rotating it if it is full.
+func (w *FileLedgerWriter) Write(ledger xdr.LedgerCloseMeta) error {
+	seq := ledger.LedgerSequence()
+	if w.next != 0 && seq != w.next {
+		return errors.Errorf("unexpected ledger sequence (expected=%d actual=%d)", w.next, seq)
+	}
+
+	if w.file != nil && w.config.LedgersPerFile > 0 && seq-w.from >= w.config.LedgersPerFile {
+		if err := w.finishFile(); err != nil {
+			return err
+		}
+	}
+
+	if w.file == nil {
+		if err := w.startFile(seq); err != nil {
+			return err
+		}
+	}
+
+	if err := xdr.MarshalFramed(w.w, ledger); err != nil {
+		return errors.Wrapf(err, "error writing ledger %d", seq)
+	}
+	w.next = seq + 1
+	return nil
+}
+
+func (w *FileLedgerWriter) startFile(from uint32) error {
+	file, err := ioutil.TempFile(w.dir, "ledgers-*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary file")
+	}
+
+	var out io.Writer = file
+	if w.config.Compress {
+		w.gzipWriter = gzip.NewWriter(file)
+		out = w.gzipWriter
+	}
+
+	w.file = file
+	w.w = bufio.NewWriter(out)
+	w.from = from
+	return nil
+}
+
+func (w *FileLedgerWriter) finishFile() error {
+	if err := w.w.Flush(); err != nil {
+		return errors.Wrap(err, "error flushing ledgers file")
+	}
+	if w.gzipWriter != nil {
+		if err := w.gzipWriter.Close(); err != nil {
+			return errors.Wrap(err, "error closing gzip stream")
+		}
+	}
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "error closing ledgers file")
+	}
+
+	name := filepath.Join(w.dir, fileShardName(w.from, w.next-1, w.config.Compress))
+	if err := os.Rename(w.file.Name(), name); err != nil {
+		return errors.Wrap(err, "error renaming ledgers file")
+	}
+
+	w.file = nil
+	w.gzipWriter = nil
+	w.w = nil
+	return nil
+}
+
+// Close finishes the currently open file.
+func (w *FileLedgerWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.finishFile()
+}
+
+// LedgerWriter is implemented by types consuming a stream of ledgers, ex.
+// FileLedgerWriter and historyarchive.CheckpointWriter.
+type LedgerWriter interface {
+	Write(ledger xdr.LedgerCloseMeta) error
+}
+
+// CaptureLedgers reads all ledgers in the given bounded range from the backend
+// and writes them using the writer. It can be used to capture ledgers from any
+// LedgerBackend (ex. captive stellar-core) so they can be replayed later using
+// FileBackend or to publish them to a history archive using
+// historyarchive.CheckpointWriter.
+func CaptureLedgers(backend LedgerBackend, ledgerRange Range, writer LedgerWriter) error {
+	if !ledgerRange.bounded {
+		return errors.New("only bounded ranges can be captured")
+	}
+
+	if err := backend.PrepareRange(ledgerRange); err != nil {
+		return errors.Wrap(err, "error preparing range")
+	}
+
+	for seq := ledgerRange.from; seq <= ledgerRange.to; seq++ {
+		exists, ledger, err := backend.GetLedger(seq)
+		if err != nil {
+			return errors.Wrapf(err, "error getting ledger %d", seq)
+		}
+		if !exists {
+			return errors.Errorf("ledger %d does not exist", seq)
+		}
+		if err = writer.Write(ledger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
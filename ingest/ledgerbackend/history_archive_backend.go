@@ -0,0 +1,230 @@
+package ledgerbackend
+
+import (
+	"context"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Ensure HistoryArchiveBackend implements LedgerBackend
+var _ LedgerBackend = (*HistoryArchiveBackend)(nil)
+
+// HistoryArchiveBackend is a LedgerBackend which builds xdr.LedgerCloseMeta
+// objects from the `ledger`, `transactions` and `results` checkpoint files
+// published in history archives. It does not require stellar-core.
+//
+// History archives do not contain transaction meta so the returned ledgers
+// contain empty meta: FeeProcessing is empty, TxApplyProcessing contains
+// TransactionMeta.V=2 without any changes and UpgradesProcessing is empty.
+// Because of this HistoryArchiveBackend can be used only by processors that
+// need ledger headers, transactions and results (ex. payment exporters) but it
+// cannot be used to build ledger state.
+//
+// Only ledgers in published checkpoints are available. Ledgers are fetched
+// from the archive one checkpoint at a time so it's the most efficient to
+// request them in an increasing order.
+//
+// HistoryArchiveBackend is not thread-safe and should not be accessed by
+// multiple go routines.
+type HistoryArchiveBackend struct {
+	archive           historyarchive.ArchiveInterface
+	checkpointManager historyarchive.CheckpointManager
+
+	preparedRange *Range
+
+	// cachedCheckpoint is the checkpoint ledger of the ledgers in cache.
+	cachedCheckpoint uint32
+	cache            map[uint32]*historyarchive.Ledger
+}
+
+// HistoryArchiveBackendConfig contains all the parameters required to create
+// a HistoryArchiveBackend instance.
+type HistoryArchiveBackendConfig struct {
+	// HistoryArchiveURLs are a list of history archive urls
+	HistoryArchiveURLs []string
+	// NetworkPassphrase is the Stellar network passphrase of the history archives
+	NetworkPassphrase string
+
+	// Optional fields
+
+	// CheckpointFrequency is the number of ledgers between checkpoints
+	// if unset, DefaultCheckpointFrequency will be used
+	CheckpointFrequency uint32
+	// Context is the (optional) context used when connecting to history
+	// archives. If Context is omitted context.Background will be used.
+	Context context.Context
+}
+
+// NewHistoryArchiveBackend returns a new HistoryArchiveBackend fetching
+// ledgers from a pool of the given history archives.
+func NewHistoryArchiveBackend(config HistoryArchiveBackendConfig) (*HistoryArchiveBackend, error) {
+	archivePool, err := historyarchive.NewRetryingArchivePool(
+		config.HistoryArchiveURLs,
+		historyarchive.ConnectOptions{
+			NetworkPassphrase:   config.NetworkPassphrase,
+			CheckpointFrequency: config.CheckpointFrequency,
+			Context:             config.Context,
+		},
+		historyarchive.ArchivePoolOptions{},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error connecting to ALL history archives.")
+	}
+
+	return NewHistoryArchiveBackendFromArchive(archivePool), nil
+}
+
+// NewHistoryArchiveBackendFromArchive returns a new HistoryArchiveBackend
+// fetching ledgers from the given archive.
+func NewHistoryArchiveBackendFromArchive(archive historyarchive.ArchiveInterface) *HistoryArchiveBackend {
+	return &HistoryArchiveBackend{
+		archive:           archive,
+		checkpointManager: archive.GetCheckpointManager(),
+	}
+}
+
+// GetLatestLedgerSequence returns the sequence of the latest checkpoint
+// ledger published in the history archive.
+func (hab *HistoryArchiveBackend) GetLatestLedgerSequence() (uint32, error) {
+	has, err := hab.archive.GetRootHAS()
+	if err != nil {
+		return 0, errors.Wrap(err, "error getting root HAS")
+	}
+	return has.CurrentLedger, nil
+}
+
+// PrepareRange checks if the `from` (and `to` if the range is bounded) ledgers
+// are published and fetches the checkpoint containing the `from` ledger.
+func (hab *HistoryArchiveBackend) PrepareRange(ledgerRange Range) error {
+	return hab.PrepareRangeContext(context.Background(), ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but aborts fetching the checkpoint
+// when the context is done.
+func (hab *HistoryArchiveBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	latest, err := hab.GetLatestLedgerSequence()
+	if err != nil {
+		return err
+	}
+
+	if ledgerRange.from > latest {
+		return errors.Errorf(
+			"`from` ledger %d is not published yet (latest=%d)",
+			ledgerRange.from,
+			latest,
+		)
+	}
+
+	if ledgerRange.bounded && ledgerRange.to > latest {
+		return errors.Errorf(
+			"`to` ledger %d is not published yet (latest=%d)",
+			ledgerRange.to,
+			latest,
+		)
+	}
+
+	exists, _, err := hab.GetLedgerContext(ctx, ledgerRange.from)
+	if err != nil {
+		return errors.Wrapf(err, "error fetching ledger %d", ledgerRange.from)
+	}
+	if !exists {
+		return errors.Errorf("`from` ledger %d does not exist", ledgerRange.from)
+	}
+
+	hab.preparedRange = &ledgerRange
+	return nil
+}
+
+// IsPrepared returns true if a given ledgerRange is prepared.
+func (hab *HistoryArchiveBackend) IsPrepared(ledgerRange Range) (bool, error) {
+	return hab.preparedRange != nil && hab.preparedRange.Contains(ledgerRange), nil
+}
+
+// GetLedger returns the LedgerCloseMeta for the given ledger sequence number.
+// The first returned value is false when the checkpoint containing the ledger
+// is not published yet.
+func (hab *HistoryArchiveBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return hab.GetLedgerContext(context.Background(), sequence)
+}
+
+// GetLedgerContext is like GetLedger but aborts downloading the checkpoint
+// files when the context is done.
+func (hab *HistoryArchiveBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	if sequence == 0 {
+		return false, xdr.LedgerCloseMeta{}, nil
+	}
+
+	checkpoint := hab.checkpointManager.GetCheckpoint(sequence)
+	if hab.cache == nil || hab.cachedCheckpoint != checkpoint {
+		exists, err := hab.archive.CategoryCheckpointExists("ledger", checkpoint)
+		if err != nil {
+			return false, xdr.LedgerCloseMeta{}, errors.Wrap(err, "error checking if checkpoint exists")
+		}
+		if !exists {
+			return false, xdr.LedgerCloseMeta{}, nil
+		}
+
+		checkpointRange := hab.checkpointManager.GetCheckpointRange(sequence)
+		ledgers, err := hab.archive.GetLedgersContext(ctx, checkpointRange.Low, checkpointRange.High)
+		if err != nil {
+			return false, xdr.LedgerCloseMeta{}, errors.Wrapf(err, "error getting ledgers of checkpoint %d", checkpoint)
+		}
+
+		hab.cache = ledgers
+		hab.cachedCheckpoint = checkpoint
+	}
+
+	ledger, ok := hab.cache[sequence]
+	if !ok {
+		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+			"ledger %d not found in checkpoint %d",
+			sequence,
+			checkpoint,
+		)
+	}
+
+	return true, ledgerCloseMetaFromArchive(ledger), nil
+}
+
+// ledgerCloseMetaFromArchive builds a LedgerCloseMeta without the meta
+// (which is not stored in history archives).
+func ledgerCloseMetaFromArchive(ledger *historyarchive.Ledger) xdr.LedgerCloseMeta {
+	txSet := ledger.Transaction.TxSet
+	if ledger.Transaction.LedgerSeq == 0 {
+		// Ledgers without transactions are not present in `transactions`
+		// checkpoint files.
+		txSet = xdr.TransactionSet{
+			PreviousLedgerHash: ledger.Header.Header.PreviousLedgerHash,
+		}
+	}
+
+	results := ledger.TransactionResult.TxResultSet.Results
+	txProcessing := make([]xdr.TransactionResultMeta, len(results))
+	for i, result := range results {
+		txProcessing[i] = xdr.TransactionResultMeta{
+			Result: result,
+			TxApplyProcessing: xdr.TransactionMeta{
+				V:  2,
+				V2: &xdr.TransactionMetaV2{},
+			},
+		}
+	}
+
+	return xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader:       ledger.Header,
+			TxSet:              txSet,
+			TxProcessing:       txProcessing,
+			UpgradesProcessing: []xdr.UpgradeEntryMeta{},
+		},
+	}
+}
+
+// Close clears the cached checkpoint.
+func (hab *HistoryArchiveBackend) Close() error {
+	hab.preparedRange = nil
+	hab.cache = nil
+	return nil
+}
@@ -0,0 +1,139 @@
+package ledgerbackend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/xdr"
+)
+
+func TestHistoryArchiveBackend(t *testing.T) {
+	txHash := xdr.Hash{1, 2, 3}
+	ledgers := map[uint32]*historyarchive.Ledger{}
+	for seq := uint32(64); seq <= 127; seq++ {
+		ledgers[seq] = &historyarchive.Ledger{
+			Header: xdr.LedgerHeaderHistoryEntry{
+				Hash: xdr.Hash{byte(seq)},
+				Header: xdr.LedgerHeader{
+					LedgerSeq:          xdr.Uint32(seq),
+					PreviousLedgerHash: xdr.Hash{byte(seq - 1)},
+				},
+			},
+		}
+	}
+	ledgers[100].Transaction = xdr.TransactionHistoryEntry{
+		LedgerSeq: 100,
+		TxSet: xdr.TransactionSet{
+			PreviousLedgerHash: xdr.Hash{99},
+			Txs:                []xdr.TransactionEnvelope{{}},
+		},
+	}
+	ledgers[100].TransactionResult = xdr.TransactionHistoryResultEntry{
+		LedgerSeq: 100,
+		TxResultSet: xdr.TransactionResultSet{
+			Results: []xdr.TransactionResultPair{{TransactionHash: txHash}},
+		},
+	}
+
+	mockArchive := &historyarchive.MockArchive{}
+	mockArchive.On("GetCheckpointManager").Return(historyarchive.NewCheckpointManager(64))
+	mockArchive.On("GetRootHAS").Return(historyarchive.HistoryArchiveState{CurrentLedger: 127}, nil)
+	mockArchive.On("CategoryCheckpointExists", "ledger", uint32(127)).Return(true, nil).Once()
+	mockArchive.On("GetLedgersContext", mock.Anything, uint32(64), uint32(127)).Return(ledgers, nil).Once()
+	mockArchive.On("CategoryCheckpointExists", "ledger", uint32(191)).Return(false, nil).Once()
+
+	backend := NewHistoryArchiveBackendFromArchive(mockArchive)
+
+	latest, err := backend.GetLatestLedgerSequence()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(127), latest)
+
+	err = backend.PrepareRange(BoundedRange(100, 200))
+	assert.EqualError(t, err, "`to` ledger 200 is not published yet (latest=127)")
+
+	assert.NoError(t, backend.PrepareRange(BoundedRange(99, 127)))
+	prepared, err := backend.IsPrepared(BoundedRange(100, 120))
+	assert.NoError(t, err)
+	assert.True(t, prepared)
+
+	exists, meta, err := backend.GetLedger(99)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(99), meta.LedgerSequence())
+	assert.Equal(t, xdr.Hash{98}, meta.V0.TxSet.PreviousLedgerHash)
+	assert.Empty(t, meta.V0.TxSet.Txs)
+	assert.Empty(t, meta.V0.TxProcessing)
+
+	exists, meta, err = backend.GetLedger(100)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Len(t, meta.V0.TxSet.Txs, 1)
+	assert.Len(t, meta.V0.TxProcessing, 1)
+	assert.Equal(t, txHash, meta.V0.TxProcessing[0].Result.TransactionHash)
+	assert.Equal(t, int32(2), meta.V0.TxProcessing[0].TxApplyProcessing.V)
+	assert.Empty(t, meta.V0.TxProcessing[0].FeeProcessing)
+
+	exists, _, err = backend.GetLedger(128)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, backend.Close())
+	mockArchive.AssertExpectations(t)
+}
+
+func TestPublishedLedgersRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Write a valid chain of ledgers to files.
+	fileWriter, err := NewFileLedgerWriter(dir, FileWriterConfig{})
+	require.NoError(t, err)
+	var prevHash xdr.Hash
+	for seq := uint32(64); seq <= 130; seq++ {
+		header := xdr.LedgerHeader{LedgerSeq: xdr.Uint32(seq), PreviousLedgerHash: prevHash}
+		hash, hashErr := historyarchive.HashXdr(&header)
+		require.NoError(t, hashErr)
+		require.NoError(t, fileWriter.Write(xdr.LedgerCloseMeta{
+			V0: &xdr.LedgerCloseMetaV0{
+				LedgerHeader: xdr.LedgerHeaderHistoryEntry{Hash: xdr.Hash(hash), Header: header},
+				TxSet:        xdr.TransactionSet{PreviousLedgerHash: prevHash},
+			},
+		}))
+		prevHash = xdr.Hash(hash)
+	}
+	require.NoError(t, fileWriter.Close())
+
+	fileBackend, err := NewFileBackend(dir)
+	require.NoError(t, err)
+	defer fileBackend.Close()
+
+	// Publish them to a history archive and replay them from the archive.
+	archive, err := historyarchive.Connect("mock://test", historyarchive.ConnectOptions{CheckpointFrequency: 64})
+	require.NoError(t, err)
+	writer := historyarchive.NewCheckpointWriter(archive, historyarchive.CheckpointWriterConfig{})
+	require.NoError(t, CaptureLedgers(fileBackend, BoundedRange(64, 130), writer))
+
+	backend := NewHistoryArchiveBackendFromArchive(archive)
+	latest, err := backend.GetLatestLedgerSequence()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(127), latest)
+
+	require.NoError(t, backend.PrepareRange(BoundedRange(64, 127)))
+	for seq := uint32(64); seq <= 127; seq++ {
+		exists, meta, getErr := backend.GetLedger(seq)
+		require.NoError(t, getErr)
+		require.True(t, exists)
+
+		_, expected, getErr := fileBackend.GetLedger(seq)
+		require.NoError(t, getErr)
+		assert.Equal(t, expected.V0.LedgerHeader, meta.V0.LedgerHeader)
+		assert.Equal(t, expected.V0.TxSet.PreviousLedgerHash, meta.V0.TxSet.PreviousLedgerHash)
+	}
+}
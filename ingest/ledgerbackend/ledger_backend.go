@@ -1,6 +1,8 @@
 package ledgerbackend
 
 import (
+	"context"
+
 	"github.com/stellar/go/xdr"
 )
 
@@ -11,10 +13,16 @@ type LedgerBackend interface {
 	GetLatestLedgerSequence() (sequence uint32, err error)
 	// The first returned value is false when the ledger does not exist in a backend.
 	GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error)
+	// GetLedgerContext is like GetLedger but it stops waiting for the ledger
+	// and returns ctx.Err() when the context is done.
+	GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error)
 	// PrepareRange prepares the given range (including from and to) to be loaded.
 	// Some backends (like captive stellar-core) need to initalize data to be
 	// able to stream ledgers. Blocks until the first ledger is available.
 	PrepareRange(ledgerRange Range) error
+	// PrepareRangeContext is like PrepareRange but it stops waiting for the
+	// first ledger and returns ctx.Err() when the context is done.
+	PrepareRangeContext(ctx context.Context, ledgerRange Range) error
 	// IsPrepared returns true if a given ledgerRange is prepared.
 	IsPrepared(ledgerRange Range) (bool, error)
 	Close() error
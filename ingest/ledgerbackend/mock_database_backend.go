@@ -1,6 +1,8 @@
 package ledgerbackend
 
 import (
+	"context"
+
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,6 +23,11 @@ func (m *MockDatabaseBackend) PrepareRange(ledgerRange Range) error {
 	return args.Error(0)
 }
 
+func (m *MockDatabaseBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	args := m.Called(ctx, ledgerRange)
+	return args.Error(0)
+}
+
 func (m *MockDatabaseBackend) IsPrepared(ledgerRange Range) (bool, error) {
 	args := m.Called(ledgerRange)
 	return args.Bool(0), args.Error(1)
@@ -31,6 +38,11 @@ func (m *MockDatabaseBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseM
 	return args.Bool(0), args.Get(1).(xdr.LedgerCloseMeta), args.Error(2)
 }
 
+func (m *MockDatabaseBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	args := m.Called(ctx, sequence)
+	return args.Bool(0), args.Get(1).(xdr.LedgerCloseMeta), args.Error(2)
+}
+
 func (m *MockDatabaseBackend) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -0,0 +1,500 @@
+package ledgerbackend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// prefetchRetryInterval defines how long a worker waits before requesting a
+// ledger again when the wrapped backend reports it is not available yet (ex.
+// in an unbounded range).
+const prefetchRetryInterval = time.Second
+
+// Ensure PrefetchingBackend implements LedgerBackend
+var _ LedgerBackend = (*PrefetchingBackend)(nil)
+
+// PrefetchingBackendConfig contains the parameters used to create a
+// PrefetchingBackend.
+type PrefetchingBackendConfig struct {
+	// BufferSize is the maximum number of ledgers fetched ahead of the ledger
+	// requested by a client (including ledgers that are currently being
+	// fetched). If unset, ledgerReadAheadBufferSize is used.
+	BufferSize uint32
+	// Workers is the number of go routines fetching ledgers. If unset, a single
+	// worker is used. Using more than one worker requires the wrapped backend
+	// to support concurrent GetLedger calls with sequences in any order (ex.
+	// DatabaseBackend). Backends streaming ledgers (like CaptiveStellarCore or
+	// RemoteCaptiveStellarCore) must be used with a single worker.
+	Workers uint32
+	// MaxBufferBytes is the (optional) memory budget of the buffer: workers
+	// stop fetching new ledgers when the size of buffered ledgers (XDR encoded)
+	// reaches it. Tracking the size requires encoding every ledger so it's
+	// disabled when unset.
+	MaxBufferBytes int64
+}
+
+// PrefetchStats contains statistics of the PrefetchingBackend buffer.
+type PrefetchStats struct {
+	// BufferedLedgers is the number of fetched ledgers waiting in the buffer.
+	BufferedLedgers int
+	// BufferedBytes is the size of the buffered ledgers (XDR encoded). It's
+	// tracked only when MaxBufferBytes is set.
+	BufferedBytes int64
+	// InFlightLedgers is the number of ledgers currently being fetched.
+	InFlightLedgers int
+	// FetchedLedgers is the total number of ledgers fetched from the wrapped
+	// backend.
+	FetchedLedgers uint64
+	// BufferHits is the number of GetLedger calls which found the requested
+	// ledger in the buffer.
+	BufferHits uint64
+	// BufferMisses is the number of GetLedger calls which had to wait for the
+	// requested ledger to be fetched.
+	BufferMisses uint64
+}
+
+type prefetchResult struct {
+	meta xdr.LedgerCloseMeta
+	size int64
+	err  error
+}
+
+// prefetchSession holds the state of workers prefetching a prepared range.
+type prefetchSession struct {
+	ledgerRange Range
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	closed      bool
+
+	// nextToFetch is the sequence of the next ledger a worker will fetch.
+	nextToFetch uint32
+	// nextToReturn is the sequence of the next ledger returned by GetLedger.
+	nextToReturn  uint32
+	inFlight      int
+	results       map[uint32]prefetchResult
+	bufferedBytes int64
+	// err is the first error returned by the wrapped backend. Workers stop
+	// fetching new ledgers once it is set. It is returned by GetLedger when
+	// the requested ledger will not be fetched anymore, ex. because the
+	// ledger which failed was skipped.
+	err error
+}
+
+// PrefetchingBackend is a LedgerBackend decorator which, once PrepareRange is
+// called, fetches ledgers ahead of the ledger requested by a client using a
+// bounded pool of workers. Ledgers are returned in order so it can wrap any
+// LedgerBackend to decouple fetching ledgers from processing them.
+//
+// Like CaptiveStellarCore, in a prepared range ledgers must be requested in a
+// non-decreasing order. Requesting a ledger ahead of the next ledger drops the
+// ledgers in between. For BoundedRange GetLedger blocks until the requested
+// ledger is fetched, for UnboundedRange it returns immediately with the first
+// argument equal false if the ledger is not fetched yet.
+//
+// PrefetchingBackend is thread-safe but the order in which ledgers are
+// returned is defined only for a single client.
+type PrefetchingBackend struct {
+	backend LedgerBackend
+	config  PrefetchingBackendConfig
+
+	// lock protects all the fields below and the state of the session.
+	lock sync.Mutex
+	// cond is signalled when the buffer changes or the session is closed.
+	cond    *sync.Cond
+	session *prefetchSession
+
+	// cachedMeta keeps that ledger data of the last fetched ledger. Updated in GetLedger().
+	cachedMeta *xdr.LedgerCloseMeta
+
+	fetchedLedgers uint64
+	bufferHits     uint64
+	bufferMisses   uint64
+}
+
+// NewPrefetchingBackend returns a new PrefetchingBackend wrapping the given
+// backend.
+func NewPrefetchingBackend(backend LedgerBackend, config PrefetchingBackendConfig) *PrefetchingBackend {
+	if config.BufferSize == 0 {
+		config.BufferSize = ledgerReadAheadBufferSize
+	}
+	if config.Workers == 0 {
+		config.Workers = 1
+	}
+
+	b := &PrefetchingBackend{
+		backend: backend,
+		config:  config,
+	}
+	b.cond = sync.NewCond(&b.lock)
+	return b
+}
+
+// GetLatestLedgerSequence returns the sequence of the latest ledger available
+// in the wrapped backend.
+func (b *PrefetchingBackend) GetLatestLedgerSequence() (uint32, error) {
+	return b.backend.GetLatestLedgerSequence()
+}
+
+// PrepareRange prepares the given range in the wrapped backend and starts
+// workers prefetching ledgers from it.
+func (b *PrefetchingBackend) PrepareRange(ledgerRange Range) error {
+	return b.PrepareRangeContext(context.Background(), ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but the context is passed to the
+// wrapped backend. The context does not affect workers started by this method,
+// use Close to stop them.
+func (b *PrefetchingBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	b.lock.Lock()
+	if b.isPrepared(ledgerRange) {
+		b.lock.Unlock()
+		return nil
+	}
+	old := b.session
+	b.closeSession()
+	b.lock.Unlock()
+
+	if old != nil {
+		old.wg.Wait()
+	}
+
+	if err := b.backend.PrepareRangeContext(ctx, ledgerRange); err != nil {
+		return errors.Wrap(err, "error preparing range in the wrapped backend")
+	}
+
+	workersCtx, cancel := context.WithCancel(context.Background())
+	session := &prefetchSession{
+		ledgerRange:  ledgerRange,
+		cancel:       cancel,
+		nextToFetch:  ledgerRange.from,
+		nextToReturn: ledgerRange.from,
+		results:      map[uint32]prefetchResult{},
+	}
+
+	b.lock.Lock()
+	b.session = session
+	b.cachedMeta = nil
+	b.lock.Unlock()
+
+	session.wg.Add(int(b.config.Workers))
+	for i := uint32(0); i < b.config.Workers; i++ {
+		go b.worker(workersCtx, session)
+	}
+	return nil
+}
+
+// IsPrepared returns true if a given ledgerRange is prepared.
+func (b *PrefetchingBackend) IsPrepared(ledgerRange Range) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.isPrepared(ledgerRange), nil
+}
+
+func (b *PrefetchingBackend) isPrepared(ledgerRange Range) bool {
+	s := b.session
+	if s == nil || s.closed || s.err != nil || !s.ledgerRange.Contains(ledgerRange) {
+		return false
+	}
+	cachedLedger := uint32(0)
+	if b.cachedMeta != nil {
+		cachedLedger = b.cachedMeta.LedgerSequence()
+	}
+	return s.nextToReturn <= ledgerRange.from || cachedLedger == ledgerRange.from
+}
+
+// GetLedger returns true when ledger is found and it's LedgerCloseMeta.
+// If PrepareRange was never called the call is passed to the wrapped backend.
+// Once the prefetch session is closed (ex. after an error) an error is
+// returned until PrepareRange is called again: workers of the closed session
+// may still be using the wrapped backend.
+func (b *PrefetchingBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return b.GetLedgerContext(context.Background(), sequence)
+}
+
+// GetLedgerContext is like GetLedger but stops waiting for the ledger to be
+// fetched when the context is done. The ledger is still fetched in the
+// background so the call can be repeated.
+func (b *PrefetchingBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.cachedMeta != nil && sequence == b.cachedMeta.LedgerSequence() {
+		// GetLedger can be called multiple times using the same sequence, ex. to create
+		// change and transaction readers. If we have this ledger buffered, let's return it.
+		return true, *b.cachedMeta, nil
+	}
+
+	s := b.session
+	if s == nil {
+		return b.backend.GetLedgerContext(ctx, sequence)
+	}
+	if s.closed {
+		return false, xdr.LedgerCloseMeta{}, errors.New("session is closed, call PrepareRange first")
+	}
+
+	if sequence < s.nextToReturn {
+		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+			"requested ledger %d is behind the prefetch buffer (expected=%d)",
+			sequence,
+			s.nextToReturn,
+		)
+	}
+
+	if s.ledgerRange.bounded && sequence > s.ledgerRange.to {
+		return false, xdr.LedgerCloseMeta{}, errors.Errorf(
+			"reading past bounded range (requested sequence=%d, last ledger in range=%d)",
+			sequence,
+			s.ledgerRange.to,
+		)
+	}
+
+	if sequence > s.nextToReturn {
+		b.skipTo(s, sequence)
+	}
+
+	result, ok := s.results[sequence]
+	if ok {
+		b.bufferHits++
+	} else {
+		b.bufferMisses++
+		if s.ledgerRange.bounded {
+			// Wake up the loop below when the context is done.
+			stop := b.broadcastOnDone(ctx)
+			defer close(stop)
+		}
+	}
+	for !ok {
+		if s.err != nil && s.inFlight == 0 {
+			// Workers stopped on an error so the requested ledger will
+			// never be fetched.
+			b.closeSession()
+			return false, xdr.LedgerCloseMeta{}, s.err
+		}
+		if !s.ledgerRange.bounded {
+			return false, xdr.LedgerCloseMeta{}, nil
+		}
+		if s.closed {
+			return false, xdr.LedgerCloseMeta{}, errors.New("session is closed, call PrepareRange first")
+		}
+		if err := ctx.Err(); err != nil {
+			return false, xdr.LedgerCloseMeta{}, err
+		}
+		b.cond.Wait()
+		result, ok = s.results[sequence]
+	}
+
+	delete(s.results, sequence)
+	s.bufferedBytes -= result.size
+	s.nextToReturn++
+	b.cond.Broadcast()
+
+	if result.err != nil {
+		// The stream is broken, stop workers so PrepareRange has to be called
+		// again.
+		b.closeSession()
+		return false, xdr.LedgerCloseMeta{}, result.err
+	}
+
+	b.cachedMeta = &result.meta
+	return true, result.meta, nil
+}
+
+// broadcastOnDone starts a go routine signalling cond when the context is done.
+// The go routine exits when the returned channel is closed.
+func (b *PrefetchingBackend) broadcastOnDone(ctx context.Context) chan struct{} {
+	stop := make(chan struct{})
+	if ctx.Done() == nil {
+		return stop
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.lock.Lock()
+			b.cond.Broadcast()
+			b.lock.Unlock()
+		case <-stop:
+		}
+	}()
+	return stop
+}
+
+// skipTo drops buffered ledgers behind the given sequence.
+func (b *PrefetchingBackend) skipTo(s *prefetchSession, sequence uint32) {
+	for seq := s.nextToReturn; seq < sequence; seq++ {
+		if result, ok := s.results[seq]; ok {
+			s.bufferedBytes -= result.size
+			delete(s.results, seq)
+		}
+	}
+	s.nextToReturn = sequence
+	if s.nextToFetch < sequence {
+		s.nextToFetch = sequence
+	}
+	b.cond.Broadcast()
+}
+
+// Stats returns statistics of the prefetch buffer.
+func (b *PrefetchingBackend) Stats() PrefetchStats {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	stats := PrefetchStats{
+		FetchedLedgers: b.fetchedLedgers,
+		BufferHits:     b.bufferHits,
+		BufferMisses:   b.bufferMisses,
+	}
+	if b.session != nil {
+		stats.BufferedLedgers = len(b.session.results)
+		stats.BufferedBytes = b.session.bufferedBytes
+		stats.InFlightLedgers = b.session.inFlight
+	}
+	return stats
+}
+
+func (b *PrefetchingBackend) worker(ctx context.Context, s *prefetchSession) {
+	defer s.wg.Done()
+
+	for {
+		sequence, ok := b.nextSequenceToFetch(s)
+		if !ok {
+			return
+		}
+
+		if !b.storeResult(s, sequence, b.fetch(ctx, s, sequence)) {
+			return
+		}
+	}
+}
+
+// nextSequenceToFetch blocks until there is space in the buffer and returns the
+// next sequence to fetch. The second returned value is false when the worker
+// should exit.
+func (b *PrefetchingBackend) nextSequenceToFetch(s *prefetchSession) (uint32, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for {
+		if s.closed || s.err != nil {
+			return 0, false
+		}
+		if s.ledgerRange.bounded && s.nextToFetch > s.ledgerRange.to {
+			return 0, false
+		}
+		if b.hasCapacity(s) {
+			break
+		}
+		b.cond.Wait()
+	}
+
+	sequence := s.nextToFetch
+	s.nextToFetch++
+	s.inFlight++
+	return sequence, true
+}
+
+func (b *PrefetchingBackend) hasCapacity(s *prefetchSession) bool {
+	if s.nextToFetch-s.nextToReturn >= b.config.BufferSize {
+		return false
+	}
+	// Always allow fetching the next ledger requested by a client to prevent
+	// blocking when a single ledger exceeds the budget.
+	return b.config.MaxBufferBytes == 0 ||
+		s.bufferedBytes < b.config.MaxBufferBytes ||
+		s.nextToFetch == s.nextToReturn
+}
+
+func (b *PrefetchingBackend) fetch(ctx context.Context, s *prefetchSession, sequence uint32) prefetchResult {
+	for {
+		exists, meta, err := b.backend.GetLedgerContext(ctx, sequence)
+		if err != nil {
+			return prefetchResult{err: errors.Wrapf(err, "error getting ledger %d", sequence)}
+		}
+
+		if exists {
+			result := prefetchResult{meta: meta}
+			if b.config.MaxBufferBytes > 0 {
+				var counter byteCounter
+				if _, err = xdr.Marshal(&counter, meta); err != nil {
+					return prefetchResult{err: errors.Wrapf(err, "error encoding ledger %d", sequence)}
+				}
+				result.size = counter.count
+			}
+			return result
+		}
+
+		if s.ledgerRange.bounded {
+			return prefetchResult{err: errors.Errorf("ledger %d does not exist", sequence)}
+		}
+
+		// The ledger is not available yet, wait and try again.
+		select {
+		case <-ctx.Done():
+			return prefetchResult{err: ctx.Err()}
+		case <-time.After(prefetchRetryInterval):
+		}
+	}
+}
+
+// storeResult adds a fetched ledger to the buffer. Returns false when the
+// worker should exit. Errors are also kept in the session so that they are
+// returned by GetLedger even if the ledger which failed is skipped.
+func (b *PrefetchingBackend) storeResult(s *prefetchSession, sequence uint32, result prefetchResult) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s.inFlight--
+	if s.closed {
+		return false
+	}
+
+	b.fetchedLedgers++
+	// Ledgers behind the next ledger were skipped by a client.
+	if sequence >= s.nextToReturn {
+		s.results[sequence] = result
+		s.bufferedBytes += result.size
+	}
+	if result.err != nil && s.err == nil {
+		s.err = result.err
+	}
+	b.cond.Broadcast()
+	return s.err == nil
+}
+
+// closeSession stops the workers of the current session. It does not wait for
+// them to exit.
+func (b *PrefetchingBackend) closeSession() {
+	if b.session == nil || b.session.closed {
+		return
+	}
+	b.session.closed = true
+	b.session.cancel()
+	b.cond.Broadcast()
+}
+
+// Close stops prefetching and closes the wrapped backend.
+func (b *PrefetchingBackend) Close() error {
+	b.lock.Lock()
+	session := b.session
+	b.closeSession()
+	b.lock.Unlock()
+
+	// Close the wrapped backend first to interrupt blocking GetLedger calls.
+	err := b.backend.Close()
+	if session != nil {
+		session.wg.Wait()
+	}
+	return err
+}
+
+type byteCounter struct {
+	count int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.count += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,240 @@
+package ledgerbackend
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// memoryBackend is a thread-safe LedgerBackend serving ledgers from a
+// contiguous range.
+type memoryBackend struct {
+	lock     sync.Mutex
+	from, to uint32
+	failAt   uint32
+	// blockAt is a ledger which is returned only when the context is done.
+	blockAt   uint32
+	requested map[uint32]int
+}
+
+func (m *memoryBackend) GetLatestLedgerSequence() (uint32, error) {
+	return m.to, nil
+}
+
+func (m *memoryBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return m.GetLedgerContext(context.Background(), sequence)
+}
+
+func (m *memoryBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	if sequence == m.blockAt {
+		<-ctx.Done()
+		return false, xdr.LedgerCloseMeta{}, ctx.Err()
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requested[sequence]++
+	if sequence == m.failAt {
+		return false, xdr.LedgerCloseMeta{}, errors.New("transient error")
+	}
+	if sequence < m.from || sequence > m.to {
+		return false, xdr.LedgerCloseMeta{}, nil
+	}
+	return true, testFileLedger(sequence), nil
+}
+
+func (m *memoryBackend) PrepareRange(ledgerRange Range) error {
+	return nil
+}
+
+func (m *memoryBackend) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	return ctx.Err()
+}
+
+func (m *memoryBackend) IsPrepared(ledgerRange Range) (bool, error) {
+	return true, nil
+}
+
+func (m *memoryBackend) Close() error {
+	return nil
+}
+
+func TestPrefetchingBackendOrdering(t *testing.T) {
+	inner := &memoryBackend{from: 2, to: 200, requested: map[uint32]int{}}
+	backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{
+		BufferSize: 10,
+		Workers:    4,
+	})
+
+	require.NoError(t, backend.PrepareRange(BoundedRange(2, 200)))
+	prepared, err := backend.IsPrepared(BoundedRange(2, 100))
+	assert.NoError(t, err)
+	assert.True(t, prepared)
+
+	for seq := uint32(2); seq <= 200; seq++ {
+		exists, meta, err := backend.GetLedger(seq)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, seq, meta.LedgerSequence())
+
+		// Calling GetLedger again with the same sequence returns cached ledger.
+		exists, meta, err = backend.GetLedger(seq)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, seq, meta.LedgerSequence())
+
+		stats := backend.Stats()
+		require.True(t, stats.BufferedLedgers+stats.InFlightLedgers <= 10)
+	}
+
+	_, _, err = backend.GetLedger(201)
+	assert.EqualError(t, err, "reading past bounded range (requested sequence=201, last ledger in range=200)")
+	_, _, err = backend.GetLedger(100)
+	assert.EqualError(t, err, "requested ledger 100 is behind the prefetch buffer (expected=201)")
+
+	assert.NoError(t, backend.Close())
+
+	stats := backend.Stats()
+	assert.Equal(t, uint64(199), stats.FetchedLedgers)
+	assert.Equal(t, uint64(199), stats.BufferHits+stats.BufferMisses)
+	for seq := uint32(2); seq <= 200; seq++ {
+		assert.Equal(t, 1, inner.requested[seq])
+	}
+}
+
+func TestPrefetchingBackendSkip(t *testing.T) {
+	inner := &memoryBackend{from: 2, to: 100, requested: map[uint32]int{}}
+	backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{
+		BufferSize:     5,
+		Workers:        2,
+		MaxBufferBytes: 1,
+	})
+
+	require.NoError(t, backend.PrepareRange(BoundedRange(2, 100)))
+	exists, meta, err := backend.GetLedger(50)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(50), meta.LedgerSequence())
+
+	exists, meta, err = backend.GetLedger(51)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(51), meta.LedgerSequence())
+
+	assert.NoError(t, backend.Close())
+}
+
+func TestPrefetchingBackendError(t *testing.T) {
+	inner := &memoryBackend{from: 2, to: 100, failAt: 5, requested: map[uint32]int{}}
+	backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{Workers: 3})
+
+	require.NoError(t, backend.PrepareRange(BoundedRange(2, 100)))
+	for seq := uint32(2); seq < 5; seq++ {
+		_, _, err := backend.GetLedger(seq)
+		require.NoError(t, err)
+	}
+	_, _, err := backend.GetLedger(5)
+	assert.EqualError(t, err, "error getting ledger 5: transient error")
+
+	prepared, err := backend.IsPrepared(BoundedRange(6, 100))
+	assert.NoError(t, err)
+	assert.False(t, prepared)
+
+	// The closed session doesn't pass calls to the wrapped backend, workers
+	// may still be using it.
+	_, _, err = backend.GetLedger(6)
+	assert.EqualError(t, err, "session is closed, call PrepareRange first")
+
+	// PrepareRange starts a new session.
+	inner.lock.Lock()
+	inner.failAt = 0
+	inner.lock.Unlock()
+	require.NoError(t, backend.PrepareRange(BoundedRange(5, 100)))
+	exists, meta, err := backend.GetLedger(5)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(5), meta.LedgerSequence())
+
+	assert.NoError(t, backend.Close())
+}
+
+func TestPrefetchingBackendErrorThenSkip(t *testing.T) {
+	for _, workers := range []uint32{1, 3} {
+		inner := &memoryBackend{from: 2, to: 100, failAt: 5, requested: map[uint32]int{}}
+		backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{Workers: workers, BufferSize: 3})
+
+		require.NoError(t, backend.PrepareRange(BoundedRange(2, 100)))
+		_, _, err := backend.GetLedger(2)
+		require.NoError(t, err)
+		// Wait until ledgers 3-5 are fetched.
+		require.Eventually(t, func() bool {
+			return backend.Stats().FetchedLedgers == 4
+		}, time.Second, time.Millisecond)
+
+		// Ledger 5 failed and workers stopped, ledger 50 will never be
+		// fetched so the error is returned instead of blocking.
+		_, _, err = backend.GetLedger(50)
+		assert.EqualError(t, err, "error getting ledger 5: transient error")
+
+		prepared, err := backend.IsPrepared(BoundedRange(50, 100))
+		assert.NoError(t, err)
+		assert.False(t, prepared)
+		assert.NoError(t, backend.Close())
+	}
+}
+
+func TestPrefetchingBackendUnbounded(t *testing.T) {
+	inner := &memoryBackend{from: 2, to: 10, requested: map[uint32]int{}}
+	backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{})
+
+	require.NoError(t, backend.PrepareRange(UnboundedRange(2)))
+	for seq := uint32(2); seq <= 10; {
+		exists, meta, err := backend.GetLedger(seq)
+		require.NoError(t, err)
+		if !exists {
+			continue
+		}
+		assert.Equal(t, seq, meta.LedgerSequence())
+		seq++
+	}
+
+	// Ledger 11 is not available yet so GetLedger does not block.
+	exists, _, err := backend.GetLedger(11)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, backend.Close())
+}
+
+func TestPrefetchingBackendContext(t *testing.T) {
+	inner := &memoryBackend{from: 2, to: 100, blockAt: 4, requested: map[uint32]int{}}
+	backend := NewPrefetchingBackend(inner, PrefetchingBackendConfig{Workers: 2})
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := backend.PrepareRangeContext(cancelledCtx, BoundedRange(2, 100))
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+
+	require.NoError(t, backend.PrepareRange(BoundedRange(2, 100)))
+	for seq := uint32(2); seq < 4; seq++ {
+		_, _, err = backend.GetLedgerContext(context.Background(), seq)
+		require.NoError(t, err)
+	}
+
+	// Ledger 4 is never fetched so GetLedgerContext returns when the context
+	// is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err = backend.GetLedgerContext(ctx, 4)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Close interrupts the worker blocked on ledger 4.
+	assert.NoError(t, backend.Close())
+}
@@ -63,10 +63,12 @@ func (r Base64Ledger) MarshalJSON() ([]byte, error) {
 
 // RemoteCaptiveStellarCore is an http client for interacting with a remote captive core server.
 type RemoteCaptiveStellarCore struct {
-	url                      *url.URL
-	client                   *http.Client
-	lock                     *sync.Mutex
-	cancel                   context.CancelFunc
+	url    *url.URL
+	client *http.Client
+	lock   *sync.Mutex
+	// cancel is shared by all copies of a RemoteCaptiveStellarCore value so
+	// that Close can cancel a PrepareRange call made on a different copy.
+	cancel                   *context.CancelFunc
 	prepareRangePollInterval time.Duration
 }
 
@@ -95,6 +97,7 @@ func NewRemoteCaptive(captiveCoreURL string, options ...RemoteCaptiveOption) (Re
 		url:                      u,
 		client:                   &http.Client{Timeout: 5 * time.Second},
 		lock:                     &sync.Mutex{},
+		cancel:                   new(context.CancelFunc),
 	}
 	for _, option := range options {
 		option(&client)
@@ -148,22 +151,22 @@ func (c RemoteCaptiveStellarCore) GetLatestLedgerSequence() (sequence uint32, er
 func (c RemoteCaptiveStellarCore) Close() error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if c.cancel != nil {
-		c.cancel()
+	if *c.cancel != nil {
+		(*c.cancel)()
 	}
 	return nil
 }
 
-func (c RemoteCaptiveStellarCore) createContext() context.Context {
+func (c RemoteCaptiveStellarCore) createContext(parent context.Context) context.Context {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if c.cancel != nil {
-		c.cancel()
+	if *c.cancel != nil {
+		(*c.cancel)()
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	c.cancel = cancel
+	ctx, cancel := context.WithCancel(parent)
+	*c.cancel = cancel
 	return ctx
 }
 
@@ -177,7 +180,13 @@ func (c RemoteCaptiveStellarCore) createContext() context.Context {
 // Please note that using a BoundedRange, currently, requires a full-trust on
 // history archive. This issue is being fixed in Stellar-Core.
 func (c RemoteCaptiveStellarCore) PrepareRange(ledgerRange Range) error {
-	ctx := c.createContext()
+	return c.PrepareRangeContext(context.Background(), ledgerRange)
+}
+
+// PrepareRangeContext is like PrepareRange but stops polling the captive core
+// server when the context is done.
+func (c RemoteCaptiveStellarCore) PrepareRangeContext(ctx context.Context, ledgerRange Range) error {
+	ctx = c.createContext(ctx)
 	u := *c.url
 	u.Path = path.Join(u.Path, "prepare-range")
 	rangeBytes, err := json.Marshal(ledgerRange)
@@ -260,10 +269,21 @@ func (c RemoteCaptiveStellarCore) IsPrepared(ledgerRange Range) (bool, error) {
 //     the first argument equal false.
 // This is done to provide maximum performance when streaming old ledgers.
 func (c RemoteCaptiveStellarCore) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return c.GetLedgerContext(context.Background(), sequence)
+}
+
+// GetLedgerContext is like GetLedger but cancels the request to the captive
+// core server when the context is done.
+func (c RemoteCaptiveStellarCore) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
 	u := *c.url
 	u.Path = path.Join(u.Path, "ledger", strconv.FormatUint(uint64(sequence), 10))
 
-	response, err := c.client.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, xdr.LedgerCloseMeta{}, errors.Wrap(err, "cannot construct http request")
+	}
+
+	response, err := c.client.Do(req)
 	if err != nil {
 		return false, xdr.LedgerCloseMeta{}, errors.Wrap(err, "failed to execute request")
 	}
@@ -415,6 +415,9 @@ func (r *stellarCoreRunner) getProcessExitError() (bool, error) {
 // the necessary cleanup on the resources associated with the captive core process
 // close is both thread safe and idempotent
 func (r *stellarCoreRunner) close() error {
+	// Cancel the context before acquiring the lock to interrupt commands
+	// started by catchup (ex. new-db) which hold the lock.
+	r.cancel()
 	r.lock.Lock()
 	started := r.started
 	storagePath := r.storagePath
@@ -426,8 +429,6 @@ func (r *stellarCoreRunner) close() error {
 		r.lock.Unlock()
 		return nil
 	}
-
-	r.cancel()
 	r.lock.Unlock()
 
 	// only reap captive core sub process and related go routines if we've started
@@ -273,6 +273,48 @@ type OrderBookSummary struct {
 	Buying  Asset        `json:"counter"`
 }
 
+// OrderBookDepth represents an aggregated view of a given order book computed
+// from the in-memory order book used for path finding
+type OrderBookDepth struct {
+	Selling  Asset                 `json:"base"`
+	Buying   Asset                 `json:"counter"`
+	MidPrice string                `json:"mid_price,omitempty"`
+	Bids     []DepthLevel          `json:"bids"`
+	Asks     []DepthLevel          `json:"asks"`
+	Within   *OrderBookDepthWithin `json:"within,omitempty"`
+	Buy      *OrderBookQuote       `json:"buy,omitempty"`
+	Sell     *OrderBookQuote       `json:"sell,omitempty"`
+}
+
+// DepthLevel represents an aggregation of offers that share a given price
+// together with the sum of amounts of offers at better prices
+type DepthLevel struct {
+	PriceR           Price  `json:"price_r"`
+	Price            string `json:"price"`
+	Amount           string `json:"amount"`
+	CumulativeAmount string `json:"cumulative_amount"`
+}
+
+// OrderBookDepthWithin represents the amounts of offers with prices within a
+// given percentage of the mid price
+type OrderBookDepthWithin struct {
+	Percentage string `json:"percentage"`
+	BidsAmount string `json:"bids_amount"`
+	AsksAmount string `json:"asks_amount"`
+}
+
+// OrderBookQuote represents the result of buying or selling a given amount of
+// the base asset directly against the order book
+type OrderBookQuote struct {
+	Amount       string `json:"amount"`
+	Total        string `json:"total"`
+	BestPrice    string `json:"best_price"`
+	WorstPrice   string `json:"worst_price"`
+	AveragePrice string `json:"average_price"`
+	PriceImpact  string `json:"price_impact"`
+	Slippage     string `json:"slippage"`
+}
+
 // Path represents a single payment path.
 type Path struct {
 	SourceAssetType        string  `json:"source_asset_type"`
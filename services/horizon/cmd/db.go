@@ -138,6 +138,7 @@ var (
 	parallelJobSize     uint32
 	retries             uint
 	retryBackoffSeconds uint
+	prefetchLedgers     uint32
 )
 var reingestRangeCmdOpts = []*support.ConfigOption{
 	{
@@ -181,6 +182,14 @@ var reingestRangeCmdOpts = []*support.ConfigOption{
 		FlagDefault: uint(5),
 		Usage:       "[optional] backoff seconds between reingest retries",
 	},
+	{
+		Name:        "prefetch-ledgers",
+		ConfigKey:   &prefetchLedgers,
+		OptType:     types.Uint32,
+		Required:    false,
+		FlagDefault: uint32(0),
+		Usage:       "[optional] number of ledgers to fetch ahead from the ledger backend while reingesting (0 disables prefetching)",
+	},
 }
 
 var dbReingestRangeCmd = &cobra.Command{
@@ -246,6 +255,7 @@ func RunDBReingestRange(from, to uint32, reingestForce bool, parallelWorkers uin
 		CheckpointFrequency:         config.CheckpointFrequency,
 		MaxReingestRetries:          int(retries),
 		ReingestRetryBackoffSeconds: int(retryBackoffSeconds),
+		PrefetchLedgers:             prefetchLedgers,
 		EnableCaptiveCore:           config.EnableCaptiveCoreIngestion,
 		CaptiveCoreBinaryPath:       config.CaptiveCoreBinaryPath,
 		RemoteCaptiveCoreURL:        config.RemoteCaptiveCoreURL,
@@ -0,0 +1,172 @@
+package actions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/exp/orderbook"
+	protocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	horizonProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+// GetOrderBookDepthHandler is the action handler for the /order_book/depth
+// endpoint. The response is computed from the in memory order book graph.
+type GetOrderBookDepthHandler struct {
+	OrderBookGraph *orderbook.OrderBookGraph
+	LedgerState    *ledger.State
+	// StaleThreshold is the number of ledgers ingestion may lag behind
+	// stellar-core before requests are rejected, 0 disables the check.
+	StaleThreshold uint
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 7, 64)
+}
+
+func convertDepthLevels(src []orderbook.DepthLevel) []protocol.DepthLevel {
+	result := make([]protocol.DepthLevel, len(src))
+	for i, level := range src {
+		result[i] = protocol.DepthLevel{
+			PriceR: protocol.Price{
+				N: int32(level.Price.N),
+				D: int32(level.Price.D),
+			},
+			Price:            level.Price.String(),
+			Amount:           amount.String(level.Amount),
+			CumulativeAmount: amount.String(level.CumulativeAmount),
+		}
+	}
+	return result
+}
+
+func convertQuote(quote orderbook.Quote) *protocol.OrderBookQuote {
+	return &protocol.OrderBookQuote{
+		Amount:       amount.String(quote.Amount),
+		Total:        amount.String(quote.Total),
+		BestPrice:    quote.BestPrice.String(),
+		WorstPrice:   quote.WorstPrice.String(),
+		AveragePrice: formatFloat(quote.AveragePrice),
+		PriceImpact:  formatFloat(quote.PriceImpact),
+		Slippage:     formatFloat(quote.Slippage),
+	}
+}
+
+// getPositiveAmount returns the amount of the given parameter or 0 if the
+// parameter is empty
+func getPositiveAmount(r *http.Request, name string) (xdr.Int64, error) {
+	value, err := getString(r, name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+	parsed, err := amount.Parse(value)
+	if err != nil || parsed <= 0 {
+		return 0, problem.MakeInvalidFieldProblem(name, errors.New("amount must be positive"))
+	}
+	return parsed, nil
+}
+
+// getPercentage returns the percentage of the given parameter or 0 if the
+// parameter is empty
+func getPercentage(r *http.Request, name string) (float64, error) {
+	value, err := getString(r, name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 || parsed > 100 {
+		return 0, problem.MakeInvalidFieldProblem(name, errors.New("percentage must be greater than 0 and at most 100"))
+	}
+	return parsed, nil
+}
+
+// GetResource implements the /order_book/depth endpoint. Besides price levels
+// the response includes the amounts of offers within `within_percentage` of
+// the mid price (computed from the returned price levels) and quotes for
+// buying and selling `amount` of the base asset, if these parameters are
+// provided. A quote is omitted if the order book can't fill the amount.
+func (handler GetOrderBookDepthHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	selling, err := getAsset(r, "selling_")
+	if err != nil {
+		return nil, invalidOrderBook
+	}
+	buying, err := getAsset(r, "buying_")
+	if err != nil {
+		return nil, invalidOrderBook
+	}
+	limit, err := getLimit(r, "limit", 20, 200)
+	if err != nil {
+		return nil, invalidOrderBook
+	}
+	quoteAmount, err := getPositiveAmount(r, "amount")
+	if err != nil {
+		return nil, err
+	}
+	percentage, err := getPercentage(r, "within_percentage")
+	if err != nil {
+		return nil, err
+	}
+
+	if handler.OrderBookGraph.IsEmpty() {
+		return nil, horizonProblem.StillIngesting
+	}
+	if handler.StaleThreshold > 0 {
+		ls := handler.LedgerState.CurrentStatus()
+		if ls.CoreLatest-ls.HistoryLatest > int32(handler.StaleThreshold) {
+			err := horizonProblem.StaleHistory
+			err.Extras = map[string]interface{}{
+				"history_latest_ledger": ls.HistoryLatest,
+				"core_latest_ledger":    ls.CoreLatest,
+			}
+			return nil, err
+		}
+	}
+
+	depth, lastLedger := handler.OrderBookGraph.FindDepth(selling, buying, int(limit))
+
+	var response protocol.OrderBookDepth
+	if err = resourceadapter.PopulateAsset(r.Context(), &response.Selling, selling); err != nil {
+		return nil, err
+	}
+	if err = resourceadapter.PopulateAsset(r.Context(), &response.Buying, buying); err != nil {
+		return nil, err
+	}
+	response.Bids = convertDepthLevels(depth.Bids)
+	response.Asks = convertDepthLevels(depth.Asks)
+	if mid, ok := depth.MidPrice(); ok {
+		response.MidPrice = formatFloat(mid)
+	}
+
+	if percentage > 0 {
+		asks, bids := depth.AmountWithin(percentage)
+		response.Within = &protocol.OrderBookDepthWithin{
+			Percentage: strconv.FormatFloat(percentage, 'f', -1, 64),
+			BidsAmount: amount.String(bids),
+			AsksAmount: amount.String(asks),
+		}
+	}
+
+	if quoteAmount > 0 {
+		buy, _, err := handler.OrderBookGraph.QuoteBuy(selling, buying, quoteAmount)
+		if err == nil {
+			response.Buy = convertQuote(buy)
+		} else if err != orderbook.ErrNotEnoughLiquidity {
+			return nil, err
+		}
+
+		sell, _, err := handler.OrderBookGraph.QuoteSell(selling, buying, quoteAmount)
+		if err == nil {
+			response.Sell = convertQuote(sell)
+		} else if err != orderbook.ErrNotEnoughLiquidity {
+			return nil, err
+		}
+	}
+
+	SetLastLedgerHeader(w, lastLedger)
+	return response, nil
+}
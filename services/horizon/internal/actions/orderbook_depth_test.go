@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/exp/orderbook"
+	protocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	horizonProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+func TestGetOrderBookDepthHandler(t *testing.T) {
+	issuer := "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	nativeAsset := xdr.MustNewNativeAsset()
+	usdAsset := xdr.MustNewCreditAsset("USD", issuer)
+
+	graph := orderbook.NewOrderBookGraph()
+	ledgerState := &ledger.State{}
+	ledgerState.SetStatus(ledger.Status{CoreLatest: 7, HistoryLatest: 7})
+	handler := GetOrderBookDepthHandler{
+		OrderBookGraph: graph,
+		LedgerState:    ledgerState,
+		StaleThreshold: 10,
+	}
+	params := map[string]string{
+		"selling_asset_type":   "native",
+		"buying_asset_type":    "credit_alphanum4",
+		"buying_asset_code":    "USD",
+		"buying_asset_issuer":  issuer,
+		"amount":               "0.0000750",
+		"within_percentage":    "10",
+		"unrelated_parameters": "ignored",
+	}
+
+	_, err := handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	assert.Equal(t, horizonProblem.StillIngesting, err)
+
+	for i, price := range []xdr.Price{{N: 1, D: 4}, {N: 1, D: 2}, {N: 1, D: 1}} {
+		graph.AddOffer(xdr.OfferEntry{
+			SellerId: xdr.MustAddress(issuer),
+			OfferId:  xdr.Int64(i + 1),
+			Selling:  nativeAsset,
+			Buying:   usdAsset,
+			Price:    price,
+			Amount:   500,
+		})
+	}
+	for i, price := range []xdr.Price{{N: 2, D: 1}, {N: 4, D: 1}} {
+		graph.AddOffer(xdr.OfferEntry{
+			SellerId: xdr.MustAddress(issuer),
+			OfferId:  xdr.Int64(i + 10),
+			Selling:  usdAsset,
+			Buying:   nativeAsset,
+			Price:    price,
+			Amount:   100,
+		})
+	}
+	require.NoError(t, graph.Apply(7))
+
+	w := httptest.NewRecorder()
+	response, err := handler.GetResource(w, makeRequest(t, params, nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "7", w.Header().Get(LastLedgerHeaderName))
+
+	depth := response.(protocol.OrderBookDepth)
+	assert.Equal(t, "native", depth.Selling.Type)
+	assert.Equal(t, "USD", depth.Buying.Code)
+	assert.Equal(t, "0.3750000", depth.MidPrice)
+	assert.Equal(t, []protocol.DepthLevel{
+		{PriceR: protocol.Price{N: 1, D: 4}, Price: "0.2500000", Amount: "0.0000500", CumulativeAmount: "0.0000500"},
+		{PriceR: protocol.Price{N: 1, D: 2}, Price: "0.5000000", Amount: "0.0000500", CumulativeAmount: "0.0001000"},
+		{PriceR: protocol.Price{N: 1, D: 1}, Price: "1.0000000", Amount: "0.0000500", CumulativeAmount: "0.0001500"},
+	}, depth.Asks)
+	assert.Equal(t, []protocol.DepthLevel{
+		{PriceR: protocol.Price{N: 1, D: 2}, Price: "0.5000000", Amount: "0.0000100", CumulativeAmount: "0.0000100"},
+		{PriceR: protocol.Price{N: 1, D: 4}, Price: "0.2500000", Amount: "0.0000100", CumulativeAmount: "0.0000200"},
+	}, depth.Bids)
+	assert.Equal(t, &protocol.OrderBookDepthWithin{
+		Percentage: "10",
+		BidsAmount: "0.0000100",
+		AsksAmount: "0.0000500",
+	}, depth.Within)
+	assert.Equal(t, &protocol.OrderBookQuote{
+		Amount:       "0.0000750",
+		Total:        "0.0000250",
+		BestPrice:    "0.2500000",
+		WorstPrice:   "0.5000000",
+		AveragePrice: "0.3333333",
+		PriceImpact:  "1.0000000",
+		Slippage:     "0.3333333",
+	}, depth.Buy)
+	// bids can't fill the amount
+	assert.Nil(t, depth.Sell)
+
+	params["amount"] = "-1"
+	_, err = handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	assert.Equal(t, "amount", err.(*problem.P).Extras["invalid_field"])
+
+	params["amount"] = ""
+	params["within_percentage"] = "101"
+	_, err = handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	assert.Equal(t, "within_percentage", err.(*problem.P).Extras["invalid_field"])
+
+	params["within_percentage"] = ""
+	response, err = handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	require.NoError(t, err)
+	depth = response.(protocol.OrderBookDepth)
+	assert.Nil(t, depth.Within)
+	assert.Nil(t, depth.Buy)
+	assert.Nil(t, depth.Sell)
+
+	// stale order books are rejected like stale history
+	ledgerState.SetStatus(ledger.Status{CoreLatest: 20, HistoryLatest: 7})
+	_, err = handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	staleErr := horizonProblem.StaleHistory
+	staleErr.Extras = map[string]interface{}{
+		"history_latest_ledger": int32(7),
+		"core_latest_ledger":    int32(20),
+	}
+	assert.Equal(t, staleErr, err)
+
+	params["buying_asset_type"] = "invalid"
+	_, err = handler.GetResource(httptest.NewRecorder(), makeRequest(t, params, nil, nil))
+	assert.Equal(t, invalidOrderBook, err)
+}
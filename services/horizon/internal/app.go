@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stellar/go/clients/stellarcore"
+	"github.com/stellar/go/exp/orderbook"
 	proto "github.com/stellar/go/protocols/stellarcore"
 	"github.com/stellar/go/services/horizon/internal/actions"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
@@ -63,6 +64,7 @@ type App struct {
 	orderBookStream *ingest.OrderBookStream
 	submitter       *txsub.System
 	paths           paths.Finder
+	orderBookGraph  *orderbook.OrderBookGraph
 	ingester        ingest.System
 	reaper          *reap.System
 	ticks           *time.Ticker
@@ -487,6 +489,7 @@ func (a *App) init() error {
 		NetworkPassphrase:     a.config.NetworkPassphrase,
 		MaxPathLength:         a.config.MaxPathLength,
 		PathFinder:            a.paths,
+		OrderBookGraph:        a.orderBookGraph,
 		PrometheusRegistry:    a.prometheusRegistry,
 		CoreGetter:            a,
 		HorizonVersion:        a.horizonVersion,
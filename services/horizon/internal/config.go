@@ -16,6 +16,16 @@ type Config struct {
 	Port               uint
 	AdminPort          uint
 
+	// HistoryArchiveBucketCachePath is the directory of the local bucket
+	// cache used when building state. The cache is disabled when empty.
+	HistoryArchiveBucketCachePath string
+	// HistoryArchiveBucketCacheSize is the maximum size of the bucket cache
+	// in MB, 0 means no limit.
+	HistoryArchiveBucketCacheSize uint
+	// IngestStateTempDir is the directory of temporary files used to reduce
+	// memory usage when building state. Disabled when empty.
+	IngestStateTempDir string
+
 	EnableCaptiveCoreIngestion  bool
 	CaptiveCoreBinaryPath       string
 	CaptiveCoreConfigAppendPath string
@@ -45,6 +55,9 @@ type Config struct {
 	SentryDSN         string
 	LogglyToken       string
 	LogglyTag         string
+	// PathFindingSnapshotFile is the path of the file the in-memory order book
+	// graph used for path finding is saved to and restored from on startup.
+	PathFindingSnapshotFile string
 	// TLSCert is a path to a certificate file to use for horizon's TLS config
 	TLSCert string
 	// TLSKey is the path to a private key file to use for horizon's TLS config
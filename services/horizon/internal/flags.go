@@ -188,6 +188,30 @@ func Flags() (*Config, support.ConfigOptions) {
 			},
 			Usage: "comma-separated list of stellar history archives to connect with",
 		},
+		&support.ConfigOption{
+			Name:        "history-archive-bucket-cache-path",
+			ConfigKey:   &config.HistoryArchiveBucketCachePath,
+			OptType:     types.String,
+			FlagDefault: "",
+			Required:    false,
+			Usage:       "directory of the local cache of history archive buckets used when building state, disabled when empty (can be shared with stellar-archivist)",
+		},
+		&support.ConfigOption{
+			Name:        "history-archive-bucket-cache-size",
+			ConfigKey:   &config.HistoryArchiveBucketCacheSize,
+			OptType:     types.Uint,
+			FlagDefault: uint(0),
+			Required:    false,
+			Usage:       "maximum size of the history archive bucket cache in MB, 0 means no limit",
+		},
+		&support.ConfigOption{
+			Name:        "ingest-state-temp-dir",
+			ConfigKey:   &config.IngestStateTempDir,
+			OptType:     types.String,
+			FlagDefault: "",
+			Required:    false,
+			Usage:       "directory of temporary files used to reduce memory usage when building state from history archives, state is kept in memory when empty",
+		},
 		&support.ConfigOption{
 			Name:        "port",
 			ConfigKey:   &config.Port,
@@ -297,6 +321,14 @@ func Flags() (*Config, support.ConfigOptions) {
 			FlagDefault: uint(3),
 			Usage:       "the maximum number of assets on the path in `/paths` endpoint, warning: increasing this value will increase /paths response time",
 		},
+		&support.ConfigOption{
+			Name:        "path-finding-snapshot-file",
+			ConfigKey:   &config.PathFindingSnapshotFile,
+			OptType:     types.String,
+			FlagDefault: "",
+			Required:    false,
+			Usage:       "file the in-memory order book used for path finding is periodically saved to and restored from on startup, so path finding is ready without rebuilding the order book from the database",
+		},
 		&support.ConfigOption{
 			Name:      "network-passphrase",
 			ConfigKey: &config.NetworkPassphrase,
@@ -15,6 +15,7 @@ import (
 	"github.com/rs/cors"
 	"github.com/stellar/throttled"
 
+	"github.com/stellar/go/exp/orderbook"
 	"github.com/stellar/go/services/horizon/internal/actions"
 	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/paths"
@@ -40,6 +41,7 @@ type RouterConfig struct {
 	NetworkPassphrase     string
 	MaxPathLength         uint
 	PathFinder            paths.Finder
+	OrderBookGraph        *orderbook.OrderBookGraph
 	PrometheusRegistry    *prometheus.Registry
 	CoreGetter            actions.CoreSettingsGetter
 	HorizonVersion        string
@@ -188,6 +190,11 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 				action:        actions.GetOrderbookHandler{},
 			},
 		)
+		r.Method(http.MethodGet, "/order_book/depth", ObjectActionHandler{actions.GetOrderBookDepthHandler{
+			OrderBookGraph: config.OrderBookGraph,
+			LedgerState:    ledgerState,
+			StaleThreshold: config.StaleThreshold,
+		}})
 	})
 
 	// account actions - /accounts/{account_id} has been created above so we
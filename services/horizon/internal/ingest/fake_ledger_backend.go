@@ -1,6 +1,8 @@
 package ingest
 
 import (
+	"context"
+
 	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/keypair"
 	logpkg "github.com/stellar/go/support/log"
@@ -20,6 +22,10 @@ func (fakeLedgerBackend) PrepareRange(r ledgerbackend.Range) error {
 	return nil
 }
 
+func (f fakeLedgerBackend) PrepareRangeContext(ctx context.Context, r ledgerbackend.Range) error {
+	return f.PrepareRange(r)
+}
+
 func (fakeLedgerBackend) IsPrepared(r ledgerbackend.Range) (bool, error) {
 	return true, nil
 }
@@ -199,6 +205,10 @@ func (f fakeLedgerBackend) GetLedger(sequence uint32) (bool, xdr.LedgerCloseMeta
 	return true, ledgerCloseMeta, nil
 }
 
+func (f fakeLedgerBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	return f.GetLedger(sequence)
+}
+
 func (fakeLedgerBackend) Close() error {
 	return nil
 }
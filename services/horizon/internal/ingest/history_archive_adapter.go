@@ -11,7 +11,8 @@ import (
 
 // historyArchiveAdapter is an adapter for the historyarchive package to read from history archives
 type historyArchiveAdapter struct {
-	archive historyarchive.ArchiveInterface
+	archive       historyarchive.ArchiveInterface
+	readerOptions []ingest.CheckpointChangeReaderOption
 }
 
 type historyArchiveAdapterInterface interface {
@@ -20,9 +21,13 @@ type historyArchiveAdapterInterface interface {
 	GetState(ctx context.Context, sequence uint32) (ingest.ChangeReader, error)
 }
 
-// newHistoryArchiveAdapter is a constructor to make a historyArchiveAdapter
-func newHistoryArchiveAdapter(archive historyarchive.ArchiveInterface) historyArchiveAdapterInterface {
-	return &historyArchiveAdapter{archive: archive}
+// newHistoryArchiveAdapter is a constructor to make a historyArchiveAdapter,
+// readerOptions are passed to checkpoint change readers created in GetState.
+func newHistoryArchiveAdapter(
+	archive historyarchive.ArchiveInterface,
+	readerOptions ...ingest.CheckpointChangeReaderOption,
+) historyArchiveAdapterInterface {
+	return &historyArchiveAdapter{archive: archive, readerOptions: readerOptions}
 }
 
 // GetLatestLedgerSequence returns the latest ledger sequence or an error
@@ -64,7 +69,7 @@ func (haa *historyArchiveAdapter) GetState(ctx context.Context, sequence uint32)
 		return nil, errors.Errorf("history checkpoint does not exist for ledger %d", sequence)
 	}
 
-	sr, e := ingest.NewCheckpointChangeReader(ctx, haa.archive, sequence)
+	sr, e := ingest.NewCheckpointChangeReader(ctx, haa.archive, sequence, haa.readerOptions...)
 	if e != nil {
 		return nil, errors.Wrap(e, "could not make memory state reader")
 	}
@@ -58,6 +58,11 @@ const (
 	//  * Metrics updates.
 	MaxDBConnections = 3
 
+	// databasePrefetchWorkers is the number of workers prefetching ledgers
+	// from the Stellar-Core DB. Captive core streams ledgers so it's always
+	// prefetched by a single worker.
+	databasePrefetchWorkers = 4
+
 	defaultCoreCursorName           = "HORIZON"
 	stateVerificationErrorThreshold = 3
 )
@@ -82,9 +87,25 @@ type Config struct {
 	HistoryArchiveURL        string
 	DisableStateVerification bool
 
+	// BucketCachePath is the (optional) directory of the local cache of
+	// history archive buckets.
+	BucketCachePath string
+	// BucketCacheSize is the maximum size (in bytes) of the bucket cache, 0
+	// means no limit.
+	BucketCacheSize int64
+
+	// StateTempDir is the (optional) directory of temporary files used when
+	// building state. When set, keys of ledger entries seen in history
+	// archive buckets are spilled to disk instead of being kept in memory.
+	StateTempDir string
+
 	MaxReingestRetries          int
 	ReingestRetryBackoffSeconds int
 
+	// PrefetchLedgers is the number of ledgers fetched ahead from the ledger
+	// backend when ingesting a range. Prefetching is disabled when unset.
+	PrefetchLedgers uint32
+
 	// The checkpoint frequency will be 64 unless you are using an exotic test setup.
 	CheckpointFrequency uint32
 }
@@ -171,12 +192,23 @@ type system struct {
 func NewSystem(config Config) (System, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	var bucketCache *historyarchive.BucketCache
+	if config.BucketCachePath != "" {
+		var err error
+		bucketCache, err = historyarchive.NewBucketCache(config.BucketCachePath, config.BucketCacheSize)
+		if err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "error creating bucket cache")
+		}
+	}
+
 	archive, err := historyarchive.Connect(
 		config.HistoryArchiveURL,
 		historyarchive.ConnectOptions{
 			Context:             ctx,
 			NetworkPassphrase:   config.NetworkPassphrase,
 			CheckpointFrequency: config.CheckpointFrequency,
+			BucketCache:         bucketCache,
 		},
 	)
 	if err != nil {
@@ -225,10 +257,28 @@ func NewSystem(config Config) (System, error) {
 		}
 	}
 
+	if config.PrefetchLedgers > 0 {
+		workers := uint32(1)
+		if !config.EnableCaptiveCore {
+			workers = databasePrefetchWorkers
+		}
+		ledgerBackend = ledgerbackend.NewPrefetchingBackend(
+			ledgerBackend,
+			ledgerbackend.PrefetchingBackendConfig{
+				BufferSize: config.PrefetchLedgers,
+				Workers:    workers,
+			},
+		)
+	}
+
 	historyQ := &history.Q{config.HistorySession.Clone()}
 	historyQ.Ctx = ctx
 
-	historyAdapter := newHistoryArchiveAdapter(archive)
+	var readerOptions []ingest.CheckpointChangeReaderOption
+	if config.StateTempDir != "" {
+		readerOptions = append(readerOptions, ingest.DiskTempSet(config.StateTempDir, 0))
+	}
+	historyAdapter := newHistoryArchiveAdapter(archive, readerOptions...)
 
 	system := &system{
 		cancel:                      cancel,
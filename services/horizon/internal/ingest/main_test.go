@@ -381,6 +381,16 @@ func (m *mockLedgerBackend) PrepareRange(ledgerRange ledgerbackend.Range) error
 	return args.Error(0)
 }
 
+func (m *mockLedgerBackend) GetLedgerContext(ctx context.Context, sequence uint32) (bool, xdr.LedgerCloseMeta, error) {
+	args := m.Called(ctx, sequence)
+	return args.Get(0).(bool), args.Get(1).(xdr.LedgerCloseMeta), args.Error(2)
+}
+
+func (m *mockLedgerBackend) PrepareRangeContext(ctx context.Context, ledgerRange ledgerbackend.Range) error {
+	args := m.Called(ctx, ledgerRange)
+	return args.Error(0)
+}
+
 func (m *mockLedgerBackend) IsPrepared(ledgerRange ledgerbackend.Range) (bool, error) {
 	args := m.Called(ledgerRange)
 	return args.Get(0).(bool), args.Error(1)
@@ -1,6 +1,8 @@
 package ingest
 
 import (
+	"io"
+
 	"github.com/stellar/go/exp/orderbook"
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/mock"
@@ -49,3 +51,13 @@ func (m *mockOrderBookGraph) RemoveOffer(id xdr.Int64) orderbook.OBGraph {
 func (m *mockOrderBookGraph) Clear() {
 	m.Called()
 }
+
+func (m *mockOrderBookGraph) WriteSnapshot(w io.Writer) error {
+	args := m.Called(w)
+	return args.Error(0)
+}
+
+func (m *mockOrderBookGraph) ReadSnapshot(r io.Reader) (uint32, error) {
+	args := m.Called(r)
+	return args.Get(0).(uint32), args.Error(1)
+}
@@ -1,9 +1,13 @@
 package ingest
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"sort"
 	"time"
 
@@ -18,6 +22,7 @@ import (
 const (
 	verificationFrequency = time.Hour
 	updateFrequency       = 2 * time.Second
+	snapshotFrequency     = 5 * time.Minute
 )
 
 // OrderBookStream updates an in memory graph to be consistent with
@@ -32,8 +37,13 @@ type OrderBookStream struct {
 	// LatestLedgerGauge exposes the local (order book graph)
 	// latest processed ledger
 	LatestLedgerGauge prometheus.Gauge
-	lastLedger        uint32
-	lastVerification  time.Time
+	// SnapshotFile (optional) is the path of a file the order book graph is
+	// saved to periodically and on shutdown. The graph is restored from the
+	// file when the stream starts so it doesn't need to be rebuilt from the
+	// Horizon DB.
+	SnapshotFile     string
+	lastLedger       uint32
+	lastVerification time.Time
 }
 
 // NewOrderBookStream constructs and initializes an OrderBookStream instance
@@ -260,18 +270,100 @@ func (o *OrderBookStream) Update() error {
 	return nil
 }
 
+// LoadSnapshot restores the order book graph from SnapshotFile. Offers
+// updated after the snapshot ledger are applied by the next Update() call.
+// If the snapshot is not consistent with the Horizon DB (ingestion is behind
+// the snapshot ledger or offers removed after the snapshot ledger were
+// already compacted) the next Update() call rebuilds the graph from the
+// Horizon DB. Nothing is loaded if SnapshotFile does not exist.
+func (o *OrderBookStream) LoadSnapshot() error {
+	file, err := os.Open(o.SnapshotFile)
+	if os.IsNotExist(err) {
+		log.WithField("file", o.SnapshotFile).Info("order book snapshot does not exist")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "Error opening order book snapshot")
+	}
+	defer file.Close()
+
+	ledger, err := o.graph.ReadSnapshot(bufio.NewReader(file))
+	if err != nil {
+		o.lastLedger = 0
+		return errors.Wrap(err, "Error reading order book snapshot")
+	}
+
+	o.lastLedger = ledger
+	o.LatestLedgerGauge.Set(float64(ledger))
+	log.WithField("file", o.SnapshotFile).
+		WithField("ledger", ledger).
+		Info("order book restored from snapshot")
+	return nil
+}
+
+// SaveSnapshot writes the order book graph to SnapshotFile. The snapshot is
+// written to a temporary file first so SnapshotFile is never left
+// incomplete. Nothing is saved if the graph was not populated yet.
+func (o *OrderBookStream) SaveSnapshot() error {
+	if o.lastLedger == 0 {
+		return nil
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(o.SnapshotFile), filepath.Base(o.SnapshotFile)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "Error creating temporary order book snapshot")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if err = o.graph.WriteSnapshot(writer); err != nil {
+		return errors.Wrap(err, "Error writing order book snapshot")
+	}
+	if err = writer.Flush(); err != nil {
+		return errors.Wrap(err, "Error writing order book snapshot")
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrap(err, "Error closing temporary order book snapshot")
+	}
+	if err = os.Rename(file.Name(), o.SnapshotFile); err != nil {
+		return errors.Wrap(err, "Error renaming temporary order book snapshot")
+	}
+	return nil
+}
+
 // Run will call Update() every 30 seconds until the given context is terminated.
+// If SnapshotFile is set, the order book graph is restored from the snapshot
+// before the first update and saved every 5 minutes and on shutdown.
 func (o *OrderBookStream) Run(ctx context.Context) {
 	ticker := time.NewTicker(updateFrequency)
 	defer ticker.Stop()
 
+	var snapshotTicker <-chan time.Time
+	if o.SnapshotFile != "" {
+		if err := o.LoadSnapshot(); err != nil {
+			log.WithError(err).Warn("could not load order book snapshot")
+		}
+		t := time.NewTicker(snapshotFrequency)
+		defer t.Stop()
+		snapshotTicker = t.C
+	}
+
 	for {
 		select {
 		case <-ticker.C:
 			if err := o.Update(); err != nil && !isCancelledError(err) {
 				log.WithError(err).Error("could not apply updates from order book stream")
 			}
+		case <-snapshotTicker:
+			if err := o.SaveSnapshot(); err != nil {
+				log.WithError(err).Error("could not save order book snapshot")
+			}
 		case <-ctx.Done():
+			if o.SnapshotFile != "" {
+				if err := o.SaveSnapshot(); err != nil {
+					log.WithError(err).Error("could not save order book snapshot")
+				}
+			}
 			log.Info("shutting down OrderBookStream")
 			return
 		}
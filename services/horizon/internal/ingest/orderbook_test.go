@@ -4,11 +4,17 @@ package ingest
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/stellar/go/exp/orderbook"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -644,3 +650,69 @@ func (t *VerifyOrderBookStreamTestSuite) TestSuccess() {
 	t.Assert().Equal(uint32(300), t.stream.lastLedger)
 	t.Assert().False(t.stream.lastVerification.Equal(t.initialTime))
 }
+
+func TestOrderBookStreamSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "order-book-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "snapshot")
+
+	graph := orderbook.NewOrderBookGraph()
+	stream := NewOrderBookStream(&mockDBQ{}, graph)
+	stream.SnapshotFile = file
+
+	// Nothing is saved until the graph is populated.
+	require.NoError(t, stream.SaveSnapshot())
+	_, err = os.Stat(file)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, stream.LoadSnapshot())
+	assert.Equal(t, uint32(0), stream.lastLedger)
+
+	sellerID := "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	offer := history.Offer{
+		OfferID:      1,
+		SellerID:     sellerID,
+		SellingAsset: xdr.MustNewNativeAsset(),
+		BuyingAsset:  xdr.MustNewCreditAsset("USD", sellerID),
+		Amount:       123,
+		Pricen:       1,
+		Priced:       2,
+	}
+	otherOffer := offer
+	otherOffer.OfferID = 2
+	addOfferToGraph(graph, offer)
+	addOfferToGraph(graph, otherOffer)
+	require.NoError(t, graph.Apply(100))
+	stream.lastLedger = 100
+	require.NoError(t, stream.SaveSnapshot())
+
+	historyQ := &mockDBQ{}
+	restoredGraph := orderbook.NewOrderBookGraph()
+	restored := NewOrderBookStream(historyQ, restoredGraph)
+	restored.SnapshotFile = file
+	require.NoError(t, restored.LoadSnapshot())
+	assert.Equal(t, uint32(100), restored.lastLedger)
+	assert.Equal(t, graph.OffersMap(), restoredGraph.OffersMap())
+
+	// Offers updated after the snapshot ledger are applied.
+	deletedOffer := offer
+	deletedOffer.Deleted = true
+	historyQ.MockQOffers.On("GetUpdatedOffers", uint32(100)).
+		Return([]history.Offer{deletedOffer}, nil).
+		Once()
+	reset, err := restored.update(ingestionStatus{
+		HistoryConsistentWithState: true,
+		LastIngestedLedger:         110,
+		LastOfferCompactionLedger:  50,
+	})
+	require.NoError(t, err)
+	assert.False(t, reset)
+	assert.Equal(t, uint32(110), restored.lastLedger)
+	assert.Len(t, restoredGraph.Offers(), 1)
+	historyQ.AssertExpectations(t)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("invalid"), 0644))
+	assert.Error(t, restored.LoadSnapshot())
+	assert.Equal(t, uint32(0), restored.lastLedger)
+	assert.True(t, restoredGraph.IsEmpty())
+}
@@ -66,6 +66,9 @@ func initIngester(app *App) {
 		// Use the first archive for now. We don't have a mechanism to
 		// use multiple archives at the same time currently.
 		HistoryArchiveURL:           app.config.HistoryArchiveURLs[0],
+		BucketCachePath:             app.config.HistoryArchiveBucketCachePath,
+		BucketCacheSize:             int64(app.config.HistoryArchiveBucketCacheSize) * 1024 * 1024,
+		StateTempDir:                app.config.IngestStateTempDir,
 		CheckpointFrequency:         app.config.CheckpointFrequency,
 		StellarCoreURL:              app.config.StellarCoreURL,
 		StellarCoreCursor:           app.config.CursorName,
@@ -91,7 +94,9 @@ func initPathFinder(app *App) {
 		&history.Q{app.HorizonSession(app.ctx)},
 		orderBookGraph,
 	)
+	app.orderBookStream.SnapshotFile = app.config.PathFindingSnapshotFile
 
+	app.orderBookGraph = orderBookGraph
 	app.paths = simplepath.NewInMemoryFinder(orderBookGraph)
 }
 
@@ -33,9 +33,14 @@ func TestVersion(t *testing.T) {
 			ExpectedVersionByte: VersionByteHashX,
 		},
 		{
-			Name:                "Other (0x60)",
-			Address:             "MBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
-			ExpectedVersionByte: VersionByte(0x60),
+			Name:                "MuxedAccount",
+			Address:             "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK",
+			ExpectedVersionByte: VersionByteMuxedAccount,
+		},
+		{
+			Name:                "Other (0x68)",
+			Address:             "NBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
+			ExpectedVersionByte: VersionByte(0x68),
 		},
 	}
 
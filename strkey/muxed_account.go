@@ -0,0 +1,83 @@
+package strkey
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// MuxedAccount is a multiplexed account as defined in SEP-23: an ed25519
+// account and a 64 bit ID which identifies a user of the account
+type MuxedAccount struct {
+	id      uint64
+	ed25519 [32]byte
+}
+
+// SetID sets the ID of the muxed account
+func (m *MuxedAccount) SetID(id uint64) {
+	m.id = id
+}
+
+// SetAccountID sets the account of the muxed account from its G address
+func (m *MuxedAccount) SetAccountID(address string) error {
+	raw, err := Decode(VersionByteAccountID, address)
+	if err != nil {
+		return err
+	}
+	if len(raw) != 32 {
+		return errors.New("invalid account ID")
+	}
+	copy(m.ed25519[:], raw)
+	return nil
+}
+
+// ID returns the ID of the muxed account
+func (m *MuxedAccount) ID() uint64 {
+	return m.id
+}
+
+// AccountID returns the G address of the account underlying the muxed
+// account
+func (m *MuxedAccount) AccountID() (string, error) {
+	return Encode(VersionByteAccountID, m.ed25519[:])
+}
+
+// Ed25519 returns the ed25519 public key of the account underlying the
+// muxed account
+func (m *MuxedAccount) Ed25519() [32]byte {
+	return m.ed25519
+}
+
+// SetEd25519 sets the ed25519 public key of the account underlying the muxed
+// account
+func (m *MuxedAccount) SetEd25519(key [32]byte) {
+	m.ed25519 = key
+}
+
+// Address returns the M address of the muxed account. The payload of the
+// address is the ed25519 public key followed by the ID in big endian.
+func (m *MuxedAccount) Address() (string, error) {
+	var raw bytes.Buffer
+	raw.Write(m.ed25519[:])
+	if err := binary.Write(&raw, binary.BigEndian, m.id); err != nil {
+		return "", err
+	}
+	return Encode(VersionByteMuxedAccount, raw.Bytes())
+}
+
+// DecodeMuxedAccount decodes an M address
+func DecodeMuxedAccount(address string) (*MuxedAccount, error) {
+	raw, err := Decode(VersionByteMuxedAccount, address)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != 40 {
+		return nil, errors.New("invalid muxed account address")
+	}
+
+	var muxed MuxedAccount
+	copy(muxed.ed25519[:], raw[:32])
+	muxed.id = binary.BigEndian.Uint64(raw[32:])
+	return &muxed, nil
+}
@@ -0,0 +1,51 @@
+package strkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuxedAccount(t *testing.T) {
+	// test vectors from SEP-23
+	accountID := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	for _, testCase := range []struct {
+		id      uint64
+		address string
+	}{
+		{0, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ"},
+		{9223372036854775808, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"},
+	} {
+		var muxed MuxedAccount
+		require.NoError(t, muxed.SetAccountID(accountID))
+		muxed.SetID(testCase.id)
+		address, err := muxed.Address()
+		require.NoError(t, err)
+		assert.Equal(t, testCase.address, address)
+
+		decoded, err := DecodeMuxedAccount(testCase.address)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.id, decoded.ID())
+		decodedAccountID, err := decoded.AccountID()
+		require.NoError(t, err)
+		assert.Equal(t, accountID, decodedAccountID)
+	}
+
+	for _, invalid := range []string{
+		// G address
+		accountID,
+		// the payload is too short
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAEHFA",
+		// corrupted checksum
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLL",
+		// padding
+		"MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK===",
+	} {
+		_, err := DecodeMuxedAccount(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	var muxed MuxedAccount
+	assert.Error(t, muxed.SetAccountID("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"))
+}
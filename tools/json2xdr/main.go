@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var (
+	typ string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "json2xdr [JSON object]",
+	Short: "json2xdr transforms JSON objects printed by xdr2json into base64 encoded XDR",
+	Long:  "json2xdr transforms JSON objects printed by xdr2json into base64 encoded XDR. The object is read from stdin if no argument is given.",
+	RunE:  run,
+}
+
+func main() {
+	rootCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, for example TransactionEnvelope, LedgerEntry or LedgerCloseMeta")
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("At most one command argument with JSON object is allowed.")
+	}
+	var input []byte
+	if len(args) == 1 {
+		input = []byte(args[0])
+	} else {
+		var err error
+		input, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return errors.Wrap(err, "Error reading stdin.")
+		}
+	}
+
+	object, err := xdr.NewValue(typ)
+	if err != nil {
+		return err
+	}
+	if err = xdr.UnmarshalJSON(input, object); err != nil {
+		return errors.Wrap(err, "Error converting JSON to XDR structure.")
+	}
+	encoded, err := xdr.MarshalBase64(object)
+	if err != nil {
+		return errors.Wrap(err, "Error marshalling XDR structure.")
+	}
+	fmt.Println(encoded)
+	return nil
+}
@@ -42,13 +42,29 @@ func status(a string, opts *Options) {
 }
 
 type Options struct {
-	Low         int
-	High        uint32
-	Last        int
-	Recent      bool
-	Profile     bool
-	CommandOpts historyarchive.CommandOptions
-	ConnectOpts historyarchive.ConnectOptions
+	Low             int
+	High            uint32
+	Last            int
+	Recent          bool
+	Profile         bool
+	BucketCache     string
+	BucketCacheSize int64
+	CommandOpts     historyarchive.CommandOptions
+	ConnectOpts     historyarchive.ConnectOptions
+}
+
+// SrcConnectOpts returns the options used to connect to source archives. If
+// the bucket cache is enabled, buckets are copied from the cache.
+func (opts *Options) SrcConnectOpts() historyarchive.ConnectOptions {
+	connectOpts := opts.ConnectOpts
+	if opts.BucketCache != "" {
+		cache, err := historyarchive.NewBucketCache(opts.BucketCache, opts.BucketCacheSize*1024*1024)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "Error opening bucket cache"))
+		}
+		connectOpts.BucketCache = cache
+	}
+	return connectOpts
 }
 
 func (opts *Options) SetRange(srcArch *historyarchive.Archive, dstArch *historyarchive.Archive) {
@@ -123,7 +139,7 @@ func scan(a string, opts *Options) {
 }
 
 func mirror(src string, dst string, opts *Options) {
-	srcArch := historyarchive.MustConnect(src, opts.ConnectOpts)
+	srcArch := historyarchive.MustConnect(src, opts.SrcConnectOpts())
 	dstArch := historyarchive.MustConnect(dst, opts.ConnectOpts)
 	opts.SetRange(srcArch, dstArch)
 	log.Printf("mirroring %v -> %v\n", src, dst)
@@ -134,7 +150,7 @@ func mirror(src string, dst string, opts *Options) {
 }
 
 func repair(src string, dst string, opts *Options) {
-	srcArch := historyarchive.MustConnect(src, opts.ConnectOpts)
+	srcArch := historyarchive.MustConnect(src, opts.SrcConnectOpts())
 	dstArch := historyarchive.MustConnect(dst, opts.ConnectOpts)
 	opts.SetRange(srcArch, dstArch)
 	log.Printf("repairing %v -> %v\n", src, dst)
@@ -209,6 +225,20 @@ func main() {
 		"S3 endpoint to use",
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&opts.ConnectOpts.GCSEndpoint,
+		"gcsendpoint",
+		"",
+		"Google Cloud Storage JSON API endpoint to use",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&opts.ConnectOpts.AzureEndpoint,
+		"azureendpoint",
+		"",
+		"Azure Blob Storage endpoint to use",
+	)
+
 	rootCmd.PersistentFlags().BoolVarP(
 		&opts.CommandOpts.DryRun,
 		"dryrun",
@@ -239,6 +269,20 @@ func main() {
 		"decode and re-encode all buckets",
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&opts.BucketCache,
+		"bucket-cache",
+		"",
+		"directory of the local bucket cache used when copying buckets from the source archive",
+	)
+
+	rootCmd.PersistentFlags().Int64Var(
+		&opts.BucketCacheSize,
+		"bucket-cache-size",
+		0,
+		"maximum size of the bucket cache in MB, 0 means no limit",
+	)
+
 	rootCmd.PersistentFlags().BoolVar(
 		&opts.Profile,
 		"profile",
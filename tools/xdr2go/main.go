@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"go/format"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/stellar/go/support/errors"
@@ -14,13 +15,13 @@ var (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "xdr2go [base64-encoded XDR object]",
+	Use:   "xdr2go [base64-encoded XDR object or JSON printed by xdr2json]",
 	Short: "xdr2go transforms base64 encoded XDR objects into a pretty Go code",
 	RunE:  run,
 }
 
 func main() {
-	rootCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, currently only TransactionEnvelope is available")
+	rootCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, for example TransactionEnvelope, LedgerEntry or LedgerCloseMeta")
 	rootCmd.Execute()
 }
 
@@ -28,16 +29,23 @@ func run(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("Exactly one command argument with XDR object is required.")
 	}
-	var object interface{}
-	switch typ {
-	case "TransactionEnvelope":
-		object = &xdr.TransactionEnvelope{}
-	default:
-		return errors.New("Unknown type.")
-	}
-	err := xdr.SafeUnmarshalBase64(args[0], object)
+	object, err := xdr.NewValue(typ)
 	if err != nil {
-		return errors.Wrap(err, "Error unmarshalling XDR stucture.")
+		return errors.Wrap(err, "Unknown type.")
+	}
+	input := strings.TrimSpace(args[0])
+	// base64 can't start with any of the characters JSON values of xdr
+	// types start with
+	if strings.HasPrefix(input, "{") || strings.HasPrefix(input, "\"") || strings.HasPrefix(input, "[") {
+		err = xdr.UnmarshalJSON([]byte(input), object)
+		if err != nil {
+			return errors.Wrap(err, "Error converting JSON to XDR structure.")
+		}
+	} else {
+		err = xdr.SafeUnmarshalBase64(input, object)
+		if err != nil {
+			return errors.Wrap(err, "Error unmarshalling XDR stucture.")
+		}
 	}
 
 	source := fmt.Sprintf("%#v\n", object)
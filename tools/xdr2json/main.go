@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var (
+	typ string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "xdr2json [base64-encoded XDR object]",
+	Short: "xdr2json transforms base64 encoded XDR objects into JSON",
+	Long:  "xdr2json transforms base64 encoded XDR objects into JSON. The object is read from stdin if no argument is given.",
+	RunE:  run,
+}
+
+func main() {
+	rootCmd.Flags().StringVarP(&typ, "type", "t", "TransactionEnvelope", "xdr type, for example TransactionEnvelope, LedgerEntry or LedgerCloseMeta")
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("At most one command argument with XDR object is allowed.")
+	}
+	input, err := readInput(args)
+	if err != nil {
+		return err
+	}
+	object, err := xdr.NewValue(typ)
+	if err != nil {
+		return err
+	}
+	err = xdr.SafeUnmarshalBase64(input, object)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshalling XDR structure.")
+	}
+
+	encoded, err := xdr.MarshalJSON(object)
+	if err != nil {
+		return errors.Wrap(err, "Error converting XDR structure to JSON.")
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, encoded, "", "  "); err != nil {
+		return errors.Wrap(err, "Error formatting JSON.")
+	}
+	fmt.Println(indented.String())
+	return nil
+}
+
+func readInput(args []string) (string, error) {
+	if len(args) == 1 {
+		return strings.TrimSpace(args[0]), nil
+	}
+	input, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading stdin.")
+	}
+	return strings.TrimSpace(string(input)), nil
+}
@@ -66,8 +66,7 @@ func (cb *Clawback) FromXDR(xdrOp xdr.Operation) error {
 	}
 
 	cb.SourceAccount = accountFromXDR(xdrOp.SourceAccount)
-	fromAID := result.From.ToAccountId()
-	cb.From = fromAID.Address()
+	cb.From = result.From.Address()
 	cb.Amount = amount.String(result.Amount)
 	asset, err := assetFromXDR(result.Asset)
 	if err != nil {
@@ -81,7 +80,7 @@ func (cb *Clawback) FromXDR(xdrOp xdr.Operation) error {
 // Validate for Clawback validates the required struct fields. It returns an error if any
 // of the fields are invalid. Otherwise, it returns nil.
 func (cb *Clawback) Validate() error {
-	_, err := xdr.AddressToAccountId(cb.From)
+	_, err := xdr.AddressToMuxedAccount(cb.From)
 	if err != nil {
 		return NewValidationError("From", err.Error())
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -77,4 +78,23 @@ func TestClaimableBalanceID(t *testing.T) {
 	balanceId, err := tx.ClaimableBalanceID(0)
 	assert.NoError(t, err)
 	assert.Equal(t, "0000000095001252ab3b4d16adbfa5364ce526dfcda03cb2258b827edbb2e0450087be51", balanceId)
+
+	// The ID of a muxed source account does not change the balance ID
+	muxedAccount, err := xdr.MuxedAccountFromAccountId(aKeys.Address(), 7)
+	assert.NoError(t, err)
+	aAccount = SimpleAccount{AccountID: muxedAccount.Address(), Sequence: 123}
+	tx, err = NewTransaction(
+		TransactionParams{
+			SourceAccount:        &aAccount,
+			IncrementSequenceNum: true,
+			BaseFee:              MinBaseFee,
+			Timebounds:           NewInfiniteTimeout(),
+			Operations:           []Operation{&claimableBalanceEntry},
+		},
+	)
+	assert.NoError(t, err)
+
+	balanceId, err = tx.ClaimableBalanceID(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000095001252ab3b4d16adbfa5364ce526dfcda03cb2258b827edbb2e0450087be51", balanceId)
 }
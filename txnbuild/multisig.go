@@ -0,0 +1,277 @@
+package txnbuild
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// SignerSet describes the signers and thresholds of an account. Like the
+// signers of accounts returned by Horizon, Signers includes the master key of
+// the account (using the address of the account) unless its weight is 0.
+type SignerSet struct {
+	Signers       SignerSummary
+	LowThreshold  Threshold
+	MedThreshold  Threshold
+	HighThreshold Threshold
+}
+
+// threshold returns the threshold of the given level.
+func (s SignerSet) threshold(level xdr.ThresholdIndexes) Threshold {
+	switch level {
+	case xdr.ThresholdIndexesThresholdLow:
+		return s.LowThreshold
+	case xdr.ThresholdIndexesThresholdMed:
+		return s.MedThreshold
+	default:
+		return s.HighThreshold
+	}
+}
+
+// signers returns the signers of the set with a non zero weight, sorted by
+// key.
+func (s SignerSet) signers() ([]xdr.Signer, error) {
+	keys := make([]string, 0, len(s.Signers))
+	for key, weight := range s.Signers {
+		if weight > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	signers := make([]xdr.Signer, 0, len(keys))
+	for _, key := range keys {
+		var signerKey xdr.SignerKey
+		if err := signerKey.SetAddress(key); err != nil {
+			return nil, errors.Wrapf(err, "invalid signer %s", key)
+		}
+		signers = append(signers, xdr.Signer{Key: signerKey, Weight: xdr.Uint32(s.Signers[key])})
+	}
+	return signers, nil
+}
+
+// ThresholdStatus describes whether the signatures of a transaction meet a
+// threshold of one of its source accounts.
+type ThresholdStatus struct {
+	AccountID string
+	Level     xdr.ThresholdIndexes
+	Threshold Threshold
+	// Transaction is true if the threshold is required by the transaction
+	// itself, which requires the low threshold of its source account.
+	Transaction bool
+	// Operations holds the indexes of the operations requiring the threshold.
+	Operations []int
+	// Weight is the total weight of the signers which signed the
+	// transaction, capped at 255 per signer like stellar-core does.
+	Weight int32
+	// Signed holds the signers which signed the transaction.
+	Signed []string
+	// Candidates holds the signers which haven't signed the transaction yet
+	// and could add their signatures, sorted by decreasing weight. Pre-auth
+	// transaction signers for other transactions can't sign and are never
+	// candidates.
+	Candidates []string
+}
+
+// Met returns true if the signatures meet the threshold. Like stellar-core, at
+// least one signature is required even if the threshold is 0.
+func (s ThresholdStatus) Met() bool {
+	return len(s.Signed) > 0 && s.Weight >= int32(s.Threshold)
+}
+
+// SourceAccounts returns the addresses of the source account of the
+// transaction and of the source accounts of its operations, without
+// duplicates. Muxed accounts are returned as the address of their underlying
+// account. These are the accounts whose signers can sign the transaction.
+func (t *Transaction) SourceAccounts() []string {
+	accountIDs := []string{accountAddress(t.envelope.SourceAccount())}
+	seen := map[string]bool{accountIDs[0]: true}
+	for _, op := range t.envelope.Operations() {
+		accountID := t.operationSource(op)
+		if !seen[accountID] {
+			seen[accountID] = true
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	return accountIDs
+}
+
+// SignatureStatus reports, for every threshold of a source account required
+// by the transaction or one of its operations, the signers which already
+// signed the transaction and the signers which could still sign it. signers
+// maps the addresses returned by SourceAccounts to their signer sets, an
+// error is returned if one of them is missing.
+//
+// The statuses are returned in the order in which stellar-core checks them:
+// the low threshold of the transaction source account first, then the
+// thresholds of the operations.
+func (t *Transaction) SignatureStatus(network string, signers map[string]SignerSet) ([]ThresholdStatus, error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash transaction")
+	}
+	checker := newSignatureChecker(hash, t.Signatures())
+
+	var statuses []ThresholdStatus
+	indexes := map[string]int{}
+	add := func(accountID string, level xdr.ThresholdIndexes) (*ThresholdStatus, error) {
+		key := accountID + "/" + level.String()
+		if i, ok := indexes[key]; ok {
+			return &statuses[i], nil
+		}
+		set, ok := signers[accountID]
+		if !ok {
+			return nil, errors.Errorf("signers of account %s are missing", accountID)
+		}
+		status, err := checker.status(accountID, set, level)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signers of account %s", accountID)
+		}
+		indexes[key] = len(statuses)
+		statuses = append(statuses, status)
+		return &statuses[len(statuses)-1], nil
+	}
+
+	status, err := add(accountAddress(t.envelope.SourceAccount()), xdr.ThresholdIndexesThresholdLow)
+	if err != nil {
+		return nil, err
+	}
+	status.Transaction = true
+	for i, op := range t.envelope.Operations() {
+		status, err := add(t.operationSource(op), operationThreshold(op))
+		if err != nil {
+			return nil, err
+		}
+		status.Operations = append(status.Operations, i)
+	}
+	return statuses, nil
+}
+
+// status returns the status of the threshold of an account.
+func (c *signatureChecker) status(accountID string, set SignerSet, level xdr.ThresholdIndexes) (ThresholdStatus, error) {
+	status := ThresholdStatus{
+		AccountID: accountID,
+		Level:     level,
+		Threshold: set.threshold(level),
+	}
+	signers, err := set.signers()
+	if err != nil {
+		return status, err
+	}
+
+	var candidates []xdr.Signer
+	for _, signer := range signers {
+		if c.signed(signer.Key) {
+			status.Weight += signerWeight(signer)
+			status.Signed = append(status.Signed, signer.Key.Address())
+		} else if signer.Key.Type != xdr.SignerKeyTypeSignerKeyTypePreAuthTx {
+			candidates = append(candidates, signer)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight > candidates[j].Weight
+	})
+	for _, signer := range candidates {
+		status.Candidates = append(status.Candidates, signer.Key.Address())
+	}
+	return status, nil
+}
+
+// signed returns true if the transaction is signed by the signer key. Unlike
+// check, it doesn't mark signatures as used.
+func (c *signatureChecker) signed(key xdr.SignerKey) bool {
+	if key.Type == xdr.SignerKeyTypeSignerKeyTypePreAuthTx {
+		return xdr.Hash(*key.PreAuthTx) == c.hash
+	}
+	for _, signature := range c.signatures {
+		if c.verify(key, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeSignatures returns a new Transaction instance which extends the
+// current instance with the signatures of other instances of the same
+// transaction, e.g. copies of the transaction signed by other parties.
+// Duplicate signatures are only added once. An error is returned if one of
+// the other transactions is a different transaction.
+func (t *Transaction) MergeSignatures(network string, others ...*Transaction) (*Transaction, error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash transaction")
+	}
+
+	signatures := make([]xdr.DecoratedSignature, 0, len(t.Signatures()))
+	for _, tx := range append([]*Transaction{t}, others...) {
+		otherHash, err := tx.Hash(network)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to hash transaction")
+		}
+		if otherHash != hash {
+			return nil, errors.New("transactions are not the same transaction")
+		}
+		for _, signature := range tx.Signatures() {
+			if !containsSignature(signatures, signature) {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	return t.clone(signatures), nil
+}
+
+func containsSignature(signatures []xdr.DecoratedSignature, signature xdr.DecoratedSignature) bool {
+	for _, s := range signatures {
+		if s.Hint == signature.Hint && bytes.Equal(s.Signature, signature.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveExtraneousSignatures returns a new Transaction instance without the
+// signatures stellar-core would reject the transaction for with
+// tx_bad_auth_extra: signatures which don't belong to a signer of a source
+// account, duplicates and signatures which aren't needed because the
+// threshold they would count towards is already met by the preceding
+// signatures. signers maps the addresses returned by SourceAccounts to their
+// signer sets, an error is returned if one of them is missing.
+func (t *Transaction) RemoveExtraneousSignatures(network string, signers map[string]SignerSet) (*Transaction, error) {
+	hash, err := t.Hash(network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash transaction")
+	}
+	checker := newSignatureChecker(hash, t.Signatures())
+
+	check := func(accountID string, level xdr.ThresholdIndexes) error {
+		set, ok := signers[accountID]
+		if !ok {
+			return errors.Errorf("signers of account %s are missing", accountID)
+		}
+		accountSigners, err := set.signers()
+		if err != nil {
+			return errors.Wrapf(err, "invalid signers of account %s", accountID)
+		}
+		checker.check(accountSigners, int32(set.threshold(level)))
+		return nil
+	}
+
+	if err = check(accountAddress(t.envelope.SourceAccount()), xdr.ThresholdIndexesThresholdLow); err != nil {
+		return nil, err
+	}
+	for _, op := range t.envelope.Operations() {
+		if err = check(t.operationSource(op), operationThreshold(op)); err != nil {
+			return nil, err
+		}
+	}
+
+	var signatures []xdr.DecoratedSignature
+	for i, signature := range checker.signatures {
+		if checker.used[i] {
+			signatures = append(signatures, signature)
+		}
+	}
+	return t.clone(signatures), nil
+}
@@ -0,0 +1,271 @@
+package txnbuild
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureStatus(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	signers := map[string]SignerSet{
+		kp0.Address(): {
+			Signers:       SignerSummary{kp0.Address(): 1, kp1.Address(): 2},
+			LowThreshold:  1,
+			MedThreshold:  2,
+			HighThreshold: 3,
+		},
+		kp2.Address(): {
+			Signers: SignerSummary{kp2.Address(): 1},
+		},
+	}
+	lowThreshold := Threshold(2)
+	tx := preflightTransaction(t, kp0.Address(),
+		&Payment{Destination: kp2.Address(), Amount: "10", Asset: NativeAsset{}},
+		&SetOptions{LowThreshold: &lowThreshold},
+		&Payment{Destination: kp0.Address(), Amount: "10", Asset: NativeAsset{}, SourceAccount: kp2.Address()},
+		&Payment{Destination: kp2.Address(), Amount: "10", Asset: NativeAsset{}},
+	)
+	assert.Equal(t, []string{kp0.Address(), kp2.Address()}, tx.SourceAccounts())
+
+	statuses, err := tx.SignatureStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.Equal(t, []ThresholdStatus{
+		{
+			AccountID:   kp0.Address(),
+			Level:       xdr.ThresholdIndexesThresholdLow,
+			Threshold:   1,
+			Transaction: true,
+			Candidates:  []string{kp1.Address(), kp0.Address()},
+		},
+		{
+			AccountID:  kp0.Address(),
+			Level:      xdr.ThresholdIndexesThresholdMed,
+			Threshold:  2,
+			Operations: []int{0, 3},
+			Candidates: []string{kp1.Address(), kp0.Address()},
+		},
+		{
+			AccountID:  kp0.Address(),
+			Level:      xdr.ThresholdIndexesThresholdHigh,
+			Threshold:  3,
+			Operations: []int{1},
+			Candidates: []string{kp1.Address(), kp0.Address()},
+		},
+		{
+			AccountID:  kp2.Address(),
+			Level:      xdr.ThresholdIndexesThresholdMed,
+			Threshold:  0,
+			Operations: []int{2},
+			Candidates: []string{kp2.Address()},
+		},
+	}, statuses)
+	for _, status := range statuses {
+		assert.False(t, status.Met())
+	}
+
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp1, kp2)
+	require.NoError(t, err)
+	statuses, err = tx.SignatureStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+	assert.True(t, statuses[0].Met())
+	assert.True(t, statuses[1].Met())
+	assert.False(t, statuses[2].Met())
+	assert.Equal(t, int32(2), statuses[2].Weight)
+	assert.Equal(t, []string{kp1.Address()}, statuses[2].Signed)
+	assert.Equal(t, []string{kp0.Address()}, statuses[2].Candidates)
+	assert.True(t, statuses[3].Met())
+
+	// signatures for the wrong network don't count
+	statuses, err = tx.SignatureStatus(network.PublicNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.False(t, statuses[0].Met())
+
+	delete(signers, kp2.Address())
+	_, err = tx.SignatureStatus(network.TestNetworkPassphrase, signers)
+	assert.EqualError(t, err, "signers of account "+kp2.Address()+" are missing")
+}
+
+func TestSignatureStatusPreAuthAndHashX(t *testing.T) {
+	kp0 := newKeypair0()
+	preimage := []byte("secret")
+	tx := preflightTransaction(t, kp0.Address(), &BumpSequence{BumpTo: 10})
+	hash, err := tx.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	preAuth := xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypePreAuthTx, PreAuthTx: (*xdr.Uint256)(&hash)}
+	hashX := xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypeHashX, HashX: &xdr.Uint256{}}
+	*hashX.HashX = xdr.Uint256(sha256.Sum256(preimage))
+	otherPreAuth := xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypePreAuthTx, PreAuthTx: &xdr.Uint256{1}}
+
+	signers := map[string]SignerSet{
+		kp0.Address(): {
+			Signers: SignerSummary{
+				preAuth.Address():      1,
+				hashX.Address():        1,
+				otherPreAuth.Address(): 1,
+			},
+			LowThreshold: 2,
+		},
+	}
+	statuses, err := tx.SignatureStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.False(t, statuses[0].Met())
+	assert.Equal(t, []string{preAuth.Address()}, statuses[0].Signed)
+	assert.Equal(t, []string{hashX.Address()}, statuses[0].Candidates)
+
+	tx, err = tx.SignHashX(preimage)
+	require.NoError(t, err)
+	statuses, err = tx.SignatureStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Met())
+	assert.Empty(t, statuses[0].Candidates)
+}
+
+func TestMergeSignatures(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	tx := preflightTransaction(t, kp0.Address(), &BumpSequence{BumpTo: 10})
+
+	signed0, err := tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	signed1, err := tx.Sign(network.TestNetworkPassphrase, kp1, kp0)
+	require.NoError(t, err)
+	signed2, err := tx.Sign(network.TestNetworkPassphrase, kp2)
+	require.NoError(t, err)
+
+	merged, err := signed0.MergeSignatures(network.TestNetworkPassphrase, signed1, signed2, signed0)
+	require.NoError(t, err)
+	assert.Equal(t, []xdr.DecoratedSignature{
+		signed0.Signatures()[0],
+		signed1.Signatures()[0],
+		signed2.Signatures()[0],
+	}, merged.Signatures())
+	// the original transactions are not modified
+	assert.Len(t, signed0.Signatures(), 1)
+
+	// envelopes exchanged as base64 can be merged
+	b64, err := signed2.Base64()
+	require.NoError(t, err)
+	parsed, err := TransactionFromXDR(b64)
+	require.NoError(t, err)
+	parsedTx, ok := parsed.Transaction()
+	require.True(t, ok)
+	merged, err = signed0.MergeSignatures(network.TestNetworkPassphrase, parsedTx)
+	require.NoError(t, err)
+	assert.Len(t, merged.Signatures(), 2)
+
+	other := preflightTransaction(t, kp0.Address(), &BumpSequence{BumpTo: 11})
+	_, err = signed0.MergeSignatures(network.TestNetworkPassphrase, other)
+	assert.EqualError(t, err, "transactions are not the same transaction")
+}
+
+func TestRemoveExtraneousSignatures(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	signers := map[string]SignerSet{
+		kp0.Address(): {
+			Signers:      SignerSummary{kp0.Address(): 1, kp1.Address(): 1},
+			LowThreshold: 1,
+			MedThreshold: 2,
+		},
+	}
+
+	// both signers are needed for the payment, kp2 isn't a signer
+	tx := preflightTransaction(t, kp0.Address(),
+		&Payment{Destination: kp2.Address(), Amount: "10", Asset: NativeAsset{}},
+	)
+	tx, err := tx.Sign(network.TestNetworkPassphrase, kp2, kp0, kp1, kp0)
+	require.NoError(t, err)
+	stripped, err := tx.RemoveExtraneousSignatures(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signatures()[1:3], stripped.Signatures())
+	assert.Len(t, tx.Signatures(), 4)
+
+	// one signer is enough for bumping the sequence number
+	tx = preflightTransaction(t, kp0.Address(), &BumpSequence{BumpTo: 10})
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp0, kp1)
+	require.NoError(t, err)
+	stripped, err = tx.RemoveExtraneousSignatures(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.Len(t, stripped.Signatures(), 1)
+	statuses, err := stripped.SignatureStatus(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Met())
+	}
+
+	// the stripped transaction passes the signature checks of stellar-core
+	source := accountEntry(kp0.Address(), 10*xlm, 1)
+	source.Data.Account.Thresholds = xdr.Thresholds{1, 1, 2, 0}
+	source.Data.Account.Signers = []xdr.Signer{{Key: xdr.MustSigner(kp1.Address()), Weight: 1}}
+	result, err := stripped.Preflight(PreflightParams{
+		State:             NewStaticLedgerState(source),
+		NetworkPassphrase: network.TestNetworkPassphrase,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_success", result.TransactionCode)
+	result, err = tx.Preflight(PreflightParams{
+		State:             NewStaticLedgerState(source),
+		NetworkPassphrase: network.TestNetworkPassphrase,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_auth_extra", result.TransactionCode)
+
+	_, err = tx.RemoveExtraneousSignatures(network.TestNetworkPassphrase, map[string]SignerSet{})
+	assert.EqualError(t, err, "signers of account "+kp0.Address()+" are missing")
+}
+
+func TestRemoveExtraneousSignaturesOverlappingSigners(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	// kp1 signs for both accounts with different weights.
+	signers := map[string]SignerSet{
+		kp0.Address(): {
+			Signers:      SignerSummary{kp0.Address(): 1, kp1.Address(): 1},
+			LowThreshold: 1,
+			MedThreshold: 1,
+		},
+		kp2.Address(): {
+			Signers:      SignerSummary{kp2.Address(): 1, kp1.Address(): 2},
+			LowThreshold: 1,
+			MedThreshold: 2,
+		},
+	}
+	tx := preflightTransaction(t, kp0.Address(),
+		&Payment{Destination: kp0.Address(), Amount: "10", Asset: NativeAsset{}, SourceAccount: kp2.Address()},
+	)
+	tx, err := tx.Sign(network.TestNetworkPassphrase, kp0, kp1, kp2)
+	require.NoError(t, err)
+
+	// Like stellar-core, the signatures are matched in order: the low
+	// threshold of kp0 is met by the first signature (kp0) and the medium
+	// threshold of kp2 by the second one (kp1) so only the signature of kp2
+	// is extraneous.
+	stripped, err := tx.RemoveExtraneousSignatures(network.TestNetworkPassphrase, signers)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signatures()[:2], stripped.Signatures())
+
+	source := accountEntry(kp0.Address(), 10*xlm, 1)
+	source.Data.Account.Thresholds = xdr.Thresholds{1, 1, 1, 0}
+	source.Data.Account.Signers = []xdr.Signer{{Key: xdr.MustSigner(kp1.Address()), Weight: 1}}
+	other := accountEntry(kp2.Address(), 100*xlm, 1)
+	other.Data.Account.Thresholds = xdr.Thresholds{1, 1, 2, 0}
+	other.Data.Account.Signers = []xdr.Signer{{Key: xdr.MustSigner(kp1.Address()), Weight: 2}}
+	for _, testCase := range []struct {
+		tx       *Transaction
+		expected string
+	}{
+		{stripped, "tx_success"},
+		{tx, "tx_bad_auth_extra"},
+	} {
+		result, err := testCase.tx.Preflight(PreflightParams{
+			State:             NewStaticLedgerState(source, other),
+			NetworkPassphrase: network.TestNetworkPassphrase,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, result.TransactionCode)
+	}
+}
@@ -82,8 +82,7 @@ func operationFromXDR(xdrOp xdr.Operation) (Operation, error) {
 
 func accountFromXDR(account *xdr.MuxedAccount) string {
 	if account != nil {
-		aid := account.ToAccountId()
-		return aid.Address()
+		return account.Address()
 	}
 	return ""
 }
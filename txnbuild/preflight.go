@@ -0,0 +1,1369 @@
+package txnbuild
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// defaultBaseReserve is the base reserve of the public network and testnet in
+// stroops.
+const defaultBaseReserve = 5000000
+
+// maxSigners is the maximum number of signers an account can have, not
+// counting its master key.
+const maxSigners = 20
+
+// Result codes used by Preflight. They are the strings Horizon uses to report
+// the result codes of failed transactions.
+const (
+	txSuccess    = "tx_success"
+	txFailed     = "tx_failed"
+	opSuccess    = "op_success"
+	opMalformed  = "op_malformed"
+	opUnderfund  = "op_underfunded"
+	opLowReserve = "op_low_reserve"
+	opLineFull   = "op_line_full"
+	opNoTrust    = "op_no_trust"
+	opNotAuth    = "op_not_authorized"
+)
+
+// LedgerState provides the ledger entries Transaction.Preflight needs to
+// simulate a transaction. Implementations return nil entries, and no error,
+// for entries which don't exist. horizonclient.Client.PreflightTransaction
+// uses an implementation which loads the entries from Horizon;
+// NewStaticLedgerState returns one holding entries supplied directly.
+type LedgerState interface {
+	// Account returns the account with the given address.
+	Account(accountID string) (*xdr.AccountEntry, error)
+	// TrustLine returns the trust line of the account for a credit asset.
+	TrustLine(accountID string, asset xdr.Asset) (*xdr.TrustLineEntry, error)
+	// Offers returns all the offers of the account.
+	Offers(sellerID string) ([]xdr.OfferEntry, error)
+	// Data returns the data entry of the account with the given name.
+	Data(accountID, name string) (*xdr.DataEntry, error)
+}
+
+// StaticLedgerState is a LedgerState holding ledger entries which were
+// supplied directly, for example from a database or a test fixture.
+type StaticLedgerState struct {
+	accounts   map[string]xdr.AccountEntry
+	trustLines map[string]xdr.TrustLineEntry
+	offers     map[string][]xdr.OfferEntry
+	data       map[string]xdr.DataEntry
+}
+
+// NewStaticLedgerState returns a StaticLedgerState holding the account, trust
+// line, offer and data entries of entries. Entries of other types are ignored.
+func NewStaticLedgerState(entries ...xdr.LedgerEntry) *StaticLedgerState {
+	state := &StaticLedgerState{
+		accounts:   map[string]xdr.AccountEntry{},
+		trustLines: map[string]xdr.TrustLineEntry{},
+		offers:     map[string][]xdr.OfferEntry{},
+		data:       map[string]xdr.DataEntry{},
+	}
+	for _, entry := range entries {
+		switch entry.Data.Type {
+		case xdr.LedgerEntryTypeAccount:
+			account := entry.Data.MustAccount()
+			state.accounts[account.AccountId.Address()] = account
+		case xdr.LedgerEntryTypeTrustline:
+			line := entry.Data.MustTrustLine()
+			state.trustLines[trustLineKey(line.AccountId.Address(), line.Asset)] = line
+		case xdr.LedgerEntryTypeOffer:
+			offer := entry.Data.MustOffer()
+			seller := offer.SellerId.Address()
+			state.offers[seller] = append(state.offers[seller], offer)
+		case xdr.LedgerEntryTypeData:
+			data := entry.Data.MustData()
+			state.data[dataKey(data.AccountId.Address(), string(data.DataName))] = data
+		}
+	}
+	return state
+}
+
+// Account implements LedgerState.
+func (s *StaticLedgerState) Account(accountID string) (*xdr.AccountEntry, error) {
+	if account, ok := s.accounts[accountID]; ok {
+		return &account, nil
+	}
+	return nil, nil
+}
+
+// TrustLine implements LedgerState.
+func (s *StaticLedgerState) TrustLine(accountID string, asset xdr.Asset) (*xdr.TrustLineEntry, error) {
+	if line, ok := s.trustLines[trustLineKey(accountID, asset)]; ok {
+		return &line, nil
+	}
+	return nil, nil
+}
+
+// Offers implements LedgerState.
+func (s *StaticLedgerState) Offers(sellerID string) ([]xdr.OfferEntry, error) {
+	return append([]xdr.OfferEntry(nil), s.offers[sellerID]...), nil
+}
+
+// Data implements LedgerState.
+func (s *StaticLedgerState) Data(accountID, name string) (*xdr.DataEntry, error) {
+	if data, ok := s.data[dataKey(accountID, name)]; ok {
+		return &data, nil
+	}
+	return nil, nil
+}
+
+// PreflightParams configures Transaction.Preflight.
+type PreflightParams struct {
+	// State provides the ledger entries the transaction uses. It is required.
+	State LedgerState
+	// BaseFee is the base fee of the network in stroops. MinBaseFee is used
+	// if it is 0.
+	BaseFee int64
+	// BaseReserve is the base reserve of the network in stroops. 0.5 XLM is
+	// used if it is 0.
+	BaseReserve int64
+	// CloseTime is the expected close time of the ledger which will include
+	// the transaction, used to check its time bounds. The current time is
+	// used if it is zero.
+	CloseTime time.Time
+	// NetworkPassphrase is the passphrase of the network. If it is set, the
+	// signatures of the transaction are checked against the signers and
+	// thresholds of its source accounts.
+	NetworkPassphrase string
+}
+
+// PreflightResult is the outcome of the simulation of a transaction.
+type PreflightResult struct {
+	// TransactionCode is the predicted result code of the transaction, in the
+	// format Horizon uses to report result codes, e.g. "tx_success",
+	// "tx_failed" or "tx_bad_seq". It is empty if the outcome can't be
+	// predicted because the transaction contains operations which can't be
+	// simulated, and none of the others fail.
+	TransactionCode string
+	// OperationCodes are the predicted result codes of the operations, e.g.
+	// "op_success" or "op_underfunded". They are nil if the transaction fails
+	// before its operations are checked. The codes of operations which can't
+	// be simulated, like path payments and claims of claimable balances, are
+	// empty.
+	OperationCodes []string
+	// Fee is the fee in stroops charged for the transaction when the network
+	// isn't in surge pricing: the base fee times the number of operations.
+	Fee int64
+	// Reserves are the native balances and minimum balances of the accounts
+	// used by the transaction after it is applied. If the transaction is
+	// predicted to fail, they include the effects of the operations which are
+	// predicted to succeed.
+	Reserves []AccountReserve
+}
+
+// AccountReserve is the native balance of an account and the part of it
+// which can't be spent.
+type AccountReserve struct {
+	AccountID string
+	// Balance is the native balance of the account in stroops.
+	Balance int64
+	// MinimumBalance is the minimum balance in stroops required by the
+	// reserves of the account and its subentries.
+	MinimumBalance int64
+	// SellingLiabilities is the native amount in stroops reserved by the
+	// offers of the account.
+	SellingLiabilities int64
+}
+
+// Spendable returns the amount of lumens in stroops the account can spend.
+func (r AccountReserve) Spendable() int64 {
+	return r.Balance - r.MinimumBalance - r.SellingLiabilities
+}
+
+// Preflight predicts the result codes stellar-core would return for the
+// transaction and its operations if it was submitted to a network whose
+// ledger contains the entries provided by params.State. Operations are
+// simulated in order, so an operation sees the effects of the preceding
+// ones, e.g. a payment can use a trust line created earlier in the
+// transaction. Offers are checked against the other offers of their seller
+// but not against the rest of the order book, so offers which cross offers of
+// other accounts are predicted to succeed. The returned error is only
+// non-nil if the ledger entries could not be loaded.
+func (t *Transaction) Preflight(params PreflightParams) (PreflightResult, error) {
+	if params.State == nil {
+		return PreflightResult{}, errors.New("ledger state is required")
+	}
+	p := &preflight{
+		state:       params.State,
+		baseReserve: params.BaseReserve,
+		ledger:      newPreflightLedger(),
+		loaded:      newPreflightLedger(),
+	}
+	if p.baseReserve == 0 {
+		p.baseReserve = defaultBaseReserve
+	}
+	baseFee := params.BaseFee
+	if baseFee == 0 {
+		baseFee = MinBaseFee
+	}
+	closeTime := params.CloseTime
+	if closeTime.IsZero() {
+		closeTime = time.Now()
+	}
+
+	operations := t.envelope.Operations()
+	result := PreflightResult{Fee: baseFee * int64(len(operations))}
+	code, err := p.checkTransaction(t, params.NetworkPassphrase, closeTime, result.Fee)
+	if err != nil {
+		return PreflightResult{}, err
+	}
+	if code != "" {
+		result.TransactionCode = code
+		if code == txFailed {
+			result.OperationCodes = p.codes
+		}
+		return result, nil
+	}
+
+	source, err := p.account(accountAddress(t.envelope.SourceAccount()))
+	if err != nil {
+		return PreflightResult{}, err
+	}
+	source.balance -= result.Fee
+	source.seqNum = t.envelope.SeqNum()
+
+	failed, unknown := false, false
+	for i, op := range operations {
+		saved := p.ledger.clone()
+		code, err := p.apply(t.operationSource(op), op)
+		if err != nil {
+			return PreflightResult{}, errors.Wrapf(err, "could not simulate operation %d", i)
+		}
+		p.codes[i] = code
+		switch code {
+		case opSuccess:
+		case "":
+			unknown = true
+		default:
+			failed = true
+			p.ledger = saved
+			p.ledger.fill(p.loaded)
+		}
+	}
+
+	result.OperationCodes = p.codes
+	switch {
+	case failed:
+		result.TransactionCode = txFailed
+	case unknown:
+	case len(p.ledger.sponsors) > 0:
+		result.TransactionCode = "tx_bad_sponsorship"
+	default:
+		result.TransactionCode = txSuccess
+	}
+	for _, accountID := range p.accountIDs {
+		if account := p.ledger.accounts[accountID]; account != nil {
+			result.Reserves = append(result.Reserves, AccountReserve{
+				AccountID:          accountID,
+				Balance:            account.balance,
+				MinimumBalance:     p.minimumBalance(account),
+				SellingLiabilities: account.selling,
+			})
+		}
+	}
+	return result, nil
+}
+
+// operationSource returns the address of the source account of op.
+func (t *Transaction) operationSource(op xdr.Operation) string {
+	if op.SourceAccount != nil {
+		return accountAddress(*op.SourceAccount)
+	}
+	return accountAddress(t.envelope.SourceAccount())
+}
+
+// preflight simulates a transaction.
+type preflight struct {
+	state       LedgerState
+	baseReserve int64
+	ledger      preflightLedger
+	// loaded holds the entries as they were loaded from the state, so that
+	// entries loaded by failed operations aren't loaded again after their
+	// changes are discarded.
+	loaded preflightLedger
+	// accountIDs are the accounts used by the transaction, in the order in
+	// which they were loaded.
+	accountIDs []string
+	codes      []string
+}
+
+// preflightLedger holds the ledger entries loaded or changed by a simulated
+// transaction. A nil entry is an entry which doesn't exist.
+type preflightLedger struct {
+	accounts   map[string]*preflightAccount
+	trustLines map[string]*preflightTrustLine
+	offers     map[string][]xdr.OfferEntry
+	data       map[string]bool
+	// sponsors maps accounts whose future reserves are sponsored to their
+	// sponsors.
+	sponsors map[string]string
+}
+
+type preflightAccount struct {
+	id         string
+	balance    int64
+	buying     int64
+	selling    int64
+	seqNum     int64
+	subentries int64
+	sponsoring int64
+	sponsored  int64
+	flags      xdr.AccountFlags
+	thresholds xdr.Thresholds
+	signers    []xdr.Signer
+}
+
+type preflightTrustLine struct {
+	balance int64
+	limit   int64
+	buying  int64
+	selling int64
+	flags   xdr.TrustLineFlags
+}
+
+func newPreflightLedger() preflightLedger {
+	return preflightLedger{
+		accounts:   map[string]*preflightAccount{},
+		trustLines: map[string]*preflightTrustLine{},
+		offers:     map[string][]xdr.OfferEntry{},
+		data:       map[string]bool{},
+		sponsors:   map[string]string{},
+	}
+}
+
+// clone returns a deep copy of the ledger, which can be restored when an
+// operation fails. Slices are shared because they are never modified in
+// place.
+func (l preflightLedger) clone() preflightLedger {
+	c := newPreflightLedger()
+	for id, account := range l.accounts {
+		if account != nil {
+			copied := *account
+			account = &copied
+		}
+		c.accounts[id] = account
+	}
+	for key, line := range l.trustLines {
+		if line != nil {
+			copied := *line
+			line = &copied
+		}
+		c.trustLines[key] = line
+	}
+	for seller, offers := range l.offers {
+		c.offers[seller] = offers
+	}
+	for key, exists := range l.data {
+		c.data[key] = exists
+	}
+	for sponsored, sponsor := range l.sponsors {
+		c.sponsors[sponsored] = sponsor
+	}
+	return c
+}
+
+// fill adds the entries of other which aren't in the ledger.
+func (l preflightLedger) fill(other preflightLedger) {
+	other = other.clone()
+	for id, account := range other.accounts {
+		if _, ok := l.accounts[id]; !ok {
+			l.accounts[id] = account
+		}
+	}
+	for key, line := range other.trustLines {
+		if _, ok := l.trustLines[key]; !ok {
+			l.trustLines[key] = line
+		}
+	}
+	for seller, offers := range other.offers {
+		if _, ok := l.offers[seller]; !ok {
+			l.offers[seller] = offers
+		}
+	}
+	for key, exists := range other.data {
+		if _, ok := l.data[key]; !ok {
+			l.data[key] = exists
+		}
+	}
+}
+
+// checkTransaction runs the checks stellar-core runs before applying a
+// transaction and returns the result code of the transaction if one of them
+// fails.
+func (p *preflight) checkTransaction(t *Transaction, passphrase string, closeTime time.Time, fee int64) (string, error) {
+	operations := t.envelope.Operations()
+	p.codes = make([]string, len(operations))
+	for i := range p.codes {
+		p.codes[i] = opSuccess
+	}
+	if len(operations) == 0 {
+		return "tx_missing_operation", nil
+	}
+	if timeBounds := t.envelope.TimeBounds(); timeBounds != nil {
+		if closeTime.Unix() < int64(timeBounds.MinTime) {
+			return "tx_too_early", nil
+		}
+		if timeBounds.MaxTime != 0 && closeTime.Unix() > int64(timeBounds.MaxTime) {
+			return "tx_too_late", nil
+		}
+	}
+	if t.maxFee < fee {
+		return "tx_insufficient_fee", nil
+	}
+
+	source, err := p.account(accountAddress(t.envelope.SourceAccount()))
+	if err != nil {
+		return "", err
+	}
+	if source == nil {
+		return "tx_no_source_account", nil
+	}
+	if t.envelope.SeqNum() != source.seqNum+1 {
+		return "tx_bad_seq", nil
+	}
+
+	var checker *signatureChecker
+	if passphrase != "" {
+		hash, err := t.Hash(passphrase)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to hash transaction")
+		}
+		checker = newSignatureChecker(hash, t.Signatures())
+		if !checker.checkAccount(source.id, source.thresholds, source.signers, xdr.ThresholdIndexesThresholdLow) {
+			return "tx_bad_auth", nil
+		}
+	}
+	if p.availableBalance(source) < fee {
+		return "tx_insufficient_balance", nil
+	}
+	if checker == nil {
+		return "", nil
+	}
+
+	// the signatures of the operations are checked against the accounts as
+	// they are before the transaction is applied
+	for i, op := range operations {
+		account, err := p.account(t.operationSource(op))
+		if err != nil {
+			return "", err
+		}
+		var ok bool
+		if account != nil {
+			ok = checker.checkAccount(account.id, account.thresholds, account.signers, operationThreshold(op))
+		} else if op.SourceAccount != nil {
+			// an account created by the transaction has to sign with its
+			// master key
+			id := op.SourceAccount.ToAccountId()
+			ok = checker.checkAccount(id.Address(), xdr.Thresholds{1}, nil, xdr.ThresholdIndexesThresholdLow)
+		}
+		if !ok {
+			p.codes[i] = "op_bad_auth"
+			return txFailed, nil
+		}
+	}
+	if !checker.allUsed() {
+		return "tx_bad_auth_extra", nil
+	}
+	return "", nil
+}
+
+// apply simulates an operation and returns its result code, which is empty
+// if the operation can't be simulated.
+func (p *preflight) apply(sourceID string, op xdr.Operation) (string, error) {
+	source, err := p.account(sourceID)
+	if err != nil {
+		return "", err
+	}
+	if source == nil {
+		return "op_no_source_account", nil
+	}
+
+	body := op.Body
+	switch body.Type {
+	case xdr.OperationTypeCreateAccount:
+		return p.createAccount(source, body.MustCreateAccountOp())
+	case xdr.OperationTypePayment:
+		return p.payment(source, body.MustPaymentOp())
+	case xdr.OperationTypeManageSellOffer:
+		offer := body.MustManageSellOfferOp()
+		return p.manageOffer(source, newSellOffer(int64(offer.OfferId), offer.Selling, offer.Buying, int64(offer.Amount), offer.Price, false))
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		offer := body.MustCreatePassiveSellOfferOp()
+		if offer.Amount == 0 {
+			return opMalformed, nil
+		}
+		return p.manageOffer(source, newSellOffer(0, offer.Selling, offer.Buying, int64(offer.Amount), offer.Price, true))
+	case xdr.OperationTypeManageBuyOffer:
+		return p.manageOffer(source, newBuyOffer(body.MustManageBuyOfferOp()))
+	case xdr.OperationTypeSetOptions:
+		return p.setOptions(source, body.MustSetOptionsOp())
+	case xdr.OperationTypeChangeTrust:
+		return p.changeTrust(source, body.MustChangeTrustOp())
+	case xdr.OperationTypeAllowTrust:
+		op := body.MustAllowTrustOp()
+		if op.Asset.Type != xdr.AssetTypeAssetTypeCreditAlphanum4 && op.Asset.Type != xdr.AssetTypeAssetTypeCreditAlphanum12 {
+			return opMalformed, nil
+		}
+		asset := op.Asset.ToAsset(xdr.MustAddress(source.id))
+		authFlags := xdr.TrustLineFlagsAuthorizedFlag | xdr.TrustLineFlagsAuthorizedToMaintainLiabilitiesFlag
+		return p.setTrustLineFlags(source, op.Trustor.Address(), asset, authFlags, xdr.TrustLineFlags(op.Authorize))
+	case xdr.OperationTypeSetTrustLineFlags:
+		op := body.MustSetTrustLineFlagsOp()
+		return p.setTrustLineFlags(source, op.Trustor.Address(), op.Asset, xdr.TrustLineFlags(op.ClearFlags), xdr.TrustLineFlags(op.SetFlags))
+	case xdr.OperationTypeAccountMerge:
+		return p.accountMerge(source, body.MustDestination())
+	case xdr.OperationTypeInflation:
+		// inflation was disabled in protocol 12
+		return "op_not_time", nil
+	case xdr.OperationTypeManageData:
+		return p.manageData(source, body.MustManageDataOp())
+	case xdr.OperationTypeBumpSequence:
+		if bumpTo := int64(body.MustBumpSequenceOp().BumpTo); bumpTo > source.seqNum {
+			source.seqNum = bumpTo
+		}
+		return opSuccess, nil
+	case xdr.OperationTypeBeginSponsoringFutureReserves:
+		op := body.MustBeginSponsoringFutureReservesOp()
+		return p.beginSponsoring(source, op.SponsoredId.Address())
+	case xdr.OperationTypeEndSponsoringFutureReserves:
+		if _, ok := p.ledger.sponsors[source.id]; !ok {
+			return "op_not_sponsored", nil
+		}
+		delete(p.ledger.sponsors, source.id)
+		return opSuccess, nil
+	case xdr.OperationTypeCreateClaimableBalance:
+		return p.createClaimableBalance(source, body.MustCreateClaimableBalanceOp())
+	case xdr.OperationTypeClawback:
+		return p.clawback(source, body.MustClawbackOp())
+	default:
+		// path payments depend on the order book, and claimable balances
+		// and sponsorships of existing entries aren't part of LedgerState
+		return "", nil
+	}
+}
+
+func (p *preflight) createAccount(source *preflightAccount, op xdr.CreateAccountOp) (string, error) {
+	destinationID := op.Destination.Address()
+	destination, err := p.account(destinationID)
+	if err != nil {
+		return "", err
+	}
+	if destination != nil {
+		return "op_already_exists", nil
+	}
+
+	destination = &preflightAccount{
+		id:         destinationID,
+		balance:    int64(op.StartingBalance),
+		thresholds: xdr.Thresholds{1},
+	}
+	if sponsorID, ok := p.ledger.sponsors[destinationID]; ok {
+		sponsor, err := p.account(sponsorID)
+		if err != nil {
+			return "", err
+		}
+		if sponsor == nil {
+			return opLowReserve, nil
+		}
+		sponsor.sponsoring += 2
+		destination.sponsored = 2
+		if p.availableBalance(sponsor) < 0 {
+			return opLowReserve, nil
+		}
+	} else if p.availableBalance(destination) < 0 {
+		return opLowReserve, nil
+	}
+	if p.availableBalance(source) < int64(op.StartingBalance) {
+		return opUnderfund, nil
+	}
+	source.balance -= int64(op.StartingBalance)
+	p.ledger.accounts[destinationID] = destination
+	return opSuccess, nil
+}
+
+func (p *preflight) payment(source *preflightAccount, op xdr.PaymentOp) (string, error) {
+	destination, err := p.account(accountAddress(op.Destination))
+	if err != nil {
+		return "", err
+	}
+	if destination == nil {
+		return "op_no_destination", nil
+	}
+	amount := int64(op.Amount)
+
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative {
+		if nativeCapacity(destination) < amount {
+			return opLineFull, nil
+		}
+		destination.balance += amount
+		if p.availableBalance(source) < amount {
+			return opUnderfund, nil
+		}
+		source.balance -= amount
+		return opSuccess, nil
+	}
+
+	issuer := assetIssuer(op.Asset)
+	if destination.id != issuer {
+		line, err := p.trustLine(destination.id, op.Asset)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case line == nil:
+			return opNoTrust, nil
+		case !line.flags.IsAuthorized():
+			return opNotAuth, nil
+		case line.capacity() < amount:
+			return opLineFull, nil
+		}
+		line.balance += amount
+	}
+	if source.id != issuer {
+		line, err := p.trustLine(source.id, op.Asset)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case line == nil:
+			return "op_src_no_trust", nil
+		case !line.flags.IsAuthorized():
+			return "op_src_not_authorized", nil
+		case line.available() < amount:
+			return opUnderfund, nil
+		}
+		line.balance -= amount
+	}
+	return opSuccess, nil
+}
+
+// offerChange is a new, updated or deleted offer, as a sell offer.
+type offerChange struct {
+	id      int64
+	selling xdr.Asset
+	buying  xdr.Asset
+	// amount is the amount of the selling asset. It is 0 if the offer is
+	// deleted.
+	amount int64
+	// price is the price of the selling asset in terms of the buying asset.
+	price              xdr.Price
+	passive            bool
+	sellingLiabilities int64
+	buyingLiabilities  int64
+}
+
+func newSellOffer(id int64, selling, buying xdr.Asset, amount int64, price xdr.Price, passive bool) offerChange {
+	return offerChange{
+		id:                 id,
+		selling:            selling,
+		buying:             buying,
+		amount:             amount,
+		price:              price,
+		passive:            passive,
+		sellingLiabilities: amount,
+		buyingLiabilities:  multiplyPrice(amount, price.N, price.D),
+	}
+}
+
+func newBuyOffer(op xdr.ManageBuyOfferOp) offerChange {
+	// the price of a buy offer is the price of the buying asset in terms of
+	// the selling asset
+	sellingAmount := multiplyPrice(int64(op.BuyAmount), op.Price.N, op.Price.D)
+	return offerChange{
+		id:                 int64(op.OfferId),
+		selling:            op.Selling,
+		buying:             op.Buying,
+		amount:             sellingAmount,
+		price:              xdr.Price{N: op.Price.D, D: op.Price.N},
+		sellingLiabilities: sellingAmount,
+		buyingLiabilities:  int64(op.BuyAmount),
+	}
+}
+
+// multiplyPrice returns amount * n / d, rounded up.
+func multiplyPrice(amount int64, n, d xdr.Int32) int64 {
+	if d <= 0 {
+		return math.MaxInt64
+	}
+	product := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(n)))
+	product.Add(product, big.NewInt(int64(d)-1))
+	product.Quo(product, big.NewInt(int64(d)))
+	if !product.IsInt64() {
+		return math.MaxInt64
+	}
+	return product.Int64()
+}
+
+func (p *preflight) manageOffer(source *preflightAccount, change offerChange) (string, error) {
+	deleting := change.amount == 0
+	if deleting && change.id == 0 {
+		return opMalformed, nil
+	}
+	if !deleting {
+		if code, err := p.checkOfferLine(source, change.selling, "op_sell_no_trust", "sell_not_authorized"); code != "" || err != nil {
+			return code, err
+		}
+		if code, err := p.checkOfferLine(source, change.buying, "op_buy_no_trust", "buy_not_authorized"); code != "" || err != nil {
+			return code, err
+		}
+	}
+
+	offers, err := p.offers(source.id)
+	if err != nil {
+		return "", err
+	}
+	remaining := make([]xdr.OfferEntry, 0, len(offers)+1)
+	found := false
+	for _, offer := range offers {
+		if change.id != 0 && int64(offer.OfferId) == change.id {
+			found = true
+			if err := p.releaseOffer(source, offer); err != nil {
+				return "", err
+			}
+			continue
+		}
+		remaining = append(remaining, offer)
+	}
+	if change.id != 0 && !found {
+		return "op_offer_not_found", nil
+	}
+	if deleting {
+		source.subentries--
+		p.ledger.offers[source.id] = remaining
+		return opSuccess, nil
+	}
+
+	if change.id == 0 {
+		ok, err := p.addSubentries(source, 1)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return opLowReserve, nil
+		}
+	}
+	available, _, err := p.assetLimits(source, change.selling)
+	if err != nil {
+		return "", err
+	}
+	if available < change.sellingLiabilities {
+		return opUnderfund, nil
+	}
+	_, capacity, err := p.assetLimits(source, change.buying)
+	if err != nil {
+		return "", err
+	}
+	if capacity < change.buyingLiabilities {
+		return opLineFull, nil
+	}
+	for _, offer := range remaining {
+		if offer.Selling.Equals(change.buying) && offer.Buying.Equals(change.selling) && crosses(change, offer.Price) {
+			return "op_cross_self", nil
+		}
+	}
+
+	offer := xdr.OfferEntry{
+		SellerId: xdr.MustAddress(source.id),
+		OfferId:  xdr.Int64(change.id),
+		Selling:  change.selling,
+		Buying:   change.buying,
+		Amount:   xdr.Int64(change.amount),
+		Price:    change.price,
+	}
+	if change.passive {
+		offer.Flags = xdr.Uint32(xdr.OfferEntryFlagsPassiveFlag)
+	}
+	if err := p.addLiabilities(source, change.selling, change.buying, change.sellingLiabilities, change.buyingLiabilities); err != nil {
+		return "", err
+	}
+	p.ledger.offers[source.id] = append(remaining, offer)
+	return opSuccess, nil
+}
+
+// checkOfferLine checks the account can trade asset, returning noTrust or
+// notAuthorized if it can't.
+func (p *preflight) checkOfferLine(account *preflightAccount, asset xdr.Asset, noTrust, notAuthorized string) (string, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative || assetIssuer(asset) == account.id {
+		return "", nil
+	}
+	line, err := p.trustLine(account.id, asset)
+	if err != nil {
+		return "", err
+	}
+	if line == nil {
+		return noTrust, nil
+	}
+	if !line.flags.IsAuthorized() {
+		return notAuthorized, nil
+	}
+	return "", nil
+}
+
+// crosses returns true if the offer crosses an offer selling its buying
+// asset for its selling asset at price, which is the price of the buying
+// asset in terms of the selling asset.
+func crosses(change offerChange, price xdr.Price) bool {
+	left := int64(change.price.N) * int64(price.N)
+	right := int64(change.price.D) * int64(price.D)
+	if change.passive {
+		// passive offers don't take offers at the same price
+		return left < right
+	}
+	return left <= right
+}
+
+// releaseOffer removes the liabilities of an offer of the account.
+func (p *preflight) releaseOffer(account *preflightAccount, offer xdr.OfferEntry) error {
+	buying := multiplyPrice(int64(offer.Amount), offer.Price.N, offer.Price.D)
+	return p.addLiabilities(account, offer.Selling, offer.Buying, -int64(offer.Amount), -buying)
+}
+
+// addLiabilities adds liabilities to the native balance or trust lines of
+// the account. Issuers don't have liabilities for their own assets.
+func (p *preflight) addLiabilities(account *preflightAccount, selling, buying xdr.Asset, sellingAmount, buyingAmount int64) error {
+	if selling.Type == xdr.AssetTypeAssetTypeNative {
+		account.selling += sellingAmount
+	} else if assetIssuer(selling) != account.id {
+		line, err := p.trustLine(account.id, selling)
+		if err != nil {
+			return err
+		}
+		if line != nil {
+			line.selling += sellingAmount
+		}
+	}
+	if buying.Type == xdr.AssetTypeAssetTypeNative {
+		account.buying += buyingAmount
+	} else if assetIssuer(buying) != account.id {
+		line, err := p.trustLine(account.id, buying)
+		if err != nil {
+			return err
+		}
+		if line != nil {
+			line.buying += buyingAmount
+		}
+	}
+	return nil
+}
+
+// assetLimits returns the amount of asset the account can send and the
+// amount it can receive.
+func (p *preflight) assetLimits(account *preflightAccount, asset xdr.Asset) (int64, int64, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		return p.availableBalance(account), nativeCapacity(account), nil
+	}
+	if assetIssuer(asset) == account.id {
+		return math.MaxInt64, math.MaxInt64, nil
+	}
+	line, err := p.trustLine(account.id, asset)
+	if err != nil || line == nil {
+		return 0, 0, err
+	}
+	return line.available(), line.capacity(), nil
+}
+
+func (p *preflight) setOptions(source *preflightAccount, op xdr.SetOptionsOp) (string, error) {
+	if op.InflationDest != nil {
+		destination, err := p.account(op.InflationDest.Address())
+		if err != nil {
+			return "", err
+		}
+		if destination == nil {
+			return "op_invalid_inflation", nil
+		}
+	}
+	if op.ClearFlags != nil || op.SetFlags != nil {
+		if source.flags.IsAuthImmutable() {
+			return "op_cant_change", nil
+		}
+		flags := source.flags
+		if op.ClearFlags != nil {
+			flags &^= xdr.AccountFlags(*op.ClearFlags)
+		}
+		if op.SetFlags != nil {
+			flags |= xdr.AccountFlags(*op.SetFlags)
+		}
+		if flags.IsAuthClawbackEnabled() && !flags.IsAuthRevocable() {
+			return "op_auth_revocable_required", nil
+		}
+		source.flags = flags
+	}
+	for i, value := range []*xdr.Uint32{op.MasterWeight, op.LowThreshold, op.MedThreshold, op.HighThreshold} {
+		if value != nil {
+			source.thresholds[i] = byte(*value)
+		}
+	}
+	if op.Signer == nil {
+		return opSuccess, nil
+	}
+
+	signer := *op.Signer
+	if signer.Key.Address() == source.id {
+		return "op_bad_signer", nil
+	}
+	signers := make([]xdr.Signer, 0, len(source.signers)+1)
+	found := false
+	for _, existing := range source.signers {
+		if existing.Key.Equals(signer.Key) {
+			found = true
+			if signer.Weight == 0 {
+				continue
+			}
+			existing.Weight = signer.Weight
+		}
+		signers = append(signers, existing)
+	}
+	switch {
+	case found && signer.Weight == 0:
+		source.subentries--
+	case !found && signer.Weight > 0:
+		if len(source.signers) >= maxSigners {
+			return "op_too_many_signers", nil
+		}
+		ok, err := p.addSubentries(source, 1)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return opLowReserve, nil
+		}
+		signers = append(signers, signer)
+	}
+	source.signers = signers
+	return opSuccess, nil
+}
+
+func (p *preflight) changeTrust(source *preflightAccount, op xdr.ChangeTrustOp) (string, error) {
+	if op.Line.Type == xdr.AssetTypeAssetTypeNative {
+		return opMalformed, nil
+	}
+	issuerID := assetIssuer(op.Line)
+	if issuerID == source.id {
+		return "op_self_not_allowed", nil
+	}
+	line, err := p.trustLine(source.id, op.Line)
+	if err != nil {
+		return "", err
+	}
+	limit := int64(op.Limit)
+
+	if line != nil {
+		if limit < line.balance+line.buying {
+			return "op_invalid_limit", nil
+		}
+		if limit == 0 {
+			p.ledger.trustLines[trustLineKey(source.id, op.Line)] = nil
+			source.subentries--
+		} else {
+			line.limit = limit
+		}
+		return opSuccess, nil
+	}
+
+	if limit == 0 {
+		return "op_invalid_limit", nil
+	}
+	issuer, err := p.account(issuerID)
+	if err != nil {
+		return "", err
+	}
+	if issuer == nil {
+		return "op_no_issuer", nil
+	}
+	ok, err := p.addSubentries(source, 1)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return opLowReserve, nil
+	}
+	line = &preflightTrustLine{limit: limit}
+	if !issuer.flags.IsAuthRequired() {
+		line.flags |= xdr.TrustLineFlagsAuthorizedFlag
+	}
+	if issuer.flags.IsAuthClawbackEnabled() {
+		line.flags |= xdr.TrustLineFlagsTrustlineClawbackEnabledFlag
+	}
+	p.ledger.trustLines[trustLineKey(source.id, op.Line)] = line
+	return opSuccess, nil
+}
+
+// setTrustLineFlags simulates AllowTrust and SetTrustLineFlags operations.
+func (p *preflight) setTrustLineFlags(source *preflightAccount, trustorID string, asset xdr.Asset, clearFlags, setFlags xdr.TrustLineFlags) (string, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative || assetIssuer(asset) != source.id {
+		return opMalformed, nil
+	}
+	line, err := p.trustLine(trustorID, asset)
+	if err != nil {
+		return "", err
+	}
+	if line == nil {
+		return opNoTrust, nil
+	}
+
+	flags := line.flags&^clearFlags | setFlags
+	maintainsLiabilities := flags.IsAuthorized() || flags.IsAuthorizedToMaintainLiabilitiesFlag()
+	revoked := line.flags.IsAuthorized() && !flags.IsAuthorized() ||
+		line.flags.IsAuthorizedToMaintainLiabilitiesFlag() && !maintainsLiabilities
+	if revoked && !source.flags.IsAuthRevocable() {
+		return "op_cant_revoke", nil
+	}
+	if flags.IsAuthorized() && flags.IsAuthorizedToMaintainLiabilitiesFlag() {
+		return "op_invalid_state", nil
+	}
+	line.flags = flags
+	if maintainsLiabilities {
+		return opSuccess, nil
+	}
+
+	// the offers of the trustor for the asset are removed when it can't
+	// maintain liabilities anymore
+	trustor, err := p.account(trustorID)
+	if err != nil || trustor == nil {
+		return opSuccess, err
+	}
+	offers, err := p.offers(trustorID)
+	if err != nil {
+		return "", err
+	}
+	var remaining []xdr.OfferEntry
+	for _, offer := range offers {
+		if offer.Selling.Equals(asset) || offer.Buying.Equals(asset) {
+			if err := p.releaseOffer(trustor, offer); err != nil {
+				return "", err
+			}
+			trustor.subentries--
+			continue
+		}
+		remaining = append(remaining, offer)
+	}
+	p.ledger.offers[trustorID] = remaining
+	return opSuccess, nil
+}
+
+func (p *preflight) accountMerge(source *preflightAccount, destinationAccount xdr.MuxedAccount) (string, error) {
+	destination, err := p.account(accountAddress(destinationAccount))
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case destination == nil:
+		return "op_no_account", nil
+	case destination.id == source.id:
+		return opMalformed, nil
+	case source.flags.IsAuthImmutable():
+		return "op_immutable_set", nil
+	case source.subentries != int64(len(source.signers)):
+		return "op_has_sub_entries", nil
+	case source.sponsoring > 0:
+		return "op_is_sponsor", nil
+	case nativeCapacity(destination) < source.balance:
+		return "op_dest_full", nil
+	}
+	destination.balance += source.balance
+	p.ledger.accounts[source.id] = nil
+	return opSuccess, nil
+}
+
+func (p *preflight) manageData(source *preflightAccount, op xdr.ManageDataOp) (string, error) {
+	name := string(op.DataName)
+	exists, err := p.data(source.id, name)
+	if err != nil {
+		return "", err
+	}
+	if op.DataValue == nil {
+		if !exists {
+			return "op_data_name_not_found", nil
+		}
+		source.subentries--
+		p.ledger.data[dataKey(source.id, name)] = false
+		return opSuccess, nil
+	}
+	if !exists {
+		ok, err := p.addSubentries(source, 1)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return opLowReserve, nil
+		}
+		p.ledger.data[dataKey(source.id, name)] = true
+	}
+	return opSuccess, nil
+}
+
+func (p *preflight) beginSponsoring(source *preflightAccount, sponsoredID string) (string, error) {
+	if sponsoredID == source.id {
+		return opMalformed, nil
+	}
+	if _, ok := p.ledger.sponsors[sponsoredID]; ok {
+		return "op_already_sponsored", nil
+	}
+	if _, ok := p.ledger.sponsors[source.id]; ok {
+		return "op_recursive", nil
+	}
+	for _, sponsor := range p.ledger.sponsors {
+		if sponsor == sponsoredID {
+			return "op_recursive", nil
+		}
+	}
+	p.ledger.sponsors[sponsoredID] = source.id
+	return opSuccess, nil
+}
+
+func (p *preflight) createClaimableBalance(source *preflightAccount, op xdr.CreateClaimableBalanceOp) (string, error) {
+	amount := int64(op.Amount)
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative {
+		if p.availableBalance(source) < amount {
+			return opUnderfund, nil
+		}
+		source.balance -= amount
+	} else if assetIssuer(op.Asset) != source.id {
+		line, err := p.trustLine(source.id, op.Asset)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case line == nil:
+			return opNoTrust, nil
+		case !line.flags.IsAuthorized():
+			return opNotAuth, nil
+		case line.available() < amount:
+			return opUnderfund, nil
+		}
+		line.balance -= amount
+	}
+
+	// claimable balances are always sponsored, by the source account unless
+	// its future reserves are sponsored
+	sponsor := source
+	if sponsorID, ok := p.ledger.sponsors[source.id]; ok {
+		var err error
+		if sponsor, err = p.account(sponsorID); err != nil {
+			return "", err
+		}
+		if sponsor == nil {
+			return opLowReserve, nil
+		}
+	}
+	sponsor.sponsoring += int64(len(op.Claimants))
+	if p.availableBalance(sponsor) < 0 {
+		return opLowReserve, nil
+	}
+	return opSuccess, nil
+}
+
+func (p *preflight) clawback(source *preflightAccount, op xdr.ClawbackOp) (string, error) {
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative || assetIssuer(op.Asset) != source.id {
+		return opMalformed, nil
+	}
+	line, err := p.trustLine(accountAddress(op.From), op.Asset)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case line == nil:
+		return opNoTrust, nil
+	case !line.flags.IsClawbackEnabledFlag():
+		return "op_not_clawback_enabled", nil
+	case line.available() < int64(op.Amount):
+		return opUnderfund, nil
+	}
+	line.balance -= int64(op.Amount)
+	return opSuccess, nil
+}
+
+// addSubentries adds n subentries to the account. If the future reserves of
+// the account are sponsored, the sponsor pays for the subentries. It returns
+// false if the account paying for them doesn't have enough lumens.
+func (p *preflight) addSubentries(account *preflightAccount, n int64) (bool, error) {
+	account.subentries += n
+	sponsorID, ok := p.ledger.sponsors[account.id]
+	if !ok {
+		return p.availableBalance(account) >= 0, nil
+	}
+	sponsor, err := p.account(sponsorID)
+	if err != nil || sponsor == nil {
+		return false, err
+	}
+	account.sponsored += n
+	sponsor.sponsoring += n
+	return p.availableBalance(sponsor) >= 0, nil
+}
+
+func (p *preflight) minimumBalance(account *preflightAccount) int64 {
+	return (2 + account.subentries + account.sponsoring - account.sponsored) * p.baseReserve
+}
+
+// availableBalance returns the amount of lumens the account can spend.
+func (p *preflight) availableBalance(account *preflightAccount) int64 {
+	return account.balance - p.minimumBalance(account) - account.selling
+}
+
+// nativeCapacity returns the amount of lumens the account can receive.
+func nativeCapacity(account *preflightAccount) int64 {
+	return math.MaxInt64 - account.balance - account.buying
+}
+
+func (l *preflightTrustLine) available() int64 {
+	return l.balance - l.selling
+}
+
+func (l *preflightTrustLine) capacity() int64 {
+	return l.limit - l.balance - l.buying
+}
+
+// account returns the account, loading it from the state if it hasn't been
+// loaded yet.
+func (p *preflight) account(accountID string) (*preflightAccount, error) {
+	if account, ok := p.ledger.accounts[accountID]; ok {
+		return account, nil
+	}
+	entry, err := p.state.Account(accountID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load account %s", accountID)
+	}
+	var account *preflightAccount
+	if entry != nil {
+		liabilities := entry.Liabilities()
+		account = &preflightAccount{
+			id:         accountID,
+			balance:    int64(entry.Balance),
+			buying:     int64(liabilities.Buying),
+			selling:    int64(liabilities.Selling),
+			seqNum:     int64(entry.SeqNum),
+			subentries: int64(entry.NumSubEntries),
+			sponsoring: int64(entry.NumSponsoring()),
+			sponsored:  int64(entry.NumSponsored()),
+			flags:      xdr.AccountFlags(entry.Flags),
+			thresholds: entry.Thresholds,
+			signers:    entry.Signers,
+		}
+	}
+	p.ledger.accounts[accountID] = account
+	if account != nil {
+		copied := *account
+		p.loaded.accounts[accountID] = &copied
+	} else {
+		p.loaded.accounts[accountID] = nil
+	}
+	p.accountIDs = append(p.accountIDs, accountID)
+	return account, nil
+}
+
+// trustLine returns the trust line, loading it from the state if it hasn't
+// been loaded yet.
+func (p *preflight) trustLine(accountID string, asset xdr.Asset) (*preflightTrustLine, error) {
+	key := trustLineKey(accountID, asset)
+	if line, ok := p.ledger.trustLines[key]; ok {
+		return line, nil
+	}
+	entry, err := p.state.TrustLine(accountID, asset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load trust line of %s for %s", accountID, asset.StringCanonical())
+	}
+	var line *preflightTrustLine
+	if entry != nil {
+		liabilities := entry.Liabilities()
+		line = &preflightTrustLine{
+			balance: int64(entry.Balance),
+			limit:   int64(entry.Limit),
+			buying:  int64(liabilities.Buying),
+			selling: int64(liabilities.Selling),
+			flags:   xdr.TrustLineFlags(entry.Flags),
+		}
+	}
+	p.ledger.trustLines[key] = line
+	if line != nil {
+		copied := *line
+		p.loaded.trustLines[key] = &copied
+	} else {
+		p.loaded.trustLines[key] = nil
+	}
+	return line, nil
+}
+
+// offers returns the offers of the account, loading them from the state if
+// they haven't been loaded yet.
+func (p *preflight) offers(sellerID string) ([]xdr.OfferEntry, error) {
+	if offers, ok := p.ledger.offers[sellerID]; ok {
+		return offers, nil
+	}
+	offers, err := p.state.Offers(sellerID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load offers of %s", sellerID)
+	}
+	p.ledger.offers[sellerID] = offers
+	p.loaded.offers[sellerID] = offers
+	return offers, nil
+}
+
+// data returns true if the account has a data entry with the given name.
+func (p *preflight) data(accountID, name string) (bool, error) {
+	key := dataKey(accountID, name)
+	if exists, ok := p.ledger.data[key]; ok {
+		return exists, nil
+	}
+	entry, err := p.state.Data(accountID, name)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not load data entry %s of %s", name, accountID)
+	}
+	p.ledger.data[key] = entry != nil
+	p.loaded.data[key] = entry != nil
+	return entry != nil, nil
+}
+
+func trustLineKey(accountID string, asset xdr.Asset) string {
+	return accountID + "/" + asset.StringCanonical()
+}
+
+func dataKey(accountID, name string) string {
+	return accountID + "/" + name
+}
+
+// accountAddress returns the address of the account underlying a muxed
+// account.
+func accountAddress(account xdr.MuxedAccount) string {
+	id := account.ToAccountId()
+	return id.Address()
+}
+
+// assetIssuer returns the address of the issuer of a credit asset.
+func assetIssuer(asset xdr.Asset) string {
+	switch asset.Type {
+	case xdr.AssetTypeAssetTypeCreditAlphanum4:
+		return asset.AlphaNum4.Issuer.Address()
+	case xdr.AssetTypeAssetTypeCreditAlphanum12:
+		return asset.AlphaNum12.Issuer.Address()
+	default:
+		return ""
+	}
+}
+
+// operationThreshold returns the threshold of the signatures required by an
+// operation.
+func operationThreshold(op xdr.Operation) xdr.ThresholdIndexes {
+	switch op.Body.Type {
+	case xdr.OperationTypeAllowTrust, xdr.OperationTypeSetTrustLineFlags,
+		xdr.OperationTypeBumpSequence, xdr.OperationTypeClaimClaimableBalance,
+		xdr.OperationTypeInflation:
+		return xdr.ThresholdIndexesThresholdLow
+	case xdr.OperationTypeAccountMerge:
+		return xdr.ThresholdIndexesThresholdHigh
+	case xdr.OperationTypeSetOptions:
+		setOptions := op.Body.MustSetOptionsOp()
+		if setOptions.MasterWeight != nil || setOptions.LowThreshold != nil ||
+			setOptions.MedThreshold != nil || setOptions.HighThreshold != nil ||
+			setOptions.Signer != nil {
+			return xdr.ThresholdIndexesThresholdHigh
+		}
+	}
+	return xdr.ThresholdIndexesThresholdMed
+}
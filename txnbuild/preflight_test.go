@@ -0,0 +1,359 @@
+package txnbuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xlm = 10000000
+
+func accountEntry(address string, balance int64, subentries uint32) xdr.LedgerEntry {
+	return xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeAccount,
+		Account: &xdr.AccountEntry{
+			AccountId:     xdr.MustAddress(address),
+			Balance:       xdr.Int64(balance),
+			SeqNum:        1,
+			NumSubEntries: xdr.Uint32(subentries),
+			Thresholds:    xdr.Thresholds{1, 0, 0, 0},
+		},
+	}}
+}
+
+func trustLineEntry(address string, asset xdr.Asset, balance, limit int64) xdr.LedgerEntry {
+	return xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeTrustline,
+		TrustLine: &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress(address),
+			Asset:     asset,
+			Balance:   xdr.Int64(balance),
+			Limit:     xdr.Int64(limit),
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		},
+	}}
+}
+
+func preflightTransaction(t *testing.T, source string, ops ...Operation) *Transaction {
+	tx, err := NewTransaction(TransactionParams{
+		SourceAccount:        &SimpleAccount{AccountID: source, Sequence: 1},
+		IncrementSequenceNum: true,
+		BaseFee:              MinBaseFee,
+		Timebounds:           NewInfiniteTimeout(),
+		Operations:           ops,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestPreflightPayments(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	usd := CreditAsset{Code: "USD", Issuer: kp2.Address()}
+	xdrUSD, err := usd.ToXDR()
+	require.NoError(t, err)
+	state := NewStaticLedgerState(
+		accountEntry(kp0.Address(), 100*xlm, 1),
+		accountEntry(kp1.Address(), 5*xlm, 0),
+		accountEntry(kp2.Address(), 5*xlm, 0),
+		trustLineEntry(kp0.Address(), xdrUSD, 50*xlm, 100*xlm),
+	)
+
+	tx := preflightTransaction(t, kp0.Address(),
+		&Payment{Destination: kp1.Address(), Amount: "10", Asset: NativeAsset{}},
+		&Payment{Destination: kp1.Address(), Amount: "10", Asset: usd},
+		&ChangeTrust{Line: usd, SourceAccount: kp1.Address()},
+		&Payment{Destination: kp1.Address(), Amount: "60", Asset: usd},
+		&Payment{Destination: kp1.Address(), Amount: "50", Asset: usd},
+		&Payment{Destination: keypair.MustRandom().Address(), Amount: "1", Asset: NativeAsset{}},
+		&Payment{Destination: kp2.Address(), Amount: "1", Asset: usd, SourceAccount: kp1.Address()},
+	)
+	result, err := tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{
+		"op_success",
+		"op_no_trust",
+		"op_success",
+		"op_underfunded",
+		"op_success",
+		"op_no_destination",
+		"op_success",
+	}, result.OperationCodes)
+	assert.Equal(t, int64(700), result.Fee)
+	assert.Equal(t, []AccountReserve{
+		{AccountID: kp0.Address(), Balance: 90*xlm - 700, MinimumBalance: 15 * xlm / 10},
+		{AccountID: kp1.Address(), Balance: 15 * xlm, MinimumBalance: 15 * xlm / 10},
+		{AccountID: kp2.Address(), Balance: 5 * xlm, MinimumBalance: xlm},
+	}, result.Reserves)
+	assert.Equal(t, int64(90*xlm-700-15*xlm/10), result.Reserves[0].Spendable())
+
+	// the payment of the trust line limit fails once the trust line holds
+	// the other payments
+	tx = preflightTransaction(t, kp0.Address(),
+		&ChangeTrust{Line: usd, Limit: "20", SourceAccount: kp1.Address()},
+		&Payment{Destination: kp1.Address(), Amount: "20", Asset: usd},
+		&Payment{Destination: kp1.Address(), Amount: "1", Asset: usd},
+	)
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{"op_success", "op_success", "op_line_full"}, result.OperationCodes)
+}
+
+func TestPreflightReserves(t *testing.T) {
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	newAccount := keypair.MustRandom().Address()
+	state := NewStaticLedgerState(
+		accountEntry(kp0.Address(), 3*xlm, 1),
+		accountEntry(kp1.Address(), 100*xlm, 0),
+	)
+
+	tx := preflightTransaction(t, kp0.Address(),
+		&CreateAccount{Destination: newAccount, Amount: "0.5"},
+		&Payment{Destination: kp1.Address(), Amount: "2", Asset: NativeAsset{}},
+		&ManageData{Name: "name", Value: []byte("value")},
+		&Payment{Destination: kp1.Address(), Amount: "1", Asset: NativeAsset{}},
+		&ManageData{Name: "other", Value: nil},
+	)
+	result, err := tx.Preflight(PreflightParams{State: state, BaseReserve: xlm / 2})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{
+		"op_low_reserve",
+		"op_underfunded",
+		"op_success",
+		"op_underfunded",
+		"op_data_name_not_found",
+	}, result.OperationCodes)
+
+	// the new account is sponsored
+	tx = preflightTransaction(t, kp1.Address(),
+		&BeginSponsoringFutureReserves{SponsoredID: newAccount},
+		&CreateAccount{Destination: newAccount, Amount: "0"},
+		&EndSponsoringFutureReserves{SourceAccount: newAccount},
+	)
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_success", result.TransactionCode)
+	assert.Equal(t, []string{"op_success", "op_success", "op_success"}, result.OperationCodes)
+	assert.Equal(t, []AccountReserve{
+		{AccountID: kp1.Address(), Balance: 100*xlm - 300, MinimumBalance: 2 * xlm},
+		{AccountID: newAccount, Balance: 0, MinimumBalance: 0},
+	}, result.Reserves)
+
+	tx = preflightTransaction(t, kp1.Address(),
+		&BeginSponsoringFutureReserves{SponsoredID: newAccount},
+		&CreateAccount{Destination: newAccount, Amount: "0"},
+	)
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_sponsorship", result.TransactionCode)
+}
+
+func TestPreflightTransactionChecks(t *testing.T) {
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	state := NewStaticLedgerState(
+		accountEntry(kp0.Address(), 100*xlm, 0),
+		accountEntry(kp1.Address(), xlm, 0),
+	)
+	payment := &Payment{Destination: kp1.Address(), Amount: "10", Asset: NativeAsset{}}
+
+	tx := preflightTransaction(t, kp0.Address(), payment)
+	result, err := tx.Preflight(PreflightParams{State: state, BaseFee: 200})
+	require.NoError(t, err)
+	assert.Equal(t, PreflightResult{TransactionCode: "tx_insufficient_fee", Fee: 200}, result)
+
+	tx = preflightTransaction(t, keypair.MustRandom().Address(), payment)
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_no_source_account", result.TransactionCode)
+	assert.Nil(t, result.OperationCodes)
+
+	tx, err = NewTransaction(TransactionParams{
+		SourceAccount: &SimpleAccount{AccountID: kp0.Address(), Sequence: 5},
+		BaseFee:       MinBaseFee,
+		Timebounds:    NewTimebounds(0, 1000),
+		Operations:    []Operation{payment},
+	})
+	require.NoError(t, err)
+	result, err = tx.Preflight(PreflightParams{State: state, CloseTime: time.Unix(500, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_seq", result.TransactionCode)
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_too_late", result.TransactionCode)
+
+	tx = preflightTransaction(t, kp1.Address(), &BumpSequence{BumpTo: 10})
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_insufficient_balance", result.TransactionCode)
+
+	tx = preflightTransaction(t, kp0.Address(), &Inflation{})
+	_, err = tx.Preflight(PreflightParams{})
+	assert.EqualError(t, err, "ledger state is required")
+}
+
+func TestPreflightOffers(t *testing.T) {
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	usd := CreditAsset{Code: "USD", Issuer: kp1.Address()}
+	xdrUSD, err := usd.ToXDR()
+	require.NoError(t, err)
+	existing := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeOffer,
+		Offer: &xdr.OfferEntry{
+			SellerId: xdr.MustAddress(kp0.Address()),
+			OfferId:  7,
+			Selling:  xdrUSD,
+			Buying:   xdr.MustNewNativeAsset(),
+			Amount:   10 * xlm,
+			Price:    xdr.Price{N: 2, D: 1},
+		},
+	}}
+	line := trustLineEntry(kp0.Address(), xdrUSD, 20*xlm, 100*xlm)
+	line.Data.TrustLine.Ext = xdr.TrustLineEntryExt{V: 1, V1: &xdr.TrustLineEntryV1{
+		Liabilities: xdr.Liabilities{Selling: 10 * xlm},
+	}}
+	state := NewStaticLedgerState(
+		accountEntry(kp0.Address(), 100*xlm, 2),
+		accountEntry(kp1.Address(), 100*xlm, 0),
+		line,
+		existing,
+	)
+
+	tx := preflightTransaction(t, kp0.Address(),
+		&ManageSellOffer{Selling: NativeAsset{}, Buying: usd, Amount: "10", Price: "0.4"},
+		&ManageSellOffer{Selling: NativeAsset{}, Buying: usd, Amount: "10", Price: "0.6"},
+		&CreatePassiveSellOffer{Selling: NativeAsset{}, Buying: usd, Amount: "10", Price: "0.5"},
+		&ManageSellOffer{Selling: usd, Buying: NativeAsset{}, Amount: "11", Price: "3"},
+		&ManageSellOffer{Selling: usd, Buying: NativeAsset{}, Amount: "10", Price: "3"},
+		&ManageSellOffer{Selling: usd, Buying: NativeAsset{}, Amount: "15", Price: "3", OfferID: 7},
+		&ManageSellOffer{Selling: usd, Buying: NativeAsset{}, Amount: "0", Price: "3", OfferID: 8},
+		&ManageBuyOffer{Selling: CreditAsset{Code: "EUR", Issuer: kp1.Address()}, Buying: NativeAsset{}, Amount: "1", Price: "1"},
+		&ManageSellOffer{Selling: NativeAsset{}, Buying: usd, Amount: "1000", Price: "1"},
+	)
+	result, err := tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{
+		"op_cross_self",
+		"op_success",
+		"op_success",
+		"op_underfunded",
+		"op_success",
+		"op_underfunded",
+		"op_offer_not_found",
+		"op_sell_no_trust",
+		"op_underfunded",
+	}, result.OperationCodes)
+	assert.Equal(t, []AccountReserve{
+		{AccountID: kp0.Address(), Balance: 100*xlm - 900, MinimumBalance: 35 * xlm / 10, SellingLiabilities: 20 * xlm},
+	}, result.Reserves)
+}
+
+func TestPreflightSetOptions(t *testing.T) {
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	state := NewStaticLedgerState(accountEntry(kp0.Address(), 10*xlm, 0))
+
+	tx := preflightTransaction(t, kp0.Address(),
+		&SetOptions{SetFlags: []AccountFlag{AuthClawbackEnabled}},
+		&SetOptions{SetFlags: []AccountFlag{AuthRevocable, AuthClawbackEnabled, AuthImmutable}},
+		&SetOptions{ClearFlags: []AccountFlag{AuthRevocable}},
+		&SetOptions{Signer: &Signer{Address: kp1.Address(), Weight: 1}},
+		&SetOptions{InflationDestination: NewInflationDestination(kp1.Address())},
+		&AccountMerge{Destination: kp1.Address()},
+	)
+	result, err := tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"op_auth_revocable_required",
+		"op_success",
+		"op_cant_change",
+		"op_success",
+		"op_invalid_inflation",
+		"op_no_account",
+	}, result.OperationCodes)
+	assert.Equal(t, int64(15*xlm/10), result.Reserves[0].MinimumBalance)
+}
+
+func TestPreflightSignatures(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	account := accountEntry(kp0.Address(), 100*xlm, 1)
+	account.Data.Account.Thresholds = xdr.Thresholds{1, 1, 2, 3}
+	account.Data.Account.Signers = []xdr.Signer{{Key: xdr.MustSigner(kp1.Address()), Weight: 1}}
+	state := NewStaticLedgerState(account, accountEntry(kp2.Address(), 100*xlm, 0))
+	params := PreflightParams{State: state, NetworkPassphrase: network.TestNetworkPassphrase}
+
+	tx := preflightTransaction(t, kp0.Address(),
+		&BumpSequence{BumpTo: 2},
+		&Payment{Destination: kp2.Address(), Amount: "10", Asset: NativeAsset{}},
+	)
+	result, err := tx.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_auth", result.TransactionCode)
+
+	signed, err := tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	result, err = signed.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{"op_success", "op_bad_auth"}, result.OperationCodes)
+
+	signed, err = tx.Sign(network.TestNetworkPassphrase, kp0, kp1)
+	require.NoError(t, err)
+	result, err = signed.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_success", result.TransactionCode)
+
+	signed, err = tx.Sign(network.TestNetworkPassphrase, kp0, kp1, kp2)
+	require.NoError(t, err)
+	result, err = signed.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_auth_extra", result.TransactionCode)
+
+	// operations of other accounts need their signatures
+	tx = preflightTransaction(t, kp0.Address(),
+		&BumpSequence{BumpTo: 2, SourceAccount: kp2.Address()},
+	)
+	signed, err = tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	result, err = signed.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_bad_auth"}, result.OperationCodes)
+	signed, err = tx.Sign(network.TestNetworkPassphrase, kp0, kp2)
+	require.NoError(t, err)
+	result, err = signed.Preflight(params)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_success", result.TransactionCode)
+}
+
+func TestPreflightUnpredictableOperations(t *testing.T) {
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	state := NewStaticLedgerState(
+		accountEntry(kp0.Address(), 100*xlm, 0),
+		accountEntry(kp1.Address(), 100*xlm, 0),
+	)
+	pathPayment := &PathPaymentStrictSend{
+		SendAsset:   NativeAsset{},
+		SendAmount:  "1",
+		Destination: kp1.Address(),
+		DestAsset:   CreditAsset{Code: "USD", Issuer: kp1.Address()},
+		DestMin:     "1",
+	}
+
+	tx := preflightTransaction(t, kp0.Address(), pathPayment, &BumpSequence{BumpTo: 5})
+	result, err := tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "", result.TransactionCode)
+	assert.Equal(t, []string{"", "op_success"}, result.OperationCodes)
+
+	tx = preflightTransaction(t, kp0.Address(), pathPayment, &Inflation{})
+	result, err = tx.Preflight(PreflightParams{State: state})
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{"", "op_not_time"}, result.OperationCodes)
+}
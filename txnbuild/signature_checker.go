@@ -0,0 +1,124 @@
+package txnbuild
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+
+	"github.com/stellar/go/xdr"
+)
+
+// signatureChecker checks the signatures of a transaction against the signers
+// of accounts the way stellar-core does.
+type signatureChecker struct {
+	hash       [32]byte
+	signatures []xdr.DecoratedSignature
+	used       []bool
+}
+
+func newSignatureChecker(hash [32]byte, signatures []xdr.DecoratedSignature) *signatureChecker {
+	return &signatureChecker{
+		hash:       hash,
+		signatures: signatures,
+		used:       make([]bool, len(signatures)),
+	}
+}
+
+// checkAccount returns true if the signatures meet the given threshold of an
+// account.
+func (c *signatureChecker) checkAccount(accountID string, thresholds xdr.Thresholds, signers []xdr.Signer, threshold xdr.ThresholdIndexes) bool {
+	return c.check(accountSigners(accountID, thresholds[0], signers), int32(thresholds[threshold]))
+}
+
+// check returns true if the total weight of the signers which signed the
+// transaction is at least needed. Like stellar-core, it requires at least one
+// signer even if needed is 0.
+func (c *signatureChecker) check(signers []xdr.Signer, needed int32) bool {
+	total := int32(0)
+	for _, signer := range signers {
+		if signer.Key.Type == xdr.SignerKeyTypeSignerKeyTypePreAuthTx && xdr.Hash(*signer.Key.PreAuthTx) == c.hash {
+			total += signerWeight(signer)
+			if total >= needed {
+				return true
+			}
+		}
+	}
+	// stellar-core checks hash(x) signers before ed25519 signers. For every
+	// type it iterates over the signatures and matches each of them with the
+	// first signer which wasn't matched yet, which determines the signatures
+	// marked as used when the threshold is met.
+	for _, keyType := range []xdr.SignerKeyType{xdr.SignerKeyTypeSignerKeyTypeHashX, xdr.SignerKeyTypeSignerKeyTypeEd25519} {
+		var remaining []xdr.Signer
+		for _, signer := range signers {
+			if signer.Key.Type == keyType {
+				remaining = append(remaining, signer)
+			}
+		}
+		for i, signature := range c.signatures {
+			for j, signer := range remaining {
+				if !c.verify(signer.Key, signature) {
+					continue
+				}
+				c.used[i] = true
+				total += signerWeight(signer)
+				if total >= needed {
+					return true
+				}
+				remaining = append(remaining[:j], remaining[j+1:]...)
+				break
+			}
+		}
+	}
+	return false
+}
+
+// verify returns true if signature is a valid signature of the transaction
+// for the signer key.
+func (c *signatureChecker) verify(key xdr.SignerKey, signature xdr.DecoratedSignature) bool {
+	switch key.Type {
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519:
+		publicKey := key.MustEd25519()
+		return signatureHint(publicKey) == signature.Hint &&
+			ed25519.Verify(publicKey[:], c.hash[:], signature.Signature)
+	case xdr.SignerKeyTypeSignerKeyTypeHashX:
+		hash := key.MustHashX()
+		return signatureHint(hash) == signature.Hint &&
+			sha256.Sum256(signature.Signature) == [32]byte(hash)
+	default:
+		return false
+	}
+}
+
+// allUsed returns true if every signature was used by a successful check.
+func (c *signatureChecker) allUsed() bool {
+	for _, used := range c.used {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
+
+// accountSigners returns the signers of an account, including its master key
+// unless its weight is 0.
+func accountSigners(accountID string, masterWeight byte, signers []xdr.Signer) []xdr.Signer {
+	all := make([]xdr.Signer, 0, len(signers)+1)
+	if masterWeight > 0 {
+		all = append(all, xdr.Signer{Key: xdr.MustSigner(accountID), Weight: xdr.Uint32(masterWeight)})
+	}
+	return append(all, signers...)
+}
+
+// signerWeight returns the weight of a signer, which stellar-core caps at 255.
+func signerWeight(signer xdr.Signer) int32 {
+	if signer.Weight > 255 {
+		return 255
+	}
+	return int32(signer.Weight)
+}
+
+// signatureHint returns the hint of signatures for a key: its last 4 bytes.
+func signatureHint(key xdr.Uint256) xdr.SignatureHint {
+	var hint xdr.SignatureHint
+	copy(hint[:], key[len(key)-4:])
+	return hint
+}
@@ -341,10 +341,16 @@ func (t *Transaction) ClaimableBalanceID(operationIndex int) (string, error) {
 
 	// We mimic the relevant code from Stellar Core
 	// https://github.com/stellar/stellar-core/blob/9f3cc04e6ec02c38974c42545a86cdc79809252b/src/test/TestAccount.cpp#L285
+	// The ID of a muxed source account is not part of the operation ID.
+	sourceAccount, err := xdr.AddressToMuxedAccount(t.sourceAccount.AccountID)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid source account")
+	}
+	sourceAccountID := sourceAccount.ToAccountId()
 	operationId := xdr.OperationId{
 		Type: xdr.EnvelopeTypeEnvelopeTypeOpId,
 		Id: &xdr.OperationIdId{
-			SourceAccount: xdr.MustMuxedAddress(t.sourceAccount.AccountID),
+			SourceAccount: sourceAccountID.ToMuxedAccount(),
 			SeqNum:        xdr.SequenceNumber(t.sourceAccount.Sequence),
 			OpNum:         xdr.Uint32(operationIndex),
 		},
@@ -538,7 +544,7 @@ func transactionFromParsedXDR(xdrEnv xdr.TransactionEnvelope) (*GenericTransacti
 		if err != nil {
 			return newTx, errors.New("could not parse inner transaction")
 		}
-		feeBumpAccount := xdrEnv.FeeBumpAccount().ToAccountId()
+		feeBumpAccount := xdrEnv.FeeBumpAccount()
 		newTx.feeBump = &FeeBumpTransaction{
 			envelope: xdrEnv,
 			// A fee-bump transaction has an effective number of operations equal to one plus the
@@ -553,7 +559,7 @@ func transactionFromParsedXDR(xdrEnv xdr.TransactionEnvelope) (*GenericTransacti
 		return newTx, nil
 	}
 
-	sourceAccount := xdrEnv.SourceAccount().ToAccountId()
+	sourceAccount := xdrEnv.SourceAccount()
 
 	totalFee := int64(xdrEnv.Fee())
 	baseFee := totalFee
@@ -635,7 +641,7 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 		timebounds: params.Timebounds,
 	}
 
-	accountID, err := xdr.AddressToAccountId(tx.sourceAccount.AccountID)
+	sourceAccount, err := xdr.AddressToMuxedAccount(tx.sourceAccount.AccountID)
 	if err != nil {
 		return nil, errors.Wrap(err, "account id is not valid")
 	}
@@ -669,7 +675,7 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
 		V1: &xdr.TransactionV1Envelope{
 			Tx: xdr.Transaction{
-				SourceAccount: accountID.ToMuxedAccount(),
+				SourceAccount: sourceAccount,
 				Fee:           xdr.Uint32(tx.maxFee),
 				SeqNum:        xdr.SequenceNumber(sequence),
 				TimeBounds: &xdr.TimeBounds{
@@ -782,7 +788,7 @@ func NewFeeBumpTransaction(params FeeBumpTransactionParams) (*FeeBumpTransaction
 		)
 	}
 
-	accountID, err := xdr.AddressToAccountId(tx.feeAccount)
+	feeSource, err := xdr.AddressToMuxedAccount(tx.feeAccount)
 	if err != nil {
 		return tx, errors.Wrap(err, "fee account is not a valid address")
 	}
@@ -791,7 +797,7 @@ func NewFeeBumpTransaction(params FeeBumpTransactionParams) (*FeeBumpTransaction
 		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
 		FeeBump: &xdr.FeeBumpTransactionEnvelope{
 			Tx: xdr.FeeBumpTransaction{
-				FeeSource: accountID.ToMuxedAccount(),
+				FeeSource: feeSource,
 				Fee:       xdr.Int64(tx.maxFee),
 				InnerTx: xdr.FeeBumpTransactionInnerTx{
 					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
@@ -4132,3 +4132,83 @@ func TestVerifyTxSignatureInvalid(t *testing.T) {
 		assert.Contains(t, err.Error(), "transaction not signed by GATBMIXTHXYKSUZSZUEJKACZ2OS2IYUWP2AIF3CA32PIDLJ67CH6Y5UY")
 	}
 }
+
+func TestMuxedAccounts(t *testing.T) {
+	kp0 := newKeypair0()
+	muxedSource, err := xdr.MuxedAccountFromAccountId(kp0.Address(), 42)
+	require.NoError(t, err)
+	sourceAccount := NewSimpleAccount(muxedSource.Address(), int64(9605939170639897))
+	destination := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+
+	payment := Payment{
+		Destination:   destination,
+		Amount:        "10",
+		Asset:         NativeAsset{},
+		SourceAccount: destination,
+	}
+	pathPayment := PathPaymentStrictSend{
+		SendAsset:   NativeAsset{},
+		SendAmount:  "10",
+		Destination: destination,
+		DestAsset:   NativeAsset{},
+		DestMin:     "1",
+	}
+	accountMerge := AccountMerge{Destination: destination}
+	tx, err := NewTransaction(
+		TransactionParams{
+			SourceAccount:        &sourceAccount,
+			IncrementSequenceNum: true,
+			Operations:           []Operation{&payment, &pathPayment, &accountMerge},
+			BaseFee:              MinBaseFee,
+			Timebounds:           NewInfiniteTimeout(),
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, muxedSource, tx.ToXDR().SourceAccount())
+
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp0)
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	parsed, err := TransactionFromXDR(b64)
+	require.NoError(t, err)
+	parsedTx, ok := parsed.Transaction()
+	require.True(t, ok)
+	assert.Equal(t, muxedSource.Address(), parsedTx.SourceAccount().AccountID)
+	parsedOps := parsedTx.Operations()
+	require.Len(t, parsedOps, 3)
+	assert.Equal(t, destination, parsedOps[0].(*Payment).Destination)
+	assert.Equal(t, destination, parsedOps[0].(*Payment).SourceAccount)
+	assert.Equal(t, destination, parsedOps[1].(*PathPaymentStrictSend).Destination)
+	assert.Equal(t, destination, parsedOps[2].(*AccountMerge).Destination)
+
+	feeBump, err := NewFeeBumpTransaction(FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: destination,
+		BaseFee:    MinBaseFee,
+	})
+	require.NoError(t, err)
+	b64, err = feeBump.Base64()
+	require.NoError(t, err)
+	parsed, err = TransactionFromXDR(b64)
+	require.NoError(t, err)
+	parsedFeeBump, ok := parsed.FeeBump()
+	require.True(t, ok)
+	assert.Equal(t, destination, parsedFeeBump.FeeAccount())
+	assert.Equal(t, muxedSource.Address(), parsedFeeBump.InnerTransaction().SourceAccount().AccountID)
+
+	payment.Destination = "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUR"
+	_, err = NewTransaction(
+		TransactionParams{
+			SourceAccount:        &sourceAccount,
+			IncrementSequenceNum: true,
+			Operations:           []Operation{&payment},
+			BaseFee:              MinBaseFee,
+			Timebounds:           NewInfiniteTimeout(),
+		},
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "validation failed for *txnbuild.Payment operation: Field: Destination")
+	}
+}
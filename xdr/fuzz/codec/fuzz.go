@@ -0,0 +1,91 @@
+// +build gofuzz
+
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	xdr3 "github.com/stellar/go-xdr/xdr3"
+	"github.com/stellar/go/xdr"
+)
+
+// types are the types decoded by Fuzz. The first byte of the input selects
+// the type.
+var types = []reflect.Type{
+	reflect.TypeOf(xdr.BucketEntry{}),
+	reflect.TypeOf(xdr.LedgerCloseMeta{}),
+	reflect.TypeOf(xdr.LedgerEntry{}),
+	reflect.TypeOf(xdr.LedgerEntryChanges{}),
+	reflect.TypeOf(xdr.LedgerHeaderHistoryEntry{}),
+	reflect.TypeOf(xdr.LedgerKey{}),
+	reflect.TypeOf(xdr.ScpEnvelope{}),
+	reflect.TypeOf(xdr.StellarMessage{}),
+	reflect.TypeOf(xdr.TransactionEnvelope{}),
+	reflect.TypeOf(xdr.TransactionMeta{}),
+	reflect.TypeOf(xdr.TransactionResult{}),
+	reflect.TypeOf(xdr.TransactionResultPair{}),
+}
+
+// Fuzz is go-fuzz function for checking the generated DecodeFrom and EncodeTo
+// methods decode and encode exactly like the reflection based decoder and
+// encoder, and that decoded values can be converted to JSON and back.
+func Fuzz(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
+	typ := types[int(data[0])%len(types)]
+	data = data[1:]
+
+	// The reflection based decoder allocates slices of any length it reads
+	// so only the inputs accepted by the generated decoder are compared.
+	generated := reflect.New(typ)
+	n, err := xdr.Unmarshal(bytes.NewReader(data), generated.Interface())
+	if err != nil {
+		return 0
+	}
+
+	reflection := reflect.New(typ)
+	reflectionN, err := xdr3.Unmarshal(bytes.NewReader(data), reflection.Interface())
+	if err != nil {
+		panic(fmt.Sprintf("reflection based decoder failed: %v", err))
+	}
+	if n != reflectionN {
+		panic(fmt.Sprintf("decoded %d bytes, reflection based decoder decoded %d bytes", n, reflectionN))
+	}
+	if !reflect.DeepEqual(generated.Interface(), reflection.Interface()) {
+		panic("decoded values are not equal")
+	}
+
+	var encoded, reflectionEncoded bytes.Buffer
+	if _, err := xdr.Marshal(&encoded, generated.Interface()); err != nil {
+		panic(err)
+	}
+	if _, err := xdr3.Marshal(&reflectionEncoded, generated.Interface()); err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(encoded.Bytes(), reflectionEncoded.Bytes()) {
+		panic("encoded values are not equal")
+	}
+	if !bytes.Equal(encoded.Bytes(), data[:n]) {
+		panic("encoded value is not equal to the input")
+	}
+
+	jsonEncoded, err := xdr.MarshalJSON(generated.Interface())
+	if err != nil {
+		panic(err)
+	}
+	jsonDecoded := reflect.New(typ)
+	if err := xdr.UnmarshalJSON(jsonEncoded, jsonDecoded.Interface()); err != nil {
+		panic(fmt.Sprintf("decoding %s failed: %v", jsonEncoded, err))
+	}
+	var jsonDecodedEncoded bytes.Buffer
+	if _, err := xdr.Marshal(&jsonDecodedEncoded, jsonDecoded.Interface()); err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(jsonDecodedEncoded.Bytes(), data[:n]) {
+		panic(fmt.Sprintf("value decoded from %s is not equal to the input", jsonEncoded))
+	}
+	return 1
+}
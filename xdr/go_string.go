@@ -80,15 +80,8 @@ func (m Memo) GoString() string {
 // GoString implements fmt.GoStringer.
 func (m MuxedAccount) GoString() string {
 	switch m.Type {
-	case CryptoKeyTypeKeyTypeEd25519:
-		accountID := m.ToAccountId()
-		return fmt.Sprintf("xdr.MustMuxedAddress(%#v)", accountID.Address())
-	case CryptoKeyTypeKeyTypeMuxedEd25519:
-		var sb strings.Builder
-		sb.WriteString("xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,")
-		sb.WriteString(fmt.Sprintf("Med25519: &%#v", *m.Med25519))
-		sb.WriteString("}")
-		return sb.String()
+	case CryptoKeyTypeKeyTypeEd25519, CryptoKeyTypeKeyTypeMuxedEd25519:
+		return fmt.Sprintf("xdr.MustMuxedAddress(%#v)", m.Address())
 	default:
 		panic("Unknown type")
 	}
@@ -0,0 +1,663 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jsonOverrides are the types with hand written encodeJSON and decodeJSON
+// methods in xdr/xdr_json.go.
+var jsonOverrides = map[string]bool{
+	"Asset":        true,
+	"AssetCode4":   true,
+	"AssetCode12":  true,
+	"MuxedAccount": true,
+	"PublicKey":    true,
+	"SignerKey":    true,
+}
+
+// jsonNamesSuffix is the suffix of the generated maps of the JSON names of
+// enum values.
+const jsonNamesSuffix = "JSONNames"
+
+// registryName is the name of the generated map of all types by name.
+const registryName = "xdrTypes"
+
+// snakeCase converts a Go identifier to snake case, for example
+// SourceAccount to source_account, AlphaNum4 to alpha_num4 and IPv4 to ipv4.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	lower := func(i int) bool {
+		return i < len(runes) && unicode.IsLower(runes[i])
+	}
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				// the last letter of an acronym followed by a word starts
+				// the word, as in HTTPServer
+				wordAfterAcronym := unicode.IsUpper(prev) && lower(i+1) && lower(i+2)
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || wordAfterAcronym {
+					b.WriteByte('_')
+				}
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// jsonKeys returns the JSON keys of the fields of a struct in declaration
+// order.
+func jsonKeys(typ *ast.StructType) ([]string, map[string]string, error) {
+	var names []string
+	keys := map[string]string{}
+	seen := map[string]bool{}
+	for _, field := range typ.Fields.List {
+		for _, fieldName := range field.Names {
+			key := snakeCase(fieldName.Name)
+			if seen[key] {
+				return nil, nil, fmt.Errorf("duplicate JSON key %s", key)
+			}
+			seen[key] = true
+			names = append(names, fieldName.Name)
+			keys[fieldName.Name] = key
+		}
+	}
+	return names, keys, nil
+}
+
+// registry returns the map of all types which can be converted to JSON by
+// name.
+func (g *generator) registry(order []string) (string, error) {
+	names := append([]string{}, order...)
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("// xdrTypes are the types which can be created by name with NewValue.\n")
+	fmt.Fprintf(&b, "var %s = map[string]reflect.Type{\n", registryName)
+	for _, name := range names {
+		if _, ok := g.types[name].Type.(*ast.StarExpr); ok {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%q: reflect.TypeOf((*%s)(nil)).Elem(),\n", name, name)
+	}
+	b.WriteString("}\n")
+	return formatDecls(b.String())
+}
+
+// jsonCodec returns the encodeJSON and decodeJSON methods of a type.
+func (g *generator) jsonCodec(name string) (string, error) {
+	if jsonOverrides[name] {
+		return "", nil
+	}
+	spec := g.types[name]
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		if g.methods[name]["SwitchFieldName"] != nil {
+			return g.unionJSON(name, typ)
+		}
+		return g.structureJSON(name, typ)
+	case *ast.Ident:
+		if g.methods[name]["ValidEnum"] != nil {
+			return g.enumJSON(name)
+		}
+		if isBasic(typ.Name) {
+			return g.basicTypedefJSON(name, typ)
+		}
+		return fmt.Sprintf(`// encodeJSON writes the JSON representation of this value to enc.
+func (s %[1]s) encodeJSON(enc *jsonEncoder) error {
+	return %[2]s(s).encodeJSON(enc)
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *%[1]s) decodeJSON(data []byte) error {
+	return (*%[2]s)(s).decodeJSON(data)
+}
+
+`, name, typ.Name), nil
+	case *ast.ArrayType:
+		return g.arrayTypedefJSON(name, typ)
+	case *ast.StarExpr:
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", types.ExprString(spec.Type))
+}
+
+func encodeJSON(recv, name string, body func(m *method) error) (string, error) {
+	m := &method{}
+	if err := body(m); err != nil {
+		return "", err
+	}
+	vars := ""
+	if m.err {
+		vars = "\tvar err error\n"
+	}
+	return fmt.Sprintf(
+		"// encodeJSON writes the JSON representation of this value to enc.\nfunc (%s %s) encodeJSON(enc *jsonEncoder) error {\n%s%s}\n\n",
+		recv, name, vars, m.b.String(),
+	), nil
+}
+
+func decodeJSON(recv, name string, body func(m *method) error) (string, error) {
+	m := &method{}
+	if err := body(m); err != nil {
+		return "", err
+	}
+	vars := ""
+	if m.length {
+		vars = "\tvar elems [][]byte\n"
+	}
+	return fmt.Sprintf(
+		"// decodeJSON decodes the JSON representation of this value.\nfunc (%s *%s) decodeJSON(data []byte) error {\n%s%s}\n\n",
+		recv, name, vars, m.b.String(),
+	), nil
+}
+
+// fieldFailure returns the statement returning an error of the value of a
+// key.
+func fieldFailure(key string) func(err string) string {
+	return func(err string) string {
+		return fmt.Sprintf("return jsonFieldError(%q, %s)", key, err)
+	}
+}
+
+func failure(err string) string {
+	return "return " + err
+}
+
+func (g *generator) structureJSON(name string, typ *ast.StructType) (string, error) {
+	names, keys, err := jsonKeys(typ)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]*ast.Field{}
+	for _, field := range typ.Fields.List {
+		for _, fieldName := range field.Names {
+			fields[fieldName.Name] = field
+		}
+	}
+
+	encode, err := encodeJSON("s", name, func(m *method) error {
+		m.printf("\tenc.beginObject()\n")
+		for _, fieldName := range names {
+			m.printf("\tenc.key(%q)\n", keys[fieldName])
+			if err := g.encodeJSONValue(m, "s."+fieldName, fields[fieldName].Type, fieldFailure(keys[fieldName]), 0); err != nil {
+				return err
+			}
+		}
+		m.printf("\tenc.endObject()\n\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	decode, err := decodeJSON("s", name, func(m *method) error {
+		quoted := make([]string, len(names))
+		for i, fieldName := range names {
+			quoted[i] = strconv.Quote(keys[fieldName])
+		}
+		m.printf("\tfields, err := decodeJSONObject(data, %s)\n\tif err != nil {\n\t\treturn err\n\t}\n", strings.Join(quoted, ", "))
+		for _, fieldName := range names {
+			field := fields[fieldName]
+			data := fmt.Sprintf("fields[%q]", keys[fieldName])
+			if err := g.decodeJSONValue(m, "s."+fieldName, data, field.Type, maxSize(field), fieldFailure(keys[fieldName]), 0); err != nil {
+				return err
+			}
+		}
+		m.printf("\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+func (g *generator) unionJSON(name string, typ *ast.StructType) (string, error) {
+	discriminant, err := g.switchField(name)
+	if err != nil {
+		return "", err
+	}
+	tag, cases, dflt, err := g.unionSwitch(name)
+	if err != nil {
+		return "", err
+	}
+	_, keys, err := jsonKeys(typ)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]*ast.Field{}
+	for _, field := range typ.Fields.List {
+		for _, fieldName := range field.Names {
+			fields[fieldName.Name] = field
+		}
+	}
+	discriminantField := fields[discriminant]
+	if discriminantField == nil {
+		return "", fmt.Errorf("unknown discriminant %s", discriminant)
+	}
+	discriminantKey := keys[discriminant]
+	arm := func(c unionCase) (*ast.Field, ast.Expr, error) {
+		field := fields[c.arm]
+		if field == nil {
+			return nil, nil, fmt.Errorf("unknown arm %s", c.arm)
+		}
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			return nil, nil, fmt.Errorf("arm %s is not a pointer", c.arm)
+		}
+		return field, star.X, nil
+	}
+
+	encode, err := encodeJSON("u", name, func(m *method) error {
+		m.printf("\tenc.beginObject()\n\tenc.key(%q)\n", discriminantKey)
+		if err := g.encodeJSONValue(m, "u."+discriminant, discriminantField.Type, fieldFailure(discriminantKey), 0); err != nil {
+			return err
+		}
+		m.printf("\tswitch %s(u.%s) {\n", tag, discriminant)
+		encodeCase := func(c unionCase) error {
+			if c.arm == "" {
+				m.printf("\t\t// Void\n")
+				return nil
+			}
+			_, elem, err := arm(c)
+			if err != nil {
+				return err
+			}
+			key := keys[c.arm]
+			m.printf("\t\tif u.%s == nil {\n\t\t\treturn jsonFieldError(%q, errEncodeNilUnionValue())\n\t\t}\n", c.arm, key)
+			m.printf("\t\tenc.key(%q)\n", key)
+			return g.encodeJSONValue(m, "(*u."+c.arm+")", elem, fieldFailure(key), 0)
+		}
+		for _, c := range cases {
+			m.printf("\tcase %s:\n", c.values)
+			if err := encodeCase(c); err != nil {
+				return err
+			}
+		}
+		m.printf("\tdefault:\n")
+		if dflt != nil {
+			if err := encodeCase(*dflt); err != nil {
+				return err
+			}
+		} else {
+			m.printf("\t\treturn errEncodeInvalidUnionSwitch(int32(u.%s))\n", discriminant)
+		}
+		m.printf("\t}\n\tenc.endObject()\n\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	decode, err := decodeJSON("u", name, func(m *method) error {
+		m.printf("\t*u = %s{}\n", name)
+		m.printf("\tfields, err := decodeJSONUnion(data, %q)\n\tif err != nil {\n\t\treturn err\n\t}\n", discriminantKey)
+		data := fmt.Sprintf("fields[%q]", discriminantKey)
+		if err := g.decodeJSONValue(m, "u."+discriminant, data, discriminantField.Type, "", fieldFailure(discriminantKey), 0); err != nil {
+			return err
+		}
+		m.printf("\tswitch %s(u.%s) {\n", tag, discriminant)
+		decodeCase := func(c unionCase) error {
+			if c.arm == "" {
+				m.printf("\t\t// Void\n\t\treturn checkJSONKeys(fields, %q)\n", discriminantKey)
+				return nil
+			}
+			field, elem, err := arm(c)
+			if err != nil {
+				return err
+			}
+			key := keys[c.arm]
+			m.printf("\t\tif err = checkJSONKeys(fields, %q, %q); err != nil {\n\t\t\treturn err\n\t\t}\n", discriminantKey, key)
+			m.printf("\t\tu.%s = new(%s)\n", c.arm, types.ExprString(elem))
+			data := fmt.Sprintf("fields[%q]", key)
+			if err := g.decodeJSONValue(m, "(*u."+c.arm+")", data, elem, maxSize(field), fieldFailure(key), 0); err != nil {
+				return err
+			}
+			m.printf("\t\treturn nil\n")
+			return nil
+		}
+		for _, c := range cases {
+			m.printf("\tcase %s:\n", c.values)
+			if err := decodeCase(c); err != nil {
+				return err
+			}
+		}
+		if dflt != nil {
+			m.printf("\tdefault:\n")
+			if err := decodeCase(*dflt); err != nil {
+				return err
+			}
+			m.printf("\t}\n")
+			return nil
+		}
+		m.printf("\t}\n\treturn jsonFieldError(%q, errDecodeInvalidUnionSwitch(int32(u.%s)))\n", discriminantKey, discriminant)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+// enumJSONNames returns the JSON names of the values of an enum, which are
+// the snake case names of the values without the name of the enum, for
+// example memo_text for MemoTypeMemoText.
+func (g *generator) enumJSONNames(name string) ([]string, []string, error) {
+	mapName := strings.ToLower(name[:1]) + name[1:] + "Map"
+	spec := g.vars[mapName]
+	if spec == nil || len(spec.Values) != 1 {
+		return nil, nil, fmt.Errorf("%s not found", mapName)
+	}
+	lit, ok := spec.Values[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected %s", mapName)
+	}
+	var values, names []string
+	seen := map[string]bool{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected %s", mapName)
+		}
+		valueName, err := strconv.Unquote(kv.Value.(*ast.BasicLit).Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		jsonName := strings.TrimPrefix(valueName, name)
+		if jsonName == "" {
+			jsonName = valueName
+		}
+		jsonName = snakeCase(jsonName)
+		if seen[jsonName] {
+			return nil, nil, fmt.Errorf("duplicate JSON name %s", jsonName)
+		}
+		seen[jsonName] = true
+		values = append(values, types.ExprString(kv.Key))
+		names = append(names, jsonName)
+	}
+	return values, names, nil
+}
+
+func (g *generator) enumJSON(name string) (string, error) {
+	values, names, err := g.enumJSONNames(name)
+	if err != nil {
+		return "", err
+	}
+	namesVar := strings.ToLower(name[:1]) + name[1:] + jsonNamesSuffix
+	var b strings.Builder
+	fmt.Fprintf(&b, "var %s = map[int32]string{\n", namesVar)
+	for i := range values {
+		fmt.Fprintf(&b, "\t%s: %q,\n", values[i], names[i])
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, `// encodeJSON writes the JSON representation of this value to enc.
+func (e %[1]s) encodeJSON(enc *jsonEncoder) error {
+	name, ok := %[2]s[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *%[1]s) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, %[2]s)
+	if err != nil {
+		return err
+	}
+	*e = %[1]s(v)
+	return nil
+}
+
+`, name, namesVar)
+	return b.String(), nil
+}
+
+// jsonMethods are the methods of jsonEncoder and the suffixes of the decoding
+// functions used for the basic types.
+var jsonMethods = map[string]string{
+	"int32":  "Int32",
+	"uint32": "Uint32",
+	"int64":  "Int64",
+	"uint64": "Uint64",
+	"bool":   "Bool",
+	"string": "String",
+}
+
+func (g *generator) basicTypedefJSON(name string, typ *ast.Ident) (string, error) {
+	max, err := g.xdrMaxSize(name)
+	if err != nil {
+		return "", err
+	}
+	basic := jsonMethods[typ.Name]
+	arg := ""
+	if basic == "String" {
+		arg = ", " + strconv.Itoa(max)
+	}
+	return fmt.Sprintf(`// encodeJSON writes the JSON representation of this value to enc.
+func (s %[1]s) encodeJSON(enc *jsonEncoder) error {
+	enc.%[2]s(%[3]s(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *%[1]s) decodeJSON(data []byte) error {
+	v, err := decodeJSON%[4]s(data%[5]s)
+	if err != nil {
+		return err
+	}
+	*s = %[1]s(v)
+	return nil
+}
+
+`, name, typ.Name, typ.Name, basic, arg), nil
+}
+
+func (g *generator) arrayTypedefJSON(name string, typ *ast.ArrayType) (string, error) {
+	max, err := g.xdrMaxSize(name)
+	if err != nil {
+		return "", err
+	}
+	if isByte(typ.Elt) {
+		if typ.Len == nil {
+			return fmt.Sprintf(`// encodeJSON writes the JSON representation of this value to enc.
+func (s %[1]s) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *%[1]s) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, %[2]d)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+`, name, max), nil
+		}
+		return fmt.Sprintf(`// encodeJSON writes the JSON representation of this value to enc.
+func (s %[1]s) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s[:])
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *%[1]s) decodeJSON(data []byte) error {
+	return decodeJSONFixedOpaque(data, s[:])
+}
+
+`, name), nil
+	}
+
+	encode, err := encodeJSON("s", name, func(m *method) error {
+		if err := g.encodeJSONValue(m, "s", typ, failure, 0); err != nil {
+			return err
+		}
+		m.printf("\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	decode, err := decodeJSON("s", name, func(m *method) error {
+		m.printf("\tvar err error\n")
+		if err := g.decodeJSONValue(m, "(*s)", "data", typ, strconv.Itoa(max), failure, 0); err != nil {
+			return err
+		}
+		m.printf("\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+// encodeJSONValue writes the statements encoding expr which is of type typ.
+// fail returns the statement returning an error.
+func (g *generator) encodeJSONValue(m *method, expr string, typ ast.Expr, fail func(string) string, depth int) error {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		if basic, ok := jsonMethods[typ.Name]; ok {
+			m.printf("\tenc.%s%s(%s)\n", strings.ToLower(basic[:1]), basic[1:], assignable(expr))
+			return nil
+		}
+		spec := g.types[typ.Name]
+		if spec == nil {
+			return fmt.Errorf("unknown type %s", typ.Name)
+		}
+		if star, ok := spec.Type.(*ast.StarExpr); ok {
+			pointee := fmt.Sprintf("(*(%s)(%s))", types.ExprString(star), expr)
+			return g.encodeJSONOptional(m, expr, pointee, star.X, fail, depth)
+		}
+		m.err = true
+		m.printf("\tif err = %s.encodeJSON(enc); err != nil {\n\t\t%s\n\t}\n", methodReceiver(expr), fail("err"))
+		return nil
+	case *ast.ArrayType:
+		if isByte(typ.Elt) {
+			if typ.Len == nil {
+				m.printf("\tenc.opaque(%s)\n", assignable(expr))
+			} else {
+				m.printf("\tenc.opaque(%s[:])\n", expr)
+			}
+			return nil
+		}
+		i := loopVariable(depth)
+		m.printf("\tenc.beginArray()\n")
+		m.printf("\tfor %[1]s := 0; %[1]s < len(%[2]s); %[1]s++ {\n\t\tenc.element()\n", i, assignable(expr))
+		elemFail := func(err string) string {
+			return fail(fmt.Sprintf("jsonIndexError(%s, %s)", i, err))
+		}
+		if err := g.encodeJSONValue(m, fmt.Sprintf("%s[%s]", expr, i), typ.Elt, elemFail, depth+1); err != nil {
+			return err
+		}
+		m.printf("\t}\n\tenc.endArray()\n")
+		return nil
+	case *ast.StarExpr:
+		return g.encodeJSONOptional(m, expr, "(*"+expr+")", typ.X, fail, depth)
+	}
+	return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+}
+
+// encodeJSONOptional writes the statements encoding the optional value expr
+// as null or the value it points to.
+func (g *generator) encodeJSONOptional(m *method, expr, pointee string, elem ast.Expr, fail func(string) string, depth int) error {
+	m.printf("\tif %s == nil {\n\t\tenc.null()\n\t} else {\n", assignable(expr))
+	if err := g.encodeJSONValue(m, pointee, elem, fail, depth); err != nil {
+		return err
+	}
+	m.printf("\t}\n")
+	return nil
+}
+
+// decodeJSONValue writes the statements decoding data into target which is
+// of type typ. max is the xdrmaxsize tag of the field.
+func (g *generator) decodeJSONValue(m *method, target, data string, typ ast.Expr, max string, fail func(string) string, depth int) error {
+	if max == "" {
+		max = "0"
+	}
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		if basic, ok := jsonMethods[typ.Name]; ok {
+			arg := ""
+			if basic == "String" {
+				arg = ", " + max
+			}
+			m.printf("\tif %s, err = decodeJSON%s(%s%s); err != nil {\n\t\t%s\n\t}\n", assignable(target), basic, data, arg, fail("err"))
+			return nil
+		}
+		spec := g.types[typ.Name]
+		if spec == nil {
+			return fmt.Errorf("unknown type %s", typ.Name)
+		}
+		if star, ok := spec.Type.(*ast.StarExpr); ok {
+			pointee := fmt.Sprintf("(*(%s)(%s))", types.ExprString(star), assignable(target))
+			return g.decodeJSONOptional(m, target, data, pointee, star.X, fail, depth)
+		}
+		if max != "0" {
+			return fmt.Errorf("xdrmaxsize tags on fields of type %s are not supported", typ.Name)
+		}
+		m.printf("\tif err = %s.decodeJSON(%s); err != nil {\n\t\t%s\n\t}\n", methodReceiver(target), data, fail("err"))
+		return nil
+	case *ast.ArrayType:
+		if isByte(typ.Elt) {
+			if typ.Len == nil {
+				m.printf("\tif %s, err = decodeJSONOpaque(%s, %s); err != nil {\n\t\t%s\n\t}\n", assignable(target), data, max, fail("err"))
+			} else {
+				m.printf("\tif err = decodeJSONFixedOpaque(%s, %s[:]); err != nil {\n\t\t%s\n\t}\n", data, target, fail("err"))
+			}
+			return nil
+		}
+		if m.length && depth > 0 {
+			return fmt.Errorf("nested arrays are not supported")
+		}
+		m.length = true
+		i := loopVariable(depth)
+		if typ.Len != nil {
+			m.printf("\tif elems, err = decodeJSONFixedArray(%s, len(%s)); err != nil {\n\t\t%s\n\t}\n", data, assignable(target), fail("err"))
+		} else {
+			m.printf("\tif elems, err = decodeJSONArray(%s, %s); err != nil {\n\t\t%s\n\t}\n", data, max, fail("err"))
+			// like the xdr decoder, decode empty arrays as nil slices
+			m.printf("\t%s = nil\n", assignable(target))
+			m.printf("\tif len(elems) > 0 {\n\t\t%s = make(%s, len(elems))\n\t}\n", assignable(target), types.ExprString(typ))
+		}
+		m.printf("\tfor %s, elem := range elems {\n", i)
+		elemFail := func(err string) string {
+			return fail(fmt.Sprintf("jsonIndexError(%s, %s)", i, err))
+		}
+		if err := g.decodeJSONValue(m, fmt.Sprintf("%s[%s]", target, i), "elem", typ.Elt, "", elemFail, depth+1); err != nil {
+			return err
+		}
+		m.printf("\t}\n")
+		return nil
+	case *ast.StarExpr:
+		return g.decodeJSONOptional(m, target, data, "(*"+target+")", typ.X, fail, depth)
+	}
+	return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+}
+
+// decodeJSONOptional writes the statements decoding the optional value
+// target from null or the value it points to.
+func (g *generator) decodeJSONOptional(m *method, target, data, pointee string, elem ast.Expr, fail func(string) string, depth int) error {
+	m.printf("\t%s = nil\n", assignable(target))
+	m.printf("\tif !isJSONNull(%s) {\n\t\t%s = new(%s)\n", data, assignable(target), types.ExprString(elem))
+	// like the xdr decoder, ignore the max size of optional values
+	if err := g.decodeJSONValue(m, pointee, data, elem, "", fail, depth); err != nil {
+		return err
+	}
+	m.printf("\t}\n")
+	return nil
+}
@@ -0,0 +1,1217 @@
+// codecgen adds reflection free EncodeTo and DecodeFrom methods to the types
+// generated by xdrgen. It rewrites xdr/xdr_generated.go in place and is run by
+// `rake xdr:generate` after xdrgen. Running it again on its own output
+// replaces the methods it generated before.
+//
+// The generated methods encode and decode exactly like the reflection based
+// encoder of github.com/stellar/go-xdr, which is still used by xdr.Marshal and
+// xdr.Unmarshal for values without generated methods.
+//
+// codecgen also generates the encodeJSON and decodeJSON methods used by
+// xdr.MarshalJSON and xdr.UnmarshalJSON, and the map of all types by name
+// used by xdr.NewValue.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: codecgen [path to xdr_generated.go]\n")
+	}
+	flag.Parse()
+	path := "xdr/xdr_generated.go"
+	if flag.NArg() > 0 {
+		path = flag.Arg(0)
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := generate(path, src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// header replaces the Marshal and Unmarshal functions generated by xdrgen.
+const header = `
+type encoderTo interface {
+	EncodeTo(enc *xdr.Encoder) error
+}
+
+type decoderFrom interface {
+	DecodeFrom(dec *xdr.Decoder) (int, error)
+}
+
+// Unmarshal reads an xdr element from ` + "`r`" + ` into ` + "`v`" + `.
+func Unmarshal(r io.Reader, v interface{}) (int, error) {
+	if decodable, ok := v.(decoderFrom); ok && !isNilPointer(v) {
+		return decodable.DecodeFrom(xdr.NewDecoder(r))
+	}
+	// delegate to xdr package's Unmarshal
+	return xdr.Unmarshal(r, v)
+}
+
+// Marshal writes an xdr element ` + "`v`" + ` into ` + "`w`" + `.
+func Marshal(w io.Writer, v interface{}) (int, error) {
+	if encodable, ok := v.(encoderTo); ok && !isNilPointer(v) {
+		cw := countingWriter{w: w}
+		err := encodable.EncodeTo(xdr.NewEncoder(&cw))
+		return cw.n, err
+	}
+	// delegate to xdr package's Marshal
+	return xdr.Marshal(w, v)
+}
+
+// isNilPointer returns true if v is a nil pointer. The reflection based
+// encoder returns errors for nil pointers, which are kept for compatibility.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// decodeFixedOpaque decodes fixed length opaque data into dst.
+func decodeFixedOpaque(dec *xdr.Decoder, dst []byte) (int, error) {
+	b, n, err := dec.DecodeFixedOpaque(int32(len(dst)))
+	if err != nil {
+		return n, err
+	}
+	copy(dst, b)
+	return n, nil
+}
+
+// sliceCapacity returns the initial capacity of a decoded slice of length l.
+// The capacity is limited because the length is read from the input and
+// could be much larger than the input.
+func sliceCapacity(l uint32) int {
+	if l > 1024 {
+		return 1024
+	}
+	return int(l)
+}
+
+// decodeOpaque decodes variable length opaque data of at most maxSize bytes,
+// or any length if maxSize is 0.
+func decodeOpaque(dec *xdr.Decoder, maxSize uint32) ([]byte, int, error) {
+	l, n, err := dec.DecodeUint()
+	if err != nil {
+		return nil, n, err
+	}
+	if maxSize == 0 {
+		maxSize = math.MaxInt32
+	}
+	if l > maxSize {
+		return nil, n, errDecodeMaxSlice(l)
+	}
+	v, nTmp, err := dec.DecodeFixedOpaque(int32(l))
+	return v, n + nTmp, err
+}
+
+func errEncodeInvalidEnum(v interface{}) error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadEnumValue,
+		Func:        "encode",
+		Description: "invalid enum",
+		Value:       v,
+	}
+}
+
+func errEncodeInvalidUnionSwitch(sw int32) error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "encodeUnion",
+		Description: fmt.Sprintf("invalid union switch: %d", sw),
+	}
+}
+
+func errEncodeNilUnionValue() error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadUnionValue,
+		Func:        "encodeUnion",
+		Description: "can't encode nil union value",
+	}
+}
+
+func errDecodeInvalidEnum(v int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadEnumValue,
+		Func:        "decode",
+		Description: "invalid enum",
+		Value:       v,
+	}
+}
+
+func errDecodeInvalidUnionSwitch(sw int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "decode",
+		Description: fmt.Sprintf("switch '%d' is not valid for union", sw),
+	}
+}
+
+func errDecodeInvalidUnionEnum(sw int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "decode",
+		Description: fmt.Sprintf("switch '%d' is not valid enum value for union", sw),
+	}
+}
+
+func errDecodeMaxSlice(length uint32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrOverflow,
+		Func:        "decodeArray",
+		Description: "data exceeds max slice limit",
+		Value:       length,
+	}
+}
+`
+
+// headerDecls are the top level declarations of header. They are removed
+// together with the generated methods when the output is regenerated.
+var headerDecls = map[string]bool{
+	"Marshal":                     true,
+	"Unmarshal":                   true,
+	"encoderTo":                   true,
+	"decoderFrom":                 true,
+	"isNilPointer":                true,
+	"countingWriter":              true,
+	"decodeFixedOpaque":           true,
+	"decodeOpaque":                true,
+	"sliceCapacity":               true,
+	"errEncodeInvalidEnum":        true,
+	"errEncodeInvalidUnionSwitch": true,
+	"errEncodeNilUnionValue":      true,
+	"errDecodeInvalidEnum":        true,
+	"errDecodeInvalidUnionSwitch": true,
+	"errDecodeInvalidUnionEnum":   true,
+	"errDecodeMaxSlice":           true,
+}
+
+// generatedMethods are the names of the generated methods, which are removed
+// when the output is regenerated.
+var generatedMethods = map[string]bool{
+	"EncodeTo":   true,
+	"DecodeFrom": true,
+	"encodeJSON": true,
+	"decodeJSON": true,
+}
+
+// headerImports are the imports used by header and the generated methods.
+var headerImports = []string{"fmt", "io", "math", "reflect"}
+
+// edit replaces src[start:end] with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+type generator struct {
+	fset    *token.FileSet
+	src     []byte
+	types   map[string]*ast.TypeSpec
+	vars    map[string]*ast.ValueSpec
+	methods map[string]map[string]*ast.FuncDecl
+}
+
+func generate(path string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		fset:    fset,
+		src:     src,
+		types:   map[string]*ast.TypeSpec{},
+		vars:    map[string]*ast.ValueSpec{},
+		methods: map[string]map[string]*ast.FuncDecl{},
+	}
+
+	var edits []edit
+	var order []string
+	headerPos := -1
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				edits = append(edits, g.imports(decl))
+				continue
+			}
+			if decl.Tok == token.VAR {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					if len(spec.Names) != 1 {
+						continue
+					}
+					name := spec.Names[0].Name
+					if name == registryName || strings.HasSuffix(name, jsonNamesSuffix) {
+						if len(decl.Specs) != 1 {
+							return nil, fmt.Errorf("%s must be declared on its own", name)
+						}
+						edits = append(edits, g.remove(decl, decl.Doc))
+						continue
+					}
+					g.vars[name] = spec
+				}
+				continue
+			}
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if headerDecls[spec.Name.Name] {
+					if len(decl.Specs) != 1 {
+						return nil, fmt.Errorf("%s must be declared on its own", spec.Name.Name)
+					}
+					edits = append(edits, g.remove(decl, decl.Doc))
+					continue
+				}
+				g.types[spec.Name.Name] = spec
+				order = append(order, spec.Name.Name)
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				if headerDecls[decl.Name.Name] {
+					if headerPos < 0 {
+						headerPos = g.offset(docPos(decl, decl.Doc))
+					}
+					edits = append(edits, g.remove(decl, decl.Doc))
+				}
+				continue
+			}
+			recv := receiverName(decl)
+			if headerDecls[recv] {
+				edits = append(edits, g.remove(decl, decl.Doc))
+				continue
+			}
+			if generatedMethods[decl.Name.Name] {
+				edits = append(edits, g.remove(decl, decl.Doc))
+				continue
+			}
+			if g.methods[recv] == nil {
+				g.methods[recv] = map[string]*ast.FuncDecl{}
+			}
+			g.methods[recv][decl.Name.Name] = decl
+		}
+	}
+	if headerPos < 0 {
+		return nil, fmt.Errorf("Marshal and Unmarshal functions not found")
+	}
+	registry, err := g.registry(order)
+	if err != nil {
+		return nil, fmt.Errorf("generating %s: %v", registryName, err)
+	}
+	edits = append(edits, edit{start: headerPos, end: headerPos, text: strings.TrimPrefix(header, "\n") + "\n" + registry + "\n"})
+
+	for _, name := range order {
+		text, err := g.codec(name)
+		if err != nil {
+			return nil, fmt.Errorf("generating %s: %v", name, err)
+		}
+		jsonText, err := g.jsonCodec(name)
+		if err != nil {
+			return nil, fmt.Errorf("generating JSON methods of %s: %v", name, err)
+		}
+		text += jsonText
+		if text == "" {
+			continue
+		}
+		text, err = formatDecls(text)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %v", name, err)
+		}
+		text += "\n"
+		marshal := g.methods[name]["MarshalBinary"]
+		if marshal == nil {
+			return nil, fmt.Errorf("%s has no MarshalBinary method", name)
+		}
+		pos := g.offset(docPos(marshal, marshal.Doc))
+		edits = append(edits, edit{start: pos, end: pos, text: text})
+	}
+
+	out, err := apply(src, edits)
+	if err != nil {
+		return nil, err
+	}
+	// the generated code is formatted before it is inserted, the rest of the
+	// file is left as it is
+	if _, err := parser.ParseFile(token.NewFileSet(), path, out, 0); err != nil {
+		return nil, fmt.Errorf("parsing output: %v", err)
+	}
+	return out, nil
+}
+
+// formatDecls formats the source of top level declarations.
+func formatDecls(text string) (string, error) {
+	const prefix = "package xdr\n\n"
+	formatted, err := format.Source([]byte(prefix + text))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(string(formatted), prefix), nil
+}
+
+// apply applies non overlapping edits to src.
+func apply(src []byte, edits []edit) ([]byte, error) {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start < edits[j].start
+		}
+		// insertions go before removals starting at the same offset
+		return edits[i].end < edits[j].end
+	})
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		if e.start < last {
+			return nil, fmt.Errorf("overlapping edits at offset %d", e.start)
+		}
+		out.Write(src[last:e.start])
+		out.WriteString(e.text)
+		last = e.end
+	}
+	out.Write(src[last:])
+	return out.Bytes(), nil
+}
+
+func (g *generator) offset(pos token.Pos) int {
+	return g.fset.Position(pos).Offset
+}
+
+func docPos(node ast.Node, doc *ast.CommentGroup) token.Pos {
+	if doc != nil {
+		return doc.Pos()
+	}
+	return node.Pos()
+}
+
+// remove removes a declaration, its doc comment and the newlines following
+// it.
+func (g *generator) remove(node ast.Node, doc *ast.CommentGroup) edit {
+	start := g.offset(docPos(node, doc))
+	end := g.offset(node.End())
+	for end < len(g.src) && g.src[end] == '\n' {
+		end++
+	}
+	return edit{start: start, end: end}
+}
+
+// imports adds the imports used by the generated code to the import
+// declaration.
+func (g *generator) imports(decl *ast.GenDecl) edit {
+	var std, other []string
+	seen := map[string]bool{}
+	add := func(path, name string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		line := strconv.Quote(path)
+		if name != "" {
+			line = name + " " + line
+		}
+		if strings.Contains(path, ".") {
+			other = append(other, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	for _, spec := range decl.Specs {
+		spec := spec.(*ast.ImportSpec)
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		add(path, name)
+	}
+	for _, path := range headerImports {
+		add(path, "")
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for _, line := range std {
+		fmt.Fprintf(&b, "\t%s\n", line)
+	}
+	if len(other) > 0 {
+		b.WriteString("\n")
+		for _, line := range other {
+			fmt.Fprintf(&b, "\t%s\n", line)
+		}
+	}
+	b.WriteString(")")
+	return edit{start: g.offset(decl.Pos()), end: g.offset(decl.End()), text: b.String()}
+}
+
+func receiverName(decl *ast.FuncDecl) string {
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// codec returns the EncodeTo and DecodeFrom methods of a type.
+func (g *generator) codec(name string) (string, error) {
+	spec := g.types[name]
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		if g.methods[name]["SwitchFieldName"] != nil {
+			return g.union(name, typ)
+		}
+		return g.structure(name, typ)
+	case *ast.Ident:
+		if g.methods[name]["ValidEnum"] != nil {
+			return g.enum(name, typ)
+		}
+		if isBasic(typ.Name) {
+			return g.basicTypedef(name, typ)
+		}
+		if g.types[typ.Name] == nil {
+			return "", fmt.Errorf("unknown type %s", typ.Name)
+		}
+		return g.namedTypedef(name, typ)
+	case *ast.ArrayType:
+		return g.arrayTypedef(name, typ)
+	case *ast.StarExpr:
+		// pointer types can't have methods, values of these types are
+		// encoded inline
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", types.ExprString(spec.Type))
+}
+
+// method contains the code of a generated method
+type method struct {
+	b       bytes.Buffer
+	length  bool
+	present bool
+	err     bool
+}
+
+func (m *method) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&m.b, format, args...)
+}
+
+func encodeTo(recv, name string, body func(m *method) error) (string, error) {
+	m := &method{}
+	if err := body(m); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"// EncodeTo encodes this value using the Encoder.\nfunc (%s %s) EncodeTo(enc *xdr.Encoder) error {\n%s}\n\n",
+		recv, name, m.b.String(),
+	), nil
+}
+
+func decodeFrom(recv, name string, body func(m *method) error) (string, error) {
+	m := &method{}
+	if err := body(m); err != nil {
+		return "", err
+	}
+	var vars strings.Builder
+	if m.length {
+		vars.WriteString("\tvar l uint32\n")
+	}
+	if m.present {
+		vars.WriteString("\tvar present bool\n")
+	}
+	return fmt.Sprintf(
+		"// DecodeFrom decodes this value using the Decoder.\nfunc (%s *%s) DecodeFrom(dec *xdr.Decoder) (int, error) {\n%s%s}\n\n",
+		recv, name, vars.String(), m.b.String(),
+	), nil
+}
+
+func (g *generator) structure(name string, typ *ast.StructType) (string, error) {
+	encode, err := encodeTo("s", name, func(m *method) error {
+		m.printf("\tvar err error\n")
+		for _, field := range typ.Fields.List {
+			if err := checkTag(field); err != nil {
+				return err
+			}
+			for _, fieldName := range field.Names {
+				if err := g.encode(m, "s."+fieldName.Name, field.Type, 0); err != nil {
+					return err
+				}
+			}
+		}
+		m.printf("\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	decode, err := decodeFrom("s", name, func(m *method) error {
+		m.printf("\tvar err error\n\tvar n, nTmp int\n")
+		for _, field := range typ.Fields.List {
+			for _, fieldName := range field.Names {
+				if err := g.decode(m, "s."+fieldName.Name, field.Type, maxSize(field), 0); err != nil {
+					return err
+				}
+			}
+		}
+		m.printf("\treturn n, nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+// unionCase is a case of the switch statement in ArmForSwitch
+type unionCase struct {
+	values string
+	arm    string
+	ok     bool
+}
+
+// unionSwitch parses the switch statement in the ArmForSwitch method of a
+// union.
+func (g *generator) unionSwitch(name string) (tag string, cases []unionCase, dflt *unionCase, err error) {
+	decl := g.methods[name]["ArmForSwitch"]
+	if decl == nil || len(decl.Body.List) == 0 || len(decl.Body.List) > 2 {
+		return "", nil, nil, fmt.Errorf("unexpected ArmForSwitch")
+	}
+	sw, ok := decl.Body.List[0].(*ast.SwitchStmt)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("unexpected ArmForSwitch")
+	}
+	call, ok := sw.Tag.(*ast.CallExpr)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("unexpected switch in ArmForSwitch")
+	}
+	tag = types.ExprString(call.Fun)
+	for _, stmt := range sw.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if len(clause.Body) != 1 {
+			return "", nil, nil, fmt.Errorf("unexpected case in ArmForSwitch")
+		}
+		ret, ok := clause.Body[0].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 2 {
+			return "", nil, nil, fmt.Errorf("unexpected case in ArmForSwitch")
+		}
+		arm, err := strconv.Unquote(ret.Results[0].(*ast.BasicLit).Value)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		c := unionCase{arm: arm, ok: types.ExprString(ret.Results[1]) == "true"}
+		if !c.ok {
+			return "", nil, nil, fmt.Errorf("unexpected invalid case in ArmForSwitch")
+		}
+		if clause.List == nil {
+			dflt = &c
+			continue
+		}
+		var values []string
+		for _, value := range clause.List {
+			values = append(values, types.ExprString(value))
+		}
+		c.values = strings.Join(values, ", ")
+		cases = append(cases, c)
+	}
+	return tag, cases, dflt, nil
+}
+
+// switchField returns the name of the discriminant of a union
+func (g *generator) switchField(name string) (string, error) {
+	decl := g.methods[name]["SwitchFieldName"]
+	if len(decl.Body.List) == 1 {
+		if ret, ok := decl.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			if lit, ok := ret.Results[0].(*ast.BasicLit); ok {
+				return strconv.Unquote(lit.Value)
+			}
+		}
+	}
+	return "", fmt.Errorf("unexpected SwitchFieldName")
+}
+
+func (g *generator) union(name string, typ *ast.StructType) (string, error) {
+	discriminant, err := g.switchField(name)
+	if err != nil {
+		return "", err
+	}
+	tag, cases, dflt, err := g.unionSwitch(name)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]*ast.Field{}
+	for _, field := range typ.Fields.List {
+		for _, fieldName := range field.Names {
+			fields[fieldName.Name] = field
+		}
+	}
+	discriminantField := fields[discriminant]
+	if discriminantField == nil {
+		return "", fmt.Errorf("unknown discriminant %s", discriminant)
+	}
+	arm := func(c unionCase) (*ast.Field, ast.Expr, error) {
+		field := fields[c.arm]
+		if field == nil {
+			return nil, nil, fmt.Errorf("unknown arm %s", c.arm)
+		}
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			return nil, nil, fmt.Errorf("arm %s is not a pointer", c.arm)
+		}
+		return field, star.X, nil
+	}
+
+	encode, err := encodeTo("u", name, func(m *method) error {
+		m.printf("\tvar err error\n")
+		if err := g.encode(m, "u."+discriminant, discriminantField.Type, 0); err != nil {
+			return err
+		}
+		m.printf("\tswitch %s(u.%s) {\n", tag, discriminant)
+		encodeCase := func(c unionCase) error {
+			if c.arm == "" {
+				m.printf("\t\t// Void\n\t\treturn nil\n")
+				return nil
+			}
+			_, elem, err := arm(c)
+			if err != nil {
+				return err
+			}
+			m.printf("\t\tif u.%s == nil {\n\t\t\treturn errEncodeNilUnionValue()\n\t\t}\n", c.arm)
+			if err := g.encode(m, "(*u."+c.arm+")", elem, 0); err != nil {
+				return err
+			}
+			m.printf("\t\treturn nil\n")
+			return nil
+		}
+		for _, c := range cases {
+			m.printf("\tcase %s:\n", c.values)
+			if err := encodeCase(c); err != nil {
+				return err
+			}
+		}
+		if dflt != nil {
+			m.printf("\tdefault:\n")
+			if err := encodeCase(*dflt); err != nil {
+				return err
+			}
+			m.printf("\t}\n")
+			return nil
+		}
+		m.printf("\t}\n\treturn errEncodeInvalidUnionSwitch(int32(u.%s))\n", discriminant)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	decode, err := decodeFrom("u", name, func(m *method) error {
+		m.printf("\tvar err error\n\tvar n, nTmp int\n\t*u = %s{}\n", name)
+		// like the reflection based decoder, decode the discriminant as
+		// an int and report invalid enum values as invalid union switches
+		m.printf("\tvar sw int32\n\tsw, nTmp, err = dec.DecodeInt()\n%s", check)
+		if _, ok := g.enumType(discriminantField.Type); ok {
+			m.printf("\tif !u.%s.ValidEnum(sw) {\n\t\treturn n, errDecodeInvalidUnionEnum(sw)\n\t}\n", discriminant)
+		}
+		m.printf("\tu.%s = %s(sw)\n", discriminant, types.ExprString(discriminantField.Type))
+		m.printf("\tswitch %s(u.%s) {\n", tag, discriminant)
+		decodeCase := func(c unionCase) error {
+			if c.arm == "" {
+				m.printf("\t\t// Void\n\t\treturn n, nil\n")
+				return nil
+			}
+			field, elem, err := arm(c)
+			if err != nil {
+				return err
+			}
+			m.printf("\t\tu.%s = new(%s)\n", c.arm, types.ExprString(elem))
+			if err := g.decode(m, "(*u."+c.arm+")", elem, maxSize(field), 0); err != nil {
+				return err
+			}
+			m.printf("\t\treturn n, nil\n")
+			return nil
+		}
+		for _, c := range cases {
+			m.printf("\tcase %s:\n", c.values)
+			if err := decodeCase(c); err != nil {
+				return err
+			}
+		}
+		if dflt != nil {
+			m.printf("\tdefault:\n")
+			if err := decodeCase(*dflt); err != nil {
+				return err
+			}
+			m.printf("\t}\n")
+			return nil
+		}
+		m.printf("\t}\n\treturn n, errDecodeInvalidUnionSwitch(int32(u.%s))\n", discriminant)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+// enumType returns the name of typ if it is an enum.
+func (g *generator) enumType(typ ast.Expr) (string, bool) {
+	ident, ok := typ.(*ast.Ident)
+	if !ok || g.methods[ident.Name]["ValidEnum"] == nil {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+func (g *generator) enum(name string, typ *ast.Ident) (string, error) {
+	if typ.Name != "int32" {
+		return "", fmt.Errorf("unsupported enum type %s", typ.Name)
+	}
+	return fmt.Sprintf(`// EncodeTo encodes this value using the Encoder.
+func (e %[1]s) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *%[1]s) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = %[1]s(v)
+	return n, nil
+}
+
+`, name), nil
+}
+
+// basicMethods are the methods of xdr.Encoder and xdr.Decoder used for the
+// basic types.
+var basicMethods = map[string]string{
+	"int32":  "Int",
+	"uint32": "Uint",
+	"int64":  "Hyper",
+	"uint64": "Uhyper",
+	"bool":   "Bool",
+	"string": "String",
+}
+
+func isBasic(name string) bool {
+	_, ok := basicMethods[name]
+	return ok
+}
+
+// sizedArgument returns the max size argument of the Decoder method of
+// strings.
+func sizedArgument(basic string, max int) string {
+	if basic == "String" {
+		return strconv.Itoa(max)
+	}
+	return ""
+}
+
+func (g *generator) basicTypedef(name string, typ *ast.Ident) (string, error) {
+	basic := basicMethods[typ.Name]
+	max, err := g.xdrMaxSize(name)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`// EncodeTo encodes this value using the Encoder.
+func (s %[1]s) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.Encode%[2]s(%[3]s(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *%[1]s) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.Decode%[2]s(%[4]s)
+	if err != nil {
+		return n, err
+	}
+	*s = %[1]s(v)
+	return n, nil
+}
+
+`, name, basic, typ.Name, sizedArgument(basic, max)), nil
+}
+
+func (g *generator) namedTypedef(name string, typ *ast.Ident) (string, error) {
+	return fmt.Sprintf(`// EncodeTo encodes this value using the Encoder.
+func (s %[1]s) EncodeTo(enc *xdr.Encoder) error {
+	return %[2]s(s).EncodeTo(enc)
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *%[1]s) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return (*%[2]s)(s).DecodeFrom(dec)
+}
+
+`, name, typ.Name), nil
+}
+
+func (g *generator) arrayTypedef(name string, typ *ast.ArrayType) (string, error) {
+	max, err := g.xdrMaxSize(name)
+	if err != nil {
+		return "", err
+	}
+	if isByte(typ.Elt) {
+		if typ.Len == nil {
+			return fmt.Sprintf(`// EncodeTo encodes this value using the Encoder.
+func (s %[1]s) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *%[1]s) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, %[2]d)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+`, name, max), nil
+		}
+		return fmt.Sprintf(`// EncodeTo encodes this value using the Encoder.
+func (s %[1]s) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *%[1]s) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
+`, name), nil
+	}
+
+	encode, err := encodeTo("s", name, func(m *method) error {
+		m.printf("\tvar err error\n")
+		if err := g.encode(m, "s", typ, 0); err != nil {
+			return err
+		}
+		m.printf("\treturn nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	decode, err := decodeFrom("s", name, func(m *method) error {
+		m.printf("\tvar err error\n\tvar n, nTmp int\n")
+		if err := g.decode(m, "(*s)", typ, strconv.Itoa(max), 0); err != nil {
+			return err
+		}
+		m.printf("\treturn n, nil\n")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return encode + decode, nil
+}
+
+// xdrMaxSize returns the size returned by the XDRMaxSize method of a type, or
+// 0 if the type has no maximum size.
+func (g *generator) xdrMaxSize(name string) (int, error) {
+	decl := g.methods[name]["XDRMaxSize"]
+	if decl == nil {
+		return 0, nil
+	}
+	if len(decl.Body.List) == 1 {
+		if ret, ok := decl.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			if lit, ok := ret.Results[0].(*ast.BasicLit); ok {
+				return strconv.Atoi(lit.Value)
+			}
+		}
+	}
+	return 0, fmt.Errorf("unexpected XDRMaxSize")
+}
+
+// checkTag returns an error if a struct field has tags which are not
+// supported
+func checkTag(field *ast.Field) error {
+	if field.Tag == nil {
+		return nil
+	}
+	tag := tag(field)
+	if tag.Get("xdropaque") != "" {
+		return fmt.Errorf("xdropaque tags are not supported")
+	}
+	return nil
+}
+
+func tag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	value, _ := strconv.Unquote(field.Tag.Value)
+	return reflect.StructTag(value)
+}
+
+// maxSize returns the xdrmaxsize tag of a field
+func maxSize(field *ast.Field) string {
+	return tag(field).Get("xdrmaxsize")
+}
+
+func isByte(typ ast.Expr) bool {
+	ident, ok := typ.(*ast.Ident)
+	return ok && (ident.Name == "byte" || ident.Name == "uint8")
+}
+
+// loopVariable returns the name of the index variable of a loop at the
+// given nesting depth
+func loopVariable(depth int) string {
+	return string(rune('i' + depth))
+}
+
+// methodReceiver returns the expression used to call a method on expr
+func methodReceiver(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return expr
+}
+
+// assignable returns the expression used to assign to expr
+func assignable(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[1 : len(expr)-1]
+	}
+	return expr
+}
+
+// encode writes the statements encoding expr which is of type typ.
+func (g *generator) encode(m *method, expr string, typ ast.Expr, depth int) error {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		if basic, ok := basicMethods[typ.Name]; ok {
+			m.printf("\tif _, err = enc.Encode%s(%s); err != nil {\n\t\treturn err\n\t}\n", basic, assignable(expr))
+			return nil
+		}
+		spec := g.types[typ.Name]
+		if spec == nil {
+			return fmt.Errorf("unknown type %s", typ.Name)
+		}
+		if star, ok := spec.Type.(*ast.StarExpr); ok {
+			// named pointer types have no methods, the value is
+			// converted to the pointer type to call them
+			pointee := fmt.Sprintf("(*(%s)(%s))", types.ExprString(star), expr)
+			return g.encodeOptional(m, expr, pointee, star.X, depth)
+		}
+		m.printf("\tif err = %s.EncodeTo(enc); err != nil {\n\t\treturn err\n\t}\n", methodReceiver(expr))
+		return nil
+	case *ast.ArrayType:
+		if isByte(typ.Elt) {
+			if typ.Len == nil {
+				m.printf("\tif _, err = enc.EncodeOpaque(%s); err != nil {\n\t\treturn err\n\t}\n", assignable(expr))
+			} else {
+				m.printf("\tif _, err = enc.EncodeFixedOpaque(%s[:]); err != nil {\n\t\treturn err\n\t}\n", expr)
+			}
+			return nil
+		}
+		if typ.Len == nil {
+			m.printf("\tif _, err = enc.EncodeUint(uint32(len(%s))); err != nil {\n\t\treturn err\n\t}\n", assignable(expr))
+		}
+		i := loopVariable(depth)
+		m.printf("\tfor %[1]s := 0; %[1]s < len(%[2]s); %[1]s++ {\n", i, assignable(expr))
+		if err := g.encode(m, fmt.Sprintf("%s[%s]", expr, i), typ.Elt, depth+1); err != nil {
+			return err
+		}
+		m.printf("\t}\n")
+		return nil
+	case *ast.StarExpr:
+		return g.encodeOptional(m, expr, "(*"+expr+")", typ.X, depth)
+	}
+	return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+}
+
+// encodeOptional writes the statements encoding the optional value expr.
+// pointee is the expression of the value expr points to.
+func (g *generator) encodeOptional(m *method, expr, pointee string, elem ast.Expr, depth int) error {
+	m.printf("\tif _, err = enc.EncodeBool(%s != nil); err != nil {\n\t\treturn err\n\t}\n", assignable(expr))
+	m.printf("\tif %s != nil {\n", assignable(expr))
+	if err := g.encode(m, pointee, elem, depth); err != nil {
+		return err
+	}
+	m.printf("\t}\n")
+	return nil
+}
+
+// zeroValue returns the expression of the zero value of typ.
+func (g *generator) zeroValue(typ ast.Expr) (string, error) {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		switch typ.Name {
+		case "string":
+			return `""`, nil
+		case "bool":
+			return "false", nil
+		}
+		if isBasic(typ.Name) {
+			return "0", nil
+		}
+		spec := g.types[typ.Name]
+		if spec == nil {
+			return "", fmt.Errorf("unknown type %s", typ.Name)
+		}
+		switch underlying := spec.Type.(type) {
+		case *ast.StructType:
+			return typ.Name + "{}", nil
+		case *ast.ArrayType:
+			if underlying.Len != nil {
+				return typ.Name + "{}", nil
+			}
+			return "nil", nil
+		}
+		return g.zeroValue(spec.Type)
+	case *ast.ArrayType:
+		if typ.Len != nil {
+			return types.ExprString(typ) + "{}", nil
+		}
+		return "nil", nil
+	case *ast.StarExpr:
+		return "nil", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", types.ExprString(typ))
+}
+
+// check is the code checking the error returned by a Decoder method
+const check = "\tn += nTmp\n\tif err != nil {\n\t\treturn n, err\n\t}\n"
+
+// decode writes the statements decoding into target which is of type typ.
+// max is the xdrmaxsize tag of the field.
+func (g *generator) decode(m *method, target string, typ ast.Expr, max string, depth int) error {
+	if max == "" {
+		max = "0"
+	}
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		if basic, ok := basicMethods[typ.Name]; ok {
+			arg := ""
+			if basic == "String" {
+				arg = max
+			}
+			m.printf("\t%s, nTmp, err = dec.Decode%s(%s)\n%s", assignable(target), basic, arg, check)
+			return nil
+		}
+		spec := g.types[typ.Name]
+		if spec == nil {
+			return fmt.Errorf("unknown type %s", typ.Name)
+		}
+		if star, ok := spec.Type.(*ast.StarExpr); ok {
+			pointee := fmt.Sprintf("(*(%s)(%s))", types.ExprString(star), assignable(target))
+			return g.decodeOptional(m, target, pointee, star.X, depth)
+		}
+		if max != "0" {
+			return fmt.Errorf("xdrmaxsize tags on fields of type %s are not supported", typ.Name)
+		}
+		m.printf("\tnTmp, err = %s.DecodeFrom(dec)\n%s", methodReceiver(target), check)
+		return nil
+	case *ast.ArrayType:
+		if isByte(typ.Elt) {
+			if typ.Len == nil {
+				m.printf("\t%s, nTmp, err = decodeOpaque(dec, %s)\n%s", assignable(target), max, check)
+			} else {
+				m.printf("\tnTmp, err = decodeFixedOpaque(dec, %s[:])\n%s", target, check)
+			}
+			return nil
+		}
+		i := loopVariable(depth)
+		if typ.Len != nil {
+			m.printf("\tfor %[1]s := 0; %[1]s < len(%[2]s); %[1]s++ {\n", i, assignable(target))
+			if err := g.decode(m, fmt.Sprintf("%s[%s]", target, i), typ.Elt, "", depth+1); err != nil {
+				return err
+			}
+			m.printf("\t}\n")
+			return nil
+		}
+		if m.length && depth > 0 {
+			return fmt.Errorf("nested variable length arrays are not supported")
+		}
+		m.length = true
+		m.printf("\tl, nTmp, err = dec.DecodeUint()\n%s", check)
+		limit := max
+		if limit == "0" {
+			limit = "math.MaxInt32"
+		}
+		m.printf("\tif l > %s {\n\t\treturn n, errDecodeMaxSlice(l)\n\t}\n", limit)
+		m.printf("\t%s = nil\n", assignable(target))
+		zero, err := g.zeroValue(typ.Elt)
+		if err != nil {
+			return err
+		}
+		// the slice grows while it's decoded as the length read from the
+		// input can't be trusted
+		m.printf("\tif l > 0 {\n\t\t%s = make([]%s, 0, sliceCapacity(l))\n", assignable(target), types.ExprString(typ.Elt))
+		m.printf("\t\tfor %[1]s := uint32(0); %[1]s < l; %[1]s++ {\n", i)
+		m.printf("\t\t\t%s = append(%s, %s)\n", assignable(target), assignable(target), zero)
+		if err := g.decode(m, fmt.Sprintf("%s[%s]", target, i), typ.Elt, "", depth+1); err != nil {
+			return err
+		}
+		m.printf("\t\t}\n\t}\n")
+		return nil
+	case *ast.StarExpr:
+		return g.decodeOptional(m, target, "(*"+target+")", typ.X, depth)
+	}
+	return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+}
+
+// decodeOptional writes the statements decoding the optional value target.
+// pointee is the expression of the value target points to.
+func (g *generator) decodeOptional(m *method, target, pointee string, elem ast.Expr, depth int) error {
+	m.present = true
+	m.printf("\tpresent, nTmp, err = dec.DecodeBool()\n%s", check)
+	m.printf("\t%s = nil\n", assignable(target))
+	m.printf("\tif present {\n\t\t%s = new(%s)\n", assignable(target), types.ExprString(elem))
+	// like the reflection based decoder, ignore the max size of optional
+	// values
+	if err := g.decode(m, pointee, elem, "", depth); err != nil {
+		return err
+	}
+	m.printf("\t}\n")
+	return nil
+}
@@ -11,7 +11,6 @@ import (
 	"io"
 	"strings"
 
-	xdr "github.com/stellar/go-xdr/xdr3"
 	"github.com/stellar/go/support/errors"
 )
 
@@ -149,7 +148,7 @@ func UnmarshalFramed(r io.Reader, v interface{}) (int, error) {
 	if err != nil {
 		return 0, errors.Wrap(err, "unmarshalling XDR frame header")
 	}
-	m, err := xdr.Unmarshal(r, v)
+	m, err := Unmarshal(r, v)
 	if err != nil {
 		return 0, errors.Wrap(err, "unmarshalling framed XDR")
 	}
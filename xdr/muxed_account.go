@@ -21,30 +21,105 @@ func MustMuxedAddressPtr(address string) *MuxedAccount {
 	return &muxed
 }
 
+// AddressToMuxedAccount returns the MuxedAccount form of the provided G or M
+// address
+func AddressToMuxedAccount(address string) (MuxedAccount, error) {
+	result := MuxedAccount{}
+	err := result.SetAddress(address)
+
+	return result, err
+}
+
+// MuxedAccountFromAccountId returns the muxed account with the given ID of
+// the account with the given G address
+func MuxedAccountFromAccountId(address string, id uint64) (MuxedAccount, error) {
+	accountID, err := AddressToAccountId(address)
+	if err != nil {
+		return MuxedAccount{}, err
+	}
+	return NewMuxedAccount(CryptoKeyTypeKeyTypeMuxedEd25519, MuxedAccountMed25519{
+		Id:      Uint64(id),
+		Ed25519: *accountID.Ed25519,
+	})
+}
+
 // SetAddress modifies the receiver, setting it's value to the MuxedAccount form
-// of the provided address.
+// of the provided address. Both G addresses and M addresses (SEP-23) are
+// accepted.
 func (m *MuxedAccount) SetAddress(address string) error {
 	if m == nil {
 		return nil
 	}
 
-	switch len(address) {
-	case 56:
-		raw, err := strkey.Decode(strkey.VersionByteAccountID, address)
+	// M addresses encode a 32 byte key and an 8 byte ID
+	if len(address) == 69 {
+		muxed, err := strkey.DecodeMuxedAccount(address)
 		if err != nil {
 			return err
 		}
-		if len(raw) != 32 {
-			return errors.New("invalid address")
-		}
-		var ui Uint256
-		copy(ui[:], raw)
-		*m, err = NewMuxedAccount(CryptoKeyTypeKeyTypeEd25519, ui)
+		*m, err = NewMuxedAccount(CryptoKeyTypeKeyTypeMuxedEd25519, MuxedAccountMed25519{
+			Id:      Uint64(muxed.ID()),
+			Ed25519: Uint256(muxed.Ed25519()),
+		})
 		return err
-	default:
+	}
+
+	raw, err := strkey.Decode(strkey.VersionByteAccountID, address)
+	if err != nil {
+		return err
+	}
+	if len(raw) != 32 {
 		return errors.New("invalid address")
 	}
+	var ui Uint256
+	copy(ui[:], raw)
+	*m, err = NewMuxedAccount(CryptoKeyTypeKeyTypeEd25519, ui)
+	return err
+}
+
+// Address returns the strkey encoded form of this MuxedAccount: a G address
+// for ed25519 accounts and an M address for muxed accounts. This method will
+// panic if the MuxedAccount is of an unknown type.
+func (m MuxedAccount) Address() string {
+	address, err := m.GetAddress()
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
 
+// GetAddress returns the strkey encoded form of this MuxedAccount, and an
+// error if the MuxedAccount is of an unknown type.
+func (m MuxedAccount) GetAddress() (string, error) {
+	switch m.Type {
+	case CryptoKeyTypeKeyTypeEd25519:
+		ed, ok := m.GetEd25519()
+		if !ok {
+			return "", fmt.Errorf("Could not get Ed25519")
+		}
+		return strkey.Encode(strkey.VersionByteAccountID, ed[:])
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		med, ok := m.GetMed25519()
+		if !ok {
+			return "", fmt.Errorf("Could not get Med25519")
+		}
+		var muxed strkey.MuxedAccount
+		muxed.SetID(uint64(med.Id))
+		muxed.SetEd25519(med.Ed25519)
+		return muxed.Address()
+	default:
+		return "", fmt.Errorf("Unknown muxed account type: %v", m.Type)
+	}
+}
+
+// GetId returns the ID of a muxed account, and an error if the MuxedAccount
+// is not a muxed account
+func (m MuxedAccount) GetId() (uint64, error) {
+	med, ok := m.GetMed25519()
+	if !ok {
+		return 0, errors.New("muxed account has no ID")
+	}
+	return uint64(med.Id), nil
 }
 
 // ToAccountId transforms a MuxedAccount to an AccountId, dropping the
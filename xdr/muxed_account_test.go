@@ -26,6 +26,47 @@ var _ = Describe("xdr.MuxedAccount#Get/SetAddress()", func() {
 		err = muxed.SetAddress("G47QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVP2I")
 		Expect(err).Should(HaveOccurred())
 
+		err = muxed.SetAddress("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAEHFA")
+		Expect(err).Should(HaveOccurred())
+
+		err = muxed.SetAddress("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUR")
+		Expect(err).Should(HaveOccurred())
+
+	})
+
+	It("round trips G addresses", func() {
+		address := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+		muxed, err := AddressToMuxedAccount(address)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(muxed.Type).To(Equal(CryptoKeyTypeKeyTypeEd25519))
+		Expect(muxed.Address()).To(Equal(address))
+
+		_, err = muxed.GetId()
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("round trips M addresses", func() {
+		address := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+		muxed, err := AddressToMuxedAccount(address)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(muxed.Type).To(Equal(CryptoKeyTypeKeyTypeMuxedEd25519))
+		Expect(muxed.Address()).To(Equal(address))
+
+		id, err := muxed.GetId()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(id).To(Equal(uint64(9223372036854775808)))
+
+		aid := muxed.ToAccountId()
+		Expect(aid.Address()).To(Equal("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"))
+	})
+
+	It("builds M addresses from G addresses", func() {
+		muxed, err := MuxedAccountFromAccountId("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ", 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(muxed.Address()).To(Equal("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ"))
+
+		_, err = MuxedAccountFromAccountId("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ", 0)
+		Expect(err).Should(HaveOccurred())
 	})
 })
 
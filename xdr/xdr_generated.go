@@ -17,28 +17,451 @@ import (
 	"encoding"
 	"fmt"
 	"io"
+	"math"
+	"reflect"
 
 	xdr "github.com/stellar/go-xdr/xdr3"
 )
 
+type encoderTo interface {
+	EncodeTo(enc *xdr.Encoder) error
+}
+
+type decoderFrom interface {
+	DecodeFrom(dec *xdr.Decoder) (int, error)
+}
+
 // Unmarshal reads an xdr element from `r` into `v`.
 func Unmarshal(r io.Reader, v interface{}) (int, error) {
+	if decodable, ok := v.(decoderFrom); ok && !isNilPointer(v) {
+		return decodable.DecodeFrom(xdr.NewDecoder(r))
+	}
 	// delegate to xdr package's Unmarshal
 	return xdr.Unmarshal(r, v)
 }
 
 // Marshal writes an xdr element `v` into `w`.
 func Marshal(w io.Writer, v interface{}) (int, error) {
+	if encodable, ok := v.(encoderTo); ok && !isNilPointer(v) {
+		cw := countingWriter{w: w}
+		err := encodable.EncodeTo(xdr.NewEncoder(&cw))
+		return cw.n, err
+	}
 	// delegate to xdr package's Marshal
 	return xdr.Marshal(w, v)
 }
 
+// isNilPointer returns true if v is a nil pointer. The reflection based
+// encoder returns errors for nil pointers, which are kept for compatibility.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// decodeFixedOpaque decodes fixed length opaque data into dst.
+func decodeFixedOpaque(dec *xdr.Decoder, dst []byte) (int, error) {
+	b, n, err := dec.DecodeFixedOpaque(int32(len(dst)))
+	if err != nil {
+		return n, err
+	}
+	copy(dst, b)
+	return n, nil
+}
+
+// sliceCapacity returns the initial capacity of a decoded slice of length l.
+// The capacity is limited because the length is read from the input and
+// could be much larger than the input.
+func sliceCapacity(l uint32) int {
+	if l > 1024 {
+		return 1024
+	}
+	return int(l)
+}
+
+// decodeOpaque decodes variable length opaque data of at most maxSize bytes,
+// or any length if maxSize is 0.
+func decodeOpaque(dec *xdr.Decoder, maxSize uint32) ([]byte, int, error) {
+	l, n, err := dec.DecodeUint()
+	if err != nil {
+		return nil, n, err
+	}
+	if maxSize == 0 {
+		maxSize = math.MaxInt32
+	}
+	if l > maxSize {
+		return nil, n, errDecodeMaxSlice(l)
+	}
+	v, nTmp, err := dec.DecodeFixedOpaque(int32(l))
+	return v, n + nTmp, err
+}
+
+func errEncodeInvalidEnum(v interface{}) error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadEnumValue,
+		Func:        "encode",
+		Description: "invalid enum",
+		Value:       v,
+	}
+}
+
+func errEncodeInvalidUnionSwitch(sw int32) error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "encodeUnion",
+		Description: fmt.Sprintf("invalid union switch: %d", sw),
+	}
+}
+
+func errEncodeNilUnionValue() error {
+	return &xdr.MarshalError{
+		ErrorCode:   xdr.ErrBadUnionValue,
+		Func:        "encodeUnion",
+		Description: "can't encode nil union value",
+	}
+}
+
+func errDecodeInvalidEnum(v int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadEnumValue,
+		Func:        "decode",
+		Description: "invalid enum",
+		Value:       v,
+	}
+}
+
+func errDecodeInvalidUnionSwitch(sw int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "decode",
+		Description: fmt.Sprintf("switch '%d' is not valid for union", sw),
+	}
+}
+
+func errDecodeInvalidUnionEnum(sw int32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrBadUnionSwitch,
+		Func:        "decode",
+		Description: fmt.Sprintf("switch '%d' is not valid enum value for union", sw),
+	}
+}
+
+func errDecodeMaxSlice(length uint32) error {
+	return &xdr.UnmarshalError{
+		ErrorCode:   xdr.ErrOverflow,
+		Func:        "decodeArray",
+		Description: "data exceeds max slice limit",
+		Value:       length,
+	}
+}
+
+// xdrTypes are the types which can be created by name with NewValue.
+var xdrTypes = map[string]reflect.Type{
+	"AccountEntry":                        reflect.TypeOf((*AccountEntry)(nil)).Elem(),
+	"AccountEntryExt":                     reflect.TypeOf((*AccountEntryExt)(nil)).Elem(),
+	"AccountEntryExtensionV1":             reflect.TypeOf((*AccountEntryExtensionV1)(nil)).Elem(),
+	"AccountEntryExtensionV1Ext":          reflect.TypeOf((*AccountEntryExtensionV1Ext)(nil)).Elem(),
+	"AccountEntryExtensionV2":             reflect.TypeOf((*AccountEntryExtensionV2)(nil)).Elem(),
+	"AccountEntryExtensionV2Ext":          reflect.TypeOf((*AccountEntryExtensionV2Ext)(nil)).Elem(),
+	"AccountFlags":                        reflect.TypeOf((*AccountFlags)(nil)).Elem(),
+	"AccountId":                           reflect.TypeOf((*AccountId)(nil)).Elem(),
+	"AccountMergeResult":                  reflect.TypeOf((*AccountMergeResult)(nil)).Elem(),
+	"AccountMergeResultCode":              reflect.TypeOf((*AccountMergeResultCode)(nil)).Elem(),
+	"AllowTrustOp":                        reflect.TypeOf((*AllowTrustOp)(nil)).Elem(),
+	"AllowTrustResult":                    reflect.TypeOf((*AllowTrustResult)(nil)).Elem(),
+	"AllowTrustResultCode":                reflect.TypeOf((*AllowTrustResultCode)(nil)).Elem(),
+	"Asset":                               reflect.TypeOf((*Asset)(nil)).Elem(),
+	"AssetAlphaNum12":                     reflect.TypeOf((*AssetAlphaNum12)(nil)).Elem(),
+	"AssetAlphaNum4":                      reflect.TypeOf((*AssetAlphaNum4)(nil)).Elem(),
+	"AssetCode":                           reflect.TypeOf((*AssetCode)(nil)).Elem(),
+	"AssetCode12":                         reflect.TypeOf((*AssetCode12)(nil)).Elem(),
+	"AssetCode4":                          reflect.TypeOf((*AssetCode4)(nil)).Elem(),
+	"AssetType":                           reflect.TypeOf((*AssetType)(nil)).Elem(),
+	"Auth":                                reflect.TypeOf((*Auth)(nil)).Elem(),
+	"AuthCert":                            reflect.TypeOf((*AuthCert)(nil)).Elem(),
+	"AuthenticatedMessage":                reflect.TypeOf((*AuthenticatedMessage)(nil)).Elem(),
+	"AuthenticatedMessageV0":              reflect.TypeOf((*AuthenticatedMessageV0)(nil)).Elem(),
+	"BeginSponsoringFutureReservesOp":     reflect.TypeOf((*BeginSponsoringFutureReservesOp)(nil)).Elem(),
+	"BeginSponsoringFutureReservesResult": reflect.TypeOf((*BeginSponsoringFutureReservesResult)(nil)).Elem(),
+	"BeginSponsoringFutureReservesResultCode": reflect.TypeOf((*BeginSponsoringFutureReservesResultCode)(nil)).Elem(),
+	"BucketEntry":                           reflect.TypeOf((*BucketEntry)(nil)).Elem(),
+	"BucketEntryType":                       reflect.TypeOf((*BucketEntryType)(nil)).Elem(),
+	"BucketMetadata":                        reflect.TypeOf((*BucketMetadata)(nil)).Elem(),
+	"BucketMetadataExt":                     reflect.TypeOf((*BucketMetadataExt)(nil)).Elem(),
+	"BumpSequenceOp":                        reflect.TypeOf((*BumpSequenceOp)(nil)).Elem(),
+	"BumpSequenceResult":                    reflect.TypeOf((*BumpSequenceResult)(nil)).Elem(),
+	"BumpSequenceResultCode":                reflect.TypeOf((*BumpSequenceResultCode)(nil)).Elem(),
+	"ChangeTrustOp":                         reflect.TypeOf((*ChangeTrustOp)(nil)).Elem(),
+	"ChangeTrustResult":                     reflect.TypeOf((*ChangeTrustResult)(nil)).Elem(),
+	"ChangeTrustResultCode":                 reflect.TypeOf((*ChangeTrustResultCode)(nil)).Elem(),
+	"ClaimClaimableBalanceOp":               reflect.TypeOf((*ClaimClaimableBalanceOp)(nil)).Elem(),
+	"ClaimClaimableBalanceResult":           reflect.TypeOf((*ClaimClaimableBalanceResult)(nil)).Elem(),
+	"ClaimClaimableBalanceResultCode":       reflect.TypeOf((*ClaimClaimableBalanceResultCode)(nil)).Elem(),
+	"ClaimOfferAtom":                        reflect.TypeOf((*ClaimOfferAtom)(nil)).Elem(),
+	"ClaimPredicate":                        reflect.TypeOf((*ClaimPredicate)(nil)).Elem(),
+	"ClaimPredicateType":                    reflect.TypeOf((*ClaimPredicateType)(nil)).Elem(),
+	"ClaimableBalanceEntry":                 reflect.TypeOf((*ClaimableBalanceEntry)(nil)).Elem(),
+	"ClaimableBalanceEntryExt":              reflect.TypeOf((*ClaimableBalanceEntryExt)(nil)).Elem(),
+	"ClaimableBalanceEntryExtensionV1":      reflect.TypeOf((*ClaimableBalanceEntryExtensionV1)(nil)).Elem(),
+	"ClaimableBalanceEntryExtensionV1Ext":   reflect.TypeOf((*ClaimableBalanceEntryExtensionV1Ext)(nil)).Elem(),
+	"ClaimableBalanceFlags":                 reflect.TypeOf((*ClaimableBalanceFlags)(nil)).Elem(),
+	"ClaimableBalanceId":                    reflect.TypeOf((*ClaimableBalanceId)(nil)).Elem(),
+	"ClaimableBalanceIdType":                reflect.TypeOf((*ClaimableBalanceIdType)(nil)).Elem(),
+	"Claimant":                              reflect.TypeOf((*Claimant)(nil)).Elem(),
+	"ClaimantType":                          reflect.TypeOf((*ClaimantType)(nil)).Elem(),
+	"ClaimantV0":                            reflect.TypeOf((*ClaimantV0)(nil)).Elem(),
+	"ClawbackClaimableBalanceOp":            reflect.TypeOf((*ClawbackClaimableBalanceOp)(nil)).Elem(),
+	"ClawbackClaimableBalanceResult":        reflect.TypeOf((*ClawbackClaimableBalanceResult)(nil)).Elem(),
+	"ClawbackClaimableBalanceResultCode":    reflect.TypeOf((*ClawbackClaimableBalanceResultCode)(nil)).Elem(),
+	"ClawbackOp":                            reflect.TypeOf((*ClawbackOp)(nil)).Elem(),
+	"ClawbackResult":                        reflect.TypeOf((*ClawbackResult)(nil)).Elem(),
+	"ClawbackResultCode":                    reflect.TypeOf((*ClawbackResultCode)(nil)).Elem(),
+	"CreateAccountOp":                       reflect.TypeOf((*CreateAccountOp)(nil)).Elem(),
+	"CreateAccountResult":                   reflect.TypeOf((*CreateAccountResult)(nil)).Elem(),
+	"CreateAccountResultCode":               reflect.TypeOf((*CreateAccountResultCode)(nil)).Elem(),
+	"CreateClaimableBalanceOp":              reflect.TypeOf((*CreateClaimableBalanceOp)(nil)).Elem(),
+	"CreateClaimableBalanceResult":          reflect.TypeOf((*CreateClaimableBalanceResult)(nil)).Elem(),
+	"CreateClaimableBalanceResultCode":      reflect.TypeOf((*CreateClaimableBalanceResultCode)(nil)).Elem(),
+	"CreatePassiveSellOfferOp":              reflect.TypeOf((*CreatePassiveSellOfferOp)(nil)).Elem(),
+	"CryptoKeyType":                         reflect.TypeOf((*CryptoKeyType)(nil)).Elem(),
+	"Curve25519Public":                      reflect.TypeOf((*Curve25519Public)(nil)).Elem(),
+	"Curve25519Secret":                      reflect.TypeOf((*Curve25519Secret)(nil)).Elem(),
+	"DataEntry":                             reflect.TypeOf((*DataEntry)(nil)).Elem(),
+	"DataEntryExt":                          reflect.TypeOf((*DataEntryExt)(nil)).Elem(),
+	"DataValue":                             reflect.TypeOf((*DataValue)(nil)).Elem(),
+	"DecoratedSignature":                    reflect.TypeOf((*DecoratedSignature)(nil)).Elem(),
+	"DontHave":                              reflect.TypeOf((*DontHave)(nil)).Elem(),
+	"EncryptedBody":                         reflect.TypeOf((*EncryptedBody)(nil)).Elem(),
+	"EndSponsoringFutureReservesResult":     reflect.TypeOf((*EndSponsoringFutureReservesResult)(nil)).Elem(),
+	"EndSponsoringFutureReservesResultCode": reflect.TypeOf((*EndSponsoringFutureReservesResultCode)(nil)).Elem(),
+	"EnvelopeType":                          reflect.TypeOf((*EnvelopeType)(nil)).Elem(),
+	"Error":                                 reflect.TypeOf((*Error)(nil)).Elem(),
+	"ErrorCode":                             reflect.TypeOf((*ErrorCode)(nil)).Elem(),
+	"FeeBumpTransaction":                    reflect.TypeOf((*FeeBumpTransaction)(nil)).Elem(),
+	"FeeBumpTransactionEnvelope":            reflect.TypeOf((*FeeBumpTransactionEnvelope)(nil)).Elem(),
+	"FeeBumpTransactionExt":                 reflect.TypeOf((*FeeBumpTransactionExt)(nil)).Elem(),
+	"FeeBumpTransactionInnerTx":             reflect.TypeOf((*FeeBumpTransactionInnerTx)(nil)).Elem(),
+	"Hash":                                  reflect.TypeOf((*Hash)(nil)).Elem(),
+	"Hello":                                 reflect.TypeOf((*Hello)(nil)).Elem(),
+	"HmacSha256Key":                         reflect.TypeOf((*HmacSha256Key)(nil)).Elem(),
+	"HmacSha256Mac":                         reflect.TypeOf((*HmacSha256Mac)(nil)).Elem(),
+	"InflationPayout":                       reflect.TypeOf((*InflationPayout)(nil)).Elem(),
+	"InflationResult":                       reflect.TypeOf((*InflationResult)(nil)).Elem(),
+	"InflationResultCode":                   reflect.TypeOf((*InflationResultCode)(nil)).Elem(),
+	"InnerTransactionResult":                reflect.TypeOf((*InnerTransactionResult)(nil)).Elem(),
+	"InnerTransactionResultExt":             reflect.TypeOf((*InnerTransactionResultExt)(nil)).Elem(),
+	"InnerTransactionResultPair":            reflect.TypeOf((*InnerTransactionResultPair)(nil)).Elem(),
+	"InnerTransactionResultResult":          reflect.TypeOf((*InnerTransactionResultResult)(nil)).Elem(),
+	"Int32":                                 reflect.TypeOf((*Int32)(nil)).Elem(),
+	"Int64":                                 reflect.TypeOf((*Int64)(nil)).Elem(),
+	"IpAddrType":                            reflect.TypeOf((*IpAddrType)(nil)).Elem(),
+	"LedgerCloseMeta":                       reflect.TypeOf((*LedgerCloseMeta)(nil)).Elem(),
+	"LedgerCloseMetaV0":                     reflect.TypeOf((*LedgerCloseMetaV0)(nil)).Elem(),
+	"LedgerCloseValueSignature":             reflect.TypeOf((*LedgerCloseValueSignature)(nil)).Elem(),
+	"LedgerEntry":                           reflect.TypeOf((*LedgerEntry)(nil)).Elem(),
+	"LedgerEntryChange":                     reflect.TypeOf((*LedgerEntryChange)(nil)).Elem(),
+	"LedgerEntryChangeType":                 reflect.TypeOf((*LedgerEntryChangeType)(nil)).Elem(),
+	"LedgerEntryChanges":                    reflect.TypeOf((*LedgerEntryChanges)(nil)).Elem(),
+	"LedgerEntryData":                       reflect.TypeOf((*LedgerEntryData)(nil)).Elem(),
+	"LedgerEntryExt":                        reflect.TypeOf((*LedgerEntryExt)(nil)).Elem(),
+	"LedgerEntryExtensionV1":                reflect.TypeOf((*LedgerEntryExtensionV1)(nil)).Elem(),
+	"LedgerEntryExtensionV1Ext":             reflect.TypeOf((*LedgerEntryExtensionV1Ext)(nil)).Elem(),
+	"LedgerEntryType":                       reflect.TypeOf((*LedgerEntryType)(nil)).Elem(),
+	"LedgerHeader":                          reflect.TypeOf((*LedgerHeader)(nil)).Elem(),
+	"LedgerHeaderExt":                       reflect.TypeOf((*LedgerHeaderExt)(nil)).Elem(),
+	"LedgerHeaderHistoryEntry":              reflect.TypeOf((*LedgerHeaderHistoryEntry)(nil)).Elem(),
+	"LedgerHeaderHistoryEntryExt":           reflect.TypeOf((*LedgerHeaderHistoryEntryExt)(nil)).Elem(),
+	"LedgerKey":                             reflect.TypeOf((*LedgerKey)(nil)).Elem(),
+	"LedgerKeyAccount":                      reflect.TypeOf((*LedgerKeyAccount)(nil)).Elem(),
+	"LedgerKeyClaimableBalance":             reflect.TypeOf((*LedgerKeyClaimableBalance)(nil)).Elem(),
+	"LedgerKeyData":                         reflect.TypeOf((*LedgerKeyData)(nil)).Elem(),
+	"LedgerKeyOffer":                        reflect.TypeOf((*LedgerKeyOffer)(nil)).Elem(),
+	"LedgerKeyTrustLine":                    reflect.TypeOf((*LedgerKeyTrustLine)(nil)).Elem(),
+	"LedgerScpMessages":                     reflect.TypeOf((*LedgerScpMessages)(nil)).Elem(),
+	"LedgerUpgrade":                         reflect.TypeOf((*LedgerUpgrade)(nil)).Elem(),
+	"LedgerUpgradeType":                     reflect.TypeOf((*LedgerUpgradeType)(nil)).Elem(),
+	"Liabilities":                           reflect.TypeOf((*Liabilities)(nil)).Elem(),
+	"ManageBuyOfferOp":                      reflect.TypeOf((*ManageBuyOfferOp)(nil)).Elem(),
+	"ManageBuyOfferResult":                  reflect.TypeOf((*ManageBuyOfferResult)(nil)).Elem(),
+	"ManageBuyOfferResultCode":              reflect.TypeOf((*ManageBuyOfferResultCode)(nil)).Elem(),
+	"ManageDataOp":                          reflect.TypeOf((*ManageDataOp)(nil)).Elem(),
+	"ManageDataResult":                      reflect.TypeOf((*ManageDataResult)(nil)).Elem(),
+	"ManageDataResultCode":                  reflect.TypeOf((*ManageDataResultCode)(nil)).Elem(),
+	"ManageOfferEffect":                     reflect.TypeOf((*ManageOfferEffect)(nil)).Elem(),
+	"ManageOfferSuccessResult":              reflect.TypeOf((*ManageOfferSuccessResult)(nil)).Elem(),
+	"ManageOfferSuccessResultOffer":         reflect.TypeOf((*ManageOfferSuccessResultOffer)(nil)).Elem(),
+	"ManageSellOfferOp":                     reflect.TypeOf((*ManageSellOfferOp)(nil)).Elem(),
+	"ManageSellOfferResult":                 reflect.TypeOf((*ManageSellOfferResult)(nil)).Elem(),
+	"ManageSellOfferResultCode":             reflect.TypeOf((*ManageSellOfferResultCode)(nil)).Elem(),
+	"Memo":                                  reflect.TypeOf((*Memo)(nil)).Elem(),
+	"MemoType":                              reflect.TypeOf((*MemoType)(nil)).Elem(),
+	"MessageType":                           reflect.TypeOf((*MessageType)(nil)).Elem(),
+	"MuxedAccount":                          reflect.TypeOf((*MuxedAccount)(nil)).Elem(),
+	"MuxedAccountMed25519":                  reflect.TypeOf((*MuxedAccountMed25519)(nil)).Elem(),
+	"NodeId":                                reflect.TypeOf((*NodeId)(nil)).Elem(),
+	"OfferEntry":                            reflect.TypeOf((*OfferEntry)(nil)).Elem(),
+	"OfferEntryExt":                         reflect.TypeOf((*OfferEntryExt)(nil)).Elem(),
+	"OfferEntryFlags":                       reflect.TypeOf((*OfferEntryFlags)(nil)).Elem(),
+	"Operation":                             reflect.TypeOf((*Operation)(nil)).Elem(),
+	"OperationBody":                         reflect.TypeOf((*OperationBody)(nil)).Elem(),
+	"OperationId":                           reflect.TypeOf((*OperationId)(nil)).Elem(),
+	"OperationIdId":                         reflect.TypeOf((*OperationIdId)(nil)).Elem(),
+	"OperationMeta":                         reflect.TypeOf((*OperationMeta)(nil)).Elem(),
+	"OperationResult":                       reflect.TypeOf((*OperationResult)(nil)).Elem(),
+	"OperationResultCode":                   reflect.TypeOf((*OperationResultCode)(nil)).Elem(),
+	"OperationResultTr":                     reflect.TypeOf((*OperationResultTr)(nil)).Elem(),
+	"OperationType":                         reflect.TypeOf((*OperationType)(nil)).Elem(),
+	"PathPaymentStrictReceiveOp":            reflect.TypeOf((*PathPaymentStrictReceiveOp)(nil)).Elem(),
+	"PathPaymentStrictReceiveResult":        reflect.TypeOf((*PathPaymentStrictReceiveResult)(nil)).Elem(),
+	"PathPaymentStrictReceiveResultCode":    reflect.TypeOf((*PathPaymentStrictReceiveResultCode)(nil)).Elem(),
+	"PathPaymentStrictReceiveResultSuccess": reflect.TypeOf((*PathPaymentStrictReceiveResultSuccess)(nil)).Elem(),
+	"PathPaymentStrictSendOp":               reflect.TypeOf((*PathPaymentStrictSendOp)(nil)).Elem(),
+	"PathPaymentStrictSendResult":           reflect.TypeOf((*PathPaymentStrictSendResult)(nil)).Elem(),
+	"PathPaymentStrictSendResultCode":       reflect.TypeOf((*PathPaymentStrictSendResultCode)(nil)).Elem(),
+	"PathPaymentStrictSendResultSuccess":    reflect.TypeOf((*PathPaymentStrictSendResultSuccess)(nil)).Elem(),
+	"PaymentOp":                             reflect.TypeOf((*PaymentOp)(nil)).Elem(),
+	"PaymentResult":                         reflect.TypeOf((*PaymentResult)(nil)).Elem(),
+	"PaymentResultCode":                     reflect.TypeOf((*PaymentResultCode)(nil)).Elem(),
+	"PeerAddress":                           reflect.TypeOf((*PeerAddress)(nil)).Elem(),
+	"PeerAddressIp":                         reflect.TypeOf((*PeerAddressIp)(nil)).Elem(),
+	"PeerStatList":                          reflect.TypeOf((*PeerStatList)(nil)).Elem(),
+	"PeerStats":                             reflect.TypeOf((*PeerStats)(nil)).Elem(),
+	"Price":                                 reflect.TypeOf((*Price)(nil)).Elem(),
+	"PublicKey":                             reflect.TypeOf((*PublicKey)(nil)).Elem(),
+	"PublicKeyType":                         reflect.TypeOf((*PublicKeyType)(nil)).Elem(),
+	"RevokeSponsorshipOp":                   reflect.TypeOf((*RevokeSponsorshipOp)(nil)).Elem(),
+	"RevokeSponsorshipOpSigner":             reflect.TypeOf((*RevokeSponsorshipOpSigner)(nil)).Elem(),
+	"RevokeSponsorshipResult":               reflect.TypeOf((*RevokeSponsorshipResult)(nil)).Elem(),
+	"RevokeSponsorshipResultCode":           reflect.TypeOf((*RevokeSponsorshipResultCode)(nil)).Elem(),
+	"RevokeSponsorshipType":                 reflect.TypeOf((*RevokeSponsorshipType)(nil)).Elem(),
+	"ScpBallot":                             reflect.TypeOf((*ScpBallot)(nil)).Elem(),
+	"ScpEnvelope":                           reflect.TypeOf((*ScpEnvelope)(nil)).Elem(),
+	"ScpHistoryEntry":                       reflect.TypeOf((*ScpHistoryEntry)(nil)).Elem(),
+	"ScpHistoryEntryV0":                     reflect.TypeOf((*ScpHistoryEntryV0)(nil)).Elem(),
+	"ScpNomination":                         reflect.TypeOf((*ScpNomination)(nil)).Elem(),
+	"ScpQuorumSet":                          reflect.TypeOf((*ScpQuorumSet)(nil)).Elem(),
+	"ScpStatement":                          reflect.TypeOf((*ScpStatement)(nil)).Elem(),
+	"ScpStatementConfirm":                   reflect.TypeOf((*ScpStatementConfirm)(nil)).Elem(),
+	"ScpStatementExternalize":               reflect.TypeOf((*ScpStatementExternalize)(nil)).Elem(),
+	"ScpStatementPledges":                   reflect.TypeOf((*ScpStatementPledges)(nil)).Elem(),
+	"ScpStatementPrepare":                   reflect.TypeOf((*ScpStatementPrepare)(nil)).Elem(),
+	"ScpStatementType":                      reflect.TypeOf((*ScpStatementType)(nil)).Elem(),
+	"SequenceNumber":                        reflect.TypeOf((*SequenceNumber)(nil)).Elem(),
+	"SetOptionsOp":                          reflect.TypeOf((*SetOptionsOp)(nil)).Elem(),
+	"SetOptionsResult":                      reflect.TypeOf((*SetOptionsResult)(nil)).Elem(),
+	"SetOptionsResultCode":                  reflect.TypeOf((*SetOptionsResultCode)(nil)).Elem(),
+	"SetTrustLineFlagsOp":                   reflect.TypeOf((*SetTrustLineFlagsOp)(nil)).Elem(),
+	"SetTrustLineFlagsResult":               reflect.TypeOf((*SetTrustLineFlagsResult)(nil)).Elem(),
+	"SetTrustLineFlagsResultCode":           reflect.TypeOf((*SetTrustLineFlagsResultCode)(nil)).Elem(),
+	"Signature":                             reflect.TypeOf((*Signature)(nil)).Elem(),
+	"SignatureHint":                         reflect.TypeOf((*SignatureHint)(nil)).Elem(),
+	"SignedSurveyRequestMessage":            reflect.TypeOf((*SignedSurveyRequestMessage)(nil)).Elem(),
+	"SignedSurveyResponseMessage":           reflect.TypeOf((*SignedSurveyResponseMessage)(nil)).Elem(),
+	"Signer":                                reflect.TypeOf((*Signer)(nil)).Elem(),
+	"SignerKey":                             reflect.TypeOf((*SignerKey)(nil)).Elem(),
+	"SignerKeyType":                         reflect.TypeOf((*SignerKeyType)(nil)).Elem(),
+	"SimplePaymentResult":                   reflect.TypeOf((*SimplePaymentResult)(nil)).Elem(),
+	"StellarMessage":                        reflect.TypeOf((*StellarMessage)(nil)).Elem(),
+	"StellarValue":                          reflect.TypeOf((*StellarValue)(nil)).Elem(),
+	"StellarValueExt":                       reflect.TypeOf((*StellarValueExt)(nil)).Elem(),
+	"StellarValueType":                      reflect.TypeOf((*StellarValueType)(nil)).Elem(),
+	"String32":                              reflect.TypeOf((*String32)(nil)).Elem(),
+	"String64":                              reflect.TypeOf((*String64)(nil)).Elem(),
+	"SurveyMessageCommandType":              reflect.TypeOf((*SurveyMessageCommandType)(nil)).Elem(),
+	"SurveyRequestMessage":                  reflect.TypeOf((*SurveyRequestMessage)(nil)).Elem(),
+	"SurveyResponseBody":                    reflect.TypeOf((*SurveyResponseBody)(nil)).Elem(),
+	"SurveyResponseMessage":                 reflect.TypeOf((*SurveyResponseMessage)(nil)).Elem(),
+	"ThresholdIndexes":                      reflect.TypeOf((*ThresholdIndexes)(nil)).Elem(),
+	"Thresholds":                            reflect.TypeOf((*Thresholds)(nil)).Elem(),
+	"TimeBounds":                            reflect.TypeOf((*TimeBounds)(nil)).Elem(),
+	"TimePoint":                             reflect.TypeOf((*TimePoint)(nil)).Elem(),
+	"TopologyResponseBody":                  reflect.TypeOf((*TopologyResponseBody)(nil)).Elem(),
+	"Transaction":                           reflect.TypeOf((*Transaction)(nil)).Elem(),
+	"TransactionEnvelope":                   reflect.TypeOf((*TransactionEnvelope)(nil)).Elem(),
+	"TransactionExt":                        reflect.TypeOf((*TransactionExt)(nil)).Elem(),
+	"TransactionHistoryEntry":               reflect.TypeOf((*TransactionHistoryEntry)(nil)).Elem(),
+	"TransactionHistoryEntryExt":            reflect.TypeOf((*TransactionHistoryEntryExt)(nil)).Elem(),
+	"TransactionHistoryResultEntry":         reflect.TypeOf((*TransactionHistoryResultEntry)(nil)).Elem(),
+	"TransactionHistoryResultEntryExt":      reflect.TypeOf((*TransactionHistoryResultEntryExt)(nil)).Elem(),
+	"TransactionMeta":                       reflect.TypeOf((*TransactionMeta)(nil)).Elem(),
+	"TransactionMetaV1":                     reflect.TypeOf((*TransactionMetaV1)(nil)).Elem(),
+	"TransactionMetaV2":                     reflect.TypeOf((*TransactionMetaV2)(nil)).Elem(),
+	"TransactionResult":                     reflect.TypeOf((*TransactionResult)(nil)).Elem(),
+	"TransactionResultCode":                 reflect.TypeOf((*TransactionResultCode)(nil)).Elem(),
+	"TransactionResultExt":                  reflect.TypeOf((*TransactionResultExt)(nil)).Elem(),
+	"TransactionResultMeta":                 reflect.TypeOf((*TransactionResultMeta)(nil)).Elem(),
+	"TransactionResultPair":                 reflect.TypeOf((*TransactionResultPair)(nil)).Elem(),
+	"TransactionResultResult":               reflect.TypeOf((*TransactionResultResult)(nil)).Elem(),
+	"TransactionResultSet":                  reflect.TypeOf((*TransactionResultSet)(nil)).Elem(),
+	"TransactionSet":                        reflect.TypeOf((*TransactionSet)(nil)).Elem(),
+	"TransactionSignaturePayload":           reflect.TypeOf((*TransactionSignaturePayload)(nil)).Elem(),
+	"TransactionSignaturePayloadTaggedTransaction": reflect.TypeOf((*TransactionSignaturePayloadTaggedTransaction)(nil)).Elem(),
+	"TransactionV0":         reflect.TypeOf((*TransactionV0)(nil)).Elem(),
+	"TransactionV0Envelope": reflect.TypeOf((*TransactionV0Envelope)(nil)).Elem(),
+	"TransactionV0Ext":      reflect.TypeOf((*TransactionV0Ext)(nil)).Elem(),
+	"TransactionV1Envelope": reflect.TypeOf((*TransactionV1Envelope)(nil)).Elem(),
+	"TrustLineEntry":        reflect.TypeOf((*TrustLineEntry)(nil)).Elem(),
+	"TrustLineEntryExt":     reflect.TypeOf((*TrustLineEntryExt)(nil)).Elem(),
+	"TrustLineEntryV1":      reflect.TypeOf((*TrustLineEntryV1)(nil)).Elem(),
+	"TrustLineEntryV1Ext":   reflect.TypeOf((*TrustLineEntryV1Ext)(nil)).Elem(),
+	"TrustLineFlags":        reflect.TypeOf((*TrustLineFlags)(nil)).Elem(),
+	"Uint256":               reflect.TypeOf((*Uint256)(nil)).Elem(),
+	"Uint32":                reflect.TypeOf((*Uint32)(nil)).Elem(),
+	"Uint64":                reflect.TypeOf((*Uint64)(nil)).Elem(),
+	"UpgradeEntryMeta":      reflect.TypeOf((*UpgradeEntryMeta)(nil)).Elem(),
+	"UpgradeType":           reflect.TypeOf((*UpgradeType)(nil)).Elem(),
+	"Value":                 reflect.TypeOf((*Value)(nil)).Elem(),
+}
+
 // Value is an XDR Typedef defines as:
 //
 //   typedef opaque Value<>;
 //
 type Value []byte
 
+// EncodeTo encodes this value using the Encoder.
+func (s Value) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Value) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, 0)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Value) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Value) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, 0)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Value) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -70,6 +493,66 @@ type ScpBallot struct {
 	Value   Value
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpBallot) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Counter.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Value.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpBallot) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Counter.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Value.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpBallot) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("counter")
+	if err = s.Counter.encodeJSON(enc); err != nil {
+		return jsonFieldError("counter", err)
+	}
+	enc.key("value")
+	if err = s.Value.encodeJSON(enc); err != nil {
+		return jsonFieldError("value", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpBallot) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "counter", "value")
+	if err != nil {
+		return err
+	}
+	if err = s.Counter.decodeJSON(fields["counter"]); err != nil {
+		return jsonFieldError("counter", err)
+	}
+	if err = s.Value.decodeJSON(fields["value"]); err != nil {
+		return jsonFieldError("value", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpBallot) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -127,6 +610,55 @@ func (e ScpStatementType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ScpStatementType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ScpStatementType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ScpStatementType(v)
+	return n, nil
+}
+
+var scpStatementTypeJSONNames = map[int32]string{
+	0: "scp_st_prepare",
+	1: "scp_st_confirm",
+	2: "scp_st_externalize",
+	3: "scp_st_nominate",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ScpStatementType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := scpStatementTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ScpStatementType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, scpStatementTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ScpStatementType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpStatementType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -160,6 +692,151 @@ type ScpNomination struct {
 	Accepted      []Value
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpNomination) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.QuorumSetHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Votes))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Votes); i++ {
+		if err = s.Votes[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Accepted))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Accepted); i++ {
+		if err = s.Accepted[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpNomination) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.QuorumSetHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Votes = nil
+	if l > 0 {
+		s.Votes = make([]Value, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Votes = append(s.Votes, nil)
+			nTmp, err = s.Votes[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Accepted = nil
+	if l > 0 {
+		s.Accepted = make([]Value, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Accepted = append(s.Accepted, nil)
+			nTmp, err = s.Accepted[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpNomination) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("quorum_set_hash")
+	if err = s.QuorumSetHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	enc.key("votes")
+	enc.beginArray()
+	for i := 0; i < len(s.Votes); i++ {
+		enc.element()
+		if err = s.Votes[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("votes", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("accepted")
+	enc.beginArray()
+	for i := 0; i < len(s.Accepted); i++ {
+		enc.element()
+		if err = s.Accepted[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("accepted", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpNomination) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "quorum_set_hash", "votes", "accepted")
+	if err != nil {
+		return err
+	}
+	if err = s.QuorumSetHash.decodeJSON(fields["quorum_set_hash"]); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	if elems, err = decodeJSONArray(fields["votes"], 0); err != nil {
+		return jsonFieldError("votes", err)
+	}
+	s.Votes = nil
+	if len(elems) > 0 {
+		s.Votes = make([]Value, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Votes[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("votes", jsonIndexError(i, err))
+		}
+	}
+	if elems, err = decodeJSONArray(fields["accepted"], 0); err != nil {
+		return jsonFieldError("accepted", err)
+	}
+	s.Accepted = nil
+	if len(elems) > 0 {
+		s.Accepted = make([]Value, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Accepted[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("accepted", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpNomination) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -199,6 +876,171 @@ type ScpStatementPrepare struct {
 	NH            Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpStatementPrepare) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.QuorumSetHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ballot.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeBool(s.Prepared != nil); err != nil {
+		return err
+	}
+	if s.Prepared != nil {
+		if err = s.Prepared.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.PreparedPrime != nil); err != nil {
+		return err
+	}
+	if s.PreparedPrime != nil {
+		if err = s.PreparedPrime.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.NC.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NH.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpStatementPrepare) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.QuorumSetHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ballot.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.Prepared = nil
+	if present {
+		s.Prepared = new(ScpBallot)
+		nTmp, err = s.Prepared.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.PreparedPrime = nil
+	if present {
+		s.PreparedPrime = new(ScpBallot)
+		nTmp, err = s.PreparedPrime.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.NC.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NH.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpStatementPrepare) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("quorum_set_hash")
+	if err = s.QuorumSetHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	enc.key("ballot")
+	if err = s.Ballot.encodeJSON(enc); err != nil {
+		return jsonFieldError("ballot", err)
+	}
+	enc.key("prepared")
+	if s.Prepared == nil {
+		enc.null()
+	} else {
+		if err = s.Prepared.encodeJSON(enc); err != nil {
+			return jsonFieldError("prepared", err)
+		}
+	}
+	enc.key("prepared_prime")
+	if s.PreparedPrime == nil {
+		enc.null()
+	} else {
+		if err = s.PreparedPrime.encodeJSON(enc); err != nil {
+			return jsonFieldError("prepared_prime", err)
+		}
+	}
+	enc.key("nc")
+	if err = s.NC.encodeJSON(enc); err != nil {
+		return jsonFieldError("nc", err)
+	}
+	enc.key("nh")
+	if err = s.NH.encodeJSON(enc); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpStatementPrepare) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "quorum_set_hash", "ballot", "prepared", "prepared_prime", "nc", "nh")
+	if err != nil {
+		return err
+	}
+	if err = s.QuorumSetHash.decodeJSON(fields["quorum_set_hash"]); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	if err = s.Ballot.decodeJSON(fields["ballot"]); err != nil {
+		return jsonFieldError("ballot", err)
+	}
+	s.Prepared = nil
+	if !isJSONNull(fields["prepared"]) {
+		s.Prepared = new(ScpBallot)
+		if err = s.Prepared.decodeJSON(fields["prepared"]); err != nil {
+			return jsonFieldError("prepared", err)
+		}
+	}
+	s.PreparedPrime = nil
+	if !isJSONNull(fields["prepared_prime"]) {
+		s.PreparedPrime = new(ScpBallot)
+		if err = s.PreparedPrime.decodeJSON(fields["prepared_prime"]); err != nil {
+			return jsonFieldError("prepared_prime", err)
+		}
+	}
+	if err = s.NC.decodeJSON(fields["nc"]); err != nil {
+		return jsonFieldError("nc", err)
+	}
+	if err = s.NH.decodeJSON(fields["nh"]); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpStatementPrepare) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -236,6 +1078,111 @@ type ScpStatementConfirm struct {
 	QuorumSetHash Hash
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpStatementConfirm) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Ballot.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NPrepared.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NCommit.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NH.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.QuorumSetHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpStatementConfirm) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Ballot.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NPrepared.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NCommit.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NH.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.QuorumSetHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpStatementConfirm) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ballot")
+	if err = s.Ballot.encodeJSON(enc); err != nil {
+		return jsonFieldError("ballot", err)
+	}
+	enc.key("n_prepared")
+	if err = s.NPrepared.encodeJSON(enc); err != nil {
+		return jsonFieldError("n_prepared", err)
+	}
+	enc.key("n_commit")
+	if err = s.NCommit.encodeJSON(enc); err != nil {
+		return jsonFieldError("n_commit", err)
+	}
+	enc.key("nh")
+	if err = s.NH.encodeJSON(enc); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	enc.key("quorum_set_hash")
+	if err = s.QuorumSetHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpStatementConfirm) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ballot", "n_prepared", "n_commit", "nh", "quorum_set_hash")
+	if err != nil {
+		return err
+	}
+	if err = s.Ballot.decodeJSON(fields["ballot"]); err != nil {
+		return jsonFieldError("ballot", err)
+	}
+	if err = s.NPrepared.decodeJSON(fields["n_prepared"]); err != nil {
+		return jsonFieldError("n_prepared", err)
+	}
+	if err = s.NCommit.decodeJSON(fields["n_commit"]); err != nil {
+		return jsonFieldError("n_commit", err)
+	}
+	if err = s.NH.decodeJSON(fields["nh"]); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	if err = s.QuorumSetHash.decodeJSON(fields["quorum_set_hash"]); err != nil {
+		return jsonFieldError("quorum_set_hash", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpStatementConfirm) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -269,6 +1216,81 @@ type ScpStatementExternalize struct {
 	CommitQuorumSetHash Hash
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpStatementExternalize) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Commit.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NH.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.CommitQuorumSetHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpStatementExternalize) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Commit.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NH.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.CommitQuorumSetHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpStatementExternalize) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("commit")
+	if err = s.Commit.encodeJSON(enc); err != nil {
+		return jsonFieldError("commit", err)
+	}
+	enc.key("nh")
+	if err = s.NH.encodeJSON(enc); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	enc.key("commit_quorum_set_hash")
+	if err = s.CommitQuorumSetHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("commit_quorum_set_hash", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpStatementExternalize) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "commit", "nh", "commit_quorum_set_hash")
+	if err != nil {
+		return err
+	}
+	if err = s.Commit.decodeJSON(fields["commit"]); err != nil {
+		return jsonFieldError("commit", err)
+	}
+	if err = s.NH.decodeJSON(fields["nh"]); err != nil {
+		return jsonFieldError("nh", err)
+	}
+	if err = s.CommitQuorumSetHash.decodeJSON(fields["commit_quorum_set_hash"]); err != nil {
+		return jsonFieldError("commit_quorum_set_hash", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpStatementExternalize) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -487,6 +1509,200 @@ func (u ScpStatementPledges) GetNominate() (result ScpNomination, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ScpStatementPledges) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ScpStatementType(u.Type) {
+	case ScpStatementTypeScpStPrepare:
+		if u.Prepare == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Prepare.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case ScpStatementTypeScpStConfirm:
+		if u.Confirm == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Confirm.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case ScpStatementTypeScpStExternalize:
+		if u.Externalize == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Externalize.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case ScpStatementTypeScpStNominate:
+		if u.Nominate == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Nominate.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ScpStatementPledges) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ScpStatementPledges{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = ScpStatementType(sw)
+	switch ScpStatementType(u.Type) {
+	case ScpStatementTypeScpStPrepare:
+		u.Prepare = new(ScpStatementPrepare)
+		nTmp, err = u.Prepare.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case ScpStatementTypeScpStConfirm:
+		u.Confirm = new(ScpStatementConfirm)
+		nTmp, err = u.Confirm.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case ScpStatementTypeScpStExternalize:
+		u.Externalize = new(ScpStatementExternalize)
+		nTmp, err = u.Externalize.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case ScpStatementTypeScpStNominate:
+		u.Nominate = new(ScpNomination)
+		nTmp, err = u.Nominate.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ScpStatementPledges) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ScpStatementType(u.Type) {
+	case ScpStatementTypeScpStPrepare:
+		if u.Prepare == nil {
+			return jsonFieldError("prepare", errEncodeNilUnionValue())
+		}
+		enc.key("prepare")
+		if err = u.Prepare.encodeJSON(enc); err != nil {
+			return jsonFieldError("prepare", err)
+		}
+	case ScpStatementTypeScpStConfirm:
+		if u.Confirm == nil {
+			return jsonFieldError("confirm", errEncodeNilUnionValue())
+		}
+		enc.key("confirm")
+		if err = u.Confirm.encodeJSON(enc); err != nil {
+			return jsonFieldError("confirm", err)
+		}
+	case ScpStatementTypeScpStExternalize:
+		if u.Externalize == nil {
+			return jsonFieldError("externalize", errEncodeNilUnionValue())
+		}
+		enc.key("externalize")
+		if err = u.Externalize.encodeJSON(enc); err != nil {
+			return jsonFieldError("externalize", err)
+		}
+	case ScpStatementTypeScpStNominate:
+		if u.Nominate == nil {
+			return jsonFieldError("nominate", errEncodeNilUnionValue())
+		}
+		enc.key("nominate")
+		if err = u.Nominate.encodeJSON(enc); err != nil {
+			return jsonFieldError("nominate", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ScpStatementPledges) decodeJSON(data []byte) error {
+	*u = ScpStatementPledges{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ScpStatementType(u.Type) {
+	case ScpStatementTypeScpStPrepare:
+		if err = checkJSONKeys(fields, "type", "prepare"); err != nil {
+			return err
+		}
+		u.Prepare = new(ScpStatementPrepare)
+		if err = u.Prepare.decodeJSON(fields["prepare"]); err != nil {
+			return jsonFieldError("prepare", err)
+		}
+		return nil
+	case ScpStatementTypeScpStConfirm:
+		if err = checkJSONKeys(fields, "type", "confirm"); err != nil {
+			return err
+		}
+		u.Confirm = new(ScpStatementConfirm)
+		if err = u.Confirm.decodeJSON(fields["confirm"]); err != nil {
+			return jsonFieldError("confirm", err)
+		}
+		return nil
+	case ScpStatementTypeScpStExternalize:
+		if err = checkJSONKeys(fields, "type", "externalize"); err != nil {
+			return err
+		}
+		u.Externalize = new(ScpStatementExternalize)
+		if err = u.Externalize.decodeJSON(fields["externalize"]); err != nil {
+			return jsonFieldError("externalize", err)
+		}
+		return nil
+	case ScpStatementTypeScpStNominate:
+		if err = checkJSONKeys(fields, "type", "nominate"); err != nil {
+			return err
+		}
+		u.Nominate = new(ScpNomination)
+		if err = u.Nominate.decodeJSON(fields["nominate"]); err != nil {
+			return jsonFieldError("nominate", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpStatementPledges) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -552,15 +1768,90 @@ type ScpStatement struct {
 	Pledges   ScpStatementPledges
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s ScpStatement) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (s ScpStatement) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.NodeId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SlotIndex.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Pledges.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *ScpStatement) UnmarshalBinary(inp []byte) error {
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpStatement) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.NodeId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SlotIndex.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Pledges.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpStatement) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("node_id")
+	if err = s.NodeId.encodeJSON(enc); err != nil {
+		return jsonFieldError("node_id", err)
+	}
+	enc.key("slot_index")
+	if err = s.SlotIndex.encodeJSON(enc); err != nil {
+		return jsonFieldError("slot_index", err)
+	}
+	enc.key("pledges")
+	if err = s.Pledges.encodeJSON(enc); err != nil {
+		return jsonFieldError("pledges", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpStatement) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "node_id", "slot_index", "pledges")
+	if err != nil {
+		return err
+	}
+	if err = s.NodeId.decodeJSON(fields["node_id"]); err != nil {
+		return jsonFieldError("node_id", err)
+	}
+	if err = s.SlotIndex.decodeJSON(fields["slot_index"]); err != nil {
+		return jsonFieldError("slot_index", err)
+	}
+	if err = s.Pledges.decodeJSON(fields["pledges"]); err != nil {
+		return jsonFieldError("pledges", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s ScpStatement) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *ScpStatement) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
@@ -583,6 +1874,66 @@ type ScpEnvelope struct {
 	Signature Signature
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpEnvelope) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Statement.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Signature.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpEnvelope) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Statement.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Signature.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpEnvelope) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("statement")
+	if err = s.Statement.encodeJSON(enc); err != nil {
+		return jsonFieldError("statement", err)
+	}
+	enc.key("signature")
+	if err = s.Signature.encodeJSON(enc); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpEnvelope) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "statement", "signature")
+	if err != nil {
+		return err
+	}
+	if err = s.Statement.decodeJSON(fields["statement"]); err != nil {
+		return jsonFieldError("statement", err)
+	}
+	if err = s.Signature.decodeJSON(fields["signature"]); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpEnvelope) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -616,6 +1967,151 @@ type ScpQuorumSet struct {
 	InnerSets  []ScpQuorumSet
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpQuorumSet) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Threshold.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Validators))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Validators); i++ {
+		if err = s.Validators[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.InnerSets))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.InnerSets); i++ {
+		if err = s.InnerSets[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpQuorumSet) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Threshold.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Validators = nil
+	if l > 0 {
+		s.Validators = make([]PublicKey, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Validators = append(s.Validators, PublicKey{})
+			nTmp, err = s.Validators[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.InnerSets = nil
+	if l > 0 {
+		s.InnerSets = make([]ScpQuorumSet, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.InnerSets = append(s.InnerSets, ScpQuorumSet{})
+			nTmp, err = s.InnerSets[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpQuorumSet) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("threshold")
+	if err = s.Threshold.encodeJSON(enc); err != nil {
+		return jsonFieldError("threshold", err)
+	}
+	enc.key("validators")
+	enc.beginArray()
+	for i := 0; i < len(s.Validators); i++ {
+		enc.element()
+		if err = s.Validators[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("validators", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("inner_sets")
+	enc.beginArray()
+	for i := 0; i < len(s.InnerSets); i++ {
+		enc.element()
+		if err = s.InnerSets[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("inner_sets", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpQuorumSet) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "threshold", "validators", "inner_sets")
+	if err != nil {
+		return err
+	}
+	if err = s.Threshold.decodeJSON(fields["threshold"]); err != nil {
+		return jsonFieldError("threshold", err)
+	}
+	if elems, err = decodeJSONArray(fields["validators"], 0); err != nil {
+		return jsonFieldError("validators", err)
+	}
+	s.Validators = nil
+	if len(elems) > 0 {
+		s.Validators = make([]PublicKey, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Validators[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("validators", jsonIndexError(i, err))
+		}
+	}
+	if elems, err = decodeJSONArray(fields["inner_sets"], 0); err != nil {
+		return jsonFieldError("inner_sets", err)
+	}
+	s.InnerSets = nil
+	if len(elems) > 0 {
+		s.InnerSets = make([]ScpQuorumSet, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.InnerSets[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("inner_sets", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpQuorumSet) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -671,6 +2167,26 @@ func (u AccountId) GetEd25519() (result Uint256, ok bool) {
 	return PublicKey(u).GetEd25519()
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AccountId) EncodeTo(enc *xdr.Encoder) error {
+	return PublicKey(s).EncodeTo(enc)
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AccountId) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return (*PublicKey)(s).DecodeFrom(dec)
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AccountId) encodeJSON(enc *jsonEncoder) error {
+	return PublicKey(s).encodeJSON(enc)
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AccountId) decodeJSON(data []byte) error {
+	return (*PublicKey)(s).decodeJSON(data)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountId) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -700,6 +2216,28 @@ func (e Thresholds) XDRMaxSize() int {
 	return 4
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Thresholds) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Thresholds) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Thresholds) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s[:])
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Thresholds) decodeJSON(data []byte) error {
+	return decodeJSONFixedOpaque(data, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Thresholds) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -729,6 +2267,38 @@ func (e String32) XDRMaxSize() int {
 	return 32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s String32) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeString(string(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *String32) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeString(32)
+	if err != nil {
+		return n, err
+	}
+	*s = String32(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s String32) encodeJSON(enc *jsonEncoder) error {
+	enc.string(string(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *String32) decodeJSON(data []byte) error {
+	v, err := decodeJSONString(data, 32)
+	if err != nil {
+		return err
+	}
+	*s = String32(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s String32) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -758,6 +2328,38 @@ func (e String64) XDRMaxSize() int {
 	return 64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s String64) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeString(string(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *String64) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeString(64)
+	if err != nil {
+		return n, err
+	}
+	*s = String64(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s String64) encodeJSON(enc *jsonEncoder) error {
+	enc.string(string(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *String64) decodeJSON(data []byte) error {
+	v, err := decodeJSONString(data, 64)
+	if err != nil {
+		return err
+	}
+	*s = String64(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s String64) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -782,6 +2384,26 @@ var (
 //
 type SequenceNumber Int64
 
+// EncodeTo encodes this value using the Encoder.
+func (s SequenceNumber) EncodeTo(enc *xdr.Encoder) error {
+	return Int64(s).EncodeTo(enc)
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SequenceNumber) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return (*Int64)(s).DecodeFrom(dec)
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SequenceNumber) encodeJSON(enc *jsonEncoder) error {
+	return Int64(s).encodeJSON(enc)
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SequenceNumber) decodeJSON(data []byte) error {
+	return (*Int64)(s).decodeJSON(data)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SequenceNumber) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -806,6 +2428,26 @@ var (
 //
 type TimePoint Uint64
 
+// EncodeTo encodes this value using the Encoder.
+func (s TimePoint) EncodeTo(enc *xdr.Encoder) error {
+	return Uint64(s).EncodeTo(enc)
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TimePoint) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return (*Uint64)(s).DecodeFrom(dec)
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TimePoint) encodeJSON(enc *jsonEncoder) error {
+	return Uint64(s).encodeJSON(enc)
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TimePoint) decodeJSON(data []byte) error {
+	return (*Uint64)(s).decodeJSON(data)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TimePoint) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -835,6 +2477,38 @@ func (e DataValue) XDRMaxSize() int {
 	return 64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s DataValue) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *DataValue) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, 64)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s DataValue) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *DataValue) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, 64)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s DataValue) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -864,6 +2538,17 @@ func (e AssetCode4) XDRMaxSize() int {
 	return 4
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AssetCode4) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AssetCode4) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetCode4) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -893,6 +2578,17 @@ func (e AssetCode12) XDRMaxSize() int {
 	return 12
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AssetCode12) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AssetCode12) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetCode12) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -947,6 +2643,54 @@ func (e AssetType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e AssetType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *AssetType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = AssetType(v)
+	return n, nil
+}
+
+var assetTypeJSONNames = map[int32]string{
+	0: "asset_type_native",
+	1: "asset_type_credit_alphanum4",
+	2: "asset_type_credit_alphanum12",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e AssetType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := assetTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *AssetType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, assetTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = AssetType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1074,6 +2818,134 @@ func (u AssetCode) GetAssetCode12() (result AssetCode12, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AssetCode) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if u.AssetCode4 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AssetCode4.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if u.AssetCode12 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AssetCode12.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AssetCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AssetCode{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = AssetType(sw)
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeCreditAlphanum4:
+		u.AssetCode4 = new(AssetCode4)
+		nTmp, err = u.AssetCode4.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case AssetTypeAssetTypeCreditAlphanum12:
+		u.AssetCode12 = new(AssetCode12)
+		nTmp, err = u.AssetCode12.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AssetCode) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if u.AssetCode4 == nil {
+			return jsonFieldError("asset_code4", errEncodeNilUnionValue())
+		}
+		enc.key("asset_code4")
+		if err = u.AssetCode4.encodeJSON(enc); err != nil {
+			return jsonFieldError("asset_code4", err)
+		}
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if u.AssetCode12 == nil {
+			return jsonFieldError("asset_code12", errEncodeNilUnionValue())
+		}
+		enc.key("asset_code12")
+		if err = u.AssetCode12.encodeJSON(enc); err != nil {
+			return jsonFieldError("asset_code12", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AssetCode) decodeJSON(data []byte) error {
+	*u = AssetCode{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if err = checkJSONKeys(fields, "type", "asset_code4"); err != nil {
+			return err
+		}
+		u.AssetCode4 = new(AssetCode4)
+		if err = u.AssetCode4.decodeJSON(fields["asset_code4"]); err != nil {
+			return jsonFieldError("asset_code4", err)
+		}
+		return nil
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if err = checkJSONKeys(fields, "type", "asset_code12"); err != nil {
+			return err
+		}
+		u.AssetCode12 = new(AssetCode12)
+		if err = u.AssetCode12.decodeJSON(fields["asset_code12"]); err != nil {
+			return jsonFieldError("asset_code12", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1105,6 +2977,66 @@ type AssetAlphaNum4 struct {
 	Issuer    AccountId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AssetAlphaNum4) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AssetCode.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Issuer.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AssetAlphaNum4) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AssetCode.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Issuer.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AssetAlphaNum4) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("asset_code")
+	if err = s.AssetCode.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset_code", err)
+	}
+	enc.key("issuer")
+	if err = s.Issuer.encodeJSON(enc); err != nil {
+		return jsonFieldError("issuer", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AssetAlphaNum4) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "asset_code", "issuer")
+	if err != nil {
+		return err
+	}
+	if err = s.AssetCode.decodeJSON(fields["asset_code"]); err != nil {
+		return jsonFieldError("asset_code", err)
+	}
+	if err = s.Issuer.decodeJSON(fields["issuer"]); err != nil {
+		return jsonFieldError("issuer", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetAlphaNum4) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1136,6 +3068,66 @@ type AssetAlphaNum12 struct {
 	Issuer    AccountId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AssetAlphaNum12) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AssetCode.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Issuer.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AssetAlphaNum12) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AssetCode.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Issuer.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AssetAlphaNum12) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("asset_code")
+	if err = s.AssetCode.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset_code", err)
+	}
+	enc.key("issuer")
+	if err = s.Issuer.encodeJSON(enc); err != nil {
+		return jsonFieldError("issuer", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AssetAlphaNum12) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "asset_code", "issuer")
+	if err != nil {
+		return err
+	}
+	if err = s.AssetCode.decodeJSON(fields["asset_code"]); err != nil {
+		return jsonFieldError("asset_code", err)
+	}
+	if err = s.Issuer.decodeJSON(fields["issuer"]); err != nil {
+		return jsonFieldError("issuer", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AssetAlphaNum12) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1278,11 +3270,80 @@ func (u Asset) GetAlphaNum12() (result AssetAlphaNum12, ok bool) {
 	return
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s Asset) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (u Asset) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeNative:
+		// Void
+		return nil
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if u.AlphaNum4 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AlphaNum4.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if u.AlphaNum12 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AlphaNum12.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *Asset) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = Asset{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = AssetType(sw)
+	switch AssetType(u.Type) {
+	case AssetTypeAssetTypeNative:
+		// Void
+		return n, nil
+	case AssetTypeAssetTypeCreditAlphanum4:
+		u.AlphaNum4 = new(AssetAlphaNum4)
+		nTmp, err = u.AlphaNum4.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case AssetTypeAssetTypeCreditAlphanum12:
+		u.AlphaNum12 = new(AssetAlphaNum12)
+		nTmp, err = u.AlphaNum12.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s Asset) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
@@ -1309,6 +3370,66 @@ type Price struct {
 	D Int32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Price) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.N.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.D.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Price) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.N.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.D.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Price) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("n")
+	if err = s.N.encodeJSON(enc); err != nil {
+		return jsonFieldError("n", err)
+	}
+	enc.key("d")
+	if err = s.D.encodeJSON(enc); err != nil {
+		return jsonFieldError("d", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Price) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "n", "d")
+	if err != nil {
+		return err
+	}
+	if err = s.N.decodeJSON(fields["n"]); err != nil {
+		return jsonFieldError("n", err)
+	}
+	if err = s.D.decodeJSON(fields["d"]); err != nil {
+		return jsonFieldError("d", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Price) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1340,6 +3461,66 @@ type Liabilities struct {
 	Selling Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Liabilities) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Buying.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Selling.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Liabilities) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Buying.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Selling.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Liabilities) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("buying")
+	if err = s.Buying.encodeJSON(enc); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	enc.key("selling")
+	if err = s.Selling.encodeJSON(enc); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Liabilities) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "buying", "selling")
+	if err != nil {
+		return err
+	}
+	if err = s.Buying.decodeJSON(fields["buying"]); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	if err = s.Selling.decodeJSON(fields["selling"]); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Liabilities) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1397,6 +3578,55 @@ func (e ThresholdIndexes) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ThresholdIndexes) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ThresholdIndexes) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ThresholdIndexes(v)
+	return n, nil
+}
+
+var thresholdIndexesJSONNames = map[int32]string{
+	0: "threshold_master_weight",
+	1: "threshold_low",
+	2: "threshold_med",
+	3: "threshold_high",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ThresholdIndexes) encodeJSON(enc *jsonEncoder) error {
+	name, ok := thresholdIndexesJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ThresholdIndexes) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, thresholdIndexesJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ThresholdIndexes(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ThresholdIndexes) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1457,6 +3687,56 @@ func (e LedgerEntryType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e LedgerEntryType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *LedgerEntryType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = LedgerEntryType(v)
+	return n, nil
+}
+
+var ledgerEntryTypeJSONNames = map[int32]string{
+	0: "account",
+	1: "trustline",
+	2: "offer",
+	3: "data",
+	4: "claimable_balance",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e LedgerEntryType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := ledgerEntryTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *LedgerEntryType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, ledgerEntryTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = LedgerEntryType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1488,6 +3768,66 @@ type Signer struct {
 	Weight Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Signer) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Key.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Weight.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Signer) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Key.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Weight.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Signer) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("key")
+	if err = s.Key.encodeJSON(enc); err != nil {
+		return jsonFieldError("key", err)
+	}
+	enc.key("weight")
+	if err = s.Weight.encodeJSON(enc); err != nil {
+		return jsonFieldError("weight", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Signer) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "key", "weight")
+	if err != nil {
+		return err
+	}
+	if err = s.Key.decodeJSON(fields["key"]); err != nil {
+		return jsonFieldError("key", err)
+	}
+	if err = s.Weight.decodeJSON(fields["weight"]); err != nil {
+		return jsonFieldError("weight", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Signer) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1555,6 +3895,55 @@ func (e AccountFlags) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e AccountFlags) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *AccountFlags) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = AccountFlags(v)
+	return n, nil
+}
+
+var accountFlagsJSONNames = map[int32]string{
+	1: "auth_required_flag",
+	2: "auth_revocable_flag",
+	4: "auth_immutable_flag",
+	8: "auth_clawback_enabled_flag",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e AccountFlags) encodeJSON(enc *jsonEncoder) error {
+	name, ok := accountFlagsJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *AccountFlags) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, accountFlagsJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = AccountFlags(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountFlags) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1653,6 +4042,73 @@ func NewAccountEntryExtensionV2Ext(v int32, value interface{}) (result AccountEn
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AccountEntryExtensionV2Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AccountEntryExtensionV2Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AccountEntryExtensionV2Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AccountEntryExtensionV2Ext) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AccountEntryExtensionV2Ext) decodeJSON(data []byte) error {
+	*u = AccountEntryExtensionV2Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountEntryExtensionV2Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1694,6 +4150,155 @@ type AccountEntryExtensionV2 struct {
 	Ext                 AccountEntryExtensionV2Ext
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AccountEntryExtensionV2) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.NumSponsored.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NumSponsoring.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.SignerSponsoringIDs))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.SignerSponsoringIDs); i++ {
+		if _, err = enc.EncodeBool(s.SignerSponsoringIDs[i] != nil); err != nil {
+			return err
+		}
+		if s.SignerSponsoringIDs[i] != nil {
+			if err = (*AccountId)(s.SignerSponsoringIDs[i]).EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AccountEntryExtensionV2) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.NumSponsored.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NumSponsoring.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 20 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.SignerSponsoringIDs = nil
+	if l > 0 {
+		s.SignerSponsoringIDs = make([]SponsorshipDescriptor, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.SignerSponsoringIDs = append(s.SignerSponsoringIDs, nil)
+			present, nTmp, err = dec.DecodeBool()
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+			s.SignerSponsoringIDs[i] = nil
+			if present {
+				s.SignerSponsoringIDs[i] = new(AccountId)
+				nTmp, err = (*AccountId)(s.SignerSponsoringIDs[i]).DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AccountEntryExtensionV2) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("num_sponsored")
+	if err = s.NumSponsored.encodeJSON(enc); err != nil {
+		return jsonFieldError("num_sponsored", err)
+	}
+	enc.key("num_sponsoring")
+	if err = s.NumSponsoring.encodeJSON(enc); err != nil {
+		return jsonFieldError("num_sponsoring", err)
+	}
+	enc.key("signer_sponsoring_ids")
+	enc.beginArray()
+	for i := 0; i < len(s.SignerSponsoringIDs); i++ {
+		enc.element()
+		if s.SignerSponsoringIDs[i] == nil {
+			enc.null()
+		} else {
+			if err = (*AccountId)(s.SignerSponsoringIDs[i]).encodeJSON(enc); err != nil {
+				return jsonFieldError("signer_sponsoring_ids", jsonIndexError(i, err))
+			}
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AccountEntryExtensionV2) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "num_sponsored", "num_sponsoring", "signer_sponsoring_ids", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.NumSponsored.decodeJSON(fields["num_sponsored"]); err != nil {
+		return jsonFieldError("num_sponsored", err)
+	}
+	if err = s.NumSponsoring.decodeJSON(fields["num_sponsoring"]); err != nil {
+		return jsonFieldError("num_sponsoring", err)
+	}
+	if elems, err = decodeJSONArray(fields["signer_sponsoring_ids"], 20); err != nil {
+		return jsonFieldError("signer_sponsoring_ids", err)
+	}
+	s.SignerSponsoringIDs = nil
+	if len(elems) > 0 {
+		s.SignerSponsoringIDs = make([]SponsorshipDescriptor, len(elems))
+	}
+	for i, elem := range elems {
+		s.SignerSponsoringIDs[i] = nil
+		if !isJSONNull(elem) {
+			s.SignerSponsoringIDs[i] = new(AccountId)
+			if err = (*AccountId)(s.SignerSponsoringIDs[i]).decodeJSON(elem); err != nil {
+				return jsonFieldError("signer_sponsoring_ids", jsonIndexError(i, err))
+			}
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountEntryExtensionV2) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1787,6 +4392,107 @@ func (u AccountEntryExtensionV1Ext) GetV2() (result AccountEntryExtensionV2, ok
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AccountEntryExtensionV1Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	case 2:
+		if u.V2 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V2.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AccountEntryExtensionV1Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AccountEntryExtensionV1Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	case 2:
+		u.V2 = new(AccountEntryExtensionV2)
+		nTmp, err = u.V2.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AccountEntryExtensionV1Ext) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	case 2:
+		if u.V2 == nil {
+			return jsonFieldError("v2", errEncodeNilUnionValue())
+		}
+		enc.key("v2")
+		if err = u.V2.encodeJSON(enc); err != nil {
+			return jsonFieldError("v2", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AccountEntryExtensionV1Ext) decodeJSON(data []byte) error {
+	*u = AccountEntryExtensionV1Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case 2:
+		if err = checkJSONKeys(fields, "v", "v2"); err != nil {
+			return err
+		}
+		u.V2 = new(AccountEntryExtensionV2)
+		if err = u.V2.decodeJSON(fields["v2"]); err != nil {
+			return jsonFieldError("v2", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountEntryExtensionV1Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1826,6 +4532,66 @@ type AccountEntryExtensionV1 struct {
 	Ext         AccountEntryExtensionV1Ext
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AccountEntryExtensionV1) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Liabilities.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AccountEntryExtensionV1) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Liabilities.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AccountEntryExtensionV1) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("liabilities")
+	if err = s.Liabilities.encodeJSON(enc); err != nil {
+		return jsonFieldError("liabilities", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AccountEntryExtensionV1) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "liabilities", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.Liabilities.decodeJSON(fields["liabilities"]); err != nil {
+		return jsonFieldError("liabilities", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountEntryExtensionV1) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1919,6 +4685,107 @@ func (u AccountEntryExt) GetV1() (result AccountEntryExtensionV1, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AccountEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	case 1:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AccountEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AccountEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	case 1:
+		u.V1 = new(AccountEntryExtensionV1)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AccountEntryExt) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	case 1:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AccountEntryExt) decodeJSON(data []byte) error {
+	*u = AccountEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case 1:
+		if err = checkJSONKeys(fields, "v", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(AccountEntryExtensionV1)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -1981,18 +4848,257 @@ type AccountEntry struct {
 	Ext           AccountEntryExt
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s AccountEntry) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
-}
-
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *AccountEntry) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
-}
+// EncodeTo encodes this value using the Encoder.
+func (s AccountEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Balance.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SeqNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NumSubEntries.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeBool(s.InflationDest != nil); err != nil {
+		return err
+	}
+	if s.InflationDest != nil {
+		if err = s.InflationDest.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Flags.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.HomeDomain.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Thresholds.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Signers))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Signers); i++ {
+		if err = s.Signers[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AccountEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Balance.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SeqNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NumSubEntries.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.InflationDest = nil
+	if present {
+		s.InflationDest = new(AccountId)
+		nTmp, err = s.InflationDest.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Flags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.HomeDomain.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Thresholds.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 20 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Signers = nil
+	if l > 0 {
+		s.Signers = make([]Signer, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Signers = append(s.Signers, Signer{})
+			nTmp, err = s.Signers[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AccountEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("balance")
+	if err = s.Balance.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance", err)
+	}
+	enc.key("seq_num")
+	if err = s.SeqNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	enc.key("num_sub_entries")
+	if err = s.NumSubEntries.encodeJSON(enc); err != nil {
+		return jsonFieldError("num_sub_entries", err)
+	}
+	enc.key("inflation_dest")
+	if s.InflationDest == nil {
+		enc.null()
+	} else {
+		if err = s.InflationDest.encodeJSON(enc); err != nil {
+			return jsonFieldError("inflation_dest", err)
+		}
+	}
+	enc.key("flags")
+	if err = s.Flags.encodeJSON(enc); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	enc.key("home_domain")
+	if err = s.HomeDomain.encodeJSON(enc); err != nil {
+		return jsonFieldError("home_domain", err)
+	}
+	enc.key("thresholds")
+	if err = s.Thresholds.encodeJSON(enc); err != nil {
+		return jsonFieldError("thresholds", err)
+	}
+	enc.key("signers")
+	enc.beginArray()
+	for i := 0; i < len(s.Signers); i++ {
+		enc.element()
+		if err = s.Signers[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("signers", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AccountEntry) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "account_id", "balance", "seq_num", "num_sub_entries", "inflation_dest", "flags", "home_domain", "thresholds", "signers", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.Balance.decodeJSON(fields["balance"]); err != nil {
+		return jsonFieldError("balance", err)
+	}
+	if err = s.SeqNum.decodeJSON(fields["seq_num"]); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	if err = s.NumSubEntries.decodeJSON(fields["num_sub_entries"]); err != nil {
+		return jsonFieldError("num_sub_entries", err)
+	}
+	s.InflationDest = nil
+	if !isJSONNull(fields["inflation_dest"]) {
+		s.InflationDest = new(AccountId)
+		if err = s.InflationDest.decodeJSON(fields["inflation_dest"]); err != nil {
+			return jsonFieldError("inflation_dest", err)
+		}
+	}
+	if err = s.Flags.decodeJSON(fields["flags"]); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	if err = s.HomeDomain.decodeJSON(fields["home_domain"]); err != nil {
+		return jsonFieldError("home_domain", err)
+	}
+	if err = s.Thresholds.decodeJSON(fields["thresholds"]); err != nil {
+		return jsonFieldError("thresholds", err)
+	}
+	if elems, err = decodeJSONArray(fields["signers"], 20); err != nil {
+		return jsonFieldError("signers", err)
+	}
+	s.Signers = nil
+	if len(elems) > 0 {
+		s.Signers = make([]Signer, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Signers[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("signers", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s AccountEntry) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *AccountEntry) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
 
 var (
 	_ encoding.BinaryMarshaler   = (*AccountEntry)(nil)
@@ -2040,6 +5146,54 @@ func (e TrustLineFlags) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e TrustLineFlags) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *TrustLineFlags) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = TrustLineFlags(v)
+	return n, nil
+}
+
+var trustLineFlagsJSONNames = map[int32]string{
+	1: "authorized_flag",
+	2: "authorized_to_maintain_liabilities_flag",
+	4: "trustline_clawback_enabled_flag",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e TrustLineFlags) encodeJSON(enc *jsonEncoder) error {
+	name, ok := trustLineFlagsJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *TrustLineFlags) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, trustLineFlagsJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = TrustLineFlags(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TrustLineFlags) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2114,6 +5268,73 @@ func NewTrustLineEntryV1Ext(v int32, value interface{}) (result TrustLineEntryV1
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TrustLineEntryV1Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TrustLineEntryV1Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TrustLineEntryV1Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TrustLineEntryV1Ext) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TrustLineEntryV1Ext) decodeJSON(data []byte) error {
+	*u = TrustLineEntryV1Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TrustLineEntryV1Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2151,6 +5372,66 @@ type TrustLineEntryV1 struct {
 	Ext         TrustLineEntryV1Ext
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TrustLineEntryV1) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Liabilities.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TrustLineEntryV1) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Liabilities.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TrustLineEntryV1) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("liabilities")
+	if err = s.Liabilities.encodeJSON(enc); err != nil {
+		return jsonFieldError("liabilities", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TrustLineEntryV1) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "liabilities", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.Liabilities.decodeJSON(fields["liabilities"]); err != nil {
+		return jsonFieldError("liabilities", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TrustLineEntryV1) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2254,6 +5535,107 @@ func (u TrustLineEntryExt) GetV1() (result TrustLineEntryV1, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TrustLineEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	case 1:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TrustLineEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TrustLineEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	case 1:
+		u.V1 = new(TrustLineEntryV1)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TrustLineEntryExt) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	case 1:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TrustLineEntryExt) decodeJSON(data []byte) error {
+	*u = TrustLineEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case 1:
+		if err = checkJSONKeys(fields, "v", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(TrustLineEntryV1)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TrustLineEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2314,6 +5696,126 @@ type TrustLineEntry struct {
 	Ext       TrustLineEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TrustLineEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Balance.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Limit.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Flags.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TrustLineEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Balance.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Limit.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Flags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TrustLineEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("balance")
+	if err = s.Balance.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance", err)
+	}
+	enc.key("limit")
+	if err = s.Limit.encodeJSON(enc); err != nil {
+		return jsonFieldError("limit", err)
+	}
+	enc.key("flags")
+	if err = s.Flags.encodeJSON(enc); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TrustLineEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id", "asset", "balance", "limit", "flags", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Balance.decodeJSON(fields["balance"]); err != nil {
+		return jsonFieldError("balance", err)
+	}
+	if err = s.Limit.decodeJSON(fields["limit"]); err != nil {
+		return jsonFieldError("limit", err)
+	}
+	if err = s.Flags.decodeJSON(fields["flags"]); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TrustLineEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2363,6 +5865,52 @@ func (e OfferEntryFlags) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e OfferEntryFlags) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *OfferEntryFlags) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = OfferEntryFlags(v)
+	return n, nil
+}
+
+var offerEntryFlagsJSONNames = map[int32]string{
+	1: "passive_flag",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e OfferEntryFlags) encodeJSON(enc *jsonEncoder) error {
+	name, ok := offerEntryFlagsJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *OfferEntryFlags) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, offerEntryFlagsJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = OfferEntryFlags(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OfferEntryFlags) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2425,6 +5973,73 @@ func NewOfferEntryExt(v int32, value interface{}) (result OfferEntryExt, err err
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u OfferEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *OfferEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = OfferEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u OfferEntryExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *OfferEntryExt) decodeJSON(data []byte) error {
+	*u = OfferEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OfferEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2481,29 +6096,179 @@ type OfferEntry struct {
 	Ext      OfferEntryExt
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s OfferEntry) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (s OfferEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SellerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OfferId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Selling.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Buying.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Price.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Flags.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *OfferEntry) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// DecodeFrom decodes this value using the Decoder.
+func (s *OfferEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SellerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OfferId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Selling.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Buying.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Price.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Flags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*OfferEntry)(nil)
-	_ encoding.BinaryUnmarshaler = (*OfferEntry)(nil)
-)
-
-// DataEntryExt is an XDR NestedUnion defines as:
-//
-//   union switch (int v)
-//        {
-//        case 0:
+// encodeJSON writes the JSON representation of this value to enc.
+func (s OfferEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("seller_id")
+	if err = s.SellerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	enc.key("offer_id")
+	if err = s.OfferId.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	enc.key("selling")
+	if err = s.Selling.encodeJSON(enc); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	enc.key("buying")
+	if err = s.Buying.encodeJSON(enc); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.key("price")
+	if err = s.Price.encodeJSON(enc); err != nil {
+		return jsonFieldError("price", err)
+	}
+	enc.key("flags")
+	if err = s.Flags.encodeJSON(enc); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *OfferEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "seller_id", "offer_id", "selling", "buying", "amount", "price", "flags", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.SellerId.decodeJSON(fields["seller_id"]); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	if err = s.OfferId.decodeJSON(fields["offer_id"]); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	if err = s.Selling.decodeJSON(fields["selling"]); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	if err = s.Buying.decodeJSON(fields["buying"]); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	if err = s.Price.decodeJSON(fields["price"]); err != nil {
+		return jsonFieldError("price", err)
+	}
+	if err = s.Flags.decodeJSON(fields["flags"]); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s OfferEntry) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *OfferEntry) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*OfferEntry)(nil)
+	_ encoding.BinaryUnmarshaler = (*OfferEntry)(nil)
+)
+
+// DataEntryExt is an XDR NestedUnion defines as:
+//
+//   union switch (int v)
+//        {
+//        case 0:
 //            void;
 //        }
 //
@@ -2537,6 +6302,73 @@ func NewDataEntryExt(v int32, value interface{}) (result DataEntryExt, err error
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u DataEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *DataEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = DataEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u DataEntryExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *DataEntryExt) decodeJSON(data []byte) error {
+	*u = DataEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s DataEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2579,6 +6411,96 @@ type DataEntry struct {
 	Ext       DataEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s DataEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DataName.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DataValue.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *DataEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DataName.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DataValue.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s DataEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("data_name")
+	if err = s.DataName.encodeJSON(enc); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	enc.key("data_value")
+	if err = s.DataValue.encodeJSON(enc); err != nil {
+		return jsonFieldError("data_value", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *DataEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id", "data_name", "data_value", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.DataName.decodeJSON(fields["data_name"]); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	if err = s.DataValue.decodeJSON(fields["data_value"]); err != nil {
+		return jsonFieldError("data_value", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s DataEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2642,6 +6564,57 @@ func (e ClaimPredicateType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClaimPredicateType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClaimPredicateType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClaimPredicateType(v)
+	return n, nil
+}
+
+var claimPredicateTypeJSONNames = map[int32]string{
+	0: "claim_predicate_unconditional",
+	1: "claim_predicate_and",
+	2: "claim_predicate_or",
+	3: "claim_predicate_not",
+	4: "claim_predicate_before_absolute_time",
+	5: "claim_predicate_before_relative_time",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClaimPredicateType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := claimPredicateTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClaimPredicateType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, claimPredicateTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClaimPredicateType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimPredicateType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2884,6 +6857,337 @@ func (u ClaimPredicate) GetRelBefore() (result Int64, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClaimPredicate) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClaimPredicateType(u.Type) {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		// Void
+		return nil
+	case ClaimPredicateTypeClaimPredicateAnd:
+		if u.AndPredicates == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.AndPredicates))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.AndPredicates); i++ {
+			if err = (*u.AndPredicates)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateOr:
+		if u.OrPredicates == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.OrPredicates))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.OrPredicates); i++ {
+			if err = (*u.OrPredicates)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateNot:
+		if u.NotPredicate == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeBool(*u.NotPredicate != nil); err != nil {
+			return err
+		}
+		if *u.NotPredicate != nil {
+			if err = (*u.NotPredicate).EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		if u.AbsBefore == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AbsBefore.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if u.RelBefore == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.RelBefore.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClaimPredicate) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var present bool
+	var err error
+	var n, nTmp int
+	*u = ClaimPredicate{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = ClaimPredicateType(sw)
+	switch ClaimPredicateType(u.Type) {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		// Void
+		return n, nil
+	case ClaimPredicateTypeClaimPredicateAnd:
+		u.AndPredicates = new([]ClaimPredicate)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > 2 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.AndPredicates = nil
+		if l > 0 {
+			*u.AndPredicates = make([]ClaimPredicate, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.AndPredicates = append(*u.AndPredicates, ClaimPredicate{})
+				nTmp, err = (*u.AndPredicates)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	case ClaimPredicateTypeClaimPredicateOr:
+		u.OrPredicates = new([]ClaimPredicate)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > 2 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.OrPredicates = nil
+		if l > 0 {
+			*u.OrPredicates = make([]ClaimPredicate, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.OrPredicates = append(*u.OrPredicates, ClaimPredicate{})
+				nTmp, err = (*u.OrPredicates)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	case ClaimPredicateTypeClaimPredicateNot:
+		u.NotPredicate = new(*ClaimPredicate)
+		present, nTmp, err = dec.DecodeBool()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		*u.NotPredicate = nil
+		if present {
+			*u.NotPredicate = new(ClaimPredicate)
+			nTmp, err = (*u.NotPredicate).DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+		return n, nil
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		u.AbsBefore = new(Int64)
+		nTmp, err = u.AbsBefore.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		u.RelBefore = new(Int64)
+		nTmp, err = u.RelBefore.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClaimPredicate) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimPredicateType(u.Type) {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		// Void
+	case ClaimPredicateTypeClaimPredicateAnd:
+		if u.AndPredicates == nil {
+			return jsonFieldError("and_predicates", errEncodeNilUnionValue())
+		}
+		enc.key("and_predicates")
+		enc.beginArray()
+		for i := 0; i < len(*u.AndPredicates); i++ {
+			enc.element()
+			if err = (*u.AndPredicates)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("and_predicates", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	case ClaimPredicateTypeClaimPredicateOr:
+		if u.OrPredicates == nil {
+			return jsonFieldError("or_predicates", errEncodeNilUnionValue())
+		}
+		enc.key("or_predicates")
+		enc.beginArray()
+		for i := 0; i < len(*u.OrPredicates); i++ {
+			enc.element()
+			if err = (*u.OrPredicates)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("or_predicates", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	case ClaimPredicateTypeClaimPredicateNot:
+		if u.NotPredicate == nil {
+			return jsonFieldError("not_predicate", errEncodeNilUnionValue())
+		}
+		enc.key("not_predicate")
+		if *u.NotPredicate == nil {
+			enc.null()
+		} else {
+			if err = (*u.NotPredicate).encodeJSON(enc); err != nil {
+				return jsonFieldError("not_predicate", err)
+			}
+		}
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		if u.AbsBefore == nil {
+			return jsonFieldError("abs_before", errEncodeNilUnionValue())
+		}
+		enc.key("abs_before")
+		if err = u.AbsBefore.encodeJSON(enc); err != nil {
+			return jsonFieldError("abs_before", err)
+		}
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if u.RelBefore == nil {
+			return jsonFieldError("rel_before", errEncodeNilUnionValue())
+		}
+		enc.key("rel_before")
+		if err = u.RelBefore.encodeJSON(enc); err != nil {
+			return jsonFieldError("rel_before", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClaimPredicate) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = ClaimPredicate{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimPredicateType(u.Type) {
+	case ClaimPredicateTypeClaimPredicateUnconditional:
+		// Void
+		return checkJSONKeys(fields, "type")
+	case ClaimPredicateTypeClaimPredicateAnd:
+		if err = checkJSONKeys(fields, "type", "and_predicates"); err != nil {
+			return err
+		}
+		u.AndPredicates = new([]ClaimPredicate)
+		if elems, err = decodeJSONArray(fields["and_predicates"], 2); err != nil {
+			return jsonFieldError("and_predicates", err)
+		}
+		*u.AndPredicates = nil
+		if len(elems) > 0 {
+			*u.AndPredicates = make([]ClaimPredicate, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.AndPredicates)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("and_predicates", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateOr:
+		if err = checkJSONKeys(fields, "type", "or_predicates"); err != nil {
+			return err
+		}
+		u.OrPredicates = new([]ClaimPredicate)
+		if elems, err = decodeJSONArray(fields["or_predicates"], 2); err != nil {
+			return jsonFieldError("or_predicates", err)
+		}
+		*u.OrPredicates = nil
+		if len(elems) > 0 {
+			*u.OrPredicates = make([]ClaimPredicate, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.OrPredicates)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("or_predicates", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateNot:
+		if err = checkJSONKeys(fields, "type", "not_predicate"); err != nil {
+			return err
+		}
+		u.NotPredicate = new(*ClaimPredicate)
+		*u.NotPredicate = nil
+		if !isJSONNull(fields["not_predicate"]) {
+			*u.NotPredicate = new(ClaimPredicate)
+			if err = (*u.NotPredicate).decodeJSON(fields["not_predicate"]); err != nil {
+				return jsonFieldError("not_predicate", err)
+			}
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		if err = checkJSONKeys(fields, "type", "abs_before"); err != nil {
+			return err
+		}
+		u.AbsBefore = new(Int64)
+		if err = u.AbsBefore.decodeJSON(fields["abs_before"]); err != nil {
+			return jsonFieldError("abs_before", err)
+		}
+		return nil
+	case ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if err = checkJSONKeys(fields, "type", "rel_before"); err != nil {
+			return err
+		}
+		u.RelBefore = new(Int64)
+		if err = u.RelBefore.decodeJSON(fields["rel_before"]); err != nil {
+			return jsonFieldError("rel_before", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimPredicate) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2932,6 +7236,52 @@ func (e ClaimantType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClaimantType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClaimantType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClaimantType(v)
+	return n, nil
+}
+
+var claimantTypeJSONNames = map[int32]string{
+	0: "claimant_type_v0",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClaimantType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := claimantTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClaimantType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, claimantTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClaimantType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimantType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -2963,6 +7313,66 @@ type ClaimantV0 struct {
 	Predicate   ClaimPredicate
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClaimantV0) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Predicate.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClaimantV0) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Predicate.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClaimantV0) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("predicate")
+	if err = s.Predicate.encodeJSON(enc); err != nil {
+		return jsonFieldError("predicate", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClaimantV0) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "destination", "predicate")
+	if err != nil {
+		return err
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.Predicate.decodeJSON(fields["predicate"]); err != nil {
+		return jsonFieldError("predicate", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimantV0) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3054,25 +7464,120 @@ func (u Claimant) GetV0() (result ClaimantV0, ok bool) {
 	return
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s Claimant) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (u Claimant) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClaimantType(u.Type) {
+	case ClaimantTypeClaimantTypeV0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *Claimant) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// DecodeFrom decodes this value using the Decoder.
+func (u *Claimant) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = Claimant{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = ClaimantType(sw)
+	switch ClaimantType(u.Type) {
+	case ClaimantTypeClaimantTypeV0:
+		u.V0 = new(ClaimantV0)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*Claimant)(nil)
-	_ encoding.BinaryUnmarshaler = (*Claimant)(nil)
-)
-
-// ClaimableBalanceIdType is an XDR Enum defines as:
+// encodeJSON writes the JSON representation of this value to enc.
+func (u Claimant) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimantType(u.Type) {
+	case ClaimantTypeClaimantTypeV0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *Claimant) decodeJSON(data []byte) error {
+	*u = Claimant{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimantType(u.Type) {
+	case ClaimantTypeClaimantTypeV0:
+		if err = checkJSONKeys(fields, "type", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(ClaimantV0)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s Claimant) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Claimant) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Claimant)(nil)
+	_ encoding.BinaryUnmarshaler = (*Claimant)(nil)
+)
+
+// ClaimableBalanceIdType is an XDR Enum defines as:
 //
 //   enum ClaimableBalanceIDType
 //    {
@@ -3102,6 +7607,52 @@ func (e ClaimableBalanceIdType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClaimableBalanceIdType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClaimableBalanceIdType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClaimableBalanceIdType(v)
+	return n, nil
+}
+
+var claimableBalanceIdTypeJSONNames = map[int32]string{
+	0: "claimable_balance_id_type_v0",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClaimableBalanceIdType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := claimableBalanceIdTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClaimableBalanceIdType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, claimableBalanceIdTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClaimableBalanceIdType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceIdType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3189,6 +7740,101 @@ func (u ClaimableBalanceId) GetV0() (result Hash, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClaimableBalanceId) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClaimableBalanceIdType(u.Type) {
+	case ClaimableBalanceIdTypeClaimableBalanceIdTypeV0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClaimableBalanceId) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClaimableBalanceId{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = ClaimableBalanceIdType(sw)
+	switch ClaimableBalanceIdType(u.Type) {
+	case ClaimableBalanceIdTypeClaimableBalanceIdTypeV0:
+		u.V0 = new(Hash)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClaimableBalanceId) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimableBalanceIdType(u.Type) {
+	case ClaimableBalanceIdTypeClaimableBalanceIdTypeV0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClaimableBalanceId) decodeJSON(data []byte) error {
+	*u = ClaimableBalanceId{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch ClaimableBalanceIdType(u.Type) {
+	case ClaimableBalanceIdTypeClaimableBalanceIdTypeV0:
+		if err = checkJSONKeys(fields, "type", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(Hash)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceId) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3239,6 +7885,52 @@ func (e ClaimableBalanceFlags) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClaimableBalanceFlags) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClaimableBalanceFlags) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClaimableBalanceFlags(v)
+	return n, nil
+}
+
+var claimableBalanceFlagsJSONNames = map[int32]string{
+	1: "claimable_balance_clawback_enabled_flag",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClaimableBalanceFlags) encodeJSON(enc *jsonEncoder) error {
+	name, ok := claimableBalanceFlagsJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClaimableBalanceFlags) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, claimableBalanceFlagsJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClaimableBalanceFlags(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceFlags) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3301,6 +7993,73 @@ func NewClaimableBalanceEntryExtensionV1Ext(v int32, value interface{}) (result
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClaimableBalanceEntryExtensionV1Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClaimableBalanceEntryExtensionV1Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClaimableBalanceEntryExtensionV1Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClaimableBalanceEntryExtensionV1Ext) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClaimableBalanceEntryExtensionV1Ext) decodeJSON(data []byte) error {
+	*u = ClaimableBalanceEntryExtensionV1Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceEntryExtensionV1Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3338,6 +8097,66 @@ type ClaimableBalanceEntryExtensionV1 struct {
 	Flags Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClaimableBalanceEntryExtensionV1) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Flags.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClaimableBalanceEntryExtensionV1) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Flags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClaimableBalanceEntryExtensionV1) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.key("flags")
+	if err = s.Flags.encodeJSON(enc); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClaimableBalanceEntryExtensionV1) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ext", "flags")
+	if err != nil {
+		return err
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	if err = s.Flags.decodeJSON(fields["flags"]); err != nil {
+		return jsonFieldError("flags", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceEntryExtensionV1) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3431,6 +8250,107 @@ func (u ClaimableBalanceEntryExt) GetV1() (result ClaimableBalanceEntryExtension
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClaimableBalanceEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	case 1:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClaimableBalanceEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClaimableBalanceEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	case 1:
+		u.V1 = new(ClaimableBalanceEntryExtensionV1)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClaimableBalanceEntryExt) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	case 1:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClaimableBalanceEntryExt) decodeJSON(data []byte) error {
+	*u = ClaimableBalanceEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case 1:
+		if err = checkJSONKeys(fields, "v", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(ClaimableBalanceEntryExtensionV1)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3484,6 +8404,147 @@ type ClaimableBalanceEntry struct {
 	Ext       ClaimableBalanceEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClaimableBalanceEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.BalanceId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Claimants))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Claimants); i++ {
+		if err = s.Claimants[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClaimableBalanceEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.BalanceId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 10 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Claimants = nil
+	if l > 0 {
+		s.Claimants = make([]Claimant, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Claimants = append(s.Claimants, Claimant{})
+			nTmp, err = s.Claimants[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClaimableBalanceEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("balance_id")
+	if err = s.BalanceId.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	enc.key("claimants")
+	enc.beginArray()
+	for i := 0; i < len(s.Claimants); i++ {
+		enc.element()
+		if err = s.Claimants[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("claimants", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClaimableBalanceEntry) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "balance_id", "claimants", "asset", "amount", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.BalanceId.decodeJSON(fields["balance_id"]); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	if elems, err = decodeJSONArray(fields["claimants"], 10); err != nil {
+		return jsonFieldError("claimants", err)
+	}
+	s.Claimants = nil
+	if len(elems) > 0 {
+		s.Claimants = make([]Claimant, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Claimants[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("claimants", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimableBalanceEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3540,6 +8601,73 @@ func NewLedgerEntryExtensionV1Ext(v int32, value interface{}) (result LedgerEntr
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerEntryExtensionV1Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerEntryExtensionV1Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerEntryExtensionV1Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerEntryExtensionV1Ext) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerEntryExtensionV1Ext) decodeJSON(data []byte) error {
+	*u = LedgerEntryExtensionV1Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryExtensionV1Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3577,6 +8705,89 @@ type LedgerEntryExtensionV1 struct {
 	Ext          LedgerEntryExtensionV1Ext
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerEntryExtensionV1) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeBool(s.SponsoringId != nil); err != nil {
+		return err
+	}
+	if s.SponsoringId != nil {
+		if err = (*AccountId)(s.SponsoringId).EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerEntryExtensionV1) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var present bool
+	var err error
+	var n, nTmp int
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.SponsoringId = nil
+	if present {
+		s.SponsoringId = new(AccountId)
+		nTmp, err = (*AccountId)(s.SponsoringId).DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerEntryExtensionV1) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("sponsoring_id")
+	if s.SponsoringId == nil {
+		enc.null()
+	} else {
+		if err = (*AccountId)(s.SponsoringId).encodeJSON(enc); err != nil {
+			return jsonFieldError("sponsoring_id", err)
+		}
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerEntryExtensionV1) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "sponsoring_id", "ext")
+	if err != nil {
+		return err
+	}
+	s.SponsoringId = nil
+	if !isJSONNull(fields["sponsoring_id"]) {
+		s.SponsoringId = new(AccountId)
+		if err = (*AccountId)(s.SponsoringId).decodeJSON(fields["sponsoring_id"]); err != nil {
+			return jsonFieldError("sponsoring_id", err)
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryExtensionV1) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3812,14 +9023,241 @@ func (u LedgerEntryData) GetClaimableBalance() (result ClaimableBalanceEntry, ok
 	return
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s LedgerEntryData) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerEntryData) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if u.Account == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Account.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeTrustline:
+		if u.TrustLine == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.TrustLine.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeOffer:
+		if u.Offer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Offer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeData:
+		if u.Data == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Data.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeClaimableBalance:
+		if u.ClaimableBalance == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClaimableBalance.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerEntryData) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerEntryData{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = LedgerEntryType(sw)
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		u.Account = new(AccountEntry)
+		nTmp, err = u.Account.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeTrustline:
+		u.TrustLine = new(TrustLineEntry)
+		nTmp, err = u.TrustLine.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeOffer:
+		u.Offer = new(OfferEntry)
+		nTmp, err = u.Offer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeData:
+		u.Data = new(DataEntry)
+		nTmp, err = u.Data.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeClaimableBalance:
+		u.ClaimableBalance = new(ClaimableBalanceEntry)
+		nTmp, err = u.ClaimableBalance.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerEntryData) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if u.Account == nil {
+			return jsonFieldError("account", errEncodeNilUnionValue())
+		}
+		enc.key("account")
+		if err = u.Account.encodeJSON(enc); err != nil {
+			return jsonFieldError("account", err)
+		}
+	case LedgerEntryTypeTrustline:
+		if u.TrustLine == nil {
+			return jsonFieldError("trust_line", errEncodeNilUnionValue())
+		}
+		enc.key("trust_line")
+		if err = u.TrustLine.encodeJSON(enc); err != nil {
+			return jsonFieldError("trust_line", err)
+		}
+	case LedgerEntryTypeOffer:
+		if u.Offer == nil {
+			return jsonFieldError("offer", errEncodeNilUnionValue())
+		}
+		enc.key("offer")
+		if err = u.Offer.encodeJSON(enc); err != nil {
+			return jsonFieldError("offer", err)
+		}
+	case LedgerEntryTypeData:
+		if u.Data == nil {
+			return jsonFieldError("data", errEncodeNilUnionValue())
+		}
+		enc.key("data")
+		if err = u.Data.encodeJSON(enc); err != nil {
+			return jsonFieldError("data", err)
+		}
+	case LedgerEntryTypeClaimableBalance:
+		if u.ClaimableBalance == nil {
+			return jsonFieldError("claimable_balance", errEncodeNilUnionValue())
+		}
+		enc.key("claimable_balance")
+		if err = u.ClaimableBalance.encodeJSON(enc); err != nil {
+			return jsonFieldError("claimable_balance", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerEntryData) decodeJSON(data []byte) error {
+	*u = LedgerEntryData{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if err = checkJSONKeys(fields, "type", "account"); err != nil {
+			return err
+		}
+		u.Account = new(AccountEntry)
+		if err = u.Account.decodeJSON(fields["account"]); err != nil {
+			return jsonFieldError("account", err)
+		}
+		return nil
+	case LedgerEntryTypeTrustline:
+		if err = checkJSONKeys(fields, "type", "trust_line"); err != nil {
+			return err
+		}
+		u.TrustLine = new(TrustLineEntry)
+		if err = u.TrustLine.decodeJSON(fields["trust_line"]); err != nil {
+			return jsonFieldError("trust_line", err)
+		}
+		return nil
+	case LedgerEntryTypeOffer:
+		if err = checkJSONKeys(fields, "type", "offer"); err != nil {
+			return err
+		}
+		u.Offer = new(OfferEntry)
+		if err = u.Offer.decodeJSON(fields["offer"]); err != nil {
+			return jsonFieldError("offer", err)
+		}
+		return nil
+	case LedgerEntryTypeData:
+		if err = checkJSONKeys(fields, "type", "data"); err != nil {
+			return err
+		}
+		u.Data = new(DataEntry)
+		if err = u.Data.decodeJSON(fields["data"]); err != nil {
+			return jsonFieldError("data", err)
+		}
+		return nil
+	case LedgerEntryTypeClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "claimable_balance"); err != nil {
+			return err
+		}
+		u.ClaimableBalance = new(ClaimableBalanceEntry)
+		if err = u.ClaimableBalance.decodeJSON(fields["claimable_balance"]); err != nil {
+			return jsonFieldError("claimable_balance", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s LedgerEntryData) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (s *LedgerEntryData) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
@@ -3905,6 +9343,107 @@ func (u LedgerEntryExt) GetV1() (result LedgerEntryExtensionV1, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	case 1:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	case 1:
+		u.V1 = new(LedgerEntryExtensionV1)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerEntryExt) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	case 1:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerEntryExt) decodeJSON(data []byte) error {
+	*u = LedgerEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case 1:
+		if err = checkJSONKeys(fields, "v", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(LedgerEntryExtensionV1)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3961,6 +9500,81 @@ type LedgerEntry struct {
 	Ext                   LedgerEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LastModifiedLedgerSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Data.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LastModifiedLedgerSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Data.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("last_modified_ledger_seq")
+	if err = s.LastModifiedLedgerSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("last_modified_ledger_seq", err)
+	}
+	enc.key("data")
+	if err = s.Data.encodeJSON(enc); err != nil {
+		return jsonFieldError("data", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "last_modified_ledger_seq", "data", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.LastModifiedLedgerSeq.decodeJSON(fields["last_modified_ledger_seq"]); err != nil {
+		return jsonFieldError("last_modified_ledger_seq", err)
+	}
+	if err = s.Data.decodeJSON(fields["data"]); err != nil {
+		return jsonFieldError("data", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -3990,6 +9604,51 @@ type LedgerKeyAccount struct {
 	AccountId AccountId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerKeyAccount) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerKeyAccount) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerKeyAccount) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerKeyAccount) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerKeyAccount) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4021,6 +9680,66 @@ type LedgerKeyTrustLine struct {
 	Asset     Asset
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerKeyTrustLine) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerKeyTrustLine) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerKeyTrustLine) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerKeyTrustLine) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id", "asset")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerKeyTrustLine) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4052,6 +9771,66 @@ type LedgerKeyOffer struct {
 	OfferId  Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerKeyOffer) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SellerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OfferId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerKeyOffer) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SellerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OfferId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerKeyOffer) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("seller_id")
+	if err = s.SellerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	enc.key("offer_id")
+	if err = s.OfferId.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerKeyOffer) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "seller_id", "offer_id")
+	if err != nil {
+		return err
+	}
+	if err = s.SellerId.decodeJSON(fields["seller_id"]); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	if err = s.OfferId.decodeJSON(fields["offer_id"]); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerKeyOffer) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4083,6 +9862,66 @@ type LedgerKeyData struct {
 	DataName  String64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerKeyData) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DataName.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerKeyData) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DataName.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerKeyData) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("data_name")
+	if err = s.DataName.encodeJSON(enc); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerKeyData) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id", "data_name")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.DataName.decodeJSON(fields["data_name"]); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerKeyData) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4112,6 +9951,51 @@ type LedgerKeyClaimableBalance struct {
 	BalanceId ClaimableBalanceId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerKeyClaimableBalance) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.BalanceId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerKeyClaimableBalance) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.BalanceId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerKeyClaimableBalance) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("balance_id")
+	if err = s.BalanceId.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerKeyClaimableBalance) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "balance_id")
+	if err != nil {
+		return err
+	}
+	if err = s.BalanceId.decodeJSON(fields["balance_id"]); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerKeyClaimableBalance) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4369,25 +10253,252 @@ func (u LedgerKey) GetClaimableBalance() (result LedgerKeyClaimableBalance, ok b
 	return
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s LedgerKey) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerKey) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if u.Account == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Account.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeTrustline:
+		if u.TrustLine == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.TrustLine.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeOffer:
+		if u.Offer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Offer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeData:
+		if u.Data == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Data.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryTypeClaimableBalance:
+		if u.ClaimableBalance == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClaimableBalance.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *LedgerKey) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerKey) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerKey{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = LedgerEntryType(sw)
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		u.Account = new(LedgerKeyAccount)
+		nTmp, err = u.Account.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeTrustline:
+		u.TrustLine = new(LedgerKeyTrustLine)
+		nTmp, err = u.TrustLine.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeOffer:
+		u.Offer = new(LedgerKeyOffer)
+		nTmp, err = u.Offer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeData:
+		u.Data = new(LedgerKeyData)
+		nTmp, err = u.Data.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryTypeClaimableBalance:
+		u.ClaimableBalance = new(LedgerKeyClaimableBalance)
+		nTmp, err = u.ClaimableBalance.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*LedgerKey)(nil)
-	_ encoding.BinaryUnmarshaler = (*LedgerKey)(nil)
-)
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerKey) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if u.Account == nil {
+			return jsonFieldError("account", errEncodeNilUnionValue())
+		}
+		enc.key("account")
+		if err = u.Account.encodeJSON(enc); err != nil {
+			return jsonFieldError("account", err)
+		}
+	case LedgerEntryTypeTrustline:
+		if u.TrustLine == nil {
+			return jsonFieldError("trust_line", errEncodeNilUnionValue())
+		}
+		enc.key("trust_line")
+		if err = u.TrustLine.encodeJSON(enc); err != nil {
+			return jsonFieldError("trust_line", err)
+		}
+	case LedgerEntryTypeOffer:
+		if u.Offer == nil {
+			return jsonFieldError("offer", errEncodeNilUnionValue())
+		}
+		enc.key("offer")
+		if err = u.Offer.encodeJSON(enc); err != nil {
+			return jsonFieldError("offer", err)
+		}
+	case LedgerEntryTypeData:
+		if u.Data == nil {
+			return jsonFieldError("data", errEncodeNilUnionValue())
+		}
+		enc.key("data")
+		if err = u.Data.encodeJSON(enc); err != nil {
+			return jsonFieldError("data", err)
+		}
+	case LedgerEntryTypeClaimableBalance:
+		if u.ClaimableBalance == nil {
+			return jsonFieldError("claimable_balance", errEncodeNilUnionValue())
+		}
+		enc.key("claimable_balance")
+		if err = u.ClaimableBalance.encodeJSON(enc); err != nil {
+			return jsonFieldError("claimable_balance", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
 
-// EnvelopeType is an XDR Enum defines as:
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerKey) decodeJSON(data []byte) error {
+	*u = LedgerKey{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryType(u.Type) {
+	case LedgerEntryTypeAccount:
+		if err = checkJSONKeys(fields, "type", "account"); err != nil {
+			return err
+		}
+		u.Account = new(LedgerKeyAccount)
+		if err = u.Account.decodeJSON(fields["account"]); err != nil {
+			return jsonFieldError("account", err)
+		}
+		return nil
+	case LedgerEntryTypeTrustline:
+		if err = checkJSONKeys(fields, "type", "trust_line"); err != nil {
+			return err
+		}
+		u.TrustLine = new(LedgerKeyTrustLine)
+		if err = u.TrustLine.decodeJSON(fields["trust_line"]); err != nil {
+			return jsonFieldError("trust_line", err)
+		}
+		return nil
+	case LedgerEntryTypeOffer:
+		if err = checkJSONKeys(fields, "type", "offer"); err != nil {
+			return err
+		}
+		u.Offer = new(LedgerKeyOffer)
+		if err = u.Offer.decodeJSON(fields["offer"]); err != nil {
+			return jsonFieldError("offer", err)
+		}
+		return nil
+	case LedgerEntryTypeData:
+		if err = checkJSONKeys(fields, "type", "data"); err != nil {
+			return err
+		}
+		u.Data = new(LedgerKeyData)
+		if err = u.Data.decodeJSON(fields["data"]); err != nil {
+			return jsonFieldError("data", err)
+		}
+		return nil
+	case LedgerEntryTypeClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "claimable_balance"); err != nil {
+			return err
+		}
+		u.ClaimableBalance = new(LedgerKeyClaimableBalance)
+		if err = u.ClaimableBalance.decodeJSON(fields["claimable_balance"]); err != nil {
+			return jsonFieldError("claimable_balance", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s LedgerKey) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *LedgerKey) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*LedgerKey)(nil)
+	_ encoding.BinaryUnmarshaler = (*LedgerKey)(nil)
+)
+
+// EnvelopeType is an XDR Enum defines as:
 //
 //   enum EnvelopeType
 //    {
@@ -4435,6 +10546,58 @@ func (e EnvelopeType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e EnvelopeType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *EnvelopeType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = EnvelopeType(v)
+	return n, nil
+}
+
+var envelopeTypeJSONNames = map[int32]string{
+	0: "envelope_type_tx_v0",
+	1: "envelope_type_scp",
+	2: "envelope_type_tx",
+	3: "envelope_type_auth",
+	4: "envelope_type_scpvalue",
+	5: "envelope_type_tx_fee_bump",
+	6: "envelope_type_op_id",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e EnvelopeType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := envelopeTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *EnvelopeType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, envelopeTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = EnvelopeType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s EnvelopeType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4464,6 +10627,38 @@ func (e UpgradeType) XDRMaxSize() int {
 	return 128
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s UpgradeType) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *UpgradeType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, 128)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s UpgradeType) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *UpgradeType) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, 128)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s UpgradeType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4515,6 +10710,53 @@ func (e StellarValueType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e StellarValueType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *StellarValueType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = StellarValueType(v)
+	return n, nil
+}
+
+var stellarValueTypeJSONNames = map[int32]string{
+	0: "stellar_value_basic",
+	1: "stellar_value_signed",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e StellarValueType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := stellarValueTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *StellarValueType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, stellarValueTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = StellarValueType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s StellarValueType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4546,6 +10788,66 @@ type LedgerCloseValueSignature struct {
 	Signature Signature
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerCloseValueSignature) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.NodeId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Signature.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerCloseValueSignature) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.NodeId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Signature.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerCloseValueSignature) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("node_id")
+	if err = s.NodeId.encodeJSON(enc); err != nil {
+		return jsonFieldError("node_id", err)
+	}
+	enc.key("signature")
+	if err = s.Signature.encodeJSON(enc); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerCloseValueSignature) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "node_id", "signature")
+	if err != nil {
+		return err
+	}
+	if err = s.NodeId.decodeJSON(fields["node_id"]); err != nil {
+		return jsonFieldError("node_id", err)
+	}
+	if err = s.Signature.decodeJSON(fields["signature"]); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerCloseValueSignature) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4639,6 +10941,112 @@ func (u StellarValueExt) GetLcValueSignature() (result LedgerCloseValueSignature
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u StellarValueExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.V.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch StellarValueType(u.V) {
+	case StellarValueTypeStellarValueBasic:
+		// Void
+		return nil
+	case StellarValueTypeStellarValueSigned:
+		if u.LcValueSignature == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.LcValueSignature.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *StellarValueExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = StellarValueExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.V.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.V = StellarValueType(sw)
+	switch StellarValueType(u.V) {
+	case StellarValueTypeStellarValueBasic:
+		// Void
+		return n, nil
+	case StellarValueTypeStellarValueSigned:
+		u.LcValueSignature = new(LedgerCloseValueSignature)
+		nTmp, err = u.LcValueSignature.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u StellarValueExt) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	if err = u.V.encodeJSON(enc); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch StellarValueType(u.V) {
+	case StellarValueTypeStellarValueBasic:
+		// Void
+	case StellarValueTypeStellarValueSigned:
+		if u.LcValueSignature == nil {
+			return jsonFieldError("lc_value_signature", errEncodeNilUnionValue())
+		}
+		enc.key("lc_value_signature")
+		if err = u.LcValueSignature.encodeJSON(enc); err != nil {
+			return jsonFieldError("lc_value_signature", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *StellarValueExt) decodeJSON(data []byte) error {
+	*u = StellarValueExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if err = u.V.decodeJSON(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch StellarValueType(u.V) {
+	case StellarValueTypeStellarValueBasic:
+		// Void
+		return checkJSONKeys(fields, "v")
+	case StellarValueTypeStellarValueSigned:
+		if err = checkJSONKeys(fields, "v", "lc_value_signature"); err != nil {
+			return err
+		}
+		u.LcValueSignature = new(LedgerCloseValueSignature)
+		if err = u.LcValueSignature.decodeJSON(fields["lc_value_signature"]); err != nil {
+			return jsonFieldError("lc_value_signature", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s StellarValueExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4689,6 +11097,132 @@ type StellarValue struct {
 	Ext       StellarValueExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s StellarValue) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.TxSetHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.CloseTime.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Upgrades))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Upgrades); i++ {
+		if err = s.Upgrades[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *StellarValue) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.TxSetHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.CloseTime.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 6 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Upgrades = nil
+	if l > 0 {
+		s.Upgrades = make([]UpgradeType, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Upgrades = append(s.Upgrades, nil)
+			nTmp, err = s.Upgrades[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s StellarValue) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx_set_hash")
+	if err = s.TxSetHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_set_hash", err)
+	}
+	enc.key("close_time")
+	if err = s.CloseTime.encodeJSON(enc); err != nil {
+		return jsonFieldError("close_time", err)
+	}
+	enc.key("upgrades")
+	enc.beginArray()
+	for i := 0; i < len(s.Upgrades); i++ {
+		enc.element()
+		if err = s.Upgrades[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("upgrades", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *StellarValue) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx_set_hash", "close_time", "upgrades", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.TxSetHash.decodeJSON(fields["tx_set_hash"]); err != nil {
+		return jsonFieldError("tx_set_hash", err)
+	}
+	if err = s.CloseTime.decodeJSON(fields["close_time"]); err != nil {
+		return jsonFieldError("close_time", err)
+	}
+	if elems, err = decodeJSONArray(fields["upgrades"], 6); err != nil {
+		return jsonFieldError("upgrades", err)
+	}
+	s.Upgrades = nil
+	if len(elems) > 0 {
+		s.Upgrades = make([]UpgradeType, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Upgrades[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("upgrades", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s StellarValue) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4745,6 +11279,73 @@ func NewLedgerHeaderExt(v int32, value interface{}) (result LedgerHeaderExt, err
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerHeaderExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerHeaderExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerHeaderExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerHeaderExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerHeaderExt) decodeJSON(data []byte) error {
+	*u = LedgerHeaderExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerHeaderExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4821,6 +11422,276 @@ type LedgerHeader struct {
 	Ext                LedgerHeaderExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerHeader) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerVersion.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.PreviousLedgerHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.ScpValue.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TxSetResultHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BucketListHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.LedgerSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TotalCoins.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.FeePool.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.InflationSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.IdPool.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BaseFee.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BaseReserve.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.MaxTxSetSize.EncodeTo(enc); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.SkipList); i++ {
+		if err = s.SkipList[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerHeader) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerVersion.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.PreviousLedgerHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.ScpValue.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TxSetResultHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BucketListHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.LedgerSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TotalCoins.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.FeePool.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.InflationSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.IdPool.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BaseFee.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BaseReserve.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.MaxTxSetSize.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	for i := 0; i < len(s.SkipList); i++ {
+		nTmp, err = s.SkipList[i].DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerHeader) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_version")
+	if err = s.LedgerVersion.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	enc.key("previous_ledger_hash")
+	if err = s.PreviousLedgerHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("previous_ledger_hash", err)
+	}
+	enc.key("scp_value")
+	if err = s.ScpValue.encodeJSON(enc); err != nil {
+		return jsonFieldError("scp_value", err)
+	}
+	enc.key("tx_set_result_hash")
+	if err = s.TxSetResultHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_set_result_hash", err)
+	}
+	enc.key("bucket_list_hash")
+	if err = s.BucketListHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("bucket_list_hash", err)
+	}
+	enc.key("ledger_seq")
+	if err = s.LedgerSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	enc.key("total_coins")
+	if err = s.TotalCoins.encodeJSON(enc); err != nil {
+		return jsonFieldError("total_coins", err)
+	}
+	enc.key("fee_pool")
+	if err = s.FeePool.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee_pool", err)
+	}
+	enc.key("inflation_seq")
+	if err = s.InflationSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("inflation_seq", err)
+	}
+	enc.key("id_pool")
+	if err = s.IdPool.encodeJSON(enc); err != nil {
+		return jsonFieldError("id_pool", err)
+	}
+	enc.key("base_fee")
+	if err = s.BaseFee.encodeJSON(enc); err != nil {
+		return jsonFieldError("base_fee", err)
+	}
+	enc.key("base_reserve")
+	if err = s.BaseReserve.encodeJSON(enc); err != nil {
+		return jsonFieldError("base_reserve", err)
+	}
+	enc.key("max_tx_set_size")
+	if err = s.MaxTxSetSize.encodeJSON(enc); err != nil {
+		return jsonFieldError("max_tx_set_size", err)
+	}
+	enc.key("skip_list")
+	enc.beginArray()
+	for i := 0; i < len(s.SkipList); i++ {
+		enc.element()
+		if err = s.SkipList[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("skip_list", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerHeader) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "ledger_version", "previous_ledger_hash", "scp_value", "tx_set_result_hash", "bucket_list_hash", "ledger_seq", "total_coins", "fee_pool", "inflation_seq", "id_pool", "base_fee", "base_reserve", "max_tx_set_size", "skip_list", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerVersion.decodeJSON(fields["ledger_version"]); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	if err = s.PreviousLedgerHash.decodeJSON(fields["previous_ledger_hash"]); err != nil {
+		return jsonFieldError("previous_ledger_hash", err)
+	}
+	if err = s.ScpValue.decodeJSON(fields["scp_value"]); err != nil {
+		return jsonFieldError("scp_value", err)
+	}
+	if err = s.TxSetResultHash.decodeJSON(fields["tx_set_result_hash"]); err != nil {
+		return jsonFieldError("tx_set_result_hash", err)
+	}
+	if err = s.BucketListHash.decodeJSON(fields["bucket_list_hash"]); err != nil {
+		return jsonFieldError("bucket_list_hash", err)
+	}
+	if err = s.LedgerSeq.decodeJSON(fields["ledger_seq"]); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	if err = s.TotalCoins.decodeJSON(fields["total_coins"]); err != nil {
+		return jsonFieldError("total_coins", err)
+	}
+	if err = s.FeePool.decodeJSON(fields["fee_pool"]); err != nil {
+		return jsonFieldError("fee_pool", err)
+	}
+	if err = s.InflationSeq.decodeJSON(fields["inflation_seq"]); err != nil {
+		return jsonFieldError("inflation_seq", err)
+	}
+	if err = s.IdPool.decodeJSON(fields["id_pool"]); err != nil {
+		return jsonFieldError("id_pool", err)
+	}
+	if err = s.BaseFee.decodeJSON(fields["base_fee"]); err != nil {
+		return jsonFieldError("base_fee", err)
+	}
+	if err = s.BaseReserve.decodeJSON(fields["base_reserve"]); err != nil {
+		return jsonFieldError("base_reserve", err)
+	}
+	if err = s.MaxTxSetSize.decodeJSON(fields["max_tx_set_size"]); err != nil {
+		return jsonFieldError("max_tx_set_size", err)
+	}
+	if elems, err = decodeJSONFixedArray(fields["skip_list"], len(s.SkipList)); err != nil {
+		return jsonFieldError("skip_list", err)
+	}
+	for i, elem := range elems {
+		if err = s.SkipList[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("skip_list", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerHeader) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -4878,21 +11749,70 @@ func (e LedgerUpgradeType) String() string {
 	return name
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s LedgerUpgradeType) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (e LedgerUpgradeType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *LedgerUpgradeType) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// DecodeFrom decodes this value using the Decoder.
+func (e *LedgerUpgradeType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = LedgerUpgradeType(v)
+	return n, nil
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*LedgerUpgradeType)(nil)
+var ledgerUpgradeTypeJSONNames = map[int32]string{
+	1: "ledger_upgrade_version",
+	2: "ledger_upgrade_base_fee",
+	3: "ledger_upgrade_max_tx_set_size",
+	4: "ledger_upgrade_base_reserve",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e LedgerUpgradeType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := ledgerUpgradeTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *LedgerUpgradeType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, ledgerUpgradeTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = LedgerUpgradeType(v)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s LedgerUpgradeType) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *LedgerUpgradeType) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*LedgerUpgradeType)(nil)
 	_ encoding.BinaryUnmarshaler = (*LedgerUpgradeType)(nil)
 )
 
@@ -5076,6 +11996,200 @@ func (u LedgerUpgrade) GetNewBaseReserve() (result Uint32, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerUpgrade) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch LedgerUpgradeType(u.Type) {
+	case LedgerUpgradeTypeLedgerUpgradeVersion:
+		if u.NewLedgerVersion == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NewLedgerVersion.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseFee:
+		if u.NewBaseFee == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NewBaseFee.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize:
+		if u.NewMaxTxSetSize == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NewMaxTxSetSize.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseReserve:
+		if u.NewBaseReserve == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NewBaseReserve.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerUpgrade) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerUpgrade{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = LedgerUpgradeType(sw)
+	switch LedgerUpgradeType(u.Type) {
+	case LedgerUpgradeTypeLedgerUpgradeVersion:
+		u.NewLedgerVersion = new(Uint32)
+		nTmp, err = u.NewLedgerVersion.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseFee:
+		u.NewBaseFee = new(Uint32)
+		nTmp, err = u.NewBaseFee.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize:
+		u.NewMaxTxSetSize = new(Uint32)
+		nTmp, err = u.NewMaxTxSetSize.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseReserve:
+		u.NewBaseReserve = new(Uint32)
+		nTmp, err = u.NewBaseReserve.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerUpgrade) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerUpgradeType(u.Type) {
+	case LedgerUpgradeTypeLedgerUpgradeVersion:
+		if u.NewLedgerVersion == nil {
+			return jsonFieldError("new_ledger_version", errEncodeNilUnionValue())
+		}
+		enc.key("new_ledger_version")
+		if err = u.NewLedgerVersion.encodeJSON(enc); err != nil {
+			return jsonFieldError("new_ledger_version", err)
+		}
+	case LedgerUpgradeTypeLedgerUpgradeBaseFee:
+		if u.NewBaseFee == nil {
+			return jsonFieldError("new_base_fee", errEncodeNilUnionValue())
+		}
+		enc.key("new_base_fee")
+		if err = u.NewBaseFee.encodeJSON(enc); err != nil {
+			return jsonFieldError("new_base_fee", err)
+		}
+	case LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize:
+		if u.NewMaxTxSetSize == nil {
+			return jsonFieldError("new_max_tx_set_size", errEncodeNilUnionValue())
+		}
+		enc.key("new_max_tx_set_size")
+		if err = u.NewMaxTxSetSize.encodeJSON(enc); err != nil {
+			return jsonFieldError("new_max_tx_set_size", err)
+		}
+	case LedgerUpgradeTypeLedgerUpgradeBaseReserve:
+		if u.NewBaseReserve == nil {
+			return jsonFieldError("new_base_reserve", errEncodeNilUnionValue())
+		}
+		enc.key("new_base_reserve")
+		if err = u.NewBaseReserve.encodeJSON(enc); err != nil {
+			return jsonFieldError("new_base_reserve", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerUpgrade) decodeJSON(data []byte) error {
+	*u = LedgerUpgrade{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerUpgradeType(u.Type) {
+	case LedgerUpgradeTypeLedgerUpgradeVersion:
+		if err = checkJSONKeys(fields, "type", "new_ledger_version"); err != nil {
+			return err
+		}
+		u.NewLedgerVersion = new(Uint32)
+		if err = u.NewLedgerVersion.decodeJSON(fields["new_ledger_version"]); err != nil {
+			return jsonFieldError("new_ledger_version", err)
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseFee:
+		if err = checkJSONKeys(fields, "type", "new_base_fee"); err != nil {
+			return err
+		}
+		u.NewBaseFee = new(Uint32)
+		if err = u.NewBaseFee.decodeJSON(fields["new_base_fee"]); err != nil {
+			return jsonFieldError("new_base_fee", err)
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeMaxTxSetSize:
+		if err = checkJSONKeys(fields, "type", "new_max_tx_set_size"); err != nil {
+			return err
+		}
+		u.NewMaxTxSetSize = new(Uint32)
+		if err = u.NewMaxTxSetSize.decodeJSON(fields["new_max_tx_set_size"]); err != nil {
+			return jsonFieldError("new_max_tx_set_size", err)
+		}
+		return nil
+	case LedgerUpgradeTypeLedgerUpgradeBaseReserve:
+		if err = checkJSONKeys(fields, "type", "new_base_reserve"); err != nil {
+			return err
+		}
+		u.NewBaseReserve = new(Uint32)
+		if err = u.NewBaseReserve.decodeJSON(fields["new_base_reserve"]); err != nil {
+			return jsonFieldError("new_base_reserve", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerUpgrade) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5135,6 +12249,55 @@ func (e BucketEntryType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e BucketEntryType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *BucketEntryType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = BucketEntryType(v)
+	return n, nil
+}
+
+var bucketEntryTypeJSONNames = map[int32]string{
+	-1: "metaentry",
+	0:  "liveentry",
+	1:  "deadentry",
+	2:  "initentry",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e BucketEntryType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := bucketEntryTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *BucketEntryType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, bucketEntryTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = BucketEntryType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BucketEntryType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5191,6 +12354,73 @@ func NewBucketMetadataExt(v int32, value interface{}) (result BucketMetadataExt,
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u BucketMetadataExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *BucketMetadataExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = BucketMetadataExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u BucketMetadataExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *BucketMetadataExt) decodeJSON(data []byte) error {
+	*u = BucketMetadataExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BucketMetadataExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5230,6 +12460,66 @@ type BucketMetadata struct {
 	Ext           BucketMetadataExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s BucketMetadata) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerVersion.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *BucketMetadata) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerVersion.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s BucketMetadata) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_version")
+	if err = s.LedgerVersion.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *BucketMetadata) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ledger_version", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerVersion.decodeJSON(fields["ledger_version"]); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BucketMetadata) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5402,6 +12692,200 @@ func (u BucketEntry) GetMetaEntry() (result BucketMetadata, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u BucketEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch BucketEntryType(u.Type) {
+	case BucketEntryTypeLiveentry:
+		if u.LiveEntry == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.LiveEntry.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case BucketEntryTypeInitentry:
+		if u.LiveEntry == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.LiveEntry.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case BucketEntryTypeDeadentry:
+		if u.DeadEntry == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.DeadEntry.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case BucketEntryTypeMetaentry:
+		if u.MetaEntry == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.MetaEntry.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *BucketEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = BucketEntry{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = BucketEntryType(sw)
+	switch BucketEntryType(u.Type) {
+	case BucketEntryTypeLiveentry:
+		u.LiveEntry = new(LedgerEntry)
+		nTmp, err = u.LiveEntry.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case BucketEntryTypeInitentry:
+		u.LiveEntry = new(LedgerEntry)
+		nTmp, err = u.LiveEntry.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case BucketEntryTypeDeadentry:
+		u.DeadEntry = new(LedgerKey)
+		nTmp, err = u.DeadEntry.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case BucketEntryTypeMetaentry:
+		u.MetaEntry = new(BucketMetadata)
+		nTmp, err = u.MetaEntry.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u BucketEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch BucketEntryType(u.Type) {
+	case BucketEntryTypeLiveentry:
+		if u.LiveEntry == nil {
+			return jsonFieldError("live_entry", errEncodeNilUnionValue())
+		}
+		enc.key("live_entry")
+		if err = u.LiveEntry.encodeJSON(enc); err != nil {
+			return jsonFieldError("live_entry", err)
+		}
+	case BucketEntryTypeInitentry:
+		if u.LiveEntry == nil {
+			return jsonFieldError("live_entry", errEncodeNilUnionValue())
+		}
+		enc.key("live_entry")
+		if err = u.LiveEntry.encodeJSON(enc); err != nil {
+			return jsonFieldError("live_entry", err)
+		}
+	case BucketEntryTypeDeadentry:
+		if u.DeadEntry == nil {
+			return jsonFieldError("dead_entry", errEncodeNilUnionValue())
+		}
+		enc.key("dead_entry")
+		if err = u.DeadEntry.encodeJSON(enc); err != nil {
+			return jsonFieldError("dead_entry", err)
+		}
+	case BucketEntryTypeMetaentry:
+		if u.MetaEntry == nil {
+			return jsonFieldError("meta_entry", errEncodeNilUnionValue())
+		}
+		enc.key("meta_entry")
+		if err = u.MetaEntry.encodeJSON(enc); err != nil {
+			return jsonFieldError("meta_entry", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *BucketEntry) decodeJSON(data []byte) error {
+	*u = BucketEntry{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch BucketEntryType(u.Type) {
+	case BucketEntryTypeLiveentry:
+		if err = checkJSONKeys(fields, "type", "live_entry"); err != nil {
+			return err
+		}
+		u.LiveEntry = new(LedgerEntry)
+		if err = u.LiveEntry.decodeJSON(fields["live_entry"]); err != nil {
+			return jsonFieldError("live_entry", err)
+		}
+		return nil
+	case BucketEntryTypeInitentry:
+		if err = checkJSONKeys(fields, "type", "live_entry"); err != nil {
+			return err
+		}
+		u.LiveEntry = new(LedgerEntry)
+		if err = u.LiveEntry.decodeJSON(fields["live_entry"]); err != nil {
+			return jsonFieldError("live_entry", err)
+		}
+		return nil
+	case BucketEntryTypeDeadentry:
+		if err = checkJSONKeys(fields, "type", "dead_entry"); err != nil {
+			return err
+		}
+		u.DeadEntry = new(LedgerKey)
+		if err = u.DeadEntry.decodeJSON(fields["dead_entry"]); err != nil {
+			return jsonFieldError("dead_entry", err)
+		}
+		return nil
+	case BucketEntryTypeMetaentry:
+		if err = checkJSONKeys(fields, "type", "meta_entry"); err != nil {
+			return err
+		}
+		u.MetaEntry = new(BucketMetadata)
+		if err = u.MetaEntry.decodeJSON(fields["meta_entry"]); err != nil {
+			return jsonFieldError("meta_entry", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BucketEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5433,6 +12917,102 @@ type TransactionSet struct {
 	Txs                []TransactionEnvelope
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionSet) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.PreviousLedgerHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Txs))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Txs); i++ {
+		if err = s.Txs[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionSet) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.PreviousLedgerHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Txs = nil
+	if l > 0 {
+		s.Txs = make([]TransactionEnvelope, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Txs = append(s.Txs, TransactionEnvelope{})
+			nTmp, err = s.Txs[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionSet) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("previous_ledger_hash")
+	if err = s.PreviousLedgerHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("previous_ledger_hash", err)
+	}
+	enc.key("txs")
+	enc.beginArray()
+	for i := 0; i < len(s.Txs); i++ {
+		enc.element()
+		if err = s.Txs[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("txs", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionSet) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "previous_ledger_hash", "txs")
+	if err != nil {
+		return err
+	}
+	if err = s.PreviousLedgerHash.decodeJSON(fields["previous_ledger_hash"]); err != nil {
+		return jsonFieldError("previous_ledger_hash", err)
+	}
+	if elems, err = decodeJSONArray(fields["txs"], 0); err != nil {
+		return jsonFieldError("txs", err)
+	}
+	s.Txs = nil
+	if len(elems) > 0 {
+		s.Txs = make([]TransactionEnvelope, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Txs[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("txs", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionSet) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5464,6 +13044,66 @@ type TransactionResultPair struct {
 	Result          TransactionResult
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionResultPair) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.TransactionHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Result.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionResultPair) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.TransactionHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Result.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionResultPair) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("transaction_hash")
+	if err = s.TransactionHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("transaction_hash", err)
+	}
+	enc.key("result")
+	if err = s.Result.encodeJSON(enc); err != nil {
+		return jsonFieldError("result", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionResultPair) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "transaction_hash", "result")
+	if err != nil {
+		return err
+	}
+	if err = s.TransactionHash.decodeJSON(fields["transaction_hash"]); err != nil {
+		return jsonFieldError("transaction_hash", err)
+	}
+	if err = s.Result.decodeJSON(fields["result"]); err != nil {
+		return jsonFieldError("result", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionResultPair) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5493,16 +13133,97 @@ type TransactionResultSet struct {
 	Results []TransactionResultPair
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s TransactionResultSet) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionResultSet) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s.Results))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Results); i++ {
+		if err = s.Results[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionResultSet) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Results = nil
+	if l > 0 {
+		s.Results = make([]TransactionResultPair, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Results = append(s.Results, TransactionResultPair{})
+			nTmp, err = s.Results[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *TransactionResultSet) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionResultSet) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("results")
+	enc.beginArray()
+	for i := 0; i < len(s.Results); i++ {
+		enc.element()
+		if err = s.Results[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("results", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionResultSet) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "results")
+	if err != nil {
+		return err
+	}
+	if elems, err = decodeJSONArray(fields["results"], 0); err != nil {
+		return jsonFieldError("results", err)
+	}
+	s.Results = nil
+	if len(elems) > 0 {
+		s.Results = make([]TransactionResultPair, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Results[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("results", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionResultSet) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionResultSet) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
 
@@ -5549,6 +13270,73 @@ func NewTransactionHistoryEntryExt(v int32, value interface{}) (result Transacti
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionHistoryEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionHistoryEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionHistoryEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionHistoryEntryExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionHistoryEntryExt) decodeJSON(data []byte) error {
+	*u = TransactionHistoryEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionHistoryEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5589,6 +13377,81 @@ type TransactionHistoryEntry struct {
 	Ext       TransactionHistoryEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionHistoryEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TxSet.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionHistoryEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TxSet.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionHistoryEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_seq")
+	if err = s.LedgerSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	enc.key("tx_set")
+	if err = s.TxSet.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_set", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionHistoryEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ledger_seq", "tx_set", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerSeq.decodeJSON(fields["ledger_seq"]); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	if err = s.TxSet.decodeJSON(fields["tx_set"]); err != nil {
+		return jsonFieldError("tx_set", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionHistoryEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5645,6 +13508,73 @@ func NewTransactionHistoryResultEntryExt(v int32, value interface{}) (result Tra
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionHistoryResultEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionHistoryResultEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionHistoryResultEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionHistoryResultEntryExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionHistoryResultEntryExt) decodeJSON(data []byte) error {
+	*u = TransactionHistoryResultEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionHistoryResultEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5685,6 +13615,81 @@ type TransactionHistoryResultEntry struct {
 	Ext         TransactionHistoryResultEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionHistoryResultEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TxResultSet.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionHistoryResultEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TxResultSet.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionHistoryResultEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_seq")
+	if err = s.LedgerSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	enc.key("tx_result_set")
+	if err = s.TxResultSet.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_result_set", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionHistoryResultEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ledger_seq", "tx_result_set", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerSeq.decodeJSON(fields["ledger_seq"]); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	if err = s.TxResultSet.decodeJSON(fields["tx_result_set"]); err != nil {
+		return jsonFieldError("tx_result_set", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionHistoryResultEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5741,6 +13746,73 @@ func NewLedgerHeaderHistoryEntryExt(v int32, value interface{}) (result LedgerHe
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerHeaderHistoryEntryExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerHeaderHistoryEntryExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerHeaderHistoryEntryExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerHeaderHistoryEntryExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerHeaderHistoryEntryExt) decodeJSON(data []byte) error {
+	*u = LedgerHeaderHistoryEntryExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerHeaderHistoryEntryExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5781,6 +13853,81 @@ type LedgerHeaderHistoryEntry struct {
 	Ext    LedgerHeaderHistoryEntryExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerHeaderHistoryEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Hash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Header.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerHeaderHistoryEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Hash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Header.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerHeaderHistoryEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("hash")
+	if err = s.Hash.encodeJSON(enc); err != nil {
+		return jsonFieldError("hash", err)
+	}
+	enc.key("header")
+	if err = s.Header.encodeJSON(enc); err != nil {
+		return jsonFieldError("header", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerHeaderHistoryEntry) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "hash", "header", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.Hash.decodeJSON(fields["hash"]); err != nil {
+		return jsonFieldError("hash", err)
+	}
+	if err = s.Header.decodeJSON(fields["header"]); err != nil {
+		return jsonFieldError("header", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerHeaderHistoryEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5812,6 +13959,102 @@ type LedgerScpMessages struct {
 	Messages  []ScpEnvelope
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerScpMessages) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerSeq.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Messages))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Messages); i++ {
+		if err = s.Messages[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerScpMessages) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerSeq.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Messages = nil
+	if l > 0 {
+		s.Messages = make([]ScpEnvelope, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Messages = append(s.Messages, ScpEnvelope{})
+			nTmp, err = s.Messages[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerScpMessages) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_seq")
+	if err = s.LedgerSeq.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	enc.key("messages")
+	enc.beginArray()
+	for i := 0; i < len(s.Messages); i++ {
+		enc.element()
+		if err = s.Messages[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("messages", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerScpMessages) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "ledger_seq", "messages")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerSeq.decodeJSON(fields["ledger_seq"]); err != nil {
+		return jsonFieldError("ledger_seq", err)
+	}
+	if elems, err = decodeJSONArray(fields["messages"], 0); err != nil {
+		return jsonFieldError("messages", err)
+	}
+	s.Messages = nil
+	if len(elems) > 0 {
+		s.Messages = make([]ScpEnvelope, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Messages[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("messages", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerScpMessages) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5843,6 +14086,102 @@ type ScpHistoryEntryV0 struct {
 	LedgerMessages LedgerScpMessages
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ScpHistoryEntryV0) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s.QuorumSets))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.QuorumSets); i++ {
+		if err = s.QuorumSets[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.LedgerMessages.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ScpHistoryEntryV0) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.QuorumSets = nil
+	if l > 0 {
+		s.QuorumSets = make([]ScpQuorumSet, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.QuorumSets = append(s.QuorumSets, ScpQuorumSet{})
+			nTmp, err = s.QuorumSets[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.LedgerMessages.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ScpHistoryEntryV0) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("quorum_sets")
+	enc.beginArray()
+	for i := 0; i < len(s.QuorumSets); i++ {
+		enc.element()
+		if err = s.QuorumSets[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("quorum_sets", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ledger_messages")
+	if err = s.LedgerMessages.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_messages", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ScpHistoryEntryV0) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "quorum_sets", "ledger_messages")
+	if err != nil {
+		return err
+	}
+	if elems, err = decodeJSONArray(fields["quorum_sets"], 0); err != nil {
+		return jsonFieldError("quorum_sets", err)
+	}
+	s.QuorumSets = nil
+	if len(elems) > 0 {
+		s.QuorumSets = make([]ScpQuorumSet, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.QuorumSets[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("quorum_sets", jsonIndexError(i, err))
+		}
+	}
+	if err = s.LedgerMessages.decodeJSON(fields["ledger_messages"]); err != nil {
+		return jsonFieldError("ledger_messages", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpHistoryEntryV0) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5930,6 +14269,96 @@ func (u ScpHistoryEntry) GetV0() (result ScpHistoryEntryV0, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ScpHistoryEntry) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ScpHistoryEntry) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ScpHistoryEntry{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		u.V0 = new(ScpHistoryEntryV0)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ScpHistoryEntry) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ScpHistoryEntry) decodeJSON(data []byte) error {
+	*u = ScpHistoryEntry{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		if err = checkJSONKeys(fields, "v", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(ScpHistoryEntryV0)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ScpHistoryEntry) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -5987,6 +14416,55 @@ func (e LedgerEntryChangeType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e LedgerEntryChangeType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *LedgerEntryChangeType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = LedgerEntryChangeType(v)
+	return n, nil
+}
+
+var ledgerEntryChangeTypeJSONNames = map[int32]string{
+	0: "ledger_entry_created",
+	1: "ledger_entry_updated",
+	2: "ledger_entry_removed",
+	3: "ledger_entry_state",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e LedgerEntryChangeType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := ledgerEntryChangeTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *LedgerEntryChangeType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, ledgerEntryChangeTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = LedgerEntryChangeType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryChangeType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6185,16 +14663,210 @@ func (u LedgerEntryChange) GetState() (result LedgerEntry, ok bool) {
 	return
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s LedgerEntryChange) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerEntryChange) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch LedgerEntryChangeType(u.Type) {
+	case LedgerEntryChangeTypeLedgerEntryCreated:
+		if u.Created == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Created.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryUpdated:
+		if u.Updated == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Updated.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryRemoved:
+		if u.Removed == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Removed.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryState:
+		if u.State == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.State.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *LedgerEntryChange) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerEntryChange) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerEntryChange{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = LedgerEntryChangeType(sw)
+	switch LedgerEntryChangeType(u.Type) {
+	case LedgerEntryChangeTypeLedgerEntryCreated:
+		u.Created = new(LedgerEntry)
+		nTmp, err = u.Created.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryChangeTypeLedgerEntryUpdated:
+		u.Updated = new(LedgerEntry)
+		nTmp, err = u.Updated.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryChangeTypeLedgerEntryRemoved:
+		u.Removed = new(LedgerKey)
+		nTmp, err = u.Removed.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case LedgerEntryChangeTypeLedgerEntryState:
+		u.State = new(LedgerEntry)
+		nTmp, err = u.State.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerEntryChange) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryChangeType(u.Type) {
+	case LedgerEntryChangeTypeLedgerEntryCreated:
+		if u.Created == nil {
+			return jsonFieldError("created", errEncodeNilUnionValue())
+		}
+		enc.key("created")
+		if err = u.Created.encodeJSON(enc); err != nil {
+			return jsonFieldError("created", err)
+		}
+	case LedgerEntryChangeTypeLedgerEntryUpdated:
+		if u.Updated == nil {
+			return jsonFieldError("updated", errEncodeNilUnionValue())
+		}
+		enc.key("updated")
+		if err = u.Updated.encodeJSON(enc); err != nil {
+			return jsonFieldError("updated", err)
+		}
+	case LedgerEntryChangeTypeLedgerEntryRemoved:
+		if u.Removed == nil {
+			return jsonFieldError("removed", errEncodeNilUnionValue())
+		}
+		enc.key("removed")
+		if err = u.Removed.encodeJSON(enc); err != nil {
+			return jsonFieldError("removed", err)
+		}
+	case LedgerEntryChangeTypeLedgerEntryState:
+		if u.State == nil {
+			return jsonFieldError("state", errEncodeNilUnionValue())
+		}
+		enc.key("state")
+		if err = u.State.encodeJSON(enc); err != nil {
+			return jsonFieldError("state", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerEntryChange) decodeJSON(data []byte) error {
+	*u = LedgerEntryChange{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch LedgerEntryChangeType(u.Type) {
+	case LedgerEntryChangeTypeLedgerEntryCreated:
+		if err = checkJSONKeys(fields, "type", "created"); err != nil {
+			return err
+		}
+		u.Created = new(LedgerEntry)
+		if err = u.Created.decodeJSON(fields["created"]); err != nil {
+			return jsonFieldError("created", err)
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryUpdated:
+		if err = checkJSONKeys(fields, "type", "updated"); err != nil {
+			return err
+		}
+		u.Updated = new(LedgerEntry)
+		if err = u.Updated.decodeJSON(fields["updated"]); err != nil {
+			return jsonFieldError("updated", err)
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryRemoved:
+		if err = checkJSONKeys(fields, "type", "removed"); err != nil {
+			return err
+		}
+		u.Removed = new(LedgerKey)
+		if err = u.Removed.decodeJSON(fields["removed"]); err != nil {
+			return jsonFieldError("removed", err)
+		}
+		return nil
+	case LedgerEntryChangeTypeLedgerEntryState:
+		if err = checkJSONKeys(fields, "type", "state"); err != nil {
+			return err
+		}
+		u.State = new(LedgerEntry)
+		if err = u.State.decodeJSON(fields["state"]); err != nil {
+			return jsonFieldError("state", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s LedgerEntryChange) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *LedgerEntryChange) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
 
@@ -6209,6 +14881,81 @@ var (
 //
 type LedgerEntryChanges []LedgerEntryChange
 
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerEntryChanges) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s); i++ {
+		if err = s[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerEntryChanges) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	*s = nil
+	if l > 0 {
+		*s = make([]LedgerEntryChange, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			*s = append(*s, LedgerEntryChange{})
+			nTmp, err = (*s)[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerEntryChanges) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginArray()
+	for i := 0; i < len(s); i++ {
+		enc.element()
+		if err = s[i].encodeJSON(enc); err != nil {
+			return jsonIndexError(i, err)
+		}
+	}
+	enc.endArray()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerEntryChanges) decodeJSON(data []byte) error {
+	var elems [][]byte
+	var err error
+	if elems, err = decodeJSONArray(data, 0); err != nil {
+		return err
+	}
+	*s = nil
+	if len(elems) > 0 {
+		*s = make([]LedgerEntryChange, len(elems))
+	}
+	for i, elem := range elems {
+		if err = (*s)[i].decodeJSON(elem); err != nil {
+			return jsonIndexError(i, err)
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerEntryChanges) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6238,6 +14985,51 @@ type OperationMeta struct {
 	Changes LedgerEntryChanges
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s OperationMeta) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Changes.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *OperationMeta) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Changes.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s OperationMeta) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("changes")
+	if err = s.Changes.encodeJSON(enc); err != nil {
+		return jsonFieldError("changes", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *OperationMeta) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "changes")
+	if err != nil {
+		return err
+	}
+	if err = s.Changes.decodeJSON(fields["changes"]); err != nil {
+		return jsonFieldError("changes", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationMeta) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6269,6 +15061,102 @@ type TransactionMetaV1 struct {
 	Operations []OperationMeta
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionMetaV1) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.TxChanges.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Operations))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Operations); i++ {
+		if err = s.Operations[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionMetaV1) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.TxChanges.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Operations = nil
+	if l > 0 {
+		s.Operations = make([]OperationMeta, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Operations = append(s.Operations, OperationMeta{})
+			nTmp, err = s.Operations[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionMetaV1) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx_changes")
+	if err = s.TxChanges.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_changes", err)
+	}
+	enc.key("operations")
+	enc.beginArray()
+	for i := 0; i < len(s.Operations); i++ {
+		enc.element()
+		if err = s.Operations[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionMetaV1) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx_changes", "operations")
+	if err != nil {
+		return err
+	}
+	if err = s.TxChanges.decodeJSON(fields["tx_changes"]); err != nil {
+		return jsonFieldError("tx_changes", err)
+	}
+	if elems, err = decodeJSONArray(fields["operations"], 0); err != nil {
+		return jsonFieldError("operations", err)
+	}
+	s.Operations = nil
+	if len(elems) > 0 {
+		s.Operations = make([]OperationMeta, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Operations[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionMetaV1) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6304,6 +15192,117 @@ type TransactionMetaV2 struct {
 	TxChangesAfter  LedgerEntryChanges
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionMetaV2) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.TxChangesBefore.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Operations))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Operations); i++ {
+		if err = s.Operations[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.TxChangesAfter.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionMetaV2) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.TxChangesBefore.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Operations = nil
+	if l > 0 {
+		s.Operations = make([]OperationMeta, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Operations = append(s.Operations, OperationMeta{})
+			nTmp, err = s.Operations[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.TxChangesAfter.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionMetaV2) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx_changes_before")
+	if err = s.TxChangesBefore.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_changes_before", err)
+	}
+	enc.key("operations")
+	enc.beginArray()
+	for i := 0; i < len(s.Operations); i++ {
+		enc.element()
+		if err = s.Operations[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("tx_changes_after")
+	if err = s.TxChangesAfter.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_changes_after", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionMetaV2) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx_changes_before", "operations", "tx_changes_after")
+	if err != nil {
+		return err
+	}
+	if err = s.TxChangesBefore.decodeJSON(fields["tx_changes_before"]); err != nil {
+		return jsonFieldError("tx_changes_before", err)
+	}
+	if elems, err = decodeJSONArray(fields["operations"], 0); err != nil {
+		return jsonFieldError("operations", err)
+	}
+	s.Operations = nil
+	if len(elems) > 0 {
+		s.Operations = make([]OperationMeta, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Operations[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	if err = s.TxChangesAfter.decodeJSON(fields["tx_changes_after"]); err != nil {
+		return jsonFieldError("tx_changes_after", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionMetaV2) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6465,6 +15464,198 @@ func (u TransactionMeta) GetV2() (result TransactionMetaV2, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionMeta) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		if u.Operations == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Operations))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Operations); i++ {
+			if err = (*u.Operations)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 1:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case 2:
+		if u.V2 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V2.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionMeta) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	*u = TransactionMeta{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		u.Operations = new([]OperationMeta)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Operations = nil
+		if l > 0 {
+			*u.Operations = make([]OperationMeta, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Operations = append(*u.Operations, OperationMeta{})
+				nTmp, err = (*u.Operations)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	case 1:
+		u.V1 = new(TransactionMetaV1)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case 2:
+		u.V2 = new(TransactionMetaV2)
+		nTmp, err = u.V2.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionMeta) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		if u.Operations == nil {
+			return jsonFieldError("operations", errEncodeNilUnionValue())
+		}
+		enc.key("operations")
+		enc.beginArray()
+		for i := 0; i < len(*u.Operations); i++ {
+			enc.element()
+			if err = (*u.Operations)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("operations", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	case 1:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	case 2:
+		if u.V2 == nil {
+			return jsonFieldError("v2", errEncodeNilUnionValue())
+		}
+		enc.key("v2")
+		if err = u.V2.encodeJSON(enc); err != nil {
+			return jsonFieldError("v2", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionMeta) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = TransactionMeta{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		if err = checkJSONKeys(fields, "v", "operations"); err != nil {
+			return err
+		}
+		u.Operations = new([]OperationMeta)
+		if elems, err = decodeJSONArray(fields["operations"], 0); err != nil {
+			return jsonFieldError("operations", err)
+		}
+		*u.Operations = nil
+		if len(elems) > 0 {
+			*u.Operations = make([]OperationMeta, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Operations)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("operations", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case 1:
+		if err = checkJSONKeys(fields, "v", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(TransactionMetaV1)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	case 2:
+		if err = checkJSONKeys(fields, "v", "v2"); err != nil {
+			return err
+		}
+		u.V2 = new(TransactionMetaV2)
+		if err = u.V2.decodeJSON(fields["v2"]); err != nil {
+			return jsonFieldError("v2", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionMeta) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6498,6 +15689,81 @@ type TransactionResultMeta struct {
 	TxApplyProcessing TransactionMeta
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionResultMeta) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Result.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.FeeProcessing.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TxApplyProcessing.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionResultMeta) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Result.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.FeeProcessing.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TxApplyProcessing.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionResultMeta) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("result")
+	if err = s.Result.encodeJSON(enc); err != nil {
+		return jsonFieldError("result", err)
+	}
+	enc.key("fee_processing")
+	if err = s.FeeProcessing.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee_processing", err)
+	}
+	enc.key("tx_apply_processing")
+	if err = s.TxApplyProcessing.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_apply_processing", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionResultMeta) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "result", "fee_processing", "tx_apply_processing")
+	if err != nil {
+		return err
+	}
+	if err = s.Result.decodeJSON(fields["result"]); err != nil {
+		return jsonFieldError("result", err)
+	}
+	if err = s.FeeProcessing.decodeJSON(fields["fee_processing"]); err != nil {
+		return jsonFieldError("fee_processing", err)
+	}
+	if err = s.TxApplyProcessing.decodeJSON(fields["tx_apply_processing"]); err != nil {
+		return jsonFieldError("tx_apply_processing", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionResultMeta) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6529,6 +15795,66 @@ type UpgradeEntryMeta struct {
 	Changes LedgerEntryChanges
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s UpgradeEntryMeta) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Upgrade.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Changes.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *UpgradeEntryMeta) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Upgrade.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Changes.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s UpgradeEntryMeta) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("upgrade")
+	if err = s.Upgrade.encodeJSON(enc); err != nil {
+		return jsonFieldError("upgrade", err)
+	}
+	enc.key("changes")
+	if err = s.Changes.encodeJSON(enc); err != nil {
+		return jsonFieldError("changes", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *UpgradeEntryMeta) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "upgrade", "changes")
+	if err != nil {
+		return err
+	}
+	if err = s.Upgrade.decodeJSON(fields["upgrade"]); err != nil {
+		return jsonFieldError("upgrade", err)
+	}
+	if err = s.Changes.decodeJSON(fields["changes"]); err != nil {
+		return jsonFieldError("changes", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s UpgradeEntryMeta) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6575,22 +15901,231 @@ type LedgerCloseMetaV0 struct {
 	ScpInfo            []ScpHistoryEntry
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s LedgerCloseMetaV0) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
-}
-
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *LedgerCloseMetaV0) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// EncodeTo encodes this value using the Encoder.
+func (s LedgerCloseMetaV0) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerHeader.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TxSet.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.TxProcessing))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.TxProcessing); i++ {
+		if err = s.TxProcessing[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.UpgradesProcessing))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.UpgradesProcessing); i++ {
+		if err = s.UpgradesProcessing[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.ScpInfo))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.ScpInfo); i++ {
+		if err = s.ScpInfo[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *LedgerCloseMetaV0) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerHeader.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TxSet.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.TxProcessing = nil
+	if l > 0 {
+		s.TxProcessing = make([]TransactionResultMeta, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.TxProcessing = append(s.TxProcessing, TransactionResultMeta{})
+			nTmp, err = s.TxProcessing[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.UpgradesProcessing = nil
+	if l > 0 {
+		s.UpgradesProcessing = make([]UpgradeEntryMeta, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.UpgradesProcessing = append(s.UpgradesProcessing, UpgradeEntryMeta{})
+			nTmp, err = s.UpgradesProcessing[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.ScpInfo = nil
+	if l > 0 {
+		s.ScpInfo = make([]ScpHistoryEntry, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.ScpInfo = append(s.ScpInfo, ScpHistoryEntry{})
+			nTmp, err = s.ScpInfo[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*LedgerCloseMetaV0)(nil)
-	_ encoding.BinaryUnmarshaler = (*LedgerCloseMetaV0)(nil)
+// encodeJSON writes the JSON representation of this value to enc.
+func (s LedgerCloseMetaV0) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_header")
+	if err = s.LedgerHeader.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_header", err)
+	}
+	enc.key("tx_set")
+	if err = s.TxSet.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx_set", err)
+	}
+	enc.key("tx_processing")
+	enc.beginArray()
+	for i := 0; i < len(s.TxProcessing); i++ {
+		enc.element()
+		if err = s.TxProcessing[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("tx_processing", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("upgrades_processing")
+	enc.beginArray()
+	for i := 0; i < len(s.UpgradesProcessing); i++ {
+		enc.element()
+		if err = s.UpgradesProcessing[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("upgrades_processing", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("scp_info")
+	enc.beginArray()
+	for i := 0; i < len(s.ScpInfo); i++ {
+		enc.element()
+		if err = s.ScpInfo[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("scp_info", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *LedgerCloseMetaV0) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "ledger_header", "tx_set", "tx_processing", "upgrades_processing", "scp_info")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerHeader.decodeJSON(fields["ledger_header"]); err != nil {
+		return jsonFieldError("ledger_header", err)
+	}
+	if err = s.TxSet.decodeJSON(fields["tx_set"]); err != nil {
+		return jsonFieldError("tx_set", err)
+	}
+	if elems, err = decodeJSONArray(fields["tx_processing"], 0); err != nil {
+		return jsonFieldError("tx_processing", err)
+	}
+	s.TxProcessing = nil
+	if len(elems) > 0 {
+		s.TxProcessing = make([]TransactionResultMeta, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.TxProcessing[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("tx_processing", jsonIndexError(i, err))
+		}
+	}
+	if elems, err = decodeJSONArray(fields["upgrades_processing"], 0); err != nil {
+		return jsonFieldError("upgrades_processing", err)
+	}
+	s.UpgradesProcessing = nil
+	if len(elems) > 0 {
+		s.UpgradesProcessing = make([]UpgradeEntryMeta, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.UpgradesProcessing[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("upgrades_processing", jsonIndexError(i, err))
+		}
+	}
+	if elems, err = decodeJSONArray(fields["scp_info"], 0); err != nil {
+		return jsonFieldError("scp_info", err)
+	}
+	s.ScpInfo = nil
+	if len(elems) > 0 {
+		s.ScpInfo = make([]ScpHistoryEntry, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.ScpInfo[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("scp_info", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s LedgerCloseMetaV0) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *LedgerCloseMetaV0) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*LedgerCloseMetaV0)(nil)
+	_ encoding.BinaryUnmarshaler = (*LedgerCloseMetaV0)(nil)
 )
 
 // LedgerCloseMeta is an XDR Union defines as:
@@ -6662,6 +16197,96 @@ func (u LedgerCloseMeta) GetV0() (result LedgerCloseMetaV0, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u LedgerCloseMeta) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *LedgerCloseMeta) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = LedgerCloseMeta{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		u.V0 = new(LedgerCloseMetaV0)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u LedgerCloseMeta) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *LedgerCloseMeta) decodeJSON(data []byte) error {
+	*u = LedgerCloseMeta{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		if err = checkJSONKeys(fields, "v", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(LedgerCloseMetaV0)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s LedgerCloseMeta) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6722,6 +16347,56 @@ func (e ErrorCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ErrorCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ErrorCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ErrorCode(v)
+	return n, nil
+}
+
+var errorCodeJSONNames = map[int32]string{
+	0: "err_misc",
+	1: "err_data",
+	2: "err_conf",
+	3: "err_auth",
+	4: "err_load",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ErrorCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := errorCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ErrorCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, errorCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ErrorCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ErrorCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6753,6 +16428,64 @@ type Error struct {
 	Msg  string `xdrmaxsize:"100"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Error) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeString(s.Msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Error) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Code.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.Msg, nTmp, err = dec.DecodeString(100)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Error) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = s.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	enc.key("msg")
+	enc.string(s.Msg)
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Error) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "code", "msg")
+	if err != nil {
+		return err
+	}
+	if err = s.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	if s.Msg, err = decodeJSONString(fields["msg"], 100); err != nil {
+		return jsonFieldError("msg", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Error) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6786,6 +16519,81 @@ type AuthCert struct {
 	Sig        Signature
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AuthCert) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Pubkey.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Expiration.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Sig.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AuthCert) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Pubkey.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Expiration.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Sig.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AuthCert) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("pubkey")
+	if err = s.Pubkey.encodeJSON(enc); err != nil {
+		return jsonFieldError("pubkey", err)
+	}
+	enc.key("expiration")
+	if err = s.Expiration.encodeJSON(enc); err != nil {
+		return jsonFieldError("expiration", err)
+	}
+	enc.key("sig")
+	if err = s.Sig.encodeJSON(enc); err != nil {
+		return jsonFieldError("sig", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AuthCert) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "pubkey", "expiration", "sig")
+	if err != nil {
+		return err
+	}
+	if err = s.Pubkey.decodeJSON(fields["pubkey"]); err != nil {
+		return jsonFieldError("pubkey", err)
+	}
+	if err = s.Expiration.decodeJSON(fields["expiration"]); err != nil {
+		return jsonFieldError("expiration", err)
+	}
+	if err = s.Sig.decodeJSON(fields["sig"]); err != nil {
+		return jsonFieldError("sig", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AuthCert) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6831,6 +16639,167 @@ type Hello struct {
 	Nonce             Uint256
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Hello) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.LedgerVersion.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OverlayVersion.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OverlayMinVersion.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NetworkId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeString(s.VersionStr); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeInt(s.ListeningPort); err != nil {
+		return err
+	}
+	if err = s.PeerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Cert.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Nonce.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Hello) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.LedgerVersion.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OverlayVersion.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OverlayMinVersion.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NetworkId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.VersionStr, nTmp, err = dec.DecodeString(100)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.ListeningPort, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.PeerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Cert.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Nonce.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Hello) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ledger_version")
+	if err = s.LedgerVersion.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	enc.key("overlay_version")
+	if err = s.OverlayVersion.encodeJSON(enc); err != nil {
+		return jsonFieldError("overlay_version", err)
+	}
+	enc.key("overlay_min_version")
+	if err = s.OverlayMinVersion.encodeJSON(enc); err != nil {
+		return jsonFieldError("overlay_min_version", err)
+	}
+	enc.key("network_id")
+	if err = s.NetworkId.encodeJSON(enc); err != nil {
+		return jsonFieldError("network_id", err)
+	}
+	enc.key("version_str")
+	enc.string(s.VersionStr)
+	enc.key("listening_port")
+	enc.int32(s.ListeningPort)
+	enc.key("peer_id")
+	if err = s.PeerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("peer_id", err)
+	}
+	enc.key("cert")
+	if err = s.Cert.encodeJSON(enc); err != nil {
+		return jsonFieldError("cert", err)
+	}
+	enc.key("nonce")
+	if err = s.Nonce.encodeJSON(enc); err != nil {
+		return jsonFieldError("nonce", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Hello) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ledger_version", "overlay_version", "overlay_min_version", "network_id", "version_str", "listening_port", "peer_id", "cert", "nonce")
+	if err != nil {
+		return err
+	}
+	if err = s.LedgerVersion.decodeJSON(fields["ledger_version"]); err != nil {
+		return jsonFieldError("ledger_version", err)
+	}
+	if err = s.OverlayVersion.decodeJSON(fields["overlay_version"]); err != nil {
+		return jsonFieldError("overlay_version", err)
+	}
+	if err = s.OverlayMinVersion.decodeJSON(fields["overlay_min_version"]); err != nil {
+		return jsonFieldError("overlay_min_version", err)
+	}
+	if err = s.NetworkId.decodeJSON(fields["network_id"]); err != nil {
+		return jsonFieldError("network_id", err)
+	}
+	if s.VersionStr, err = decodeJSONString(fields["version_str"], 100); err != nil {
+		return jsonFieldError("version_str", err)
+	}
+	if s.ListeningPort, err = decodeJSONInt32(fields["listening_port"]); err != nil {
+		return jsonFieldError("listening_port", err)
+	}
+	if err = s.PeerId.decodeJSON(fields["peer_id"]); err != nil {
+		return jsonFieldError("peer_id", err)
+	}
+	if err = s.Cert.decodeJSON(fields["cert"]); err != nil {
+		return jsonFieldError("cert", err)
+	}
+	if err = s.Nonce.decodeJSON(fields["nonce"]); err != nil {
+		return jsonFieldError("nonce", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Hello) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6862,6 +16831,48 @@ type Auth struct {
 	Unused int32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Auth) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(s.Unused); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Auth) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	s.Unused, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Auth) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("unused")
+	enc.int32(s.Unused)
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Auth) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "unused")
+	if err != nil {
+		return err
+	}
+	if s.Unused, err = decodeJSONInt32(fields["unused"]); err != nil {
+		return jsonFieldError("unused", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Auth) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -6913,6 +16924,53 @@ func (e IpAddrType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e IpAddrType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *IpAddrType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = IpAddrType(v)
+	return n, nil
+}
+
+var ipAddrTypeJSONNames = map[int32]string{
+	0: "ipv4",
+	1: "ipv6",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e IpAddrType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := ipAddrTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *IpAddrType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, ipAddrTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = IpAddrType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s IpAddrType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7037,6 +17095,130 @@ func (u PeerAddressIp) GetIpv6() (result [16]byte, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u PeerAddressIp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch IpAddrType(u.Type) {
+	case IpAddrTypeIPv4:
+		if u.Ipv4 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeFixedOpaque((*u.Ipv4)[:]); err != nil {
+			return err
+		}
+		return nil
+	case IpAddrTypeIPv6:
+		if u.Ipv6 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeFixedOpaque((*u.Ipv6)[:]); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *PeerAddressIp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = PeerAddressIp{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = IpAddrType(sw)
+	switch IpAddrType(u.Type) {
+	case IpAddrTypeIPv4:
+		u.Ipv4 = new([4]byte)
+		nTmp, err = decodeFixedOpaque(dec, (*u.Ipv4)[:])
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case IpAddrTypeIPv6:
+		u.Ipv6 = new([16]byte)
+		nTmp, err = decodeFixedOpaque(dec, (*u.Ipv6)[:])
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u PeerAddressIp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch IpAddrType(u.Type) {
+	case IpAddrTypeIPv4:
+		if u.Ipv4 == nil {
+			return jsonFieldError("ipv4", errEncodeNilUnionValue())
+		}
+		enc.key("ipv4")
+		enc.opaque((*u.Ipv4)[:])
+	case IpAddrTypeIPv6:
+		if u.Ipv6 == nil {
+			return jsonFieldError("ipv6", errEncodeNilUnionValue())
+		}
+		enc.key("ipv6")
+		enc.opaque((*u.Ipv6)[:])
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *PeerAddressIp) decodeJSON(data []byte) error {
+	*u = PeerAddressIp{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch IpAddrType(u.Type) {
+	case IpAddrTypeIPv4:
+		if err = checkJSONKeys(fields, "type", "ipv4"); err != nil {
+			return err
+		}
+		u.Ipv4 = new([4]byte)
+		if err = decodeJSONFixedOpaque(fields["ipv4"], (*u.Ipv4)[:]); err != nil {
+			return jsonFieldError("ipv4", err)
+		}
+		return nil
+	case IpAddrTypeIPv6:
+		if err = checkJSONKeys(fields, "type", "ipv6"); err != nil {
+			return err
+		}
+		u.Ipv6 = new([16]byte)
+		if err = decodeJSONFixedOpaque(fields["ipv6"], (*u.Ipv6)[:]); err != nil {
+			return jsonFieldError("ipv6", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PeerAddressIp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7077,24 +17259,99 @@ type PeerAddress struct {
 	NumFailures Uint32
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s PeerAddress) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (s PeerAddress) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Ip.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Port.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.NumFailures.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *PeerAddress) UnmarshalBinary(inp []byte) error {
-	_, err := Unmarshal(bytes.NewReader(inp), s)
-	return err
+// DecodeFrom decodes this value using the Decoder.
+func (s *PeerAddress) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Ip.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Port.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.NumFailures.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
 }
 
-var (
-	_ encoding.BinaryMarshaler   = (*PeerAddress)(nil)
-	_ encoding.BinaryUnmarshaler = (*PeerAddress)(nil)
-)
-
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PeerAddress) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("ip")
+	if err = s.Ip.encodeJSON(enc); err != nil {
+		return jsonFieldError("ip", err)
+	}
+	enc.key("port")
+	if err = s.Port.encodeJSON(enc); err != nil {
+		return jsonFieldError("port", err)
+	}
+	enc.key("num_failures")
+	if err = s.NumFailures.encodeJSON(enc); err != nil {
+		return jsonFieldError("num_failures", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PeerAddress) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "ip", "port", "num_failures")
+	if err != nil {
+		return err
+	}
+	if err = s.Ip.decodeJSON(fields["ip"]); err != nil {
+		return jsonFieldError("ip", err)
+	}
+	if err = s.Port.decodeJSON(fields["port"]); err != nil {
+		return jsonFieldError("port", err)
+	}
+	if err = s.NumFailures.decodeJSON(fields["num_failures"]); err != nil {
+		return jsonFieldError("num_failures", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s PeerAddress) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *PeerAddress) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*PeerAddress)(nil)
+	_ encoding.BinaryUnmarshaler = (*PeerAddress)(nil)
+)
+
 // MessageType is an XDR Enum defines as:
 //
 //   enum MessageType
@@ -7175,6 +17432,66 @@ func (e MessageType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e MessageType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *MessageType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = MessageType(v)
+	return n, nil
+}
+
+var messageTypeJSONNames = map[int32]string{
+	0:  "error_msg",
+	2:  "auth",
+	3:  "dont_have",
+	4:  "get_peers",
+	5:  "peers",
+	6:  "get_tx_set",
+	7:  "tx_set",
+	8:  "transaction",
+	9:  "get_scp_quorumset",
+	10: "scp_quorumset",
+	11: "scp_message",
+	12: "get_scp_state",
+	13: "hello",
+	14: "survey_request",
+	15: "survey_response",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e MessageType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := messageTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *MessageType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, messageTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = MessageType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s MessageType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7206,6 +17523,66 @@ type DontHave struct {
 	ReqHash Uint256
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s DontHave) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.ReqHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *DontHave) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Type.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.ReqHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s DontHave) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = s.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	enc.key("req_hash")
+	if err = s.ReqHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("req_hash", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *DontHave) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "type", "req_hash")
+	if err != nil {
+		return err
+	}
+	if err = s.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	if err = s.ReqHash.decodeJSON(fields["req_hash"]); err != nil {
+		return jsonFieldError("req_hash", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s DontHave) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7254,6 +17631,52 @@ func (e SurveyMessageCommandType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e SurveyMessageCommandType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *SurveyMessageCommandType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = SurveyMessageCommandType(v)
+	return n, nil
+}
+
+var surveyMessageCommandTypeJSONNames = map[int32]string{
+	0: "survey_topology",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e SurveyMessageCommandType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := surveyMessageCommandTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *SurveyMessageCommandType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, surveyMessageCommandTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = SurveyMessageCommandType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SurveyMessageCommandType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7291,6 +17714,111 @@ type SurveyRequestMessage struct {
 	CommandType    SurveyMessageCommandType
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SurveyRequestMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SurveyorPeerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SurveyedPeerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.LedgerNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.EncryptionKey.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.CommandType.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SurveyRequestMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SurveyorPeerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SurveyedPeerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.LedgerNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.EncryptionKey.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.CommandType.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SurveyRequestMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("surveyor_peer_id")
+	if err = s.SurveyorPeerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("surveyor_peer_id", err)
+	}
+	enc.key("surveyed_peer_id")
+	if err = s.SurveyedPeerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("surveyed_peer_id", err)
+	}
+	enc.key("ledger_num")
+	if err = s.LedgerNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_num", err)
+	}
+	enc.key("encryption_key")
+	if err = s.EncryptionKey.encodeJSON(enc); err != nil {
+		return jsonFieldError("encryption_key", err)
+	}
+	enc.key("command_type")
+	if err = s.CommandType.encodeJSON(enc); err != nil {
+		return jsonFieldError("command_type", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SurveyRequestMessage) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "surveyor_peer_id", "surveyed_peer_id", "ledger_num", "encryption_key", "command_type")
+	if err != nil {
+		return err
+	}
+	if err = s.SurveyorPeerId.decodeJSON(fields["surveyor_peer_id"]); err != nil {
+		return jsonFieldError("surveyor_peer_id", err)
+	}
+	if err = s.SurveyedPeerId.decodeJSON(fields["surveyed_peer_id"]); err != nil {
+		return jsonFieldError("surveyed_peer_id", err)
+	}
+	if err = s.LedgerNum.decodeJSON(fields["ledger_num"]); err != nil {
+		return jsonFieldError("ledger_num", err)
+	}
+	if err = s.EncryptionKey.decodeJSON(fields["encryption_key"]); err != nil {
+		return jsonFieldError("encryption_key", err)
+	}
+	if err = s.CommandType.decodeJSON(fields["command_type"]); err != nil {
+		return jsonFieldError("command_type", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SurveyRequestMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7322,6 +17850,66 @@ type SignedSurveyRequestMessage struct {
 	Request          SurveyRequestMessage
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SignedSurveyRequestMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.RequestSignature.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Request.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SignedSurveyRequestMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.RequestSignature.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Request.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SignedSurveyRequestMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("request_signature")
+	if err = s.RequestSignature.encodeJSON(enc); err != nil {
+		return jsonFieldError("request_signature", err)
+	}
+	enc.key("request")
+	if err = s.Request.encodeJSON(enc); err != nil {
+		return jsonFieldError("request", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SignedSurveyRequestMessage) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "request_signature", "request")
+	if err != nil {
+		return err
+	}
+	if err = s.RequestSignature.decodeJSON(fields["request_signature"]); err != nil {
+		return jsonFieldError("request_signature", err)
+	}
+	if err = s.Request.decodeJSON(fields["request"]); err != nil {
+		return jsonFieldError("request", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SignedSurveyRequestMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7351,6 +17939,38 @@ func (e EncryptedBody) XDRMaxSize() int {
 	return 64000
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s EncryptedBody) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *EncryptedBody) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, 64000)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s EncryptedBody) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *EncryptedBody) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, 64000)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s EncryptedBody) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7388,6 +18008,111 @@ type SurveyResponseMessage struct {
 	EncryptedBody  EncryptedBody
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SurveyResponseMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SurveyorPeerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SurveyedPeerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.LedgerNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.CommandType.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.EncryptedBody.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SurveyResponseMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SurveyorPeerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SurveyedPeerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.LedgerNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.CommandType.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.EncryptedBody.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SurveyResponseMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("surveyor_peer_id")
+	if err = s.SurveyorPeerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("surveyor_peer_id", err)
+	}
+	enc.key("surveyed_peer_id")
+	if err = s.SurveyedPeerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("surveyed_peer_id", err)
+	}
+	enc.key("ledger_num")
+	if err = s.LedgerNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("ledger_num", err)
+	}
+	enc.key("command_type")
+	if err = s.CommandType.encodeJSON(enc); err != nil {
+		return jsonFieldError("command_type", err)
+	}
+	enc.key("encrypted_body")
+	if err = s.EncryptedBody.encodeJSON(enc); err != nil {
+		return jsonFieldError("encrypted_body", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SurveyResponseMessage) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "surveyor_peer_id", "surveyed_peer_id", "ledger_num", "command_type", "encrypted_body")
+	if err != nil {
+		return err
+	}
+	if err = s.SurveyorPeerId.decodeJSON(fields["surveyor_peer_id"]); err != nil {
+		return jsonFieldError("surveyor_peer_id", err)
+	}
+	if err = s.SurveyedPeerId.decodeJSON(fields["surveyed_peer_id"]); err != nil {
+		return jsonFieldError("surveyed_peer_id", err)
+	}
+	if err = s.LedgerNum.decodeJSON(fields["ledger_num"]); err != nil {
+		return jsonFieldError("ledger_num", err)
+	}
+	if err = s.CommandType.decodeJSON(fields["command_type"]); err != nil {
+		return jsonFieldError("command_type", err)
+	}
+	if err = s.EncryptedBody.decodeJSON(fields["encrypted_body"]); err != nil {
+		return jsonFieldError("encrypted_body", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SurveyResponseMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7419,6 +18144,66 @@ type SignedSurveyResponseMessage struct {
 	Response          SurveyResponseMessage
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SignedSurveyResponseMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.ResponseSignature.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Response.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SignedSurveyResponseMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.ResponseSignature.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Response.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SignedSurveyResponseMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("response_signature")
+	if err = s.ResponseSignature.encodeJSON(enc); err != nil {
+		return jsonFieldError("response_signature", err)
+	}
+	enc.key("response")
+	if err = s.Response.encodeJSON(enc); err != nil {
+		return jsonFieldError("response", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SignedSurveyResponseMessage) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "response_signature", "response")
+	if err != nil {
+		return err
+	}
+	if err = s.ResponseSignature.decodeJSON(fields["response_signature"]); err != nil {
+		return jsonFieldError("response_signature", err)
+	}
+	if err = s.Response.decodeJSON(fields["response"]); err != nil {
+		return jsonFieldError("response", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SignedSurveyResponseMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7478,6 +18263,259 @@ type PeerStats struct {
 	DuplicateFetchMessageRecv Uint64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PeerStats) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Id.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeString(s.VersionStr); err != nil {
+		return err
+	}
+	if err = s.MessagesRead.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.MessagesWritten.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BytesRead.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BytesWritten.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SecondsConnected.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.UniqueFloodBytesRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DuplicateFloodBytesRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.UniqueFetchBytesRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DuplicateFetchBytesRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.UniqueFloodMessageRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DuplicateFloodMessageRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.UniqueFetchMessageRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DuplicateFetchMessageRecv.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PeerStats) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Id.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.VersionStr, nTmp, err = dec.DecodeString(100)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.MessagesRead.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.MessagesWritten.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BytesRead.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BytesWritten.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SecondsConnected.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.UniqueFloodBytesRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DuplicateFloodBytesRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.UniqueFetchBytesRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DuplicateFetchBytesRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.UniqueFloodMessageRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DuplicateFloodMessageRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.UniqueFetchMessageRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DuplicateFetchMessageRecv.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PeerStats) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("id")
+	if err = s.Id.encodeJSON(enc); err != nil {
+		return jsonFieldError("id", err)
+	}
+	enc.key("version_str")
+	enc.string(s.VersionStr)
+	enc.key("messages_read")
+	if err = s.MessagesRead.encodeJSON(enc); err != nil {
+		return jsonFieldError("messages_read", err)
+	}
+	enc.key("messages_written")
+	if err = s.MessagesWritten.encodeJSON(enc); err != nil {
+		return jsonFieldError("messages_written", err)
+	}
+	enc.key("bytes_read")
+	if err = s.BytesRead.encodeJSON(enc); err != nil {
+		return jsonFieldError("bytes_read", err)
+	}
+	enc.key("bytes_written")
+	if err = s.BytesWritten.encodeJSON(enc); err != nil {
+		return jsonFieldError("bytes_written", err)
+	}
+	enc.key("seconds_connected")
+	if err = s.SecondsConnected.encodeJSON(enc); err != nil {
+		return jsonFieldError("seconds_connected", err)
+	}
+	enc.key("unique_flood_bytes_recv")
+	if err = s.UniqueFloodBytesRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("unique_flood_bytes_recv", err)
+	}
+	enc.key("duplicate_flood_bytes_recv")
+	if err = s.DuplicateFloodBytesRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("duplicate_flood_bytes_recv", err)
+	}
+	enc.key("unique_fetch_bytes_recv")
+	if err = s.UniqueFetchBytesRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("unique_fetch_bytes_recv", err)
+	}
+	enc.key("duplicate_fetch_bytes_recv")
+	if err = s.DuplicateFetchBytesRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("duplicate_fetch_bytes_recv", err)
+	}
+	enc.key("unique_flood_message_recv")
+	if err = s.UniqueFloodMessageRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("unique_flood_message_recv", err)
+	}
+	enc.key("duplicate_flood_message_recv")
+	if err = s.DuplicateFloodMessageRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("duplicate_flood_message_recv", err)
+	}
+	enc.key("unique_fetch_message_recv")
+	if err = s.UniqueFetchMessageRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("unique_fetch_message_recv", err)
+	}
+	enc.key("duplicate_fetch_message_recv")
+	if err = s.DuplicateFetchMessageRecv.encodeJSON(enc); err != nil {
+		return jsonFieldError("duplicate_fetch_message_recv", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PeerStats) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "id", "version_str", "messages_read", "messages_written", "bytes_read", "bytes_written", "seconds_connected", "unique_flood_bytes_recv", "duplicate_flood_bytes_recv", "unique_fetch_bytes_recv", "duplicate_fetch_bytes_recv", "unique_flood_message_recv", "duplicate_flood_message_recv", "unique_fetch_message_recv", "duplicate_fetch_message_recv")
+	if err != nil {
+		return err
+	}
+	if err = s.Id.decodeJSON(fields["id"]); err != nil {
+		return jsonFieldError("id", err)
+	}
+	if s.VersionStr, err = decodeJSONString(fields["version_str"], 100); err != nil {
+		return jsonFieldError("version_str", err)
+	}
+	if err = s.MessagesRead.decodeJSON(fields["messages_read"]); err != nil {
+		return jsonFieldError("messages_read", err)
+	}
+	if err = s.MessagesWritten.decodeJSON(fields["messages_written"]); err != nil {
+		return jsonFieldError("messages_written", err)
+	}
+	if err = s.BytesRead.decodeJSON(fields["bytes_read"]); err != nil {
+		return jsonFieldError("bytes_read", err)
+	}
+	if err = s.BytesWritten.decodeJSON(fields["bytes_written"]); err != nil {
+		return jsonFieldError("bytes_written", err)
+	}
+	if err = s.SecondsConnected.decodeJSON(fields["seconds_connected"]); err != nil {
+		return jsonFieldError("seconds_connected", err)
+	}
+	if err = s.UniqueFloodBytesRecv.decodeJSON(fields["unique_flood_bytes_recv"]); err != nil {
+		return jsonFieldError("unique_flood_bytes_recv", err)
+	}
+	if err = s.DuplicateFloodBytesRecv.decodeJSON(fields["duplicate_flood_bytes_recv"]); err != nil {
+		return jsonFieldError("duplicate_flood_bytes_recv", err)
+	}
+	if err = s.UniqueFetchBytesRecv.decodeJSON(fields["unique_fetch_bytes_recv"]); err != nil {
+		return jsonFieldError("unique_fetch_bytes_recv", err)
+	}
+	if err = s.DuplicateFetchBytesRecv.decodeJSON(fields["duplicate_fetch_bytes_recv"]); err != nil {
+		return jsonFieldError("duplicate_fetch_bytes_recv", err)
+	}
+	if err = s.UniqueFloodMessageRecv.decodeJSON(fields["unique_flood_message_recv"]); err != nil {
+		return jsonFieldError("unique_flood_message_recv", err)
+	}
+	if err = s.DuplicateFloodMessageRecv.decodeJSON(fields["duplicate_flood_message_recv"]); err != nil {
+		return jsonFieldError("duplicate_flood_message_recv", err)
+	}
+	if err = s.UniqueFetchMessageRecv.decodeJSON(fields["unique_fetch_message_recv"]); err != nil {
+		return jsonFieldError("unique_fetch_message_recv", err)
+	}
+	if err = s.DuplicateFetchMessageRecv.decodeJSON(fields["duplicate_fetch_message_recv"]); err != nil {
+		return jsonFieldError("duplicate_fetch_message_recv", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PeerStats) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7507,14 +18545,89 @@ func (e PeerStatList) XDRMaxSize() int {
 	return 25
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s PeerStatList) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
+// EncodeTo encodes this value using the Encoder.
+func (s PeerStatList) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s); i++ {
+		if err = s[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PeerStatList) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 25 {
+		return n, errDecodeMaxSlice(l)
+	}
+	*s = nil
+	if l > 0 {
+		*s = make([]PeerStats, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			*s = append(*s, PeerStats{})
+			nTmp, err = (*s)[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PeerStatList) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginArray()
+	for i := 0; i < len(s); i++ {
+		enc.element()
+		if err = s[i].encodeJSON(enc); err != nil {
+			return jsonIndexError(i, err)
+		}
+	}
+	enc.endArray()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PeerStatList) decodeJSON(data []byte) error {
+	var elems [][]byte
+	var err error
+	if elems, err = decodeJSONArray(data, 25); err != nil {
+		return err
+	}
+	*s = nil
+	if len(elems) > 0 {
+		*s = make([]PeerStats, len(elems))
+	}
+	for i, elem := range elems {
+		if err = (*s)[i].decodeJSON(elem); err != nil {
+			return jsonIndexError(i, err)
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s PeerStatList) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (s *PeerStatList) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
@@ -7543,6 +18656,96 @@ type TopologyResponseBody struct {
 	TotalOutboundPeerCount Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TopologyResponseBody) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.InboundPeers.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OutboundPeers.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TotalInboundPeerCount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TotalOutboundPeerCount.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TopologyResponseBody) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.InboundPeers.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OutboundPeers.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TotalInboundPeerCount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TotalOutboundPeerCount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TopologyResponseBody) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("inbound_peers")
+	if err = s.InboundPeers.encodeJSON(enc); err != nil {
+		return jsonFieldError("inbound_peers", err)
+	}
+	enc.key("outbound_peers")
+	if err = s.OutboundPeers.encodeJSON(enc); err != nil {
+		return jsonFieldError("outbound_peers", err)
+	}
+	enc.key("total_inbound_peer_count")
+	if err = s.TotalInboundPeerCount.encodeJSON(enc); err != nil {
+		return jsonFieldError("total_inbound_peer_count", err)
+	}
+	enc.key("total_outbound_peer_count")
+	if err = s.TotalOutboundPeerCount.encodeJSON(enc); err != nil {
+		return jsonFieldError("total_outbound_peer_count", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TopologyResponseBody) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "inbound_peers", "outbound_peers", "total_inbound_peer_count", "total_outbound_peer_count")
+	if err != nil {
+		return err
+	}
+	if err = s.InboundPeers.decodeJSON(fields["inbound_peers"]); err != nil {
+		return jsonFieldError("inbound_peers", err)
+	}
+	if err = s.OutboundPeers.decodeJSON(fields["outbound_peers"]); err != nil {
+		return jsonFieldError("outbound_peers", err)
+	}
+	if err = s.TotalInboundPeerCount.decodeJSON(fields["total_inbound_peer_count"]); err != nil {
+		return jsonFieldError("total_inbound_peer_count", err)
+	}
+	if err = s.TotalOutboundPeerCount.decodeJSON(fields["total_outbound_peer_count"]); err != nil {
+		return jsonFieldError("total_outbound_peer_count", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TopologyResponseBody) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -7630,6 +18833,101 @@ func (u SurveyResponseBody) GetTopologyResponseBody() (result TopologyResponseBo
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u SurveyResponseBody) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch SurveyMessageCommandType(u.Type) {
+	case SurveyMessageCommandTypeSurveyTopology:
+		if u.TopologyResponseBody == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.TopologyResponseBody.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *SurveyResponseBody) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = SurveyResponseBody{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = SurveyMessageCommandType(sw)
+	switch SurveyMessageCommandType(u.Type) {
+	case SurveyMessageCommandTypeSurveyTopology:
+		u.TopologyResponseBody = new(TopologyResponseBody)
+		nTmp, err = u.TopologyResponseBody.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u SurveyResponseBody) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch SurveyMessageCommandType(u.Type) {
+	case SurveyMessageCommandTypeSurveyTopology:
+		if u.TopologyResponseBody == nil {
+			return jsonFieldError("topology_response_body", errEncodeNilUnionValue())
+		}
+		enc.key("topology_response_body")
+		if err = u.TopologyResponseBody.encodeJSON(enc); err != nil {
+			return jsonFieldError("topology_response_body", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *SurveyResponseBody) decodeJSON(data []byte) error {
+	*u = SurveyResponseBody{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch SurveyMessageCommandType(u.Type) {
+	case SurveyMessageCommandTypeSurveyTopology:
+		if err = checkJSONKeys(fields, "type", "topology_response_body"); err != nil {
+			return err
+		}
+		u.TopologyResponseBody = new(TopologyResponseBody)
+		if err = u.TopologyResponseBody.decodeJSON(fields["topology_response_body"]); err != nil {
+			return jsonFieldError("topology_response_body", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SurveyResponseBody) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8210,6 +19508,577 @@ func (u StellarMessage) GetGetScpLedgerSeq() (result Uint32, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u StellarMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch MessageType(u.Type) {
+	case MessageTypeErrorMsg:
+		if u.Error == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Error.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeHello:
+		if u.Hello == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Hello.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeAuth:
+		if u.Auth == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Auth.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeDontHave:
+		if u.DontHave == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.DontHave.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeGetPeers:
+		// Void
+		return nil
+	case MessageTypePeers:
+		if u.Peers == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Peers))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Peers); i++ {
+			if err = (*u.Peers)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case MessageTypeGetTxSet:
+		if u.TxSetHash == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.TxSetHash.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeTxSet:
+		if u.TxSet == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.TxSet.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeTransaction:
+		if u.Transaction == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Transaction.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeSurveyRequest:
+		if u.SignedSurveyRequestMessage == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SignedSurveyRequestMessage.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeSurveyResponse:
+		if u.SignedSurveyResponseMessage == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SignedSurveyResponseMessage.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeGetScpQuorumset:
+		if u.QSetHash == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.QSetHash.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeScpQuorumset:
+		if u.QSet == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.QSet.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeScpMessage:
+		if u.Envelope == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Envelope.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MessageTypeGetScpState:
+		if u.GetScpLedgerSeq == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.GetScpLedgerSeq.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *StellarMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	*u = StellarMessage{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = MessageType(sw)
+	switch MessageType(u.Type) {
+	case MessageTypeErrorMsg:
+		u.Error = new(Error)
+		nTmp, err = u.Error.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeHello:
+		u.Hello = new(Hello)
+		nTmp, err = u.Hello.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeAuth:
+		u.Auth = new(Auth)
+		nTmp, err = u.Auth.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeDontHave:
+		u.DontHave = new(DontHave)
+		nTmp, err = u.DontHave.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeGetPeers:
+		// Void
+		return n, nil
+	case MessageTypePeers:
+		u.Peers = new([]PeerAddress)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > 100 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Peers = nil
+		if l > 0 {
+			*u.Peers = make([]PeerAddress, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Peers = append(*u.Peers, PeerAddress{})
+				nTmp, err = (*u.Peers)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	case MessageTypeGetTxSet:
+		u.TxSetHash = new(Uint256)
+		nTmp, err = u.TxSetHash.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeTxSet:
+		u.TxSet = new(TransactionSet)
+		nTmp, err = u.TxSet.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeTransaction:
+		u.Transaction = new(TransactionEnvelope)
+		nTmp, err = u.Transaction.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeSurveyRequest:
+		u.SignedSurveyRequestMessage = new(SignedSurveyRequestMessage)
+		nTmp, err = u.SignedSurveyRequestMessage.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeSurveyResponse:
+		u.SignedSurveyResponseMessage = new(SignedSurveyResponseMessage)
+		nTmp, err = u.SignedSurveyResponseMessage.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeGetScpQuorumset:
+		u.QSetHash = new(Uint256)
+		nTmp, err = u.QSetHash.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeScpQuorumset:
+		u.QSet = new(ScpQuorumSet)
+		nTmp, err = u.QSet.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeScpMessage:
+		u.Envelope = new(ScpEnvelope)
+		nTmp, err = u.Envelope.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MessageTypeGetScpState:
+		u.GetScpLedgerSeq = new(Uint32)
+		nTmp, err = u.GetScpLedgerSeq.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u StellarMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch MessageType(u.Type) {
+	case MessageTypeErrorMsg:
+		if u.Error == nil {
+			return jsonFieldError("error", errEncodeNilUnionValue())
+		}
+		enc.key("error")
+		if err = u.Error.encodeJSON(enc); err != nil {
+			return jsonFieldError("error", err)
+		}
+	case MessageTypeHello:
+		if u.Hello == nil {
+			return jsonFieldError("hello", errEncodeNilUnionValue())
+		}
+		enc.key("hello")
+		if err = u.Hello.encodeJSON(enc); err != nil {
+			return jsonFieldError("hello", err)
+		}
+	case MessageTypeAuth:
+		if u.Auth == nil {
+			return jsonFieldError("auth", errEncodeNilUnionValue())
+		}
+		enc.key("auth")
+		if err = u.Auth.encodeJSON(enc); err != nil {
+			return jsonFieldError("auth", err)
+		}
+	case MessageTypeDontHave:
+		if u.DontHave == nil {
+			return jsonFieldError("dont_have", errEncodeNilUnionValue())
+		}
+		enc.key("dont_have")
+		if err = u.DontHave.encodeJSON(enc); err != nil {
+			return jsonFieldError("dont_have", err)
+		}
+	case MessageTypeGetPeers:
+		// Void
+	case MessageTypePeers:
+		if u.Peers == nil {
+			return jsonFieldError("peers", errEncodeNilUnionValue())
+		}
+		enc.key("peers")
+		enc.beginArray()
+		for i := 0; i < len(*u.Peers); i++ {
+			enc.element()
+			if err = (*u.Peers)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("peers", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	case MessageTypeGetTxSet:
+		if u.TxSetHash == nil {
+			return jsonFieldError("tx_set_hash", errEncodeNilUnionValue())
+		}
+		enc.key("tx_set_hash")
+		if err = u.TxSetHash.encodeJSON(enc); err != nil {
+			return jsonFieldError("tx_set_hash", err)
+		}
+	case MessageTypeTxSet:
+		if u.TxSet == nil {
+			return jsonFieldError("tx_set", errEncodeNilUnionValue())
+		}
+		enc.key("tx_set")
+		if err = u.TxSet.encodeJSON(enc); err != nil {
+			return jsonFieldError("tx_set", err)
+		}
+	case MessageTypeTransaction:
+		if u.Transaction == nil {
+			return jsonFieldError("transaction", errEncodeNilUnionValue())
+		}
+		enc.key("transaction")
+		if err = u.Transaction.encodeJSON(enc); err != nil {
+			return jsonFieldError("transaction", err)
+		}
+	case MessageTypeSurveyRequest:
+		if u.SignedSurveyRequestMessage == nil {
+			return jsonFieldError("signed_survey_request_message", errEncodeNilUnionValue())
+		}
+		enc.key("signed_survey_request_message")
+		if err = u.SignedSurveyRequestMessage.encodeJSON(enc); err != nil {
+			return jsonFieldError("signed_survey_request_message", err)
+		}
+	case MessageTypeSurveyResponse:
+		if u.SignedSurveyResponseMessage == nil {
+			return jsonFieldError("signed_survey_response_message", errEncodeNilUnionValue())
+		}
+		enc.key("signed_survey_response_message")
+		if err = u.SignedSurveyResponseMessage.encodeJSON(enc); err != nil {
+			return jsonFieldError("signed_survey_response_message", err)
+		}
+	case MessageTypeGetScpQuorumset:
+		if u.QSetHash == nil {
+			return jsonFieldError("q_set_hash", errEncodeNilUnionValue())
+		}
+		enc.key("q_set_hash")
+		if err = u.QSetHash.encodeJSON(enc); err != nil {
+			return jsonFieldError("q_set_hash", err)
+		}
+	case MessageTypeScpQuorumset:
+		if u.QSet == nil {
+			return jsonFieldError("q_set", errEncodeNilUnionValue())
+		}
+		enc.key("q_set")
+		if err = u.QSet.encodeJSON(enc); err != nil {
+			return jsonFieldError("q_set", err)
+		}
+	case MessageTypeScpMessage:
+		if u.Envelope == nil {
+			return jsonFieldError("envelope", errEncodeNilUnionValue())
+		}
+		enc.key("envelope")
+		if err = u.Envelope.encodeJSON(enc); err != nil {
+			return jsonFieldError("envelope", err)
+		}
+	case MessageTypeGetScpState:
+		if u.GetScpLedgerSeq == nil {
+			return jsonFieldError("get_scp_ledger_seq", errEncodeNilUnionValue())
+		}
+		enc.key("get_scp_ledger_seq")
+		if err = u.GetScpLedgerSeq.encodeJSON(enc); err != nil {
+			return jsonFieldError("get_scp_ledger_seq", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *StellarMessage) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = StellarMessage{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch MessageType(u.Type) {
+	case MessageTypeErrorMsg:
+		if err = checkJSONKeys(fields, "type", "error"); err != nil {
+			return err
+		}
+		u.Error = new(Error)
+		if err = u.Error.decodeJSON(fields["error"]); err != nil {
+			return jsonFieldError("error", err)
+		}
+		return nil
+	case MessageTypeHello:
+		if err = checkJSONKeys(fields, "type", "hello"); err != nil {
+			return err
+		}
+		u.Hello = new(Hello)
+		if err = u.Hello.decodeJSON(fields["hello"]); err != nil {
+			return jsonFieldError("hello", err)
+		}
+		return nil
+	case MessageTypeAuth:
+		if err = checkJSONKeys(fields, "type", "auth"); err != nil {
+			return err
+		}
+		u.Auth = new(Auth)
+		if err = u.Auth.decodeJSON(fields["auth"]); err != nil {
+			return jsonFieldError("auth", err)
+		}
+		return nil
+	case MessageTypeDontHave:
+		if err = checkJSONKeys(fields, "type", "dont_have"); err != nil {
+			return err
+		}
+		u.DontHave = new(DontHave)
+		if err = u.DontHave.decodeJSON(fields["dont_have"]); err != nil {
+			return jsonFieldError("dont_have", err)
+		}
+		return nil
+	case MessageTypeGetPeers:
+		// Void
+		return checkJSONKeys(fields, "type")
+	case MessageTypePeers:
+		if err = checkJSONKeys(fields, "type", "peers"); err != nil {
+			return err
+		}
+		u.Peers = new([]PeerAddress)
+		if elems, err = decodeJSONArray(fields["peers"], 100); err != nil {
+			return jsonFieldError("peers", err)
+		}
+		*u.Peers = nil
+		if len(elems) > 0 {
+			*u.Peers = make([]PeerAddress, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Peers)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("peers", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case MessageTypeGetTxSet:
+		if err = checkJSONKeys(fields, "type", "tx_set_hash"); err != nil {
+			return err
+		}
+		u.TxSetHash = new(Uint256)
+		if err = u.TxSetHash.decodeJSON(fields["tx_set_hash"]); err != nil {
+			return jsonFieldError("tx_set_hash", err)
+		}
+		return nil
+	case MessageTypeTxSet:
+		if err = checkJSONKeys(fields, "type", "tx_set"); err != nil {
+			return err
+		}
+		u.TxSet = new(TransactionSet)
+		if err = u.TxSet.decodeJSON(fields["tx_set"]); err != nil {
+			return jsonFieldError("tx_set", err)
+		}
+		return nil
+	case MessageTypeTransaction:
+		if err = checkJSONKeys(fields, "type", "transaction"); err != nil {
+			return err
+		}
+		u.Transaction = new(TransactionEnvelope)
+		if err = u.Transaction.decodeJSON(fields["transaction"]); err != nil {
+			return jsonFieldError("transaction", err)
+		}
+		return nil
+	case MessageTypeSurveyRequest:
+		if err = checkJSONKeys(fields, "type", "signed_survey_request_message"); err != nil {
+			return err
+		}
+		u.SignedSurveyRequestMessage = new(SignedSurveyRequestMessage)
+		if err = u.SignedSurveyRequestMessage.decodeJSON(fields["signed_survey_request_message"]); err != nil {
+			return jsonFieldError("signed_survey_request_message", err)
+		}
+		return nil
+	case MessageTypeSurveyResponse:
+		if err = checkJSONKeys(fields, "type", "signed_survey_response_message"); err != nil {
+			return err
+		}
+		u.SignedSurveyResponseMessage = new(SignedSurveyResponseMessage)
+		if err = u.SignedSurveyResponseMessage.decodeJSON(fields["signed_survey_response_message"]); err != nil {
+			return jsonFieldError("signed_survey_response_message", err)
+		}
+		return nil
+	case MessageTypeGetScpQuorumset:
+		if err = checkJSONKeys(fields, "type", "q_set_hash"); err != nil {
+			return err
+		}
+		u.QSetHash = new(Uint256)
+		if err = u.QSetHash.decodeJSON(fields["q_set_hash"]); err != nil {
+			return jsonFieldError("q_set_hash", err)
+		}
+		return nil
+	case MessageTypeScpQuorumset:
+		if err = checkJSONKeys(fields, "type", "q_set"); err != nil {
+			return err
+		}
+		u.QSet = new(ScpQuorumSet)
+		if err = u.QSet.decodeJSON(fields["q_set"]); err != nil {
+			return jsonFieldError("q_set", err)
+		}
+		return nil
+	case MessageTypeScpMessage:
+		if err = checkJSONKeys(fields, "type", "envelope"); err != nil {
+			return err
+		}
+		u.Envelope = new(ScpEnvelope)
+		if err = u.Envelope.decodeJSON(fields["envelope"]); err != nil {
+			return jsonFieldError("envelope", err)
+		}
+		return nil
+	case MessageTypeGetScpState:
+		if err = checkJSONKeys(fields, "type", "get_scp_ledger_seq"); err != nil {
+			return err
+		}
+		u.GetScpLedgerSeq = new(Uint32)
+		if err = u.GetScpLedgerSeq.decodeJSON(fields["get_scp_ledger_seq"]); err != nil {
+			return jsonFieldError("get_scp_ledger_seq", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s StellarMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8243,7 +20112,82 @@ type AuthenticatedMessageV0 struct {
 	Mac      HmacSha256Mac
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
+// EncodeTo encodes this value using the Encoder.
+func (s AuthenticatedMessageV0) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Sequence.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Message.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Mac.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AuthenticatedMessageV0) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Sequence.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Message.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Mac.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AuthenticatedMessageV0) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("sequence")
+	if err = s.Sequence.encodeJSON(enc); err != nil {
+		return jsonFieldError("sequence", err)
+	}
+	enc.key("message")
+	if err = s.Message.encodeJSON(enc); err != nil {
+		return jsonFieldError("message", err)
+	}
+	enc.key("mac")
+	if err = s.Mac.encodeJSON(enc); err != nil {
+		return jsonFieldError("mac", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AuthenticatedMessageV0) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "sequence", "message", "mac")
+	if err != nil {
+		return err
+	}
+	if err = s.Sequence.decodeJSON(fields["sequence"]); err != nil {
+		return jsonFieldError("sequence", err)
+	}
+	if err = s.Message.decodeJSON(fields["message"]); err != nil {
+		return jsonFieldError("message", err)
+	}
+	if err = s.Mac.decodeJSON(fields["mac"]); err != nil {
+		return jsonFieldError("mac", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
 func (s AuthenticatedMessageV0) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
 	_, err := Marshal(b, s)
@@ -8335,6 +20279,98 @@ func (u AuthenticatedMessage) GetV0() (result AuthenticatedMessageV0, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AuthenticatedMessage) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.V.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch Uint32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AuthenticatedMessage) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AuthenticatedMessage{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = Uint32(sw)
+	switch Uint32(u.V) {
+	case 0:
+		u.V0 = new(AuthenticatedMessageV0)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AuthenticatedMessage) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("v")
+	if err = u.V.encodeJSON(enc); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch Uint32(u.V) {
+	case 0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AuthenticatedMessage) decodeJSON(data []byte) error {
+	*u = AuthenticatedMessage{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if err = u.V.decodeJSON(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch Uint32(u.V) {
+	case 0:
+		if err = checkJSONKeys(fields, "v", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(AuthenticatedMessageV0)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AuthenticatedMessage) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8366,6 +20402,66 @@ type MuxedAccountMed25519 struct {
 	Ed25519 Uint256
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s MuxedAccountMed25519) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Id.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ed25519.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *MuxedAccountMed25519) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Id.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ed25519.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s MuxedAccountMed25519) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("id")
+	if err = s.Id.encodeJSON(enc); err != nil {
+		return jsonFieldError("id", err)
+	}
+	enc.key("ed25519")
+	if err = s.Ed25519.encodeJSON(enc); err != nil {
+		return jsonFieldError("ed25519", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *MuxedAccountMed25519) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "id", "ed25519")
+	if err != nil {
+		return err
+	}
+	if err = s.Id.decodeJSON(fields["id"]); err != nil {
+		return jsonFieldError("id", err)
+	}
+	if err = s.Ed25519.decodeJSON(fields["ed25519"]); err != nil {
+		return jsonFieldError("ed25519", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s MuxedAccountMed25519) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8494,6 +20590,69 @@ func (u MuxedAccount) GetMed25519() (result MuxedAccountMed25519, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u MuxedAccount) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch CryptoKeyType(u.Type) {
+	case CryptoKeyTypeKeyTypeEd25519:
+		if u.Ed25519 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Ed25519.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		if u.Med25519 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Med25519.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *MuxedAccount) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = MuxedAccount{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = CryptoKeyType(sw)
+	switch CryptoKeyType(u.Type) {
+	case CryptoKeyTypeKeyTypeEd25519:
+		u.Ed25519 = new(Uint256)
+		nTmp, err = u.Ed25519.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		u.Med25519 = new(MuxedAccountMed25519)
+		nTmp, err = u.Med25519.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s MuxedAccount) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8525,6 +20684,66 @@ type DecoratedSignature struct {
 	Signature Signature
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s DecoratedSignature) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Hint.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Signature.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *DecoratedSignature) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Hint.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Signature.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s DecoratedSignature) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("hint")
+	if err = s.Hint.encodeJSON(enc); err != nil {
+		return jsonFieldError("hint", err)
+	}
+	enc.key("signature")
+	if err = s.Signature.encodeJSON(enc); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *DecoratedSignature) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "hint", "signature")
+	if err != nil {
+		return err
+	}
+	if err = s.Hint.decodeJSON(fields["hint"]); err != nil {
+		return jsonFieldError("hint", err)
+	}
+	if err = s.Signature.decodeJSON(fields["signature"]); err != nil {
+		return jsonFieldError("signature", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s DecoratedSignature) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8637,6 +20856,73 @@ func (e OperationType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e OperationType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *OperationType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = OperationType(v)
+	return n, nil
+}
+
+var operationTypeJSONNames = map[int32]string{
+	0:  "create_account",
+	1:  "payment",
+	2:  "path_payment_strict_receive",
+	3:  "manage_sell_offer",
+	4:  "create_passive_sell_offer",
+	5:  "set_options",
+	6:  "change_trust",
+	7:  "allow_trust",
+	8:  "account_merge",
+	9:  "inflation",
+	10: "manage_data",
+	11: "bump_sequence",
+	12: "manage_buy_offer",
+	13: "path_payment_strict_send",
+	14: "create_claimable_balance",
+	15: "claim_claimable_balance",
+	16: "begin_sponsoring_future_reserves",
+	17: "end_sponsoring_future_reserves",
+	18: "revoke_sponsorship",
+	19: "clawback",
+	20: "clawback_claimable_balance",
+	21: "set_trust_line_flags",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e OperationType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := operationTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *OperationType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, operationTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = OperationType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8668,6 +20954,66 @@ type CreateAccountOp struct {
 	StartingBalance Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s CreateAccountOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.StartingBalance.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *CreateAccountOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.StartingBalance.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s CreateAccountOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("starting_balance")
+	if err = s.StartingBalance.encodeJSON(enc); err != nil {
+		return jsonFieldError("starting_balance", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *CreateAccountOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "destination", "starting_balance")
+	if err != nil {
+		return err
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.StartingBalance.decodeJSON(fields["starting_balance"]); err != nil {
+		return jsonFieldError("starting_balance", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateAccountOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8701,6 +21047,81 @@ type PaymentOp struct {
 	Amount      Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PaymentOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PaymentOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PaymentOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PaymentOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "destination", "asset", "amount")
+	if err != nil {
+		return err
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PaymentOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8744,6 +21165,162 @@ type PathPaymentStrictReceiveOp struct {
 	Path        []Asset `xdrmaxsize:"5"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PathPaymentStrictReceiveOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SendAsset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SendMax.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DestAsset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DestAmount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Path))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Path); i++ {
+		if err = s.Path[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PathPaymentStrictReceiveOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SendAsset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SendMax.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DestAsset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DestAmount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 5 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Path = nil
+	if l > 0 {
+		s.Path = make([]Asset, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Path = append(s.Path, Asset{})
+			nTmp, err = s.Path[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PathPaymentStrictReceiveOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("send_asset")
+	if err = s.SendAsset.encodeJSON(enc); err != nil {
+		return jsonFieldError("send_asset", err)
+	}
+	enc.key("send_max")
+	if err = s.SendMax.encodeJSON(enc); err != nil {
+		return jsonFieldError("send_max", err)
+	}
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("dest_asset")
+	if err = s.DestAsset.encodeJSON(enc); err != nil {
+		return jsonFieldError("dest_asset", err)
+	}
+	enc.key("dest_amount")
+	if err = s.DestAmount.encodeJSON(enc); err != nil {
+		return jsonFieldError("dest_amount", err)
+	}
+	enc.key("path")
+	enc.beginArray()
+	for i := 0; i < len(s.Path); i++ {
+		enc.element()
+		if err = s.Path[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("path", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PathPaymentStrictReceiveOp) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "send_asset", "send_max", "destination", "dest_asset", "dest_amount", "path")
+	if err != nil {
+		return err
+	}
+	if err = s.SendAsset.decodeJSON(fields["send_asset"]); err != nil {
+		return jsonFieldError("send_asset", err)
+	}
+	if err = s.SendMax.decodeJSON(fields["send_max"]); err != nil {
+		return jsonFieldError("send_max", err)
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.DestAsset.decodeJSON(fields["dest_asset"]); err != nil {
+		return jsonFieldError("dest_asset", err)
+	}
+	if err = s.DestAmount.decodeJSON(fields["dest_amount"]); err != nil {
+		return jsonFieldError("dest_amount", err)
+	}
+	if elems, err = decodeJSONArray(fields["path"], 5); err != nil {
+		return jsonFieldError("path", err)
+	}
+	s.Path = nil
+	if len(elems) > 0 {
+		s.Path = make([]Asset, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Path[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("path", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictReceiveOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8787,6 +21364,162 @@ type PathPaymentStrictSendOp struct {
 	Path        []Asset `xdrmaxsize:"5"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PathPaymentStrictSendOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SendAsset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SendAmount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DestAsset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.DestMin.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Path))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Path); i++ {
+		if err = s.Path[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PathPaymentStrictSendOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SendAsset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SendAmount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DestAsset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.DestMin.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 5 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Path = nil
+	if l > 0 {
+		s.Path = make([]Asset, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Path = append(s.Path, Asset{})
+			nTmp, err = s.Path[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PathPaymentStrictSendOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("send_asset")
+	if err = s.SendAsset.encodeJSON(enc); err != nil {
+		return jsonFieldError("send_asset", err)
+	}
+	enc.key("send_amount")
+	if err = s.SendAmount.encodeJSON(enc); err != nil {
+		return jsonFieldError("send_amount", err)
+	}
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("dest_asset")
+	if err = s.DestAsset.encodeJSON(enc); err != nil {
+		return jsonFieldError("dest_asset", err)
+	}
+	enc.key("dest_min")
+	if err = s.DestMin.encodeJSON(enc); err != nil {
+		return jsonFieldError("dest_min", err)
+	}
+	enc.key("path")
+	enc.beginArray()
+	for i := 0; i < len(s.Path); i++ {
+		enc.element()
+		if err = s.Path[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("path", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PathPaymentStrictSendOp) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "send_asset", "send_amount", "destination", "dest_asset", "dest_min", "path")
+	if err != nil {
+		return err
+	}
+	if err = s.SendAsset.decodeJSON(fields["send_asset"]); err != nil {
+		return jsonFieldError("send_asset", err)
+	}
+	if err = s.SendAmount.decodeJSON(fields["send_amount"]); err != nil {
+		return jsonFieldError("send_amount", err)
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.DestAsset.decodeJSON(fields["dest_asset"]); err != nil {
+		return jsonFieldError("dest_asset", err)
+	}
+	if err = s.DestMin.decodeJSON(fields["dest_min"]); err != nil {
+		return jsonFieldError("dest_min", err)
+	}
+	if elems, err = decodeJSONArray(fields["path"], 5); err != nil {
+		return jsonFieldError("path", err)
+	}
+	s.Path = nil
+	if len(elems) > 0 {
+		s.Path = make([]Asset, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Path[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("path", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictSendOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8826,6 +21559,111 @@ type ManageSellOfferOp struct {
 	OfferId Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ManageSellOfferOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Selling.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Buying.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Price.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OfferId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ManageSellOfferOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Selling.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Buying.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Price.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OfferId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ManageSellOfferOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("selling")
+	if err = s.Selling.encodeJSON(enc); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	enc.key("buying")
+	if err = s.Buying.encodeJSON(enc); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.key("price")
+	if err = s.Price.encodeJSON(enc); err != nil {
+		return jsonFieldError("price", err)
+	}
+	enc.key("offer_id")
+	if err = s.OfferId.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ManageSellOfferOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "selling", "buying", "amount", "price", "offer_id")
+	if err != nil {
+		return err
+	}
+	if err = s.Selling.decodeJSON(fields["selling"]); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	if err = s.Buying.decodeJSON(fields["buying"]); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	if err = s.Price.decodeJSON(fields["price"]); err != nil {
+		return jsonFieldError("price", err)
+	}
+	if err = s.OfferId.decodeJSON(fields["offer_id"]); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageSellOfferOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8866,14 +21704,119 @@ type ManageBuyOfferOp struct {
 	OfferId   Int64
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (s ManageBuyOfferOp) MarshalBinary() ([]byte, error) {
-	b := new(bytes.Buffer)
-	_, err := Marshal(b, s)
-	return b.Bytes(), err
-}
-
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// EncodeTo encodes this value using the Encoder.
+func (s ManageBuyOfferOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Selling.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Buying.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.BuyAmount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Price.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OfferId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ManageBuyOfferOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Selling.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Buying.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.BuyAmount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Price.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OfferId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ManageBuyOfferOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("selling")
+	if err = s.Selling.encodeJSON(enc); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	enc.key("buying")
+	if err = s.Buying.encodeJSON(enc); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	enc.key("buy_amount")
+	if err = s.BuyAmount.encodeJSON(enc); err != nil {
+		return jsonFieldError("buy_amount", err)
+	}
+	enc.key("price")
+	if err = s.Price.encodeJSON(enc); err != nil {
+		return jsonFieldError("price", err)
+	}
+	enc.key("offer_id")
+	if err = s.OfferId.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ManageBuyOfferOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "selling", "buying", "buy_amount", "price", "offer_id")
+	if err != nil {
+		return err
+	}
+	if err = s.Selling.decodeJSON(fields["selling"]); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	if err = s.Buying.decodeJSON(fields["buying"]); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	if err = s.BuyAmount.decodeJSON(fields["buy_amount"]); err != nil {
+		return jsonFieldError("buy_amount", err)
+	}
+	if err = s.Price.decodeJSON(fields["price"]); err != nil {
+		return jsonFieldError("price", err)
+	}
+	if err = s.OfferId.decodeJSON(fields["offer_id"]); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s ManageBuyOfferOp) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (s *ManageBuyOfferOp) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
@@ -8901,6 +21844,96 @@ type CreatePassiveSellOfferOp struct {
 	Price   Price
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s CreatePassiveSellOfferOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Selling.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Buying.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Price.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *CreatePassiveSellOfferOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Selling.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Buying.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Price.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s CreatePassiveSellOfferOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("selling")
+	if err = s.Selling.encodeJSON(enc); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	enc.key("buying")
+	if err = s.Buying.encodeJSON(enc); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.key("price")
+	if err = s.Price.encodeJSON(enc); err != nil {
+		return jsonFieldError("price", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *CreatePassiveSellOfferOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "selling", "buying", "amount", "price")
+	if err != nil {
+		return err
+	}
+	if err = s.Selling.decodeJSON(fields["selling"]); err != nil {
+		return jsonFieldError("selling", err)
+	}
+	if err = s.Buying.decodeJSON(fields["buying"]); err != nil {
+		return jsonFieldError("buying", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	if err = s.Price.decodeJSON(fields["price"]); err != nil {
+		return jsonFieldError("price", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreatePassiveSellOfferOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8953,6 +21986,370 @@ type SetOptionsOp struct {
 	Signer        *Signer
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SetOptionsOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeBool(s.InflationDest != nil); err != nil {
+		return err
+	}
+	if s.InflationDest != nil {
+		if err = s.InflationDest.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.ClearFlags != nil); err != nil {
+		return err
+	}
+	if s.ClearFlags != nil {
+		if err = s.ClearFlags.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.SetFlags != nil); err != nil {
+		return err
+	}
+	if s.SetFlags != nil {
+		if err = s.SetFlags.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.MasterWeight != nil); err != nil {
+		return err
+	}
+	if s.MasterWeight != nil {
+		if err = s.MasterWeight.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.LowThreshold != nil); err != nil {
+		return err
+	}
+	if s.LowThreshold != nil {
+		if err = s.LowThreshold.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.MedThreshold != nil); err != nil {
+		return err
+	}
+	if s.MedThreshold != nil {
+		if err = s.MedThreshold.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.HighThreshold != nil); err != nil {
+		return err
+	}
+	if s.HighThreshold != nil {
+		if err = s.HighThreshold.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.HomeDomain != nil); err != nil {
+		return err
+	}
+	if s.HomeDomain != nil {
+		if err = s.HomeDomain.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if _, err = enc.EncodeBool(s.Signer != nil); err != nil {
+		return err
+	}
+	if s.Signer != nil {
+		if err = s.Signer.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SetOptionsOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var present bool
+	var err error
+	var n, nTmp int
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.InflationDest = nil
+	if present {
+		s.InflationDest = new(AccountId)
+		nTmp, err = s.InflationDest.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.ClearFlags = nil
+	if present {
+		s.ClearFlags = new(Uint32)
+		nTmp, err = s.ClearFlags.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.SetFlags = nil
+	if present {
+		s.SetFlags = new(Uint32)
+		nTmp, err = s.SetFlags.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.MasterWeight = nil
+	if present {
+		s.MasterWeight = new(Uint32)
+		nTmp, err = s.MasterWeight.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.LowThreshold = nil
+	if present {
+		s.LowThreshold = new(Uint32)
+		nTmp, err = s.LowThreshold.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.MedThreshold = nil
+	if present {
+		s.MedThreshold = new(Uint32)
+		nTmp, err = s.MedThreshold.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.HighThreshold = nil
+	if present {
+		s.HighThreshold = new(Uint32)
+		nTmp, err = s.HighThreshold.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.HomeDomain = nil
+	if present {
+		s.HomeDomain = new(String32)
+		nTmp, err = s.HomeDomain.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.Signer = nil
+	if present {
+		s.Signer = new(Signer)
+		nTmp, err = s.Signer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SetOptionsOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("inflation_dest")
+	if s.InflationDest == nil {
+		enc.null()
+	} else {
+		if err = s.InflationDest.encodeJSON(enc); err != nil {
+			return jsonFieldError("inflation_dest", err)
+		}
+	}
+	enc.key("clear_flags")
+	if s.ClearFlags == nil {
+		enc.null()
+	} else {
+		if err = s.ClearFlags.encodeJSON(enc); err != nil {
+			return jsonFieldError("clear_flags", err)
+		}
+	}
+	enc.key("set_flags")
+	if s.SetFlags == nil {
+		enc.null()
+	} else {
+		if err = s.SetFlags.encodeJSON(enc); err != nil {
+			return jsonFieldError("set_flags", err)
+		}
+	}
+	enc.key("master_weight")
+	if s.MasterWeight == nil {
+		enc.null()
+	} else {
+		if err = s.MasterWeight.encodeJSON(enc); err != nil {
+			return jsonFieldError("master_weight", err)
+		}
+	}
+	enc.key("low_threshold")
+	if s.LowThreshold == nil {
+		enc.null()
+	} else {
+		if err = s.LowThreshold.encodeJSON(enc); err != nil {
+			return jsonFieldError("low_threshold", err)
+		}
+	}
+	enc.key("med_threshold")
+	if s.MedThreshold == nil {
+		enc.null()
+	} else {
+		if err = s.MedThreshold.encodeJSON(enc); err != nil {
+			return jsonFieldError("med_threshold", err)
+		}
+	}
+	enc.key("high_threshold")
+	if s.HighThreshold == nil {
+		enc.null()
+	} else {
+		if err = s.HighThreshold.encodeJSON(enc); err != nil {
+			return jsonFieldError("high_threshold", err)
+		}
+	}
+	enc.key("home_domain")
+	if s.HomeDomain == nil {
+		enc.null()
+	} else {
+		if err = s.HomeDomain.encodeJSON(enc); err != nil {
+			return jsonFieldError("home_domain", err)
+		}
+	}
+	enc.key("signer")
+	if s.Signer == nil {
+		enc.null()
+	} else {
+		if err = s.Signer.encodeJSON(enc); err != nil {
+			return jsonFieldError("signer", err)
+		}
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SetOptionsOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "inflation_dest", "clear_flags", "set_flags", "master_weight", "low_threshold", "med_threshold", "high_threshold", "home_domain", "signer")
+	if err != nil {
+		return err
+	}
+	s.InflationDest = nil
+	if !isJSONNull(fields["inflation_dest"]) {
+		s.InflationDest = new(AccountId)
+		if err = s.InflationDest.decodeJSON(fields["inflation_dest"]); err != nil {
+			return jsonFieldError("inflation_dest", err)
+		}
+	}
+	s.ClearFlags = nil
+	if !isJSONNull(fields["clear_flags"]) {
+		s.ClearFlags = new(Uint32)
+		if err = s.ClearFlags.decodeJSON(fields["clear_flags"]); err != nil {
+			return jsonFieldError("clear_flags", err)
+		}
+	}
+	s.SetFlags = nil
+	if !isJSONNull(fields["set_flags"]) {
+		s.SetFlags = new(Uint32)
+		if err = s.SetFlags.decodeJSON(fields["set_flags"]); err != nil {
+			return jsonFieldError("set_flags", err)
+		}
+	}
+	s.MasterWeight = nil
+	if !isJSONNull(fields["master_weight"]) {
+		s.MasterWeight = new(Uint32)
+		if err = s.MasterWeight.decodeJSON(fields["master_weight"]); err != nil {
+			return jsonFieldError("master_weight", err)
+		}
+	}
+	s.LowThreshold = nil
+	if !isJSONNull(fields["low_threshold"]) {
+		s.LowThreshold = new(Uint32)
+		if err = s.LowThreshold.decodeJSON(fields["low_threshold"]); err != nil {
+			return jsonFieldError("low_threshold", err)
+		}
+	}
+	s.MedThreshold = nil
+	if !isJSONNull(fields["med_threshold"]) {
+		s.MedThreshold = new(Uint32)
+		if err = s.MedThreshold.decodeJSON(fields["med_threshold"]); err != nil {
+			return jsonFieldError("med_threshold", err)
+		}
+	}
+	s.HighThreshold = nil
+	if !isJSONNull(fields["high_threshold"]) {
+		s.HighThreshold = new(Uint32)
+		if err = s.HighThreshold.decodeJSON(fields["high_threshold"]); err != nil {
+			return jsonFieldError("high_threshold", err)
+		}
+	}
+	s.HomeDomain = nil
+	if !isJSONNull(fields["home_domain"]) {
+		s.HomeDomain = new(String32)
+		if err = s.HomeDomain.decodeJSON(fields["home_domain"]); err != nil {
+			return jsonFieldError("home_domain", err)
+		}
+	}
+	s.Signer = nil
+	if !isJSONNull(fields["signer"]) {
+		s.Signer = new(Signer)
+		if err = s.Signer.decodeJSON(fields["signer"]); err != nil {
+			return jsonFieldError("signer", err)
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SetOptionsOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -8986,6 +22383,66 @@ type ChangeTrustOp struct {
 	Limit Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ChangeTrustOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Line.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Limit.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ChangeTrustOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Line.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Limit.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ChangeTrustOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("line")
+	if err = s.Line.encodeJSON(enc); err != nil {
+		return jsonFieldError("line", err)
+	}
+	enc.key("limit")
+	if err = s.Limit.encodeJSON(enc); err != nil {
+		return jsonFieldError("limit", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ChangeTrustOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "line", "limit")
+	if err != nil {
+		return err
+	}
+	if err = s.Line.decodeJSON(fields["line"]); err != nil {
+		return jsonFieldError("line", err)
+	}
+	if err = s.Limit.decodeJSON(fields["limit"]); err != nil {
+		return jsonFieldError("limit", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ChangeTrustOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9022,6 +22479,81 @@ type AllowTrustOp struct {
 	Authorize Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s AllowTrustOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Trustor.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Authorize.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *AllowTrustOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Trustor.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Authorize.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s AllowTrustOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("trustor")
+	if err = s.Trustor.encodeJSON(enc); err != nil {
+		return jsonFieldError("trustor", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("authorize")
+	if err = s.Authorize.encodeJSON(enc); err != nil {
+		return jsonFieldError("authorize", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *AllowTrustOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "trustor", "asset", "authorize")
+	if err != nil {
+		return err
+	}
+	if err = s.Trustor.decodeJSON(fields["trustor"]); err != nil {
+		return jsonFieldError("trustor", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Authorize.decodeJSON(fields["authorize"]); err != nil {
+		return jsonFieldError("authorize", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AllowTrustOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9053,6 +22585,89 @@ type ManageDataOp struct {
 	DataValue *DataValue
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ManageDataOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.DataName.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeBool(s.DataValue != nil); err != nil {
+		return err
+	}
+	if s.DataValue != nil {
+		if err = s.DataValue.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ManageDataOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.DataName.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.DataValue = nil
+	if present {
+		s.DataValue = new(DataValue)
+		nTmp, err = s.DataValue.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ManageDataOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("data_name")
+	if err = s.DataName.encodeJSON(enc); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	enc.key("data_value")
+	if s.DataValue == nil {
+		enc.null()
+	} else {
+		if err = s.DataValue.encodeJSON(enc); err != nil {
+			return jsonFieldError("data_value", err)
+		}
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ManageDataOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "data_name", "data_value")
+	if err != nil {
+		return err
+	}
+	if err = s.DataName.decodeJSON(fields["data_name"]); err != nil {
+		return jsonFieldError("data_name", err)
+	}
+	s.DataValue = nil
+	if !isJSONNull(fields["data_value"]) {
+		s.DataValue = new(DataValue)
+		if err = s.DataValue.decodeJSON(fields["data_value"]); err != nil {
+			return jsonFieldError("data_value", err)
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageDataOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9082,6 +22697,51 @@ type BumpSequenceOp struct {
 	BumpTo SequenceNumber
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s BumpSequenceOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.BumpTo.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *BumpSequenceOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.BumpTo.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s BumpSequenceOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("bump_to")
+	if err = s.BumpTo.encodeJSON(enc); err != nil {
+		return jsonFieldError("bump_to", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *BumpSequenceOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "bump_to")
+	if err != nil {
+		return err
+	}
+	if err = s.BumpTo.decodeJSON(fields["bump_to"]); err != nil {
+		return jsonFieldError("bump_to", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BumpSequenceOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9115,6 +22775,117 @@ type CreateClaimableBalanceOp struct {
 	Claimants []Claimant `xdrmaxsize:"10"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s CreateClaimableBalanceOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Claimants))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Claimants); i++ {
+		if err = s.Claimants[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *CreateClaimableBalanceOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 10 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Claimants = nil
+	if l > 0 {
+		s.Claimants = make([]Claimant, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Claimants = append(s.Claimants, Claimant{})
+			nTmp, err = s.Claimants[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s CreateClaimableBalanceOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.key("claimants")
+	enc.beginArray()
+	for i := 0; i < len(s.Claimants); i++ {
+		enc.element()
+		if err = s.Claimants[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("claimants", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *CreateClaimableBalanceOp) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "asset", "amount", "claimants")
+	if err != nil {
+		return err
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	if elems, err = decodeJSONArray(fields["claimants"], 10); err != nil {
+		return jsonFieldError("claimants", err)
+	}
+	s.Claimants = nil
+	if len(elems) > 0 {
+		s.Claimants = make([]Claimant, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Claimants[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("claimants", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateClaimableBalanceOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9144,6 +22915,51 @@ type ClaimClaimableBalanceOp struct {
 	BalanceId ClaimableBalanceId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClaimClaimableBalanceOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.BalanceId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClaimClaimableBalanceOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.BalanceId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClaimClaimableBalanceOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("balance_id")
+	if err = s.BalanceId.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClaimClaimableBalanceOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "balance_id")
+	if err != nil {
+		return err
+	}
+	if err = s.BalanceId.decodeJSON(fields["balance_id"]); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimClaimableBalanceOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9173,6 +22989,51 @@ type BeginSponsoringFutureReservesOp struct {
 	SponsoredId AccountId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s BeginSponsoringFutureReservesOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SponsoredId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *BeginSponsoringFutureReservesOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SponsoredId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s BeginSponsoringFutureReservesOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("sponsored_id")
+	if err = s.SponsoredId.encodeJSON(enc); err != nil {
+		return jsonFieldError("sponsored_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *BeginSponsoringFutureReservesOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "sponsored_id")
+	if err != nil {
+		return err
+	}
+	if err = s.SponsoredId.decodeJSON(fields["sponsored_id"]); err != nil {
+		return jsonFieldError("sponsored_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BeginSponsoringFutureReservesOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9224,6 +23085,53 @@ func (e RevokeSponsorshipType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e RevokeSponsorshipType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *RevokeSponsorshipType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = RevokeSponsorshipType(v)
+	return n, nil
+}
+
+var revokeSponsorshipTypeJSONNames = map[int32]string{
+	0: "revoke_sponsorship_ledger_entry",
+	1: "revoke_sponsorship_signer",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e RevokeSponsorshipType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := revokeSponsorshipTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *RevokeSponsorshipType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, revokeSponsorshipTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = RevokeSponsorshipType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s RevokeSponsorshipType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9255,6 +23163,66 @@ type RevokeSponsorshipOpSigner struct {
 	SignerKey SignerKey
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s RevokeSponsorshipOpSigner) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.AccountId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SignerKey.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *RevokeSponsorshipOpSigner) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.AccountId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SignerKey.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s RevokeSponsorshipOpSigner) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("account_id")
+	if err = s.AccountId.encodeJSON(enc); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	enc.key("signer_key")
+	if err = s.SignerKey.encodeJSON(enc); err != nil {
+		return jsonFieldError("signer_key", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *RevokeSponsorshipOpSigner) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "account_id", "signer_key")
+	if err != nil {
+		return err
+	}
+	if err = s.AccountId.decodeJSON(fields["account_id"]); err != nil {
+		return jsonFieldError("account_id", err)
+	}
+	if err = s.SignerKey.decodeJSON(fields["signer_key"]); err != nil {
+		return jsonFieldError("signer_key", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s RevokeSponsorshipOpSigner) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9384,6 +23352,134 @@ func (u RevokeSponsorshipOp) GetSigner() (result RevokeSponsorshipOpSigner, ok b
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u RevokeSponsorshipOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch RevokeSponsorshipType(u.Type) {
+	case RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry:
+		if u.LedgerKey == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.LedgerKey.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case RevokeSponsorshipTypeRevokeSponsorshipSigner:
+		if u.Signer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Signer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *RevokeSponsorshipOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = RevokeSponsorshipOp{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = RevokeSponsorshipType(sw)
+	switch RevokeSponsorshipType(u.Type) {
+	case RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry:
+		u.LedgerKey = new(LedgerKey)
+		nTmp, err = u.LedgerKey.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case RevokeSponsorshipTypeRevokeSponsorshipSigner:
+		u.Signer = new(RevokeSponsorshipOpSigner)
+		nTmp, err = u.Signer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u RevokeSponsorshipOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch RevokeSponsorshipType(u.Type) {
+	case RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry:
+		if u.LedgerKey == nil {
+			return jsonFieldError("ledger_key", errEncodeNilUnionValue())
+		}
+		enc.key("ledger_key")
+		if err = u.LedgerKey.encodeJSON(enc); err != nil {
+			return jsonFieldError("ledger_key", err)
+		}
+	case RevokeSponsorshipTypeRevokeSponsorshipSigner:
+		if u.Signer == nil {
+			return jsonFieldError("signer", errEncodeNilUnionValue())
+		}
+		enc.key("signer")
+		if err = u.Signer.encodeJSON(enc); err != nil {
+			return jsonFieldError("signer", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *RevokeSponsorshipOp) decodeJSON(data []byte) error {
+	*u = RevokeSponsorshipOp{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch RevokeSponsorshipType(u.Type) {
+	case RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry:
+		if err = checkJSONKeys(fields, "type", "ledger_key"); err != nil {
+			return err
+		}
+		u.LedgerKey = new(LedgerKey)
+		if err = u.LedgerKey.decodeJSON(fields["ledger_key"]); err != nil {
+			return jsonFieldError("ledger_key", err)
+		}
+		return nil
+	case RevokeSponsorshipTypeRevokeSponsorshipSigner:
+		if err = checkJSONKeys(fields, "type", "signer"); err != nil {
+			return err
+		}
+		u.Signer = new(RevokeSponsorshipOpSigner)
+		if err = u.Signer.decodeJSON(fields["signer"]); err != nil {
+			return jsonFieldError("signer", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s RevokeSponsorshipOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9417,6 +23513,81 @@ type ClawbackOp struct {
 	Amount Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClawbackOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.From.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClawbackOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.From.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClawbackOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("from")
+	if err = s.From.encodeJSON(enc); err != nil {
+		return jsonFieldError("from", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClawbackOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "asset", "from", "amount")
+	if err != nil {
+		return err
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.From.decodeJSON(fields["from"]); err != nil {
+		return jsonFieldError("from", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9446,6 +23617,51 @@ type ClawbackClaimableBalanceOp struct {
 	BalanceId ClaimableBalanceId
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClawbackClaimableBalanceOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.BalanceId.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClawbackClaimableBalanceOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.BalanceId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClawbackClaimableBalanceOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("balance_id")
+	if err = s.BalanceId.encodeJSON(enc); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClawbackClaimableBalanceOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "balance_id")
+	if err != nil {
+		return err
+	}
+	if err = s.BalanceId.decodeJSON(fields["balance_id"]); err != nil {
+		return jsonFieldError("balance_id", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackClaimableBalanceOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -9482,6 +23698,96 @@ type SetTrustLineFlagsOp struct {
 	SetFlags   Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SetTrustLineFlagsOp) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Trustor.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.ClearFlags.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SetFlags.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SetTrustLineFlagsOp) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Trustor.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.ClearFlags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SetFlags.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SetTrustLineFlagsOp) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("trustor")
+	if err = s.Trustor.encodeJSON(enc); err != nil {
+		return jsonFieldError("trustor", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("clear_flags")
+	if err = s.ClearFlags.encodeJSON(enc); err != nil {
+		return jsonFieldError("clear_flags", err)
+	}
+	enc.key("set_flags")
+	if err = s.SetFlags.encodeJSON(enc); err != nil {
+		return jsonFieldError("set_flags", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SetTrustLineFlagsOp) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "trustor", "asset", "clear_flags", "set_flags")
+	if err != nil {
+		return err
+	}
+	if err = s.Trustor.decodeJSON(fields["trustor"]); err != nil {
+		return jsonFieldError("trustor", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.ClearFlags.decodeJSON(fields["clear_flags"]); err != nil {
+		return jsonFieldError("clear_flags", err)
+	}
+	if err = s.SetFlags.decodeJSON(fields["set_flags"]); err != nil {
+		return jsonFieldError("set_flags", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SetTrustLineFlagsOp) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10280,8 +24586,752 @@ func (u OperationBody) GetSetTrustLineFlagsOp() (result SetTrustLineFlagsOp, ok
 		result = *u.SetTrustLineFlagsOp
 		ok = true
 	}
-
-	return
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u OperationBody) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if u.CreateAccountOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreateAccountOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePayment:
+		if u.PaymentOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PaymentOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePathPaymentStrictReceive:
+		if u.PathPaymentStrictReceiveOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PathPaymentStrictReceiveOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeManageSellOffer:
+		if u.ManageSellOfferOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageSellOfferOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeCreatePassiveSellOffer:
+		if u.CreatePassiveSellOfferOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreatePassiveSellOfferOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeSetOptions:
+		if u.SetOptionsOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SetOptionsOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeChangeTrust:
+		if u.ChangeTrustOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ChangeTrustOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeAllowTrust:
+		if u.AllowTrustOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AllowTrustOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeAccountMerge:
+		if u.Destination == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Destination.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeInflation:
+		// Void
+		return nil
+	case OperationTypeManageData:
+		if u.ManageDataOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageDataOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeBumpSequence:
+		if u.BumpSequenceOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.BumpSequenceOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeManageBuyOffer:
+		if u.ManageBuyOfferOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageBuyOfferOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePathPaymentStrictSend:
+		if u.PathPaymentStrictSendOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PathPaymentStrictSendOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeCreateClaimableBalance:
+		if u.CreateClaimableBalanceOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreateClaimableBalanceOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClaimClaimableBalance:
+		if u.ClaimClaimableBalanceOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClaimClaimableBalanceOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		if u.BeginSponsoringFutureReservesOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.BeginSponsoringFutureReservesOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeEndSponsoringFutureReserves:
+		// Void
+		return nil
+	case OperationTypeRevokeSponsorship:
+		if u.RevokeSponsorshipOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.RevokeSponsorshipOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClawback:
+		if u.ClawbackOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClawbackOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClawbackClaimableBalance:
+		if u.ClawbackClaimableBalanceOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClawbackClaimableBalanceOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeSetTrustLineFlags:
+		if u.SetTrustLineFlagsOp == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SetTrustLineFlagsOp.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *OperationBody) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = OperationBody{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = OperationType(sw)
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		u.CreateAccountOp = new(CreateAccountOp)
+		nTmp, err = u.CreateAccountOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePayment:
+		u.PaymentOp = new(PaymentOp)
+		nTmp, err = u.PaymentOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePathPaymentStrictReceive:
+		u.PathPaymentStrictReceiveOp = new(PathPaymentStrictReceiveOp)
+		nTmp, err = u.PathPaymentStrictReceiveOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeManageSellOffer:
+		u.ManageSellOfferOp = new(ManageSellOfferOp)
+		nTmp, err = u.ManageSellOfferOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeCreatePassiveSellOffer:
+		u.CreatePassiveSellOfferOp = new(CreatePassiveSellOfferOp)
+		nTmp, err = u.CreatePassiveSellOfferOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeSetOptions:
+		u.SetOptionsOp = new(SetOptionsOp)
+		nTmp, err = u.SetOptionsOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeChangeTrust:
+		u.ChangeTrustOp = new(ChangeTrustOp)
+		nTmp, err = u.ChangeTrustOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeAllowTrust:
+		u.AllowTrustOp = new(AllowTrustOp)
+		nTmp, err = u.AllowTrustOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeAccountMerge:
+		u.Destination = new(MuxedAccount)
+		nTmp, err = u.Destination.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeInflation:
+		// Void
+		return n, nil
+	case OperationTypeManageData:
+		u.ManageDataOp = new(ManageDataOp)
+		nTmp, err = u.ManageDataOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeBumpSequence:
+		u.BumpSequenceOp = new(BumpSequenceOp)
+		nTmp, err = u.BumpSequenceOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeManageBuyOffer:
+		u.ManageBuyOfferOp = new(ManageBuyOfferOp)
+		nTmp, err = u.ManageBuyOfferOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePathPaymentStrictSend:
+		u.PathPaymentStrictSendOp = new(PathPaymentStrictSendOp)
+		nTmp, err = u.PathPaymentStrictSendOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeCreateClaimableBalance:
+		u.CreateClaimableBalanceOp = new(CreateClaimableBalanceOp)
+		nTmp, err = u.CreateClaimableBalanceOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClaimClaimableBalance:
+		u.ClaimClaimableBalanceOp = new(ClaimClaimableBalanceOp)
+		nTmp, err = u.ClaimClaimableBalanceOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		u.BeginSponsoringFutureReservesOp = new(BeginSponsoringFutureReservesOp)
+		nTmp, err = u.BeginSponsoringFutureReservesOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeEndSponsoringFutureReserves:
+		// Void
+		return n, nil
+	case OperationTypeRevokeSponsorship:
+		u.RevokeSponsorshipOp = new(RevokeSponsorshipOp)
+		nTmp, err = u.RevokeSponsorshipOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClawback:
+		u.ClawbackOp = new(ClawbackOp)
+		nTmp, err = u.ClawbackOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClawbackClaimableBalance:
+		u.ClawbackClaimableBalanceOp = new(ClawbackClaimableBalanceOp)
+		nTmp, err = u.ClawbackClaimableBalanceOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeSetTrustLineFlags:
+		u.SetTrustLineFlagsOp = new(SetTrustLineFlagsOp)
+		nTmp, err = u.SetTrustLineFlagsOp.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u OperationBody) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if u.CreateAccountOp == nil {
+			return jsonFieldError("create_account_op", errEncodeNilUnionValue())
+		}
+		enc.key("create_account_op")
+		if err = u.CreateAccountOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_account_op", err)
+		}
+	case OperationTypePayment:
+		if u.PaymentOp == nil {
+			return jsonFieldError("payment_op", errEncodeNilUnionValue())
+		}
+		enc.key("payment_op")
+		if err = u.PaymentOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("payment_op", err)
+		}
+	case OperationTypePathPaymentStrictReceive:
+		if u.PathPaymentStrictReceiveOp == nil {
+			return jsonFieldError("path_payment_strict_receive_op", errEncodeNilUnionValue())
+		}
+		enc.key("path_payment_strict_receive_op")
+		if err = u.PathPaymentStrictReceiveOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("path_payment_strict_receive_op", err)
+		}
+	case OperationTypeManageSellOffer:
+		if u.ManageSellOfferOp == nil {
+			return jsonFieldError("manage_sell_offer_op", errEncodeNilUnionValue())
+		}
+		enc.key("manage_sell_offer_op")
+		if err = u.ManageSellOfferOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_sell_offer_op", err)
+		}
+	case OperationTypeCreatePassiveSellOffer:
+		if u.CreatePassiveSellOfferOp == nil {
+			return jsonFieldError("create_passive_sell_offer_op", errEncodeNilUnionValue())
+		}
+		enc.key("create_passive_sell_offer_op")
+		if err = u.CreatePassiveSellOfferOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_passive_sell_offer_op", err)
+		}
+	case OperationTypeSetOptions:
+		if u.SetOptionsOp == nil {
+			return jsonFieldError("set_options_op", errEncodeNilUnionValue())
+		}
+		enc.key("set_options_op")
+		if err = u.SetOptionsOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("set_options_op", err)
+		}
+	case OperationTypeChangeTrust:
+		if u.ChangeTrustOp == nil {
+			return jsonFieldError("change_trust_op", errEncodeNilUnionValue())
+		}
+		enc.key("change_trust_op")
+		if err = u.ChangeTrustOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("change_trust_op", err)
+		}
+	case OperationTypeAllowTrust:
+		if u.AllowTrustOp == nil {
+			return jsonFieldError("allow_trust_op", errEncodeNilUnionValue())
+		}
+		enc.key("allow_trust_op")
+		if err = u.AllowTrustOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("allow_trust_op", err)
+		}
+	case OperationTypeAccountMerge:
+		if u.Destination == nil {
+			return jsonFieldError("destination", errEncodeNilUnionValue())
+		}
+		enc.key("destination")
+		if err = u.Destination.encodeJSON(enc); err != nil {
+			return jsonFieldError("destination", err)
+		}
+	case OperationTypeInflation:
+		// Void
+	case OperationTypeManageData:
+		if u.ManageDataOp == nil {
+			return jsonFieldError("manage_data_op", errEncodeNilUnionValue())
+		}
+		enc.key("manage_data_op")
+		if err = u.ManageDataOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_data_op", err)
+		}
+	case OperationTypeBumpSequence:
+		if u.BumpSequenceOp == nil {
+			return jsonFieldError("bump_sequence_op", errEncodeNilUnionValue())
+		}
+		enc.key("bump_sequence_op")
+		if err = u.BumpSequenceOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("bump_sequence_op", err)
+		}
+	case OperationTypeManageBuyOffer:
+		if u.ManageBuyOfferOp == nil {
+			return jsonFieldError("manage_buy_offer_op", errEncodeNilUnionValue())
+		}
+		enc.key("manage_buy_offer_op")
+		if err = u.ManageBuyOfferOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_buy_offer_op", err)
+		}
+	case OperationTypePathPaymentStrictSend:
+		if u.PathPaymentStrictSendOp == nil {
+			return jsonFieldError("path_payment_strict_send_op", errEncodeNilUnionValue())
+		}
+		enc.key("path_payment_strict_send_op")
+		if err = u.PathPaymentStrictSendOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("path_payment_strict_send_op", err)
+		}
+	case OperationTypeCreateClaimableBalance:
+		if u.CreateClaimableBalanceOp == nil {
+			return jsonFieldError("create_claimable_balance_op", errEncodeNilUnionValue())
+		}
+		enc.key("create_claimable_balance_op")
+		if err = u.CreateClaimableBalanceOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_claimable_balance_op", err)
+		}
+	case OperationTypeClaimClaimableBalance:
+		if u.ClaimClaimableBalanceOp == nil {
+			return jsonFieldError("claim_claimable_balance_op", errEncodeNilUnionValue())
+		}
+		enc.key("claim_claimable_balance_op")
+		if err = u.ClaimClaimableBalanceOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("claim_claimable_balance_op", err)
+		}
+	case OperationTypeBeginSponsoringFutureReserves:
+		if u.BeginSponsoringFutureReservesOp == nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_op", errEncodeNilUnionValue())
+		}
+		enc.key("begin_sponsoring_future_reserves_op")
+		if err = u.BeginSponsoringFutureReservesOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_op", err)
+		}
+	case OperationTypeEndSponsoringFutureReserves:
+		// Void
+	case OperationTypeRevokeSponsorship:
+		if u.RevokeSponsorshipOp == nil {
+			return jsonFieldError("revoke_sponsorship_op", errEncodeNilUnionValue())
+		}
+		enc.key("revoke_sponsorship_op")
+		if err = u.RevokeSponsorshipOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("revoke_sponsorship_op", err)
+		}
+	case OperationTypeClawback:
+		if u.ClawbackOp == nil {
+			return jsonFieldError("clawback_op", errEncodeNilUnionValue())
+		}
+		enc.key("clawback_op")
+		if err = u.ClawbackOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("clawback_op", err)
+		}
+	case OperationTypeClawbackClaimableBalance:
+		if u.ClawbackClaimableBalanceOp == nil {
+			return jsonFieldError("clawback_claimable_balance_op", errEncodeNilUnionValue())
+		}
+		enc.key("clawback_claimable_balance_op")
+		if err = u.ClawbackClaimableBalanceOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("clawback_claimable_balance_op", err)
+		}
+	case OperationTypeSetTrustLineFlags:
+		if u.SetTrustLineFlagsOp == nil {
+			return jsonFieldError("set_trust_line_flags_op", errEncodeNilUnionValue())
+		}
+		enc.key("set_trust_line_flags_op")
+		if err = u.SetTrustLineFlagsOp.encodeJSON(enc); err != nil {
+			return jsonFieldError("set_trust_line_flags_op", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *OperationBody) decodeJSON(data []byte) error {
+	*u = OperationBody{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if err = checkJSONKeys(fields, "type", "create_account_op"); err != nil {
+			return err
+		}
+		u.CreateAccountOp = new(CreateAccountOp)
+		if err = u.CreateAccountOp.decodeJSON(fields["create_account_op"]); err != nil {
+			return jsonFieldError("create_account_op", err)
+		}
+		return nil
+	case OperationTypePayment:
+		if err = checkJSONKeys(fields, "type", "payment_op"); err != nil {
+			return err
+		}
+		u.PaymentOp = new(PaymentOp)
+		if err = u.PaymentOp.decodeJSON(fields["payment_op"]); err != nil {
+			return jsonFieldError("payment_op", err)
+		}
+		return nil
+	case OperationTypePathPaymentStrictReceive:
+		if err = checkJSONKeys(fields, "type", "path_payment_strict_receive_op"); err != nil {
+			return err
+		}
+		u.PathPaymentStrictReceiveOp = new(PathPaymentStrictReceiveOp)
+		if err = u.PathPaymentStrictReceiveOp.decodeJSON(fields["path_payment_strict_receive_op"]); err != nil {
+			return jsonFieldError("path_payment_strict_receive_op", err)
+		}
+		return nil
+	case OperationTypeManageSellOffer:
+		if err = checkJSONKeys(fields, "type", "manage_sell_offer_op"); err != nil {
+			return err
+		}
+		u.ManageSellOfferOp = new(ManageSellOfferOp)
+		if err = u.ManageSellOfferOp.decodeJSON(fields["manage_sell_offer_op"]); err != nil {
+			return jsonFieldError("manage_sell_offer_op", err)
+		}
+		return nil
+	case OperationTypeCreatePassiveSellOffer:
+		if err = checkJSONKeys(fields, "type", "create_passive_sell_offer_op"); err != nil {
+			return err
+		}
+		u.CreatePassiveSellOfferOp = new(CreatePassiveSellOfferOp)
+		if err = u.CreatePassiveSellOfferOp.decodeJSON(fields["create_passive_sell_offer_op"]); err != nil {
+			return jsonFieldError("create_passive_sell_offer_op", err)
+		}
+		return nil
+	case OperationTypeSetOptions:
+		if err = checkJSONKeys(fields, "type", "set_options_op"); err != nil {
+			return err
+		}
+		u.SetOptionsOp = new(SetOptionsOp)
+		if err = u.SetOptionsOp.decodeJSON(fields["set_options_op"]); err != nil {
+			return jsonFieldError("set_options_op", err)
+		}
+		return nil
+	case OperationTypeChangeTrust:
+		if err = checkJSONKeys(fields, "type", "change_trust_op"); err != nil {
+			return err
+		}
+		u.ChangeTrustOp = new(ChangeTrustOp)
+		if err = u.ChangeTrustOp.decodeJSON(fields["change_trust_op"]); err != nil {
+			return jsonFieldError("change_trust_op", err)
+		}
+		return nil
+	case OperationTypeAllowTrust:
+		if err = checkJSONKeys(fields, "type", "allow_trust_op"); err != nil {
+			return err
+		}
+		u.AllowTrustOp = new(AllowTrustOp)
+		if err = u.AllowTrustOp.decodeJSON(fields["allow_trust_op"]); err != nil {
+			return jsonFieldError("allow_trust_op", err)
+		}
+		return nil
+	case OperationTypeAccountMerge:
+		if err = checkJSONKeys(fields, "type", "destination"); err != nil {
+			return err
+		}
+		u.Destination = new(MuxedAccount)
+		if err = u.Destination.decodeJSON(fields["destination"]); err != nil {
+			return jsonFieldError("destination", err)
+		}
+		return nil
+	case OperationTypeInflation:
+		// Void
+		return checkJSONKeys(fields, "type")
+	case OperationTypeManageData:
+		if err = checkJSONKeys(fields, "type", "manage_data_op"); err != nil {
+			return err
+		}
+		u.ManageDataOp = new(ManageDataOp)
+		if err = u.ManageDataOp.decodeJSON(fields["manage_data_op"]); err != nil {
+			return jsonFieldError("manage_data_op", err)
+		}
+		return nil
+	case OperationTypeBumpSequence:
+		if err = checkJSONKeys(fields, "type", "bump_sequence_op"); err != nil {
+			return err
+		}
+		u.BumpSequenceOp = new(BumpSequenceOp)
+		if err = u.BumpSequenceOp.decodeJSON(fields["bump_sequence_op"]); err != nil {
+			return jsonFieldError("bump_sequence_op", err)
+		}
+		return nil
+	case OperationTypeManageBuyOffer:
+		if err = checkJSONKeys(fields, "type", "manage_buy_offer_op"); err != nil {
+			return err
+		}
+		u.ManageBuyOfferOp = new(ManageBuyOfferOp)
+		if err = u.ManageBuyOfferOp.decodeJSON(fields["manage_buy_offer_op"]); err != nil {
+			return jsonFieldError("manage_buy_offer_op", err)
+		}
+		return nil
+	case OperationTypePathPaymentStrictSend:
+		if err = checkJSONKeys(fields, "type", "path_payment_strict_send_op"); err != nil {
+			return err
+		}
+		u.PathPaymentStrictSendOp = new(PathPaymentStrictSendOp)
+		if err = u.PathPaymentStrictSendOp.decodeJSON(fields["path_payment_strict_send_op"]); err != nil {
+			return jsonFieldError("path_payment_strict_send_op", err)
+		}
+		return nil
+	case OperationTypeCreateClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "create_claimable_balance_op"); err != nil {
+			return err
+		}
+		u.CreateClaimableBalanceOp = new(CreateClaimableBalanceOp)
+		if err = u.CreateClaimableBalanceOp.decodeJSON(fields["create_claimable_balance_op"]); err != nil {
+			return jsonFieldError("create_claimable_balance_op", err)
+		}
+		return nil
+	case OperationTypeClaimClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "claim_claimable_balance_op"); err != nil {
+			return err
+		}
+		u.ClaimClaimableBalanceOp = new(ClaimClaimableBalanceOp)
+		if err = u.ClaimClaimableBalanceOp.decodeJSON(fields["claim_claimable_balance_op"]); err != nil {
+			return jsonFieldError("claim_claimable_balance_op", err)
+		}
+		return nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		if err = checkJSONKeys(fields, "type", "begin_sponsoring_future_reserves_op"); err != nil {
+			return err
+		}
+		u.BeginSponsoringFutureReservesOp = new(BeginSponsoringFutureReservesOp)
+		if err = u.BeginSponsoringFutureReservesOp.decodeJSON(fields["begin_sponsoring_future_reserves_op"]); err != nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_op", err)
+		}
+		return nil
+	case OperationTypeEndSponsoringFutureReserves:
+		// Void
+		return checkJSONKeys(fields, "type")
+	case OperationTypeRevokeSponsorship:
+		if err = checkJSONKeys(fields, "type", "revoke_sponsorship_op"); err != nil {
+			return err
+		}
+		u.RevokeSponsorshipOp = new(RevokeSponsorshipOp)
+		if err = u.RevokeSponsorshipOp.decodeJSON(fields["revoke_sponsorship_op"]); err != nil {
+			return jsonFieldError("revoke_sponsorship_op", err)
+		}
+		return nil
+	case OperationTypeClawback:
+		if err = checkJSONKeys(fields, "type", "clawback_op"); err != nil {
+			return err
+		}
+		u.ClawbackOp = new(ClawbackOp)
+		if err = u.ClawbackOp.decodeJSON(fields["clawback_op"]); err != nil {
+			return jsonFieldError("clawback_op", err)
+		}
+		return nil
+	case OperationTypeClawbackClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "clawback_claimable_balance_op"); err != nil {
+			return err
+		}
+		u.ClawbackClaimableBalanceOp = new(ClawbackClaimableBalanceOp)
+		if err = u.ClawbackClaimableBalanceOp.decodeJSON(fields["clawback_claimable_balance_op"]); err != nil {
+			return jsonFieldError("clawback_claimable_balance_op", err)
+		}
+		return nil
+	case OperationTypeSetTrustLineFlags:
+		if err = checkJSONKeys(fields, "type", "set_trust_line_flags_op"); err != nil {
+			return err
+		}
+		u.SetTrustLineFlagsOp = new(SetTrustLineFlagsOp)
+		if err = u.SetTrustLineFlagsOp.decodeJSON(fields["set_trust_line_flags_op"]); err != nil {
+			return jsonFieldError("set_trust_line_flags_op", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -10366,6 +25416,89 @@ type Operation struct {
 	Body          OperationBody
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Operation) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeBool(s.SourceAccount != nil); err != nil {
+		return err
+	}
+	if s.SourceAccount != nil {
+		if err = s.SourceAccount.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Body.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Operation) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var present bool
+	var err error
+	var n, nTmp int
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.SourceAccount = nil
+	if present {
+		s.SourceAccount = new(MuxedAccount)
+		nTmp, err = s.SourceAccount.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Body.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Operation) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("source_account")
+	if s.SourceAccount == nil {
+		enc.null()
+	} else {
+		if err = s.SourceAccount.encodeJSON(enc); err != nil {
+			return jsonFieldError("source_account", err)
+		}
+	}
+	enc.key("body")
+	if err = s.Body.encodeJSON(enc); err != nil {
+		return jsonFieldError("body", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Operation) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "source_account", "body")
+	if err != nil {
+		return err
+	}
+	s.SourceAccount = nil
+	if !isJSONNull(fields["source_account"]) {
+		s.SourceAccount = new(MuxedAccount)
+		if err = s.SourceAccount.decodeJSON(fields["source_account"]); err != nil {
+			return jsonFieldError("source_account", err)
+		}
+	}
+	if err = s.Body.decodeJSON(fields["body"]); err != nil {
+		return jsonFieldError("body", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Operation) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10399,6 +25532,81 @@ type OperationIdId struct {
 	OpNum         Uint32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s OperationIdId) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SourceAccount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SeqNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OpNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *OperationIdId) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SourceAccount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SeqNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OpNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s OperationIdId) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("source_account")
+	if err = s.SourceAccount.encodeJSON(enc); err != nil {
+		return jsonFieldError("source_account", err)
+	}
+	enc.key("seq_num")
+	if err = s.SeqNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	enc.key("op_num")
+	if err = s.OpNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("op_num", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *OperationIdId) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "source_account", "seq_num", "op_num")
+	if err != nil {
+		return err
+	}
+	if err = s.SourceAccount.decodeJSON(fields["source_account"]); err != nil {
+		return jsonFieldError("source_account", err)
+	}
+	if err = s.SeqNum.decodeJSON(fields["seq_num"]); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	if err = s.OpNum.decodeJSON(fields["op_num"]); err != nil {
+		return jsonFieldError("op_num", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationIdId) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10491,6 +25699,101 @@ func (u OperationId) GetId() (result OperationIdId, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u OperationId) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeOpId:
+		if u.Id == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Id.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *OperationId) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = OperationId{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = EnvelopeType(sw)
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeOpId:
+		u.Id = new(OperationIdId)
+		nTmp, err = u.Id.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u OperationId) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeOpId:
+		if u.Id == nil {
+			return jsonFieldError("id", errEncodeNilUnionValue())
+		}
+		enc.key("id")
+		if err = u.Id.encodeJSON(enc); err != nil {
+			return jsonFieldError("id", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *OperationId) decodeJSON(data []byte) error {
+	*u = OperationId{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeOpId:
+		if err = checkJSONKeys(fields, "type", "id"); err != nil {
+			return err
+		}
+		u.Id = new(OperationIdId)
+		if err = u.Id.decodeJSON(fields["id"]); err != nil {
+			return jsonFieldError("id", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationId) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10551,6 +25854,56 @@ func (e MemoType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e MemoType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *MemoType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = MemoType(v)
+	return n, nil
+}
+
+var memoTypeJSONNames = map[int32]string{
+	0: "memo_none",
+	1: "memo_text",
+	2: "memo_id",
+	3: "memo_hash",
+	4: "memo_return",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e MemoType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := memoTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *MemoType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, memoTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = MemoType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s MemoType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10755,6 +26108,209 @@ func (u Memo) GetRetHash() (result Hash, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u Memo) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch MemoType(u.Type) {
+	case MemoTypeMemoNone:
+		// Void
+		return nil
+	case MemoTypeMemoText:
+		if u.Text == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeString(*u.Text); err != nil {
+			return err
+		}
+		return nil
+	case MemoTypeMemoId:
+		if u.Id == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Id.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MemoTypeMemoHash:
+		if u.Hash == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Hash.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case MemoTypeMemoReturn:
+		if u.RetHash == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.RetHash.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *Memo) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = Memo{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = MemoType(sw)
+	switch MemoType(u.Type) {
+	case MemoTypeMemoNone:
+		// Void
+		return n, nil
+	case MemoTypeMemoText:
+		u.Text = new(string)
+		*u.Text, nTmp, err = dec.DecodeString(28)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MemoTypeMemoId:
+		u.Id = new(Uint64)
+		nTmp, err = u.Id.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MemoTypeMemoHash:
+		u.Hash = new(Hash)
+		nTmp, err = u.Hash.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case MemoTypeMemoReturn:
+		u.RetHash = new(Hash)
+		nTmp, err = u.RetHash.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u Memo) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch MemoType(u.Type) {
+	case MemoTypeMemoNone:
+		// Void
+	case MemoTypeMemoText:
+		if u.Text == nil {
+			return jsonFieldError("text", errEncodeNilUnionValue())
+		}
+		enc.key("text")
+		enc.string(*u.Text)
+	case MemoTypeMemoId:
+		if u.Id == nil {
+			return jsonFieldError("id", errEncodeNilUnionValue())
+		}
+		enc.key("id")
+		if err = u.Id.encodeJSON(enc); err != nil {
+			return jsonFieldError("id", err)
+		}
+	case MemoTypeMemoHash:
+		if u.Hash == nil {
+			return jsonFieldError("hash", errEncodeNilUnionValue())
+		}
+		enc.key("hash")
+		if err = u.Hash.encodeJSON(enc); err != nil {
+			return jsonFieldError("hash", err)
+		}
+	case MemoTypeMemoReturn:
+		if u.RetHash == nil {
+			return jsonFieldError("ret_hash", errEncodeNilUnionValue())
+		}
+		enc.key("ret_hash")
+		if err = u.RetHash.encodeJSON(enc); err != nil {
+			return jsonFieldError("ret_hash", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *Memo) decodeJSON(data []byte) error {
+	*u = Memo{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch MemoType(u.Type) {
+	case MemoTypeMemoNone:
+		// Void
+		return checkJSONKeys(fields, "type")
+	case MemoTypeMemoText:
+		if err = checkJSONKeys(fields, "type", "text"); err != nil {
+			return err
+		}
+		u.Text = new(string)
+		if *u.Text, err = decodeJSONString(fields["text"], 28); err != nil {
+			return jsonFieldError("text", err)
+		}
+		return nil
+	case MemoTypeMemoId:
+		if err = checkJSONKeys(fields, "type", "id"); err != nil {
+			return err
+		}
+		u.Id = new(Uint64)
+		if err = u.Id.decodeJSON(fields["id"]); err != nil {
+			return jsonFieldError("id", err)
+		}
+		return nil
+	case MemoTypeMemoHash:
+		if err = checkJSONKeys(fields, "type", "hash"); err != nil {
+			return err
+		}
+		u.Hash = new(Hash)
+		if err = u.Hash.decodeJSON(fields["hash"]); err != nil {
+			return jsonFieldError("hash", err)
+		}
+		return nil
+	case MemoTypeMemoReturn:
+		if err = checkJSONKeys(fields, "type", "ret_hash"); err != nil {
+			return err
+		}
+		u.RetHash = new(Hash)
+		if err = u.RetHash.decodeJSON(fields["ret_hash"]); err != nil {
+			return jsonFieldError("ret_hash", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Memo) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10786,6 +26342,66 @@ type TimeBounds struct {
 	MaxTime TimePoint
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TimeBounds) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.MinTime.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.MaxTime.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TimeBounds) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.MinTime.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.MaxTime.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TimeBounds) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("min_time")
+	if err = s.MinTime.encodeJSON(enc); err != nil {
+		return jsonFieldError("min_time", err)
+	}
+	enc.key("max_time")
+	if err = s.MaxTime.encodeJSON(enc); err != nil {
+		return jsonFieldError("max_time", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TimeBounds) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "min_time", "max_time")
+	if err != nil {
+		return err
+	}
+	if err = s.MinTime.decodeJSON(fields["min_time"]); err != nil {
+		return jsonFieldError("min_time", err)
+	}
+	if err = s.MaxTime.decodeJSON(fields["max_time"]); err != nil {
+		return jsonFieldError("max_time", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TimeBounds) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10848,6 +26464,73 @@ func NewTransactionV0Ext(v int32, value interface{}) (result TransactionV0Ext, e
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionV0Ext) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionV0Ext) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionV0Ext{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionV0Ext) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionV0Ext) decodeJSON(data []byte) error {
+	*u = TransactionV0Ext{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionV0Ext) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10894,6 +26577,200 @@ type TransactionV0 struct {
 	Ext                  TransactionV0Ext
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionV0) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SourceAccountEd25519.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Fee.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SeqNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeBool(s.TimeBounds != nil); err != nil {
+		return err
+	}
+	if s.TimeBounds != nil {
+		if err = s.TimeBounds.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Memo.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Operations))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Operations); i++ {
+		if err = s.Operations[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionV0) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SourceAccountEd25519.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Fee.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SeqNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.TimeBounds = nil
+	if present {
+		s.TimeBounds = new(TimeBounds)
+		nTmp, err = s.TimeBounds.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Memo.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 100 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Operations = nil
+	if l > 0 {
+		s.Operations = make([]Operation, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Operations = append(s.Operations, Operation{})
+			nTmp, err = s.Operations[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionV0) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("source_account_ed25519")
+	if err = s.SourceAccountEd25519.encodeJSON(enc); err != nil {
+		return jsonFieldError("source_account_ed25519", err)
+	}
+	enc.key("fee")
+	if err = s.Fee.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	enc.key("seq_num")
+	if err = s.SeqNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	enc.key("time_bounds")
+	if s.TimeBounds == nil {
+		enc.null()
+	} else {
+		if err = s.TimeBounds.encodeJSON(enc); err != nil {
+			return jsonFieldError("time_bounds", err)
+		}
+	}
+	enc.key("memo")
+	if err = s.Memo.encodeJSON(enc); err != nil {
+		return jsonFieldError("memo", err)
+	}
+	enc.key("operations")
+	enc.beginArray()
+	for i := 0; i < len(s.Operations); i++ {
+		enc.element()
+		if err = s.Operations[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionV0) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "source_account_ed25519", "fee", "seq_num", "time_bounds", "memo", "operations", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.SourceAccountEd25519.decodeJSON(fields["source_account_ed25519"]); err != nil {
+		return jsonFieldError("source_account_ed25519", err)
+	}
+	if err = s.Fee.decodeJSON(fields["fee"]); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	if err = s.SeqNum.decodeJSON(fields["seq_num"]); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	s.TimeBounds = nil
+	if !isJSONNull(fields["time_bounds"]) {
+		s.TimeBounds = new(TimeBounds)
+		if err = s.TimeBounds.decodeJSON(fields["time_bounds"]); err != nil {
+			return jsonFieldError("time_bounds", err)
+		}
+	}
+	if err = s.Memo.decodeJSON(fields["memo"]); err != nil {
+		return jsonFieldError("memo", err)
+	}
+	if elems, err = decodeJSONArray(fields["operations"], 100); err != nil {
+		return jsonFieldError("operations", err)
+	}
+	s.Operations = nil
+	if len(elems) > 0 {
+		s.Operations = make([]Operation, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Operations[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionV0) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10927,6 +26804,102 @@ type TransactionV0Envelope struct {
 	Signatures []DecoratedSignature `xdrmaxsize:"20"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionV0Envelope) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Tx.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Signatures))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Signatures); i++ {
+		if err = s.Signatures[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionV0Envelope) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Tx.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 20 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Signatures = nil
+	if l > 0 {
+		s.Signatures = make([]DecoratedSignature, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Signatures = append(s.Signatures, DecoratedSignature{})
+			nTmp, err = s.Signatures[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionV0Envelope) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx")
+	if err = s.Tx.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	enc.key("signatures")
+	enc.beginArray()
+	for i := 0; i < len(s.Signatures); i++ {
+		enc.element()
+		if err = s.Signatures[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionV0Envelope) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx", "signatures")
+	if err != nil {
+		return err
+	}
+	if err = s.Tx.decodeJSON(fields["tx"]); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	if elems, err = decodeJSONArray(fields["signatures"], 20); err != nil {
+		return jsonFieldError("signatures", err)
+	}
+	s.Signatures = nil
+	if len(elems) > 0 {
+		s.Signatures = make([]DecoratedSignature, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Signatures[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionV0Envelope) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -10983,6 +26956,73 @@ func NewTransactionExt(v int32, value interface{}) (result TransactionExt, err e
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionExt) decodeJSON(data []byte) error {
+	*u = TransactionExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11040,6 +27080,200 @@ type Transaction struct {
 	Ext           TransactionExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Transaction) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SourceAccount.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Fee.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.SeqNum.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeBool(s.TimeBounds != nil); err != nil {
+		return err
+	}
+	if s.TimeBounds != nil {
+		if err = s.TimeBounds.EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Memo.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Operations))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Operations); i++ {
+		if err = s.Operations[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Transaction) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var present bool
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SourceAccount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Fee.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.SeqNum.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	present, nTmp, err = dec.DecodeBool()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	s.TimeBounds = nil
+	if present {
+		s.TimeBounds = new(TimeBounds)
+		nTmp, err = s.TimeBounds.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+	}
+	nTmp, err = s.Memo.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 100 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Operations = nil
+	if l > 0 {
+		s.Operations = make([]Operation, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Operations = append(s.Operations, Operation{})
+			nTmp, err = s.Operations[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Transaction) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("source_account")
+	if err = s.SourceAccount.encodeJSON(enc); err != nil {
+		return jsonFieldError("source_account", err)
+	}
+	enc.key("fee")
+	if err = s.Fee.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	enc.key("seq_num")
+	if err = s.SeqNum.encodeJSON(enc); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	enc.key("time_bounds")
+	if s.TimeBounds == nil {
+		enc.null()
+	} else {
+		if err = s.TimeBounds.encodeJSON(enc); err != nil {
+			return jsonFieldError("time_bounds", err)
+		}
+	}
+	enc.key("memo")
+	if err = s.Memo.encodeJSON(enc); err != nil {
+		return jsonFieldError("memo", err)
+	}
+	enc.key("operations")
+	enc.beginArray()
+	for i := 0; i < len(s.Operations); i++ {
+		enc.element()
+		if err = s.Operations[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Transaction) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "source_account", "fee", "seq_num", "time_bounds", "memo", "operations", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.SourceAccount.decodeJSON(fields["source_account"]); err != nil {
+		return jsonFieldError("source_account", err)
+	}
+	if err = s.Fee.decodeJSON(fields["fee"]); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	if err = s.SeqNum.decodeJSON(fields["seq_num"]); err != nil {
+		return jsonFieldError("seq_num", err)
+	}
+	s.TimeBounds = nil
+	if !isJSONNull(fields["time_bounds"]) {
+		s.TimeBounds = new(TimeBounds)
+		if err = s.TimeBounds.decodeJSON(fields["time_bounds"]); err != nil {
+			return jsonFieldError("time_bounds", err)
+		}
+	}
+	if err = s.Memo.decodeJSON(fields["memo"]); err != nil {
+		return jsonFieldError("memo", err)
+	}
+	if elems, err = decodeJSONArray(fields["operations"], 100); err != nil {
+		return jsonFieldError("operations", err)
+	}
+	s.Operations = nil
+	if len(elems) > 0 {
+		s.Operations = make([]Operation, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Operations[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("operations", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Transaction) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11073,6 +27307,102 @@ type TransactionV1Envelope struct {
 	Signatures []DecoratedSignature `xdrmaxsize:"20"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionV1Envelope) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Tx.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Signatures))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Signatures); i++ {
+		if err = s.Signatures[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionV1Envelope) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Tx.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 20 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Signatures = nil
+	if l > 0 {
+		s.Signatures = make([]DecoratedSignature, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Signatures = append(s.Signatures, DecoratedSignature{})
+			nTmp, err = s.Signatures[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionV1Envelope) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx")
+	if err = s.Tx.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	enc.key("signatures")
+	enc.beginArray()
+	for i := 0; i < len(s.Signatures); i++ {
+		enc.element()
+		if err = s.Signatures[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionV1Envelope) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx", "signatures")
+	if err != nil {
+		return err
+	}
+	if err = s.Tx.decodeJSON(fields["tx"]); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	if elems, err = decodeJSONArray(fields["signatures"], 20); err != nil {
+		return jsonFieldError("signatures", err)
+	}
+	s.Signatures = nil
+	if len(elems) > 0 {
+		s.Signatures = make([]DecoratedSignature, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Signatures[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionV1Envelope) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11160,6 +27490,101 @@ func (u FeeBumpTransactionInnerTx) GetV1() (result TransactionV1Envelope, ok boo
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u FeeBumpTransactionInnerTx) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *FeeBumpTransactionInnerTx) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = FeeBumpTransactionInnerTx{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = EnvelopeType(sw)
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		u.V1 = new(TransactionV1Envelope)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u FeeBumpTransactionInnerTx) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *FeeBumpTransactionInnerTx) decodeJSON(data []byte) error {
+	*u = FeeBumpTransactionInnerTx{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if err = checkJSONKeys(fields, "type", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(TransactionV1Envelope)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s FeeBumpTransactionInnerTx) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11216,6 +27641,73 @@ func NewFeeBumpTransactionExt(v int32, value interface{}) (result FeeBumpTransac
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u FeeBumpTransactionExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *FeeBumpTransactionExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = FeeBumpTransactionExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u FeeBumpTransactionExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *FeeBumpTransactionExt) decodeJSON(data []byte) error {
+	*u = FeeBumpTransactionExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s FeeBumpTransactionExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11261,6 +27753,96 @@ type FeeBumpTransaction struct {
 	Ext       FeeBumpTransactionExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s FeeBumpTransaction) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.FeeSource.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Fee.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.InnerTx.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *FeeBumpTransaction) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.FeeSource.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Fee.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.InnerTx.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s FeeBumpTransaction) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("fee_source")
+	if err = s.FeeSource.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee_source", err)
+	}
+	enc.key("fee")
+	if err = s.Fee.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	enc.key("inner_tx")
+	if err = s.InnerTx.encodeJSON(enc); err != nil {
+		return jsonFieldError("inner_tx", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *FeeBumpTransaction) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "fee_source", "fee", "inner_tx", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.FeeSource.decodeJSON(fields["fee_source"]); err != nil {
+		return jsonFieldError("fee_source", err)
+	}
+	if err = s.Fee.decodeJSON(fields["fee"]); err != nil {
+		return jsonFieldError("fee", err)
+	}
+	if err = s.InnerTx.decodeJSON(fields["inner_tx"]); err != nil {
+		return jsonFieldError("inner_tx", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s FeeBumpTransaction) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11294,6 +27876,102 @@ type FeeBumpTransactionEnvelope struct {
 	Signatures []DecoratedSignature `xdrmaxsize:"20"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s FeeBumpTransactionEnvelope) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Tx.EncodeTo(enc); err != nil {
+		return err
+	}
+	if _, err = enc.EncodeUint(uint32(len(s.Signatures))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Signatures); i++ {
+		if err = s.Signatures[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *FeeBumpTransactionEnvelope) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Tx.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > 20 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Signatures = nil
+	if l > 0 {
+		s.Signatures = make([]DecoratedSignature, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Signatures = append(s.Signatures, DecoratedSignature{})
+			nTmp, err = s.Signatures[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s FeeBumpTransactionEnvelope) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("tx")
+	if err = s.Tx.encodeJSON(enc); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	enc.key("signatures")
+	enc.beginArray()
+	for i := 0; i < len(s.Signatures); i++ {
+		enc.element()
+		if err = s.Signatures[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *FeeBumpTransactionEnvelope) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "tx", "signatures")
+	if err != nil {
+		return err
+	}
+	if err = s.Tx.decodeJSON(fields["tx"]); err != nil {
+		return jsonFieldError("tx", err)
+	}
+	if elems, err = decodeJSONArray(fields["signatures"], 20); err != nil {
+		return jsonFieldError("signatures", err)
+	}
+	s.Signatures = nil
+	if len(elems) > 0 {
+		s.Signatures = make([]DecoratedSignature, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Signatures[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("signatures", jsonIndexError(i, err))
+		}
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s FeeBumpTransactionEnvelope) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11438,21 +28116,182 @@ func (u TransactionEnvelope) MustFeeBump() FeeBumpTransactionEnvelope {
 	if !ok {
 		panic("arm FeeBump is not set")
 	}
-
-	return val
+
+	return val
+}
+
+// GetFeeBump retrieves the FeeBump value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionEnvelope) GetFeeBump() (result FeeBumpTransactionEnvelope, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "FeeBump" {
+		result = *u.FeeBump
+		ok = true
+	}
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionEnvelope) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		if u.V0 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V0.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.V1 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.V1.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if u.FeeBump == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.FeeBump.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionEnvelope) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionEnvelope{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = EnvelopeType(sw)
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		u.V0 = new(TransactionV0Envelope)
+		nTmp, err = u.V0.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case EnvelopeTypeEnvelopeTypeTx:
+		u.V1 = new(TransactionV1Envelope)
+		nTmp, err = u.V1.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		u.FeeBump = new(FeeBumpTransactionEnvelope)
+		nTmp, err = u.FeeBump.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionEnvelope) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		if u.V0 == nil {
+			return jsonFieldError("v0", errEncodeNilUnionValue())
+		}
+		enc.key("v0")
+		if err = u.V0.encodeJSON(enc); err != nil {
+			return jsonFieldError("v0", err)
+		}
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.V1 == nil {
+			return jsonFieldError("v1", errEncodeNilUnionValue())
+		}
+		enc.key("v1")
+		if err = u.V1.encodeJSON(enc); err != nil {
+			return jsonFieldError("v1", err)
+		}
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if u.FeeBump == nil {
+			return jsonFieldError("fee_bump", errEncodeNilUnionValue())
+		}
+		enc.key("fee_bump")
+		if err = u.FeeBump.encodeJSON(enc); err != nil {
+			return jsonFieldError("fee_bump", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
 }
 
-// GetFeeBump retrieves the FeeBump value from the union,
-// returning ok if the union's switch indicated the value is valid.
-func (u TransactionEnvelope) GetFeeBump() (result FeeBumpTransactionEnvelope, ok bool) {
-	armName, _ := u.ArmForSwitch(int32(u.Type))
-
-	if armName == "FeeBump" {
-		result = *u.FeeBump
-		ok = true
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionEnvelope) decodeJSON(data []byte) error {
+	*u = TransactionEnvelope{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
 	}
-
-	return
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		if err = checkJSONKeys(fields, "type", "v0"); err != nil {
+			return err
+		}
+		u.V0 = new(TransactionV0Envelope)
+		if err = u.V0.decodeJSON(fields["v0"]); err != nil {
+			return jsonFieldError("v0", err)
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTx:
+		if err = checkJSONKeys(fields, "type", "v1"); err != nil {
+			return err
+		}
+		u.V1 = new(TransactionV1Envelope)
+		if err = u.V1.decodeJSON(fields["v1"]); err != nil {
+			return jsonFieldError("v1", err)
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if err = checkJSONKeys(fields, "type", "fee_bump"); err != nil {
+			return err
+		}
+		u.FeeBump = new(FeeBumpTransactionEnvelope)
+		if err = u.FeeBump.decodeJSON(fields["fee_bump"]); err != nil {
+			return jsonFieldError("fee_bump", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -11580,6 +28419,134 @@ func (u TransactionSignaturePayloadTaggedTransaction) GetFeeBump() (result FeeBu
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionSignaturePayloadTaggedTransaction) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.Tx == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Tx.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if u.FeeBump == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.FeeBump.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionSignaturePayloadTaggedTransaction) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionSignaturePayloadTaggedTransaction{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = EnvelopeType(sw)
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		u.Tx = new(Transaction)
+		nTmp, err = u.Tx.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		u.FeeBump = new(FeeBumpTransaction)
+		nTmp, err = u.FeeBump.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionSignaturePayloadTaggedTransaction) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if u.Tx == nil {
+			return jsonFieldError("tx", errEncodeNilUnionValue())
+		}
+		enc.key("tx")
+		if err = u.Tx.encodeJSON(enc); err != nil {
+			return jsonFieldError("tx", err)
+		}
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if u.FeeBump == nil {
+			return jsonFieldError("fee_bump", errEncodeNilUnionValue())
+		}
+		enc.key("fee_bump")
+		if err = u.FeeBump.encodeJSON(enc); err != nil {
+			return jsonFieldError("fee_bump", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionSignaturePayloadTaggedTransaction) decodeJSON(data []byte) error {
+	*u = TransactionSignaturePayloadTaggedTransaction{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch EnvelopeType(u.Type) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		if err = checkJSONKeys(fields, "type", "tx"); err != nil {
+			return err
+		}
+		u.Tx = new(Transaction)
+		if err = u.Tx.decodeJSON(fields["tx"]); err != nil {
+			return jsonFieldError("tx", err)
+		}
+		return nil
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if err = checkJSONKeys(fields, "type", "fee_bump"); err != nil {
+			return err
+		}
+		u.FeeBump = new(FeeBumpTransaction)
+		if err = u.FeeBump.decodeJSON(fields["fee_bump"]); err != nil {
+			return jsonFieldError("fee_bump", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionSignaturePayloadTaggedTransaction) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11619,6 +28586,66 @@ type TransactionSignaturePayload struct {
 	TaggedTransaction TransactionSignaturePayloadTaggedTransaction
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionSignaturePayload) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.NetworkId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.TaggedTransaction.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionSignaturePayload) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.NetworkId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.TaggedTransaction.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionSignaturePayload) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("network_id")
+	if err = s.NetworkId.encodeJSON(enc); err != nil {
+		return jsonFieldError("network_id", err)
+	}
+	enc.key("tagged_transaction")
+	if err = s.TaggedTransaction.encodeJSON(enc); err != nil {
+		return jsonFieldError("tagged_transaction", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionSignaturePayload) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "network_id", "tagged_transaction")
+	if err != nil {
+		return err
+	}
+	if err = s.NetworkId.decodeJSON(fields["network_id"]); err != nil {
+		return jsonFieldError("network_id", err)
+	}
+	if err = s.TaggedTransaction.decodeJSON(fields["tagged_transaction"]); err != nil {
+		return jsonFieldError("tagged_transaction", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionSignaturePayload) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11663,6 +28690,126 @@ type ClaimOfferAtom struct {
 	AmountBought Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ClaimOfferAtom) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.SellerId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.OfferId.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.AssetSold.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.AmountSold.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.AssetBought.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.AmountBought.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ClaimOfferAtom) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.SellerId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.OfferId.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.AssetSold.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.AmountSold.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.AssetBought.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.AmountBought.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ClaimOfferAtom) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("seller_id")
+	if err = s.SellerId.encodeJSON(enc); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	enc.key("offer_id")
+	if err = s.OfferId.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	enc.key("asset_sold")
+	if err = s.AssetSold.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset_sold", err)
+	}
+	enc.key("amount_sold")
+	if err = s.AmountSold.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount_sold", err)
+	}
+	enc.key("asset_bought")
+	if err = s.AssetBought.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset_bought", err)
+	}
+	enc.key("amount_bought")
+	if err = s.AmountBought.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount_bought", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ClaimOfferAtom) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "seller_id", "offer_id", "asset_sold", "amount_sold", "asset_bought", "amount_bought")
+	if err != nil {
+		return err
+	}
+	if err = s.SellerId.decodeJSON(fields["seller_id"]); err != nil {
+		return jsonFieldError("seller_id", err)
+	}
+	if err = s.OfferId.decodeJSON(fields["offer_id"]); err != nil {
+		return jsonFieldError("offer_id", err)
+	}
+	if err = s.AssetSold.decodeJSON(fields["asset_sold"]); err != nil {
+		return jsonFieldError("asset_sold", err)
+	}
+	if err = s.AmountSold.decodeJSON(fields["amount_sold"]); err != nil {
+		return jsonFieldError("amount_sold", err)
+	}
+	if err = s.AssetBought.decodeJSON(fields["asset_bought"]); err != nil {
+		return jsonFieldError("asset_bought", err)
+	}
+	if err = s.AmountBought.decodeJSON(fields["amount_bought"]); err != nil {
+		return jsonFieldError("amount_bought", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimOfferAtom) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11727,6 +28874,56 @@ func (e CreateAccountResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e CreateAccountResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *CreateAccountResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = CreateAccountResultCode(v)
+	return n, nil
+}
+
+var createAccountResultCodeJSONNames = map[int32]string{
+	0:  "create_account_success",
+	-1: "create_account_malformed",
+	-2: "create_account_underfunded",
+	-3: "create_account_low_reserve",
+	-4: "create_account_already_exist",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e CreateAccountResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := createAccountResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *CreateAccountResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, createAccountResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = CreateAccountResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateAccountResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11788,6 +28985,85 @@ func NewCreateAccountResult(code CreateAccountResultCode, value interface{}) (re
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u CreateAccountResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch CreateAccountResultCode(u.Code) {
+	case CreateAccountResultCodeCreateAccountSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *CreateAccountResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = CreateAccountResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = CreateAccountResultCode(sw)
+	switch CreateAccountResultCode(u.Code) {
+	case CreateAccountResultCodeCreateAccountSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u CreateAccountResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch CreateAccountResultCode(u.Code) {
+	case CreateAccountResultCodeCreateAccountSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *CreateAccountResult) decodeJSON(data []byte) error {
+	*u = CreateAccountResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch CreateAccountResultCode(u.Code) {
+	case CreateAccountResultCodeCreateAccountSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateAccountResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11866,6 +29142,61 @@ func (e PaymentResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e PaymentResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *PaymentResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = PaymentResultCode(v)
+	return n, nil
+}
+
+var paymentResultCodeJSONNames = map[int32]string{
+	0:  "payment_success",
+	-1: "payment_malformed",
+	-2: "payment_underfunded",
+	-3: "payment_src_no_trust",
+	-4: "payment_src_not_authorized",
+	-5: "payment_no_destination",
+	-6: "payment_no_trust",
+	-7: "payment_not_authorized",
+	-8: "payment_line_full",
+	-9: "payment_no_issuer",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e PaymentResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := paymentResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *PaymentResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, paymentResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = PaymentResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PaymentResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -11927,6 +29258,85 @@ func NewPaymentResult(code PaymentResultCode, value interface{}) (result Payment
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u PaymentResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch PaymentResultCode(u.Code) {
+	case PaymentResultCodePaymentSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *PaymentResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = PaymentResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = PaymentResultCode(sw)
+	switch PaymentResultCode(u.Code) {
+	case PaymentResultCodePaymentSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u PaymentResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PaymentResultCode(u.Code) {
+	case PaymentResultCodePaymentSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *PaymentResult) decodeJSON(data []byte) error {
+	*u = PaymentResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PaymentResultCode(u.Code) {
+	case PaymentResultCodePaymentSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PaymentResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12023,6 +29433,64 @@ func (e PathPaymentStrictReceiveResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e PathPaymentStrictReceiveResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *PathPaymentStrictReceiveResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = PathPaymentStrictReceiveResultCode(v)
+	return n, nil
+}
+
+var pathPaymentStrictReceiveResultCodeJSONNames = map[int32]string{
+	0:   "path_payment_strict_receive_success",
+	-1:  "path_payment_strict_receive_malformed",
+	-2:  "path_payment_strict_receive_underfunded",
+	-3:  "path_payment_strict_receive_src_no_trust",
+	-4:  "path_payment_strict_receive_src_not_authorized",
+	-5:  "path_payment_strict_receive_no_destination",
+	-6:  "path_payment_strict_receive_no_trust",
+	-7:  "path_payment_strict_receive_not_authorized",
+	-8:  "path_payment_strict_receive_line_full",
+	-9:  "path_payment_strict_receive_no_issuer",
+	-10: "path_payment_strict_receive_too_few_offers",
+	-11: "path_payment_strict_receive_offer_cross_self",
+	-12: "path_payment_strict_receive_over_sendmax",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e PathPaymentStrictReceiveResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := pathPaymentStrictReceiveResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *PathPaymentStrictReceiveResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, pathPaymentStrictReceiveResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = PathPaymentStrictReceiveResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictReceiveResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12056,6 +29524,81 @@ type SimplePaymentResult struct {
 	Amount      Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SimplePaymentResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Asset.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SimplePaymentResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Asset.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SimplePaymentResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("asset")
+	if err = s.Asset.encodeJSON(enc); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SimplePaymentResult) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "destination", "asset", "amount")
+	if err != nil {
+		return err
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.Asset.decodeJSON(fields["asset"]); err != nil {
+		return jsonFieldError("asset", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SimplePaymentResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12087,6 +29630,102 @@ type PathPaymentStrictReceiveResultSuccess struct {
 	Last   SimplePaymentResult
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PathPaymentStrictReceiveResultSuccess) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s.Offers))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Offers); i++ {
+		if err = s.Offers[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Last.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PathPaymentStrictReceiveResultSuccess) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Offers = nil
+	if l > 0 {
+		s.Offers = make([]ClaimOfferAtom, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Offers = append(s.Offers, ClaimOfferAtom{})
+			nTmp, err = s.Offers[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Last.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PathPaymentStrictReceiveResultSuccess) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("offers")
+	enc.beginArray()
+	for i := 0; i < len(s.Offers); i++ {
+		enc.element()
+		if err = s.Offers[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("offers", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("last")
+	if err = s.Last.encodeJSON(enc); err != nil {
+		return jsonFieldError("last", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PathPaymentStrictReceiveResultSuccess) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "offers", "last")
+	if err != nil {
+		return err
+	}
+	if elems, err = decodeJSONArray(fields["offers"], 0); err != nil {
+		return jsonFieldError("offers", err)
+	}
+	s.Offers = nil
+	if len(elems) > 0 {
+		s.Offers = make([]ClaimOfferAtom, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Offers[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("offers", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Last.decodeJSON(fields["last"]); err != nil {
+		return jsonFieldError("last", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictReceiveResultSuccess) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12220,6 +29859,140 @@ func (u PathPaymentStrictReceiveResult) GetNoIssuer() (result Asset, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u PathPaymentStrictReceiveResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch PathPaymentStrictReceiveResultCode(u.Code) {
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess:
+		if u.Success == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Success.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoIssuer:
+		if u.NoIssuer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NoIssuer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *PathPaymentStrictReceiveResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = PathPaymentStrictReceiveResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = PathPaymentStrictReceiveResultCode(sw)
+	switch PathPaymentStrictReceiveResultCode(u.Code) {
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess:
+		u.Success = new(PathPaymentStrictReceiveResultSuccess)
+		nTmp, err = u.Success.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoIssuer:
+		u.NoIssuer = new(Asset)
+		nTmp, err = u.NoIssuer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u PathPaymentStrictReceiveResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PathPaymentStrictReceiveResultCode(u.Code) {
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess:
+		if u.Success == nil {
+			return jsonFieldError("success", errEncodeNilUnionValue())
+		}
+		enc.key("success")
+		if err = u.Success.encodeJSON(enc); err != nil {
+			return jsonFieldError("success", err)
+		}
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoIssuer:
+		if u.NoIssuer == nil {
+			return jsonFieldError("no_issuer", errEncodeNilUnionValue())
+		}
+		enc.key("no_issuer")
+		if err = u.NoIssuer.encodeJSON(enc); err != nil {
+			return jsonFieldError("no_issuer", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *PathPaymentStrictReceiveResult) decodeJSON(data []byte) error {
+	*u = PathPaymentStrictReceiveResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PathPaymentStrictReceiveResultCode(u.Code) {
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess:
+		if err = checkJSONKeys(fields, "code", "success"); err != nil {
+			return err
+		}
+		u.Success = new(PathPaymentStrictReceiveResultSuccess)
+		if err = u.Success.decodeJSON(fields["success"]); err != nil {
+			return jsonFieldError("success", err)
+		}
+		return nil
+	case PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveNoIssuer:
+		if err = checkJSONKeys(fields, "code", "no_issuer"); err != nil {
+			return err
+		}
+		u.NoIssuer = new(Asset)
+		if err = u.NoIssuer.decodeJSON(fields["no_issuer"]); err != nil {
+			return jsonFieldError("no_issuer", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictReceiveResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12315,6 +30088,64 @@ func (e PathPaymentStrictSendResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e PathPaymentStrictSendResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *PathPaymentStrictSendResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = PathPaymentStrictSendResultCode(v)
+	return n, nil
+}
+
+var pathPaymentStrictSendResultCodeJSONNames = map[int32]string{
+	0:   "path_payment_strict_send_success",
+	-1:  "path_payment_strict_send_malformed",
+	-2:  "path_payment_strict_send_underfunded",
+	-3:  "path_payment_strict_send_src_no_trust",
+	-4:  "path_payment_strict_send_src_not_authorized",
+	-5:  "path_payment_strict_send_no_destination",
+	-6:  "path_payment_strict_send_no_trust",
+	-7:  "path_payment_strict_send_not_authorized",
+	-8:  "path_payment_strict_send_line_full",
+	-9:  "path_payment_strict_send_no_issuer",
+	-10: "path_payment_strict_send_too_few_offers",
+	-11: "path_payment_strict_send_offer_cross_self",
+	-12: "path_payment_strict_send_under_destmin",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e PathPaymentStrictSendResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := pathPaymentStrictSendResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *PathPaymentStrictSendResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, pathPaymentStrictSendResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = PathPaymentStrictSendResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictSendResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12346,6 +30177,102 @@ type PathPaymentStrictSendResultSuccess struct {
 	Last   SimplePaymentResult
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s PathPaymentStrictSendResultSuccess) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s.Offers))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.Offers); i++ {
+		if err = s.Offers[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Last.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *PathPaymentStrictSendResultSuccess) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.Offers = nil
+	if l > 0 {
+		s.Offers = make([]ClaimOfferAtom, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.Offers = append(s.Offers, ClaimOfferAtom{})
+			nTmp, err = s.Offers[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Last.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s PathPaymentStrictSendResultSuccess) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("offers")
+	enc.beginArray()
+	for i := 0; i < len(s.Offers); i++ {
+		enc.element()
+		if err = s.Offers[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("offers", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("last")
+	if err = s.Last.encodeJSON(enc); err != nil {
+		return jsonFieldError("last", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *PathPaymentStrictSendResultSuccess) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "offers", "last")
+	if err != nil {
+		return err
+	}
+	if elems, err = decodeJSONArray(fields["offers"], 0); err != nil {
+		return jsonFieldError("offers", err)
+	}
+	s.Offers = nil
+	if len(elems) > 0 {
+		s.Offers = make([]ClaimOfferAtom, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.Offers[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("offers", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Last.decodeJSON(fields["last"]); err != nil {
+		return jsonFieldError("last", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictSendResultSuccess) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12479,6 +30406,140 @@ func (u PathPaymentStrictSendResult) GetNoIssuer() (result Asset, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u PathPaymentStrictSendResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch PathPaymentStrictSendResultCode(u.Code) {
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess:
+		if u.Success == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Success.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendNoIssuer:
+		if u.NoIssuer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.NoIssuer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *PathPaymentStrictSendResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = PathPaymentStrictSendResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = PathPaymentStrictSendResultCode(sw)
+	switch PathPaymentStrictSendResultCode(u.Code) {
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess:
+		u.Success = new(PathPaymentStrictSendResultSuccess)
+		nTmp, err = u.Success.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendNoIssuer:
+		u.NoIssuer = new(Asset)
+		nTmp, err = u.NoIssuer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u PathPaymentStrictSendResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PathPaymentStrictSendResultCode(u.Code) {
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess:
+		if u.Success == nil {
+			return jsonFieldError("success", errEncodeNilUnionValue())
+		}
+		enc.key("success")
+		if err = u.Success.encodeJSON(enc); err != nil {
+			return jsonFieldError("success", err)
+		}
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendNoIssuer:
+		if u.NoIssuer == nil {
+			return jsonFieldError("no_issuer", errEncodeNilUnionValue())
+		}
+		enc.key("no_issuer")
+		if err = u.NoIssuer.encodeJSON(enc); err != nil {
+			return jsonFieldError("no_issuer", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *PathPaymentStrictSendResult) decodeJSON(data []byte) error {
+	*u = PathPaymentStrictSendResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch PathPaymentStrictSendResultCode(u.Code) {
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess:
+		if err = checkJSONKeys(fields, "code", "success"); err != nil {
+			return err
+		}
+		u.Success = new(PathPaymentStrictSendResultSuccess)
+		if err = u.Success.decodeJSON(fields["success"]); err != nil {
+			return jsonFieldError("success", err)
+		}
+		return nil
+	case PathPaymentStrictSendResultCodePathPaymentStrictSendNoIssuer:
+		if err = checkJSONKeys(fields, "code", "no_issuer"); err != nil {
+			return err
+		}
+		u.NoIssuer = new(Asset)
+		if err = u.NoIssuer.decodeJSON(fields["no_issuer"]); err != nil {
+			return jsonFieldError("no_issuer", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PathPaymentStrictSendResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12573,6 +30634,64 @@ func (e ManageSellOfferResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ManageSellOfferResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ManageSellOfferResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ManageSellOfferResultCode(v)
+	return n, nil
+}
+
+var manageSellOfferResultCodeJSONNames = map[int32]string{
+	0:   "manage_sell_offer_success",
+	-1:  "manage_sell_offer_malformed",
+	-2:  "manage_sell_offer_sell_no_trust",
+	-3:  "manage_sell_offer_buy_no_trust",
+	-4:  "manage_sell_offer_sell_not_authorized",
+	-5:  "manage_sell_offer_buy_not_authorized",
+	-6:  "manage_sell_offer_line_full",
+	-7:  "manage_sell_offer_underfunded",
+	-8:  "manage_sell_offer_cross_self",
+	-9:  "manage_sell_offer_sell_no_issuer",
+	-10: "manage_sell_offer_buy_no_issuer",
+	-11: "manage_sell_offer_not_found",
+	-12: "manage_sell_offer_low_reserve",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ManageSellOfferResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := manageSellOfferResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ManageSellOfferResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, manageSellOfferResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ManageSellOfferResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageSellOfferResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12627,6 +30746,54 @@ func (e ManageOfferEffect) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ManageOfferEffect) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ManageOfferEffect) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ManageOfferEffect(v)
+	return n, nil
+}
+
+var manageOfferEffectJSONNames = map[int32]string{
+	0: "manage_offer_created",
+	1: "manage_offer_updated",
+	2: "manage_offer_deleted",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ManageOfferEffect) encodeJSON(enc *jsonEncoder) error {
+	name, ok := manageOfferEffectJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ManageOfferEffect) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, manageOfferEffectJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ManageOfferEffect(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageOfferEffect) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12729,6 +30896,140 @@ func (u ManageOfferSuccessResultOffer) GetOffer() (result OfferEntry, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ManageOfferSuccessResultOffer) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Effect.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ManageOfferEffect(u.Effect) {
+	case ManageOfferEffectManageOfferCreated:
+		if u.Offer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Offer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case ManageOfferEffectManageOfferUpdated:
+		if u.Offer == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Offer.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ManageOfferSuccessResultOffer) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ManageOfferSuccessResultOffer{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Effect.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Effect = ManageOfferEffect(sw)
+	switch ManageOfferEffect(u.Effect) {
+	case ManageOfferEffectManageOfferCreated:
+		u.Offer = new(OfferEntry)
+		nTmp, err = u.Offer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case ManageOfferEffectManageOfferUpdated:
+		u.Offer = new(OfferEntry)
+		nTmp, err = u.Offer.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ManageOfferSuccessResultOffer) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("effect")
+	if err = u.Effect.encodeJSON(enc); err != nil {
+		return jsonFieldError("effect", err)
+	}
+	switch ManageOfferEffect(u.Effect) {
+	case ManageOfferEffectManageOfferCreated:
+		if u.Offer == nil {
+			return jsonFieldError("offer", errEncodeNilUnionValue())
+		}
+		enc.key("offer")
+		if err = u.Offer.encodeJSON(enc); err != nil {
+			return jsonFieldError("offer", err)
+		}
+	case ManageOfferEffectManageOfferUpdated:
+		if u.Offer == nil {
+			return jsonFieldError("offer", errEncodeNilUnionValue())
+		}
+		enc.key("offer")
+		if err = u.Offer.encodeJSON(enc); err != nil {
+			return jsonFieldError("offer", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ManageOfferSuccessResultOffer) decodeJSON(data []byte) error {
+	*u = ManageOfferSuccessResultOffer{}
+	fields, err := decodeJSONUnion(data, "effect")
+	if err != nil {
+		return err
+	}
+	if err = u.Effect.decodeJSON(fields["effect"]); err != nil {
+		return jsonFieldError("effect", err)
+	}
+	switch ManageOfferEffect(u.Effect) {
+	case ManageOfferEffectManageOfferCreated:
+		if err = checkJSONKeys(fields, "effect", "offer"); err != nil {
+			return err
+		}
+		u.Offer = new(OfferEntry)
+		if err = u.Offer.decodeJSON(fields["offer"]); err != nil {
+			return jsonFieldError("offer", err)
+		}
+		return nil
+	case ManageOfferEffectManageOfferUpdated:
+		if err = checkJSONKeys(fields, "effect", "offer"); err != nil {
+			return err
+		}
+		u.Offer = new(OfferEntry)
+		if err = u.Offer.decodeJSON(fields["offer"]); err != nil {
+			return jsonFieldError("offer", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "effect")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageOfferSuccessResultOffer) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12770,6 +31071,102 @@ type ManageOfferSuccessResult struct {
 	Offer         ManageOfferSuccessResultOffer
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s ManageOfferSuccessResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeUint(uint32(len(s.OffersClaimed))); err != nil {
+		return err
+	}
+	for i := 0; i < len(s.OffersClaimed); i++ {
+		if err = s.OffersClaimed[i].EncodeTo(enc); err != nil {
+			return err
+		}
+	}
+	if err = s.Offer.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *ManageOfferSuccessResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	l, nTmp, err = dec.DecodeUint()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if l > math.MaxInt32 {
+		return n, errDecodeMaxSlice(l)
+	}
+	s.OffersClaimed = nil
+	if l > 0 {
+		s.OffersClaimed = make([]ClaimOfferAtom, 0, sliceCapacity(l))
+		for i := uint32(0); i < l; i++ {
+			s.OffersClaimed = append(s.OffersClaimed, ClaimOfferAtom{})
+			nTmp, err = s.OffersClaimed[i].DecodeFrom(dec)
+			n += nTmp
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	nTmp, err = s.Offer.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s ManageOfferSuccessResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("offers_claimed")
+	enc.beginArray()
+	for i := 0; i < len(s.OffersClaimed); i++ {
+		enc.element()
+		if err = s.OffersClaimed[i].encodeJSON(enc); err != nil {
+			return jsonFieldError("offers_claimed", jsonIndexError(i, err))
+		}
+	}
+	enc.endArray()
+	enc.key("offer")
+	if err = s.Offer.encodeJSON(enc); err != nil {
+		return jsonFieldError("offer", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *ManageOfferSuccessResult) decodeJSON(data []byte) error {
+	var elems [][]byte
+	fields, err := decodeJSONObject(data, "offers_claimed", "offer")
+	if err != nil {
+		return err
+	}
+	if elems, err = decodeJSONArray(fields["offers_claimed"], 0); err != nil {
+		return jsonFieldError("offers_claimed", err)
+	}
+	s.OffersClaimed = nil
+	if len(elems) > 0 {
+		s.OffersClaimed = make([]ClaimOfferAtom, len(elems))
+	}
+	for i, elem := range elems {
+		if err = s.OffersClaimed[i].decodeJSON(elem); err != nil {
+			return jsonFieldError("offers_claimed", jsonIndexError(i, err))
+		}
+	}
+	if err = s.Offer.decodeJSON(fields["offer"]); err != nil {
+		return jsonFieldError("offer", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageOfferSuccessResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -12854,12 +31251,113 @@ func (u ManageSellOfferResult) MustSuccess() ManageOfferSuccessResult {
 func (u ManageSellOfferResult) GetSuccess() (result ManageOfferSuccessResult, ok bool) {
 	armName, _ := u.ArmForSwitch(int32(u.Code))
 
-	if armName == "Success" {
-		result = *u.Success
-		ok = true
+	if armName == "Success" {
+		result = *u.Success
+		ok = true
+	}
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u ManageSellOfferResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ManageSellOfferResultCode(u.Code) {
+	case ManageSellOfferResultCodeManageSellOfferSuccess:
+		if u.Success == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Success.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ManageSellOfferResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ManageSellOfferResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ManageSellOfferResultCode(sw)
+	switch ManageSellOfferResultCode(u.Code) {
+	case ManageSellOfferResultCodeManageSellOfferSuccess:
+		u.Success = new(ManageOfferSuccessResult)
+		nTmp, err = u.Success.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ManageSellOfferResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageSellOfferResultCode(u.Code) {
+	case ManageSellOfferResultCodeManageSellOfferSuccess:
+		if u.Success == nil {
+			return jsonFieldError("success", errEncodeNilUnionValue())
+		}
+		enc.key("success")
+		if err = u.Success.encodeJSON(enc); err != nil {
+			return jsonFieldError("success", err)
+		}
+	default:
+		// Void
 	}
+	enc.endObject()
+	return nil
+}
 
-	return
+// decodeJSON decodes the JSON representation of this value.
+func (u *ManageSellOfferResult) decodeJSON(data []byte) error {
+	*u = ManageSellOfferResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageSellOfferResultCode(u.Code) {
+	case ManageSellOfferResultCodeManageSellOfferSuccess:
+		if err = checkJSONKeys(fields, "code", "success"); err != nil {
+			return err
+		}
+		u.Success = new(ManageOfferSuccessResult)
+		if err = u.Success.decodeJSON(fields["success"]); err != nil {
+			return jsonFieldError("success", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -12953,6 +31451,64 @@ func (e ManageBuyOfferResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ManageBuyOfferResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ManageBuyOfferResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ManageBuyOfferResultCode(v)
+	return n, nil
+}
+
+var manageBuyOfferResultCodeJSONNames = map[int32]string{
+	0:   "manage_buy_offer_success",
+	-1:  "manage_buy_offer_malformed",
+	-2:  "manage_buy_offer_sell_no_trust",
+	-3:  "manage_buy_offer_buy_no_trust",
+	-4:  "manage_buy_offer_sell_not_authorized",
+	-5:  "manage_buy_offer_buy_not_authorized",
+	-6:  "manage_buy_offer_line_full",
+	-7:  "manage_buy_offer_underfunded",
+	-8:  "manage_buy_offer_cross_self",
+	-9:  "manage_buy_offer_sell_no_issuer",
+	-10: "manage_buy_offer_buy_no_issuer",
+	-11: "manage_buy_offer_not_found",
+	-12: "manage_buy_offer_low_reserve",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ManageBuyOfferResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := manageBuyOfferResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ManageBuyOfferResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, manageBuyOfferResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ManageBuyOfferResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageBuyOfferResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13045,6 +31601,107 @@ func (u ManageBuyOfferResult) GetSuccess() (result ManageOfferSuccessResult, ok
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ManageBuyOfferResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ManageBuyOfferResultCode(u.Code) {
+	case ManageBuyOfferResultCodeManageBuyOfferSuccess:
+		if u.Success == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Success.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ManageBuyOfferResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ManageBuyOfferResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ManageBuyOfferResultCode(sw)
+	switch ManageBuyOfferResultCode(u.Code) {
+	case ManageBuyOfferResultCodeManageBuyOfferSuccess:
+		u.Success = new(ManageOfferSuccessResult)
+		nTmp, err = u.Success.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ManageBuyOfferResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageBuyOfferResultCode(u.Code) {
+	case ManageBuyOfferResultCodeManageBuyOfferSuccess:
+		if u.Success == nil {
+			return jsonFieldError("success", errEncodeNilUnionValue())
+		}
+		enc.key("success")
+		if err = u.Success.encodeJSON(enc); err != nil {
+			return jsonFieldError("success", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ManageBuyOfferResult) decodeJSON(data []byte) error {
+	*u = ManageBuyOfferResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageBuyOfferResultCode(u.Code) {
+	case ManageBuyOfferResultCodeManageBuyOfferSuccess:
+		if err = checkJSONKeys(fields, "code", "success"); err != nil {
+			return err
+		}
+		u.Success = new(ManageOfferSuccessResult)
+		if err = u.Success.decodeJSON(fields["success"]); err != nil {
+			return jsonFieldError("success", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageBuyOfferResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13125,6 +31782,62 @@ func (e SetOptionsResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e SetOptionsResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *SetOptionsResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = SetOptionsResultCode(v)
+	return n, nil
+}
+
+var setOptionsResultCodeJSONNames = map[int32]string{
+	0:   "set_options_success",
+	-1:  "set_options_low_reserve",
+	-2:  "set_options_too_many_signers",
+	-3:  "set_options_bad_flags",
+	-4:  "set_options_invalid_inflation",
+	-5:  "set_options_cant_change",
+	-6:  "set_options_unknown_flag",
+	-7:  "set_options_threshold_out_of_range",
+	-8:  "set_options_bad_signer",
+	-9:  "set_options_invalid_home_domain",
+	-10: "set_options_auth_revocable_required",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e SetOptionsResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := setOptionsResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *SetOptionsResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, setOptionsResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = SetOptionsResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SetOptionsResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13186,6 +31899,85 @@ func NewSetOptionsResult(code SetOptionsResultCode, value interface{}) (result S
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u SetOptionsResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch SetOptionsResultCode(u.Code) {
+	case SetOptionsResultCodeSetOptionsSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *SetOptionsResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = SetOptionsResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = SetOptionsResultCode(sw)
+	switch SetOptionsResultCode(u.Code) {
+	case SetOptionsResultCodeSetOptionsSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u SetOptionsResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch SetOptionsResultCode(u.Code) {
+	case SetOptionsResultCodeSetOptionsSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *SetOptionsResult) decodeJSON(data []byte) error {
+	*u = SetOptionsResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch SetOptionsResultCode(u.Code) {
+	case SetOptionsResultCodeSetOptionsSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SetOptionsResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13253,6 +32045,57 @@ func (e ChangeTrustResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ChangeTrustResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ChangeTrustResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ChangeTrustResultCode(v)
+	return n, nil
+}
+
+var changeTrustResultCodeJSONNames = map[int32]string{
+	0:  "change_trust_success",
+	-1: "change_trust_malformed",
+	-2: "change_trust_no_issuer",
+	-3: "change_trust_invalid_limit",
+	-4: "change_trust_low_reserve",
+	-5: "change_trust_self_not_allowed",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ChangeTrustResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := changeTrustResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ChangeTrustResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, changeTrustResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ChangeTrustResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ChangeTrustResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13314,6 +32157,85 @@ func NewChangeTrustResult(code ChangeTrustResultCode, value interface{}) (result
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ChangeTrustResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ChangeTrustResultCode(u.Code) {
+	case ChangeTrustResultCodeChangeTrustSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ChangeTrustResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ChangeTrustResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ChangeTrustResultCode(sw)
+	switch ChangeTrustResultCode(u.Code) {
+	case ChangeTrustResultCodeChangeTrustSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ChangeTrustResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ChangeTrustResultCode(u.Code) {
+	case ChangeTrustResultCodeChangeTrustSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ChangeTrustResult) decodeJSON(data []byte) error {
+	*u = ChangeTrustResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ChangeTrustResultCode(u.Code) {
+	case ChangeTrustResultCodeChangeTrustSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ChangeTrustResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13380,6 +32302,57 @@ func (e AllowTrustResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e AllowTrustResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *AllowTrustResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = AllowTrustResultCode(v)
+	return n, nil
+}
+
+var allowTrustResultCodeJSONNames = map[int32]string{
+	0:  "allow_trust_success",
+	-1: "allow_trust_malformed",
+	-2: "allow_trust_no_trust_line",
+	-3: "allow_trust_trust_not_required",
+	-4: "allow_trust_cant_revoke",
+	-5: "allow_trust_self_not_allowed",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e AllowTrustResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := allowTrustResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *AllowTrustResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, allowTrustResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = AllowTrustResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AllowTrustResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13441,6 +32414,85 @@ func NewAllowTrustResult(code AllowTrustResultCode, value interface{}) (result A
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u AllowTrustResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch AllowTrustResultCode(u.Code) {
+	case AllowTrustResultCodeAllowTrustSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AllowTrustResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AllowTrustResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = AllowTrustResultCode(sw)
+	switch AllowTrustResultCode(u.Code) {
+	case AllowTrustResultCodeAllowTrustSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AllowTrustResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch AllowTrustResultCode(u.Code) {
+	case AllowTrustResultCodeAllowTrustSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *AllowTrustResult) decodeJSON(data []byte) error {
+	*u = AllowTrustResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch AllowTrustResultCode(u.Code) {
+	case AllowTrustResultCodeAllowTrustSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AllowTrustResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13513,6 +32565,59 @@ func (e AccountMergeResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e AccountMergeResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *AccountMergeResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = AccountMergeResultCode(v)
+	return n, nil
+}
+
+var accountMergeResultCodeJSONNames = map[int32]string{
+	0:  "account_merge_success",
+	-1: "account_merge_malformed",
+	-2: "account_merge_no_account",
+	-3: "account_merge_immutable_set",
+	-4: "account_merge_has_sub_entries",
+	-5: "account_merge_seqnum_too_far",
+	-6: "account_merge_dest_full",
+	-7: "account_merge_is_sponsor",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e AccountMergeResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := accountMergeResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *AccountMergeResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, accountMergeResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = AccountMergeResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s AccountMergeResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13575,34 +32680,135 @@ func NewAccountMergeResult(code AccountMergeResultCode, value interface{}) (resu
 		}
 		result.SourceAccountBalance = &tv
 	default:
-		// void
+		// void
+	}
+	return
+}
+
+// MustSourceAccountBalance retrieves the SourceAccountBalance value from the union,
+// panicing if the value is not set.
+func (u AccountMergeResult) MustSourceAccountBalance() Int64 {
+	val, ok := u.GetSourceAccountBalance()
+
+	if !ok {
+		panic("arm SourceAccountBalance is not set")
+	}
+
+	return val
+}
+
+// GetSourceAccountBalance retrieves the SourceAccountBalance value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u AccountMergeResult) GetSourceAccountBalance() (result Int64, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Code))
+
+	if armName == "SourceAccountBalance" {
+		result = *u.SourceAccountBalance
+		ok = true
+	}
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u AccountMergeResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch AccountMergeResultCode(u.Code) {
+	case AccountMergeResultCodeAccountMergeSuccess:
+		if u.SourceAccountBalance == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SourceAccountBalance.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *AccountMergeResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = AccountMergeResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = AccountMergeResultCode(sw)
+	switch AccountMergeResultCode(u.Code) {
+	case AccountMergeResultCodeAccountMergeSuccess:
+		u.SourceAccountBalance = new(Int64)
+		nTmp, err = u.SourceAccountBalance.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
 	}
-	return
 }
 
-// MustSourceAccountBalance retrieves the SourceAccountBalance value from the union,
-// panicing if the value is not set.
-func (u AccountMergeResult) MustSourceAccountBalance() Int64 {
-	val, ok := u.GetSourceAccountBalance()
-
-	if !ok {
-		panic("arm SourceAccountBalance is not set")
+// encodeJSON writes the JSON representation of this value to enc.
+func (u AccountMergeResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
 	}
-
-	return val
+	switch AccountMergeResultCode(u.Code) {
+	case AccountMergeResultCodeAccountMergeSuccess:
+		if u.SourceAccountBalance == nil {
+			return jsonFieldError("source_account_balance", errEncodeNilUnionValue())
+		}
+		enc.key("source_account_balance")
+		if err = u.SourceAccountBalance.encodeJSON(enc); err != nil {
+			return jsonFieldError("source_account_balance", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
 }
 
-// GetSourceAccountBalance retrieves the SourceAccountBalance value from the union,
-// returning ok if the union's switch indicated the value is valid.
-func (u AccountMergeResult) GetSourceAccountBalance() (result Int64, ok bool) {
-	armName, _ := u.ArmForSwitch(int32(u.Code))
-
-	if armName == "SourceAccountBalance" {
-		result = *u.SourceAccountBalance
-		ok = true
+// decodeJSON decodes the JSON representation of this value.
+func (u *AccountMergeResult) decodeJSON(data []byte) error {
+	*u = AccountMergeResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch AccountMergeResultCode(u.Code) {
+	case AccountMergeResultCodeAccountMergeSuccess:
+		if err = checkJSONKeys(fields, "code", "source_account_balance"); err != nil {
+			return err
+		}
+		u.SourceAccountBalance = new(Int64)
+		if err = u.SourceAccountBalance.decodeJSON(fields["source_account_balance"]); err != nil {
+			return jsonFieldError("source_account_balance", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
 	}
-
-	return
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -13658,6 +32864,53 @@ func (e InflationResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e InflationResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *InflationResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = InflationResultCode(v)
+	return n, nil
+}
+
+var inflationResultCodeJSONNames = map[int32]string{
+	0:  "inflation_success",
+	-1: "inflation_not_time",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e InflationResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := inflationResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *InflationResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, inflationResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = InflationResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InflationResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13689,6 +32942,66 @@ type InflationPayout struct {
 	Amount      Int64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s InflationPayout) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.Destination.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Amount.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *InflationPayout) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.Destination.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Amount.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s InflationPayout) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("destination")
+	if err = s.Destination.encodeJSON(enc); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	enc.key("amount")
+	if err = s.Amount.encodeJSON(enc); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *InflationPayout) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "destination", "amount")
+	if err != nil {
+		return err
+	}
+	if err = s.Destination.decodeJSON(fields["destination"]); err != nil {
+		return jsonFieldError("destination", err)
+	}
+	if err = s.Amount.decodeJSON(fields["amount"]); err != nil {
+		return jsonFieldError("amount", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InflationPayout) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13781,6 +33094,143 @@ func (u InflationResult) GetPayouts() (result []InflationPayout, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u InflationResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch InflationResultCode(u.Code) {
+	case InflationResultCodeInflationSuccess:
+		if u.Payouts == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Payouts))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Payouts); i++ {
+			if err = (*u.Payouts)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *InflationResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	*u = InflationResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = InflationResultCode(sw)
+	switch InflationResultCode(u.Code) {
+	case InflationResultCodeInflationSuccess:
+		u.Payouts = new([]InflationPayout)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Payouts = nil
+		if l > 0 {
+			*u.Payouts = make([]InflationPayout, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Payouts = append(*u.Payouts, InflationPayout{})
+				nTmp, err = (*u.Payouts)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u InflationResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch InflationResultCode(u.Code) {
+	case InflationResultCodeInflationSuccess:
+		if u.Payouts == nil {
+			return jsonFieldError("payouts", errEncodeNilUnionValue())
+		}
+		enc.key("payouts")
+		enc.beginArray()
+		for i := 0; i < len(*u.Payouts); i++ {
+			enc.element()
+			if err = (*u.Payouts)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("payouts", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *InflationResult) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = InflationResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch InflationResultCode(u.Code) {
+	case InflationResultCodeInflationSuccess:
+		if err = checkJSONKeys(fields, "code", "payouts"); err != nil {
+			return err
+		}
+		u.Payouts = new([]InflationPayout)
+		if elems, err = decodeJSONArray(fields["payouts"], 0); err != nil {
+			return jsonFieldError("payouts", err)
+		}
+		*u.Payouts = nil
+		if len(elems) > 0 {
+			*u.Payouts = make([]InflationPayout, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Payouts)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("payouts", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InflationResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13845,6 +33295,56 @@ func (e ManageDataResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ManageDataResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ManageDataResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ManageDataResultCode(v)
+	return n, nil
+}
+
+var manageDataResultCodeJSONNames = map[int32]string{
+	0:  "manage_data_success",
+	-1: "manage_data_not_supported_yet",
+	-2: "manage_data_name_not_found",
+	-3: "manage_data_low_reserve",
+	-4: "manage_data_invalid_name",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ManageDataResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := manageDataResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ManageDataResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, manageDataResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ManageDataResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageDataResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13906,6 +33406,85 @@ func NewManageDataResult(code ManageDataResultCode, value interface{}) (result M
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ManageDataResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ManageDataResultCode(u.Code) {
+	case ManageDataResultCodeManageDataSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ManageDataResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ManageDataResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ManageDataResultCode(sw)
+	switch ManageDataResultCode(u.Code) {
+	case ManageDataResultCodeManageDataSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ManageDataResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageDataResultCode(u.Code) {
+	case ManageDataResultCodeManageDataSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ManageDataResult) decodeJSON(data []byte) error {
+	*u = ManageDataResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ManageDataResultCode(u.Code) {
+	case ManageDataResultCodeManageDataSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ManageDataResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -13959,6 +33538,53 @@ func (e BumpSequenceResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e BumpSequenceResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *BumpSequenceResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = BumpSequenceResultCode(v)
+	return n, nil
+}
+
+var bumpSequenceResultCodeJSONNames = map[int32]string{
+	0:  "bump_sequence_success",
+	-1: "bump_sequence_bad_seq",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e BumpSequenceResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := bumpSequenceResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *BumpSequenceResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, bumpSequenceResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = BumpSequenceResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BumpSequenceResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14020,6 +33646,85 @@ func NewBumpSequenceResult(code BumpSequenceResultCode, value interface{}) (resu
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u BumpSequenceResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch BumpSequenceResultCode(u.Code) {
+	case BumpSequenceResultCodeBumpSequenceSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *BumpSequenceResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = BumpSequenceResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = BumpSequenceResultCode(sw)
+	switch BumpSequenceResultCode(u.Code) {
+	case BumpSequenceResultCodeBumpSequenceSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u BumpSequenceResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch BumpSequenceResultCode(u.Code) {
+	case BumpSequenceResultCodeBumpSequenceSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *BumpSequenceResult) decodeJSON(data []byte) error {
+	*u = BumpSequenceResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch BumpSequenceResultCode(u.Code) {
+	case BumpSequenceResultCodeBumpSequenceSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BumpSequenceResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14083,6 +33788,57 @@ func (e CreateClaimableBalanceResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e CreateClaimableBalanceResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *CreateClaimableBalanceResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = CreateClaimableBalanceResultCode(v)
+	return n, nil
+}
+
+var createClaimableBalanceResultCodeJSONNames = map[int32]string{
+	0:  "create_claimable_balance_success",
+	-1: "create_claimable_balance_malformed",
+	-2: "create_claimable_balance_low_reserve",
+	-3: "create_claimable_balance_no_trust",
+	-4: "create_claimable_balance_not_authorized",
+	-5: "create_claimable_balance_underfunded",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e CreateClaimableBalanceResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := createClaimableBalanceResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *CreateClaimableBalanceResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, createClaimableBalanceResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = CreateClaimableBalanceResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateClaimableBalanceResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14175,6 +33931,107 @@ func (u CreateClaimableBalanceResult) GetBalanceId() (result ClaimableBalanceId,
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u CreateClaimableBalanceResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch CreateClaimableBalanceResultCode(u.Code) {
+	case CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess:
+		if u.BalanceId == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.BalanceId.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *CreateClaimableBalanceResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = CreateClaimableBalanceResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = CreateClaimableBalanceResultCode(sw)
+	switch CreateClaimableBalanceResultCode(u.Code) {
+	case CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess:
+		u.BalanceId = new(ClaimableBalanceId)
+		nTmp, err = u.BalanceId.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u CreateClaimableBalanceResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch CreateClaimableBalanceResultCode(u.Code) {
+	case CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess:
+		if u.BalanceId == nil {
+			return jsonFieldError("balance_id", errEncodeNilUnionValue())
+		}
+		enc.key("balance_id")
+		if err = u.BalanceId.encodeJSON(enc); err != nil {
+			return jsonFieldError("balance_id", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *CreateClaimableBalanceResult) decodeJSON(data []byte) error {
+	*u = CreateClaimableBalanceResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch CreateClaimableBalanceResultCode(u.Code) {
+	case CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess:
+		if err = checkJSONKeys(fields, "code", "balance_id"); err != nil {
+			return err
+		}
+		u.BalanceId = new(ClaimableBalanceId)
+		if err = u.BalanceId.decodeJSON(fields["balance_id"]); err != nil {
+			return jsonFieldError("balance_id", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CreateClaimableBalanceResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14239,6 +34096,57 @@ func (e ClaimClaimableBalanceResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClaimClaimableBalanceResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClaimClaimableBalanceResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClaimClaimableBalanceResultCode(v)
+	return n, nil
+}
+
+var claimClaimableBalanceResultCodeJSONNames = map[int32]string{
+	0:  "claim_claimable_balance_success",
+	-1: "claim_claimable_balance_does_not_exist",
+	-2: "claim_claimable_balance_cannot_claim",
+	-3: "claim_claimable_balance_line_full",
+	-4: "claim_claimable_balance_no_trust",
+	-5: "claim_claimable_balance_not_authorized",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClaimClaimableBalanceResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := claimClaimableBalanceResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClaimClaimableBalanceResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, claimClaimableBalanceResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClaimClaimableBalanceResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimClaimableBalanceResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14300,6 +34208,85 @@ func NewClaimClaimableBalanceResult(code ClaimClaimableBalanceResultCode, value
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClaimClaimableBalanceResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClaimClaimableBalanceResultCode(u.Code) {
+	case ClaimClaimableBalanceResultCodeClaimClaimableBalanceSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClaimClaimableBalanceResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClaimClaimableBalanceResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ClaimClaimableBalanceResultCode(sw)
+	switch ClaimClaimableBalanceResultCode(u.Code) {
+	case ClaimClaimableBalanceResultCodeClaimClaimableBalanceSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClaimClaimableBalanceResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClaimClaimableBalanceResultCode(u.Code) {
+	case ClaimClaimableBalanceResultCodeClaimClaimableBalanceSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClaimClaimableBalanceResult) decodeJSON(data []byte) error {
+	*u = ClaimClaimableBalanceResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClaimClaimableBalanceResultCode(u.Code) {
+	case ClaimClaimableBalanceResultCodeClaimClaimableBalanceSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClaimClaimableBalanceResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14360,6 +34347,55 @@ func (e BeginSponsoringFutureReservesResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e BeginSponsoringFutureReservesResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *BeginSponsoringFutureReservesResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = BeginSponsoringFutureReservesResultCode(v)
+	return n, nil
+}
+
+var beginSponsoringFutureReservesResultCodeJSONNames = map[int32]string{
+	0:  "begin_sponsoring_future_reserves_success",
+	-1: "begin_sponsoring_future_reserves_malformed",
+	-2: "begin_sponsoring_future_reserves_already_sponsored",
+	-3: "begin_sponsoring_future_reserves_recursive",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e BeginSponsoringFutureReservesResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := beginSponsoringFutureReservesResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *BeginSponsoringFutureReservesResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, beginSponsoringFutureReservesResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = BeginSponsoringFutureReservesResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BeginSponsoringFutureReservesResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14421,6 +34457,85 @@ func NewBeginSponsoringFutureReservesResult(code BeginSponsoringFutureReservesRe
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u BeginSponsoringFutureReservesResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch BeginSponsoringFutureReservesResultCode(u.Code) {
+	case BeginSponsoringFutureReservesResultCodeBeginSponsoringFutureReservesSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *BeginSponsoringFutureReservesResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = BeginSponsoringFutureReservesResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = BeginSponsoringFutureReservesResultCode(sw)
+	switch BeginSponsoringFutureReservesResultCode(u.Code) {
+	case BeginSponsoringFutureReservesResultCodeBeginSponsoringFutureReservesSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u BeginSponsoringFutureReservesResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch BeginSponsoringFutureReservesResultCode(u.Code) {
+	case BeginSponsoringFutureReservesResultCodeBeginSponsoringFutureReservesSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *BeginSponsoringFutureReservesResult) decodeJSON(data []byte) error {
+	*u = BeginSponsoringFutureReservesResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch BeginSponsoringFutureReservesResultCode(u.Code) {
+	case BeginSponsoringFutureReservesResultCodeBeginSponsoringFutureReservesSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s BeginSponsoringFutureReservesResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14475,6 +34590,53 @@ func (e EndSponsoringFutureReservesResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e EndSponsoringFutureReservesResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *EndSponsoringFutureReservesResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = EndSponsoringFutureReservesResultCode(v)
+	return n, nil
+}
+
+var endSponsoringFutureReservesResultCodeJSONNames = map[int32]string{
+	0:  "end_sponsoring_future_reserves_success",
+	-1: "end_sponsoring_future_reserves_not_sponsored",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e EndSponsoringFutureReservesResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := endSponsoringFutureReservesResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *EndSponsoringFutureReservesResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, endSponsoringFutureReservesResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = EndSponsoringFutureReservesResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s EndSponsoringFutureReservesResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14536,6 +34698,85 @@ func NewEndSponsoringFutureReservesResult(code EndSponsoringFutureReservesResult
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u EndSponsoringFutureReservesResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch EndSponsoringFutureReservesResultCode(u.Code) {
+	case EndSponsoringFutureReservesResultCodeEndSponsoringFutureReservesSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *EndSponsoringFutureReservesResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = EndSponsoringFutureReservesResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = EndSponsoringFutureReservesResultCode(sw)
+	switch EndSponsoringFutureReservesResultCode(u.Code) {
+	case EndSponsoringFutureReservesResultCodeEndSponsoringFutureReservesSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u EndSponsoringFutureReservesResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch EndSponsoringFutureReservesResultCode(u.Code) {
+	case EndSponsoringFutureReservesResultCodeEndSponsoringFutureReservesSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *EndSponsoringFutureReservesResult) decodeJSON(data []byte) error {
+	*u = EndSponsoringFutureReservesResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch EndSponsoringFutureReservesResultCode(u.Code) {
+	case EndSponsoringFutureReservesResultCodeEndSponsoringFutureReservesSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s EndSponsoringFutureReservesResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14599,6 +34840,56 @@ func (e RevokeSponsorshipResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e RevokeSponsorshipResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *RevokeSponsorshipResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = RevokeSponsorshipResultCode(v)
+	return n, nil
+}
+
+var revokeSponsorshipResultCodeJSONNames = map[int32]string{
+	0:  "revoke_sponsorship_success",
+	-1: "revoke_sponsorship_does_not_exist",
+	-2: "revoke_sponsorship_not_sponsor",
+	-3: "revoke_sponsorship_low_reserve",
+	-4: "revoke_sponsorship_only_transferable",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e RevokeSponsorshipResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := revokeSponsorshipResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *RevokeSponsorshipResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, revokeSponsorshipResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = RevokeSponsorshipResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s RevokeSponsorshipResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14660,6 +34951,85 @@ func NewRevokeSponsorshipResult(code RevokeSponsorshipResultCode, value interfac
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u RevokeSponsorshipResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch RevokeSponsorshipResultCode(u.Code) {
+	case RevokeSponsorshipResultCodeRevokeSponsorshipSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *RevokeSponsorshipResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = RevokeSponsorshipResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = RevokeSponsorshipResultCode(sw)
+	switch RevokeSponsorshipResultCode(u.Code) {
+	case RevokeSponsorshipResultCodeRevokeSponsorshipSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u RevokeSponsorshipResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch RevokeSponsorshipResultCode(u.Code) {
+	case RevokeSponsorshipResultCodeRevokeSponsorshipSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *RevokeSponsorshipResult) decodeJSON(data []byte) error {
+	*u = RevokeSponsorshipResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch RevokeSponsorshipResultCode(u.Code) {
+	case RevokeSponsorshipResultCodeRevokeSponsorshipSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s RevokeSponsorshipResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14723,6 +35093,56 @@ func (e ClawbackResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClawbackResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClawbackResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClawbackResultCode(v)
+	return n, nil
+}
+
+var clawbackResultCodeJSONNames = map[int32]string{
+	0:  "clawback_success",
+	-1: "clawback_malformed",
+	-2: "clawback_not_clawback_enabled",
+	-3: "clawback_no_trust",
+	-4: "clawback_underfunded",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClawbackResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := clawbackResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClawbackResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, clawbackResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClawbackResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14784,6 +35204,85 @@ func NewClawbackResult(code ClawbackResultCode, value interface{}) (result Clawb
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClawbackResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClawbackResultCode(u.Code) {
+	case ClawbackResultCodeClawbackSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClawbackResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClawbackResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ClawbackResultCode(sw)
+	switch ClawbackResultCode(u.Code) {
+	case ClawbackResultCodeClawbackSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClawbackResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClawbackResultCode(u.Code) {
+	case ClawbackResultCodeClawbackSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClawbackResult) decodeJSON(data []byte) error {
+	*u = ClawbackResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClawbackResultCode(u.Code) {
+	case ClawbackResultCodeClawbackSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14844,6 +35343,55 @@ func (e ClawbackClaimableBalanceResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e ClawbackClaimableBalanceResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *ClawbackClaimableBalanceResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = ClawbackClaimableBalanceResultCode(v)
+	return n, nil
+}
+
+var clawbackClaimableBalanceResultCodeJSONNames = map[int32]string{
+	0:  "clawback_claimable_balance_success",
+	-1: "clawback_claimable_balance_does_not_exist",
+	-2: "clawback_claimable_balance_not_issuer",
+	-3: "clawback_claimable_balance_not_clawback_enabled",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e ClawbackClaimableBalanceResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := clawbackClaimableBalanceResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *ClawbackClaimableBalanceResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, clawbackClaimableBalanceResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = ClawbackClaimableBalanceResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackClaimableBalanceResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14905,6 +35453,85 @@ func NewClawbackClaimableBalanceResult(code ClawbackClaimableBalanceResultCode,
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u ClawbackClaimableBalanceResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch ClawbackClaimableBalanceResultCode(u.Code) {
+	case ClawbackClaimableBalanceResultCodeClawbackClaimableBalanceSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *ClawbackClaimableBalanceResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = ClawbackClaimableBalanceResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = ClawbackClaimableBalanceResultCode(sw)
+	switch ClawbackClaimableBalanceResultCode(u.Code) {
+	case ClawbackClaimableBalanceResultCodeClawbackClaimableBalanceSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u ClawbackClaimableBalanceResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClawbackClaimableBalanceResultCode(u.Code) {
+	case ClawbackClaimableBalanceResultCodeClawbackClaimableBalanceSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *ClawbackClaimableBalanceResult) decodeJSON(data []byte) error {
+	*u = ClawbackClaimableBalanceResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch ClawbackClaimableBalanceResultCode(u.Code) {
+	case ClawbackClaimableBalanceResultCodeClawbackClaimableBalanceSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s ClawbackClaimableBalanceResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -14962,10 +35589,60 @@ func (e SetTrustLineFlagsResultCode) ValidEnum(v int32) bool {
 	return ok
 }
 
-// String returns the name of `e`
-func (e SetTrustLineFlagsResultCode) String() string {
-	name, _ := setTrustLineFlagsResultCodeMap[int32(e)]
-	return name
+// String returns the name of `e`
+func (e SetTrustLineFlagsResultCode) String() string {
+	name, _ := setTrustLineFlagsResultCodeMap[int32(e)]
+	return name
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (e SetTrustLineFlagsResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *SetTrustLineFlagsResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = SetTrustLineFlagsResultCode(v)
+	return n, nil
+}
+
+var setTrustLineFlagsResultCodeJSONNames = map[int32]string{
+	0:  "set_trust_line_flags_success",
+	-1: "set_trust_line_flags_malformed",
+	-2: "set_trust_line_flags_no_trust_line",
+	-3: "set_trust_line_flags_cant_revoke",
+	-4: "set_trust_line_flags_invalid_state",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e SetTrustLineFlagsResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := setTrustLineFlagsResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *SetTrustLineFlagsResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, setTrustLineFlagsResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = SetTrustLineFlagsResultCode(v)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -15029,6 +35706,85 @@ func NewSetTrustLineFlagsResult(code SetTrustLineFlagsResultCode, value interfac
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u SetTrustLineFlagsResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch SetTrustLineFlagsResultCode(u.Code) {
+	case SetTrustLineFlagsResultCodeSetTrustLineFlagsSuccess:
+		// Void
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *SetTrustLineFlagsResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = SetTrustLineFlagsResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = SetTrustLineFlagsResultCode(sw)
+	switch SetTrustLineFlagsResultCode(u.Code) {
+	case SetTrustLineFlagsResultCodeSetTrustLineFlagsSuccess:
+		// Void
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u SetTrustLineFlagsResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch SetTrustLineFlagsResultCode(u.Code) {
+	case SetTrustLineFlagsResultCodeSetTrustLineFlagsSuccess:
+		// Void
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *SetTrustLineFlagsResult) decodeJSON(data []byte) error {
+	*u = SetTrustLineFlagsResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch SetTrustLineFlagsResultCode(u.Code) {
+	case SetTrustLineFlagsResultCodeSetTrustLineFlagsSuccess:
+		// Void
+		return checkJSONKeys(fields, "code")
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SetTrustLineFlagsResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -15096,6 +35852,58 @@ func (e OperationResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e OperationResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *OperationResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = OperationResultCode(v)
+	return n, nil
+}
+
+var operationResultCodeJSONNames = map[int32]string{
+	0:  "op_inner",
+	-1: "op_bad_auth",
+	-2: "op_no_account",
+	-3: "op_not_supported",
+	-4: "op_too_many_subentries",
+	-5: "op_exceeded_work_limit",
+	-6: "op_too_many_sponsoring",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e OperationResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := operationResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *OperationResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, operationResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = OperationResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -15943,21 +36751,809 @@ func (u OperationResultTr) MustSetTrustLineFlagsResult() SetTrustLineFlagsResult
 	if !ok {
 		panic("arm SetTrustLineFlagsResult is not set")
 	}
-
-	return val
+
+	return val
+}
+
+// GetSetTrustLineFlagsResult retrieves the SetTrustLineFlagsResult value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u OperationResultTr) GetSetTrustLineFlagsResult() (result SetTrustLineFlagsResult, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "SetTrustLineFlagsResult" {
+		result = *u.SetTrustLineFlagsResult
+		ok = true
+	}
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u OperationResultTr) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if u.CreateAccountResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreateAccountResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePayment:
+		if u.PaymentResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PaymentResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePathPaymentStrictReceive:
+		if u.PathPaymentStrictReceiveResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PathPaymentStrictReceiveResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeManageSellOffer:
+		if u.ManageSellOfferResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageSellOfferResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeCreatePassiveSellOffer:
+		if u.CreatePassiveSellOfferResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreatePassiveSellOfferResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeSetOptions:
+		if u.SetOptionsResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SetOptionsResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeChangeTrust:
+		if u.ChangeTrustResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ChangeTrustResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeAllowTrust:
+		if u.AllowTrustResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AllowTrustResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeAccountMerge:
+		if u.AccountMergeResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.AccountMergeResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeInflation:
+		if u.InflationResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.InflationResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeManageData:
+		if u.ManageDataResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageDataResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeBumpSequence:
+		if u.BumpSeqResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.BumpSeqResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeManageBuyOffer:
+		if u.ManageBuyOfferResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ManageBuyOfferResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypePathPaymentStrictSend:
+		if u.PathPaymentStrictSendResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PathPaymentStrictSendResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeCreateClaimableBalance:
+		if u.CreateClaimableBalanceResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.CreateClaimableBalanceResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClaimClaimableBalance:
+		if u.ClaimClaimableBalanceResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClaimClaimableBalanceResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		if u.BeginSponsoringFutureReservesResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.BeginSponsoringFutureReservesResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeEndSponsoringFutureReserves:
+		if u.EndSponsoringFutureReservesResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.EndSponsoringFutureReservesResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeRevokeSponsorship:
+		if u.RevokeSponsorshipResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.RevokeSponsorshipResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClawback:
+		if u.ClawbackResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClawbackResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeClawbackClaimableBalance:
+		if u.ClawbackClaimableBalanceResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.ClawbackClaimableBalanceResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case OperationTypeSetTrustLineFlags:
+		if u.SetTrustLineFlagsResult == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.SetTrustLineFlagsResult.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *OperationResultTr) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = OperationResultTr{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = OperationType(sw)
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		u.CreateAccountResult = new(CreateAccountResult)
+		nTmp, err = u.CreateAccountResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePayment:
+		u.PaymentResult = new(PaymentResult)
+		nTmp, err = u.PaymentResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePathPaymentStrictReceive:
+		u.PathPaymentStrictReceiveResult = new(PathPaymentStrictReceiveResult)
+		nTmp, err = u.PathPaymentStrictReceiveResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeManageSellOffer:
+		u.ManageSellOfferResult = new(ManageSellOfferResult)
+		nTmp, err = u.ManageSellOfferResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeCreatePassiveSellOffer:
+		u.CreatePassiveSellOfferResult = new(ManageSellOfferResult)
+		nTmp, err = u.CreatePassiveSellOfferResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeSetOptions:
+		u.SetOptionsResult = new(SetOptionsResult)
+		nTmp, err = u.SetOptionsResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeChangeTrust:
+		u.ChangeTrustResult = new(ChangeTrustResult)
+		nTmp, err = u.ChangeTrustResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeAllowTrust:
+		u.AllowTrustResult = new(AllowTrustResult)
+		nTmp, err = u.AllowTrustResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeAccountMerge:
+		u.AccountMergeResult = new(AccountMergeResult)
+		nTmp, err = u.AccountMergeResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeInflation:
+		u.InflationResult = new(InflationResult)
+		nTmp, err = u.InflationResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeManageData:
+		u.ManageDataResult = new(ManageDataResult)
+		nTmp, err = u.ManageDataResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeBumpSequence:
+		u.BumpSeqResult = new(BumpSequenceResult)
+		nTmp, err = u.BumpSeqResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeManageBuyOffer:
+		u.ManageBuyOfferResult = new(ManageBuyOfferResult)
+		nTmp, err = u.ManageBuyOfferResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypePathPaymentStrictSend:
+		u.PathPaymentStrictSendResult = new(PathPaymentStrictSendResult)
+		nTmp, err = u.PathPaymentStrictSendResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeCreateClaimableBalance:
+		u.CreateClaimableBalanceResult = new(CreateClaimableBalanceResult)
+		nTmp, err = u.CreateClaimableBalanceResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClaimClaimableBalance:
+		u.ClaimClaimableBalanceResult = new(ClaimClaimableBalanceResult)
+		nTmp, err = u.ClaimClaimableBalanceResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		u.BeginSponsoringFutureReservesResult = new(BeginSponsoringFutureReservesResult)
+		nTmp, err = u.BeginSponsoringFutureReservesResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeEndSponsoringFutureReserves:
+		u.EndSponsoringFutureReservesResult = new(EndSponsoringFutureReservesResult)
+		nTmp, err = u.EndSponsoringFutureReservesResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeRevokeSponsorship:
+		u.RevokeSponsorshipResult = new(RevokeSponsorshipResult)
+		nTmp, err = u.RevokeSponsorshipResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClawback:
+		u.ClawbackResult = new(ClawbackResult)
+		nTmp, err = u.ClawbackResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeClawbackClaimableBalance:
+		u.ClawbackClaimableBalanceResult = new(ClawbackClaimableBalanceResult)
+		nTmp, err = u.ClawbackClaimableBalanceResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case OperationTypeSetTrustLineFlags:
+		u.SetTrustLineFlagsResult = new(SetTrustLineFlagsResult)
+		nTmp, err = u.SetTrustLineFlagsResult.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u OperationResultTr) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("type")
+	if err = u.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if u.CreateAccountResult == nil {
+			return jsonFieldError("create_account_result", errEncodeNilUnionValue())
+		}
+		enc.key("create_account_result")
+		if err = u.CreateAccountResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_account_result", err)
+		}
+	case OperationTypePayment:
+		if u.PaymentResult == nil {
+			return jsonFieldError("payment_result", errEncodeNilUnionValue())
+		}
+		enc.key("payment_result")
+		if err = u.PaymentResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("payment_result", err)
+		}
+	case OperationTypePathPaymentStrictReceive:
+		if u.PathPaymentStrictReceiveResult == nil {
+			return jsonFieldError("path_payment_strict_receive_result", errEncodeNilUnionValue())
+		}
+		enc.key("path_payment_strict_receive_result")
+		if err = u.PathPaymentStrictReceiveResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("path_payment_strict_receive_result", err)
+		}
+	case OperationTypeManageSellOffer:
+		if u.ManageSellOfferResult == nil {
+			return jsonFieldError("manage_sell_offer_result", errEncodeNilUnionValue())
+		}
+		enc.key("manage_sell_offer_result")
+		if err = u.ManageSellOfferResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_sell_offer_result", err)
+		}
+	case OperationTypeCreatePassiveSellOffer:
+		if u.CreatePassiveSellOfferResult == nil {
+			return jsonFieldError("create_passive_sell_offer_result", errEncodeNilUnionValue())
+		}
+		enc.key("create_passive_sell_offer_result")
+		if err = u.CreatePassiveSellOfferResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_passive_sell_offer_result", err)
+		}
+	case OperationTypeSetOptions:
+		if u.SetOptionsResult == nil {
+			return jsonFieldError("set_options_result", errEncodeNilUnionValue())
+		}
+		enc.key("set_options_result")
+		if err = u.SetOptionsResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("set_options_result", err)
+		}
+	case OperationTypeChangeTrust:
+		if u.ChangeTrustResult == nil {
+			return jsonFieldError("change_trust_result", errEncodeNilUnionValue())
+		}
+		enc.key("change_trust_result")
+		if err = u.ChangeTrustResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("change_trust_result", err)
+		}
+	case OperationTypeAllowTrust:
+		if u.AllowTrustResult == nil {
+			return jsonFieldError("allow_trust_result", errEncodeNilUnionValue())
+		}
+		enc.key("allow_trust_result")
+		if err = u.AllowTrustResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("allow_trust_result", err)
+		}
+	case OperationTypeAccountMerge:
+		if u.AccountMergeResult == nil {
+			return jsonFieldError("account_merge_result", errEncodeNilUnionValue())
+		}
+		enc.key("account_merge_result")
+		if err = u.AccountMergeResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("account_merge_result", err)
+		}
+	case OperationTypeInflation:
+		if u.InflationResult == nil {
+			return jsonFieldError("inflation_result", errEncodeNilUnionValue())
+		}
+		enc.key("inflation_result")
+		if err = u.InflationResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("inflation_result", err)
+		}
+	case OperationTypeManageData:
+		if u.ManageDataResult == nil {
+			return jsonFieldError("manage_data_result", errEncodeNilUnionValue())
+		}
+		enc.key("manage_data_result")
+		if err = u.ManageDataResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_data_result", err)
+		}
+	case OperationTypeBumpSequence:
+		if u.BumpSeqResult == nil {
+			return jsonFieldError("bump_seq_result", errEncodeNilUnionValue())
+		}
+		enc.key("bump_seq_result")
+		if err = u.BumpSeqResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("bump_seq_result", err)
+		}
+	case OperationTypeManageBuyOffer:
+		if u.ManageBuyOfferResult == nil {
+			return jsonFieldError("manage_buy_offer_result", errEncodeNilUnionValue())
+		}
+		enc.key("manage_buy_offer_result")
+		if err = u.ManageBuyOfferResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("manage_buy_offer_result", err)
+		}
+	case OperationTypePathPaymentStrictSend:
+		if u.PathPaymentStrictSendResult == nil {
+			return jsonFieldError("path_payment_strict_send_result", errEncodeNilUnionValue())
+		}
+		enc.key("path_payment_strict_send_result")
+		if err = u.PathPaymentStrictSendResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("path_payment_strict_send_result", err)
+		}
+	case OperationTypeCreateClaimableBalance:
+		if u.CreateClaimableBalanceResult == nil {
+			return jsonFieldError("create_claimable_balance_result", errEncodeNilUnionValue())
+		}
+		enc.key("create_claimable_balance_result")
+		if err = u.CreateClaimableBalanceResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("create_claimable_balance_result", err)
+		}
+	case OperationTypeClaimClaimableBalance:
+		if u.ClaimClaimableBalanceResult == nil {
+			return jsonFieldError("claim_claimable_balance_result", errEncodeNilUnionValue())
+		}
+		enc.key("claim_claimable_balance_result")
+		if err = u.ClaimClaimableBalanceResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("claim_claimable_balance_result", err)
+		}
+	case OperationTypeBeginSponsoringFutureReserves:
+		if u.BeginSponsoringFutureReservesResult == nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_result", errEncodeNilUnionValue())
+		}
+		enc.key("begin_sponsoring_future_reserves_result")
+		if err = u.BeginSponsoringFutureReservesResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_result", err)
+		}
+	case OperationTypeEndSponsoringFutureReserves:
+		if u.EndSponsoringFutureReservesResult == nil {
+			return jsonFieldError("end_sponsoring_future_reserves_result", errEncodeNilUnionValue())
+		}
+		enc.key("end_sponsoring_future_reserves_result")
+		if err = u.EndSponsoringFutureReservesResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("end_sponsoring_future_reserves_result", err)
+		}
+	case OperationTypeRevokeSponsorship:
+		if u.RevokeSponsorshipResult == nil {
+			return jsonFieldError("revoke_sponsorship_result", errEncodeNilUnionValue())
+		}
+		enc.key("revoke_sponsorship_result")
+		if err = u.RevokeSponsorshipResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("revoke_sponsorship_result", err)
+		}
+	case OperationTypeClawback:
+		if u.ClawbackResult == nil {
+			return jsonFieldError("clawback_result", errEncodeNilUnionValue())
+		}
+		enc.key("clawback_result")
+		if err = u.ClawbackResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("clawback_result", err)
+		}
+	case OperationTypeClawbackClaimableBalance:
+		if u.ClawbackClaimableBalanceResult == nil {
+			return jsonFieldError("clawback_claimable_balance_result", errEncodeNilUnionValue())
+		}
+		enc.key("clawback_claimable_balance_result")
+		if err = u.ClawbackClaimableBalanceResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("clawback_claimable_balance_result", err)
+		}
+	case OperationTypeSetTrustLineFlags:
+		if u.SetTrustLineFlagsResult == nil {
+			return jsonFieldError("set_trust_line_flags_result", errEncodeNilUnionValue())
+		}
+		enc.key("set_trust_line_flags_result")
+		if err = u.SetTrustLineFlagsResult.encodeJSON(enc); err != nil {
+			return jsonFieldError("set_trust_line_flags_result", err)
+		}
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Type))
+	}
+	enc.endObject()
+	return nil
 }
 
-// GetSetTrustLineFlagsResult retrieves the SetTrustLineFlagsResult value from the union,
-// returning ok if the union's switch indicated the value is valid.
-func (u OperationResultTr) GetSetTrustLineFlagsResult() (result SetTrustLineFlagsResult, ok bool) {
-	armName, _ := u.ArmForSwitch(int32(u.Type))
-
-	if armName == "SetTrustLineFlagsResult" {
-		result = *u.SetTrustLineFlagsResult
-		ok = true
+// decodeJSON decodes the JSON representation of this value.
+func (u *OperationResultTr) decodeJSON(data []byte) error {
+	*u = OperationResultTr{}
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
 	}
-
-	return
+	if err = u.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch OperationType(u.Type) {
+	case OperationTypeCreateAccount:
+		if err = checkJSONKeys(fields, "type", "create_account_result"); err != nil {
+			return err
+		}
+		u.CreateAccountResult = new(CreateAccountResult)
+		if err = u.CreateAccountResult.decodeJSON(fields["create_account_result"]); err != nil {
+			return jsonFieldError("create_account_result", err)
+		}
+		return nil
+	case OperationTypePayment:
+		if err = checkJSONKeys(fields, "type", "payment_result"); err != nil {
+			return err
+		}
+		u.PaymentResult = new(PaymentResult)
+		if err = u.PaymentResult.decodeJSON(fields["payment_result"]); err != nil {
+			return jsonFieldError("payment_result", err)
+		}
+		return nil
+	case OperationTypePathPaymentStrictReceive:
+		if err = checkJSONKeys(fields, "type", "path_payment_strict_receive_result"); err != nil {
+			return err
+		}
+		u.PathPaymentStrictReceiveResult = new(PathPaymentStrictReceiveResult)
+		if err = u.PathPaymentStrictReceiveResult.decodeJSON(fields["path_payment_strict_receive_result"]); err != nil {
+			return jsonFieldError("path_payment_strict_receive_result", err)
+		}
+		return nil
+	case OperationTypeManageSellOffer:
+		if err = checkJSONKeys(fields, "type", "manage_sell_offer_result"); err != nil {
+			return err
+		}
+		u.ManageSellOfferResult = new(ManageSellOfferResult)
+		if err = u.ManageSellOfferResult.decodeJSON(fields["manage_sell_offer_result"]); err != nil {
+			return jsonFieldError("manage_sell_offer_result", err)
+		}
+		return nil
+	case OperationTypeCreatePassiveSellOffer:
+		if err = checkJSONKeys(fields, "type", "create_passive_sell_offer_result"); err != nil {
+			return err
+		}
+		u.CreatePassiveSellOfferResult = new(ManageSellOfferResult)
+		if err = u.CreatePassiveSellOfferResult.decodeJSON(fields["create_passive_sell_offer_result"]); err != nil {
+			return jsonFieldError("create_passive_sell_offer_result", err)
+		}
+		return nil
+	case OperationTypeSetOptions:
+		if err = checkJSONKeys(fields, "type", "set_options_result"); err != nil {
+			return err
+		}
+		u.SetOptionsResult = new(SetOptionsResult)
+		if err = u.SetOptionsResult.decodeJSON(fields["set_options_result"]); err != nil {
+			return jsonFieldError("set_options_result", err)
+		}
+		return nil
+	case OperationTypeChangeTrust:
+		if err = checkJSONKeys(fields, "type", "change_trust_result"); err != nil {
+			return err
+		}
+		u.ChangeTrustResult = new(ChangeTrustResult)
+		if err = u.ChangeTrustResult.decodeJSON(fields["change_trust_result"]); err != nil {
+			return jsonFieldError("change_trust_result", err)
+		}
+		return nil
+	case OperationTypeAllowTrust:
+		if err = checkJSONKeys(fields, "type", "allow_trust_result"); err != nil {
+			return err
+		}
+		u.AllowTrustResult = new(AllowTrustResult)
+		if err = u.AllowTrustResult.decodeJSON(fields["allow_trust_result"]); err != nil {
+			return jsonFieldError("allow_trust_result", err)
+		}
+		return nil
+	case OperationTypeAccountMerge:
+		if err = checkJSONKeys(fields, "type", "account_merge_result"); err != nil {
+			return err
+		}
+		u.AccountMergeResult = new(AccountMergeResult)
+		if err = u.AccountMergeResult.decodeJSON(fields["account_merge_result"]); err != nil {
+			return jsonFieldError("account_merge_result", err)
+		}
+		return nil
+	case OperationTypeInflation:
+		if err = checkJSONKeys(fields, "type", "inflation_result"); err != nil {
+			return err
+		}
+		u.InflationResult = new(InflationResult)
+		if err = u.InflationResult.decodeJSON(fields["inflation_result"]); err != nil {
+			return jsonFieldError("inflation_result", err)
+		}
+		return nil
+	case OperationTypeManageData:
+		if err = checkJSONKeys(fields, "type", "manage_data_result"); err != nil {
+			return err
+		}
+		u.ManageDataResult = new(ManageDataResult)
+		if err = u.ManageDataResult.decodeJSON(fields["manage_data_result"]); err != nil {
+			return jsonFieldError("manage_data_result", err)
+		}
+		return nil
+	case OperationTypeBumpSequence:
+		if err = checkJSONKeys(fields, "type", "bump_seq_result"); err != nil {
+			return err
+		}
+		u.BumpSeqResult = new(BumpSequenceResult)
+		if err = u.BumpSeqResult.decodeJSON(fields["bump_seq_result"]); err != nil {
+			return jsonFieldError("bump_seq_result", err)
+		}
+		return nil
+	case OperationTypeManageBuyOffer:
+		if err = checkJSONKeys(fields, "type", "manage_buy_offer_result"); err != nil {
+			return err
+		}
+		u.ManageBuyOfferResult = new(ManageBuyOfferResult)
+		if err = u.ManageBuyOfferResult.decodeJSON(fields["manage_buy_offer_result"]); err != nil {
+			return jsonFieldError("manage_buy_offer_result", err)
+		}
+		return nil
+	case OperationTypePathPaymentStrictSend:
+		if err = checkJSONKeys(fields, "type", "path_payment_strict_send_result"); err != nil {
+			return err
+		}
+		u.PathPaymentStrictSendResult = new(PathPaymentStrictSendResult)
+		if err = u.PathPaymentStrictSendResult.decodeJSON(fields["path_payment_strict_send_result"]); err != nil {
+			return jsonFieldError("path_payment_strict_send_result", err)
+		}
+		return nil
+	case OperationTypeCreateClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "create_claimable_balance_result"); err != nil {
+			return err
+		}
+		u.CreateClaimableBalanceResult = new(CreateClaimableBalanceResult)
+		if err = u.CreateClaimableBalanceResult.decodeJSON(fields["create_claimable_balance_result"]); err != nil {
+			return jsonFieldError("create_claimable_balance_result", err)
+		}
+		return nil
+	case OperationTypeClaimClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "claim_claimable_balance_result"); err != nil {
+			return err
+		}
+		u.ClaimClaimableBalanceResult = new(ClaimClaimableBalanceResult)
+		if err = u.ClaimClaimableBalanceResult.decodeJSON(fields["claim_claimable_balance_result"]); err != nil {
+			return jsonFieldError("claim_claimable_balance_result", err)
+		}
+		return nil
+	case OperationTypeBeginSponsoringFutureReserves:
+		if err = checkJSONKeys(fields, "type", "begin_sponsoring_future_reserves_result"); err != nil {
+			return err
+		}
+		u.BeginSponsoringFutureReservesResult = new(BeginSponsoringFutureReservesResult)
+		if err = u.BeginSponsoringFutureReservesResult.decodeJSON(fields["begin_sponsoring_future_reserves_result"]); err != nil {
+			return jsonFieldError("begin_sponsoring_future_reserves_result", err)
+		}
+		return nil
+	case OperationTypeEndSponsoringFutureReserves:
+		if err = checkJSONKeys(fields, "type", "end_sponsoring_future_reserves_result"); err != nil {
+			return err
+		}
+		u.EndSponsoringFutureReservesResult = new(EndSponsoringFutureReservesResult)
+		if err = u.EndSponsoringFutureReservesResult.decodeJSON(fields["end_sponsoring_future_reserves_result"]); err != nil {
+			return jsonFieldError("end_sponsoring_future_reserves_result", err)
+		}
+		return nil
+	case OperationTypeRevokeSponsorship:
+		if err = checkJSONKeys(fields, "type", "revoke_sponsorship_result"); err != nil {
+			return err
+		}
+		u.RevokeSponsorshipResult = new(RevokeSponsorshipResult)
+		if err = u.RevokeSponsorshipResult.decodeJSON(fields["revoke_sponsorship_result"]); err != nil {
+			return jsonFieldError("revoke_sponsorship_result", err)
+		}
+		return nil
+	case OperationTypeClawback:
+		if err = checkJSONKeys(fields, "type", "clawback_result"); err != nil {
+			return err
+		}
+		u.ClawbackResult = new(ClawbackResult)
+		if err = u.ClawbackResult.decodeJSON(fields["clawback_result"]); err != nil {
+			return jsonFieldError("clawback_result", err)
+		}
+		return nil
+	case OperationTypeClawbackClaimableBalance:
+		if err = checkJSONKeys(fields, "type", "clawback_claimable_balance_result"); err != nil {
+			return err
+		}
+		u.ClawbackClaimableBalanceResult = new(ClawbackClaimableBalanceResult)
+		if err = u.ClawbackClaimableBalanceResult.decodeJSON(fields["clawback_claimable_balance_result"]); err != nil {
+			return jsonFieldError("clawback_claimable_balance_result", err)
+		}
+		return nil
+	case OperationTypeSetTrustLineFlags:
+		if err = checkJSONKeys(fields, "type", "set_trust_line_flags_result"); err != nil {
+			return err
+		}
+		u.SetTrustLineFlagsResult = new(SetTrustLineFlagsResult)
+		if err = u.SetTrustLineFlagsResult.decodeJSON(fields["set_trust_line_flags_result"]); err != nil {
+			return jsonFieldError("set_trust_line_flags_result", err)
+		}
+		return nil
+	}
+	return jsonFieldError("type", errDecodeInvalidUnionSwitch(int32(u.Type)))
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -16099,6 +37695,107 @@ func (u OperationResult) GetTr() (result OperationResultTr, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u OperationResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch OperationResultCode(u.Code) {
+	case OperationResultCodeOpInner:
+		if u.Tr == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Tr.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *OperationResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = OperationResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = OperationResultCode(sw)
+	switch OperationResultCode(u.Code) {
+	case OperationResultCodeOpInner:
+		u.Tr = new(OperationResultTr)
+		nTmp, err = u.Tr.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u OperationResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch OperationResultCode(u.Code) {
+	case OperationResultCodeOpInner:
+		if u.Tr == nil {
+			return jsonFieldError("tr", errEncodeNilUnionValue())
+		}
+		enc.key("tr")
+		if err = u.Tr.encodeJSON(enc); err != nil {
+			return jsonFieldError("tr", err)
+		}
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *OperationResult) decodeJSON(data []byte) error {
+	*u = OperationResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch OperationResultCode(u.Code) {
+	case OperationResultCodeOpInner:
+		if err = checkJSONKeys(fields, "code", "tr"); err != nil {
+			return err
+		}
+		u.Tr = new(OperationResultTr)
+		if err = u.Tr.decodeJSON(fields["tr"]); err != nil {
+			return jsonFieldError("tr", err)
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s OperationResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16196,6 +37893,67 @@ func (e TransactionResultCode) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e TransactionResultCode) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *TransactionResultCode) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = TransactionResultCode(v)
+	return n, nil
+}
+
+var transactionResultCodeJSONNames = map[int32]string{
+	1:   "tx_fee_bump_inner_success",
+	0:   "tx_success",
+	-1:  "tx_failed",
+	-2:  "tx_too_early",
+	-3:  "tx_too_late",
+	-4:  "tx_missing_operation",
+	-5:  "tx_bad_seq",
+	-6:  "tx_bad_auth",
+	-7:  "tx_insufficient_balance",
+	-8:  "tx_no_account",
+	-9:  "tx_insufficient_fee",
+	-10: "tx_bad_auth_extra",
+	-11: "tx_internal_error",
+	-12: "tx_not_supported",
+	-13: "tx_fee_bump_inner_failed",
+	-14: "tx_bad_sponsorship",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e TransactionResultCode) encodeJSON(enc *jsonEncoder) error {
+	name, ok := transactionResultCodeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *TransactionResultCode) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, transactionResultCodeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = TransactionResultCode(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionResultCode) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16254,106 +38012,436 @@ func (u InnerTransactionResultResult) SwitchFieldName() string {
 func (u InnerTransactionResultResult) ArmForSwitch(sw int32) (string, bool) {
 	switch TransactionResultCode(sw) {
 	case TransactionResultCodeTxSuccess:
-		return "Results", true
+		return "Results", true
+	case TransactionResultCodeTxFailed:
+		return "Results", true
+	case TransactionResultCodeTxTooEarly:
+		return "", true
+	case TransactionResultCodeTxTooLate:
+		return "", true
+	case TransactionResultCodeTxMissingOperation:
+		return "", true
+	case TransactionResultCodeTxBadSeq:
+		return "", true
+	case TransactionResultCodeTxBadAuth:
+		return "", true
+	case TransactionResultCodeTxInsufficientBalance:
+		return "", true
+	case TransactionResultCodeTxNoAccount:
+		return "", true
+	case TransactionResultCodeTxInsufficientFee:
+		return "", true
+	case TransactionResultCodeTxBadAuthExtra:
+		return "", true
+	case TransactionResultCodeTxInternalError:
+		return "", true
+	case TransactionResultCodeTxNotSupported:
+		return "", true
+	case TransactionResultCodeTxBadSponsorship:
+		return "", true
+	}
+	return "-", false
+}
+
+// NewInnerTransactionResultResult creates a new  InnerTransactionResultResult.
+func NewInnerTransactionResultResult(code TransactionResultCode, value interface{}) (result InnerTransactionResultResult, err error) {
+	result.Code = code
+	switch TransactionResultCode(code) {
+	case TransactionResultCodeTxSuccess:
+		tv, ok := value.([]OperationResult)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be []OperationResult")
+			return
+		}
+		result.Results = &tv
+	case TransactionResultCodeTxFailed:
+		tv, ok := value.([]OperationResult)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be []OperationResult")
+			return
+		}
+		result.Results = &tv
+	case TransactionResultCodeTxTooEarly:
+		// void
+	case TransactionResultCodeTxTooLate:
+		// void
+	case TransactionResultCodeTxMissingOperation:
+		// void
+	case TransactionResultCodeTxBadSeq:
+		// void
+	case TransactionResultCodeTxBadAuth:
+		// void
+	case TransactionResultCodeTxInsufficientBalance:
+		// void
+	case TransactionResultCodeTxNoAccount:
+		// void
+	case TransactionResultCodeTxInsufficientFee:
+		// void
+	case TransactionResultCodeTxBadAuthExtra:
+		// void
+	case TransactionResultCodeTxInternalError:
+		// void
+	case TransactionResultCodeTxNotSupported:
+		// void
+	case TransactionResultCodeTxBadSponsorship:
+		// void
+	}
+	return
+}
+
+// MustResults retrieves the Results value from the union,
+// panicing if the value is not set.
+func (u InnerTransactionResultResult) MustResults() []OperationResult {
+	val, ok := u.GetResults()
+
+	if !ok {
+		panic("arm Results is not set")
+	}
+
+	return val
+}
+
+// GetResults retrieves the Results value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u InnerTransactionResultResult) GetResults() (result []OperationResult, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Code))
+
+	if armName == "Results" {
+		result = *u.Results
+		ok = true
+	}
+
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u InnerTransactionResultResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxSuccess:
+		if u.Results == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Results))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Results); i++ {
+			if err = (*u.Results)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case TransactionResultCodeTxFailed:
+		if u.Results == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Results))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Results); i++ {
+			if err = (*u.Results)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case TransactionResultCodeTxTooEarly:
+		// Void
+		return nil
+	case TransactionResultCodeTxTooLate:
+		// Void
+		return nil
+	case TransactionResultCodeTxMissingOperation:
+		// Void
+		return nil
+	case TransactionResultCodeTxBadSeq:
+		// Void
+		return nil
+	case TransactionResultCodeTxBadAuth:
+		// Void
+		return nil
+	case TransactionResultCodeTxInsufficientBalance:
+		// Void
+		return nil
+	case TransactionResultCodeTxNoAccount:
+		// Void
+		return nil
+	case TransactionResultCodeTxInsufficientFee:
+		// Void
+		return nil
+	case TransactionResultCodeTxBadAuthExtra:
+		// Void
+		return nil
+	case TransactionResultCodeTxInternalError:
+		// Void
+		return nil
+	case TransactionResultCodeTxNotSupported:
+		// Void
+		return nil
+	case TransactionResultCodeTxBadSponsorship:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Code))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *InnerTransactionResultResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	*u = InnerTransactionResultResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = TransactionResultCode(sw)
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxSuccess:
+		u.Results = new([]OperationResult)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Results = nil
+		if l > 0 {
+			*u.Results = make([]OperationResult, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Results = append(*u.Results, OperationResult{})
+				nTmp, err = (*u.Results)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
 	case TransactionResultCodeTxFailed:
-		return "Results", true
+		u.Results = new([]OperationResult)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Results = nil
+		if l > 0 {
+			*u.Results = make([]OperationResult, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Results = append(*u.Results, OperationResult{})
+				nTmp, err = (*u.Results)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
 	case TransactionResultCodeTxTooEarly:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxTooLate:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxMissingOperation:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxBadSeq:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxBadAuth:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxInsufficientBalance:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxNoAccount:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxInsufficientFee:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxBadAuthExtra:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxInternalError:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxNotSupported:
-		return "", true
+		// Void
+		return n, nil
 	case TransactionResultCodeTxBadSponsorship:
-		return "", true
+		// Void
+		return n, nil
 	}
-	return "-", false
+	return n, errDecodeInvalidUnionSwitch(int32(u.Code))
 }
 
-// NewInnerTransactionResultResult creates a new  InnerTransactionResultResult.
-func NewInnerTransactionResultResult(code TransactionResultCode, value interface{}) (result InnerTransactionResultResult, err error) {
-	result.Code = code
-	switch TransactionResultCode(code) {
+// encodeJSON writes the JSON representation of this value to enc.
+func (u InnerTransactionResultResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch TransactionResultCode(u.Code) {
 	case TransactionResultCodeTxSuccess:
-		tv, ok := value.([]OperationResult)
-		if !ok {
-			err = fmt.Errorf("invalid value, must be []OperationResult")
-			return
+		if u.Results == nil {
+			return jsonFieldError("results", errEncodeNilUnionValue())
 		}
-		result.Results = &tv
+		enc.key("results")
+		enc.beginArray()
+		for i := 0; i < len(*u.Results); i++ {
+			enc.element()
+			if err = (*u.Results)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
 	case TransactionResultCodeTxFailed:
-		tv, ok := value.([]OperationResult)
-		if !ok {
-			err = fmt.Errorf("invalid value, must be []OperationResult")
-			return
+		if u.Results == nil {
+			return jsonFieldError("results", errEncodeNilUnionValue())
 		}
-		result.Results = &tv
+		enc.key("results")
+		enc.beginArray()
+		for i := 0; i < len(*u.Results); i++ {
+			enc.element()
+			if err = (*u.Results)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
 	case TransactionResultCodeTxTooEarly:
-		// void
+		// Void
 	case TransactionResultCodeTxTooLate:
-		// void
+		// Void
 	case TransactionResultCodeTxMissingOperation:
-		// void
+		// Void
 	case TransactionResultCodeTxBadSeq:
-		// void
+		// Void
 	case TransactionResultCodeTxBadAuth:
-		// void
+		// Void
 	case TransactionResultCodeTxInsufficientBalance:
-		// void
+		// Void
 	case TransactionResultCodeTxNoAccount:
-		// void
+		// Void
 	case TransactionResultCodeTxInsufficientFee:
-		// void
+		// Void
 	case TransactionResultCodeTxBadAuthExtra:
-		// void
+		// Void
 	case TransactionResultCodeTxInternalError:
-		// void
+		// Void
 	case TransactionResultCodeTxNotSupported:
-		// void
+		// Void
 	case TransactionResultCodeTxBadSponsorship:
-		// void
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.Code))
 	}
-	return
+	enc.endObject()
+	return nil
 }
 
-// MustResults retrieves the Results value from the union,
-// panicing if the value is not set.
-func (u InnerTransactionResultResult) MustResults() []OperationResult {
-	val, ok := u.GetResults()
-
-	if !ok {
-		panic("arm Results is not set")
+// decodeJSON decodes the JSON representation of this value.
+func (u *InnerTransactionResultResult) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = InnerTransactionResultResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
 	}
-
-	return val
-}
-
-// GetResults retrieves the Results value from the union,
-// returning ok if the union's switch indicated the value is valid.
-func (u InnerTransactionResultResult) GetResults() (result []OperationResult, ok bool) {
-	armName, _ := u.ArmForSwitch(int32(u.Code))
-
-	if armName == "Results" {
-		result = *u.Results
-		ok = true
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
 	}
-
-	return
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxSuccess:
+		if err = checkJSONKeys(fields, "code", "results"); err != nil {
+			return err
+		}
+		u.Results = new([]OperationResult)
+		if elems, err = decodeJSONArray(fields["results"], 0); err != nil {
+			return jsonFieldError("results", err)
+		}
+		*u.Results = nil
+		if len(elems) > 0 {
+			*u.Results = make([]OperationResult, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Results)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case TransactionResultCodeTxFailed:
+		if err = checkJSONKeys(fields, "code", "results"); err != nil {
+			return err
+		}
+		u.Results = new([]OperationResult)
+		if elems, err = decodeJSONArray(fields["results"], 0); err != nil {
+			return jsonFieldError("results", err)
+		}
+		*u.Results = nil
+		if len(elems) > 0 {
+			*u.Results = make([]OperationResult, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Results)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case TransactionResultCodeTxTooEarly:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxTooLate:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxMissingOperation:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxBadSeq:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxBadAuth:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxInsufficientBalance:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxNoAccount:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxInsufficientFee:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxBadAuthExtra:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxInternalError:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxNotSupported:
+		// Void
+		return checkJSONKeys(fields, "code")
+	case TransactionResultCodeTxBadSponsorship:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+	return jsonFieldError("code", errDecodeInvalidUnionSwitch(int32(u.Code)))
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -16412,6 +38500,73 @@ func NewInnerTransactionResultExt(v int32, value interface{}) (result InnerTrans
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u InnerTransactionResultExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *InnerTransactionResultExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = InnerTransactionResultExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u InnerTransactionResultExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *InnerTransactionResultExt) decodeJSON(data []byte) error {
+	*u = InnerTransactionResultExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return checkJSONKeys(fields, "v")
+	}
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InnerTransactionResultExt) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16475,6 +38630,81 @@ type InnerTransactionResult struct {
 	Ext        InnerTransactionResultExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s InnerTransactionResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.FeeCharged.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Result.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *InnerTransactionResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.FeeCharged.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Result.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s InnerTransactionResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("fee_charged")
+	if err = s.FeeCharged.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee_charged", err)
+	}
+	enc.key("result")
+	if err = s.Result.encodeJSON(enc); err != nil {
+		return jsonFieldError("result", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *InnerTransactionResult) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "fee_charged", "result", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.FeeCharged.decodeJSON(fields["fee_charged"]); err != nil {
+		return jsonFieldError("fee_charged", err)
+	}
+	if err = s.Result.decodeJSON(fields["result"]); err != nil {
+		return jsonFieldError("result", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InnerTransactionResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16506,6 +38736,66 @@ type InnerTransactionResultPair struct {
 	Result          InnerTransactionResult
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s InnerTransactionResultPair) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.TransactionHash.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Result.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *InnerTransactionResultPair) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.TransactionHash.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Result.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s InnerTransactionResultPair) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("transaction_hash")
+	if err = s.TransactionHash.encodeJSON(enc); err != nil {
+		return jsonFieldError("transaction_hash", err)
+	}
+	enc.key("result")
+	if err = s.Result.encodeJSON(enc); err != nil {
+		return jsonFieldError("result", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *InnerTransactionResultPair) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "transaction_hash", "result")
+	if err != nil {
+		return err
+	}
+	if err = s.TransactionHash.decodeJSON(fields["transaction_hash"]); err != nil {
+		return jsonFieldError("transaction_hash", err)
+	}
+	if err = s.Result.decodeJSON(fields["result"]); err != nil {
+		return jsonFieldError("result", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s InnerTransactionResultPair) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16655,6 +38945,276 @@ func (u TransactionResultResult) GetResults() (result []OperationResult, ok bool
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionResultResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Code.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		if u.InnerResultPair == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.InnerResultPair.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		if u.InnerResultPair == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.InnerResultPair.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case TransactionResultCodeTxSuccess:
+		if u.Results == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Results))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Results); i++ {
+			if err = (*u.Results)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case TransactionResultCodeTxFailed:
+		if u.Results == nil {
+			return errEncodeNilUnionValue()
+		}
+		if _, err = enc.EncodeUint(uint32(len(*u.Results))); err != nil {
+			return err
+		}
+		for i := 0; i < len(*u.Results); i++ {
+			if err = (*u.Results)[i].EncodeTo(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		// Void
+		return nil
+	}
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionResultResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var l uint32
+	var err error
+	var n, nTmp int
+	*u = TransactionResultResult{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Code.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Code = TransactionResultCode(sw)
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		u.InnerResultPair = new(InnerTransactionResultPair)
+		nTmp, err = u.InnerResultPair.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		u.InnerResultPair = new(InnerTransactionResultPair)
+		nTmp, err = u.InnerResultPair.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case TransactionResultCodeTxSuccess:
+		u.Results = new([]OperationResult)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Results = nil
+		if l > 0 {
+			*u.Results = make([]OperationResult, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Results = append(*u.Results, OperationResult{})
+				nTmp, err = (*u.Results)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	case TransactionResultCodeTxFailed:
+		u.Results = new([]OperationResult)
+		l, nTmp, err = dec.DecodeUint()
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		if l > math.MaxInt32 {
+			return n, errDecodeMaxSlice(l)
+		}
+		*u.Results = nil
+		if l > 0 {
+			*u.Results = make([]OperationResult, 0, sliceCapacity(l))
+			for i := uint32(0); i < l; i++ {
+				*u.Results = append(*u.Results, OperationResult{})
+				nTmp, err = (*u.Results)[i].DecodeFrom(dec)
+				n += nTmp
+				if err != nil {
+					return n, err
+				}
+			}
+		}
+		return n, nil
+	default:
+		// Void
+		return n, nil
+	}
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionResultResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("code")
+	if err = u.Code.encodeJSON(enc); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		if u.InnerResultPair == nil {
+			return jsonFieldError("inner_result_pair", errEncodeNilUnionValue())
+		}
+		enc.key("inner_result_pair")
+		if err = u.InnerResultPair.encodeJSON(enc); err != nil {
+			return jsonFieldError("inner_result_pair", err)
+		}
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		if u.InnerResultPair == nil {
+			return jsonFieldError("inner_result_pair", errEncodeNilUnionValue())
+		}
+		enc.key("inner_result_pair")
+		if err = u.InnerResultPair.encodeJSON(enc); err != nil {
+			return jsonFieldError("inner_result_pair", err)
+		}
+	case TransactionResultCodeTxSuccess:
+		if u.Results == nil {
+			return jsonFieldError("results", errEncodeNilUnionValue())
+		}
+		enc.key("results")
+		enc.beginArray()
+		for i := 0; i < len(*u.Results); i++ {
+			enc.element()
+			if err = (*u.Results)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	case TransactionResultCodeTxFailed:
+		if u.Results == nil {
+			return jsonFieldError("results", errEncodeNilUnionValue())
+		}
+		enc.key("results")
+		enc.beginArray()
+		for i := 0; i < len(*u.Results); i++ {
+			enc.element()
+			if err = (*u.Results)[i].encodeJSON(enc); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		enc.endArray()
+	default:
+		// Void
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionResultResult) decodeJSON(data []byte) error {
+	var elems [][]byte
+	*u = TransactionResultResult{}
+	fields, err := decodeJSONUnion(data, "code")
+	if err != nil {
+		return err
+	}
+	if err = u.Code.decodeJSON(fields["code"]); err != nil {
+		return jsonFieldError("code", err)
+	}
+	switch TransactionResultCode(u.Code) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		if err = checkJSONKeys(fields, "code", "inner_result_pair"); err != nil {
+			return err
+		}
+		u.InnerResultPair = new(InnerTransactionResultPair)
+		if err = u.InnerResultPair.decodeJSON(fields["inner_result_pair"]); err != nil {
+			return jsonFieldError("inner_result_pair", err)
+		}
+		return nil
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		if err = checkJSONKeys(fields, "code", "inner_result_pair"); err != nil {
+			return err
+		}
+		u.InnerResultPair = new(InnerTransactionResultPair)
+		if err = u.InnerResultPair.decodeJSON(fields["inner_result_pair"]); err != nil {
+			return jsonFieldError("inner_result_pair", err)
+		}
+		return nil
+	case TransactionResultCodeTxSuccess:
+		if err = checkJSONKeys(fields, "code", "results"); err != nil {
+			return err
+		}
+		u.Results = new([]OperationResult)
+		if elems, err = decodeJSONArray(fields["results"], 0); err != nil {
+			return jsonFieldError("results", err)
+		}
+		*u.Results = nil
+		if len(elems) > 0 {
+			*u.Results = make([]OperationResult, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Results)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	case TransactionResultCodeTxFailed:
+		if err = checkJSONKeys(fields, "code", "results"); err != nil {
+			return err
+		}
+		u.Results = new([]OperationResult)
+		if elems, err = decodeJSONArray(fields["results"], 0); err != nil {
+			return jsonFieldError("results", err)
+		}
+		*u.Results = nil
+		if len(elems) > 0 {
+			*u.Results = make([]OperationResult, len(elems))
+		}
+		for i, elem := range elems {
+			if err = (*u.Results)[i].decodeJSON(elem); err != nil {
+				return jsonFieldError("results", jsonIndexError(i, err))
+			}
+		}
+		return nil
+	default:
+		// Void
+		return checkJSONKeys(fields, "code")
+	}
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionResultResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16701,14 +39261,81 @@ func (u TransactionResultExt) ArmForSwitch(sw int32) (string, bool) {
 	return "-", false
 }
 
-// NewTransactionResultExt creates a new  TransactionResultExt.
-func NewTransactionResultExt(v int32, value interface{}) (result TransactionResultExt, err error) {
-	result.V = v
-	switch int32(v) {
+// NewTransactionResultExt creates a new  TransactionResultExt.
+func NewTransactionResultExt(v int32, value interface{}) (result TransactionResultExt, err error) {
+	result.V = v
+	switch int32(v) {
+	case 0:
+		// void
+	}
+	return
+}
+
+// EncodeTo encodes this value using the Encoder.
+func (u TransactionResultExt) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeInt(u.V); err != nil {
+		return err
+	}
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.V))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *TransactionResultExt) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = TransactionResultExt{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	u.V = int32(sw)
+	switch int32(u.V) {
+	case 0:
+		// Void
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.V))
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (u TransactionResultExt) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("v")
+	enc.int32(u.V)
+	switch int32(u.V) {
+	case 0:
+		// Void
+	default:
+		return errEncodeInvalidUnionSwitch(int32(u.V))
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (u *TransactionResultExt) decodeJSON(data []byte) error {
+	*u = TransactionResultExt{}
+	fields, err := decodeJSONUnion(data, "v")
+	if err != nil {
+		return err
+	}
+	if u.V, err = decodeJSONInt32(fields["v"]); err != nil {
+		return jsonFieldError("v", err)
+	}
+	switch int32(u.V) {
 	case 0:
-		// void
+		// Void
+		return checkJSONKeys(fields, "v")
 	}
-	return
+	return jsonFieldError("v", errDecodeInvalidUnionSwitch(int32(u.V)))
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
@@ -16763,6 +39390,81 @@ type TransactionResult struct {
 	Ext        TransactionResultExt
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s TransactionResult) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = s.FeeCharged.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Result.EncodeTo(enc); err != nil {
+		return err
+	}
+	if err = s.Ext.EncodeTo(enc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *TransactionResult) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = s.FeeCharged.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Result.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	nTmp, err = s.Ext.DecodeFrom(dec)
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s TransactionResult) encodeJSON(enc *jsonEncoder) error {
+	var err error
+	enc.beginObject()
+	enc.key("fee_charged")
+	if err = s.FeeCharged.encodeJSON(enc); err != nil {
+		return jsonFieldError("fee_charged", err)
+	}
+	enc.key("result")
+	if err = s.Result.encodeJSON(enc); err != nil {
+		return jsonFieldError("result", err)
+	}
+	enc.key("ext")
+	if err = s.Ext.encodeJSON(enc); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *TransactionResult) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "fee_charged", "result", "ext")
+	if err != nil {
+		return err
+	}
+	if err = s.FeeCharged.decodeJSON(fields["fee_charged"]); err != nil {
+		return jsonFieldError("fee_charged", err)
+	}
+	if err = s.Result.decodeJSON(fields["result"]); err != nil {
+		return jsonFieldError("result", err)
+	}
+	if err = s.Ext.decodeJSON(fields["ext"]); err != nil {
+		return jsonFieldError("ext", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s TransactionResult) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16792,6 +39494,28 @@ func (e Hash) XDRMaxSize() int {
 	return 32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Hash) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Hash) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Hash) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s[:])
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Hash) decodeJSON(data []byte) error {
+	return decodeJSONFixedOpaque(data, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Hash) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16821,6 +39545,28 @@ func (e Uint256) XDRMaxSize() int {
 	return 32
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Uint256) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Uint256) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Uint256) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s[:])
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Uint256) decodeJSON(data []byte) error {
+	return decodeJSONFixedOpaque(data, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Uint256) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16845,6 +39591,38 @@ var (
 //
 type Uint32 uint32
 
+// EncodeTo encodes this value using the Encoder.
+func (s Uint32) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeUint(uint32(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Uint32) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeUint()
+	if err != nil {
+		return n, err
+	}
+	*s = Uint32(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Uint32) encodeJSON(enc *jsonEncoder) error {
+	enc.uint32(uint32(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Uint32) decodeJSON(data []byte) error {
+	v, err := decodeJSONUint32(data)
+	if err != nil {
+		return err
+	}
+	*s = Uint32(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Uint32) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16869,6 +39647,38 @@ var (
 //
 type Int32 int32
 
+// EncodeTo encodes this value using the Encoder.
+func (s Int32) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeInt(int32(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Int32) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	*s = Int32(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Int32) encodeJSON(enc *jsonEncoder) error {
+	enc.int32(int32(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Int32) decodeJSON(data []byte) error {
+	v, err := decodeJSONInt32(data)
+	if err != nil {
+		return err
+	}
+	*s = Int32(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Int32) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16893,6 +39703,38 @@ var (
 //
 type Uint64 uint64
 
+// EncodeTo encodes this value using the Encoder.
+func (s Uint64) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeUhyper(uint64(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Uint64) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeUhyper()
+	if err != nil {
+		return n, err
+	}
+	*s = Uint64(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Uint64) encodeJSON(enc *jsonEncoder) error {
+	enc.uint64(uint64(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Uint64) decodeJSON(data []byte) error {
+	v, err := decodeJSONUint64(data)
+	if err != nil {
+		return err
+	}
+	*s = Uint64(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Uint64) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16917,6 +39759,38 @@ var (
 //
 type Int64 int64
 
+// EncodeTo encodes this value using the Encoder.
+func (s Int64) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeHyper(int64(s))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Int64) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeHyper()
+	if err != nil {
+		return n, err
+	}
+	*s = Int64(v)
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Int64) encodeJSON(enc *jsonEncoder) error {
+	enc.int64(int64(s))
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Int64) decodeJSON(data []byte) error {
+	v, err := decodeJSONInt64(data)
+	if err != nil {
+		return err
+	}
+	*s = Int64(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Int64) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -16976,6 +39850,55 @@ func (e CryptoKeyType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e CryptoKeyType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *CryptoKeyType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = CryptoKeyType(v)
+	return n, nil
+}
+
+var cryptoKeyTypeJSONNames = map[int32]string{
+	0:   "key_type_ed25519",
+	1:   "key_type_pre_auth_tx",
+	2:   "key_type_hash_x",
+	256: "key_type_muxed_ed25519",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e CryptoKeyType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := cryptoKeyTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *CryptoKeyType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, cryptoKeyTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = CryptoKeyType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s CryptoKeyType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17024,6 +39947,52 @@ func (e PublicKeyType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e PublicKeyType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *PublicKeyType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = PublicKeyType(v)
+	return n, nil
+}
+
+var publicKeyTypeJSONNames = map[int32]string{
+	0: "public_key_type_ed25519",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e PublicKeyType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := publicKeyTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *PublicKeyType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, publicKeyTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = PublicKeyType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PublicKeyType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17078,6 +40047,54 @@ func (e SignerKeyType) String() string {
 	return name
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (e SignerKeyType) EncodeTo(enc *xdr.Encoder) error {
+	if !e.ValidEnum(int32(e)) {
+		return errEncodeInvalidEnum(e)
+	}
+	_, err := enc.EncodeInt(int32(e))
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (e *SignerKeyType) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := dec.DecodeInt()
+	if err != nil {
+		return n, err
+	}
+	if !e.ValidEnum(v) {
+		return n, errDecodeInvalidEnum(v)
+	}
+	*e = SignerKeyType(v)
+	return n, nil
+}
+
+var signerKeyTypeJSONNames = map[int32]string{
+	0: "signer_key_type_ed25519",
+	1: "signer_key_type_pre_auth_tx",
+	2: "signer_key_type_hash_x",
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (e SignerKeyType) encodeJSON(enc *jsonEncoder) error {
+	name, ok := signerKeyTypeJSONNames[int32(e)]
+	if !ok {
+		return errEncodeInvalidEnum(e)
+	}
+	enc.string(name)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (e *SignerKeyType) decodeJSON(data []byte) error {
+	v, err := decodeJSONEnum(data, signerKeyTypeJSONNames)
+	if err != nil {
+		return err
+	}
+	*e = SignerKeyType(v)
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SignerKeyType) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17165,6 +40182,53 @@ func (u PublicKey) GetEd25519() (result Uint256, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u PublicKey) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch PublicKeyType(u.Type) {
+	case PublicKeyTypePublicKeyTypeEd25519:
+		if u.Ed25519 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Ed25519.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *PublicKey) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = PublicKey{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = PublicKeyType(sw)
+	switch PublicKeyType(u.Type) {
+	case PublicKeyTypePublicKeyTypeEd25519:
+		u.Ed25519 = new(Uint256)
+		nTmp, err = u.Ed25519.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s PublicKey) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17328,6 +40392,85 @@ func (u SignerKey) GetHashX() (result Uint256, ok bool) {
 	return
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (u SignerKey) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if err = u.Type.EncodeTo(enc); err != nil {
+		return err
+	}
+	switch SignerKeyType(u.Type) {
+	case SignerKeyTypeSignerKeyTypeEd25519:
+		if u.Ed25519 == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.Ed25519.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case SignerKeyTypeSignerKeyTypePreAuthTx:
+		if u.PreAuthTx == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.PreAuthTx.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	case SignerKeyTypeSignerKeyTypeHashX:
+		if u.HashX == nil {
+			return errEncodeNilUnionValue()
+		}
+		if err = u.HashX.EncodeTo(enc); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errEncodeInvalidUnionSwitch(int32(u.Type))
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (u *SignerKey) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	*u = SignerKey{}
+	var sw int32
+	sw, nTmp, err = dec.DecodeInt()
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	if !u.Type.ValidEnum(sw) {
+		return n, errDecodeInvalidUnionEnum(sw)
+	}
+	u.Type = SignerKeyType(sw)
+	switch SignerKeyType(u.Type) {
+	case SignerKeyTypeSignerKeyTypeEd25519:
+		u.Ed25519 = new(Uint256)
+		nTmp, err = u.Ed25519.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case SignerKeyTypeSignerKeyTypePreAuthTx:
+		u.PreAuthTx = new(Uint256)
+		nTmp, err = u.PreAuthTx.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	case SignerKeyTypeSignerKeyTypeHashX:
+		u.HashX = new(Uint256)
+		nTmp, err = u.HashX.DecodeFrom(dec)
+		n += nTmp
+		if err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, errDecodeInvalidUnionSwitch(int32(u.Type))
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SignerKey) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17357,6 +40500,38 @@ func (e Signature) XDRMaxSize() int {
 	return 64
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Signature) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeOpaque(s)
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Signature) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	v, n, err := decodeOpaque(dec, 64)
+	if err != nil {
+		return n, err
+	}
+	*s = v
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Signature) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s)
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Signature) decodeJSON(data []byte) error {
+	v, err := decodeJSONOpaque(data, 64)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Signature) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17386,6 +40561,28 @@ func (e SignatureHint) XDRMaxSize() int {
 	return 4
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s SignatureHint) EncodeTo(enc *xdr.Encoder) error {
+	_, err := enc.EncodeFixedOpaque(s[:])
+	return err
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *SignatureHint) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return decodeFixedOpaque(dec, s[:])
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s SignatureHint) encodeJSON(enc *jsonEncoder) error {
+	enc.opaque(s[:])
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *SignatureHint) decodeJSON(data []byte) error {
+	return decodeJSONFixedOpaque(data, s[:])
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s SignatureHint) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17441,6 +40638,26 @@ func (u NodeId) GetEd25519() (result Uint256, ok bool) {
 	return PublicKey(u).GetEd25519()
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s NodeId) EncodeTo(enc *xdr.Encoder) error {
+	return PublicKey(s).EncodeTo(enc)
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *NodeId) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	return (*PublicKey)(s).DecodeFrom(dec)
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s NodeId) encodeJSON(enc *jsonEncoder) error {
+	return PublicKey(s).encodeJSON(enc)
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *NodeId) decodeJSON(data []byte) error {
+	return (*PublicKey)(s).decodeJSON(data)
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s NodeId) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17470,6 +40687,48 @@ type Curve25519Secret struct {
 	Key [32]byte `xdrmaxsize:"32"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Curve25519Secret) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeFixedOpaque(s.Key[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Curve25519Secret) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = decodeFixedOpaque(dec, s.Key[:])
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Curve25519Secret) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("key")
+	enc.opaque(s.Key[:])
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Curve25519Secret) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "key")
+	if err != nil {
+		return err
+	}
+	if err = decodeJSONFixedOpaque(fields["key"], s.Key[:]); err != nil {
+		return jsonFieldError("key", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Curve25519Secret) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17499,6 +40758,48 @@ type Curve25519Public struct {
 	Key [32]byte `xdrmaxsize:"32"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s Curve25519Public) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeFixedOpaque(s.Key[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *Curve25519Public) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = decodeFixedOpaque(dec, s.Key[:])
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s Curve25519Public) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("key")
+	enc.opaque(s.Key[:])
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *Curve25519Public) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "key")
+	if err != nil {
+		return err
+	}
+	if err = decodeJSONFixedOpaque(fields["key"], s.Key[:]); err != nil {
+		return jsonFieldError("key", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s Curve25519Public) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17528,6 +40829,48 @@ type HmacSha256Key struct {
 	Key [32]byte `xdrmaxsize:"32"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s HmacSha256Key) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeFixedOpaque(s.Key[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *HmacSha256Key) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = decodeFixedOpaque(dec, s.Key[:])
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s HmacSha256Key) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("key")
+	enc.opaque(s.Key[:])
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *HmacSha256Key) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "key")
+	if err != nil {
+		return err
+	}
+	if err = decodeJSONFixedOpaque(fields["key"], s.Key[:]); err != nil {
+		return jsonFieldError("key", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s HmacSha256Key) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
@@ -17557,6 +40900,48 @@ type HmacSha256Mac struct {
 	Mac [32]byte `xdrmaxsize:"32"`
 }
 
+// EncodeTo encodes this value using the Encoder.
+func (s HmacSha256Mac) EncodeTo(enc *xdr.Encoder) error {
+	var err error
+	if _, err = enc.EncodeFixedOpaque(s.Mac[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeFrom decodes this value using the Decoder.
+func (s *HmacSha256Mac) DecodeFrom(dec *xdr.Decoder) (int, error) {
+	var err error
+	var n, nTmp int
+	nTmp, err = decodeFixedOpaque(dec, s.Mac[:])
+	n += nTmp
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeJSON writes the JSON representation of this value to enc.
+func (s HmacSha256Mac) encodeJSON(enc *jsonEncoder) error {
+	enc.beginObject()
+	enc.key("mac")
+	enc.opaque(s.Mac[:])
+	enc.endObject()
+	return nil
+}
+
+// decodeJSON decodes the JSON representation of this value.
+func (s *HmacSha256Mac) decodeJSON(data []byte) error {
+	fields, err := decodeJSONObject(data, "mac")
+	if err != nil {
+		return err
+	}
+	if err = decodeJSONFixedOpaque(fields["mac"], s.Mac[:]); err != nil {
+		return jsonFieldError("mac", err)
+	}
+	return nil
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (s HmacSha256Mac) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
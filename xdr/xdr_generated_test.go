@@ -0,0 +1,328 @@
+package xdr
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	xdr "github.com/stellar/go-xdr/xdr3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codecTypes are the types used to compare the generated EncodeTo and
+// DecodeFrom methods with the reflection based encoder. Together they cover
+// all the types in xdr_generated.go.
+var codecTypes = []interface{}{
+	AccountFlags(0),
+	AuthenticatedMessage{},
+	BucketEntry{},
+	BucketMetadata{},
+	ClaimableBalanceFlags(0),
+	Curve25519Secret{},
+	HmacSha256Key{},
+	LedgerCloseMeta{},
+	LedgerEntryChanges{},
+	LedgerEntry{},
+	LedgerHeaderHistoryEntry{},
+	LedgerKey{},
+	LedgerScpMessages{},
+	LedgerUpgrade{},
+	OfferEntryFlags(0),
+	OperationId{},
+	OperationResult{},
+	PeerStatList{},
+	ScpEnvelope{},
+	ScpHistoryEntry{},
+	ScpQuorumSet{},
+	StellarMessage{},
+	SurveyResponseBody{},
+	ThresholdIndexes(0),
+	TransactionEnvelope{},
+	TransactionHistoryEntry{},
+	TransactionHistoryResultEntry{},
+	TransactionMeta{},
+	TransactionResultPair{},
+	TransactionResult{},
+	TransactionSignaturePayload{},
+	TrustLineFlags(0),
+	UpgradeType{},
+}
+
+type xdrEnum interface {
+	ValidEnum(int32) bool
+}
+
+// randomValues generates random values of xdr types which can be encoded.
+type randomValues struct {
+	rng *rand.Rand
+	// small restricts numbers to [0, 256) and sets all opaque data to zeros.
+	// Any word of the encoded value, or of the value with a corrupted least
+	// significant byte, is then either small or too large to be the length
+	// of a slice, which keeps the reflection based decoder from allocating
+	// huge slices.
+	small bool
+}
+
+func (r randomValues) number(bits uint) uint64 {
+	if r.small {
+		return uint64(r.rng.Intn(256))
+	}
+	return r.rng.Uint64() >> (64 - bits)
+}
+
+const randomMaxDepth = 4
+
+func (r randomValues) fill(v reflect.Value, maxSize, depth int) {
+	if union, ok := v.Addr().Interface().(xdr.Union); ok {
+		r.fillUnion(v, union, depth)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Int32:
+		if enum, ok := v.Addr().Interface().(xdrEnum); ok {
+			values := validValues(enum.ValidEnum)
+			v.SetInt(int64(values[r.rng.Intn(len(values))]))
+			return
+		}
+		v.SetInt(int64(int32(r.number(32))))
+	case reflect.Int64:
+		v.SetInt(int64(r.number(64)))
+	case reflect.Uint8:
+		if !r.small {
+			v.SetUint(r.number(8))
+		}
+	case reflect.Uint32:
+		v.SetUint(r.number(32))
+	case reflect.Uint64:
+		v.SetUint(r.number(64))
+	case reflect.Bool:
+		v.SetBool(r.rng.Intn(2) == 0)
+	case reflect.String:
+		b := make([]byte, r.length(v, maxSize, depth))
+		if !r.small {
+			r.rng.Read(b)
+		}
+		v.SetString(string(b))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.fill(v.Index(i), 0, depth+1)
+		}
+	case reflect.Slice:
+		l := r.length(v, maxSize, depth)
+		if l == 0 {
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), l, l))
+		for i := 0; i < l; i++ {
+			r.fill(v.Index(i), 0, depth+1)
+		}
+	case reflect.Ptr:
+		if depth >= randomMaxDepth || r.rng.Intn(2) == 0 {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		r.fill(v.Elem(), 0, depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			r.fill(v.Field(i), tagMaxSize(v.Type().Field(i)), depth+1)
+		}
+	default:
+		panic(fmt.Sprintf("unsupported type %s", v.Type()))
+	}
+}
+
+func (r randomValues) fillUnion(v reflect.Value, union xdr.Union, depth int) {
+	discriminant := v.FieldByName(union.SwitchFieldName())
+	valid := func(sw int32) bool {
+		if enum, ok := discriminant.Addr().Interface().(xdrEnum); ok && !enum.ValidEnum(sw) {
+			return false
+		}
+		_, ok := union.ArmForSwitch(sw)
+		return ok
+	}
+	values := validValues(valid)
+	sw := values[r.rng.Intn(len(values))]
+	if discriminant.Kind() == reflect.Uint32 {
+		discriminant.SetUint(uint64(sw))
+	} else {
+		discriminant.SetInt(int64(sw))
+	}
+
+	arm, _ := union.ArmForSwitch(sw)
+	if arm == "" {
+		return
+	}
+	field, _ := v.Type().FieldByName(arm)
+	value := v.FieldByName(arm)
+	value.Set(reflect.New(value.Type().Elem()))
+	r.fill(value.Elem(), tagMaxSize(field), depth+1)
+}
+
+// length returns the length of a random slice or string.
+func (r randomValues) length(v reflect.Value, maxSize, depth int) int {
+	if sized, ok := v.Addr().Interface().(xdr.Sized); ok {
+		maxSize = sized.XDRMaxSize()
+	}
+	if maxSize == 0 || maxSize > 4 {
+		maxSize = 4
+	}
+	if depth >= randomMaxDepth {
+		return 0
+	}
+	return r.rng.Intn(maxSize + 1)
+}
+
+func tagMaxSize(field reflect.StructField) int {
+	size, _ := strconv.Atoi(field.Tag.Get("xdrmaxsize"))
+	return size
+}
+
+// validValues returns the values of enums and union discriminants for which
+// valid returns true.
+func validValues(valid func(int32) bool) []int32 {
+	var values []int32
+	for i := int32(-20); i <= 300; i++ {
+		if valid(i) {
+			values = append(values, i)
+		}
+	}
+	return values
+}
+
+// unmarshalReflection decodes data into a new value of typ using the
+// reflection based decoder.
+func unmarshalReflection(typ reflect.Type, data []byte) (interface{}, int, error) {
+	v := reflect.New(typ)
+	n, err := xdr.Unmarshal(bytes.NewReader(data), v.Interface())
+	return v.Interface(), n, err
+}
+
+// unmarshalGenerated decodes data into a new value of typ using the generated
+// DecodeFrom method.
+func unmarshalGenerated(typ reflect.Type, data []byte) (interface{}, int, error) {
+	v := reflect.New(typ)
+	n, err := Unmarshal(bytes.NewReader(data), v.Interface())
+	return v.Interface(), n, err
+}
+
+func TestGeneratedCodecMatchesReflection(t *testing.T) {
+	rng := rand.New(rand.NewSource(2021))
+	for _, value := range codecTypes {
+		typ := reflect.TypeOf(value)
+		t.Run(typ.Name(), func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				r := randomValues{rng: rng, small: i%2 == 1}
+				v := reflect.New(typ)
+				r.fill(v.Elem(), 0, 0)
+
+				var expected, actual bytes.Buffer
+				expectedN, err := xdr.Marshal(&expected, v.Interface())
+				require.NoError(t, err)
+				actualN, err := Marshal(&actual, v.Interface())
+				require.NoError(t, err)
+				require.Equal(t, expected.Bytes(), actual.Bytes())
+				require.Equal(t, expectedN, actualN)
+
+				data := expected.Bytes()
+				decoded, n, err := unmarshalGenerated(typ, data)
+				require.NoError(t, err)
+				require.Equal(t, len(data), n)
+				expectedDecoded, _, err := unmarshalReflection(typ, data)
+				require.NoError(t, err)
+				require.Equal(t, expectedDecoded, decoded)
+
+				if !r.small {
+					continue
+				}
+				// corrupt and truncate the data and check both decoders
+				// fail or succeed in the same way
+				for j := 0; j < 8 && len(data) > 0; j++ {
+					corrupted := append([]byte{}, data...)
+					corrupted[4*r.rng.Intn(len(corrupted)/4)+3] ^= byte(1 + r.rng.Intn(255))
+					corrupted = corrupted[:r.rng.Intn(len(corrupted)+1)]
+					assertSameDecoding(t, typ, corrupted)
+				}
+			}
+		})
+	}
+}
+
+func assertSameDecoding(t *testing.T, typ reflect.Type, data []byte) {
+	expected, expectedN, expectedErr := unmarshalReflection(typ, data)
+	actual, actualN, actualErr := unmarshalGenerated(typ, data)
+	if expectedErr != nil {
+		assert.EqualError(t, actualErr, expectedErr.Error(), "input %x", data)
+		return
+	}
+	if assert.NoError(t, actualErr, "input %x", data) {
+		assert.Equal(t, expectedN, actualN)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestGeneratedCodecErrors(t *testing.T) {
+	for _, value := range []interface{}{
+		MemoType(100),
+		Memo{Type: 100},
+		Memo{Type: MemoTypeMemoText},
+		(*Memo)(nil),
+	} {
+		_, expected := xdr.Marshal(&bytes.Buffer{}, value)
+		require.Error(t, expected)
+		_, err := Marshal(&bytes.Buffer{}, value)
+		assert.EqualError(t, err, expected.Error())
+	}
+
+	// an account with 21 signers
+	account := make([]byte, 72)
+	account = append(account, 0, 0, 0, 21)
+
+	for _, testCase := range []struct {
+		value interface{}
+		data  []byte
+	}{
+		{new(MemoType), []byte{0, 0, 0, 100}},
+		{new(AccountEntry), account},
+		{new(Memo), []byte{0, 0, 0, 100}},
+		{new(Memo), []byte{0, 0, 0, 1, 0, 0, 0, 29}},
+		{new(AuthenticatedMessage), []byte{0, 0, 0, 1}},
+		{(*Memo)(nil), []byte{0, 0, 0, 0}},
+		{Memo{}, []byte{0, 0, 0, 0}},
+	} {
+		_, expected := xdr.Unmarshal(bytes.NewReader(testCase.data), testCase.value)
+		require.Error(t, expected)
+		_, err := Unmarshal(bytes.NewReader(testCase.data), testCase.value)
+		assert.EqualError(t, err, expected.Error())
+	}
+}
+
+func benchmarkUnmarshal(b *testing.B, unmarshal func(typ reflect.Type, data []byte) (interface{}, int, error)) {
+	r := randomValues{rng: rand.New(rand.NewSource(2021))}
+	var meta LedgerCloseMeta
+	r.fill(reflect.ValueOf(&meta).Elem(), 0, 0)
+	data, err := meta.MarshalBinary()
+	require.NoError(b, err)
+	typ := reflect.TypeOf(meta)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := unmarshal(typ, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalReflection(b *testing.B) {
+	benchmarkUnmarshal(b, unmarshalReflection)
+}
+
+func BenchmarkUnmarshalGenerated(b *testing.B) {
+	benchmarkUnmarshal(b, unmarshalGenerated)
+}
@@ -0,0 +1,624 @@
+package xdr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarshalJSON returns the canonical JSON representation of the xdr value v.
+//
+// Structs are encoded as objects with the snake case names of their fields
+// as keys, and unions as objects with the discriminant and the value of the
+// arm. Enums are encoded as the snake case names of their values, without
+// the name of the enum (for example "memo_text"), 64 bit integers as strings,
+// opaque data as base64 strings and optional values which aren't set as
+// null. Accounts and signer keys are encoded as strkeys, and assets as
+// "native" or "CODE:ISSUER". Strings which aren't valid UTF-8 are encoded as
+// {"base64": "..."} objects.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	encodable, ok := v.(jsonEncodable)
+	if !ok || isNilPointer(v) {
+		return nil, fmt.Errorf("%T is not an xdr type", v)
+	}
+	var enc jsonEncoder
+	if err := encodable.encodeJSON(&enc); err != nil {
+		return nil, err
+	}
+	return enc.buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the canonical JSON representation of an xdr value
+// (see MarshalJSON) into v, which must be a pointer to an xdr type.
+func UnmarshalJSON(data []byte, v interface{}) error {
+	decodable, ok := v.(jsonDecodable)
+	if !ok || isNilPointer(v) {
+		return fmt.Errorf("%T is not a pointer to an xdr type", v)
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON")
+	}
+	return decodable.decodeJSON(data)
+}
+
+// NewValue returns a pointer to a new value of the xdr type with the given
+// name, for example "TransactionEnvelope".
+func NewValue(typeName string) (interface{}, error) {
+	typ, ok := xdrTypes[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown xdr type %s", typeName)
+	}
+	return reflect.New(typ).Interface(), nil
+}
+
+type jsonEncodable interface {
+	encodeJSON(enc *jsonEncoder) error
+}
+
+type jsonDecodable interface {
+	decodeJSON(data []byte) error
+}
+
+// jsonEncoder writes compact JSON, adding the commas between the members of
+// objects and the elements of arrays.
+type jsonEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *jsonEncoder) separate() {
+	if b := e.buf.Bytes(); len(b) > 0 && b[len(b)-1] != '{' && b[len(b)-1] != '[' {
+		e.buf.WriteByte(',')
+	}
+}
+
+func (e *jsonEncoder) beginObject() {
+	e.buf.WriteByte('{')
+}
+
+func (e *jsonEncoder) key(key string) {
+	e.separate()
+	e.quote(key)
+	e.buf.WriteByte(':')
+}
+
+func (e *jsonEncoder) endObject() {
+	e.buf.WriteByte('}')
+}
+
+func (e *jsonEncoder) beginArray() {
+	e.buf.WriteByte('[')
+}
+
+func (e *jsonEncoder) element() {
+	e.separate()
+}
+
+func (e *jsonEncoder) endArray() {
+	e.buf.WriteByte(']')
+}
+
+func (e *jsonEncoder) null() {
+	e.buf.WriteString("null")
+}
+
+func (e *jsonEncoder) int32(v int32) {
+	e.buf.WriteString(strconv.FormatInt(int64(v), 10))
+}
+
+func (e *jsonEncoder) uint32(v uint32) {
+	e.buf.WriteString(strconv.FormatUint(uint64(v), 10))
+}
+
+// int64 writes v as a string, as numbers larger than 2^53 can't be
+// represented by many JSON decoders.
+func (e *jsonEncoder) int64(v int64) {
+	e.quote(strconv.FormatInt(v, 10))
+}
+
+// uint64 writes v as a string, see int64.
+func (e *jsonEncoder) uint64(v uint64) {
+	e.quote(strconv.FormatUint(v, 10))
+}
+
+func (e *jsonEncoder) bool(v bool) {
+	e.buf.WriteString(strconv.FormatBool(v))
+}
+
+// string writes v as a JSON string, or as a {"base64": "..."} object if it
+// isn't valid UTF-8.
+func (e *jsonEncoder) string(v string) {
+	if !utf8.ValidString(v) {
+		e.beginObject()
+		e.key("base64")
+		e.opaque([]byte(v))
+		e.endObject()
+		return
+	}
+	e.quote(v)
+}
+
+func (e *jsonEncoder) opaque(v []byte) {
+	e.quote(base64.StdEncoding.EncodeToString(v))
+}
+
+// quote writes v, which is valid UTF-8, as a JSON string.
+func (e *jsonEncoder) quote(v string) {
+	enc := json.NewEncoder(&e.buf)
+	enc.SetEscapeHTML(false)
+	// encoding a string can't fail
+	enc.Encode(v)
+	// remove the newline added by Encode
+	e.buf.Truncate(e.buf.Len() - 1)
+}
+
+// jsonPathError is an error of the value at a path in a JSON document, for
+// example tx.operations[0].body.
+type jsonPathError struct {
+	path []string
+	err  error
+}
+
+func (e *jsonPathError) Error() string {
+	var b strings.Builder
+	for i, p := range e.path {
+		if i > 0 && !strings.HasPrefix(p, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(p)
+	}
+	return b.String() + ": " + e.err.Error()
+}
+
+func (e *jsonPathError) Unwrap() error {
+	return e.err
+}
+
+func prependJSONPath(p string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if pathErr, ok := err.(*jsonPathError); ok {
+		pathErr.path = append([]string{p}, pathErr.path...)
+		return pathErr
+	}
+	return &jsonPathError{path: []string{p}, err: err}
+}
+
+// jsonFieldError adds the key of a value in an object to the path of err, or
+// returns nil if err is nil.
+func jsonFieldError(key string, err error) error {
+	return prependJSONPath(key, err)
+}
+
+// jsonIndexError adds the index of a value in an array to the path of err.
+func jsonIndexError(i int, err error) error {
+	return prependJSONPath("["+strconv.Itoa(i)+"]", err)
+}
+
+func isJSONNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+// isJSONObject returns true if data is a JSON object. data is valid JSON.
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// decodeJSONValue decodes data, which mustn't be null, into v.
+func decodeJSONValue(data []byte, v interface{}) error {
+	if isJSONNull(data) {
+		return fmt.Errorf("unexpected null")
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeJSONFields decodes a JSON object.
+func decodeJSONFields(data []byte) (map[string]json.RawMessage, error) {
+	if !isJSONObject(data) {
+		return nil, fmt.Errorf("expected an object")
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// checkJSONKeys returns an error unless fields has exactly the given keys.
+func checkJSONKeys(fields map[string]json.RawMessage, keys ...string) error {
+	for _, key := range keys {
+		if _, ok := fields[key]; !ok {
+			return fmt.Errorf("missing key %s", key)
+		}
+	}
+	if len(fields) == len(keys) {
+		return nil
+	}
+	known := map[string]bool{}
+	for _, key := range keys {
+		known[key] = true
+	}
+	var unknown []string
+	for key := range fields {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown keys %s", strings.Join(unknown, ", "))
+}
+
+// decodeJSONObject decodes a JSON object which has exactly the given keys.
+func decodeJSONObject(data []byte, keys ...string) (map[string]json.RawMessage, error) {
+	fields, err := decodeJSONFields(data)
+	if err != nil {
+		return nil, err
+	}
+	return fields, checkJSONKeys(fields, keys...)
+}
+
+// decodeJSONUnion decodes the JSON object of a union. The keys are checked
+// once the discriminant is decoded.
+func decodeJSONUnion(data []byte, discriminant string) (map[string]json.RawMessage, error) {
+	fields, err := decodeJSONFields(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields[discriminant]; !ok {
+		return nil, fmt.Errorf("missing key %s", discriminant)
+	}
+	return fields, nil
+}
+
+// decodeJSONArray decodes a JSON array of at most maxSize elements, or any
+// number of elements if maxSize is 0.
+func decodeJSONArray(data []byte, maxSize int) ([][]byte, error) {
+	var elems []json.RawMessage
+	if err := decodeJSONValue(data, &elems); err != nil {
+		return nil, err
+	}
+	if maxSize > 0 && len(elems) > maxSize {
+		return nil, fmt.Errorf("array has %d elements, more than the maximum of %d", len(elems), maxSize)
+	}
+	result := make([][]byte, len(elems))
+	for i, elem := range elems {
+		result[i] = elem
+	}
+	return result, nil
+}
+
+// decodeJSONFixedArray decodes a JSON array of n elements.
+func decodeJSONFixedArray(data []byte, n int) ([][]byte, error) {
+	elems, err := decodeJSONArray(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) != n {
+		return nil, fmt.Errorf("array has %d elements instead of %d", len(elems), n)
+	}
+	return elems, nil
+}
+
+func decodeJSONInt32(data []byte) (int32, error) {
+	var v int32
+	err := decodeJSONValue(data, &v)
+	return v, err
+}
+
+func decodeJSONUint32(data []byte) (uint32, error) {
+	var v uint32
+	err := decodeJSONValue(data, &v)
+	return v, err
+}
+
+func decodeJSONInt64(data []byte) (int64, error) {
+	var s string
+	if err := decodeJSONValue(data, &s); err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func decodeJSONUint64(data []byte) (uint64, error) {
+	var s string
+	if err := decodeJSONValue(data, &s); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func decodeJSONBool(data []byte) (bool, error) {
+	var v bool
+	err := decodeJSONValue(data, &v)
+	return v, err
+}
+
+// decodeJSONString decodes a string of at most maxSize bytes, or any length
+// if maxSize is 0, from a JSON string or a {"base64": "..."} object.
+func decodeJSONString(data []byte, maxSize int) (string, error) {
+	var v string
+	if isJSONObject(data) {
+		fields, err := decodeJSONObject(data, "base64")
+		if err != nil {
+			return "", err
+		}
+		b, err := decodeJSONOpaque(fields["base64"], maxSize)
+		if err != nil {
+			return "", jsonFieldError("base64", err)
+		}
+		v = string(b)
+	} else if err := decodeJSONValue(data, &v); err != nil {
+		return "", err
+	}
+	if maxSize > 0 && len(v) > maxSize {
+		return "", fmt.Errorf("string has %d bytes, more than the maximum of %d", len(v), maxSize)
+	}
+	return v, nil
+}
+
+// decodeJSONOpaque decodes base64 encoded data of at most maxSize bytes, or
+// any length if maxSize is 0.
+func decodeJSONOpaque(data []byte, maxSize int) ([]byte, error) {
+	var s string
+	if err := decodeJSONValue(data, &s); err != nil {
+		return nil, err
+	}
+	v, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize > 0 && len(v) > maxSize {
+		return nil, fmt.Errorf("opaque data has %d bytes, more than the maximum of %d", len(v), maxSize)
+	}
+	if len(v) == 0 {
+		// like the xdr decoder, decode empty data as nil
+		return nil, nil
+	}
+	return v, nil
+}
+
+// decodeJSONFixedOpaque decodes base64 encoded data of len(dst) bytes into
+// dst.
+func decodeJSONFixedOpaque(data []byte, dst []byte) error {
+	v, err := decodeJSONOpaque(data, 0)
+	if err != nil {
+		return err
+	}
+	if len(v) != len(dst) {
+		return fmt.Errorf("opaque data has %d bytes instead of %d", len(v), len(dst))
+	}
+	copy(dst, v)
+	return nil
+}
+
+// decodeJSONEnum decodes the JSON name of an enum value.
+func decodeJSONEnum(data []byte, names map[int32]string) (int32, error) {
+	var name string
+	if err := decodeJSONValue(data, &name); err != nil {
+		return 0, err
+	}
+	for v, n := range names {
+		if n == name {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown value %q", name)
+}
+
+// The accounts, signer keys and assets are encoded as strings instead of
+// generated objects.
+
+func (k PublicKey) encodeJSON(enc *jsonEncoder) error {
+	aid := AccountId(k)
+	address, err := aid.GetAddress()
+	if err != nil {
+		return err
+	}
+	enc.quote(address)
+	return nil
+}
+
+func (k *PublicKey) decodeJSON(data []byte) error {
+	var address string
+	if err := decodeJSONValue(data, &address); err != nil {
+		return err
+	}
+	return (*AccountId)(k).SetAddress(address)
+}
+
+func (m MuxedAccount) encodeJSON(enc *jsonEncoder) error {
+	address, err := m.GetAddress()
+	if err != nil {
+		return err
+	}
+	enc.quote(address)
+	return nil
+}
+
+func (m *MuxedAccount) decodeJSON(data []byte) error {
+	var address string
+	if err := decodeJSONValue(data, &address); err != nil {
+		return err
+	}
+	return m.SetAddress(address)
+}
+
+func (skey SignerKey) encodeJSON(enc *jsonEncoder) error {
+	address, err := skey.GetAddress()
+	if err != nil {
+		return err
+	}
+	enc.quote(address)
+	return nil
+}
+
+func (skey *SignerKey) decodeJSON(data []byte) error {
+	var address string
+	if err := decodeJSONValue(data, &address); err != nil {
+		return err
+	}
+	return skey.SetAddress(address)
+}
+
+// encodeJSONAssetCode writes an asset code as a string without the trailing
+// zeros, or as a {"base64": "..."} object if the string couldn't be decoded
+// to the same code.
+func encodeJSONAssetCode(enc *jsonEncoder, code []byte) {
+	trimmed := bytes.TrimRight(code, "\x00")
+	if bytes.IndexByte(trimmed, 0) >= 0 || !utf8.Valid(trimmed) {
+		enc.beginObject()
+		enc.key("base64")
+		enc.opaque(code)
+		enc.endObject()
+		return
+	}
+	enc.quote(string(trimmed))
+}
+
+// decodeJSONAssetCode decodes an asset code into dst, padding it with zeros.
+func decodeJSONAssetCode(data []byte, dst []byte) error {
+	if isJSONObject(data) {
+		fields, err := decodeJSONObject(data, "base64")
+		if err != nil {
+			return err
+		}
+		return jsonFieldError("base64", decodeJSONFixedOpaque(fields["base64"], dst))
+	}
+	var code string
+	if err := decodeJSONValue(data, &code); err != nil {
+		return err
+	}
+	if len(code) > len(dst) {
+		return fmt.Errorf("asset code %q is longer than %d bytes", code, len(dst))
+	}
+	copy(dst, make([]byte, len(dst)))
+	copy(dst, code)
+	return nil
+}
+
+func (c AssetCode4) encodeJSON(enc *jsonEncoder) error {
+	encodeJSONAssetCode(enc, c[:])
+	return nil
+}
+
+func (c *AssetCode4) decodeJSON(data []byte) error {
+	return decodeJSONAssetCode(data, c[:])
+}
+
+func (c AssetCode12) encodeJSON(enc *jsonEncoder) error {
+	encodeJSONAssetCode(enc, c[:])
+	return nil
+}
+
+func (c *AssetCode12) decodeJSON(data []byte) error {
+	return decodeJSONAssetCode(data, c[:])
+}
+
+// encodeJSON writes the asset as "native" or "CODE:ISSUER". Assets with
+// invalid codes, which can be found in failed transactions, are written as
+// objects like other unions.
+func (a Asset) encodeJSON(enc *jsonEncoder) error {
+	var key string
+	var code []byte
+	var issuer AccountId
+	switch a.Type {
+	case AssetTypeAssetTypeNative:
+		enc.quote("native")
+		return nil
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if a.AlphaNum4 == nil {
+			return jsonFieldError("alpha_num4", errEncodeNilUnionValue())
+		}
+		key, code, issuer = "alpha_num4", a.AlphaNum4.AssetCode[:], a.AlphaNum4.Issuer
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if a.AlphaNum12 == nil {
+			return jsonFieldError("alpha_num12", errEncodeNilUnionValue())
+		}
+		key, code, issuer = "alpha_num12", a.AlphaNum12.AssetCode[:], a.AlphaNum12.Issuer
+	default:
+		return jsonFieldError("type", errEncodeInvalidEnum(a.Type))
+	}
+
+	// the code is written as a string if decoding the string results in the
+	// same asset
+	trimmed := string(bytes.TrimRight(code, "\x00"))
+	canonical := Asset{}
+	if ValidAssetCode.MatchString(trimmed) && canonical.SetCredit(trimmed, issuer) == nil && canonical.Type == a.Type {
+		address, err := issuer.GetAddress()
+		if err != nil {
+			return jsonFieldError(key, jsonFieldError("issuer", err))
+		}
+		enc.quote(trimmed + ":" + address)
+		return nil
+	}
+
+	enc.beginObject()
+	enc.key("type")
+	if err := a.Type.encodeJSON(enc); err != nil {
+		return jsonFieldError("type", err)
+	}
+	enc.key(key)
+	var err error
+	if a.Type == AssetTypeAssetTypeCreditAlphanum4 {
+		err = a.AlphaNum4.encodeJSON(enc)
+	} else {
+		err = a.AlphaNum12.encodeJSON(enc)
+	}
+	if err != nil {
+		return jsonFieldError(key, err)
+	}
+	enc.endObject()
+	return nil
+}
+
+func (a *Asset) decodeJSON(data []byte) error {
+	*a = Asset{}
+	if !isJSONObject(data) {
+		var s string
+		if err := decodeJSONValue(data, &s); err != nil {
+			return err
+		}
+		if s == "native" {
+			return a.SetNative()
+		}
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid asset %q", s)
+		}
+		issuer, err := AddressToAccountId(parts[1])
+		if err != nil {
+			return err
+		}
+		return a.SetCredit(parts[0], issuer)
+	}
+
+	fields, err := decodeJSONUnion(data, "type")
+	if err != nil {
+		return err
+	}
+	if err = a.Type.decodeJSON(fields["type"]); err != nil {
+		return jsonFieldError("type", err)
+	}
+	switch a.Type {
+	case AssetTypeAssetTypeCreditAlphanum4:
+		if err = checkJSONKeys(fields, "type", "alpha_num4"); err != nil {
+			return err
+		}
+		a.AlphaNum4 = new(AssetAlphaNum4)
+		return jsonFieldError("alpha_num4", a.AlphaNum4.decodeJSON(fields["alpha_num4"]))
+	case AssetTypeAssetTypeCreditAlphanum12:
+		if err = checkJSONKeys(fields, "type", "alpha_num12"); err != nil {
+			return err
+		}
+		a.AlphaNum12 = new(AssetAlphaNum12)
+		return jsonFieldError("alpha_num12", a.AlphaNum12.decodeJSON(fields["alpha_num12"]))
+	}
+	return fmt.Errorf("native assets must be encoded as \"native\"")
+}
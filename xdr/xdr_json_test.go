@@ -0,0 +1,239 @@
+package xdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(2021))
+	for _, value := range codecTypes {
+		typ := reflect.TypeOf(value)
+		t.Run(typ.Name(), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				r := randomValues{rng: rng, small: i%2 == 1}
+				v := reflect.New(typ)
+				r.fill(v.Elem(), 0, 0)
+				var expected bytes.Buffer
+				_, err := Marshal(&expected, v.Interface())
+				require.NoError(t, err)
+
+				encoded, err := MarshalJSON(v.Interface())
+				require.NoError(t, err)
+				require.True(t, json.Valid(encoded), "invalid JSON %s", encoded)
+
+				decoded := reflect.New(typ)
+				require.NoError(t, UnmarshalJSON(encoded, decoded.Interface()), "JSON %s", encoded)
+				var actual bytes.Buffer
+				_, err = Marshal(&actual, decoded.Interface())
+				require.NoError(t, err)
+				require.Equal(t, expected.Bytes(), actual.Bytes(), "JSON %s", encoded)
+
+				reencoded, err := MarshalJSON(decoded.Interface())
+				require.NoError(t, err)
+				require.Equal(t, string(encoded), string(reencoded))
+			}
+		})
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	text := "hello <world>"
+	sequence := Int64(123456789012)
+	envelope := TransactionEnvelope{
+		Type: EnvelopeTypeEnvelopeTypeTx,
+		V1: &TransactionV1Envelope{
+			Tx: Transaction{
+				SourceAccount: MustMuxedAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+				Fee:           100,
+				SeqNum:        SequenceNumber(sequence),
+				Memo:          Memo{Type: MemoTypeMemoText, Text: &text},
+				Operations: []Operation{
+					{
+						Body: OperationBody{
+							Type: OperationTypePayment,
+							PaymentOp: &PaymentOp{
+								Destination: MustMuxedAddress("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+								Asset:       MustNewCreditAsset("USD", "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+								Amount:      10000000,
+							},
+						},
+					},
+					{
+						SourceAccount: MustMuxedAddressPtr("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+						Body: OperationBody{
+							Type: OperationTypeSetOptions,
+							SetOptionsOp: &SetOptionsOp{
+								Signer: &Signer{
+									Key:    MustSigner("GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"),
+									Weight: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+			Signatures: []DecoratedSignature{
+				{Hint: SignatureHint{1, 2, 3, 4}, Signature: Signature{5, 6}},
+			},
+		},
+	}
+
+	encoded, err := MarshalJSON(envelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "envelope_type_tx",
+		"v1": {
+			"tx": {
+				"source_account": "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+				"fee": 100,
+				"seq_num": "123456789012",
+				"time_bounds": null,
+				"memo": {"type": "memo_text", "text": "hello <world>"},
+				"operations": [
+					{
+						"source_account": null,
+						"body": {
+							"type": "payment",
+							"payment_op": {
+								"destination": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+								"asset": "USD:GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+								"amount": "10000000"
+							}
+						}
+					},
+					{
+						"source_account": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+						"body": {
+							"type": "set_options",
+							"set_options_op": {
+								"inflation_dest": null,
+								"clear_flags": null,
+								"set_flags": null,
+								"master_weight": null,
+								"low_threshold": null,
+								"med_threshold": null,
+								"high_threshold": null,
+								"home_domain": null,
+								"signer": {
+									"key": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+									"weight": 1
+								}
+							}
+						}
+					}
+				],
+				"ext": {"v": 0}
+			},
+			"signatures": [{"hint": "AQIDBA==", "signature": "BQY="}]
+		}
+	}`, string(encoded))
+	assert.Contains(t, string(encoded), "hello <world>")
+
+	var decoded TransactionEnvelope
+	require.NoError(t, UnmarshalJSON(encoded, &decoded))
+	assert.Equal(t, envelope, decoded)
+}
+
+func TestJSONAssets(t *testing.T) {
+	issuer := "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	invalidCode := MustNewCreditAsset("AB", issuer)
+	invalidCode.AlphaNum4.AssetCode[3] = 'C'
+	shortCode := Asset{
+		Type:       AssetTypeAssetTypeCreditAlphanum12,
+		AlphaNum12: &AssetAlphaNum12{AssetCode: AssetCode12{'A', 'B', 'C'}, Issuer: MustAddress(issuer)},
+	}
+
+	for _, testCase := range []struct {
+		asset    Asset
+		expected string
+	}{
+		{MustNewNativeAsset(), `"native"`},
+		{MustNewCreditAsset("USD", issuer), `"USD:` + issuer + `"`},
+		{MustNewCreditAsset("USDUSDUSD", issuer), `"USDUSDUSD:` + issuer + `"`},
+		{invalidCode, `{"type":"asset_type_credit_alphanum4","alpha_num4":{"asset_code":{"base64":"QUIAQw=="},"issuer":"` + issuer + `"}}`},
+		{shortCode, `{"type":"asset_type_credit_alphanum12","alpha_num12":{"asset_code":"ABC","issuer":"` + issuer + `"}}`},
+	} {
+		encoded, err := MarshalJSON(testCase.asset)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, string(encoded))
+
+		var decoded Asset
+		require.NoError(t, UnmarshalJSON(encoded, &decoded))
+		assert.Equal(t, testCase.asset, decoded)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	_, err := MarshalJSON(Memo{Type: MemoTypeMemoText})
+	assert.EqualError(t, err, "text: xdr:encodeUnion: can't encode nil union value")
+	_, err = MarshalJSON(3)
+	assert.EqualError(t, err, "int is not an xdr type")
+	_, err = MarshalJSON((*Memo)(nil))
+	assert.EqualError(t, err, "*xdr.Memo is not an xdr type")
+
+	for _, testCase := range []struct {
+		data     string
+		expected string
+	}{
+		{`{"type": "memo_text"`, "invalid JSON"},
+		{`[]`, "expected an object"},
+		{`{"type": "memo_foo"}`, `type: unknown value "memo_foo"`},
+		{`{"type": "memo_text"}`, "missing key text"},
+		{`{"type": "memo_none", "text": "a"}`, "unknown keys text"},
+		{`{"type": "memo_text", "text": null}`, "text: unexpected null"},
+		{`{"type": "memo_text", "text": "` + string(make([]byte, 29)) + `"}`, "invalid JSON"},
+		{`{"type": "memo_text", "text": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}`, "text: string has 29 bytes, more than the maximum of 28"},
+		{`{"type": "memo_id", "id": 5}`, "id: json: cannot unmarshal number into Go value of type string"},
+		{`{"type": "memo_hash", "hash": "AQID"}`, "hash: opaque data has 3 bytes instead of 32"},
+	} {
+		var memo Memo
+		assert.EqualError(t, UnmarshalJSON([]byte(testCase.data), &memo), testCase.expected, testCase.data)
+	}
+
+	var envelope TransactionEnvelope
+	err = UnmarshalJSON([]byte(`{
+		"type": "envelope_type_tx",
+		"v1": {
+			"tx": {
+				"source_account": "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ",
+				"fee": 100,
+				"seq_num": "1",
+				"time_bounds": null,
+				"memo": {"type": "memo_none"},
+				"operations": [
+					{"source_account": null, "body": {"type": "inflation"}},
+					{"source_account": null, "body": {"type": "bump_sequence", "bump_sequence_op": {"bump_to": 1}}}
+				],
+				"ext": {"v": 0}
+			},
+			"signatures": []
+		}
+	}`), &envelope)
+	assert.EqualError(t, err, "v1.tx.operations[1].body.bump_sequence_op.bump_to: json: cannot unmarshal number into Go value of type string")
+
+	var value interface{}
+	assert.EqualError(t, UnmarshalJSON([]byte(`{}`), value), "<nil> is not a pointer to an xdr type")
+	assert.EqualError(t, UnmarshalJSON([]byte(`{}`), Memo{}), "xdr.Memo is not a pointer to an xdr type")
+}
+
+func TestNewValue(t *testing.T) {
+	v, err := NewValue("TransactionEnvelope")
+	require.NoError(t, err)
+	assert.IsType(t, &TransactionEnvelope{}, v)
+
+	v, err = NewValue("Hash")
+	require.NoError(t, err)
+	assert.IsType(t, &Hash{}, v)
+
+	_, err = NewValue("Foo")
+	assert.EqualError(t, err, "unknown xdr type Foo")
+	_, err = NewValue("SponsorshipDescriptor")
+	assert.EqualError(t, err, "unknown xdr type SponsorshipDescriptor")
+}